@@ -1,14 +1,23 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/aaronsb/slack-mcp/pkg/cache"
+	"github.com/aaronsb/slack-mcp/pkg/doctor"
+	"github.com/aaronsb/slack-mcp/pkg/features"
+	"github.com/aaronsb/slack-mcp/pkg/paths"
 	"github.com/aaronsb/slack-mcp/pkg/provider"
 	"github.com/aaronsb/slack-mcp/pkg/server"
 	"github.com/aaronsb/slack-mcp/pkg/setup"
@@ -19,6 +28,9 @@ var defaultSseHost = "127.0.0.1"
 var defaultSsePort = 13080
 
 func main() {
+	// Earlier versions used XDG-style paths on every platform
+	paths.MigrateLegacyDirs()
+
 	// Check for subcommands before flag parsing
 	if len(os.Args) > 1 && os.Args[1] == "setup" {
 		if err := setup.RunSetup(); err != nil {
@@ -26,6 +38,21 @@ func main() {
 		}
 		return
 	}
+	if len(os.Args) > 1 && os.Args[1] == "purge" {
+		os.Exit(runPurge(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "doctor" {
+		os.Exit(runDoctor(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "auth" {
+		os.Exit(runAuth(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "identity" {
+		os.Exit(runIdentity(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "refresh-credentials" {
+		os.Exit(runRefreshCredentials(os.Args[2:]))
+	}
 
 	var transport string
 	flag.StringVar(&transport, "t", "stdio", "Transport type (stdio or sse)")
@@ -34,7 +61,9 @@ func main() {
 
 	// For stdio transport, redirect logs to a file to avoid interfering with protocol
 	if transport == "stdio" {
-		logFile, err := os.OpenFile("/tmp/slack-mcp.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+		logPath := paths.LogPath()
+		os.MkdirAll(filepath.Dir(logPath), 0o700)
+		logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
 		if err == nil {
 			log.SetOutput(logFile)
 			defer logFile.Close()
@@ -48,10 +77,14 @@ func main() {
 		log.Println("No .env file found, using environment variables")
 	}
 
+	// Queued messages get the same quiet-hours and DM pacing checks on retry
+	provider.OutboxHold = features.HoldQueuedMessage
+
 	// Build provider: try config file, then env vars, then start without auth
 	p, authErr := loadProvider()
+	identities := loadIdentities()
 
-	s := server.NewSemanticMCPServer(p)
+	s := server.NewSemanticMCPServer(p, identities)
 
 	if authErr != nil {
 		// Register the server but log that auth is needed
@@ -67,7 +100,7 @@ func main() {
 			_, err := p.Provide()
 			if err != nil {
 				log.Printf("Warning: Provider boot failed: %v", err)
-				log.Println("Some features may be limited until cache is loaded")
+				log.Println("Boot will be retried on the next tool call")
 			} else {
 				log.Println("Provider booted successfully in background")
 			}
@@ -89,9 +122,44 @@ func main() {
 			port = strconv.Itoa(defaultSsePort)
 		}
 
-		sseServer := s.ServeSSE(":" + port)
-		log.Printf("SSE server listening on %s:%s", host, port)
-		if err := sseServer.Start(host + ":" + port); err != nil {
+		tlsSettings := server.TLSSettingsFromEnv()
+		baseURL := "http://:" + port
+		if tlsSettings.Enabled() {
+			baseURL = fmt.Sprintf("https://%s:%s", tlsSettings.Host(), port)
+		}
+		httpServer := &http.Server{
+			Addr:              host + ":" + port,
+			Handler:           s.ServeSSE(baseURL),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		if !tlsSettings.Enabled() {
+			log.Printf("SSE server listening on %s:%s", host, port)
+			if err := httpServer.ListenAndServe(); err != nil {
+				log.Fatalf("Server error: %v", err)
+			}
+			return
+		}
+
+		tlsConfig, challenges, err := tlsSettings.Config()
+		if err != nil {
+			log.Fatalf("TLS setup failed: %v", err)
+		}
+		httpServer.TLSConfig = tlsConfig
+		if challenges != nil {
+			// Optional: TLS-ALPN-01 on the main listener works without it
+			go func() {
+				challengeServer := &http.Server{
+					Addr:              host + ":80",
+					Handler:           challenges,
+					ReadHeaderTimeout: 10 * time.Second,
+				}
+				if err := challengeServer.ListenAndServe(); err != nil {
+					log.Printf("Not answering ACME HTTP-01 challenges on port 80: %v", err)
+				}
+			}()
+		}
+		log.Printf("SSE server listening on %s", baseURL)
+		if err := httpServer.ListenAndServeTLS("", ""); err != nil {
 			log.Fatalf("Server error: %v", err)
 		}
 	default:
@@ -99,6 +167,310 @@ func main() {
 	}
 }
 
+// runPurge deletes local caches, logs, and state, and prints what went
+func runPurge(args []string) int {
+	fs := flag.NewFlagSet("purge", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "List what would be deleted without deleting it")
+	credentials := fs.Bool("credentials", false, "Also delete stored Slack credentials")
+	fs.Parse(args)
+
+	report := cache.PurgeLocalData(nil, *credentials, *dryRun)
+	verb := "Removed"
+	if report.DryRun {
+		verb = "Would remove"
+	}
+	for _, f := range report.Files {
+		fmt.Printf("%s %s (%d bytes)\n", verb, f.Path, f.Size)
+	}
+	fmt.Printf("%s %d file(s), %d bytes\n", verb, len(report.Files), report.Bytes)
+	if !*credentials {
+		fmt.Printf("Credentials in %s were kept; add --credentials to delete them\n", paths.ConfigDir())
+	}
+	for _, f := range report.Failed {
+		fmt.Fprintf(os.Stderr, "Failed: %s\n", f)
+	}
+	if len(report.Failed) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// runDoctor checks connectivity, credentials, and cache health, prints the
+// results, and writes a sanitized bundle for bug reports
+func runDoctor(args []string) int {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	offline := fs.Bool("offline", false, "Skip the Slack API checks")
+	outDir := fs.String("out", paths.DownloadsDir(), "Directory for the diagnostics bundle")
+	noBundle := fs.Bool("no-bundle", false, "Print the report without writing a bundle")
+	fs.Parse(args)
+
+	// Keep provider logging out of the report; it goes to the log file,
+	// whose tail is included in the bundle
+	logPath := paths.LogPath()
+	os.MkdirAll(filepath.Dir(logPath), 0o700)
+	if logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600); err == nil {
+		log.SetOutput(logFile)
+		defer logFile.Close()
+	} else {
+		log.SetOutput(io.Discard)
+	}
+	godotenv.Load()
+
+	p, err := loadProvider()
+	if err != nil {
+		p = nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	report := doctor.Run(ctx, p, *offline)
+	fmt.Print(report.Text())
+
+	if !*noBundle {
+		path, err := report.WriteBundle(*outDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "\nCould not write diagnostics bundle: %v\n", err)
+			return 1
+		}
+		fmt.Printf("\nDiagnostics bundle: %s\nReview it before attaching it to a bug report; tokens, emails, and phone numbers are removed, names are not.\n", path)
+	}
+	if report.Failed() {
+		return 1
+	}
+	return 0
+}
+
+// runAuth handles `slack-mcp auth login`, which installs a Slack app via
+// OAuth and stores its token for the official-API mode
+func runAuth(args []string) int {
+	if len(args) == 0 || args[0] != "login" {
+		fmt.Fprintln(os.Stderr, "Usage: slack-mcp auth login [--bot] [--client-id ID] [--client-secret SECRET] [--scopes a,b] [--redirect-url URL]")
+		return 2
+	}
+	godotenv.Load()
+
+	opts := setup.OAuthOptionsFromEnv()
+	fs := flag.NewFlagSet("auth login", flag.ExitOnError)
+	fs.StringVar(&opts.ClientID, "client-id", opts.ClientID, "The Slack app's client ID")
+	fs.StringVar(&opts.ClientSecret, "client-secret", opts.ClientSecret, "The Slack app's client secret")
+	fs.BoolVar(&opts.Bot, "bot", false, "Get a bot token instead of a user token")
+	scopes := fs.String("scopes", "", "Comma-separated scopes to request instead of the defaults")
+	fs.StringVar(&opts.RedirectURL, "redirect-url", opts.RedirectURL, "Redirect URL registered with the app, if not http://localhost:<port>/oauth/callback")
+	fs.Parse(args[1:])
+	for _, scope := range strings.Split(*scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			opts.Scopes = append(opts.Scopes, scope)
+		}
+	}
+
+	if err := setup.RunOAuthLogin(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "  ✗ Login failed: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runRefreshCredentials re-reads an expired xoxd cookie from the local
+// browser profiles
+func runRefreshCredentials(args []string) int {
+	fs := flag.NewFlagSet("refresh-credentials", flag.ExitOnError)
+	force := fs.Bool("force", false, "Re-read the cookie even if the current one still works")
+	workspace := fs.String("workspace", "", "Workspace from the config file (default: the default workspace)")
+	fs.Parse(args)
+
+	res, err := setup.RefreshCredentials(*workspace, *force)
+	if res != nil {
+		for _, t := range res.Tried {
+			fmt.Printf("  - %s\n", t)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "  ✗ %v\n", err)
+		return 1
+	}
+	if !res.Refreshed {
+		fmt.Printf("  ✓ Credentials for %q still work (%s); use --force to re-read anyway\n", res.Workspace, res.User)
+		return 0
+	}
+	fmt.Printf("  ✓ Refreshed credentials for %q from %s\n", res.Workspace, res.Source)
+	fmt.Printf("  ✓ Saved to %s; restart the MCP host, or call its refresh-credentials tool, to pick them up\n", setup.ConfigPath())
+	return 0
+}
+
+// keepTokenFresh refreshes a rotating app token shortly before it expires
+// and hands each new token to the provider current returns, which may have
+// been swapped since. refresh is setup.RefreshWorkspaceToken or
+// setup.RefreshIdentityToken.
+func keepTokenFresh(current func() *provider.ApiProvider, name string, ws setup.WorkspaceConfig, refresh func(context.Context, string) (setup.WorkspaceConfig, error)) {
+	for {
+		refreshAt := ws.RefreshAt()
+		p := current()
+		if refreshAt.IsZero() || p == nil {
+			return
+		}
+		// A closed provider was replaced; refresh for its successor, if any
+		select {
+		case <-p.Done():
+			if current() == p {
+				return
+			}
+			continue
+		case <-time.After(time.Until(refreshAt)):
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		next, err := refresh(ctx, name)
+		cancel()
+		if err != nil {
+			log.Printf("Token refresh for %q failed: %v; retrying in a minute", name, err)
+			time.Sleep(time.Minute)
+			continue
+		}
+		p = current()
+		if p == nil {
+			return
+		}
+		if err := p.RotateToken(next.Token); err != nil {
+			log.Printf("Could not switch to the refreshed token: %v", err)
+			return
+		}
+		ws = next
+	}
+}
+
+// loadIdentities builds a provider for each named identity in the config
+// and boots them in the background. Returns nil when none are configured.
+func loadIdentities() *provider.WorkspaceManager {
+	cfg, err := setup.LoadConfig()
+	if err != nil || len(cfg.Identities) == 0 {
+		return nil
+	}
+	wm := provider.NewWorkspaceManager()
+	for name, ws := range cfg.Identities {
+		if ws.Token != "" && ws.NeedsRefresh(time.Now()) {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			if fresh, err := setup.RefreshIdentityToken(ctx, name); err != nil {
+				log.Printf("Token refresh for identity %q failed: %v", name, err)
+			} else {
+				ws = fresh
+			}
+			cancel()
+		}
+		token, cookie := ws.Credentials()
+		p, err := provider.NewIdentity(name, token, cookie)
+		if err != nil {
+			log.Printf("Skipping identity: %v", err)
+			continue
+		}
+		wm.AddProvider(name, p)
+		if ws.Token != "" {
+			current := func() *provider.ApiProvider {
+				p, _ := wm.GetProvider(name)
+				return p
+			}
+			go keepTokenFresh(current, name, ws, setup.RefreshIdentityToken)
+		}
+		go func() {
+			if _, err := p.Provide(); err != nil {
+				log.Printf("Warning: identity %q failed to boot: %v", name, err)
+			}
+		}()
+	}
+	log.Printf("Loaded %d identities; tool calls must name one with actingAs", wm.WorkspaceCount())
+	return wm
+}
+
+// runIdentity manages named identities for a shared deployment:
+//
+//	slack-mcp identity add NAME [--workspace W]   move a workspace's credentials to an identity
+//	slack-mcp identity remove NAME
+//	slack-mcp identity list
+func runIdentity(args []string) int {
+	usage := "Usage: slack-mcp identity add NAME [--workspace W] | remove NAME | list"
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	cfg, err := setup.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "  ✗ %v\n", err)
+		return 1
+	}
+
+	switch args[0] {
+	case "list":
+		names := make([]string, 0, len(cfg.Identities))
+		for name := range cfg.Identities {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			id := cfg.Identities[name]
+			fmt.Printf("%s\t%s on %s\n", name, id.UserName, id.TeamName)
+		}
+		if len(names) == 0 {
+			fmt.Println("No identities; the server acts as the default workspace")
+		}
+		return 0
+
+	case "add":
+		fs := flag.NewFlagSet("identity add", flag.ExitOnError)
+		workspace := fs.String("workspace", "", "Workspace whose credentials to use (default: the default workspace)")
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, usage)
+			return 2
+		}
+		name := args[1]
+		fs.Parse(args[2:])
+		if !provider.ValidIdentityName(name) {
+			fmt.Fprintf(os.Stderr, "  ✗ Invalid identity name %q: use letters, digits, '-' and '_'\n", name)
+			return 1
+		}
+		if *workspace == "" {
+			*workspace = cfg.ActiveWorkspace()
+		}
+		ws, ok := cfg.Workspaces[*workspace]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "  ✗ No workspace %q; run `slack-mcp setup` as the person first\n", *workspace)
+			return 1
+		}
+		if cfg.Identities == nil {
+			cfg.Identities = make(map[string]setup.WorkspaceConfig)
+		}
+		cfg.Identities[name] = ws
+		// Moved, not copied, so the next person's setup can't be mistaken
+		// for this one's
+		delete(cfg.Workspaces, *workspace)
+		if cfg.DefaultWorkspace == *workspace {
+			cfg.DefaultWorkspace = ""
+		}
+		if err := setup.SaveConfig(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "  ✗ %v\n", err)
+			return 1
+		}
+		fmt.Printf("  ✓ Identity %q acts as %s on %s\n", name, ws.UserName, ws.TeamName)
+		return 0
+
+	case "remove":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, usage)
+			return 2
+		}
+		if _, ok := cfg.Identities[args[1]]; !ok {
+			fmt.Fprintf(os.Stderr, "  ✗ No identity %q\n", args[1])
+			return 1
+		}
+		delete(cfg.Identities, args[1])
+		if err := setup.SaveConfig(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "  ✗ %v\n", err)
+			return 1
+		}
+		fmt.Printf("  ✓ Removed identity %q; its caches stay in %s until `slack-mcp purge`\n", args[1], filepath.Join(paths.DataDir(), "identities", args[1]))
+		return 0
+	}
+	fmt.Fprintln(os.Stderr, usage)
+	return 2
+}
+
 // looksLikeToken returns true if the value matches Slack token format.
 // Env vars from mcpb may contain stale or placeholder values — only use
 // them when they look like real tokens.
@@ -110,11 +482,12 @@ func looksLikeToken(v, prefix string) bool {
 //
 //  1. Config file (~/.config/slack-mcp/config.json) — always checked first
 //  2. Env vars matching token format (xoxc-/xoxd-) — manual override
-//  3. Nothing → return error (server starts, tools prompt for auth-setup)
+//  3. SLACK_MCP_TOKEN holding a Slack app's xoxb-/xoxp- token
+//  4. Nothing → return error (server starts, tools prompt for auth-setup)
 //
-// All token sources are validated against auth.test before use.
-// Invalid tokens are rejected so the server starts in no-auth mode
-// with clear guidance, rather than silently failing on every tool call.
+// Tokens are not checked here: auth.test runs lazily when the provider
+// boots, so startup never waits on the network. Rejected tokens surface
+// on the first tool call with guidance to run auth-setup.
 func loadProvider() (*provider.ApiProvider, error) {
 	// Config file is the source of truth — shared across all MCP hosts
 	cfg, err := setup.LoadConfig()
@@ -128,17 +501,27 @@ func loadProvider() (*provider.ApiProvider, error) {
 		}
 
 		if ws, ok := cfg.Workspaces[wsName]; ok {
-			log.Printf("Validating workspace %q from config file...", wsName)
-			if _, _, _, err := setup.ValidateTokens(ws.XoxcToken, ws.XoxdToken); err != nil {
-				log.Printf("Config tokens for %q failed validation: %v", wsName, err)
-				return nil, fmt.Errorf("stored tokens for workspace %q are invalid (%v) — run auth-setup to re-authenticate", wsName, err)
-			}
-			log.Printf("Workspace %q authenticated successfully", wsName)
-			// Clear any stale setup flow state — tokens are valid
+			log.Printf("Using workspace %q from config file", wsName)
+			// Clear any stale setup flow state — tokens were saved by a completed flow
 			if cfg.SetupFlow != nil {
 				log.Println("Clearing stale setup flow state")
 				cfg.ClearFlow()
 			}
+			if ws.Token != "" {
+				// Rotating tokens from `auth login` last 12 hours
+				if ws.NeedsRefresh(time.Now()) {
+					ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+					if fresh, err := setup.RefreshWorkspaceToken(ctx, wsName); err != nil {
+						log.Printf("Token refresh for %q failed: %v", wsName, err)
+					} else {
+						ws = fresh
+					}
+					cancel()
+				}
+				p := provider.NewWithTokens(ws.Token, "")
+				go keepTokenFresh(func() *provider.ApiProvider { return p }, wsName, ws, setup.RefreshWorkspaceToken)
+				return p, nil
+			}
 			return provider.NewWithTokens(ws.XoxcToken, ws.XoxdToken), nil
 		}
 	}
@@ -148,12 +531,7 @@ func loadProvider() (*provider.ApiProvider, error) {
 	cookie := os.Getenv("SLACK_MCP_XOXD_TOKEN")
 
 	if looksLikeToken(token, "xoxc-") && looksLikeToken(cookie, "xoxd-") {
-		log.Println("Validating tokens from environment variables...")
-		if _, _, _, err := setup.ValidateTokens(token, cookie); err != nil {
-			log.Printf("Env var tokens failed validation: %v", err)
-			return nil, fmt.Errorf("environment tokens are invalid (%v) — run auth-setup to configure", err)
-		}
-		log.Println("Environment tokens authenticated successfully")
+		log.Println("Using tokens from environment variables")
 		return provider.NewWithTokens(token, cookie), nil
 	}
 
@@ -161,6 +539,14 @@ func loadProvider() (*provider.ApiProvider, error) {
 		log.Println("Ignoring env var tokens — don't match expected format (xoxc-/xoxd-)")
 	}
 
+	// A Slack app's token works through the official API alone
+	if appToken := os.Getenv("SLACK_MCP_TOKEN"); looksLikeToken(appToken, "xoxb-") || looksLikeToken(appToken, "xoxp-") {
+		log.Println("Using app token from SLACK_MCP_TOKEN")
+		return provider.NewWithTokens(appToken, ""), nil
+	} else if appToken != "" {
+		log.Println("Ignoring SLACK_MCP_TOKEN — doesn't match expected format (xoxb-/xoxp-)")
+	}
+
 	// No credentials found anywhere
 	return nil, fmt.Errorf("no Slack credentials found in config (%s) or environment", setup.ConfigPath())
 }
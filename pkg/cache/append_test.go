@@ -0,0 +1,32 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAppend(t *testing.T) {
+	s, err := NewStoreIn(filepath.Join(t.TempDir(), "identities", "alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Append("audit.jsonl", []byte(`{"tool":"search"}`))
+	s.Append("audit.jsonl", []byte(`{"tool":"send-message"}`))
+
+	data, err := os.ReadFile(filepath.Join(s.Dir(), "audit.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "{\"tool\":\"search\"}\n{\"tool\":\"send-message\"}\n"; string(data) != want {
+		t.Errorf("audit.jsonl = %q, want %q", data, want)
+	}
+
+	s.close()
+	if err := s.Append("audit.jsonl", []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	if after, _ := os.ReadFile(filepath.Join(s.Dir(), "audit.jsonl")); len(after) != len(data) {
+		t.Error("closed store appended")
+	}
+}
@@ -0,0 +1,180 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/aaronsb/slack-mcp/pkg/paths"
+)
+
+// Encrypted files are AES-256-GCM: magic, salt, nonce, then ciphertext.
+// The salt only matters for passphrase keys, which are stretched with
+// PBKDF2 per file; random keys are used as is.
+var encryptedMagic = []byte("SMCPENC1")
+
+const (
+	encryptedSaltSize = 16
+	indexKeyFile      = "index.key"
+	pbkdf2Iterations  = 600_000
+)
+
+// ErrWrongKey is returned by LoadEncrypted when the file doesn't decrypt
+// with the given key.
+var ErrWrongKey = errors.New("cache: wrong key or corrupt encrypted file")
+
+// Key encrypts local files. It comes from SLACK_MCP_INDEX_KEY (a
+// passphrase) or, failing that, a random key kept in the config directory
+// with the credentials. An OS keychain source would slot in here.
+type Key struct {
+	secret     []byte
+	passphrase bool
+
+	// The last passphrase derivation; a save's salt is the next load's
+	mu       sync.Mutex
+	lastSalt []byte
+	lastKey  []byte
+}
+
+var (
+	passphraseKeyMu sync.Mutex
+	passphraseKey   *Key
+)
+
+// LoadKey returns the configured key, creating the key file on first use
+func LoadKey() (*Key, error) {
+	if pass := os.Getenv("SLACK_MCP_INDEX_KEY"); pass != "" {
+		// Reuse the key so its last derivation is too
+		passphraseKeyMu.Lock()
+		defer passphraseKeyMu.Unlock()
+		if passphraseKey == nil || string(passphraseKey.secret) != pass {
+			passphraseKey = &Key{secret: []byte(pass), passphrase: true}
+		}
+		return passphraseKey, nil
+	}
+
+	path := filepath.Join(paths.ConfigDir(), indexKeyFile)
+	if data, err := os.ReadFile(path); err == nil {
+		secret, err := hex.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil || len(secret) != 32 {
+			return nil, fmt.Errorf("cache: %s is not a 32-byte hex key", path)
+		}
+		return &Key{secret: secret}, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		// Another process created it first; use theirs
+		if os.IsExist(err) {
+			return LoadKey()
+		}
+		return nil, err
+	}
+	defer f.Close()
+	if _, err := f.WriteString(hex.EncodeToString(secret) + "\n"); err != nil {
+		return nil, err
+	}
+	return &Key{secret: secret}, nil
+}
+
+func (k *Key) forSalt(salt []byte) ([]byte, error) {
+	if !k.passphrase {
+		return k.secret, nil
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.lastKey != nil && bytes.Equal(k.lastSalt, salt) {
+		return k.lastKey, nil
+	}
+	key, err := pbkdf2.Key(sha256.New, string(k.secret), salt, pbkdf2Iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	k.lastSalt, k.lastKey = append([]byte(nil), salt...), key
+	return key, nil
+}
+
+// SaveEncrypted atomically writes data as JSON encrypted with key
+func (s *Store) SaveEncrypted(filename string, key *Key, data interface{}) error {
+	plain, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("cache: marshal %s: %w", filename, err)
+	}
+	salt := make([]byte, encryptedSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	aead, err := key.aead(salt)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(encryptedMagic)
+	buf.Write(salt)
+	buf.Write(nonce)
+	buf.Write(aead.Seal(nil, nonce, plain, encryptedMagic))
+	return s.writeAtomic(filename, buf.Bytes())
+}
+
+// LoadEncrypted reads a file written by SaveEncrypted. Returns
+// os.ErrNotExist if missing and ErrWrongKey if it doesn't decrypt.
+func (s *Store) LoadEncrypted(filename string, key *Key, dest interface{}) error {
+	raw, err := os.ReadFile(filepath.Join(s.dir, filename))
+	if err != nil {
+		return err
+	}
+	if !bytes.HasPrefix(raw, encryptedMagic) || len(raw) < len(encryptedMagic)+encryptedSaltSize {
+		return ErrWrongKey
+	}
+	raw = raw[len(encryptedMagic):]
+	salt, raw := raw[:encryptedSaltSize], raw[encryptedSaltSize:]
+	aead, err := key.aead(salt)
+	if err != nil {
+		return err
+	}
+	if len(raw) < aead.NonceSize() {
+		return ErrWrongKey
+	}
+	plain, err := aead.Open(nil, raw[:aead.NonceSize()], raw[aead.NonceSize():], encryptedMagic)
+	if err != nil {
+		return ErrWrongKey
+	}
+	return json.Unmarshal(plain, dest)
+}
+
+func (k *Key) aead(salt []byte) (cipher.AEAD, error) {
+	key, err := k.forSalt(salt)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEncryptedRoundTrip(t *testing.T) {
+	s := &Store{dir: t.TempDir()}
+	key := &Key{secret: bytes.Repeat([]byte{7}, 32)}
+	want := map[string]string{"text": "launch moves to Friday"}
+	if err := s.SaveEncrypted("index.enc", key, want); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, _ := os.ReadFile(filepath.Join(s.dir, "index.enc"))
+	if bytes.Contains(raw, []byte("Friday")) {
+		t.Fatal("plaintext on disk")
+	}
+
+	var got map[string]string
+	if err := s.LoadEncrypted("index.enc", key, &got); err != nil || got["text"] != want["text"] {
+		t.Fatalf("got %v, %v", got, err)
+	}
+
+	other := &Key{secret: bytes.Repeat([]byte{8}, 32)}
+	if err := s.LoadEncrypted("index.enc", other, &got); !errors.Is(err, ErrWrongKey) {
+		t.Errorf("wrong key: got %v", err)
+	}
+	if err := s.LoadEncrypted("missing.enc", key, &got); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing: got %v", err)
+	}
+}
+
+func TestEncryptedPassphrase(t *testing.T) {
+	s := &Store{dir: t.TempDir()}
+	t.Setenv("SLACK_MCP_INDEX_KEY", "correct horse")
+	key, err := LoadKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SaveEncrypted("index.enc", key, []int{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []int
+	fresh := &Key{secret: []byte("correct horse"), passphrase: true}
+	if err := s.LoadEncrypted("index.enc", fresh, &got); err != nil || len(got) != 2 {
+		t.Fatalf("got %v, %v", got, err)
+	}
+	wrong := &Key{secret: []byte("battery staple"), passphrase: true}
+	if err := s.LoadEncrypted("index.enc", wrong, &got); !errors.Is(err, ErrWrongKey) {
+		t.Errorf("wrong passphrase: got %v", err)
+	}
+}
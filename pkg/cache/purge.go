@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/aaronsb/slack-mcp/pkg/paths"
+)
+
+// PurgedFile is one file removed (or, in a dry run, that would be removed)
+// by a purge.
+type PurgedFile struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// PurgeReport lists what a purge removed and anything it couldn't.
+type PurgeReport struct {
+	Files  []PurgedFile `json:"files"`
+	Bytes  int64        `json:"bytes"`
+	Failed []string     `json:"failed,omitempty"`
+	DryRun bool         `json:"dryRun"`
+}
+
+// PurgeLocalData deletes everything the server keeps on disk: the data
+// directory (caches, event and usage logs, collections, indexes, mappings)
+// and the log file, plus stored credentials when credentials is set.
+// Files are overwritten with zeros before removal.
+//
+// A live store is closed first so the running server doesn't write its
+// in-memory caches straight back; pass nil from the command line.
+func PurgeLocalData(store *Store, credentials, dryRun bool) *PurgeReport {
+	report := &PurgeReport{DryRun: dryRun}
+	if store != nil && !dryRun {
+		store.close()
+	}
+
+	dataDir := paths.DataDir()
+	if store != nil {
+		dataDir = store.Dir()
+	}
+	targets := []string{dataDir, paths.LogPath(), paths.LegacyLogPath()}
+	if credentials {
+		targets = append(targets, paths.ConfigDir())
+	}
+	for _, target := range targets {
+		purgePath(target, dryRun, report)
+	}
+	return report
+}
+
+// PurgeStore deletes one store's directory, such as an identity's, and
+// closes the store first. Shared files like the log are left alone.
+func PurgeStore(store *Store, dryRun bool) *PurgeReport {
+	report := &PurgeReport{DryRun: dryRun}
+	if !dryRun {
+		store.close()
+	}
+	purgePath(store.Dir(), dryRun, report)
+	return report
+}
+
+// purgePath removes a file or directory tree, recording each file
+func purgePath(root string, dryRun bool, report *PurgeReport) {
+	if _, err := os.Lstat(root); err != nil {
+		return
+	}
+	var dirs []string
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			report.Failed = append(report.Failed, path+": "+err.Error())
+			return nil
+		}
+		if d.IsDir() {
+			dirs = append(dirs, path)
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			report.Failed = append(report.Failed, path+": "+err.Error())
+			return nil
+		}
+		if !dryRun {
+			if err := shred(path, info); err != nil {
+				report.Failed = append(report.Failed, path+": "+err.Error())
+				return nil
+			}
+		}
+		report.Files = append(report.Files, PurgedFile{Path: path, Size: info.Size()})
+		report.Bytes += info.Size()
+		return nil
+	})
+	if dryRun {
+		return
+	}
+	// Deepest first, so each directory is empty by the time it's removed
+	sort.Slice(dirs, func(i, j int) bool { return len(dirs[i]) > len(dirs[j]) })
+	for _, dir := range dirs {
+		if err := os.Remove(dir); err != nil && !os.IsNotExist(err) {
+			report.Failed = append(report.Failed, dir+": "+err.Error())
+		}
+	}
+}
+
+// shred overwrites a regular file with zeros, syncs it, and removes it.
+// On copy-on-write filesystems and SSDs the old blocks may survive; the
+// overwrite is best effort, the removal is not.
+func shred(path string, info fs.FileInfo) error {
+	if info.Mode().IsRegular() && info.Size() > 0 {
+		if f, err := os.OpenFile(path, os.O_WRONLY, 0); err == nil {
+			zeros := make([]byte, 32*1024)
+			for left := info.Size(); left > 0; {
+				n := int64(len(zeros))
+				if left < n {
+					n = left
+				}
+				if _, err := f.Write(zeros[:n]); err != nil {
+					break
+				}
+				left -= n
+			}
+			f.Sync()
+			f.Close()
+		}
+	}
+	return os.Remove(path)
+}
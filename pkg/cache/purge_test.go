@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPurgePath(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	s := &Store{dir: dir}
+	os.MkdirAll(filepath.Join(dir, "index"), 0o700)
+	s.Save("events.json", []string{"a", "b"})
+	os.WriteFile(filepath.Join(dir, "index", "vectors.bin"), []byte("12345"), 0o600)
+
+	dry := &PurgeReport{DryRun: true}
+	purgePath(dir, true, dry)
+	if len(dry.Files) != 2 || dry.Bytes != 14 {
+		t.Fatalf("dry run = %+v", dry)
+	}
+	if !s.Exists("events.json") {
+		t.Fatal("dry run removed files")
+	}
+
+	s.close()
+	report := &PurgeReport{}
+	purgePath(dir, false, report)
+	if len(report.Files) != 2 || len(report.Failed) > 0 {
+		t.Fatalf("report = %+v", report)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("data dir still exists: %v", err)
+	}
+
+	// A closed store must not write its caches back
+	if err := s.Save("events.json", []string{"c"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Error("closed store recreated data")
+	}
+}
+
+func TestPurgeStoreLeavesOthers(t *testing.T) {
+	root := t.TempDir()
+	alice, err := NewStoreIn(filepath.Join(root, "identities", "alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, _ := NewStoreIn(filepath.Join(root, "identities", "bob"))
+	alice.Save("events.json", []string{"a"})
+	bob.Save("events.json", []string{"b"})
+
+	report := PurgeStore(alice, false)
+	if len(report.Files) != 1 || len(report.Failed) > 0 {
+		t.Fatalf("report = %+v", report)
+	}
+	if alice.Exists("events.json") || !bob.Exists("events.json") {
+		t.Error("purge should remove only the given store's files")
+	}
+	alice.Save("events.json", []string{"c"})
+	if alice.Exists("events.json") {
+		t.Error("purged store wrote its caches back")
+	}
+}
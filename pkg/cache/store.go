@@ -18,13 +18,19 @@ type Store struct {
 	dir       string
 	mu        sync.RWMutex
 	dirty     bool
+	closed    bool // purged; further writes are dropped
 	flushStop chan struct{}
 }
 
 // NewStore creates a cache store using XDG data directory.
 // It ensures the directory exists and starts a periodic flush goroutine.
 func NewStore() (*Store, error) {
-	dir := paths.DataDir()
+	return NewStoreIn(paths.DataDir())
+}
+
+// NewStoreIn creates a cache store in dir, e.g. an identity's own
+// subdirectory of the data directory.
+func NewStoreIn(dir string) (*Store, error) {
 	if err := os.MkdirAll(dir, 0700); err != nil {
 		return nil, fmt.Errorf("cache: create dir %s: %w", dir, err)
 	}
@@ -74,6 +80,14 @@ func (s *Store) Stop() {
 	close(s.flushStop)
 }
 
+// close stops all further writes, so a purge isn't undone by the next
+// flush. It lasts until the process restarts.
+func (s *Store) close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+}
+
 // MarkDirty flags the cache as needing a flush.
 func (s *Store) MarkDirty() {
 	s.mu.Lock()
@@ -93,12 +107,22 @@ func (s *Store) Load(filename string, dest interface{}) error {
 
 // Save atomically writes data to a cache file using temp+rename.
 func (s *Store) Save(filename string, data interface{}) error {
-	path := filepath.Join(s.dir, filename)
-
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("cache: marshal %s: %w", filename, err)
 	}
+	return s.writeAtomic(filename, jsonData)
+}
+
+// writeAtomic writes bytes to a cache file using temp+rename.
+func (s *Store) writeAtomic(filename string, jsonData []byte) error {
+	s.mu.RLock()
+	closed := s.closed
+	s.mu.RUnlock()
+	if closed {
+		return nil
+	}
+	path := filepath.Join(s.dir, filename)
 
 	// Write to temp file in same directory (same filesystem for rename)
 	tmp, err := os.CreateTemp(s.dir, filename+".tmp.*")
@@ -126,6 +150,24 @@ func (s *Store) Save(filename string, data interface{}) error {
 	return nil
 }
 
+// Append adds a line to a log file in the store, creating it if needed
+func (s *Store) Append(filename string, line []byte) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return nil
+	}
+	f, err := os.OpenFile(filepath.Join(s.dir, filename), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("cache: open %s: %w", filename, err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("cache: append %s: %w", filename, err)
+	}
+	return f.Close()
+}
+
 // Exists checks if a cache file exists.
 func (s *Store) Exists(filename string) bool {
 	path := filepath.Join(s.dir, filename)
@@ -153,7 +195,7 @@ func (s *Store) Remove(filename string) error {
 	return err
 }
 
-// MigrateFromCWD moves old CWD-based cache files to XDG data dir.
+// MigrateFromCWD moves old CWD-based cache files to the data dir.
 // Silently skips files that don't exist or already migrated.
 func (s *Store) MigrateFromCWD(oldFiles map[string]string) {
 	for oldName, newName := range oldFiles {
@@ -178,5 +220,10 @@ func (s *Store) MigrateFromCWD(oldFiles map[string]string) {
 		}
 
 		log.Printf("cache: migrated %s -> %s", oldName, newPath)
+		// Don't leave caches behind in whatever project directory the
+		// server happened to start in
+		if err := os.Remove(oldPath); err != nil {
+			log.Printf("cache: remove %s: %v", oldPath, err)
+		}
 	}
 }
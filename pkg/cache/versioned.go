@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Errors returned by LoadVersioned for files that should be rebuilt rather
+// than retried.
+var (
+	ErrVersionMismatch = errors.New("cache: schema version mismatch")
+	ErrCorrupt         = errors.New("cache: corrupt file")
+)
+
+// envelope wraps versioned cache payloads. Checksum is the hex SHA-256 of
+// Data, so a truncated or hand-edited file is caught before it is decoded.
+type envelope struct {
+	Version  int             `json:"version"`
+	Checksum string          `json:"checksum"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// SaveVersioned atomically writes data as a gzip-compressed, checksummed
+// envelope tagged with the caller's schema version.
+func (s *Store) SaveVersioned(filename string, version int, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("cache: marshal %s: %w", filename, err)
+	}
+	sum := sha256.Sum256(payload)
+	wrapped, err := json.Marshal(envelope{
+		Version:  version,
+		Checksum: hex.EncodeToString(sum[:]),
+		Data:     payload,
+	})
+	if err != nil {
+		return fmt.Errorf("cache: marshal %s: %w", filename, err)
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(wrapped); err != nil {
+		return fmt.Errorf("cache: compress %s: %w", filename, err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("cache: compress %s: %w", filename, err)
+	}
+	return s.writeAtomic(filename, buf.Bytes())
+}
+
+// LoadVersioned reads a file written by SaveVersioned. Returns
+// os.ErrNotExist if missing, ErrVersionMismatch if it was written by a
+// different schema version, and ErrCorrupt if it fails to decompress,
+// decode, or verify.
+func (s *Store) LoadVersioned(filename string, version int, dest interface{}) error {
+	f, err := os.Open(filepath.Join(s.dir, filename))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrCorrupt, filename, err)
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrCorrupt, filename, err)
+	}
+
+	var env envelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrCorrupt, filename, err)
+	}
+	if env.Version != version {
+		return fmt.Errorf("%w: %s has v%d, want v%d", ErrVersionMismatch, filename, env.Version, version)
+	}
+	sum := sha256.Sum256(env.Data)
+	if hex.EncodeToString(sum[:]) != env.Checksum {
+		return fmt.Errorf("%w: %s: checksum mismatch", ErrCorrupt, filename)
+	}
+	if err := json.Unmarshal(env.Data, dest); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrCorrupt, filename, err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVersionedRoundTrip(t *testing.T) {
+	s := &Store{dir: t.TempDir()}
+	want := map[string]string{"U1": "D1", "U2": "D2"}
+	if err := s.SaveVersioned("dm.json.gz", 3, want); err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]string
+	if err := s.LoadVersioned("dm.json.gz", 3, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got["U2"] != "D2" {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if err := s.LoadVersioned("dm.json.gz", 4, &got); !errors.Is(err, ErrVersionMismatch) {
+		t.Errorf("expected ErrVersionMismatch, got %v", err)
+	}
+	if err := s.LoadVersioned("missing.json.gz", 3, &got); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected os.ErrNotExist, got %v", err)
+	}
+}
+
+func TestVersionedDetectsCorruption(t *testing.T) {
+	s := &Store{dir: t.TempDir()}
+
+	// Plain JSON from an older release isn't gzip
+	if err := s.Save("plain.json.gz", []string{"a"}); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	if err := s.LoadVersioned("plain.json.gz", 1, &got); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("plain JSON: expected ErrCorrupt, got %v", err)
+	}
+
+	// Truncated file
+	if err := s.SaveVersioned("cut.json.gz", 1, []string{"a", "b", "c"}); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(s.dir, "cut.json.gz")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data[:len(data)/2], 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.LoadVersioned("cut.json.gz", 1, &got); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("truncated: expected ErrCorrupt, got %v", err)
+	}
+}
@@ -0,0 +1,414 @@
+package doctor
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/features"
+	"github.com/aaronsb/slack-mcp/pkg/paths"
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/aaronsb/slack-mcp/pkg/setup"
+	"github.com/aaronsb/slack-mcp/pkg/version"
+	"github.com/slack-go/slack"
+)
+
+// Check outcomes
+const (
+	StatusOK   = "ok"
+	StatusWarn = "warn"
+	StatusFail = "fail"
+	StatusSkip = "skip"
+)
+
+// logTailLines bounds how much of the log goes into a bundle
+const logTailLines = 200
+
+// Check is one diagnostic and how long it took
+type Check struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	LatencyMs int64  `json:"latencyMs,omitempty"`
+}
+
+// CacheFile describes one file in the data directory
+type CacheFile struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	Age    string `json:"age"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Report is everything doctor found. It holds no tokens or message text.
+type Report struct {
+	Version   string      `json:"version"`
+	Commit    string      `json:"commit"`
+	Platform  string      `json:"platform"`
+	GoVersion string      `json:"goVersion"`
+	Generated time.Time   `json:"generated"`
+	Paths     []Check     `json:"paths"`
+	Checks    []Check     `json:"checks"`
+	Cache     []CacheFile `json:"cache"`
+	Env       []string    `json:"env"`
+}
+
+// Failed reports whether any check failed
+func (r *Report) Failed() bool {
+	for _, c := range append(append([]Check{}, r.Paths...), r.Checks...) {
+		if c.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
+
+// Run performs the diagnostics. With a nil provider, or offline set, only
+// the local checks run.
+func Run(ctx context.Context, p *provider.ApiProvider, offline bool) *Report {
+	r := &Report{
+		Version:   version.Version,
+		Commit:    version.CommitHash,
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+		GoVersion: runtime.Version(),
+		Generated: time.Now(),
+		Env:       configuredEnv(),
+	}
+	r.Paths = checkPaths()
+	r.Checks = append(r.Checks, checkConfig())
+	r.Cache = checkCache(paths.DataDir())
+
+	switch {
+	case p == nil:
+		r.Checks = append(r.Checks, Check{Name: "slack", Status: StatusSkip, Detail: "no credentials; run 'slack-mcp setup'"})
+	case offline:
+		r.Checks = append(r.Checks, Check{Name: "slack", Status: StatusSkip, Detail: "skipped (--offline)"})
+	default:
+		r.Checks = append(r.Checks, checkSlack(ctx, p)...)
+	}
+	return r
+}
+
+// timed runs fn and records its latency on the check it returns
+func timed(name string, fn func() (string, error)) Check {
+	start := time.Now()
+	detail, err := fn()
+	c := Check{Name: name, Status: StatusOK, Detail: detail, LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		c.Status = StatusFail
+		c.Detail = err.Error()
+	}
+	return c
+}
+
+func checkSlack(ctx context.Context, p *provider.ApiProvider) []Check {
+	var api *slack.Client
+	boot := timed("boot", func() (string, error) {
+		var err error
+		api, err = p.Provide()
+		return "", err
+	})
+	if api == nil {
+		return []Check{boot}
+	}
+
+	checks := []Check{boot}
+	checks = append(checks, timed("auth.test", func() (string, error) {
+		res, err := api.AuthTestContext(ctx)
+		if err != nil {
+			return "", err
+		}
+		return "team " + res.TeamID, nil
+	}))
+	if p.ProvideInternalClient() == nil {
+		checks = append(checks, Check{Name: "client.counts", Status: StatusSkip, Detail: p.TokenType() + " token has no internal endpoints"})
+	} else {
+		checks = append(checks, timed("client.counts", func() (string, error) {
+			counts, err := p.ProvideInternalClient().GetClientCounts(ctx)
+			if err != nil {
+				return "", err
+			}
+			if !counts.OK {
+				return "", fmt.Errorf("not ok")
+			}
+			return fmt.Sprintf("%d channels, %d DMs", len(counts.Channels), len(counts.IMs)), nil
+		}))
+	}
+	checks = append(checks, timed("search.messages", func() (string, error) {
+		sp := slack.NewSearchParameters()
+		sp.Count = 1
+		res, err := api.SearchMessagesContext(ctx, "after:"+time.Now().AddDate(0, 0, -7).Format("2006-01-02"), sp)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d matches in the last week", res.Total), nil
+	}))
+
+	var channelID string
+	for _, ch := range p.GetCachedChannels() {
+		if ch.IsMember && !ch.IsArchived {
+			channelID = ch.ID
+			break
+		}
+	}
+	if channelID == "" {
+		checks = append(checks, Check{Name: "conversations.history", Status: StatusSkip, Detail: "no cached channel to read"})
+	} else {
+		checks = append(checks, timed("conversations.history", func() (string, error) {
+			_, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{ChannelID: channelID, Limit: 1})
+			return "one message from a member channel", err
+		}))
+	}
+
+	cache := p.GetCacheInfo()
+	status := StatusOK
+	if cache.ChannelCount == 0 {
+		status = StatusWarn
+	}
+	checks = append(checks, Check{
+		Name:   "channel cache",
+		Status: status,
+		Detail: fmt.Sprintf("%d channels, %d users", cache.ChannelCount, len(p.ProvideUsersMap())),
+	})
+	return checks
+}
+
+// checkPaths confirms each directory exists (or can be created) and is
+// writable
+func checkPaths() []Check {
+	dirs := []struct{ name, dir string }{
+		{"config dir", paths.ConfigDir()},
+		{"data dir", paths.DataDir()},
+		{"log dir", filepath.Dir(paths.LogPath())},
+	}
+	var checks []Check
+	for _, d := range dirs {
+		c := Check{Name: d.name, Status: StatusOK, Detail: d.dir}
+		if err := os.MkdirAll(d.dir, 0o700); err != nil {
+			c.Status, c.Detail = StatusFail, fmt.Sprintf("%s: %v", d.dir, err)
+		} else if f, err := os.CreateTemp(d.dir, ".doctor-*"); err != nil {
+			c.Status, c.Detail = StatusFail, fmt.Sprintf("%s not writable: %v", d.dir, err)
+		} else {
+			f.Close()
+			os.Remove(f.Name())
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+// checkConfig reports on the credentials file without revealing tokens
+func checkConfig() Check {
+	c := Check{Name: "config"}
+	info, err := os.Stat(setup.ConfigPath())
+	if os.IsNotExist(err) {
+		c.Status, c.Detail = StatusWarn, "no config file; using environment variables if set"
+		return c
+	}
+	cfg, err := setup.LoadConfig()
+	if err != nil {
+		c.Status, c.Detail = StatusFail, err.Error()
+		return c
+	}
+	c.Status = StatusOK
+	var problems []string
+	for name, ws := range cfg.Workspaces {
+		if ws.Token != "" {
+			if !strings.HasPrefix(ws.Token, "xoxb-") && !strings.HasPrefix(ws.Token, "xoxp-") {
+				problems = append(problems, name+": token doesn't look like xoxb-/xoxp-")
+			}
+		} else if !strings.HasPrefix(ws.XoxcToken, "xoxc-") || !strings.HasPrefix(ws.XoxdToken, "xoxd-") {
+			problems = append(problems, name+": tokens don't look like xoxc-/xoxd-")
+		}
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+		problems = append(problems, fmt.Sprintf("permissions %o; expected 600", info.Mode().Perm()))
+	}
+	c.Detail = fmt.Sprintf("%d workspace(s)", len(cfg.Workspaces))
+	if len(problems) > 0 {
+		c.Status = StatusWarn
+		c.Detail += "; " + strings.Join(problems, "; ")
+	}
+	return c
+}
+
+// checkCache parses every JSON cache file in dir, so corruption shows up
+// here rather than as a silent rebuild
+func checkCache(dir string) []CacheFile {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var files []CacheFile
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		f := CacheFile{
+			Name:   e.Name(),
+			Size:   info.Size(),
+			Age:    time.Since(info.ModTime()).Round(time.Minute).String(),
+			Status: StatusOK,
+		}
+		if err := validateCacheFile(filepath.Join(dir, e.Name())); err != nil {
+			f.Status, f.Detail = StatusWarn, err.Error()
+		}
+		files = append(files, f)
+	}
+	return files
+}
+
+func validateCacheFile(path string) error {
+	var data []byte
+	var err error
+	switch {
+	case strings.HasSuffix(path, ".json.gz"):
+		var f *os.File
+		if f, err = os.Open(path); err != nil {
+			return err
+		}
+		defer f.Close()
+		zr, zerr := gzip.NewReader(f)
+		if zerr != nil {
+			return fmt.Errorf("not gzip: %v", zerr)
+		}
+		data, err = io.ReadAll(zr)
+	case strings.HasSuffix(path, ".json"):
+		data, err = os.ReadFile(path)
+	default:
+		// Encrypted and temporary files can't be checked without a key
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !json.Valid(data) {
+		return fmt.Errorf("invalid JSON")
+	}
+	return nil
+}
+
+// configuredEnv lists which SLACK_MCP_* variables are set, never their
+// values
+func configuredEnv() []string {
+	var names []string
+	for _, kv := range os.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+		if strings.HasPrefix(name, "SLACK_MCP_") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Text renders the report for the terminal
+func (r *Report) Text() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("slack-mcp %s (%s) on %s, %s\n\n", r.Version, r.Commit, r.Platform, r.GoVersion))
+	write := func(c Check) {
+		line := fmt.Sprintf("[%-4s] %-22s %s", c.Status, c.Name, c.Detail)
+		if c.LatencyMs > 0 {
+			line += fmt.Sprintf(" (%dms)", c.LatencyMs)
+		}
+		b.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	for _, c := range r.Paths {
+		write(c)
+	}
+	for _, c := range r.Checks {
+		write(c)
+	}
+	if len(r.Cache) > 0 {
+		b.WriteString("\nCache files:\n")
+		for _, f := range r.Cache {
+			line := fmt.Sprintf("[%-4s] %-28s %8d bytes, %s old", f.Status, f.Name, f.Size, f.Age)
+			if f.Detail != "" {
+				line += ": " + f.Detail
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	if len(r.Env) > 0 {
+		b.WriteString("\nEnvironment: " + strings.Join(r.Env, ", ") + "\n")
+	}
+	return b.String()
+}
+
+// WriteBundle writes a zip of the report and the sanitized end of the log
+// to dir, refusing to overwrite, and returns its path
+func (r *Report) WriteBundle(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("slack-mcp-diagnostics-%s.zip", r.Generated.Format("20060102-150405")))
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", err
+	}
+
+	reportJSON, _ := json.MarshalIndent(r, "", "  ")
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{"report.json", reportJSON},
+		{"report.txt", []byte(r.Text())},
+		{"log-tail.txt", []byte(sanitizedLogTail(paths.LogPath(), logTailLines))},
+	}
+
+	zw := zip.NewWriter(out)
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err == nil {
+			_, err = w.Write(f.data)
+		}
+		if err != nil {
+			zw.Close()
+			out.Close()
+			os.Remove(path)
+			return "", err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		out.Close()
+		os.Remove(path)
+		return "", err
+	}
+	return path, out.Close()
+}
+
+// sanitizedLogTail returns the last n lines of the log with secrets,
+// emails, and phone numbers scrubbed
+func sanitizedLogTail(path string, n int) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Sprintf("(no log at %s)\n", path)
+	}
+	var lines []string
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+		if len(lines) > n {
+			lines = lines[1:]
+		}
+	}
+	return features.ScrubForReport(strings.Join(lines, "\n")) + "\n"
+}
@@ -0,0 +1,56 @@
+package doctor
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckCache(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "events.json"), []byte(`[{"id":"1"}]`), 0o600)
+	os.WriteFile(filepath.Join(dir, "nudges.json"), []byte(`{"trunc`), 0o600)
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte(`{"version":3}`))
+	zw.Close()
+	os.WriteFile(filepath.Join(dir, "users.json.gz"), gz.Bytes(), 0o600)
+	os.WriteFile(filepath.Join(dir, "embeddings.json.enc"), []byte("SMCPENC1..."), 0o600)
+
+	status := map[string]string{}
+	for _, f := range checkCache(dir) {
+		status[f.Name] = f.Status
+	}
+	want := map[string]string{
+		"events.json":         StatusOK,
+		"nudges.json":         StatusWarn,
+		"users.json.gz":       StatusOK,
+		"embeddings.json.enc": StatusOK,
+	}
+	for name, w := range want {
+		if status[name] != w {
+			t.Errorf("%s = %q, want %q", name, status[name], w)
+		}
+	}
+}
+
+func TestSanitizedLogTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slack-mcp.log")
+	var log strings.Builder
+	for i := 0; i < 10; i++ {
+		log.WriteString("noise\n")
+	}
+	log.WriteString("token=xoxc-1234567890-abcdef from ann@example.com\n")
+	os.WriteFile(path, []byte(log.String()), 0o600)
+
+	tail := sanitizedLogTail(path, 3)
+	if strings.Count(tail, "\n") != 3 {
+		t.Errorf("expected 3 lines, got %q", tail)
+	}
+	if strings.Contains(tail, "xoxc-1234567890") || strings.Contains(tail, "ann@example.com") {
+		t.Errorf("secrets left in %q", tail)
+	}
+}
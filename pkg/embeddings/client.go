@@ -0,0 +1,117 @@
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Client calls an OpenAI-compatible /embeddings endpoint. Local servers
+// such as Ollama or llama.cpp expose the same API, so message text never
+// has to leave the machine.
+type Client struct {
+	httpClient *http.Client
+	url        string
+	model      string
+	apiKey     string
+}
+
+// FromEnv builds a client from SLACK_MCP_EMBEDDINGS_URL, _MODEL and
+// _API_KEY. Returns nil when no endpoint is configured.
+func FromEnv() *Client {
+	endpoint := strings.TrimSpace(os.Getenv("SLACK_MCP_EMBEDDINGS_URL"))
+	if endpoint == "" {
+		return nil
+	}
+	if !strings.HasSuffix(endpoint, "/embeddings") {
+		endpoint = strings.TrimRight(endpoint, "/") + "/embeddings"
+	}
+	model := os.Getenv("SLACK_MCP_EMBEDDINGS_MODEL")
+	if model == "" {
+		model = "nomic-embed-text"
+	}
+	return &Client{
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+		url:        endpoint,
+		model:      model,
+		apiKey:     os.Getenv("SLACK_MCP_EMBEDDINGS_API_KEY"),
+	}
+}
+
+// Model returns the embedding model name; vectors from different models
+// are not comparable
+func (c *Client) Model() string {
+	return c.model
+}
+
+type embedRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embedResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Embed returns one vector per input text, in input order
+func (c *Client) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(embedRequest{Model: c.model, Input: texts})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embedding request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(data))
+	}
+
+	var result embedResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("embedding API error: %s", result.Error.Message)
+	}
+	if len(result.Data) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(result.Data))
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, d := range result.Data {
+		if d.Index < 0 || d.Index >= len(vectors) {
+			return nil, fmt.Errorf("embedding index %d out of range", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	return vectors, nil
+}
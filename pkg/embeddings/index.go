@@ -0,0 +1,89 @@
+package embeddings
+
+import (
+	"math"
+	"sort"
+)
+
+// Entry is one embedded message
+type Entry struct {
+	ChannelID string    `json:"channelId"`
+	Timestamp string    `json:"ts"`
+	User      string    `json:"user"`
+	Text      string    `json:"text"`
+	Vector    []float32 `json:"vector"`
+}
+
+// Key identifies a message across channels
+func (e Entry) Key() string {
+	return e.ChannelID + ":" + e.Timestamp
+}
+
+// Index is a flat in-memory vector index. Workspaces index at most a few
+// thousand messages here, so a linear scan is fast enough.
+type Index struct {
+	Model   string  `json:"model"`
+	Entries []Entry `json:"entries"`
+}
+
+// Keys returns the set of indexed message keys
+func (ix *Index) Keys() map[string]bool {
+	keys := make(map[string]bool, len(ix.Entries))
+	for _, e := range ix.Entries {
+		keys[e.Key()] = true
+	}
+	return keys
+}
+
+// Trim keeps the newest max entries by message timestamp
+func (ix *Index) Trim(max int) {
+	if len(ix.Entries) <= max {
+		return
+	}
+	sort.Slice(ix.Entries, func(i, j int) bool {
+		return ix.Entries[i].Timestamp > ix.Entries[j].Timestamp
+	})
+	ix.Entries = ix.Entries[:max]
+}
+
+// Match is a search hit with its cosine similarity to the query
+type Match struct {
+	Entry
+	Score float64
+}
+
+// Search returns the k entries most similar to the query vector
+func (ix *Index) Search(query []float32, k int, minScore float64) []Match {
+	var matches []Match
+	for _, e := range ix.Entries {
+		score := Cosine(query, e.Vector)
+		if score >= minScore {
+			matches = append(matches, Match{Entry: e, Score: score})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	if len(matches) > k {
+		matches = matches[:k]
+	}
+	return matches
+}
+
+// Cosine returns the cosine similarity of two vectors, or 0 if their
+// dimensions differ or either is zero
+func Cosine(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
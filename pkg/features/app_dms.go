@@ -0,0 +1,149 @@
+package features
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// App DMs are conversations with Slackbot or an app's bot user: GitHub
+// review requests, PagerDuty pages, calendar invites. Their content lives
+// mostly in attachments and blocks, so entries carry a parsed headline and
+// body rather than the fallback text.
+
+// appDMWindow is how many unread messages are read per app DM
+const appDMWindow = 10
+
+// isAppUser reports whether a DM partner is Slackbot or an app
+func isAppUser(userID string, usersMap map[string]slack.User) bool {
+	if userID == "USLACKBOT" {
+		return true
+	}
+	u, ok := usersMap[userID]
+	return ok && (u.IsBot || u.IsAppUser)
+}
+
+// appMessageHeadline picks a title and link for an app message: the first
+// header block, else the first attachment with a title or pretext
+func appMessageHeadline(msg slack.Msg) (title, link string) {
+	for _, block := range msg.Blocks.BlockSet {
+		if h, ok := block.(*slack.HeaderBlock); ok {
+			if t := blockText(h.Text); t != "" {
+				title = t
+				break
+			}
+		}
+	}
+	for _, a := range msg.Attachments {
+		if title == "" {
+			title = firstNonEmpty(a.Title, a.Pretext)
+		}
+		if link == "" {
+			link = a.TitleLink
+		}
+		if title != "" && link != "" {
+			break
+		}
+	}
+	return title, link
+}
+
+// appDMEntry builds the check-unreads entry for one app DM from its unread
+// messages, newest first
+func appDMEntry(appName, channelID string, msgs []slack.Message, links *permalinkBatch) map[string]interface{} {
+	messages := make([]map[string]interface{}, 0, len(msgs))
+	urgent := false
+	for _, msg := range msgs {
+		body := messageBody(msg.Text, msg.Blocks, msg.Attachments)
+		title, link := appMessageHeadline(msg.Msg)
+		urgency := categorizeUrgencyForUser(title+"\n"+body, true)
+		if urgency == "high" {
+			urgent = true
+		}
+		entry := map[string]interface{}{
+			"text":      body,
+			"timestamp": formatTimestamp(parseSlackTimestamp(msg.Timestamp)),
+			"urgency":   urgency,
+		}
+		if title != "" {
+			entry["title"] = title
+		}
+		if link != "" {
+			entry["link"] = link
+		}
+		addAttachmentInfo(entry, "text", msg.Files, msg.Attachments, msg.Blocks)
+		if links != nil {
+			links.add(entry, channelID, msg.Timestamp)
+		}
+		messages = append(messages, entry)
+	}
+	return map[string]interface{}{
+		"type":        "app-dm",
+		"app":         appName,
+		"channelId":   channelID,
+		"unreadCount": len(msgs),
+		"messages":    messages,
+		"urgent":      urgent,
+	}
+}
+
+// unreadAppDMs reads the unread app DMs in client.counts, up to limit.
+// Returns the entries and how many app DMs have unreads in all.
+func unreadAppDMs(ctx context.Context, ap *provider.ApiProvider, api *slack.Client, counts *provider.ClientCountsResponse,
+	usersMap map[string]slack.User, limit int, links *permalinkBatch) ([]map[string]interface{}, int) {
+	entries := []map[string]interface{}{}
+	total := 0
+	for _, im := range counts.IMs {
+		if !im.HasUnreads {
+			continue
+		}
+		info, err := ap.GetChannelInfo(ctx, im.ID)
+		if err != nil {
+			log.Printf("Failed to get DM info for %s: %v", im.ID, err)
+			continue
+		}
+		if !isAppUser(info.User, usersMap) {
+			continue
+		}
+		total++
+		if len(entries) >= limit {
+			continue
+		}
+
+		// Only what's past the read pointer
+		resp, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: im.ID,
+			Oldest:    im.LastRead,
+			Limit:     appDMWindow,
+		})
+		if err != nil {
+			log.Printf("Failed to get app DM history for %s: %v", im.ID, err)
+			continue
+		}
+		if len(resp.Messages) == 0 {
+			continue
+		}
+		entries = append(entries, appDMEntry(appName(info.User, usersMap), im.ID, resp.Messages, links))
+	}
+	return entries, total
+}
+
+// appName names an app DM partner by its display name
+func appName(userID string, usersMap map[string]slack.User) string {
+	if userID == "USLACKBOT" {
+		return "Slackbot"
+	}
+	if u, ok := usersMap[userID]; ok {
+		if name := strings.TrimSpace(u.RealName); name != "" {
+			return name
+		}
+		if u.Profile.DisplayName != "" {
+			return u.Profile.DisplayName
+		}
+		return u.Name
+	}
+	return userID
+}
@@ -0,0 +1,58 @@
+package features
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestIsAppUser(t *testing.T) {
+	users := map[string]slack.User{
+		"U1": {ID: "U1", Name: "alice"},
+		"B1": {ID: "B1", Name: "github", IsBot: true},
+		"A1": {ID: "A1", Name: "calendar", IsAppUser: true},
+	}
+	for id, want := range map[string]bool{"U1": false, "B1": true, "A1": true, "USLACKBOT": true, "U9": false} {
+		if got := isAppUser(id, users); got != want {
+			t.Errorf("isAppUser(%s) = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestAppDMEntry(t *testing.T) {
+	page := slack.Message{Msg: slack.Msg{
+		Timestamp: "1700000000.000100",
+		Text:      "",
+		Attachments: []slack.Attachment{{
+			Title:     "[Triggered] API error rate",
+			TitleLink: "https://example.pagerduty.com/incidents/P1",
+			Fields:    []slack.AttachmentField{{Title: "Service", Value: "api"}},
+		}},
+	}}
+	review := slack.Message{Msg: slack.Msg{
+		Timestamp: "1700000100.000100",
+		Text:      "alice requested your review on #42",
+		Blocks: slack.Blocks{BlockSet: []slack.Block{
+			slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, "Review requested", false, false)),
+		}},
+	}}
+
+	entry := appDMEntry("PagerDuty", "D1", []slack.Message{page, review}, nil)
+	if entry["type"] != "app-dm" || entry["app"] != "PagerDuty" || entry["unreadCount"] != 2 {
+		t.Fatalf("entry = %v", entry)
+	}
+	msgs := entry["messages"].([]map[string]interface{})
+	if msgs[0]["title"] != "[Triggered] API error rate" || msgs[0]["link"] != "https://example.pagerduty.com/incidents/P1" {
+		t.Errorf("attachment headline = %v / %v", msgs[0]["title"], msgs[0]["link"])
+	}
+	if text, _ := msgs[0]["text"].(string); !strings.Contains(text, "Service: api") {
+		t.Errorf("attachment fields not parsed: %q", text)
+	}
+	if msgs[1]["title"] != "Review requested" {
+		t.Errorf("header headline = %v", msgs[1]["title"])
+	}
+	if _, ok := msgs[1]["link"]; ok {
+		t.Error("link set without one in the message")
+	}
+}
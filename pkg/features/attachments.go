@@ -0,0 +1,236 @@
+package features
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// fileEntries describes shared files for read results
+func fileEntries(files []slack.File) []map[string]interface{} {
+	entries := make([]map[string]interface{}, 0, len(files))
+	for _, f := range files {
+		entry := map[string]interface{}{
+			"id":       f.ID,
+			"name":     f.Name,
+			"mimetype": f.Mimetype,
+			"size":     f.Size,
+		}
+		if f.Title != "" && f.Title != f.Name {
+			entry["title"] = f.Title
+		}
+		if f.PrettyType != "" {
+			entry["type"] = f.PrettyType
+		} else if f.Filetype != "" {
+			entry["type"] = f.Filetype
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// attachmentEntries describes legacy attachments: link unfurls, bot cards,
+// and forwarded messages
+func attachmentEntries(atts []slack.Attachment) []map[string]interface{} {
+	entries := make([]map[string]interface{}, 0, len(atts))
+	for _, a := range atts {
+		entry := map[string]interface{}{}
+		if a.Title != "" {
+			entry["title"] = a.Title
+		}
+		if a.TitleLink != "" {
+			entry["link"] = a.TitleLink
+		} else if a.FromURL != "" {
+			entry["link"] = a.FromURL
+		}
+		if text := firstNonEmpty(a.Text, a.Pretext, a.Fallback); text != "" {
+			entry["text"] = text
+		}
+		if a.ServiceName != "" {
+			entry["service"] = a.ServiceName
+		} else if a.AuthorName != "" {
+			entry["author"] = a.AuthorName
+		}
+		if a.ImageURL != "" {
+			entry["hasImage"] = true
+		}
+		if len(entry) > 0 {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// imageAltTexts collects the alt text of image blocks
+func imageAltTexts(blocks slack.Blocks) []string {
+	var alts []string
+	for _, block := range blocks.BlockSet {
+		if img, ok := block.(*slack.ImageBlock); ok && img.AltText != "" {
+			alts = append(alts, img.AltText)
+		}
+	}
+	return alts
+}
+
+// interactiveActions lists the buttons and menus on a message, from action
+// blocks, section accessories, and legacy attachment actions. They can only
+// be used in Slack, so the agent can point the user at them; link buttons
+// carry their URL.
+func interactiveActions(blocks slack.Blocks, atts []slack.Attachment) []map[string]interface{} {
+	var actions []map[string]interface{}
+	add := func(kind, label, url string) {
+		if label == "" {
+			label = kind
+		}
+		action := map[string]interface{}{"type": kind, "label": label}
+		if url != "" {
+			action["url"] = url
+		}
+		actions = append(actions, action)
+	}
+	addElement := func(el slack.BlockElement) {
+		switch e := el.(type) {
+		case *slack.ButtonBlockElement:
+			add("button", blockText(e.Text), e.URL)
+		case *slack.WorkflowButtonBlockElement:
+			add("button", blockText(e.Text), "")
+		case *slack.SelectBlockElement:
+			add("select", blockText(e.Placeholder), "")
+		case *slack.MultiSelectBlockElement:
+			add("select", blockText(e.Placeholder), "")
+		case *slack.OverflowBlockElement:
+			add("menu", optionLabels(e.Options), "")
+		case *slack.DatePickerBlockElement:
+			add("date picker", blockText(e.Placeholder), "")
+		case *slack.RadioButtonsBlockElement:
+			add("radio buttons", optionLabels(e.Options), "")
+		case *slack.CheckboxGroupsBlockElement:
+			add("checkboxes", optionLabels(e.Options), "")
+		}
+	}
+	var walk func(blocks slack.Blocks)
+	walk = func(blocks slack.Blocks) {
+		for _, block := range blocks.BlockSet {
+			switch b := block.(type) {
+			case *slack.ActionBlock:
+				if b.Elements != nil {
+					for _, el := range b.Elements.ElementSet {
+						addElement(el)
+					}
+				}
+			case *slack.SectionBlock:
+				// An accessory holds exactly one element
+				switch acc := b.Accessory; {
+				case acc == nil:
+				case acc.ButtonElement != nil:
+					addElement(acc.ButtonElement)
+				case acc.WorkflowButtonElement != nil:
+					addElement(acc.WorkflowButtonElement)
+				case acc.SelectElement != nil:
+					addElement(acc.SelectElement)
+				case acc.MultiSelectElement != nil:
+					addElement(acc.MultiSelectElement)
+				case acc.OverflowElement != nil:
+					addElement(acc.OverflowElement)
+				case acc.DatePickerElement != nil:
+					addElement(acc.DatePickerElement)
+				case acc.RadioButtonsElement != nil:
+					addElement(acc.RadioButtonsElement)
+				case acc.CheckboxGroupsBlockElement != nil:
+					addElement(acc.CheckboxGroupsBlockElement)
+				}
+			}
+		}
+	}
+
+	walk(blocks)
+	for _, a := range atts {
+		for _, act := range a.Actions {
+			kind := string(act.Type)
+			if kind == "" {
+				kind = "button"
+			}
+			add(kind, act.Text, act.URL)
+		}
+		walk(a.Blocks)
+	}
+	return actions
+}
+
+// optionLabels joins the labels of a menu's options
+func optionLabels(options []*slack.OptionBlockObject) string {
+	labels := make([]string, 0, len(options))
+	for _, o := range options {
+		if o != nil {
+			if t := blockText(o.Text); t != "" {
+				labels = append(labels, t)
+			}
+		}
+	}
+	return strings.Join(labels, " / ")
+}
+
+// addAttachmentInfo records files, attachments, and image alt text on a
+// result entry. Messages that are only a file or unfurl get a short summary
+// in textKey so they don't read as empty.
+func addAttachmentInfo(entry map[string]interface{}, textKey string, files []slack.File, atts []slack.Attachment, blocks slack.Blocks) {
+	if len(files) > 0 {
+		entry["files"] = fileEntries(files)
+	}
+	attachments := attachmentEntries(atts)
+	if len(attachments) > 0 {
+		entry["attachments"] = attachments
+	}
+	alts := imageAltTexts(blocks)
+	if len(alts) > 0 {
+		entry["imageAltText"] = alts
+	}
+	if actions := interactiveActions(blocks, atts); len(actions) > 0 {
+		entry["actions"] = actions
+	}
+
+	if text, _ := entry[textKey].(string); strings.TrimSpace(text) == "" {
+		if summary := attachmentSummary(files, attachments, alts); summary != "" {
+			entry[textKey] = summary
+		}
+	}
+}
+
+// attachmentSummary renders a one-line stand-in for a message with no text
+func attachmentSummary(files []slack.File, attachments []map[string]interface{}, alts []string) string {
+	var parts []string
+	for _, f := range files {
+		name := firstNonEmpty(f.Title, f.Name, f.ID)
+		parts = append(parts, fmt.Sprintf("[file: %s, %s]", name, formatFileSize(f.Size)))
+	}
+	for _, a := range attachments {
+		if title := firstNonEmpty(str(a, "title"), str(a, "text")); title != "" {
+			parts = append(parts, fmt.Sprintf("[attachment: %s]", truncate(title, 100)))
+		}
+	}
+	for _, alt := range alts {
+		parts = append(parts, fmt.Sprintf("[image: %s]", alt))
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatFileSize(size int) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+package features
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestAddAttachmentInfoSummarizesEmptyText(t *testing.T) {
+	entry := map[string]interface{}{"text": ""}
+	files := []slack.File{{ID: "F1", Name: "q3.pdf", Title: "Q3 report", PrettyType: "PDF", Size: 2 << 20}}
+	atts := []slack.Attachment{{Title: "Design doc", TitleLink: "https://example.com/doc"}}
+	blocks := slack.Blocks{BlockSet: []slack.Block{slack.NewImageBlock("https://example.com/a.png", "architecture diagram", "", nil)}}
+
+	addAttachmentInfo(entry, "text", files, atts, blocks)
+
+	want := "[file: Q3 report, 2.0 MB] [attachment: Design doc] [image: architecture diagram]"
+	if got := entry["text"]; got != want {
+		t.Errorf("text = %q, want %q", got, want)
+	}
+	if f := asList(entry["files"]); len(f) != 1 || f[0]["type"] != "PDF" || f[0]["title"] != "Q3 report" {
+		t.Errorf("files = %v", entry["files"])
+	}
+	if a := asList(entry["attachments"]); len(a) != 1 || a[0]["link"] != "https://example.com/doc" {
+		t.Errorf("attachments = %v", entry["attachments"])
+	}
+}
+
+func TestAddAttachmentInfoKeepsText(t *testing.T) {
+	entry := map[string]interface{}{"message": "see attached"}
+	addAttachmentInfo(entry, "message", []slack.File{{ID: "F1", Name: "a.txt"}}, nil, slack.Blocks{})
+
+	if entry["message"] != "see attached" {
+		t.Errorf("message overwritten: %q", entry["message"])
+	}
+	if _, ok := entry["attachments"]; ok {
+		t.Error("empty attachments should be omitted")
+	}
+}
+
+func TestInteractiveActions(t *testing.T) {
+	// A PagerDuty-style message as Slack returns it
+	raw := `{"type":"message","text":"Incident triggered","blocks":[
+		{"type":"section","text":{"type":"mrkdwn","text":"API error rate"},
+		 "accessory":{"type":"overflow","options":[
+			{"text":{"type":"plain_text","text":"Snooze"},"value":"s"},
+			{"text":{"type":"plain_text","text":"Reassign"},"value":"r"}]}},
+		{"type":"actions","elements":[
+			{"type":"button","text":{"type":"plain_text","text":"Acknowledge"},"action_id":"ack"},
+			{"type":"button","text":{"type":"plain_text","text":"View"},"url":"https://example.pagerduty.com/P1"},
+			{"type":"static_select","placeholder":{"type":"plain_text","text":"Set priority"},
+			 "options":[{"text":{"type":"plain_text","text":"P1"},"value":"1"}]}]}],
+		"attachments":[{"text":"Legacy card","actions":[{"name":"resolve","text":"Resolve","type":"button"}]}]}`
+	var msg slack.Msg
+	if err := json.Unmarshal([]byte(raw), &msg); err != nil {
+		t.Fatal(err)
+	}
+
+	entry := map[string]interface{}{"text": msg.Text}
+	addAttachmentInfo(entry, "text", msg.Files, msg.Attachments, msg.Blocks)
+
+	var got []string
+	for _, a := range asList(entry["actions"]) {
+		got = append(got, fmt.Sprintf("%s:%s:%s", a["type"], a["label"], str(a, "url")))
+	}
+	want := []string{
+		"menu:Snooze / Reassign:",
+		"button:Acknowledge:",
+		"button:View:https://example.pagerduty.com/P1",
+		"select:Set priority:",
+		"button:Resolve:",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("actions = %v, want %v", got, want)
+	}
+
+	if line := actionLabels(asList(entry["actions"])); !strings.Contains(line, "[Acknowledge], [View] https://example.pagerduty.com/P1, [Set priority] (select)") {
+		t.Errorf("actionLabels = %q", line)
+	}
+}
@@ -0,0 +1,121 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+// PauseBackgroundRefresh holds off scheduled cache refreshes, e.g. while a
+// bulk job is spending the workspace's rate limit
+var PauseBackgroundRefresh = &Feature{
+	Name:        "pause-background-refresh",
+	Description: "Pause or resume the scheduled channel and user directory refreshes for rate-limit-sensitive periods, and show the refresh schedule",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"duration": map[string]interface{}{
+				"type":        "string",
+				"description": "How long to pause (e.g., '30m', '2h'; max 24h)",
+				"default":     "1h",
+			},
+			"resume": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Resume refreshes now instead of pausing",
+				"default":     false,
+			},
+			"status": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Only report the schedule without changing it",
+				"default":     false,
+			},
+		},
+	},
+	Handler: pauseBackgroundRefreshHandler,
+}
+
+const maxRefreshPause = 24 * time.Hour
+
+func pauseBackgroundRefreshHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	resume, _ := params["resume"].(bool)
+	statusOnly, _ := params["status"].(bool)
+
+	var message string
+	switch {
+	case statusOnly:
+		message = "Background refresh schedule"
+	case resume:
+		apiProvider.PauseBackgroundRefresh(0)
+		message = "Background refresh resumed"
+	default:
+		duration := time.Hour
+		if d, ok := params["duration"].(string); ok && d != "" {
+			parsed, err := time.ParseDuration(d)
+			if err != nil || parsed <= 0 {
+				return &FeatureResult{
+					Success:  false,
+					Message:  fmt.Sprintf("Invalid duration '%s'", d),
+					Guidance: "Use a duration like '30m' or '2h'",
+				}, nil
+			}
+			duration = parsed
+		}
+		if duration > maxRefreshPause {
+			duration = maxRefreshPause
+		}
+		apiProvider.PauseBackgroundRefresh(duration)
+		message = fmt.Sprintf("Background refresh paused for %s", duration)
+	}
+
+	status := apiProvider.BackgroundRefreshStatus()
+	data := map[string]interface{}{
+		"paused":        !status.PausedUntil.IsZero(),
+		"channelsEvery": refreshEvery(status.ChannelsEvery),
+		"usersEvery":    refreshEvery(status.UsersEvery),
+		"skipped":       status.Skipped,
+	}
+	if !status.PausedUntil.IsZero() {
+		data["pausedUntil"] = formatTimestamp(status.PausedUntil)
+	}
+	schedule := map[string]interface{}{}
+	for _, kind := range []string{"channels", "users"} {
+		entry := map[string]interface{}{}
+		if t, ok := status.Last[kind]; ok {
+			entry["last"] = formatTimestamp(t)
+		}
+		if t, ok := status.Next[kind]; ok {
+			entry["next"] = formatTimestamp(t)
+		}
+		schedule[kind] = entry
+	}
+	data["schedule"] = schedule
+
+	result := &FeatureResult{
+		Success: true,
+		Data:    data,
+		Message: message,
+		Guidance: "Scheduled refreshes are also skipped when client.boot shows the workspace unchanged. " +
+			"Set the cadence with SLACK_MCP_REFRESH_CHANNELS and SLACK_MCP_REFRESH_USERS.",
+	}
+	if !status.PausedUntil.IsZero() {
+		result.NextActions = []string{"pause-background-refresh resume=true"}
+	}
+	return result, nil
+}
+
+func refreshEvery(d time.Duration) string {
+	if d == 0 {
+		return "off"
+	}
+	return d.String()
+}
@@ -0,0 +1,239 @@
+package features
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+// maxBatchOperations caps one batch, which runs inside a single tool call's
+// time limit
+const maxBatchOperations = 20
+
+// Tools a batch can't run: itself, and ones that manage credentials or
+// local data rather than act in Slack
+var batchExcluded = map[string]bool{
+	"batch":               true,
+	"auth-setup":          true,
+	"refresh-credentials": true,
+	"purge-local-data":    true,
+}
+
+// NewBatch returns the batch tool, which runs other tools from the registry
+// one after another in a single call
+func NewBatch(registry *Registry) *Feature {
+	return &Feature{
+		Name:        "batch",
+		Description: "Run several tools in order in one call (e.g. mark channels read, react to messages, send a reply), sharing one rate-limit slot, with a consolidated result. Completed operations are not undone if a later one fails.",
+		Schema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"operations": map[string]interface{}{
+					"type": "array",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"tool": map[string]interface{}{
+								"type":        "string",
+								"description": "Tool name, e.g. 'mark-read', 'react', 'send-message'",
+							},
+							"params": map[string]interface{}{
+								"type":        "object",
+								"description": "The tool's parameters",
+							},
+						},
+						"required": []string{"tool"},
+					},
+					"description": fmt.Sprintf("Operations to run in order (max %d), each {tool, params}", maxBatchOperations),
+				},
+				"stopOnError": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Skip the remaining operations after one fails (default: true)",
+					"default":     true,
+				},
+			},
+			"required": []string{"operations"},
+		},
+		Handler: func(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+			return batchHandler(ctx, registry, params)
+		},
+	}
+}
+
+// batchOperation is one parsed entry of a batch
+type batchOperation struct {
+	Tool   string                 `json:"tool"`
+	Params map[string]interface{} `json:"params"`
+}
+
+// parseBatchOperations reads the operations list. Agents sometimes send it
+// JSON-encoded as a string, which is accepted too.
+func parseBatchOperations(v interface{}) ([]batchOperation, error) {
+	if s, ok := v.(string); ok {
+		var items []interface{}
+		if err := json.Unmarshal([]byte(s), &items); err != nil {
+			return nil, fmt.Errorf("operations must be a list of {tool, params}: %v", err)
+		}
+		v = items
+	}
+	items, ok := v.([]interface{})
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("operations must be a non-empty list of {tool, params}")
+	}
+	ops := make([]batchOperation, 0, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("operation %d is not an object", i+1)
+		}
+		op := batchOperation{Params: map[string]interface{}{}}
+		op.Tool, _ = m["tool"].(string)
+		if op.Tool == "" {
+			return nil, fmt.Errorf("operation %d has no tool", i+1)
+		}
+		if p, ok := m["params"].(map[string]interface{}); ok {
+			op.Params = p
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+func batchHandler(ctx context.Context, registry *Registry, params map[string]interface{}) (*FeatureResult, error) {
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	ops, err := parseBatchOperations(params["operations"])
+	if err != nil {
+		return &FeatureResult{
+			Success:  false,
+			Message:  err.Error(),
+			Guidance: "Example: operations=[{tool:'mark-read', params:{channel:'#general'}}, {tool:'react', params:{channel:'#eng', timestamp:'1700000000.000100', reaction:'eyes'}}]",
+		}, nil
+	}
+	if len(ops) > maxBatchOperations {
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("A batch runs at most %d operations, got %d", maxBatchOperations, len(ops)),
+			Guidance: "Split the work into several batches",
+		}, nil
+	}
+
+	// Check every tool up front so a typo doesn't leave the batch half done
+	for i, op := range ops {
+		if _, ok := registry.Get(op.Tool); !ok || batchExcluded[op.Tool] {
+			return &FeatureResult{
+				Success:  false,
+				Message:  fmt.Sprintf("Operation %d: '%s' can't be run in a batch", i+1, op.Tool),
+				Guidance: "Nothing was run. Use the tool names from the tool list; batch can't run auth-setup, refresh-credentials, purge-local-data, or itself.",
+			}, nil
+		}
+	}
+
+	stopOnError := true
+	if s, ok := params["stopOnError"].(bool); ok {
+		stopOnError = s
+	}
+
+	results := make([]map[string]interface{}, 0, len(ops))
+	succeeded, failed := 0, 0
+	stopped := false
+	for i, op := range ops {
+		entry := map[string]interface{}{
+			"index": i + 1,
+			"tool":  op.Tool,
+		}
+		if stopped || ctx.Err() != nil {
+			entry["status"] = "skipped"
+			results = append(results, entry)
+			continue
+		}
+
+		feature, _ := registry.Get(op.Tool)
+		opParams := make(map[string]interface{}, len(op.Params)+1)
+		for k, v := range op.Params {
+			if !strings.HasPrefix(k, "_") {
+				opParams[k] = v
+			}
+		}
+		opParams["_provider"] = apiProvider
+		apiProvider.RecordToolCall(op.Tool)
+		Depseudonymize(apiProvider, opParams)
+
+		result := TokenUnsupported(apiProvider, op.Tool)
+		if result == nil {
+			result, err = runBatchOperation(ctx, feature, opParams)
+			if err != nil {
+				result = &FeatureResult{Success: false, Message: err.Error()}
+			}
+		}
+		if result.Success {
+			succeeded++
+			entry["status"] = "ok"
+		} else {
+			failed++
+			entry["status"] = "failed"
+			stopped = stopOnError
+		}
+		entry["message"] = result.Message
+		entry["output"] = FormatResult(op.Tool, RedactResult(op.Tool, result))
+		results = append(results, entry)
+	}
+
+	skipped := len(ops) - succeeded - failed
+	result := &FeatureResult{
+		Success: failed == 0 && skipped == 0,
+		Message: fmt.Sprintf("Batch: %d of %d operations succeeded", succeeded, len(ops)),
+		Data: map[string]interface{}{
+			"operations": results,
+			"succeeded":  succeeded,
+			"failed":     failed,
+			"skipped":    skipped,
+		},
+		ResultCount: len(ops),
+	}
+	switch {
+	case skipped > 0 && ctx.Err() != nil:
+		result.Guidance = fmt.Sprintf("⏱️ Ran out of time; %d operations were skipped. Run them in another batch.", skipped)
+	case skipped > 0:
+		result.Guidance = fmt.Sprintf("Stopped at the first failure; %d operations were skipped. Earlier operations were not undone. Fix the failure and batch the rest.", skipped)
+	case failed > 0:
+		result.Guidance = "Some operations failed; the others went ahead"
+	}
+	return result, nil
+}
+
+// BatchActed reports whether a batch result includes operations that went
+// ahead, so it's remembered under an idempotency key even though the batch
+// as a whole failed
+func BatchActed(result *FeatureResult) bool {
+	data, ok := result.Data.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	succeeded, _ := data["succeeded"].(int)
+	return succeeded > 0
+}
+
+// runBatchOperation runs one tool, turning a panic into a failed result
+// so one bad operation doesn't take the batch down
+func runBatchOperation(ctx context.Context, feature *Feature, params map[string]interface{}) (result *FeatureResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("%s failed: %v", feature.Name, r)
+		}
+	}()
+	result, err = feature.Handler(ctx, params)
+	if err == nil && result == nil {
+		err = fmt.Errorf("%s returned no result", feature.Name)
+	}
+	return result, err
+}
@@ -0,0 +1,86 @@
+package features
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+func testBatchRegistry(calls *[]string) *Registry {
+	r := NewRegistry()
+	for _, name := range []string{"mark-read", "react", "send-message"} {
+		name := name
+		r.Register(&Feature{Name: name, Handler: func(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+			*calls = append(*calls, name)
+			if params["fail"] == true {
+				return &FeatureResult{Success: false, Message: name + " failed"}, nil
+			}
+			if _, ok := params["_provider"].(*provider.ApiProvider); !ok {
+				return &FeatureResult{Success: false, Message: "no provider"}, nil
+			}
+			return &FeatureResult{Success: true, Message: name + " done"}, nil
+		}})
+	}
+	r.Register(NewBatch(r))
+	return r
+}
+
+func runTestBatch(t *testing.T, r *Registry, params map[string]interface{}) *FeatureResult {
+	t.Helper()
+	batch, _ := r.Get("batch")
+	params["_provider"] = &provider.ApiProvider{}
+	result, err := batch.Handler(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestBatchRunsInOrder(t *testing.T) {
+	var calls []string
+	r := testBatchRegistry(&calls)
+	result := runTestBatch(t, r, map[string]interface{}{
+		"operations": `[{"tool":"mark-read","params":{"channel":"#a"}},{"tool":"react"},{"tool":"send-message"}]`,
+	})
+	if !result.Success || len(calls) != 3 || calls[0] != "mark-read" || calls[2] != "send-message" {
+		t.Fatalf("success = %v, calls = %v", result.Success, calls)
+	}
+}
+
+func TestBatchStopsOnError(t *testing.T) {
+	var calls []string
+	r := testBatchRegistry(&calls)
+	ops := []interface{}{
+		map[string]interface{}{"tool": "mark-read"},
+		map[string]interface{}{"tool": "react", "params": map[string]interface{}{"fail": true}},
+		map[string]interface{}{"tool": "send-message"},
+	}
+	result := runTestBatch(t, r, map[string]interface{}{"operations": ops})
+	data := result.Data.(map[string]interface{})
+	if result.Success || len(calls) != 2 || data["skipped"] != 1 {
+		t.Fatalf("success = %v, calls = %v, data = %v", result.Success, calls, data)
+	}
+
+	calls = nil
+	result = runTestBatch(t, r, map[string]interface{}{"operations": ops, "stopOnError": false})
+	if data := result.Data.(map[string]interface{}); len(calls) != 3 || data["failed"] != 1 || data["skipped"] != 0 {
+		t.Fatalf("calls = %v, data = %v", calls, data)
+	}
+}
+
+func TestBatchRejectsUnknownToolsUpFront(t *testing.T) {
+	var calls []string
+	r := testBatchRegistry(&calls)
+	for _, tool := range []string{"no-such-tool", "batch"} {
+		result := runTestBatch(t, r, map[string]interface{}{
+			"operations": []interface{}{
+				map[string]interface{}{"tool": "mark-read"},
+				map[string]interface{}{"tool": tool},
+			},
+		})
+		if result.Success || len(calls) != 0 {
+			t.Fatalf("%s: success = %v, calls = %v", tool, result.Success, calls)
+		}
+	}
+}
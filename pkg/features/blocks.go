@@ -0,0 +1,105 @@
+package features
+
+import (
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// flattenBlocks renders Block Kit content as plain text: headers, section
+// text and fields, context lines, and image alt text. Rich text blocks are
+// skipped because they duplicate the message's own text.
+func flattenBlocks(blocks slack.Blocks) string {
+	var lines []string
+	for _, block := range blocks.BlockSet {
+		switch b := block.(type) {
+		case *slack.HeaderBlock:
+			if t := blockText(b.Text); t != "" {
+				lines = append(lines, "*"+t+"*")
+			}
+		case *slack.SectionBlock:
+			if t := blockText(b.Text); t != "" {
+				lines = append(lines, t)
+			}
+			for _, f := range b.Fields {
+				if t := blockText(f); t != "" {
+					lines = append(lines, "• "+t)
+				}
+			}
+		case *slack.ContextBlock:
+			var parts []string
+			for _, el := range b.ContextElements.Elements {
+				switch e := el.(type) {
+				case *slack.TextBlockObject:
+					if t := blockText(e); t != "" {
+						parts = append(parts, t)
+					}
+				case *slack.ImageBlockElement:
+					if e.AltText != "" {
+						parts = append(parts, e.AltText)
+					}
+				}
+			}
+			if len(parts) > 0 {
+				lines = append(lines, strings.Join(parts, " · "))
+			}
+		case *slack.ImageBlock:
+			if b.AltText != "" {
+				lines = append(lines, "[image: "+b.AltText+"]")
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// flattenAttachmentContent renders the structured parts of legacy
+// attachments that attachmentEntries doesn't carry: fields and nested blocks
+func flattenAttachmentContent(atts []slack.Attachment) string {
+	var lines []string
+	for _, a := range atts {
+		for _, f := range a.Fields {
+			switch {
+			case f.Title != "" && f.Value != "":
+				lines = append(lines, "• "+f.Title+": "+f.Value)
+			case f.Value != "":
+				lines = append(lines, "• "+f.Value)
+			}
+		}
+		if t := flattenBlocks(a.Blocks); t != "" {
+			lines = append(lines, t)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// messageBody combines a message's text with its flattened blocks and
+// attachment content. Bot messages often carry only a fallback summary in
+// text, so block content is appended unless it merely repeats it.
+func messageBody(text string, blocks slack.Blocks, atts []slack.Attachment) string {
+	rendered := flattenBlocks(blocks)
+	if extra := flattenAttachmentContent(atts); extra != "" {
+		if rendered != "" {
+			rendered += "\n"
+		}
+		rendered += extra
+	}
+
+	text = strings.TrimSpace(text)
+	switch {
+	case rendered == "":
+		return text
+	case text == "" || strings.Contains(rendered, text):
+		return rendered
+	case strings.Contains(text, rendered):
+		return text
+	default:
+		return text + "\n" + rendered
+	}
+}
+
+func blockText(t *slack.TextBlockObject) string {
+	if t == nil {
+		return ""
+	}
+	return strings.TrimSpace(t.Text)
+}
@@ -0,0 +1,54 @@
+package features
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestFlattenBlocks(t *testing.T) {
+	blocks := slack.Blocks{BlockSet: []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, "Deploy finished", false, false)),
+		slack.NewSectionBlock(
+			slack.NewTextBlockObject(slack.MarkdownType, "*api* rolled out to prod", false, false),
+			[]*slack.TextBlockObject{
+				slack.NewTextBlockObject(slack.MarkdownType, "*Version:* 1.4.2", false, false),
+				slack.NewTextBlockObject(slack.MarkdownType, "*Duration:* 4m", false, false),
+			}, nil),
+		slack.NewDividerBlock(),
+		slack.NewContextBlock("",
+			slack.NewImageBlockElement("https://example.com/ci.png", "CI"),
+			slack.NewTextBlockObject(slack.MarkdownType, "triggered by deploy-bot", false, false)),
+	}}
+
+	want := "*Deploy finished*\n*api* rolled out to prod\n• *Version:* 1.4.2\n• *Duration:* 4m\nCI · triggered by deploy-bot"
+	if got := flattenBlocks(blocks); got != want {
+		t.Errorf("flattenBlocks =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMessageBody(t *testing.T) {
+	section := slack.Blocks{BlockSet: []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, "Build #42 failed", false, false), nil, nil),
+	}}
+	atts := []slack.Attachment{{Fields: []slack.AttachmentField{{Title: "Branch", Value: "main"}}}}
+
+	cases := []struct {
+		name   string
+		text   string
+		blocks slack.Blocks
+		atts   []slack.Attachment
+		want   string
+	}{
+		{"plain text", "hello", slack.Blocks{}, nil, "hello"},
+		{"blocks only", "", section, nil, "Build #42 failed"},
+		{"fallback repeated in blocks", "Build #42 failed", section, nil, "Build #42 failed"},
+		{"fallback differs", "New alert", section, nil, "New alert\nBuild #42 failed"},
+		{"attachment fields", "", section, atts, "Build #42 failed\n• Branch: main"},
+	}
+	for _, c := range cases {
+		if got := messageBody(c.text, c.blocks, c.atts); got != c.want {
+			t.Errorf("%s: messageBody = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
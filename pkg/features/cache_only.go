@@ -0,0 +1,210 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+// useCacheOnly reports whether a read tool should answer from cached data
+// alone: when the caller asks for it, or while the provider is still
+// booting so the first call isn't stuck behind auth and cache warm-up
+func useCacheOnly(params map[string]interface{}, ap *provider.ApiProvider) bool {
+	if c, ok := params["cacheOnly"].(bool); ok && c {
+		return true
+	}
+	return ap.Booting()
+}
+
+// markStale flags a result as served from cache
+func markStale(result *FeatureResult, cachedAt time.Time) {
+	if data, ok := result.Data.(map[string]interface{}); ok {
+		data["stale"] = true
+		if !cachedAt.IsZero() {
+			data["cachedAt"] = formatTimestamp(cachedAt)
+		}
+	}
+	note := "⏳ Answered from cached data without calling Slack; it may be out of date."
+	if result.Guidance != "" {
+		note += " " + result.Guidance
+	}
+	result.Guidance = note
+}
+
+// checkUnreadsFromCache summarizes the last client.counts snapshot using
+// cached channel and user names. No message text is available this way.
+func checkUnreadsFromCache(ctx context.Context, ap *provider.ApiProvider, focus string, includeChannels bool, limit int, section string) *FeatureResult {
+	counts, fetchedAt := ap.CachedClientCounts()
+	if counts == nil {
+		return &FeatureResult{
+			Success:     false,
+			Message:     "No cached unread counts yet",
+			Guidance:    "Unread counts are cached after the first live check-unreads. Retry without cacheOnly once Slack is connected.",
+			NextActions: []string{"check-unreads"},
+		}
+	}
+	if section != "" {
+		sidebar, idx, failed := loadSidebar(ctx, ap, section, true)
+		if failed != nil {
+			return failed
+		}
+		counts = countsInSection(counts, sidebar, idx)
+	}
+	starred := ap.CachedStarredConversations()
+	counts = starredFirst(counts, starred)
+
+	usersMap := ap.ProvideUsersMap()
+	cached := map[string]string{}
+	dmUsers := map[string]string{}
+	for _, ch := range ap.GetCachedChannels() {
+		cached[ch.ID] = ch.Name
+		if ch.IsIM {
+			dmUsers[ch.ID] = ch.User
+		}
+	}
+	nameOf := func(id string) string {
+		if name := cached[id]; name != "" {
+			return name
+		}
+		return id
+	}
+
+	dms := []map[string]interface{}{}
+	mentions := []map[string]interface{}{}
+	channels := []map[string]interface{}{}
+	appDMs := []map[string]interface{}{}
+	stats := map[string]interface{}{
+		"totalDMs":             0,
+		"totalMentions":        0,
+		"totalChannels":        0,
+		"totalChannelMessages": 0,
+		"totalAppDMs":          0,
+		"urgent":               0,
+	}
+
+	// App DM content isn't cached, only which ones have unreads
+	if focus == "app-dms" {
+		for _, im := range counts.IMs {
+			userID := dmUsers[im.ID]
+			if !im.HasUnreads || !isAppUser(userID, usersMap) {
+				continue
+			}
+			stats["totalAppDMs"] = stats["totalAppDMs"].(int) + 1
+			if len(appDMs) < limit {
+				appDMs = append(appDMs, map[string]interface{}{
+					"type":      "app-dm",
+					"app":       appName(userID, usersMap),
+					"channelId": im.ID,
+				})
+			}
+		}
+	}
+
+	if focus == "all" || focus == "dms" {
+		for _, im := range counts.IMs {
+			if !im.HasUnreads {
+				continue
+			}
+			stats["totalDMs"] = stats["totalDMs"].(int) + 1
+			if len(dms) >= limit {
+				continue
+			}
+			author := im.ID
+			if userID := dmUsers[im.ID]; userID != "" {
+				author = getUserName(userID, usersMap)
+			}
+			unread := im.MentionCount
+			if unread == 0 {
+				unread = 1
+			}
+			dms = append(dms, map[string]interface{}{
+				"type":        "dm",
+				"author":      author,
+				"unreadCount": unread,
+				"channelId":   im.ID,
+			})
+		}
+	}
+
+	type unreadChannel struct {
+		id       string
+		mentions int
+		unread   bool
+	}
+	var all []unreadChannel
+	for _, ch := range counts.Channels {
+		all = append(all, unreadChannel{ch.ID, ch.MentionCount, ch.HasUnreads})
+	}
+	for _, ch := range counts.MPIMs {
+		all = append(all, unreadChannel{ch.ID, ch.MentionCount, ch.HasUnreads})
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		if starred[all[i].id] != starred[all[j].id] {
+			return starred[all[i].id]
+		}
+		return all[i].mentions > all[j].mentions
+	})
+
+	for _, ch := range all {
+		if ch.mentions > 0 && (focus == "all" || focus == "mentions") {
+			stats["totalMentions"] = stats["totalMentions"].(int) + ch.mentions
+			if len(mentions) < limit {
+				mentions = append(mentions, map[string]interface{}{
+					"channel":   nameOf(ch.id),
+					"channelId": ch.id,
+					"message":   fmt.Sprintf("%d mention(s)", ch.mentions),
+				})
+			}
+		}
+		if ch.unread && (focus == "channels" || (focus == "all" && includeChannels)) {
+			stats["totalChannels"] = stats["totalChannels"].(int) + 1
+			if len(channels) < limit {
+				channels = append(channels, map[string]interface{}{
+					"channel":     nameOf(ch.id),
+					"channelId":   ch.id,
+					"lastMessage": "(unread)",
+				})
+			}
+		}
+	}
+
+	markStarred(dms, starred)
+	markStarred(appDMs, starred)
+	markStarred(mentions, starred)
+	markStarred(channels, starred)
+
+	data := map[string]interface{}{
+		"unreads": map[string]interface{}{
+			"dms":      dms,
+			"mentions": mentions,
+			"channels": channels,
+			"appDMs":   appDMs,
+		},
+		"stats": stats,
+	}
+	if counts.Threads.HasUnreads {
+		data["threadUnreads"] = map[string]interface{}{
+			"total":    counts.Threads.UnreadCount,
+			"mentions": counts.Threads.MentionCount,
+		}
+	}
+
+	result := &FeatureResult{
+		Success: true,
+		Data:    data,
+		Message: fmt.Sprintf("Cached unreads: %d DMs, %d mentions, %d channels",
+			stats["totalDMs"], stats["totalMentions"], stats["totalChannels"]),
+		ResultCount: len(dms) + len(mentions) + len(channels),
+		NextActions: []string{"check-unreads"},
+	}
+	if focus == "app-dms" {
+		result.Message = fmt.Sprintf("Cached unreads: %d app DMs", stats["totalAppDMs"])
+		result.ResultCount = len(appDMs)
+		result.NextActions = []string{"check-unreads focus='app-dms'"}
+	}
+	markStale(result, fetchedAt)
+	return result
+}
@@ -0,0 +1,30 @@
+package features
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarkStale(t *testing.T) {
+	result := &FeatureResult{
+		Success:  true,
+		Data:     map[string]interface{}{"channels": []map[string]interface{}{}},
+		Guidance: "✅ Showing channels from recent cache",
+	}
+	markStale(result, time.Date(2024, 3, 1, 9, 30, 0, 0, time.Local))
+
+	data := result.Data.(map[string]interface{})
+	if stale, _ := data["stale"].(bool); !stale {
+		t.Error("expected stale=true")
+	}
+	if data["cachedAt"] == nil {
+		t.Error("expected cachedAt")
+	}
+	if !strings.HasPrefix(result.Guidance, "⏳") || !strings.Contains(result.Guidance, "recent cache") {
+		t.Errorf("guidance should lead with the stale note and keep the original: %q", result.Guidance)
+	}
+	if notice := staleNotice(data); !strings.Contains(notice, "may be stale") {
+		t.Errorf("formatter notice missing: %q", notice)
+	}
+}
@@ -0,0 +1,209 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/embeddings"
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/aaronsb/slack-mcp/pkg/tickets"
+	"github.com/slack-go/slack"
+)
+
+// Capabilities reports which tools work with the current token and
+// configuration, so an agent can plan around gaps instead of hitting them
+var Capabilities = &Feature{
+	Name:        "capabilities",
+	Description: "Check which features work with the current token and configuration (internal endpoints, search, posting, semantic search, tickets) before planning multi-step work",
+	Schema: map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	},
+	Handler: capabilitiesHandler,
+}
+
+// capability is one thing a group of tools depends on
+type capability struct {
+	Name      string
+	Available bool
+	Detail    string
+	Tools     []string
+}
+
+// Tools that depend on each capability; anything not listed only needs
+// the basic read access every token has
+var capabilityTools = map[string][]string{
+	"internal": {"check-unreads", "recent-activity-timeline", "list-my-threads", "mark-read", "debug-internal"},
+	"search":   {"search", "check-saved-searches", "topic-timeline", "find-expert", "suggest-channel", "rank-my-channels", "check-reactions-to-me"},
+	"post":     {"send-message", "revise-draft", "post-snippet", "react", "quick-respond", "send-nudge", "log-decision"},
+	"semantic": {"search-semantic"},
+	"tickets":  {"create-ticket-from-thread"},
+}
+
+func capabilitiesHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	client, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Slack isn't reachable with the current credentials: %v", err),
+			Guidance: "Run auth-setup to refresh the tokens; nothing else will work until it succeeds",
+		}, nil
+	}
+	tokenType := apiProvider.TokenType()
+
+	caps := []capability{
+		probeInternal(ctx, apiProvider, tokenType),
+		probeSearch(ctx, client, tokenType),
+		postingCapability(tokenType, loadQuietHours(), time.Now()),
+		configCapability("semantic", embeddings.FromEnv() != nil, "SLACK_MCP_EMBEDDINGS_URL"),
+		configCapability("tickets", tickets.FromEnv() != nil, "SLACK_MCP_TICKET_WEBHOOK or SLACK_MCP_TICKET_COMMAND"),
+	}
+	unavailable := unavailableTools(caps)
+	capList := make([]map[string]interface{}, 0, len(caps))
+	for _, c := range caps {
+		capList = append(capList, map[string]interface{}{
+			"name":      c.Name,
+			"available": c.Available,
+			"detail":    c.Detail,
+			"tools":     c.Tools,
+		})
+	}
+
+	data := map[string]interface{}{
+		"tokenType":        tokenType,
+		"capabilities":     capList,
+		"unavailableTools": unavailable,
+	}
+	if self, err := apiProvider.Self(); err == nil {
+		data["user"] = self.User
+		data["team"] = self.Team
+	}
+
+	guidance := "✅ Every tool is usable with this setup"
+	if len(unavailable) > 0 {
+		guidance = fmt.Sprintf("Plan around %d unavailable tools; read-messages, read-thread, and catch-up still work", len(unavailable))
+	}
+	return &FeatureResult{
+		Success:  true,
+		Message:  fmt.Sprintf("%d of %d capabilities available (%s token)", len(caps)-countUnavailable(caps), len(caps), tokenType),
+		Data:     data,
+		Guidance: guidance,
+	}, nil
+}
+
+// TokenUnsupported returns the refusal for a tool the provider's token
+// can't run, or nil. Bot tokens are refused by search.messages outright, so
+// search-backed tools are turned away before spending a call.
+func TokenUnsupported(ap *provider.ApiProvider, tool string) *FeatureResult {
+	if ap == nil || ap.TokenType() != "bot" {
+		return nil
+	}
+	for _, name := range capabilityTools["search"] {
+		if name == tool {
+			return &FeatureResult{
+				Success:  false,
+				Message:  fmt.Sprintf("%s needs search, which Slack doesn't allow for bot tokens", tool),
+				Guidance: "Use read-messages or catch-up on specific channels instead, or configure a user (xoxp) token",
+			}
+		}
+	}
+	return nil
+}
+
+// probeInternal calls client.counts, the cheapest internal endpoint.
+// Only browser session tokens are accepted there.
+func probeInternal(ctx context.Context, ap *provider.ApiProvider, tokenType string) capability {
+	c := capability{Name: "internal", Tools: capabilityTools["internal"]}
+	if tokenType != "session" {
+		c.Detail = "internal endpoints need a browser session (xoxc) token; check-unreads and mark-read use the official API"
+		return c
+	}
+	counts, err := ap.ProvideInternalClient().GetClientCounts(ctx)
+	switch {
+	case err != nil:
+		c.Detail = fmt.Sprintf("client.counts failed: %v", err)
+	case !counts.OK:
+		c.Detail = fmt.Sprintf("client.counts refused: %s", counts.Error)
+	default:
+		c.Available = true
+		c.Detail = "unread counts and internal mark endpoints available"
+	}
+	return c
+}
+
+// probeSearch runs a one-result search; bot tokens and some workspace
+// policies are refused
+func probeSearch(ctx context.Context, client *slack.Client, tokenType string) capability {
+	c := capability{Name: "search", Tools: capabilityTools["search"]}
+	if tokenType == "bot" {
+		c.Detail = "bot tokens can't search; check-mentions scans channel history instead"
+		return c
+	}
+	sp := slack.NewSearchParameters()
+	sp.Count = 1
+	if _, err := client.SearchMessagesContext(ctx, "after:"+time.Now().AddDate(0, 0, -1).Format("2006-01-02"), sp); err != nil {
+		c.Detail = fmt.Sprintf("search.messages failed: %v", err)
+		return c
+	}
+	c.Available = true
+	c.Detail = "search.messages allowed"
+	return c
+}
+
+// postingCapability can't be probed without posting, so it goes by token
+// type and quiet hours
+func postingCapability(tokenType string, q *quietHours, now time.Time) capability {
+	c := capability{Name: "post", Available: true, Tools: capabilityTools["post"]}
+	switch {
+	case tokenType == "bot":
+		c.Detail = "posts as the bot, only in channels it has joined"
+	case q != nil && q.active(now) && q.mode == quietModeSchedule:
+		c.Detail = fmt.Sprintf("quiet hours (%s): messages are scheduled for %s", q.spec, q.endAfter(now).Format("Mon 15:04"))
+	case q != nil && q.active(now):
+		c.Available = false
+		c.Detail = fmt.Sprintf("quiet hours (%s): posting blocked until %s", q.spec, q.endAfter(now).Format("Mon 15:04"))
+	default:
+		c.Detail = "posts as you"
+	}
+	return c
+}
+
+// configCapability reports an optional integration that's on when set
+func configCapability(name string, configured bool, setting string) capability {
+	c := capability{Name: name, Available: configured, Tools: capabilityTools[name]}
+	if configured {
+		c.Detail = "configured"
+	} else {
+		c.Detail = "not configured; set " + setting
+	}
+	return c
+}
+
+func unavailableTools(caps []capability) []string {
+	tools := []string{}
+	for _, c := range caps {
+		if !c.Available {
+			tools = append(tools, c.Tools...)
+		}
+	}
+	return tools
+}
+
+func countUnavailable(caps []capability) int {
+	n := 0
+	for _, c := range caps {
+		if !c.Available {
+			n++
+		}
+	}
+	return n
+}
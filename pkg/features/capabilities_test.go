@@ -0,0 +1,73 @@
+package features
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+func TestPostingCapability(t *testing.T) {
+	night := time.Date(2026, 3, 10, 23, 0, 0, 0, time.Local)
+	blocking, err := parseQuietHours("22:00-07:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	scheduling := *blocking
+	scheduling.mode = quietModeSchedule
+
+	tests := []struct {
+		name      string
+		tokenType string
+		q         *quietHours
+		want      bool
+	}{
+		{"no quiet hours", "session", nil, true},
+		{"blocked", "session", blocking, false},
+		{"scheduled", "session", &scheduling, true},
+		{"bot ignores quiet hours", "bot", blocking, true},
+	}
+	for _, tt := range tests {
+		if got := postingCapability(tt.tokenType, tt.q, night); got.Available != tt.want {
+			t.Errorf("%s: available = %v, want %v (%s)", tt.name, got.Available, tt.want, got.Detail)
+		}
+	}
+}
+
+func TestUnavailableTools(t *testing.T) {
+	caps := []capability{
+		{Name: "search", Available: true, Tools: []string{"search"}},
+		configCapability("semantic", false, "SLACK_MCP_EMBEDDINGS_URL"),
+		configCapability("tickets", true, "SLACK_MCP_TICKET_WEBHOOK"),
+	}
+	if got := unavailableTools(caps); !reflect.DeepEqual(got, []string{"search-semantic"}) {
+		t.Errorf("unavailableTools = %v", got)
+	}
+	if n := countUnavailable(caps); n != 1 {
+		t.Errorf("countUnavailable = %d, want 1", n)
+	}
+}
+
+func TestTokenUnsupported(t *testing.T) {
+	t.Setenv("SLACK_MCP_DATA_DIR", t.TempDir())
+
+	bot := provider.NewWithTokens("xoxb-test", "")
+	if bot.ProvideInternalClient() != nil {
+		t.Error("bot token got an internal client")
+	}
+	if TokenUnsupported(bot, "search") == nil {
+		t.Error("search allowed for a bot token")
+	}
+	if r := TokenUnsupported(bot, "check-mentions"); r != nil {
+		t.Errorf("check-mentions refused for a bot token: %s", r.Message)
+	}
+
+	user := provider.NewWithTokens("xoxp-test", "")
+	if TokenUnsupported(user, "search") != nil {
+		t.Error("search refused for a user token")
+	}
+	if TokenUnsupported(nil, "search") != nil {
+		t.Error("search refused without a provider")
+	}
+}
@@ -16,11 +16,11 @@ var CatchUpOnChannel = &Feature{
 		"properties": map[string]interface{}{
 			"channel": map[string]interface{}{
 				"type":        "string",
-				"description": "Channel name (e.g., 'general' or 'engineering') or ID",
+				"description": "Channel name (e.g., 'general' or 'engineering'), ID, or a person ('@alice' or their name) for your DM with them",
 			},
 			"since": map[string]interface{}{
 				"type":        "string",
-				"description": "Time period to check (e.g., '1h', '6h', '1d', '3d') or specific time",
+				"description": "Time period to check (e.g., '1h', '6h', '1d', '3d') or specific time. With meetings from set-meetings: 'last-meeting' (since your last meeting ended) or 'during:2pm' / 'during:standup'",
 				"default":     "1d",
 			},
 			"focus": map[string]interface{}{
@@ -35,9 +35,34 @@ var CatchUpOnChannel = &Feature{
 			},
 			"limit": map[string]interface{}{
 				"type":        "number",
-				"description": "Maximum number of items to return (default: 20, max: 50)",
-				"default":     20,
+				"description": "Messages per page (max: 50). Leave unset to size pages from the channel's volume: one call for a quiet channel, larger and more pages for a busy one",
 			},
+			"expandThreads": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Fetch replies of busy threads and summarize each (participants, first/last message, decision) instead of showing only the root",
+				"default":     false,
+			},
+			"workflowMessages": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"important", "normal", "skip"},
+				"description": "How to treat Workflow Builder messages: always surface them, score them like other messages, or leave them out (default: SLACK_MCP_WORKFLOW_MESSAGES or normal)",
+			},
+			"includeArchived": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Allow catching up on an archived channel (excluded by default)",
+				"default":     false,
+			},
+			"joinIfNeeded": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Join a public channel you're not in when its history can't be read otherwise (members will see you join)",
+				"default":     false,
+			},
+			"leaveAfter": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Leave again after reading, when joinIfNeeded joined the channel",
+				"default":     false,
+			},
+			"estimate": estimateParam,
 		},
 		"required": []string{"channel"},
 	},
@@ -139,8 +164,13 @@ func parseTimePeriod(period string) (time.Time, error) {
 
 // Helper to identify important messages
 func isImportantMessage(msg slack.Message) bool {
-	// Any file attachment — users almost always want to know about these
-	if len(msg.Files) > 0 {
+	// Any file share — users almost always want to know about these
+	if len(msg.Files) > 0 || msg.SubType == "file_share" {
+		return true
+	}
+
+	// Text-less messages whose content lives in attachments or blocks
+	if msg.Text == "" && (len(msg.Attachments) > 0 || flattenBlocks(msg.Blocks) != "") {
 		return true
 	}
 
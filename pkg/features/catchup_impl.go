@@ -2,10 +2,13 @@ package features
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/aaronsb/slack-mcp/pkg/provider"
 	"github.com/slack-go/slack"
+	"log"
 	"strings"
+	"time"
 )
 
 // catchUpHandlerImpl provides the real implementation with auto-pagination for recent timeframes
@@ -23,7 +26,9 @@ func catchUpHandlerImpl(ctx context.Context, params map[string]interface{}) (*Fe
 	}
 
 	limit := 20
-	if l, ok := params["limit"].(float64); ok {
+	l, limited := params["limit"].(float64)
+	adaptive := !limited
+	if limited {
 		limit = int(l)
 		if limit > 50 {
 			limit = 50
@@ -33,8 +38,24 @@ func catchUpHandlerImpl(ctx context.Context, params map[string]interface{}) (*Fe
 		}
 	}
 
+	expandThreads := false
+	if e, ok := params["expandThreads"].(bool); ok {
+		expandThreads = e
+	}
+
+	workflowOverride, _ := params["workflowMessages"].(string)
+	workflows := workflowPolicy(workflowOverride)
+
+	includeArchived := false
+	if a, ok := params["includeArchived"].(bool); ok {
+		includeArchived = a
+	}
+
+	joinIfNeeded, _ := params["joinIfNeeded"].(bool)
+	leaveAfter, _ := params["leaveAfter"].(bool)
+
 	// Get the API provider
-	provider, ok := params["_provider"].(*provider.ApiProvider)
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
 	if !ok {
 		return &FeatureResult{
 			Success: false,
@@ -43,7 +64,7 @@ func catchUpHandlerImpl(ctx context.Context, params map[string]interface{}) (*Fe
 	}
 
 	// Get Slack API client
-	api, err := provider.Provide()
+	api, err := apiProvider.Provide()
 	if err != nil {
 		return &FeatureResult{
 			Success: false,
@@ -51,33 +72,92 @@ func catchUpHandlerImpl(ctx context.Context, params map[string]interface{}) (*Fe
 		}, nil
 	}
 
-	// Parse time period to get oldest timestamp
-	oldest, err := parseTimePeriod(since)
-	if err != nil {
-		return &FeatureResult{
-			Success: false,
-			Message: fmt.Sprintf("Invalid time period: %v", err),
-		}, nil
+	// Parse time period to get oldest timestamp. Calendar-relative periods
+	// ("last-meeting", "during:2pm") use the windows from set-meetings and
+	// may also bound the newest message.
+	recent := isRecentTimeframe(since)
+	period := "the last " + since
+	meetingsMu.Lock()
+	meetings := loadMeetings(apiProvider)
+	meetingsMu.Unlock()
+	oldest, latest, label, calendar, err := meetingSince(since, meetings, time.Now())
+	if calendar {
+		if err != nil {
+			return &FeatureResult{
+				Success:  false,
+				Message:  fmt.Sprintf("Can't resolve '%s': %v", since, err),
+				Guidance: "Supply meeting windows with set-meetings first, or use a period like '2h'",
+			}, nil
+		}
+		recent = true
+		period = label
+	} else {
+		oldest, err = parseTimePeriod(since)
+		if err != nil {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Invalid time period: %v", err),
+			}, nil
+		}
 	}
 
 	// Find channel by name using provider's cache or use ID directly
 	cleanName := strings.TrimPrefix(channel, "#")
-	channelID := provider.ResolveChannelID(cleanName)
+	channelID := apiProvider.ResolveChannelID(cleanName)
+
+	// Archived channels are often missing from the cache; look them up on request
+	if channelID == cleanName && !isChannelID(channelID) && includeArchived {
+		if ch, err := apiProvider.FindChannelIncludingArchived(ctx, cleanName); err == nil {
+			channelID = ch.ID
+		}
+	}
 
 	// If the resolved ID is the same as input, it means the channel wasn't found in cache
 	if channelID == cleanName && !strings.HasPrefix(channelID, "C") && !strings.HasPrefix(channelID, "D") && !strings.HasPrefix(channelID, "G") {
-		return &FeatureResult{
+		result := &FeatureResult{
 			Success: false,
 			Message: fmt.Sprintf("Channel '%s' not found. Use list-channels to see available channels.", channel),
-		}, nil
+		}
+		if !includeArchived {
+			result.Guidance = "If the channel was archived, retry with includeArchived=true"
+		}
+		return result, nil
+	}
+
+	archived := false
+	channelName := cleanName
+	info, err := apiProvider.GetChannelInfo(ctx, channelID)
+	if err == nil && info.Name != "" {
+		channelName = info.Name
+	}
+	if err == nil && info.IsArchived {
+		if !includeArchived {
+			return &FeatureResult{
+				Success:  false,
+				Message:  fmt.Sprintf("#%s is archived", cleanName),
+				Guidance: "Archived channels are excluded by default. Retry with includeArchived=true to read its history.",
+				NextActions: []string{
+					fmt.Sprintf("catch-up channel='%s' since='%s' includeArchived=true", channel, since),
+				},
+			}, nil
+		}
+		archived = true
+	}
+
+	// Naming conventions tune the defaults: incident threads are where the
+	// action is, so expand them unless the caller chose otherwise
+	category := channelCategory(loadChannelTaxonomy(), channelName)
+	if _, set := params["expandThreads"]; !set && category == categoryIncident {
+		expandThreads = true
 	}
 
 	// Determine if we should auto-follow cursors
-	shouldAutoCursor := shouldAutoFollowCursor(since, cursor)
+	shouldAutoCursor := shouldAutoFollowCursor(since, cursor) || (calendar && cursor == "")
 
 	// Collect all messages if auto-cursoring
 	allMessages := []slack.Message{}
 	importantItems := []map[string]interface{}{}
+	var links permalinkBatch
 	stats := map[string]interface{}{
 		"totalMessages": 0,
 		"threads":       0,
@@ -85,27 +165,78 @@ func catchUpHandlerImpl(ctx context.Context, params map[string]interface{}) (*Fe
 		"reactions":     0,
 	}
 
-	usersMap := provider.ProvideUsersMap()
+	usersMap := apiProvider.ProvideUsersMap()
+	expandedThreads := 0
 	currentCursor := cursor
 	hasMore := true
 	pageCount := 0
 	maxPages := 10
+	joined := false
+	left := false
+	var stopErr error
 
 	// For recent timeframes, be more aggressive
-	if isRecentTimeframe(since) && cursor == "" {
+	if recent && cursor == "" {
 		maxPages = 20
 	}
 
-	for hasMore && pageCount < maxPages {
+	// Without a limit, page size and depth follow the channel's volume
+	plan := pagingPlan{pageSize: limit, maxPages: maxPages}
+	if adaptive {
+		plan = planFirstPage(channelLatest(apiProvider, channelID), oldest, maxPages)
+	}
+
+	if estimate, _ := params["estimate"].(bool); estimate {
+		return estimateCatchUp(ctx, apiProvider, api, channel, channelID, since, oldest, latest, plan, adaptive, shouldAutoCursor, expandThreads, workflows), nil
+	}
+
+	for hasMore && pageCount < plan.maxPages {
 		// Fetch messages from channel
 		histParams := &slack.GetConversationHistoryParameters{
 			ChannelID: channelID,
 			Oldest:    fmt.Sprintf("%d", oldest.Unix()),
-			Limit:     limit,
+			Limit:     plan.pageSize,
 			Cursor:    currentCursor,
 		}
+		if !latest.IsZero() {
+			histParams.Latest = fmt.Sprintf("%d", latest.Unix())
+		}
 
 		resp, err := api.GetConversationHistoryContext(ctx, histParams)
+		if err != nil && isNotInChannel(err) && !joined {
+			if !joinIfNeeded {
+				return &FeatureResult{
+					Success:  false,
+					Message:  fmt.Sprintf("You're not a member of #%s, so its history can't be read", cleanName),
+					Guidance: "Joining lets you read it, but other members will see that you joined. Retry with joinIfNeeded=true (and leaveAfter=true to leave again afterwards).",
+					NextActions: []string{
+						fmt.Sprintf("catch-up channel='%s' since='%s' joinIfNeeded=true leaveAfter=true", channel, since),
+					},
+				}, nil
+			}
+			if _, _, _, err := api.JoinConversationContext(ctx, channelID); err != nil {
+				return &FeatureResult{
+					Success:  false,
+					Message:  fmt.Sprintf("Failed to join #%s: %v", cleanName, err),
+					Guidance: "Private channels can't be joined; ask a member to invite you",
+				}, nil
+			}
+			joined = true
+			if leaveAfter {
+				defer func() {
+					if _, err := api.LeaveConversationContext(ctx, channelID); err != nil {
+						log.Printf("Failed to leave %s after catch-up: %v", channelID, err)
+					}
+				}()
+				left = true
+			}
+			resp, err = api.GetConversationHistoryContext(ctx, histParams)
+		}
+		if err != nil && pageCount > 0 {
+			// Keep what the earlier pages read
+			stopErr = err
+			break
+		}
 		if err != nil {
 			return &FeatureResult{
 				Success: false,
@@ -118,9 +249,16 @@ func catchUpHandlerImpl(ctx context.Context, params map[string]interface{}) (*Fe
 			allMessages = append(allMessages, msg)
 
 			// Analyze each message
-			item := analyzeMessage(msg, usersMap)
+			item := analyzeMessage(msg, usersMap, workflows)
 			if item != nil {
+				if expandThreads && msg.ReplyCount > rollupMinReplies && expandedThreads < rollupMaxThreads {
+					if rollup := threadRollup(ctx, api, channelID, msg, usersMap); rollup != nil {
+						item["rollup"] = rollup
+						expandedThreads++
+					}
+				}
 				importantItems = append(importantItems, item)
+				links.add(item, channelID, msg.Timestamp)
 			}
 
 			// Update stats
@@ -137,6 +275,14 @@ func catchUpHandlerImpl(ctx context.Context, params map[string]interface{}) (*Fe
 
 		stats["totalMessages"] = len(allMessages)
 		pageCount++
+		if adaptive && pageCount == 1 && resp.HasMore && len(allMessages) > 0 {
+			end := time.Now()
+			if !latest.IsZero() {
+				end = latest
+			}
+			reached := parseSlackTimestamp(allMessages[len(allMessages)-1].Timestamp)
+			plan = planRemainingPages(plan, maxPages, len(allMessages), end, reached, oldest)
+		}
 
 		// Decide whether to continue
 		if !shouldAutoCursor || !resp.HasMore || cursor != "" {
@@ -145,7 +291,7 @@ func catchUpHandlerImpl(ctx context.Context, params map[string]interface{}) (*Fe
 			currentCursor = resp.ResponseMetaData.NextCursor
 		} else {
 			// Check if we've gone back far enough for recent timeframes
-			if len(allMessages) > 0 && isRecentTimeframe(since) {
+			if len(allMessages) > 0 && recent {
 				oldestFetched := allMessages[len(allMessages)-1]
 				msgTime := parseSlackTimestamp(oldestFetched.Timestamp)
 				if msgTime.Before(oldest) {
@@ -159,6 +305,9 @@ func catchUpHandlerImpl(ctx context.Context, params map[string]interface{}) (*Fe
 		}
 	}
 
+	links.resolve(ctx, apiProvider)
+	apiProvider.RecordAction(provider.UsageChannelsCaughtUp, 1)
+
 	// Build response
 	result := &FeatureResult{
 		Success: true,
@@ -168,16 +317,54 @@ func catchUpHandlerImpl(ctx context.Context, params map[string]interface{}) (*Fe
 			"importantItems": importantItems,
 			"statistics":     stats,
 		},
-		Message:     fmt.Sprintf("Found %d messages in #%s from the last %s", len(allMessages), channel, since),
+		Message:     fmt.Sprintf("Found %d messages in #%s from %s", len(allMessages), channel, period),
 		ResultCount: len(importantItems),
 		Pagination: &Pagination{
 			Cursor:     cursor,
 			NextCursor: currentCursor,
-			HasMore:    hasMore && pageCount >= maxPages,
+			HasMore:    hasMore && (pageCount >= plan.maxPages || stopErr != nil),
 			PageSize:   len(allMessages),
 		},
 	}
 
+	// Say how much of the period was actually read
+	if stopErr != nil || result.Pagination.HasMore {
+		end := latest
+		if end.IsZero() {
+			end = time.Now()
+		}
+		var reached time.Time
+		if len(allMessages) > 0 {
+			reached = parseSlackTimestamp(allMessages[len(allMessages)-1].Timestamp)
+		}
+		c := Coverage{Reason: PartialMaxPages, Detail: fmt.Sprintf("read %d pages; older messages in the period weren't read", pageCount)}
+		if stopErr != nil {
+			c = Coverage{Reason: stopReason(stopErr), Detail: fmt.Sprintf("stopped after %d pages: %v", pageCount, stopErr)}
+		}
+		result.MarkPartial(c.scannedRange(reached, end))
+	}
+
+	if expandedThreads > 0 {
+		result.Data.(map[string]interface{})["threadsExpanded"] = expandedThreads
+	}
+	if adaptive {
+		result.Data.(map[string]interface{})["paging"] = map[string]interface{}{
+			"pageSize": plan.pageSize,
+			"maxPages": plan.maxPages,
+			"reason":   plan.reason,
+		}
+	}
+	if archived {
+		result.Data.(map[string]interface{})["archived"] = true
+	}
+	if category != "" {
+		result.Data.(map[string]interface{})["category"] = category
+	}
+	if joined {
+		result.Data.(map[string]interface{})["joined"] = true
+		result.Data.(map[string]interface{})["leftAfter"] = left
+	}
+
 	// Add auto-cursor info if we did multiple pages
 	if pageCount > 1 && cursor == "" {
 		result.Data.(map[string]interface{})["pagesTraversed"] = pageCount
@@ -270,6 +457,31 @@ func catchUpHandlerImpl(ctx context.Context, params map[string]interface{}) (*Fe
 		}
 	}
 
+	if category == categoryLow && !archived && totalMsgCount > 0 {
+		result.NextActions = append(result.NextActions,
+			fmt.Sprintf("Low-priority channel by naming convention: mark-read channel='%s'", channel))
+	}
+
+	if joined {
+		if left {
+			result.Guidance = "👋 Joined to read history and left again. " + result.Guidance
+		} else {
+			result.Guidance = "👋 Joined this channel to read its history; you are now a member. " + result.Guidance
+		}
+	}
+
+	if archived {
+		// Archived channels are read-only; drop suggestions to post or mark read
+		actions := result.NextActions[:0]
+		for _, a := range result.NextActions {
+			if !strings.Contains(a, "send-message") && !strings.Contains(a, "mark-read") && !strings.Contains(a, "auto-marked") {
+				actions = append(actions, a)
+			}
+		}
+		result.NextActions = actions
+		result.Guidance = "🗄️ This channel is archived (read-only). " + result.Guidance
+	}
+
 	return result, nil
 }
 
@@ -301,9 +513,20 @@ func isRecentTimeframe(timeframe string) bool {
 	return false
 }
 
-func analyzeMessage(msg slack.Message, usersMap map[string]slack.User) map[string]interface{} {
-	// Skip if not important
-	if !isImportantMessage(msg) {
+func analyzeMessage(msg slack.Message, usersMap map[string]slack.User, workflows string) map[string]interface{} {
+	source, sourceName := classifySource(msg)
+
+	// Skip if not important; workflow messages follow the configured policy
+	important := isImportantMessage(msg)
+	if source == sourceWorkflow {
+		switch workflows {
+		case workflowImportant:
+			important = true
+		case workflowSkip:
+			important = false
+		}
+	}
+	if !important {
 		return nil
 	}
 
@@ -314,12 +537,14 @@ func analyzeMessage(msg slack.Message, usersMap map[string]slack.User) map[strin
 		if user.RealName != "" {
 			userName = user.RealName
 		}
+	} else if sourceName != "" {
+		userName = sourceName
 	}
 
 	// Build item
 	item := map[string]interface{}{
 		"author":    userName,
-		"message":   msg.Text,
+		"message":   messageBody(msg.Text, msg.Blocks, msg.Attachments),
 		"timestamp": msg.Timestamp,
 		"type":      "message",
 	}
@@ -344,21 +569,30 @@ func analyzeMessage(msg slack.Message, usersMap map[string]slack.User) map[strin
 		item["reactions"] = totalReactions
 	}
 
-	if len(msg.Files) > 0 {
-		if item["type"] == "message" {
-			item["type"] = "attachment"
+	if source != "" {
+		item["source"] = source
+		if sourceName != "" {
+			item["sourceName"] = sourceName
 		}
-		files := make([]map[string]interface{}, 0, len(msg.Files))
-		for _, f := range msg.Files {
-			files = append(files, map[string]interface{}{
-				"id":       f.ID,
-				"name":     f.Name,
-				"mimetype": f.Mimetype,
-				"size":     f.Size,
-			})
+		if source == sourceWorkflow && item["type"] == "message" {
+			item["type"] = "workflow"
 		}
-		item["files"] = files
 	}
 
+	if len(msg.Files) > 0 && item["type"] == "message" {
+		item["type"] = "attachment"
+	}
+	addAttachmentInfo(item, "message", msg.Files, msg.Attachments, msg.Blocks)
+
 	return item
 }
+
+// isNotInChannel reports whether a Slack error means the user must join the
+// channel before reading it
+func isNotInChannel(err error) bool {
+	var slackErr slack.SlackErrorResponse
+	if errors.As(err, &slackErr) {
+		return slackErr.Err == "not_in_channel"
+	}
+	return strings.Contains(err.Error(), "not_in_channel")
+}
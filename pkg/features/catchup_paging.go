@@ -0,0 +1,82 @@
+package features
+
+import (
+	"math"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+// Adaptive paging, used when catch-up isn't given a limit: quiet channels
+// take one call, and busy ones get pages sized from their message rate so
+// the fetch reaches back to the start of the period in as few calls as the
+// page cap allows.
+const (
+	// catchUpQuietPage is the page size for a channel with nothing new
+	catchUpQuietPage = 20
+	// catchUpSamplePage is the first page of a channel that has activity;
+	// how far back it reaches gives the channel's message rate
+	catchUpSamplePage = 100
+	// catchUpMaxPage is the largest page conversations.history serves well
+	catchUpMaxPage = 200
+)
+
+// pagingPlan is how catch-up pages through a channel's history
+type pagingPlan struct {
+	pageSize int
+	maxPages int
+	reason   string
+}
+
+// channelLatest returns when a channel last had a message, from the cached
+// client.counts response. Zero if unknown.
+func channelLatest(ap *provider.ApiProvider, channelID string) time.Time {
+	counts, _ := ap.CachedClientCounts()
+	if counts == nil {
+		return time.Time{}
+	}
+	for _, ch := range counts.Channels {
+		if ch.ID == channelID {
+			return parseSlackTimestamp(ch.Latest)
+		}
+	}
+	for _, ch := range counts.MPIMs {
+		if ch.ID == channelID {
+			return parseSlackTimestamp(ch.Latest)
+		}
+	}
+	for _, ch := range counts.IMs {
+		if ch.ID == channelID {
+			return parseSlackTimestamp(ch.Latest)
+		}
+	}
+	return time.Time{}
+}
+
+// planFirstPage picks the first page from the channel's latest message:
+// one small call when nothing arrived since the period began, otherwise a
+// sample page big enough to measure the rate
+func planFirstPage(latest, oldest time.Time, maxPages int) pagingPlan {
+	if !latest.IsZero() && latest.Before(oldest) {
+		return pagingPlan{pageSize: catchUpQuietPage, maxPages: 1, reason: "no messages since the period began"}
+	}
+	return pagingPlan{pageSize: catchUpSamplePage, maxPages: maxPages, reason: "sampled the message rate"}
+}
+
+// planRemainingPages sizes the pages after the first from the rate it
+// showed: fetched messages reached back from end to reached, and oldest
+// is where the period starts. Depth stays within maxPages. A channel
+// planned as quiet from a stale counts snapshot is resized the same way.
+func planRemainingPages(plan pagingPlan, maxPages, fetched int, end, reached, oldest time.Time) pagingPlan {
+	covered := end.Sub(reached)
+	left := reached.Sub(oldest)
+	if fetched == 0 || covered <= 0 || left <= 0 {
+		return plan
+	}
+	remaining := int(math.Ceil(float64(fetched) * float64(left) / float64(covered)))
+	plan.pageSize = max(catchUpSamplePage, min(remaining, catchUpMaxPage))
+	pages := (remaining + plan.pageSize - 1) / plan.pageSize
+	plan.maxPages = min(1+pages, maxPages)
+	plan.reason = "paged by message rate"
+	return plan
+}
@@ -0,0 +1,51 @@
+package features
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPlanFirstPage(t *testing.T) {
+	now := time.Now()
+	oldest := now.Add(-24 * time.Hour)
+
+	if p := planFirstPage(now.Add(-48*time.Hour), oldest, 20); p.maxPages != 1 || p.pageSize != catchUpQuietPage {
+		t.Errorf("quiet channel should take one small call, got %+v", p)
+	}
+	if p := planFirstPage(now.Add(-time.Hour), oldest, 20); p.pageSize != catchUpSamplePage || p.maxPages != 20 {
+		t.Errorf("active channel should sample, got %+v", p)
+	}
+	if p := planFirstPage(time.Time{}, oldest, 20); p.pageSize != catchUpSamplePage {
+		t.Errorf("unknown latest should sample, got %+v", p)
+	}
+}
+
+func TestPlanRemainingPages(t *testing.T) {
+	end := time.Now()
+	oldest := end.Add(-24 * time.Hour)
+	first := pagingPlan{pageSize: catchUpSamplePage, maxPages: 20}
+
+	// 100 messages in the last 2 hours: ~1100 more over the other 22
+	p := planRemainingPages(first, 20, 100, end, end.Add(-2*time.Hour), oldest)
+	if p.pageSize != catchUpMaxPage || p.maxPages != 1+6 {
+		t.Errorf("busy channel: got %+v", p)
+	}
+
+	// 100 messages across 20 hours: ~20 more fit one sample-sized page
+	p = planRemainingPages(first, 20, 100, end, end.Add(-20*time.Hour), oldest)
+	if p.pageSize != catchUpSamplePage || p.maxPages != 2 {
+		t.Errorf("moderate channel: got %+v", p)
+	}
+
+	// Depth never exceeds the cap
+	p = planRemainingPages(first, 10, 100, end, end.Add(-10*time.Minute), oldest)
+	if p.maxPages != 10 {
+		t.Errorf("maxPages = %d, want the cap of 10", p.maxPages)
+	}
+
+	// A quiet plan from a stale snapshot opens up when more turns up
+	quiet := pagingPlan{pageSize: catchUpQuietPage, maxPages: 1}
+	if p := planRemainingPages(quiet, 20, 20, end, end.Add(-time.Hour), oldest); p.maxPages < 2 {
+		t.Errorf("stale quiet plan should page on, got %+v", p)
+	}
+}
@@ -0,0 +1,298 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// CatchUpOnPerson gathers what one person has said recently, across the
+// channels you share and your DM with them
+var CatchUpOnPerson = &Feature{
+	Name:        "catch-up-on-person",
+	Description: "Catch up on what a person has said recently across shared channels and your DM with them, grouped by conversation",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"person": map[string]interface{}{
+				"type":        "string",
+				"description": "The person: '@alice', their name, user ID, or email",
+			},
+			"since": map[string]interface{}{
+				"type":        "string",
+				"description": "How far back to look (e.g., '1d', '7d', '2w')",
+				"default":     "7d",
+			},
+			"includeDM": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Include your DM with them",
+				"default":     true,
+			},
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": "Maximum messages to return (default: 30, max: 100)",
+				"default":     30,
+			},
+		},
+		"required": []string{"person"},
+	},
+	Handler: catchUpOnPersonHandler,
+}
+
+// personMessage is one message by the person, from search or DM history
+type personMessage struct {
+	channelID string
+	channel   string
+	ts        string
+	threadTS  string
+	text      string
+	permalink string
+}
+
+func catchUpOnPersonHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	person, _ := params["person"].(string)
+	if strings.TrimSpace(person) == "" {
+		return &FeatureResult{
+			Success: false,
+			Message: "person is required",
+		}, nil
+	}
+	since := "7d"
+	if s, ok := params["since"].(string); ok && s != "" {
+		since = s
+	}
+	includeDM := true
+	if d, ok := params["includeDM"].(bool); ok {
+		includeDM = d
+	}
+	limit := 30
+	if l, ok := params["limit"].(float64); ok {
+		limit = max(1, min(int(l), 100))
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	usersMap := apiProvider.ProvideUsersMap()
+	userID := resolveUserRef(ctx, apiProvider, person, usersMap)
+	if userID == "" {
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("No user matching '%s'", person),
+			Guidance: "Use list-users to find their username",
+		}, nil
+	}
+	name := userDisplayName(userID, usersMap)
+
+	oldest, err := parseTimePeriod(since)
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Invalid time period: %v", err),
+		}, nil
+	}
+
+	selfID := ""
+	if id := apiProvider.ProvideIdentity(); id != nil {
+		selfID = id.UserID
+	}
+
+	seen := map[string]bool{}
+	var msgs []personMessage
+	add := func(m personMessage) {
+		key := m.channelID + "/" + m.ts
+		if !seen[key] && !parseSlackTimestamp(m.ts).Before(oldest) {
+			seen[key] = true
+			msgs = append(msgs, m)
+		}
+	}
+
+	// Search finds their messages in every channel you can see. Bot tokens
+	// can't search, which leaves the DM.
+	searched := false
+	var searchErr error
+	if apiProvider.TokenType() != "bot" {
+		query := fmt.Sprintf("from:<@%s> after:%s", userID, oldest.AddDate(0, 0, -1).Format("2006-01-02"))
+		for page := 1; page <= 2; page++ {
+			sp := slack.NewSearchParameters()
+			sp.Sort = "timestamp"
+			sp.Count = 100
+			sp.Page = page
+			res, err := api.SearchMessagesContext(ctx, query, sp)
+			if err != nil {
+				searchErr = err
+				break
+			}
+			searched = true
+			for _, m := range res.Matches {
+				channel := "#" + m.Channel.Name
+				switch {
+				case strings.HasPrefix(m.Channel.ID, "D"):
+					channel = "DM"
+				case m.Channel.IsMPIM:
+					mp := slack.Channel{}
+					mp.Name = m.Channel.Name
+					channel = "group DM: " + dmDisplayName(mp, usersMap)
+				}
+				add(personMessage{
+					channelID: m.Channel.ID,
+					channel:   channel,
+					ts:        m.Timestamp,
+					text:      m.Text,
+					permalink: m.Permalink,
+				})
+			}
+			if res.Paging.Pages <= page {
+				break
+			}
+		}
+	}
+
+	// The DM is read directly: search lags behind new messages, and it
+	// shows whether the last word was theirs
+	awaitingReply := false
+	if includeDM {
+		if dm, err := apiProvider.OpenDM(ctx, userID); err == nil {
+			resp, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+				ChannelID: dm.ID,
+				Oldest:    fmt.Sprintf("%d", oldest.Unix()),
+				Limit:     100,
+			})
+			if err == nil {
+				for i, m := range resp.Messages {
+					if i == 0 && m.User == userID {
+						awaitingReply = true
+					}
+					if m.User == userID {
+						add(personMessage{channelID: dm.ID, channel: "DM", ts: m.Timestamp, threadTS: m.ThreadTimestamp, text: m.Text})
+					}
+				}
+			}
+		}
+	} else {
+		kept := msgs[:0]
+		for _, m := range msgs {
+			if m.channel != "DM" {
+				kept = append(kept, m)
+			}
+		}
+		msgs = kept
+	}
+
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].ts > msgs[j].ts })
+	total := len(msgs)
+
+	// Per-conversation summary covers everything found, not just the page
+	type convo struct {
+		channel string
+		count   int
+		latest  time.Time
+	}
+	byChannel := map[string]*convo{}
+	var order []string
+	for _, m := range msgs {
+		c, ok := byChannel[m.channelID]
+		if !ok {
+			c = &convo{channel: m.channel, latest: parseSlackTimestamp(m.ts)}
+			byChannel[m.channelID] = c
+			order = append(order, m.channelID)
+		}
+		c.count++
+	}
+	conversations := make([]map[string]interface{}, 0, len(order))
+	for _, id := range order {
+		c := byChannel[id]
+		conversations = append(conversations, map[string]interface{}{
+			"channel":    c.channel,
+			"channelId":  id,
+			"messages":   c.count,
+			"lastActive": formatTimestamp(c.latest),
+		})
+	}
+
+	if len(msgs) > limit {
+		msgs = msgs[:limit]
+	}
+	mentionsYou := 0
+	messages := make([]map[string]interface{}, 0, len(msgs))
+	for _, m := range msgs {
+		msg := map[string]interface{}{
+			"channel": m.channel,
+			"time":    formatTimestamp(parseSlackTimestamp(m.ts)),
+			"ts":      m.ts,
+			"text":    m.text,
+		}
+		if m.permalink != "" {
+			msg["permalink"] = m.permalink
+		}
+		if m.threadTS != "" && m.threadTS != m.ts {
+			msg["inThread"] = true
+		}
+		if selfID != "" && strings.Contains(m.text, "<@"+selfID+">") {
+			msg["mentionsYou"] = true
+			mentionsYou++
+		}
+		if strings.Contains(m.text, "?") {
+			msg["question"] = true
+		}
+		messages = append(messages, msg)
+	}
+
+	data := map[string]interface{}{
+		"person":        name,
+		"userId":        userID,
+		"since":         since,
+		"total":         total,
+		"mentionsYou":   mentionsYou,
+		"awaitingReply": awaitingReply,
+		"conversations": conversations,
+		"messages":      messages,
+		"searched":      searched,
+	}
+	if u, ok := usersMap[userID]; ok {
+		data["username"] = u.Name
+		if u.Profile.Title != "" {
+			data["title"] = u.Profile.Title
+		}
+	}
+
+	result := &FeatureResult{
+		Success:     true,
+		Message:     fmt.Sprintf("%s posted %d messages in %d conversations in the last %s", name, total, len(conversations), since),
+		Data:        data,
+		ResultCount: len(messages),
+	}
+	switch {
+	case searchErr != nil && !searched:
+		result.Guidance = fmt.Sprintf("Search failed (%v), so only your DM was read", searchErr)
+	case !searched:
+		result.Guidance = "Bot tokens can't search, so only your DM was read"
+	case total > len(messages):
+		result.Guidance = fmt.Sprintf("Showing the newest %d; narrow since or raise limit for more", len(messages))
+	}
+	if awaitingReply {
+		result.NextActions = append(result.NextActions, fmt.Sprintf("They're waiting on you: catch-up channel='%s' since='%s'", userID, since))
+	}
+	if len(conversations) > 0 && conversations[0]["channel"] != "DM" {
+		result.NextActions = append(result.NextActions, fmt.Sprintf("Read the conversation: catch-up channel='%s' since='%s'", conversations[0]["channelId"], since))
+	}
+	return result, nil
+}
@@ -0,0 +1,70 @@
+package features
+
+import (
+	"context"
+	"log"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	// rollupMinReplies is the reply count above which a thread is rolled up
+	rollupMinReplies = 3
+	// rollupMaxThreads caps replies fetches per catch-up call
+	rollupMaxThreads = 10
+)
+
+// threadRollup fetches a thread's replies and condenses them into who took
+// part, how it started and ended, and whether it reached a decision
+func threadRollup(ctx context.Context, api *slack.Client, channelID string, root slack.Message, usersMap map[string]slack.User) map[string]interface{} {
+	replies, _, _, err := api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: channelID,
+		Timestamp: root.Timestamp,
+		Limit:     200,
+	})
+	if err != nil || len(replies) == 0 {
+		log.Printf("Thread rollup for %s:%s failed: %v", channelID, root.Timestamp, err)
+		return nil
+	}
+
+	summarize := func(msg slack.Message) map[string]interface{} {
+		return map[string]interface{}{
+			"author":    userDisplayName(msg.User, usersMap),
+			"text":      msg.Text,
+			"timestamp": msg.Timestamp,
+		}
+	}
+
+	rollup := map[string]interface{}{
+		"replyCount":   len(replies) - 1,
+		"participants": getUniqueParticipants(replies, usersMap),
+		"first":        summarize(replies[0]),
+		"last":         summarize(replies[len(replies)-1]),
+		"decision":     false,
+	}
+
+	// The latest decision-like reply is the most likely outcome
+	for i := len(replies) - 1; i >= 0; i-- {
+		if decisionPattern.MatchString(replies[i].Text) {
+			rollup["decision"] = true
+			rollup["decisionMessage"] = summarize(replies[i])
+			break
+		}
+	}
+
+	return rollup
+}
+
+// userDisplayName prefers a user's real name, falling back to handle and ID
+func userDisplayName(userID string, usersMap map[string]slack.User) string {
+	if user, ok := usersMap[userID]; ok {
+		if user.RealName != "" {
+			return user.RealName
+		}
+		return user.Name
+	}
+	if userID == "" {
+		return "unknown"
+	}
+	return userID
+}
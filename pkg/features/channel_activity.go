@@ -0,0 +1,203 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// ChannelActivityProfile builds a day-of-week / hour-of-day histogram of a
+// channel's messages so an agent can suggest when a question is most
+// likely to get a quick answer
+var ChannelActivityProfile = &Feature{
+	Name:        "channel-activity-profile",
+	Description: "Show when a channel is active (messages by weekday and hour over recent weeks) to pick the best time to post",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel": map[string]interface{}{
+				"type":        "string",
+				"description": "Channel name or ID",
+			},
+			"weeks": map[string]interface{}{
+				"type":        "number",
+				"description": "Number of weeks of history to analyze (default: 4, max: 12)",
+				"default":     4,
+			},
+		},
+		"required": []string{"channel"},
+	},
+	Handler: channelActivityProfileHandler,
+}
+
+// activityMaxPages bounds history fetching for very busy channels
+const activityMaxPages = 25
+
+func channelActivityProfileHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	channel, _ := params["channel"].(string)
+	weeks := 4
+	if w, ok := params["weeks"].(float64); ok {
+		weeks = int(w)
+		if weeks < 1 {
+			weeks = 1
+		}
+		if weeks > 12 {
+			weeks = 12
+		}
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	channelID := apiProvider.ResolveChannelID(strings.TrimPrefix(channel, "#"))
+	if !isChannelID(channelID) {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Channel '%s' not found. Use list-channels to see available channels.", channel),
+		}, nil
+	}
+
+	oldest := time.Now().AddDate(0, 0, -7*weeks)
+	var grid [7][24]int
+	total := 0
+	truncated := false
+	var reached time.Time
+	cursor := ""
+	for page := 0; ; page++ {
+		if page >= activityMaxPages {
+			truncated = true
+			break
+		}
+		resp, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: channelID,
+			Oldest:    fmt.Sprintf("%d", oldest.Unix()),
+			Limit:     200,
+			Cursor:    cursor,
+		})
+		if err != nil {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Failed to fetch channel history: %v", err),
+			}, nil
+		}
+		for _, msg := range resp.Messages {
+			if msg.SubType == "channel_join" || msg.SubType == "channel_leave" || msg.BotID != "" {
+				continue
+			}
+			t := parseSlackTimestamp(msg.Timestamp).Local()
+			grid[t.Weekday()][t.Hour()]++
+			total++
+		}
+		if n := len(resp.Messages); n > 0 {
+			reached = parseSlackTimestamp(resp.Messages[n-1].Timestamp)
+		}
+		cursor = resp.ResponseMetaData.NextCursor
+		if !resp.HasMore || cursor == "" {
+			break
+		}
+	}
+
+	byDay := make([]map[string]interface{}, 0, 7)
+	for d := time.Monday; ; d = (d + 1) % 7 {
+		count := 0
+		for h := 0; h < 24; h++ {
+			count += grid[d][h]
+		}
+		byDay = append(byDay, map[string]interface{}{"day": d.String()[:3], "count": count})
+		if d == time.Sunday {
+			break
+		}
+	}
+
+	byHour := make([]map[string]interface{}, 0, 24)
+	for h := 0; h < 24; h++ {
+		count := 0
+		for d := 0; d < 7; d++ {
+			count += grid[d][h]
+		}
+		byHour = append(byHour, map[string]interface{}{"hour": h, "count": count})
+	}
+
+	type slot struct {
+		day   time.Weekday
+		hour  int
+		count int
+	}
+	var slots []slot
+	for d := 0; d < 7; d++ {
+		for h := 0; h < 24; h++ {
+			if grid[d][h] > 0 {
+				slots = append(slots, slot{time.Weekday(d), h, grid[d][h]})
+			}
+		}
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i].count > slots[j].count })
+	peaks := []map[string]interface{}{}
+	for i := 0; i < len(slots) && i < 5; i++ {
+		peaks = append(peaks, map[string]interface{}{
+			"slot":  fmt.Sprintf("%s %02d:00", slots[i].day.String()[:3], slots[i].hour),
+			"count": slots[i].count,
+		})
+	}
+
+	channelName := apiProvider.ResolveChannelName(ctx, channelID)
+	if channelName == "" {
+		channelName = channel
+	}
+
+	result := &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Analyzed %d messages in #%s over the last %d weeks", total, channelName, weeks),
+		Data: map[string]interface{}{
+			"channel":   channelName,
+			"weeks":     weeks,
+			"messages":  total,
+			"byDay":     byDay,
+			"byHour":    byHour,
+			"peaks":     peaks,
+			"timezone":  time.Now().Format("MST"),
+			"truncated": truncated,
+		},
+		ResultCount: total,
+	}
+	if truncated {
+		result.MarkPartial(Coverage{
+			Reason: PartialMaxPages,
+			Detail: fmt.Sprintf("read the newest %d messages of the %d weeks", activityMaxPages*200, weeks),
+		}.scannedRange(reached, time.Now()))
+	}
+
+	switch {
+	case total == 0:
+		result.Guidance = "No human messages in this period; there is no good or bad time to post here."
+	case len(peaks) > 0:
+		result.Guidance = fmt.Sprintf("Busiest slot is %s (local time). Posting shortly before a peak tends to get the fastest replies.", peaks[0]["slot"])
+	}
+	if truncated {
+		result.Guidance += " Only the most recent messages were analyzed; try fewer weeks for a full picture."
+	}
+	result.NextActions = []string{
+		fmt.Sprintf("See recent activity: catch-up channel='%s' since='1d'", channelName),
+		fmt.Sprintf("Post your question: send-message channel='%s'", channelName),
+	}
+
+	return result, nil
+}
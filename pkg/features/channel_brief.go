@@ -0,0 +1,311 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// BriefMeOnChannel assembles an onboarding brief for someone joining a
+// channel or project mid-stream: what it's for, what's pinned, who does
+// the talking, and what the recent big threads were about
+var BriefMeOnChannel = &Feature{
+	Name:        "brief-me-on-channel",
+	Description: "Onboarding brief for a channel or project: purpose and topic, pinned items, top contributors, and a summary of recent major threads and decisions",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel": map[string]interface{}{
+				"type":        "string",
+				"description": "Channel name or ID",
+			},
+			"weeks": map[string]interface{}{
+				"type":        "number",
+				"description": "How many weeks of history to cover (default: 4, max: 12)",
+				"default":     4,
+			},
+		},
+		"required": []string{"channel"},
+	},
+	Handler: briefMeOnChannelHandler,
+}
+
+const (
+	// briefMaxPins caps pinned items listed in a brief
+	briefMaxPins = 10
+	// briefMaxContributors caps the top contributors listed in a brief
+	briefMaxContributors = 5
+)
+
+func briefMeOnChannelHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	channel, _ := params["channel"].(string)
+	weeks := 4
+	if w, ok := params["weeks"].(float64); ok {
+		weeks = int(w)
+		if weeks < 1 {
+			weeks = 1
+		}
+		if weeks > 12 {
+			weeks = 12
+		}
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	channelID := apiProvider.ResolveChannelID(strings.TrimPrefix(channel, "#"))
+	if !isChannelID(channelID) {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Channel '%s' not found. Use list-channels to see available channels.", channel),
+		}, nil
+	}
+	info, err := apiProvider.GetChannelInfo(ctx, channelID)
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get channel info: %v", err),
+		}, nil
+	}
+
+	usersMap := apiProvider.ProvideUsersMap()
+	oldest := time.Now().AddDate(0, 0, -7*weeks)
+	msgs, truncated, err := fetchReportHistory(ctx, api, channelID, oldest)
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to fetch channel history: %v", err),
+		}, nil
+	}
+
+	var links permalinkBatch
+
+	about := map[string]interface{}{
+		"name":    info.Name,
+		"purpose": info.Purpose.Value,
+		"topic":   info.Topic.Value,
+		"members": info.NumMembers,
+	}
+	if info.Created > 0 {
+		about["created"] = info.Created.Time().Format("2006-01-02")
+	}
+	if info.Creator != "" {
+		about["creator"] = userDisplayName(info.Creator, usersMap)
+	}
+
+	pins := []map[string]interface{}{}
+	if items, _, err := api.ListPinsContext(ctx, channelID); err == nil {
+		for _, item := range items {
+			if len(pins) >= briefMaxPins {
+				break
+			}
+			switch {
+			case item.Message != nil:
+				pin := map[string]interface{}{
+					"type":   "message",
+					"author": userDisplayName(item.Message.User, usersMap),
+					"text":   readableText(messageBody(item.Message.Text, item.Message.Blocks, item.Message.Attachments), usersMap),
+				}
+				links.add(pin, channelID, item.Message.Timestamp)
+				pins = append(pins, pin)
+			case item.File != nil:
+				pins = append(pins, map[string]interface{}{
+					"type":      "file",
+					"text":      item.File.Title,
+					"permalink": item.File.Permalink,
+				})
+			}
+		}
+	}
+
+	counts := map[string]int{}
+	var roots []slack.Message
+	for _, msg := range msgs {
+		if msg.SubType == "channel_join" || msg.SubType == "channel_leave" {
+			continue
+		}
+		if msg.User != "" && msg.BotID == "" {
+			counts[msg.User]++
+		}
+		if msg.ReplyCount >= rollupMinReplies {
+			roots = append(roots, msg)
+		}
+	}
+	contributors := make([]map[string]interface{}, 0, len(counts))
+	for id, n := range counts {
+		contributors = append(contributors, map[string]interface{}{"name": userDisplayName(id, usersMap), "messages": n})
+	}
+	sort.Slice(contributors, func(i, j int) bool {
+		if contributors[i]["messages"].(int) != contributors[j]["messages"].(int) {
+			return contributors[i]["messages"].(int) > contributors[j]["messages"].(int)
+		}
+		return contributors[i]["name"].(string) < contributors[j]["name"].(string)
+	})
+	if len(contributors) > briefMaxContributors {
+		contributors = contributors[:briefMaxContributors]
+	}
+
+	sort.SliceStable(roots, func(i, j int) bool { return roots[i].ReplyCount > roots[j].ReplyCount })
+	if len(roots) > rollupMaxThreads {
+		roots = roots[:rollupMaxThreads]
+	}
+	threads := []map[string]interface{}{}
+	decisions := []map[string]interface{}{}
+	for _, root := range roots {
+		item := map[string]interface{}{
+			"author":    userDisplayName(root.User, usersMap),
+			"text":      readableText(messageBody(root.Text, root.Blocks, root.Attachments), usersMap),
+			"timestamp": formatTimestamp(parseSlackTimestamp(root.Timestamp)),
+			"replies":   root.ReplyCount,
+		}
+		links.add(item, channelID, root.Timestamp)
+		if rollup := threadRollup(ctx, api, channelID, root, usersMap); rollup != nil {
+			item["participants"] = rollup["participants"]
+			if last, ok := rollup["last"].(map[string]interface{}); ok {
+				text, _ := last["text"].(string)
+				item["latest"] = readableText(text, usersMap)
+			}
+			if d, ok := rollup["decisionMessage"].(map[string]interface{}); ok {
+				ts, _ := d["timestamp"].(string)
+				text, _ := d["text"].(string)
+				decision := map[string]interface{}{
+					"author":    d["author"],
+					"text":      readableText(text, usersMap),
+					"timestamp": formatTimestamp(parseSlackTimestamp(ts)),
+				}
+				links.add(decision, channelID, ts)
+				decisions = append(decisions, decision)
+			}
+		}
+		threads = append(threads, item)
+	}
+
+	links.resolve(ctx, apiProvider)
+
+	// The Markdown is rendered by the formatter, after redaction
+	data := map[string]interface{}{
+		"channel":      info.Name,
+		"channelId":    channelID,
+		"weeks":        weeks,
+		"about":        about,
+		"pins":         pins,
+		"contributors": contributors,
+		"threads":      threads,
+		"decisions":    decisions,
+		"messages":     len(msgs),
+	}
+
+	result := &FeatureResult{
+		Success: true,
+		Data:    data,
+		Message: fmt.Sprintf("Brief for #%s: %d messages and %d major threads in the last %d week(s)", info.Name, len(msgs), len(threads), weeks),
+		NextActions: []string{
+			fmt.Sprintf("See what's new: catch-up channel='%s' since='1d'", info.Name),
+			fmt.Sprintf("When people are around: channel-activity-profile channel='%s'", info.Name),
+		},
+	}
+	if truncated {
+		result.Guidance = fmt.Sprintf("Only the most recent %d messages were read; pass fewer weeks for a complete picture.", teamReportMaxPages*200)
+		var reached time.Time
+		if len(msgs) > 0 {
+			reached = parseSlackTimestamp(msgs[len(msgs)-1].Timestamp)
+		}
+		result.MarkPartial(Coverage{
+			Reason: PartialMaxPages,
+			Detail: fmt.Sprintf("read the newest %d messages of the %d week(s)", teamReportMaxPages*200, weeks),
+		}.scannedRange(reached, time.Now()))
+	}
+	if len(threads) > 0 {
+		result.NextActions = append(result.NextActions, "Dig into a thread: read-thread threadId='<permalink>'")
+	}
+	return result, nil
+}
+
+// renderChannelBrief lays the brief out as Markdown
+func renderChannelBrief(data map[string]interface{}) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("# #%s\n\n", str(data, "channel")))
+
+	if about, ok := data["about"].(map[string]interface{}); ok {
+		if p := str(about, "purpose"); p != "" {
+			b.WriteString(fmt.Sprintf("**Purpose:** %s\n", p))
+		}
+		if t := str(about, "topic"); t != "" {
+			b.WriteString(fmt.Sprintf("**Topic:** %s\n", t))
+		}
+		facts := []string{fmt.Sprintf("%d members", num(about, "members"))}
+		if c := str(about, "created"); c != "" {
+			created := "created " + c
+			if by := str(about, "creator"); by != "" {
+				created += " by " + by
+			}
+			facts = append(facts, created)
+		}
+		b.WriteString("_" + strings.Join(facts, " · ") + "_\n")
+	}
+
+	link := func(item map[string]interface{}, max int) string {
+		text := truncate(str(item, "text"), max)
+		if text == "" {
+			text = "(no text)"
+		}
+		if l := str(item, "permalink"); l != "" {
+			return fmt.Sprintf("[%s](%s)", text, l)
+		}
+		return text
+	}
+
+	if pins := asList(data["pins"]); len(pins) > 0 {
+		b.WriteString("\n## Pinned\n\n")
+		for _, p := range pins {
+			b.WriteString("- " + link(p, 140))
+			if a := str(p, "author"); a != "" {
+				b.WriteString(" — " + a)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if people := asList(data["contributors"]); len(people) > 0 {
+		b.WriteString("\n## Who's active\n\n")
+		for _, p := range people {
+			b.WriteString(fmt.Sprintf("- %s (%d messages)\n", str(p, "name"), num(p, "messages")))
+		}
+	}
+
+	if threads := asList(data["threads"]); len(threads) > 0 {
+		b.WriteString(fmt.Sprintf("\n## Major threads (last %d weeks)\n\n", num(data, "weeks")))
+		for _, t := range threads {
+			b.WriteString(fmt.Sprintf("- %s — %s, %d replies\n", link(t, 140), str(t, "author"), num(t, "replies")))
+			if latest := str(t, "latest"); latest != "" {
+				b.WriteString(fmt.Sprintf("  - Latest: %s\n", truncate(latest, 140)))
+			}
+		}
+	}
+
+	if decisions := asList(data["decisions"]); len(decisions) > 0 {
+		b.WriteString("\n## Decisions\n\n")
+		for _, d := range decisions {
+			b.WriteString(fmt.Sprintf("- %s — %s\n", link(d, 160), str(d, "author")))
+		}
+	}
+	return b.String()
+}
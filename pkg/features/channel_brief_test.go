@@ -0,0 +1,38 @@
+package features
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderChannelBrief(t *testing.T) {
+	data := map[string]interface{}{
+		"channel": "proj-x",
+		"weeks":   4,
+		"about":   map[string]interface{}{"purpose": "Ship X", "members": 12, "created": "2025-01-02", "creator": "Alice"},
+		"pins":    []map[string]interface{}{{"type": "file", "text": "Roadmap", "permalink": "https://x/f1"}},
+		"contributors": []map[string]interface{}{
+			{"name": "Bob", "messages": 30},
+		},
+		"threads": []map[string]interface{}{
+			{"text": "Launch date?", "author": "Bob", "replies": 7, "latest": "Let's say May 1"},
+		},
+	}
+
+	brief := renderChannelBrief(data)
+	for _, want := range []string{
+		"# #proj-x",
+		"**Purpose:** Ship X",
+		"_12 members · created 2025-01-02 by Alice_",
+		"## Pinned\n\n- [Roadmap](https://x/f1)\n",
+		"- Bob (30 messages)",
+		"## Major threads (last 4 weeks)\n\n- Launch date? — Bob, 7 replies\n  - Latest: Let's say May 1",
+	} {
+		if !strings.Contains(brief, want) {
+			t.Errorf("brief missing %q:\n%s", want, brief)
+		}
+	}
+	if strings.Contains(brief, "Topic") || strings.Contains(brief, "## Decisions") {
+		t.Errorf("empty parts rendered:\n%s", brief)
+	}
+}
@@ -0,0 +1,398 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// ChannelHealth tracks rough week-by-week tone and pace for channels so
+// discussions that are heating up or stalling stand out. The signals are
+// heuristics over reactions, punctuation, and reply timing, not sentiment
+// analysis.
+var ChannelHealth = &Feature{
+	Name:        "channel-health",
+	Description: "Weekly tone and pace trends for channels (reaction mood, urgency density, response times, unanswered questions), flagging discussions that are getting heated or stalled",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channels": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Channel names or IDs (default: your most important channels)",
+			},
+			"weeks": map[string]interface{}{
+				"type":        "number",
+				"description": "Weeks of history to compare (default: 4, max: 8)",
+				"default":     4,
+			},
+		},
+	},
+	Handler: channelHealthHandler,
+}
+
+const (
+	// healthMaxChannels bounds history reads per call
+	healthMaxChannels = 8
+	// healthMaxPages bounds history fetching per channel
+	healthMaxPages = 10
+	// healthMaxGap ignores pauses long enough to be overnight rather than
+	// slow replies
+	healthMaxGap = 8 * time.Hour
+)
+
+// Reactions read as approval or as friction; everything else is neutral
+var (
+	positiveReactions = map[string]bool{
+		"+1": true, "thumbsup": true, "heart": true, "tada": true, "raised_hands": true, "clap": true,
+		"white_check_mark": true, "heavy_check_mark": true, "100": true, "pray": true, "rocket": true,
+		"joy": true, "smile": true, "slightly_smiling_face": true, "star-struck": true, "sparkles": true,
+	}
+	negativeReactions = map[string]bool{
+		"-1": true, "thumbsdown": true, "rage": true, "angry": true, "disappointed": true,
+		"confused": true, "face_with_raised_eyebrow": true, "grimacing": true, "face_palm": true,
+		"facepalm": true, "x": true, "no_entry": true, "scream": true, "cry": true, "sob": true,
+	}
+)
+
+// intensityPattern catches urgency and exasperation in message text
+var intensityPattern = regexp.MustCompile(`(?i)(!{2,}|\b(urgent|asap|blocking|blocker|critical|still broken|again\?|why is|wtf|unacceptable|escalat\w*)\b)`)
+
+// healthWeek holds one week's tallies for a channel
+type healthWeek struct {
+	start       time.Time
+	messages    int
+	reactions   int
+	positive    int
+	negative    int
+	intense     int
+	questions   int
+	unanswered  int
+	replyGapsMs []int64
+}
+
+func (w *healthWeek) medianGap() time.Duration {
+	if len(w.replyGapsMs) == 0 {
+		return 0
+	}
+	gaps := append([]int64(nil), w.replyGapsMs...)
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	return time.Duration(gaps[len(gaps)/2]) * time.Millisecond
+}
+
+func (w *healthWeek) intensity() float64 {
+	if w.messages == 0 {
+		return 0
+	}
+	return float64(w.intense) / float64(w.messages)
+}
+
+func (w *healthWeek) negativeShare() float64 {
+	if w.positive+w.negative == 0 {
+		return 0
+	}
+	return float64(w.negative) / float64(w.positive+w.negative)
+}
+
+// tallyHealth buckets human messages into weeks ending at now, oldest first
+func tallyHealth(msgs []slack.Message, now time.Time, weeks int) []*healthWeek {
+	out := make([]*healthWeek, weeks)
+	for i := range out {
+		out[i] = &healthWeek{start: now.AddDate(0, 0, -7*(weeks-i))}
+	}
+	sorted := append([]slack.Message(nil), msgs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Timestamp < sorted[j].Timestamp })
+
+	// A question asked moments ago hasn't had a chance to be answered
+	answerBy := now.Add(-2 * time.Hour)
+
+	var prev *slack.Message
+	for i := range sorted {
+		msg := &sorted[i]
+		if msg.SubType == "channel_join" || msg.SubType == "channel_leave" || msg.BotID != "" {
+			continue
+		}
+		t := parseSlackTimestamp(msg.Timestamp)
+		idx := int(t.Sub(out[0].start) / (7 * 24 * time.Hour))
+		if idx < 0 || idx >= weeks {
+			continue
+		}
+		w := out[idx]
+		w.messages++
+		for _, r := range msg.Reactions {
+			name, _, _ := strings.Cut(r.Name, "::") // Drop skin tones
+			w.reactions += r.Count
+			switch {
+			case positiveReactions[name]:
+				w.positive += r.Count
+			case negativeReactions[name]:
+				w.negative += r.Count
+			}
+		}
+		if intensityPattern.MatchString(msg.Text) {
+			w.intense++
+		}
+		if strings.Contains(msg.Text, "?") {
+			w.questions++
+			if msg.ReplyCount == 0 && t.Before(answerBy) {
+				w.unanswered++
+			}
+		}
+		// Someone else picking up the conversation is a response
+		if prev != nil && prev.User != msg.User {
+			if gap := t.Sub(parseSlackTimestamp(prev.Timestamp)); gap < healthMaxGap {
+				w.replyGapsMs = append(w.replyGapsMs, gap.Milliseconds())
+			}
+		}
+		prev = msg
+	}
+	return out
+}
+
+// healthVerdict compares the latest week against the ones before it:
+// "heated", "stalled", "quiet", or "healthy", with the reasons
+func healthVerdict(weeks []*healthWeek) (string, []string) {
+	last := weeks[len(weeks)-1]
+	prior := weeks[:len(weeks)-1]
+	var base healthWeek
+	var baseGaps []int64
+	for _, w := range prior {
+		base.messages += w.messages
+		base.intense += w.intense
+		baseGaps = append(baseGaps, w.replyGapsMs...)
+	}
+	base.replyGapsMs = baseGaps
+	baseMessages := 0.0
+	if len(prior) > 0 {
+		baseMessages = float64(base.messages) / float64(len(prior))
+	}
+
+	if last.messages == 0 && baseMessages < 1 {
+		return "quiet", nil
+	}
+
+	var heated, stalled []string
+	if last.messages >= 5 && last.intensity() >= 0.2 && last.intensity() >= 1.5*base.intensity() {
+		heated = append(heated, fmt.Sprintf("%.0f%% of messages read as urgent or frustrated (was %.0f%%)", 100*last.intensity(), 100*base.intensity()))
+	}
+	if last.positive+last.negative >= 4 && last.negativeShare() >= 0.3 {
+		heated = append(heated, fmt.Sprintf("%.0f%% of reactions are negative", 100*last.negativeShare()))
+	}
+	if baseMessages >= 5 && float64(last.messages) <= 0.5*baseMessages {
+		stalled = append(stalled, fmt.Sprintf("%d messages this week vs %.0f a week before that", last.messages, baseMessages))
+	}
+	if last.questions >= 3 && float64(last.unanswered) >= 0.5*float64(last.questions) {
+		stalled = append(stalled, fmt.Sprintf("%d of %d questions got no thread reply", last.unanswered, last.questions))
+	}
+	if g, b := last.medianGap(), base.medianGap(); len(last.replyGapsMs) >= 3 && b > 0 && g >= 2*b && g >= 30*time.Minute {
+		stalled = append(stalled, fmt.Sprintf("responses take %s, up from %s", formatGap(g), formatGap(b)))
+	}
+
+	switch {
+	case len(heated) > 0:
+		return "heated", append(heated, stalled...)
+	case len(stalled) > 0:
+		return "stalled", stalled
+	case last.messages == 0:
+		return "quiet", []string{"no messages this week"}
+	}
+	return "healthy", nil
+}
+
+// formatGap renders a response time compactly ("45s", "12m", "3.5h")
+func formatGap(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%.1fh", d.Hours())
+}
+
+func channelHealthHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	names := stringList(params["channels"])
+	if len(names) > healthMaxChannels {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Check at most %d channels at a time", healthMaxChannels),
+		}, nil
+	}
+	weeks := 4
+	if w, ok := params["weeks"].(float64); ok {
+		weeks = max(2, min(int(w), 8))
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	type target struct{ id, name string }
+	var targets []target
+	if len(names) == 0 {
+		ranked, err := scoreMyChannels(ctx, apiProvider, api, "30d")
+		if err != nil {
+			return &FeatureResult{
+				Success:  false,
+				Message:  fmt.Sprintf("Couldn't pick your channels: %v", err),
+				Guidance: "Name the channels to check with channels=['...']",
+			}, nil
+		}
+		for _, c := range ranked[:min(len(ranked), healthMaxChannels)] {
+			targets = append(targets, target{c.id, c.name})
+		}
+	}
+	for _, n := range names {
+		id := apiProvider.ResolveChannelID(strings.TrimPrefix(n, "#"))
+		if !isChannelID(id) {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Channel '%s' not found. Use list-channels to see available channels.", n),
+			}, nil
+		}
+		targets = append(targets, target{id, apiProvider.ResolveChannelName(ctx, id)})
+	}
+
+	now := time.Now()
+	oldest := now.AddDate(0, 0, -7*weeks)
+	statusRank := map[string]int{"heated": 0, "stalled": 1, "healthy": 2, "quiet": 3}
+	var channels []map[string]interface{}
+	var failed []string
+	var skipped, cutShort []string
+	var skipErr, cutErr error
+	flagged := 0
+	for _, tg := range targets {
+		var msgs []slack.Message
+		truncated := false
+		cursor := ""
+		var readErr error
+		for page := 0; ; page++ {
+			if page >= healthMaxPages {
+				truncated = true
+				break
+			}
+			resp, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+				ChannelID: tg.id,
+				Oldest:    fmt.Sprintf("%d", oldest.Unix()),
+				Limit:     200,
+				Cursor:    cursor,
+			})
+			if err != nil {
+				readErr = err
+				break
+			}
+			msgs = append(msgs, resp.Messages...)
+			cursor = resp.ResponseMetaData.NextCursor
+			if !resp.HasMore || cursor == "" {
+				break
+			}
+		}
+		if readErr != nil && len(msgs) == 0 {
+			failed = append(failed, fmt.Sprintf("#%s (%v)", tg.name, readErr))
+			skipped = append(skipped, "#"+tg.name)
+			skipErr = readErr
+			continue
+		}
+		if readErr != nil {
+			cutShort = append(cutShort, "#"+tg.name)
+			cutErr = readErr
+		}
+
+		tally := tallyHealth(msgs, now, weeks)
+		status, reasons := healthVerdict(tally)
+		if status == "heated" || status == "stalled" {
+			flagged++
+		}
+		trend := make([]map[string]interface{}, 0, weeks)
+		for _, w := range tally {
+			week := map[string]interface{}{
+				"week":                w.start.Format("Jan 2"),
+				"messages":            w.messages,
+				"reactionsPerMessage": "0",
+				"intensity":           fmt.Sprintf("%.0f%%", 100*w.intensity()),
+				"questions":           w.questions,
+				"unanswered":          w.unanswered,
+			}
+			if w.messages > 0 {
+				week["reactionsPerMessage"] = fmt.Sprintf("%.1f", float64(w.reactions)/float64(w.messages))
+			}
+			if w.positive+w.negative > 0 {
+				week["negativeReactions"] = fmt.Sprintf("%.0f%%", 100*w.negativeShare())
+			}
+			if g := w.medianGap(); g > 0 {
+				week["medianResponse"] = formatGap(g)
+			}
+			trend = append(trend, week)
+		}
+		channels = append(channels, map[string]interface{}{
+			"channel":   tg.name,
+			"status":    status,
+			"reasons":   reasons,
+			"weeks":     trend,
+			"truncated": truncated,
+		})
+	}
+	sort.SliceStable(channels, func(i, j int) bool {
+		return statusRank[channels[i]["status"].(string)] < statusRank[channels[j]["status"].(string)]
+	})
+
+	result := &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Checked %d channels over %d weeks: %d need attention", len(channels), weeks, flagged),
+		Data: map[string]interface{}{
+			"weeks":    weeks,
+			"channels": channels,
+			"flagged":  flagged,
+		},
+		ResultCount: len(channels),
+	}
+	if len(skipped) > 0 {
+		result.MarkPartial(Coverage{Reason: stopReason(skipErr), Detail: "some channels couldn't be read", Skipped: skipped})
+	}
+	if len(cutShort) > 0 {
+		result.MarkPartial(Coverage{Reason: stopReason(cutErr), Detail: "history stopped partway in " + strings.Join(cutShort, ", ")})
+	}
+	var deep []string
+	for _, ch := range channels {
+		if ch["truncated"] == true {
+			deep = append(deep, "#"+ch["channel"].(string))
+		}
+	}
+	if len(deep) > 0 {
+		result.MarkPartial(Coverage{
+			Reason: PartialMaxPages,
+			Detail: fmt.Sprintf("only the newest %d messages were read in %s", healthMaxPages*200, strings.Join(deep, ", ")),
+		})
+	}
+	if len(failed) > 0 {
+		result.Data.(map[string]interface{})["failed"] = failed
+		result.Guidance = "Couldn't read " + strings.Join(failed, ", ")
+	} else {
+		result.Guidance = "These are rough signals from reactions, wording, and reply timing; read the channel before acting on them"
+	}
+	if flagged > 0 {
+		result.NextActions = []string{
+			fmt.Sprintf("See what's going on: catch-up channel='%s' since='7d'", channels[0]["channel"]),
+			fmt.Sprintf("Get the gist: brief-me-on-channel channel='%s'", channels[0]["channel"]),
+		}
+	}
+	return result, nil
+}
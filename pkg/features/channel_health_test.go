@@ -0,0 +1,60 @@
+package features
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+func healthMsg(t time.Time, user, text string) slack.Message {
+	m := slack.Message{}
+	m.Timestamp = fmt.Sprintf("%d.000100", t.Unix())
+	m.User = user
+	m.Text = text
+	return m
+}
+
+func TestChannelHealthVerdicts(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	steady := func() []slack.Message {
+		var msgs []slack.Message
+		for week := 1; week <= 3; week++ {
+			start := now.AddDate(0, 0, -7*week-3)
+			for i := 0; i < 10; i++ {
+				msgs = append(msgs, healthMsg(start.Add(time.Duration(i)*10*time.Minute), fmt.Sprintf("U%d", i%2), "sounds good"))
+			}
+		}
+		return msgs
+	}
+
+	status, _ := healthVerdict(tallyHealth(append(steady(), func() []slack.Message {
+		var msgs []slack.Message
+		for i := 0; i < 10; i++ {
+			msgs = append(msgs, healthMsg(now.Add(-time.Duration(30+i*10)*time.Minute), fmt.Sprintf("U%d", i%2), "fine"))
+		}
+		return msgs
+	}()...), now, 4))
+	if status != "healthy" {
+		t.Errorf("steady channel = %s", status)
+	}
+
+	var angry []slack.Message
+	for i := 0; i < 6; i++ {
+		angry = append(angry, healthMsg(now.Add(-time.Duration(3+i)*time.Hour), fmt.Sprintf("U%d", i%2), "this is still broken!! urgent"))
+	}
+	status, reasons := healthVerdict(tallyHealth(append(steady(), angry...), now, 4))
+	if status != "heated" || len(reasons) == 0 {
+		t.Errorf("angry week = %s %v", status, reasons)
+	}
+
+	quietWeek := []slack.Message{healthMsg(now.Add(-3*time.Hour), "U1", "anyone?")}
+	if status, _ := healthVerdict(tallyHealth(append(steady(), quietWeek...), now, 4)); status != "stalled" {
+		t.Errorf("dropped-off week = %s", status)
+	}
+
+	if status, _ := healthVerdict(tallyHealth(nil, now, 4)); status != "quiet" {
+		t.Errorf("empty channel = %s", status)
+	}
+}
@@ -0,0 +1,334 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// RankMyChannels scores the user's channels by how much they matter to them.
+// The same score decides what mark-read filter='non-important' clears.
+var RankMyChannels = &Feature{
+	Name:        "rank-my-channels",
+	Description: "Rank your channels by importance to you — how often you post there, how often you're mentioned, priority configuration, and recent activity — with a per-channel breakdown",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"since": map[string]interface{}{
+				"type":        "string",
+				"description": "Window for posting and mention activity (e.g., '2w', '30d')",
+				"default":     "30d",
+			},
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": "Maximum channels to return (default: 25, max: 200)",
+				"default":     25,
+			},
+		},
+	},
+	Handler: rankMyChannelsHandler,
+}
+
+// Channel importance scoring.
+//
+//	SLACK_MCP_PRIORITY_CHANNELS="incidents,team-core"
+//	SLACK_MCP_CHANNEL_WEIGHTS="posts=3,mentions=2,priority=2,recency=1"
+//
+// Posts and mentions are log-scaled so one very busy channel doesn't drown
+// out the rest; recency halves every importanceRecencyHalfLife.
+const (
+	importanceThreshold       = 1.5
+	importanceRecencyHalfLife = 7 * 24 * time.Hour
+	// importanceSearchPages bounds search.messages calls per signal
+	importanceSearchPages = 3
+)
+
+type importanceWeights struct {
+	posts    float64
+	mentions float64
+	priority float64
+	recency  float64
+}
+
+var defaultImportanceWeights = importanceWeights{posts: 3, mentions: 2, priority: 2, recency: 1}
+
+type channelImportance struct {
+	id           string
+	name         string
+	posts        int
+	mentions     int
+	priority     float64
+	lastActivity time.Time
+	score        float64
+}
+
+// loadImportanceWeights reads SLACK_MCP_CHANNEL_WEIGHTS over the defaults
+func loadImportanceWeights() importanceWeights {
+	w := defaultImportanceWeights
+	spec := strings.TrimSpace(os.Getenv("SLACK_MCP_CHANNEL_WEIGHTS"))
+	if spec == "" {
+		return w
+	}
+	for _, part := range strings.Split(spec, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if !ok || err != nil || v < 0 {
+			log.Printf("Ignoring SLACK_MCP_CHANNEL_WEIGHTS entry %q", part)
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "posts":
+			w.posts = v
+		case "mentions":
+			w.mentions = v
+		case "priority":
+			w.priority = v
+		case "recency":
+			w.recency = v
+		default:
+			log.Printf("Ignoring unknown SLACK_MCP_CHANNEL_WEIGHTS key %q", key)
+		}
+	}
+	return w
+}
+
+// channelPriority combines explicit priority channels with the naming
+// taxonomy: incidents and listed channels count fully, projects and
+// announcements half, low-priority channels negatively
+func channelPriority(name string, priorityChannels map[string]bool, taxonomy []taxonomyRule) float64 {
+	p := 0.0
+	if priorityChannels[strings.ToLower(name)] {
+		p++
+	}
+	switch channelCategory(taxonomy, name) {
+	case categoryIncident:
+		p++
+	case categoryProject, categoryAnnouncement:
+		p += 0.5
+	case categoryLow:
+		p--
+	}
+	return p
+}
+
+// loadPriorityChannels reads SLACK_MCP_PRIORITY_CHANNELS as a set of
+// lowercased channel names
+func loadPriorityChannels() map[string]bool {
+	priorityChannels := map[string]bool{}
+	for _, name := range stringList(os.Getenv("SLACK_MCP_PRIORITY_CHANNELS")) {
+		priorityChannels[strings.ToLower(strings.TrimPrefix(name, "#"))] = true
+	}
+	return priorityChannels
+}
+
+// computeScore sets the weighted importance as of now
+func (c *channelImportance) computeScore(w importanceWeights, now time.Time) {
+	recency := 0.0
+	if !c.lastActivity.IsZero() {
+		recency = math.Pow(0.5, float64(now.Sub(c.lastActivity))/float64(importanceRecencyHalfLife))
+	}
+	c.score = w.posts*math.Log1p(float64(c.posts)) +
+		w.mentions*math.Log1p(float64(c.mentions)) +
+		w.priority*c.priority +
+		w.recency*recency
+}
+
+func (c *channelImportance) important() bool {
+	return c.score >= importanceThreshold
+}
+
+// scoreMyChannels scores every non-archived channel the user belongs to.
+// Posting and mention activity come from search.messages; recency from the
+// unread counts when the internal client is available.
+func scoreMyChannels(ctx context.Context, ap *provider.ApiProvider, api *slack.Client, since string) ([]*channelImportance, error) {
+	byID := map[string]*channelImportance{}
+	taxonomy := loadChannelTaxonomy()
+	priorityChannels := loadPriorityChannels()
+
+	for _, ch := range ap.GetCachedChannels() {
+		if !ch.IsMember || ch.IsArchived || ch.IsIM || ch.IsMpIM {
+			continue
+		}
+		byID[ch.ID] = &channelImportance{
+			id:       ch.ID,
+			name:     ch.Name,
+			priority: channelPriority(ch.Name, priorityChannels, taxonomy),
+		}
+	}
+
+	identity := ap.ProvideIdentity()
+	if identity == nil || identity.Username == "" {
+		return nil, fmt.Errorf("current user is unknown")
+	}
+	window := parseTimeframeToDateFilter(since)
+
+	count := func(query string, add func(c *channelImportance, t time.Time)) error {
+		for page := 1; page <= importanceSearchPages; page++ {
+			sp := slack.NewSearchParameters()
+			sp.Sort = "timestamp"
+			sp.Count = 100
+			sp.Page = page
+			res, err := api.SearchMessagesContext(ctx, query, sp)
+			if err != nil {
+				return err
+			}
+			for _, m := range res.Matches {
+				if c, ok := byID[m.Channel.ID]; ok {
+					add(c, parseSlackTimestamp(m.Timestamp))
+				}
+			}
+			if res.Paging.Pages <= page {
+				break
+			}
+		}
+		return nil
+	}
+
+	if err := count("from:@"+identity.Username+" "+window, func(c *channelImportance, t time.Time) {
+		c.posts++
+		if t.After(c.lastActivity) {
+			c.lastActivity = t
+		}
+	}); err != nil {
+		return nil, err
+	}
+	if err := count("@"+identity.Username+" "+window, func(c *channelImportance, _ time.Time) {
+		c.mentions++
+	}); err != nil {
+		return nil, err
+	}
+
+	if ic := ap.ProvideInternalClient(); ic != nil {
+		if counts, err := ic.GetClientCounts(ctx); err == nil && counts.OK {
+			for _, ch := range counts.Channels {
+				c, ok := byID[ch.ID]
+				if !ok || ch.Latest == "" {
+					continue
+				}
+				if t := parseSlackTimestamp(ch.Latest); t.After(c.lastActivity) {
+					c.lastActivity = t
+				}
+			}
+		}
+	}
+
+	weights := loadImportanceWeights()
+	now := time.Now()
+	ranked := make([]*channelImportance, 0, len(byID))
+	for _, c := range byID {
+		c.computeScore(weights, now)
+		ranked = append(ranked, c)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].name < ranked[j].name
+	})
+	return ranked, nil
+}
+
+func rankMyChannelsHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	since := "30d"
+	if s, ok := params["since"].(string); ok && s != "" {
+		since = s
+	}
+	limit := 25
+	if l, ok := params["limit"].(float64); ok {
+		limit = int(l)
+		if limit > 200 {
+			limit = 200
+		}
+		if limit < 1 {
+			limit = 1
+		}
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	ranked, err := scoreMyChannels(ctx, apiProvider, api, since)
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to score channels: %v", err),
+		}, nil
+	}
+
+	importantCount := 0
+	for _, c := range ranked {
+		if c.important() {
+			importantCount++
+		}
+	}
+
+	shown := ranked
+	if len(shown) > limit {
+		shown = shown[:limit]
+	}
+	channels := make([]map[string]interface{}, 0, len(shown))
+	for i, c := range shown {
+		entry := map[string]interface{}{
+			"rank":      i + 1,
+			"channel":   c.name,
+			"channelId": c.id,
+			"score":     math.Round(c.score*100) / 100,
+			"important": c.important(),
+			"posts":     c.posts,
+			"mentions":  c.mentions,
+			"priority":  c.priority,
+		}
+		if !c.lastActivity.IsZero() {
+			entry["lastActivity"] = formatTimestamp(c.lastActivity)
+		}
+		channels = append(channels, entry)
+	}
+
+	w := loadImportanceWeights()
+	return &FeatureResult{
+		Success: true,
+		Data: map[string]interface{}{
+			"channels": channels,
+			"since":    since,
+			"weights": map[string]interface{}{
+				"posts":    w.posts,
+				"mentions": w.mentions,
+				"priority": w.priority,
+				"recency":  w.recency,
+			},
+			"threshold":      importanceThreshold,
+			"importantCount": importantCount,
+			"totalChannels":  len(ranked),
+		},
+		Message:     fmt.Sprintf("Ranked %d channels; %d count as important", len(ranked), importantCount),
+		ResultCount: len(channels),
+		Guidance: fmt.Sprintf("Channels scoring below %.1f are cleared by mark-read filter='non-important'. "+
+			"Tune with SLACK_MCP_CHANNEL_WEIGHTS (posts, mentions, priority, recency) and SLACK_MCP_PRIORITY_CHANNELS.", importanceThreshold),
+		NextActions: []string{
+			"mark-read target='all-channels' filter='non-important'",
+			"catch-up channel='<top channel>'",
+		},
+	}, nil
+}
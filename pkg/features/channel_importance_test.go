@@ -0,0 +1,61 @@
+package features
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChannelImportanceScore(t *testing.T) {
+	now := time.Now()
+	w := defaultImportanceWeights
+
+	quiet := &channelImportance{lastActivity: now.Add(-30 * 24 * time.Hour)}
+	quiet.computeScore(w, now)
+	if quiet.important() {
+		t.Errorf("old, silent channel scored %.2f", quiet.score)
+	}
+
+	busy := &channelImportance{lastActivity: now}
+	busy.computeScore(w, now)
+	if busy.important() {
+		t.Errorf("recent activity alone should not be important (%.2f)", busy.score)
+	}
+
+	posted := &channelImportance{posts: 1}
+	posted.computeScore(w, now)
+	if !posted.important() {
+		t.Errorf("a channel you post in scored only %.2f", posted.score)
+	}
+
+	low := &channelImportance{posts: 1, priority: -1}
+	low.computeScore(w, now)
+	if low.important() {
+		t.Errorf("low-priority channel with one post scored %.2f", low.score)
+	}
+}
+
+func TestChannelPriority(t *testing.T) {
+	rules, _ := parseChannelTaxonomy("inc-*=incident,proj-*=project,social-*=low")
+	listed := map[string]bool{"team-core": true}
+	cases := map[string]float64{
+		"team-core":   1,
+		"inc-42":      1,
+		"proj-atlas":  0.5,
+		"social-pets": -1,
+		"general":     0,
+	}
+	for name, want := range cases {
+		if got := channelPriority(name, listed, rules); got != want {
+			t.Errorf("channelPriority(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestLoadImportanceWeights(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNEL_WEIGHTS", "posts=1, recency=0, bogus=2, mentions=x")
+	w := loadImportanceWeights()
+	want := importanceWeights{posts: 1, mentions: 2, priority: 2, recency: 0}
+	if w != want {
+		t.Errorf("weights = %+v, want %+v", w, want)
+	}
+}
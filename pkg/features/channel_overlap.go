@@ -0,0 +1,280 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// AnalyzeChannelOverlap compares the membership and recent participants of
+// two or more channels, to help decide whether they should be consolidated
+var AnalyzeChannelOverlap = &Feature{
+	Name:        "analyze-channel-overlap",
+	Description: "Compare two or more channels: shared members and shared active participants, to judge whether they overlap enough to consolidate",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channels": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Channel names or IDs to compare (at least two)",
+			},
+			"since": map[string]interface{}{
+				"type":        "string",
+				"description": "How far back to look for active participants (e.g., '2w', '30d')",
+				"default":     "30d",
+			},
+		},
+		"required": []string{"channels"},
+	},
+	Handler: analyzeChannelOverlapHandler,
+}
+
+const (
+	// overlapMaxChannels bounds members and history fetches per call
+	overlapMaxChannels = 6
+	// overlapMaxHistoryPages bounds history fetching per channel
+	overlapMaxHistoryPages = 10
+)
+
+type overlapChannel struct {
+	id      string
+	name    string
+	members map[string]bool
+	active  map[string]int
+}
+
+func analyzeChannelOverlapHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	names := stringList(params["channels"])
+	if len(names) < 2 {
+		return &FeatureResult{
+			Success: false,
+			Message: "Provide at least two channels to compare",
+		}, nil
+	}
+	if len(names) > overlapMaxChannels {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Compare at most %d channels at a time", overlapMaxChannels),
+		}, nil
+	}
+	since := "30d"
+	if s, ok := params["since"].(string); ok && s != "" {
+		since = s
+	}
+	oldest, _ := parseTimePeriod(since)
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	var channels []*overlapChannel
+	for _, name := range names {
+		id := apiProvider.ResolveChannelID(strings.TrimPrefix(name, "#"))
+		if !isChannelID(id) {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Channel '%s' not found. Use list-channels to see available channels.", name),
+			}, nil
+		}
+		ch := &overlapChannel{
+			id:      id,
+			name:    apiProvider.ResolveChannelName(ctx, id),
+			members: map[string]bool{},
+			active:  map[string]int{},
+		}
+		if ch.name == "" {
+			ch.name = strings.TrimPrefix(name, "#")
+		}
+
+		members, err := channelMembers(ctx, api, id)
+		if err != nil {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Failed to list members of #%s: %v", ch.name, err),
+			}, nil
+		}
+		for _, m := range members {
+			ch.members[m] = true
+		}
+
+		// History is best-effort: non-member public channels still report membership
+		if err := channelActivity(ctx, api, id, oldest, ch.active); err != nil {
+			ch.active = nil
+		}
+		channels = append(channels, ch)
+	}
+
+	usersMap := apiProvider.ProvideUsersMap()
+	nameList := func(ids map[string]bool) []string {
+		out := make([]string, 0, len(ids))
+		for id := range ids {
+			out = append(out, userDisplayName(id, usersMap))
+		}
+		sort.Strings(out)
+		return out
+	}
+
+	summaries := make([]map[string]interface{}, 0, len(channels))
+	for _, ch := range channels {
+		s := map[string]interface{}{
+			"channel": ch.name,
+			"members": len(ch.members),
+		}
+		if ch.active != nil {
+			s["activeParticipants"] = len(ch.active)
+		}
+		summaries = append(summaries, s)
+	}
+
+	pairs := []map[string]interface{}{}
+	maxSimilarity := 0.0
+	for i := 0; i < len(channels); i++ {
+		for j := i + 1; j < len(channels); j++ {
+			a, b := channels[i], channels[j]
+			shared := intersectKeys(a.members, b.members)
+			similarity := jaccard(len(shared), len(a.members), len(b.members))
+			if similarity > maxSimilarity {
+				maxSimilarity = similarity
+			}
+			pair := map[string]interface{}{
+				"channels":      []string{a.name, b.name},
+				"sharedMembers": len(shared),
+				"similarity":    fmt.Sprintf("%.0f%%", similarity*100),
+			}
+			if a.active != nil && b.active != nil {
+				sharedActive := intersectKeys(toSet(a.active), toSet(b.active))
+				pair["sharedActive"] = nameList(sharedActive)
+			}
+			pairs = append(pairs, pair)
+		}
+	}
+
+	inAll := channels[0].members
+	for _, ch := range channels[1:] {
+		inAll = intersectKeys(inAll, ch.members)
+	}
+
+	data := map[string]interface{}{
+		"since":         since,
+		"channels":      summaries,
+		"pairs":         pairs,
+		"membersInAll":  len(inAll),
+		"maxSimilarity": fmt.Sprintf("%.0f%%", maxSimilarity*100),
+	}
+	if len(inAll) <= 20 {
+		data["membersInAllNames"] = nameList(inAll)
+	}
+
+	result := &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Compared %d channels: %d people are in all of them", len(channels), len(inAll)),
+		Data:    data,
+	}
+
+	switch {
+	case maxSimilarity >= 0.7:
+		result.Guidance = "High membership overlap: these channels largely reach the same people and are good candidates for consolidation."
+	case maxSimilarity >= 0.4:
+		result.Guidance = "Moderate overlap. Check whether the shared active participants are having the same conversations in both places."
+	default:
+		result.Guidance = "Low overlap: these channels serve mostly different audiences."
+	}
+	result.NextActions = []string{
+		fmt.Sprintf("Compare recent topics: catch-up channel='%s' since='%s'", channels[0].name, since),
+	}
+
+	return result, nil
+}
+
+// channelMembers pages through conversations.members
+func channelMembers(ctx context.Context, api *slack.Client, channelID string) ([]string, error) {
+	var all []string
+	cursor := ""
+	for {
+		members, next, err := api.GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
+			ChannelID: channelID,
+			Cursor:    cursor,
+			Limit:     1000,
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, members...)
+		if next == "" {
+			return all, nil
+		}
+		cursor = next
+	}
+}
+
+// channelActivity counts human messages per user since oldest
+func channelActivity(ctx context.Context, api *slack.Client, channelID string, oldest time.Time, counts map[string]int) error {
+	cursor := ""
+	for page := 0; page < overlapMaxHistoryPages; page++ {
+		resp, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: channelID,
+			Oldest:    fmt.Sprintf("%d", oldest.Unix()),
+			Limit:     200,
+			Cursor:    cursor,
+		})
+		if err != nil {
+			return err
+		}
+		for _, msg := range resp.Messages {
+			if msg.User == "" || msg.BotID != "" || msg.SubType == "channel_join" || msg.SubType == "channel_leave" {
+				continue
+			}
+			counts[msg.User]++
+		}
+		cursor = resp.ResponseMetaData.NextCursor
+		if !resp.HasMore || cursor == "" {
+			break
+		}
+	}
+	return nil
+}
+
+func intersectKeys(a, b map[string]bool) map[string]bool {
+	out := map[string]bool{}
+	for k := range a {
+		if b[k] {
+			out[k] = true
+		}
+	}
+	return out
+}
+
+func toSet(m map[string]int) map[string]bool {
+	out := make(map[string]bool, len(m))
+	for k := range m {
+		out[k] = true
+	}
+	return out
+}
+
+// jaccard is |A∩B| / |A∪B|, or 0 for two empty sets
+func jaccard(shared, a, b int) float64 {
+	union := a + b - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
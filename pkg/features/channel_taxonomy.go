@@ -0,0 +1,87 @@
+package features
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"strings"
+)
+
+// Channel naming conventions carry intent: inc-* channels are live
+// incidents, social-* can wait. Taxonomy rules map name globs to categories
+// that tune catch-up defaults, mark-read filters, and list-channels grouping.
+//
+//	SLACK_MCP_CHANNEL_TAXONOMY="inc-*=incident,proj-*=project,social-*=low"
+//
+// Rules are checked in order and the first match wins. Setting the variable
+// replaces the defaults below; "off" disables categorization.
+const (
+	categoryIncident     = "incident"
+	categoryProject      = "project"
+	categoryAnnouncement = "announcement"
+	categoryLow          = "low"
+)
+
+const defaultChannelTaxonomy = "inc-*=incident,incident-*=incident,proj-*=project,project-*=project," +
+	"announce*=announcement,social-*=low,fun-*=low,random=low"
+
+type taxonomyRule struct {
+	pattern  string
+	category string
+}
+
+// loadChannelTaxonomy returns the configured rules, falling back to the
+// defaults when the environment spec is missing or invalid
+func loadChannelTaxonomy() []taxonomyRule {
+	spec := strings.TrimSpace(os.Getenv("SLACK_MCP_CHANNEL_TAXONOMY"))
+	if strings.EqualFold(spec, "off") {
+		return nil
+	}
+	if spec != "" {
+		rules, err := parseChannelTaxonomy(spec)
+		if err == nil {
+			return rules
+		}
+		log.Printf("Ignoring SLACK_MCP_CHANNEL_TAXONOMY: %v", err)
+	}
+	rules, _ := parseChannelTaxonomy(defaultChannelTaxonomy)
+	return rules
+}
+
+// parseChannelTaxonomy parses "glob=category,glob=category"
+func parseChannelTaxonomy(spec string) ([]taxonomyRule, error) {
+	var rules []taxonomyRule
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		glob, category, ok := strings.Cut(part, "=")
+		glob = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(glob), "#"))
+		category = strings.ToLower(strings.TrimSpace(category))
+		if !ok || glob == "" || category == "" {
+			return nil, fmt.Errorf("expected glob=category, got %q", part)
+		}
+		if _, err := path.Match(glob, ""); err != nil {
+			return nil, fmt.Errorf("bad pattern %q: %v", glob, err)
+		}
+		rules = append(rules, taxonomyRule{pattern: glob, category: category})
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("no rules in %q", spec)
+	}
+	return rules, nil
+}
+
+// channelCategory returns the category of the first rule matching the
+// channel name, or "" when none does
+func channelCategory(rules []taxonomyRule, name string) string {
+	name = strings.ToLower(strings.TrimPrefix(name, "#"))
+	for _, r := range rules {
+		if ok, _ := path.Match(r.pattern, name); ok {
+			return r.category
+		}
+	}
+	return ""
+}
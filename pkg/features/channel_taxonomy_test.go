@@ -0,0 +1,49 @@
+package features
+
+import "testing"
+
+func TestChannelCategory(t *testing.T) {
+	rules, err := parseChannelTaxonomy("inc-*=incident, #proj-*=Project,social-*=low,random=low")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string]string{
+		"inc-2024-db-outage": categoryIncident,
+		"#proj-apollo":       categoryProject,
+		"Social-Dogs":        categoryLow,
+		"random":             categoryLow,
+		"random-thoughts":    "",
+		"engineering":        "",
+	}
+	for name, want := range cases {
+		if got := channelCategory(rules, name); got != want {
+			t.Errorf("channelCategory(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestParseChannelTaxonomyErrors(t *testing.T) {
+	for _, spec := range []string{"", "inc-*", "=incident", "[=bad"} {
+		if _, err := parseChannelTaxonomy(spec); err == nil {
+			t.Errorf("parseChannelTaxonomy(%q): expected an error", spec)
+		}
+	}
+}
+
+func TestLoadChannelTaxonomy(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNEL_TAXONOMY", "")
+	if got := channelCategory(loadChannelTaxonomy(), "inc-42"); got != categoryIncident {
+		t.Errorf("default rules: inc-42 = %q", got)
+	}
+
+	t.Setenv("SLACK_MCP_CHANNEL_TAXONOMY", "ops-*=incident")
+	rules := loadChannelTaxonomy()
+	if channelCategory(rules, "ops-pager") != categoryIncident || channelCategory(rules, "inc-42") != "" {
+		t.Error("configured rules should replace the defaults")
+	}
+
+	t.Setenv("SLACK_MCP_CHANNEL_TAXONOMY", "off")
+	if rules := loadChannelTaxonomy(); rules != nil {
+		t.Errorf("off: got %v", rules)
+	}
+}
@@ -17,8 +17,8 @@ var CheckUnreads = &Feature{
 		"properties": map[string]interface{}{
 			"focus": map[string]interface{}{
 				"type":        "string",
-				"enum":        []string{"all", "dms", "mentions", "channels"},
-				"description": "What type of unreads to focus on",
+				"enum":        []string{"all", "dms", "mentions", "channels", "app-dms"},
+				"description": "What type of unreads to focus on. 'app-dms' lists unread DMs from Slackbot and apps (GitHub, PagerDuty, calendar) with their alert content parsed out",
 				"default":     "all",
 			},
 			"includeChannels": map[string]interface{}{
@@ -31,6 +31,15 @@ var CheckUnreads = &Feature{
 				"description": "Maximum items per category (default: 10, max: 25)",
 				"default":     10,
 			},
+			"section": map[string]interface{}{
+				"type":        "string",
+				"description": "Only conversations in this sidebar section (e.g. 'Projects'), to work through unreads a section at a time; browser session token only",
+			},
+			"cacheOnly": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Answer instantly from cached data without calling Slack (results may be stale). Used automatically while the server is still connecting.",
+				"default":     false,
+			},
 		},
 	},
 	Handler: checkUnreadsReal,
@@ -75,14 +84,14 @@ func checkUnreadsHandler(ctx context.Context, params map[string]interface{}) (*F
 	}
 
 	// Get current user info
-	authTest, err := api.AuthTest()
+	self, err := provider.Self()
 	if err != nil {
 		return &FeatureResult{
 			Success: false,
 			Message: fmt.Sprintf("Failed to get user info: %v", err),
 		}, nil
 	}
-	currentUserID := authTest.UserID
+	currentUserID := self.UserID
 	usersMap := provider.ProvideUsersMap()
 
 	// Initialize result categories
@@ -90,12 +99,14 @@ func checkUnreadsHandler(ctx context.Context, params map[string]interface{}) (*F
 		"dms":      []map[string]interface{}{},
 		"mentions": []map[string]interface{}{},
 		"channels": []map[string]interface{}{},
+		"appDMs":   []map[string]interface{}{},
 	}
 
 	stats := map[string]interface{}{
 		"totalDMs":      0,
 		"totalMentions": 0,
 		"totalChannels": 0,
+		"totalAppDMs":   0,
 		"urgent":        0,
 	}
 
@@ -125,6 +136,10 @@ func checkUnreadsHandler(ctx context.Context, params map[string]interface{}) (*F
 		if !isDM && !includeChannels && focus == "dms" {
 			continue
 		}
+		appDM := channel.IsIM && isAppUser(channel.User, usersMap)
+		if focus == "app-dms" && !appDM {
+			continue
+		}
 
 		// Get recent messages
 		histParams := &slack.GetConversationHistoryParameters{
@@ -171,6 +186,22 @@ func checkUnreadsHandler(ctx context.Context, params map[string]interface{}) (*F
 			}
 		}
 
+		if appDM && focus == "app-dms" {
+			appDMs := unreads["appDMs"].([]map[string]interface{})
+			msgs := resp.Messages
+			if len(msgs) > channel.UnreadCount {
+				msgs = msgs[:channel.UnreadCount]
+			}
+			if len(appDMs) < limit && len(msgs) > 0 {
+				dm := appDMEntry(appName(channel.User, usersMap), channel.ID, msgs, nil)
+				if dm["urgent"].(bool) {
+					stats["urgent"] = stats["urgent"].(int) + 1
+				}
+				unreads["appDMs"] = append(appDMs, dm)
+			}
+			stats["totalAppDMs"] = stats["totalAppDMs"].(int) + 1
+		}
+
 		// Process mentions in channels
 		if !isDM && (focus == "all" || focus == "mentions") {
 			mentionPattern := fmt.Sprintf("<@%s>", currentUserID)
@@ -191,7 +222,7 @@ func checkUnreadsHandler(ctx context.Context, params map[string]interface{}) (*F
 						"message":   msg.Text,
 						"timestamp": formatTimestamp(parseSlackTimestamp(msg.Timestamp)),
 						"channelId": channel.ID,
-						"threadId":  fmt.Sprintf("%s:%s", channel.ID, msg.Timestamp),
+						"threadId":  threadRefFor(channel.ID, msg.Timestamp, msg.ThreadTimestamp).String(),
 						"urgent":    isUrgent,
 					}
 
@@ -246,10 +277,16 @@ func checkUnreadsHandler(ctx context.Context, params map[string]interface{}) (*F
 			stats["totalDMs"], stats["totalMentions"], stats["totalChannels"]),
 		ResultCount: stats["totalDMs"].(int) + stats["totalMentions"].(int) + stats["totalChannels"].(int),
 	}
+	if focus == "app-dms" {
+		result.Message = fmt.Sprintf("Found %d app DMs with unreads", stats["totalAppDMs"])
+		result.ResultCount = len(unreads["appDMs"].([]map[string]interface{}))
+	}
 
 	// Add guidance based on findings
 	if stats["urgent"].(int) > 0 {
 		result.Guidance = fmt.Sprintf("🚨 You have %d urgent items that need immediate attention", stats["urgent"].(int))
+	} else if stats["totalAppDMs"].(int) > 0 {
+		result.Guidance = fmt.Sprintf("🤖 You have %d app DMs to triage", stats["totalAppDMs"].(int))
 	} else if stats["totalDMs"].(int) > 0 {
 		result.Guidance = fmt.Sprintf("💬 You have %d unread DMs to catch up on", stats["totalDMs"].(int))
 	} else if stats["totalMentions"].(int) > 0 {
@@ -264,7 +301,7 @@ func checkUnreadsHandler(ctx context.Context, params map[string]interface{}) (*F
 		result.NextActions = append(result.NextActions, "Use 'catch-up' with a DM channel ID to see full conversation")
 	}
 	if stats["totalMentions"].(int) > 0 {
-		result.NextActions = append(result.NextActions, "Use 'search' with threadId to see full thread context")
+		result.NextActions = append(result.NextActions, "Use 'read-thread' with threadId to see full thread context")
 	}
 
 	return result, nil
@@ -39,8 +39,19 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 		}, nil
 	}
 
+	section, _ := params["section"].(string)
+	section = strings.TrimSpace(section)
+
+	if useCacheOnly(params, apiProvider) {
+		return checkUnreadsFromCache(ctx, apiProvider, focus, includeChannels, limit, section), nil
+	}
+
 	// Get internal client
 	internalClient := apiProvider.ProvideInternalClient()
+	if internalClient == nil && section != "" {
+		_, _, failed := loadSidebar(ctx, apiProvider, section, false)
+		return failed, nil
+	}
 	if internalClient == nil {
 		// Fallback to the original implementation
 		log.Println("Internal client not available, falling back to standard API")
@@ -59,6 +70,18 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 		return checkUnreadsHandler(ctx, params)
 	}
 
+	if section != "" {
+		sidebar, idx, failed := loadSidebar(ctx, apiProvider, section, false)
+		if failed != nil {
+			return failed, nil
+		}
+		counts = countsInSection(counts, sidebar, idx)
+	}
+
+	// Starred conversations go first so the per-category limit keeps them
+	starred, _ := apiProvider.StarredConversations(ctx)
+	counts = starredFirst(counts, starred)
+
 	// Get standard Slack client for additional info
 	api, err := apiProvider.Provide()
 	if err != nil {
@@ -69,14 +92,14 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 	}
 
 	// Get current user info
-	authTest, err := api.AuthTest()
+	self, err := apiProvider.Self()
 	if err != nil {
 		return &FeatureResult{
 			Success: false,
 			Message: fmt.Sprintf("Failed to get user info: %v", err),
 		}, nil
 	}
-	currentUserID := authTest.UserID
+	currentUserID := self.UserID
 	usersMap := apiProvider.ProvideUsersMap()
 
 	// Initialize result categories
@@ -84,13 +107,18 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 		"dms":      []map[string]interface{}{},
 		"mentions": []map[string]interface{}{},
 		"channels": []map[string]interface{}{},
+		"appDMs":   []map[string]interface{}{},
 	}
 
+	var links permalinkBatch
+	var unreadable skippedChannels
+
 	stats := map[string]interface{}{
 		"totalDMs":             0,
 		"totalMentions":        0,
 		"totalChannels":        0,
 		"totalChannelMessages": 0,
+		"totalAppDMs":          0,
 		"urgent":               0,
 	}
 
@@ -124,11 +152,10 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 				}
 
 				// Get channel info for user details
-				info, err := api.GetConversationInfo(&slack.GetConversationInfoInput{
-					ChannelID: im.ID,
-				})
+				info, err := apiProvider.GetChannelInfo(ctx, im.ID)
 				if err != nil {
 					log.Printf("Failed to get DM info for %s: %v", im.ID, err)
+					unreadable.skip(apiProvider, im.ID, err)
 					continue
 				}
 
@@ -140,6 +167,7 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 				resp, err := api.GetConversationHistoryContext(ctx, histParams)
 				if err != nil {
 					log.Printf("Failed to get DM history for %s: %v", im.ID, err)
+					unreadable.skip(apiProvider, im.ID, err)
 					continue
 				}
 
@@ -162,11 +190,14 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 							isUrgent = true
 						}
 
-						messages = append(messages, map[string]interface{}{
+						entry := map[string]interface{}{
 							"text":      msg.Text,
 							"timestamp": formatTimestamp(parseSlackTimestamp(msg.Timestamp)),
 							"user":      getUserName(msg.User, usersMap),
-						})
+						}
+						addAttachmentInfo(entry, "text", msg.Files, msg.Attachments, msg.Blocks)
+						links.add(entry, im.ID, msg.Timestamp)
+						messages = append(messages, entry)
 					}
 
 					if isUrgent {
@@ -183,6 +214,10 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 						"readPolicy":  shouldMarkAsRead,
 					}
 
+					if starred[im.ID] {
+						dm["starred"] = true
+					}
+
 					// Add summary for large message counts
 					if len(messages) > 15 {
 						dm["summary"] = fmt.Sprintf("Conversation with %d unread messages, showing recent %d", unreadCount, len(messages))
@@ -207,11 +242,10 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 		for _, mpim := range counts.MPIMs {
 			if mpim.MentionCount > 0 && mentionCount < limit {
 				// Get channel info
-				info, err := api.GetConversationInfo(&slack.GetConversationInfoInput{
-					ChannelID: mpim.ID,
-				})
+				info, err := apiProvider.GetChannelInfo(ctx, mpim.ID)
 				if err != nil {
 					log.Printf("Failed to get MPIM info for %s: %v", mpim.ID, err)
+					unreadable.skip(apiProvider, mpim.ID, err)
 					continue
 				}
 
@@ -223,6 +257,7 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 				resp, err := api.GetConversationHistoryContext(ctx, histParams)
 				if err != nil {
 					log.Printf("Failed to get MPIM history for %s: %v", mpim.ID, err)
+					unreadable.skip(apiProvider, mpim.ID, err)
 					continue
 				}
 
@@ -244,12 +279,14 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 							"type":      "mention",
 							"channel":   info.Name,
 							"author":    authorName,
-							"message":   msg.Text,
+							"message":   messageBody(msg.Text, msg.Blocks, msg.Attachments),
 							"timestamp": formatTimestamp(parseSlackTimestamp(msg.Timestamp)),
 							"channelId": mpim.ID,
-							"threadId":  fmt.Sprintf("%s:%s", mpim.ID, msg.Timestamp),
+							"threadId":  threadRefFor(mpim.ID, msg.Timestamp, msg.ThreadTimestamp).String(),
 							"urgent":    isUrgent,
 						}
+						addAttachmentInfo(mention, "message", msg.Files, msg.Attachments, msg.Blocks)
+						links.add(mention, mpim.ID, msg.Timestamp)
 
 						unreads["mentions"] = append(unreads["mentions"].([]map[string]interface{}), mention)
 						stats["totalMentions"] = stats["totalMentions"].(int) + 1
@@ -264,11 +301,10 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 		for _, ch := range counts.Channels {
 			if ch.MentionCount > 0 && mentionCount < limit {
 				// Get channel info
-				info, err := api.GetConversationInfo(&slack.GetConversationInfoInput{
-					ChannelID: ch.ID,
-				})
+				info, err := apiProvider.GetChannelInfo(ctx, ch.ID)
 				if err != nil {
 					log.Printf("Failed to get channel info for %s: %v", ch.ID, err)
+					unreadable.skip(apiProvider, ch.ID, err)
 					continue
 				}
 
@@ -280,6 +316,7 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 				resp, err := api.GetConversationHistoryContext(ctx, histParams)
 				if err != nil {
 					log.Printf("Failed to get channel history for %s: %v", ch.ID, err)
+					unreadable.skip(apiProvider, ch.ID, err)
 					continue
 				}
 
@@ -303,12 +340,14 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 							"type":      "mention",
 							"channel":   info.Name,
 							"author":    authorName,
-							"message":   msg.Text,
+							"message":   messageBody(msg.Text, msg.Blocks, msg.Attachments),
 							"timestamp": formatTimestamp(parseSlackTimestamp(msg.Timestamp)),
 							"channelId": ch.ID,
-							"threadId":  fmt.Sprintf("%s:%s", ch.ID, msg.Timestamp),
+							"threadId":  threadRefFor(ch.ID, msg.Timestamp, msg.ThreadTimestamp).String(),
 							"urgent":    isUrgent,
 						}
+						addAttachmentInfo(mention, "message", msg.Files, msg.Attachments, msg.Blocks)
+						links.add(mention, ch.ID, msg.Timestamp)
 
 						unreads["mentions"] = append(unreads["mentions"].([]map[string]interface{}), mention)
 						stats["totalMentions"] = stats["totalMentions"].(int) + 1
@@ -330,11 +369,10 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 		for _, ch := range counts.Channels {
 			if ch.HasUnreads && channelCount < limit {
 				// Get channel details
-				info, err := api.GetConversationInfo(&slack.GetConversationInfoInput{
-					ChannelID: ch.ID,
-				})
+				info, err := apiProvider.GetChannelInfo(ctx, ch.ID)
 				if err != nil {
 					log.Printf("Failed to get channel info for %s: %v", ch.ID, err)
+					unreadable.skip(apiProvider, ch.ID, err)
 					continue
 				}
 
@@ -362,6 +400,7 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 					authorName := getUserName(lastMsg.User, usersMap)
 					channelData["lastMessage"] = fmt.Sprintf("%s: %s", authorName, truncateMessage(lastMsg.Text, 100))
 					channelData["timestamp"] = formatTimestamp(parseSlackTimestamp(lastMsg.Timestamp))
+					links.add(channelData, ch.ID, lastMsg.Timestamp)
 				}
 
 				unreads["channels"] = append(unreads["channels"].([]map[string]interface{}), channelData)
@@ -371,6 +410,23 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 		}
 	}
 
+	// App DMs only when asked for; each costs a history read
+	if focus == "app-dms" {
+		appDMs, total := unreadAppDMs(ctx, apiProvider, api, counts, usersMap, limit, &links)
+		for _, dm := range appDMs {
+			if dm["urgent"].(bool) {
+				stats["urgent"] = stats["urgent"].(int) + 1
+			}
+		}
+		markStarred(appDMs, starred)
+		unreads["appDMs"] = appDMs
+		stats["totalAppDMs"] = total
+	}
+
+	links.resolve(ctx, apiProvider)
+	markStarred(unreads["mentions"].([]map[string]interface{}), starred)
+	markStarred(unreads["channels"].([]map[string]interface{}), starred)
+
 	// Build result
 	result := &FeatureResult{
 		Success: true,
@@ -386,6 +442,8 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 		ResultCount: stats["totalDMs"].(int) + stats["totalMentions"].(int) + stats["totalChannels"].(int),
 	}
 
+	unreadable.mark(result, "some conversations with unreads couldn't be read")
+
 	// Add thread unreads if available
 	if counts.Threads.UnreadCount > 0 {
 		result.Data.(map[string]interface{})["threadUnreads"] = map[string]interface{}{
@@ -394,10 +452,16 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 		}
 		result.Message += fmt.Sprintf(" (+ %d thread unreads)", counts.Threads.UnreadCount)
 	}
+	if focus == "app-dms" {
+		result.Message = fmt.Sprintf("Found %d app DMs with unreads", stats["totalAppDMs"])
+		result.ResultCount = len(unreads["appDMs"].([]map[string]interface{}))
+	}
 
 	// Add guidance based on findings
 	if stats["urgent"].(int) > 0 {
 		result.Guidance = fmt.Sprintf("🚨 You have %d urgent items that need immediate attention", stats["urgent"].(int))
+	} else if stats["totalAppDMs"].(int) > 0 {
+		result.Guidance = fmt.Sprintf("🤖 You have %d app DMs to triage", stats["totalAppDMs"].(int))
 	} else if stats["totalDMs"].(int) > 0 {
 		result.Guidance = fmt.Sprintf("💬 You have %d unread DMs to catch up on", stats["totalDMs"].(int))
 	} else if stats["totalMentions"].(int) > 0 {
@@ -412,7 +476,10 @@ func checkUnreadsReal(ctx context.Context, params map[string]interface{}) (*Feat
 		result.NextActions = append(result.NextActions, "Use 'catch-up' with a DM channel ID to see full conversation")
 	}
 	if stats["totalMentions"].(int) > 0 {
-		result.NextActions = append(result.NextActions, "Use 'search' with threadId to see full thread context")
+		result.NextActions = append(result.NextActions, "Use 'read-thread' with threadId to see full thread context")
+	}
+	if n := counts.ChannelBadges.AppDMs; n > 0 && focus != "app-dms" {
+		result.NextActions = append(result.NextActions, fmt.Sprintf("%d app DMs unread (alerts, review requests): check-unreads focus='app-dms'", n))
 	}
 
 	// Add contextual search hint based on volume
@@ -0,0 +1,365 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+// CollectMessage files a message into a named local collection ("research",
+// "follow-ups"), a personal knowledge layer kept on disk and independent of
+// Slack's saved items
+var CollectMessage = &Feature{
+	Name:        "collect-message",
+	Description: "File a message into a named local collection (e.g. 'research', 'follow-ups') with an optional note, or remove it again",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"collection": map[string]interface{}{
+				"type":        "string",
+				"description": "Collection name (created on first use)",
+			},
+			"permalink": map[string]interface{}{
+				"type":        "string",
+				"description": "Slack permalink of the message (or give channel and messageTs)",
+			},
+			"channel": map[string]interface{}{
+				"type":        "string",
+				"description": "Channel name or ID containing the message",
+			},
+			"messageTs": map[string]interface{}{
+				"type":        "string",
+				"description": "Timestamp of the message",
+			},
+			"note": map[string]interface{}{
+				"type":        "string",
+				"description": "Why you're keeping it",
+			},
+			"remove": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Remove the message from the collection instead",
+				"default":     false,
+			},
+		},
+		"required": []string{"collection"},
+	},
+	Handler: collectMessageHandler,
+}
+
+// ListCollection shows the messages filed into a collection, or all
+// collections when none is named
+var ListCollection = &Feature{
+	Name:        "list-collection",
+	Description: "List the messages filed into a local collection, or all collections with their sizes",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"collection": map[string]interface{}{
+				"type":        "string",
+				"description": "Collection to list (omit to list collections)",
+			},
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": "Maximum messages to return, newest first (default: 50)",
+				"default":     50,
+			},
+		},
+	},
+	Handler: listCollectionHandler,
+}
+
+const collectionsCacheFile = "collections.json"
+
+// collectionsMu serializes read-modify-write on the collections file
+var collectionsMu sync.Mutex
+
+// collectedMessage is a message reference with a snapshot of its text, so a
+// collection reads without refetching and survives the message's deletion
+type collectedMessage struct {
+	ChannelID string    `json:"channelId"`
+	Channel   string    `json:"channel"`
+	Ts        string    `json:"ts"`
+	ThreadTs  string    `json:"threadTs,omitempty"`
+	Author    string    `json:"author"`
+	Text      string    `json:"text"`
+	Permalink string    `json:"permalink,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	Added     time.Time `json:"added"`
+}
+
+func collectionName(s string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s), "#")))
+}
+
+// fileMessage adds m to items, replacing an earlier copy of the same message
+// (so re-filing updates the note). It reports whether m was new.
+func fileMessage(items []collectedMessage, m collectedMessage) ([]collectedMessage, bool) {
+	for i, it := range items {
+		if it.ChannelID == m.ChannelID && it.Ts == m.Ts {
+			if m.Note == "" {
+				m.Note = it.Note
+			}
+			m.Added = it.Added
+			items[i] = m
+			return items, false
+		}
+	}
+	return append(items, m), true
+}
+
+// unfileMessage removes a message from items, reporting whether it was there
+func unfileMessage(items []collectedMessage, channelID, ts string) ([]collectedMessage, bool) {
+	for i, it := range items {
+		if it.ChannelID == channelID && it.Ts == ts {
+			return append(items[:i], items[i+1:]...), true
+		}
+	}
+	return items, false
+}
+
+func loadCollections(p *provider.ApiProvider) map[string][]collectedMessage {
+	collections := map[string][]collectedMessage{}
+	if store := p.Store(); store != nil {
+		_ = store.Load(collectionsCacheFile, &collections)
+	}
+	return collections
+}
+
+func collectMessageHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	name, _ := params["collection"].(string)
+	name = collectionName(name)
+	if name == "" {
+		return &FeatureResult{
+			Success:  false,
+			Message:  "collection is required",
+			Guidance: "Name a collection, e.g. collection='research'",
+		}, nil
+	}
+	note, _ := params["note"].(string)
+	remove, _ := params["remove"].(bool)
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	// A permalink's p-timestamp is the message itself; thread_ts, when
+	// present, is its parent
+	var channelID, ts, threadTs string
+	if link, _ := params["permalink"].(string); link != "" {
+		m := permalinkPathPattern.FindStringSubmatch(link)
+		if m == nil {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("invalid permalink %q", link),
+			}, nil
+		}
+		ref, _ := parsePermalinkRef(link)
+		channelID, ts = ref.ChannelID, m[2][:len(m[2])-6]+"."+m[2][len(m[2])-6:]
+		if ref.ThreadTs != ts {
+			threadTs = ref.ThreadTs
+		}
+	} else {
+		channel, _ := params["channel"].(string)
+		ts, _ = params["messageTs"].(string)
+		if channel == "" || ts == "" {
+			return &FeatureResult{
+				Success: false,
+				Message: "Give the message as a permalink, or as channel and messageTs",
+			}, nil
+		}
+		channelID = apiProvider.ResolveChannelID(strings.TrimPrefix(channel, "#"))
+		if !isChannelID(channelID) {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Channel '%s' not found. Use list-channels to see available channels.", channel),
+			}, nil
+		}
+	}
+
+	collectionsMu.Lock()
+	defer collectionsMu.Unlock()
+	collections := loadCollections(apiProvider)
+
+	var (
+		item    collectedMessage
+		message string
+	)
+	if remove {
+		var found bool
+		collections[name], found = unfileMessage(collections[name], channelID, ts)
+		if !found {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("That message isn't in '%s'", name),
+			}, nil
+		}
+		if len(collections[name]) == 0 {
+			delete(collections, name)
+		}
+		message = fmt.Sprintf("Removed from '%s'", name)
+	} else {
+		api, err := apiProvider.Provide()
+		if err != nil {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+			}, nil
+		}
+		msg, err := fetchMessage(ctx, api, channelID, ts)
+		if err != nil {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Could not load the message: %v", err),
+			}, nil
+		}
+		if threadTs == "" && msg.ThreadTimestamp != "" && msg.ThreadTimestamp != ts {
+			threadTs = msg.ThreadTimestamp
+		}
+		item = collectedMessage{
+			ChannelID: channelID,
+			Channel:   apiProvider.ResolveChannelName(ctx, channelID),
+			Ts:        ts,
+			ThreadTs:  threadTs,
+			Author:    userDisplayName(msg.User, apiProvider.ProvideUsersMap()),
+			Text:      messageBody(msg.Text, msg.Blocks, msg.Attachments),
+			Note:      strings.TrimSpace(note),
+			Added:     time.Now(),
+		}
+		if link, err := apiProvider.Permalink(ctx, channelID, ts); err == nil {
+			item.Permalink = link
+		}
+		var added bool
+		collections[name], added = fileMessage(collections[name], item)
+		message = fmt.Sprintf("Filed in '%s' (%d messages)", name, len(collections[name]))
+		if !added {
+			message = fmt.Sprintf("Already in '%s'; updated", name)
+		}
+	}
+
+	if store := apiProvider.Store(); store != nil {
+		if err := store.Save(collectionsCacheFile, collections); err != nil {
+			log.Printf("Failed to save collections: %v", err)
+		}
+	}
+
+	result := &FeatureResult{
+		Success:     true,
+		Message:     message,
+		NextActions: []string{fmt.Sprintf("list-collection collection='%s'", name)},
+	}
+	if !remove {
+		result.Data = map[string]interface{}{
+			"collection": name,
+			"message":    collectedEntry(item),
+		}
+	}
+	return result, nil
+}
+
+func listCollectionHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	name, _ := params["collection"].(string)
+	name = collectionName(name)
+	limit := 50
+	if l, ok := params["limit"].(float64); ok && l > 0 {
+		limit = int(l)
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	collectionsMu.Lock()
+	collections := loadCollections(apiProvider)
+	collectionsMu.Unlock()
+
+	if name == "" {
+		list := make([]map[string]interface{}, 0, len(collections))
+		for n, items := range collections {
+			var last time.Time
+			for _, it := range items {
+				if it.Added.After(last) {
+					last = it.Added
+				}
+			}
+			list = append(list, map[string]interface{}{
+				"name":      n,
+				"count":     len(items),
+				"lastAdded": formatTimestamp(last),
+			})
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i]["name"].(string) < list[j]["name"].(string) })
+		result := &FeatureResult{
+			Success:     true,
+			Data:        map[string]interface{}{"collections": list},
+			Message:     fmt.Sprintf("%d collection(s)", len(list)),
+			ResultCount: len(list),
+		}
+		if len(list) == 0 {
+			result.Guidance = "File a message with collect-message collection='research' permalink='...'"
+		}
+		return result, nil
+	}
+
+	items, ok := collections[name]
+	if !ok {
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("No collection named '%s'", name),
+			Guidance: "Call list-collection without a name to see your collections",
+		}, nil
+	}
+	sorted := append([]collectedMessage(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Added.After(sorted[j].Added) })
+	total := len(sorted)
+	if len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+	list := make([]map[string]interface{}, 0, len(sorted))
+	for _, it := range sorted {
+		list = append(list, collectedEntry(it))
+	}
+
+	return &FeatureResult{
+		Success: true,
+		Data: map[string]interface{}{
+			"collection": name,
+			"messages":   list,
+			"total":      total,
+		},
+		Message:     fmt.Sprintf("'%s': %d message(s)", name, total),
+		ResultCount: len(list),
+		NextActions: []string{"Read one in context: read-thread threadId='<threadId>'"},
+	}, nil
+}
+
+func collectedEntry(it collectedMessage) map[string]interface{} {
+	entry := map[string]interface{}{
+		"channel":   it.Channel,
+		"author":    it.Author,
+		"text":      it.Text,
+		"timestamp": formatTimestamp(parseSlackTimestamp(it.Ts)),
+		"threadId":  threadRefFor(it.ChannelID, it.Ts, it.ThreadTs).String(),
+		"added":     formatTimestamp(it.Added),
+	}
+	if it.Note != "" {
+		entry["note"] = it.Note
+	}
+	if it.Permalink != "" {
+		entry["permalink"] = it.Permalink
+	}
+	return entry
+}
@@ -0,0 +1,37 @@
+package features
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFileMessage(t *testing.T) {
+	first := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	items, added := fileMessage(nil, collectedMessage{ChannelID: "C1", Ts: "1.000001", Note: "read later", Added: first})
+	if !added || len(items) != 1 {
+		t.Fatalf("first file: added=%v len=%d", added, len(items))
+	}
+
+	items, added = fileMessage(items, collectedMessage{ChannelID: "C1", Ts: "1.000001", Text: "edited", Added: first.Add(time.Hour)})
+	if added || len(items) != 1 {
+		t.Fatalf("refile: added=%v len=%d", added, len(items))
+	}
+	if items[0].Note != "read later" || items[0].Text != "edited" || !items[0].Added.Equal(first) {
+		t.Errorf("refile should refresh the snapshot and keep note and added time: %+v", items[0])
+	}
+
+	items, _ = fileMessage(items, collectedMessage{ChannelID: "C2", Ts: "1.000001"})
+	items, removed := unfileMessage(items, "C1", "1.000001")
+	if !removed || len(items) != 1 || items[0].ChannelID != "C2" {
+		t.Errorf("unfile: removed=%v items=%+v", removed, items)
+	}
+	if _, removed = unfileMessage(items, "C1", "1.000001"); removed {
+		t.Error("unfile of a missing message reported removal")
+	}
+}
+
+func TestCollectionName(t *testing.T) {
+	if got := collectionName("  #Follow-Ups "); got != "follow-ups" {
+		t.Errorf("collectionName = %q", got)
+	}
+}
@@ -0,0 +1,224 @@
+package features
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/aaronsb/slack-mcp/pkg/tickets"
+	"github.com/slack-go/slack"
+)
+
+// CreateTicketFromThread packages a thread (summary, participants,
+// permalink) as a ticket and hands it to the configured tracker bridge
+var CreateTicketFromThread = &Feature{
+	Name:        "create-ticket-from-thread",
+	Description: "Turn a thread into a ticket in your tracker (Jira, Linear, or any webhook/command): title, summary, participants, and a link back to Slack",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"threadId": map[string]interface{}{
+				"type":        "string",
+				"description": "Thread to file (channelId:threadTs or a permalink)",
+			},
+			"title": map[string]interface{}{
+				"type":        "string",
+				"description": "Ticket title (default: the start of the thread's first message)",
+			},
+			"summary": map[string]interface{}{
+				"type":        "string",
+				"description": "What the ticket is about, in your words. The thread's opening, latest reply, and any decision are appended.",
+			},
+			"labels": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Labels to set, where the tracker supports them",
+			},
+			"announce": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Reply in the thread with the ticket link",
+				"default":     false,
+			},
+			"preview": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Show the payload without sending it",
+				"default":     false,
+			},
+		},
+		"required": []string{"threadId"},
+	},
+	Handler: createTicketFromThreadHandler,
+}
+
+func createTicketFromThreadHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	threadID, _ := params["threadId"].(string)
+	ref, err := parseThreadRef(threadID)
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+	title, _ := params["title"].(string)
+	summary, _ := params["summary"].(string)
+	announce, _ := params["announce"].(bool)
+	preview, _ := params["preview"].(bool)
+
+	bridge := tickets.FromEnv()
+	if bridge == nil {
+		return &FeatureResult{
+			Success: false,
+			Message: "No ticket tracker configured",
+			Guidance: "Set SLACK_MCP_TICKET_WEBHOOK (an HTTP endpoint that accepts JSON) or SLACK_MCP_TICKET_COMMAND " +
+				"(a command that reads the JSON payload on stdin), and SLACK_MCP_TICKET_FORMAT=generic|jira|linear",
+		}, nil
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	replies, _, _, err := api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: ref.ChannelID,
+		Timestamp: ref.ThreadTs,
+		Limit:     200,
+	})
+	if err != nil || len(replies) == 0 {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Could not load thread %s: %v", ref, err),
+		}, nil
+	}
+
+	usersMap := apiProvider.ProvideUsersMap()
+	ticket := tickets.Ticket{
+		Title:        strings.TrimSpace(title),
+		Channel:      apiProvider.ResolveChannelName(ctx, ref.ChannelID),
+		ThreadID:     ref.String(),
+		Participants: getUniqueParticipants(replies, usersMap),
+		Labels:       stringList(params["labels"]),
+		Created:      parseSlackTimestamp(replies[0].Timestamp),
+	}
+	if self, err := apiProvider.Self(); err == nil {
+		ticket.Reporter = userDisplayName(self.UserID, usersMap)
+	}
+	if link, err := apiProvider.Permalink(ctx, ref.ChannelID, ref.ThreadTs); err == nil {
+		ticket.Permalink = link
+	}
+	if ticket.Title == "" {
+		ticket.Title = truncate(readableText(messageBody(replies[0].Text, replies[0].Blocks, replies[0].Attachments), usersMap), 80)
+	}
+	ticket.Description = ticketDescription(summary, ticket, replies, usersMap)
+
+	if preview {
+		payload, err := bridge.Payload(ticket)
+		if err != nil {
+			return &FeatureResult{
+				Success: false,
+				Message: err.Error(),
+			}, nil
+		}
+		body, _ := json.MarshalIndent(payload, "", "  ")
+		return &FeatureResult{
+			Success: true,
+			Message: fmt.Sprintf("Preview of the %s ticket for %s", bridge.Format(), bridge.Target()),
+			Data: map[string]interface{}{
+				"title":   ticket.Title,
+				"payload": string(body),
+			},
+			NextActions: []string{fmt.Sprintf("create-ticket-from-thread threadId='%s'", ref)},
+		}, nil
+	}
+
+	res, err := bridge.Create(ctx, ticket)
+	if err != nil {
+		log.Printf("Failed to create ticket: %v", err)
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Failed to create ticket: %v", err),
+			Guidance: "Check the tracker configuration; preview=true shows the payload that would be sent",
+		}, nil
+	}
+	name := res.Key
+	if name == "" {
+		name = res.ID
+	}
+
+	data := map[string]interface{}{
+		"title":    ticket.Title,
+		"ticketId": res.ID,
+		"key":      res.Key,
+		"url":      res.URL,
+		"threadId": ref.String(),
+	}
+	result := &FeatureResult{
+		Success: true,
+		Message: strings.TrimSpace(fmt.Sprintf("Created ticket %s %s", name, res.URL)),
+		Data:    data,
+	}
+
+	if announce {
+		text := fmt.Sprintf(":ticket: Filed as %s", strings.TrimSpace(name+" "+res.URL))
+		if qh := loadQuietHours(); qh != nil && qh.active(time.Now()) {
+			result.Guidance = "Quiet hours are in effect, so the thread wasn't told about the ticket"
+		} else if _, _, err := api.PostMessageContext(ctx, ref.ChannelID, slack.MsgOptionText(text, false), slack.MsgOptionTS(ref.ThreadTs)); err != nil {
+			result.Guidance = fmt.Sprintf("⚠️ Ticket created, but replying in the thread failed: %v", err)
+		} else {
+			apiProvider.RecordAction(provider.UsageMessagesSent, 1)
+			data["announced"] = true
+		}
+	} else {
+		result.NextActions = []string{
+			fmt.Sprintf("Let the thread know: send-message channel='%s' threadTs='%s'", ref.ChannelID, ref.ThreadTs),
+		}
+	}
+	return result, nil
+}
+
+// ticketDescription combines the caller's summary with the thread's
+// opening, latest reply, and any decision, and links back to Slack
+func ticketDescription(summary string, t tickets.Ticket, replies []slack.Message, usersMap map[string]slack.User) string {
+	var b strings.Builder
+	if s := strings.TrimSpace(summary); s != "" {
+		b.WriteString(s + "\n\n")
+	}
+	quote := func(label string, msg slack.Message) {
+		text := readableText(messageBody(msg.Text, msg.Blocks, msg.Attachments), usersMap)
+		b.WriteString(fmt.Sprintf("%s (%s): %s\n\n", label, userDisplayName(msg.User, usersMap), truncate(text, 500)))
+	}
+	quote("Opening message", replies[0])
+	for i := len(replies) - 1; i > 0; i-- {
+		if decisionPattern.MatchString(replies[i].Text) {
+			quote("Decision", replies[i])
+			break
+		}
+	}
+	if len(replies) > 1 {
+		quote("Latest reply", replies[len(replies)-1])
+	}
+	b.WriteString(fmt.Sprintf("Slack thread in #%s, %d replies", t.Channel, len(replies)-1))
+	if len(t.Participants) > 0 {
+		b.WriteString(", participants: " + strings.Join(t.Participants, ", "))
+	}
+	b.WriteString("\n")
+	if t.Permalink != "" {
+		b.WriteString(t.Permalink + "\n")
+	}
+	return b.String()
+}
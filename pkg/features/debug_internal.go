@@ -0,0 +1,123 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/paths"
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+// DebugInternal reports how Slack's internal endpoints have been answering,
+// for diagnosing a response shape Slack changed without notice
+var DebugInternal = &Feature{
+	Name:        "debug-internal",
+	Description: "Diagnose internal Slack endpoints (client.counts, client.boot, thread view): fields that came back with an unexpected type or couldn't be parsed since startup. dump=true calls each endpoint now and saves the raw responses to local files.",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"dump": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Call the read-only internal endpoints now, report their top-level shape and unmodeled fields, and save the raw responses (which hold message content) to the data directory",
+				"default":     false,
+			},
+		},
+	},
+	Handler: debugInternalHandler,
+}
+
+func debugInternalHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+	ic := apiProvider.ProvideInternalClient()
+	if ic == nil {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal endpoints need a browser session (xoxc) token",
+		}, nil
+	}
+
+	data := map[string]interface{}{
+		"debug": ic.SchemaDebug(),
+	}
+	dump, _ := params["dump"].(bool)
+	if dump {
+		var probes []map[string]interface{}
+		for _, p := range ic.ProbeSchemas(ctx) {
+			keys := make([]string, 0, len(p.Shape))
+			for k := range p.Shape {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			shape := make([]string, 0, len(keys))
+			for _, k := range keys {
+				shape = append(shape, k+": "+p.Shape[k])
+			}
+			probes = append(probes, map[string]interface{}{
+				"endpoint": p.Endpoint,
+				"error":    p.Error,
+				"shape":    shape,
+			})
+		}
+		data["probes"] = probes
+
+		dir := paths.DataDir()
+		if store := apiProvider.Store(); store != nil {
+			dir = store.Dir()
+		}
+		dir = filepath.Join(dir, "internal-dumps", time.Now().Format("20060102-150405"))
+		files, err := ic.WriteCaptures(dir)
+		if err != nil {
+			data["dumpError"] = err.Error()
+		}
+		data["files"] = files
+	}
+
+	issues := ic.SchemaIssues()
+	entries := make([]map[string]interface{}, 0, len(issues))
+	drift := 0
+	for _, issue := range issues {
+		if issue.Kind != provider.SchemaUnknownField {
+			drift++
+		}
+		entries = append(entries, map[string]interface{}{
+			"endpoint": issue.Endpoint,
+			"kind":     issue.Kind,
+			"detail":   issue.Detail,
+			"count":    issue.Count,
+			"lastSeen": issue.LastSeen.Format(time.RFC3339),
+		})
+	}
+	data["issues"] = entries
+
+	result := &FeatureResult{
+		Success:     true,
+		Data:        data,
+		ResultCount: len(entries),
+	}
+	switch {
+	case drift > 0:
+		result.Message = fmt.Sprintf("%d internal response problems since startup", drift)
+		result.Guidance = "Type mismatches leave that field empty and keep the rest of the response; unparseable responses fail the call. Attach a dump to a bug report after checking it for anything private."
+	default:
+		result.Message = "No internal response problems since startup"
+	}
+	if !dump {
+		result.NextActions = append(result.NextActions, "See what the endpoints return now: debug-internal dump=true")
+	} else {
+		result.Guidance = strings.TrimSpace("The saved files hold message content; purge-local-data removes them. " + result.Guidance)
+	}
+	if !ic.SchemaDebug() {
+		result.NextActions = append(result.NextActions, "Set SLACK_MCP_DEBUG=1 to check every response for unmodeled fields and keep its raw payload")
+	}
+	return result, nil
+}
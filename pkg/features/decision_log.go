@@ -0,0 +1,249 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// LogDecision records a decision reached in a thread to a decision log: a
+// channel such as #decisions, or a canvas. Without explicit text it takes
+// the latest decision-like reply, the same heuristic catch-up rollups use.
+var LogDecision = &Feature{
+	Name:        "log-decision",
+	Description: "Record a decision from a thread in the decision log channel or canvas: the decision, who took part, the date, and a link back to the thread",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"threadId": map[string]interface{}{
+				"type":        "string",
+				"description": "Thread the decision was made in (channelId:threadTs or a permalink)",
+			},
+			"decision": map[string]interface{}{
+				"type":        "string",
+				"description": "The decision in one or two sentences. Defaults to the latest decision-like reply in the thread.",
+			},
+			"target": map[string]interface{}{
+				"type":        "string",
+				"description": "Where to log it: a channel ('#decisions') or 'canvas:<canvas ID>' (default: SLACK_MCP_DECISION_LOG or #decisions)",
+			},
+			"preview": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Show the entry without posting it",
+				"default":     false,
+			},
+		},
+		"required": []string{"threadId"},
+	},
+	Handler: logDecisionHandler,
+}
+
+// Decision log destination.
+//
+//	SLACK_MCP_DECISION_LOG="#decisions"        a channel (default)
+//	SLACK_MCP_DECISION_LOG="canvas:F0123ABCD"  append to a canvas
+const defaultDecisionLog = "#decisions"
+
+type decisionRecord struct {
+	Text         string
+	Channel      string
+	Permalink    string
+	Participants []string
+	DecidedBy    string
+	Date         time.Time
+}
+
+// decisionLogTarget returns the configured destination, falling back to
+// #decisions
+func decisionLogTarget() string {
+	if t := strings.TrimSpace(os.Getenv("SLACK_MCP_DECISION_LOG")); t != "" {
+		return t
+	}
+	return defaultDecisionLog
+}
+
+// slackText renders the entry as a Slack message
+func (d decisionRecord) slackText() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(":white_check_mark: *Decision* — %s\n", d.Date.Format("2006-01-02")))
+	for _, line := range strings.Split(strings.TrimSpace(d.Text), "\n") {
+		b.WriteString("> " + line + "\n")
+	}
+	where := "#" + d.Channel
+	if d.Permalink != "" {
+		where += fmt.Sprintf(" · <%s|thread>", d.Permalink)
+	}
+	b.WriteString("*Where:* " + where + "\n")
+	if d.DecidedBy != "" {
+		b.WriteString("*Decided by:* " + d.DecidedBy + "\n")
+	}
+	if len(d.Participants) > 0 {
+		b.WriteString("*Participants:* " + strings.Join(d.Participants, ", ") + "\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// markdown renders the entry as a canvas section
+func (d decisionRecord) markdown() string {
+	var b strings.Builder
+	title, _, _ := strings.Cut(strings.TrimSpace(d.Text), "\n")
+	b.WriteString(fmt.Sprintf("## %s — %s\n\n", d.Date.Format("2006-01-02"), truncate(title, 80)))
+	b.WriteString(strings.TrimSpace(d.Text) + "\n\n")
+	where := "#" + d.Channel
+	if d.Permalink != "" {
+		where = fmt.Sprintf("[#%s thread](%s)", d.Channel, d.Permalink)
+	}
+	b.WriteString("- **Where:** " + where + "\n")
+	if d.DecidedBy != "" {
+		b.WriteString("- **Decided by:** " + d.DecidedBy + "\n")
+	}
+	if len(d.Participants) > 0 {
+		b.WriteString("- **Participants:** " + strings.Join(d.Participants, ", ") + "\n")
+	}
+	return b.String()
+}
+
+func logDecisionHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	threadID, _ := params["threadId"].(string)
+	ref, err := parseThreadRef(threadID)
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+	text, _ := params["decision"].(string)
+	target, _ := params["target"].(string)
+	if strings.TrimSpace(target) == "" {
+		target = decisionLogTarget()
+	}
+	preview, _ := params["preview"].(bool)
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	replies, _, _, err := api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: ref.ChannelID,
+		Timestamp: ref.ThreadTs,
+		Limit:     200,
+	})
+	if err != nil || len(replies) == 0 {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Could not load thread %s: %v", ref, err),
+		}, nil
+	}
+
+	usersMap := apiProvider.ProvideUsersMap()
+	record := decisionRecord{
+		Text:         strings.TrimSpace(text),
+		Channel:      apiProvider.ResolveChannelName(ctx, ref.ChannelID),
+		Participants: getUniqueParticipants(replies, usersMap),
+		Date:         time.Now(),
+	}
+	linkTs := ref.ThreadTs
+	for i := len(replies) - 1; i >= 0; i-- {
+		if decisionPattern.MatchString(replies[i].Text) {
+			if record.Text == "" {
+				record.Text = replies[i].Text
+			}
+			record.DecidedBy = userDisplayName(replies[i].User, usersMap)
+			record.Date = parseSlackTimestamp(replies[i].Timestamp)
+			linkTs = replies[i].Timestamp
+			break
+		}
+	}
+	if record.Text == "" {
+		return &FeatureResult{
+			Success:  false,
+			Message:  "No decision-like reply found in this thread",
+			Guidance: "Pass the decision text explicitly with decision='...'",
+		}, nil
+	}
+	if link, err := apiProvider.Permalink(ctx, ref.ChannelID, linkTs); err == nil {
+		record.Permalink = link
+	}
+
+	canvasID, isCanvas := strings.CutPrefix(target, "canvas:")
+	entry := record.slackText()
+	if isCanvas {
+		entry = record.markdown()
+	}
+	data := map[string]interface{}{
+		"target":       target,
+		"entry":        entry,
+		"decision":     record.Text,
+		"participants": record.Participants,
+		"date":         record.Date.Format("2006-01-02"),
+		"threadId":     ref.String(),
+	}
+	if preview {
+		return &FeatureResult{
+			Success:     true,
+			Message:     fmt.Sprintf("Preview of the decision log entry for %s", target),
+			Data:        data,
+			NextActions: []string{fmt.Sprintf("log-decision threadId='%s' target='%s'", ref, target)},
+		}, nil
+	}
+
+	if qh := loadQuietHours(); qh != nil && qh.active(time.Now()) {
+		return quietHoursBlocked(qh, "posting", time.Now()), nil
+	}
+
+	if isCanvas {
+		err = api.EditCanvasContext(ctx, slack.EditCanvasParams{
+			CanvasID: strings.TrimSpace(canvasID),
+			Changes: []slack.CanvasChange{{
+				Operation:       "insert_at_end",
+				DocumentContent: slack.DocumentContent{Type: "markdown", Markdown: entry},
+			}},
+		})
+	} else {
+		channelID := resolveChannelForSending(apiProvider, api, target)
+		if channelID == "" {
+			return &FeatureResult{
+				Success:  false,
+				Message:  fmt.Sprintf("Could not find decision log channel '%s'", target),
+				Guidance: "Create the channel, pass target='#channel', or set SLACK_MCP_DECISION_LOG",
+			}, nil
+		}
+		_, _, err = api.PostMessageContext(ctx, channelID, slack.MsgOptionText(entry, false))
+	}
+	if err != nil {
+		log.Printf("Failed to log decision: %v", err)
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Failed to log decision to %s: %v", target, err),
+			Guidance: "⚠️ Check that you can post there",
+		}, nil
+	}
+	apiProvider.RecordAction(provider.UsageMessagesSent, 1)
+
+	return &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Decision logged to %s", target),
+		Data:    data,
+		NextActions: []string{
+			fmt.Sprintf("Let the thread know: send-message channel='%s' threadTs='%s'", ref.ChannelID, ref.ThreadTs),
+		},
+	}, nil
+}
@@ -0,0 +1,37 @@
+package features
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecisionRecordRendering(t *testing.T) {
+	d := decisionRecord{
+		Text:         "We'll go with Postgres.\nRevisit in Q3.",
+		Channel:      "eng",
+		Permalink:    "https://example.slack.com/archives/C1/p1",
+		Participants: []string{"alice", "bob"},
+		DecidedBy:    "alice",
+		Date:         time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC),
+	}
+
+	text := d.slackText()
+	for _, want := range []string{"2026-03-04", "> We'll go with Postgres.\n> Revisit in Q3.", "<https://example.slack.com/archives/C1/p1|thread>", "*Participants:* alice, bob"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("slackText missing %q:\n%s", want, text)
+		}
+	}
+
+	md := d.markdown()
+	for _, want := range []string{"## 2026-03-04", "[#eng thread](https://example.slack.com/archives/C1/p1)", "- **Decided by:** alice"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	d.Permalink, d.DecidedBy, d.Participants = "", "", nil
+	if text := d.slackText(); strings.Contains(text, "Decided by") || !strings.Contains(text, "*Where:* #eng") {
+		t.Errorf("slackText without optional fields:\n%s", text)
+	}
+}
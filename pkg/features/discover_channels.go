@@ -0,0 +1,195 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// DiscoverChannels finds channels the user isn't in that discuss a topic,
+// from the keyword index over channel names, topics, and purposes plus
+// where the topic came up in recent messages
+var DiscoverChannels = &Feature{
+	Name:        "discover-channels",
+	Description: "Find channels you're not in that discuss a topic, ranked by how well their name, topic, and purpose match and how often the topic came up there recently",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"topic": map[string]interface{}{
+				"type":        "string",
+				"description": "Topic to find channels for (e.g. 'billing', 'kubernetes upgrades')",
+			},
+			"includeMember": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Also list channels you're already in",
+				"default":     false,
+			},
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": "Number of channels to return (default: 10, max: 50)",
+				"default":     10,
+			},
+		},
+		"required": []string{"topic"},
+	},
+	Handler: discoverChannelsHandler,
+}
+
+// Weight of recent discussion relative to the keyword index score
+const discoverHistoryWeight = 2.0
+
+func discoverChannelsHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	topic, _ := params["topic"].(string)
+	includeMember, _ := params["includeMember"].(bool)
+	limit := 10
+	if l, ok := params["limit"].(float64); ok {
+		limit = max(1, min(int(l), 50))
+	}
+
+	terms := keyTerms(topic)
+	if len(terms) == 0 {
+		return &FeatureResult{
+			Success: false,
+			Message: "The topic has no distinctive terms to match channels against",
+		}, nil
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	byID := map[string]slack.Channel{}
+	for _, ch := range apiProvider.GetCachedChannels() {
+		if ch.IsArchived || ch.IsIM || ch.IsMpIM || (ch.IsMember && !includeMember) {
+			continue
+		}
+		byID[ch.ID] = ch
+	}
+
+	type candidate struct {
+		id      string
+		index   float64
+		terms   []string
+		history int
+	}
+	candidates := map[string]*candidate{}
+	for _, m := range apiProvider.SearchChannelKeywords(terms) {
+		if _, ok := byID[m.ID]; ok {
+			candidates[m.ID] = &candidate{id: m.ID, index: m.Score, terms: m.Terms}
+		}
+	}
+
+	// Where has the topic come up recently? Bot tokens can't search, so
+	// they rank on the index alone.
+	searched := false
+	if apiProvider.TokenType() != "bot" {
+		if api, err := apiProvider.Provide(); err == nil {
+			sp := slack.NewSearchParameters()
+			sp.Sort = "timestamp"
+			sp.Count = 100
+			q := strings.Join(terms[:min(len(terms), suggestMaxTerms)], " OR ") + " " + parseTimeframeToDateFilter("3m")
+			if res, err := api.SearchMessagesContext(ctx, q, sp); err == nil {
+				searched = true
+				for _, m := range res.Matches {
+					if _, ok := byID[m.Channel.ID]; !ok {
+						continue
+					}
+					c, ok := candidates[m.Channel.ID]
+					if !ok {
+						c = &candidate{id: m.Channel.ID}
+						candidates[m.Channel.ID] = c
+					}
+					c.history++
+				}
+			}
+		}
+	}
+
+	ranked := make([]*candidate, 0, len(candidates))
+	scores := map[string]float64{}
+	for _, c := range candidates {
+		scores[c.id] = c.index + discoverHistoryWeight*math.Log1p(float64(c.history))
+		ranked = append(ranked, c)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if scores[ranked[i].id] != scores[ranked[j].id] {
+			return scores[ranked[i].id] > scores[ranked[j].id]
+		}
+		return ranked[i].id < ranked[j].id
+	})
+	total := len(ranked)
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	channels := make([]map[string]interface{}, 0, len(ranked))
+	for _, c := range ranked {
+		ch := byID[c.id]
+		var reasons []string
+		if len(c.terms) > 0 {
+			reasons = append(reasons, "name/topic/purpose mention "+strings.Join(dedupe(c.terms), ", "))
+		}
+		if c.history > 0 {
+			reasons = append(reasons, fmt.Sprintf("%d recent messages on the topic", c.history))
+		}
+		channels = append(channels, map[string]interface{}{
+			"channel":  ch.Name,
+			"purpose":  ch.Purpose.Value,
+			"topic":    ch.Topic.Value,
+			"members":  ch.NumMembers,
+			"isMember": ch.IsMember,
+			"messages": c.history,
+			"score":    fmt.Sprintf("%.1f", scores[c.id]),
+			"reasons":  reasons,
+		})
+	}
+
+	result := &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Found %d channels discussing %s", total, strings.Join(terms, ", ")),
+		Data: map[string]interface{}{
+			"terms":    terms,
+			"channels": channels,
+			"searched": searched,
+		},
+		ResultCount: len(channels),
+	}
+	switch {
+	case len(channels) == 0:
+		result.Guidance = "No channel matches the topic. Try broader terms, or find-expert to ask a person instead"
+	case !searched:
+		result.Guidance = "Ranked on channel names, topics, and purposes only; message search wasn't available"
+	default:
+		result.Guidance = "Skim a channel before joining; an active channel with few members may be a team's working space"
+	}
+	if len(channels) > 0 {
+		best := channels[0]["channel"]
+		result.NextActions = []string{
+			fmt.Sprintf("Preview it: catch-up channel='%s' since='7d'", best),
+			fmt.Sprintf("See what it's about: brief-me-on-channel channel='%s'", best),
+		}
+	}
+	return result, nil
+}
+
+// dedupe drops repeated strings, keeping first-seen order
+func dedupe(items []string) []string {
+	seen := make(map[string]bool, len(items))
+	out := items[:0:0]
+	for _, s := range items {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,188 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// DM pacing holds direct messages to people who have said they don't want
+// to be interrupted: do-not-disturb hours, snoozed notifications, or a
+// focus or out-of-office status.
+//
+//	SLACK_MCP_DM_PACING="warn"   "warn" (default), "schedule", or "off"
+//
+// In warn mode the message is refused until it's resent with force=true.
+// In schedule mode it's handed to chat.scheduleMessage for when the
+// recipient is expected back, when Slack says when that is.
+const (
+	dmPacingWarn     = "warn"
+	dmPacingSchedule = "schedule"
+	dmPacingOff      = "off"
+)
+
+// focusStatusPattern matches status text saying someone is heads-down or away
+var focusStatusPattern = regexp.MustCompile(`(?i)\b(focus(ing)?|heads[- ]down|deep work|do not disturb|dnd|presenting|out of (the )?office|ooo|vacation|on leave|pto|off sick|sick)\b`)
+
+// Status emoji that mean the same without any text
+var focusStatusEmoji = map[string]bool{
+	":headphones:": true, ":no_bell:": true, ":palm_tree:": true, ":desert_island:": true,
+	":face_with_thermometer:": true, ":zzz:": true, ":red_circle:": true,
+}
+
+func dmPacingMode() string {
+	switch mode := strings.ToLower(strings.TrimSpace(os.Getenv("SLACK_MCP_DM_PACING"))); mode {
+	case "":
+		return dmPacingWarn
+	case dmPacingWarn, dmPacingSchedule, dmPacingOff:
+		return mode
+	default:
+		log.Printf("Ignoring SLACK_MCP_DM_PACING: unknown mode %q", mode)
+		return dmPacingWarn
+	}
+}
+
+// recipientAvailability is what Slack says about a DM recipient right now
+type recipientAvailability struct {
+	userID   string
+	name     string
+	presence string
+	status   string
+	reason   string    // Why they'd rather not be interrupted; empty if reachable
+	until    time.Time // When that ends; zero if Slack doesn't say
+}
+
+// unavailability reads DND state and profile status for a reason not to
+// interrupt someone, and until when
+func unavailability(dnd *slack.DNDStatus, profile *slack.UserProfile, now time.Time) (string, time.Time) {
+	if dnd != nil {
+		if dnd.SnoozeEnabled && int64(dnd.SnoozeEndTime) > now.Unix() {
+			return "has notifications snoozed", time.Unix(int64(dnd.SnoozeEndTime), 0)
+		}
+		if dnd.Enabled && int64(dnd.NextStartTimestamp) <= now.Unix() && now.Unix() < int64(dnd.NextEndTimestamp) {
+			return "is in do-not-disturb hours", time.Unix(int64(dnd.NextEndTimestamp), 0)
+		}
+	}
+	if profile != nil && (focusStatusPattern.MatchString(profile.StatusText) || focusStatusEmoji[profile.StatusEmoji]) {
+		var until time.Time
+		if exp := int64(profile.StatusExpiration); exp > now.Unix() {
+			until = time.Unix(exp, 0)
+		}
+		return "has set their status to " + strings.TrimSpace(profile.StatusEmoji+" "+profile.StatusText), until
+	}
+	return "", time.Time{}
+}
+
+// checkDMRecipient looks up the other person in a DM. Returns nil for
+// anything but a one-to-one DM with someone else, or when Slack won't say.
+func checkDMRecipient(ctx context.Context, ap *provider.ApiProvider, api *slack.Client, channelID string) *recipientAvailability {
+	info, err := ap.GetChannelInfo(ctx, channelID)
+	if err != nil || !info.IsIM || info.User == "" {
+		return nil
+	}
+	if self := ap.ProvideIdentity(); self != nil && self.UserID == info.User {
+		return nil
+	}
+
+	r := &recipientAvailability{userID: info.User, name: userDisplayName(info.User, ap.ProvideUsersMap())}
+	dnd, err := api.GetDNDInfoContext(ctx, &info.User)
+	if err != nil {
+		dnd = nil
+	}
+	profile, err := api.GetUserProfileContext(ctx, &slack.GetUserProfileParameters{UserID: info.User})
+	if err != nil {
+		profile = nil
+	}
+	if dnd == nil && profile == nil {
+		return nil
+	}
+	if profile != nil {
+		r.status = strings.TrimSpace(profile.StatusEmoji + " " + profile.StatusText)
+	}
+	if p, err := api.GetUserPresenceContext(ctx, info.User); err == nil {
+		r.presence = p.Presence
+	}
+	r.reason, r.until = unavailability(dnd, profile, time.Now())
+	return r
+}
+
+func (r *recipientAvailability) data() map[string]interface{} {
+	d := map[string]interface{}{
+		"recipient": r.name,
+		"reason":    r.reason,
+	}
+	if r.presence != "" {
+		d["presence"] = r.presence
+	}
+	if r.status != "" {
+		d["status"] = r.status
+	}
+	if !r.until.IsZero() {
+		d["availableAt"] = r.until.Format(time.RFC3339)
+	}
+	return d
+}
+
+// recipientBusy builds the refusal for a held DM
+func recipientBusy(r *recipientAvailability, channel string) *FeatureResult {
+	msg := fmt.Sprintf("Message not sent: %s %s", r.name, r.reason)
+	if !r.until.IsZero() {
+		msg += fmt.Sprintf(" until %s", r.until.Local().Format("Mon 15:04"))
+	}
+	guidance := "🎧 They've asked not to be interrupted. Wait, post in a channel instead, or resend with force=true if it can't wait"
+	switch {
+	case dmPacingMode() != dmPacingSchedule:
+	case r.until.IsZero():
+		guidance = "🎧 Slack doesn't say when they'll be back, so it wasn't scheduled. Resend with force=true if it can't wait"
+	default:
+		guidance = "🎧 Messages split into parts can't be scheduled. Send it after they're back, or resend with force=true if it can't wait"
+	}
+	return &FeatureResult{
+		Success:  false,
+		Message:  msg,
+		Data:     r.data(),
+		Guidance: guidance,
+		NextActions: []string{
+			fmt.Sprintf("Send anyway: send-message channel='%s' force=true", channel),
+		},
+	}
+}
+
+// scheduleForRecipient queues a DM for when its recipient is expected back
+func scheduleForRecipient(ctx context.Context, api *slack.Client, r *recipientAvailability, channel, channelID, threadTs, message string, options []slack.MsgOption) (*FeatureResult, error) {
+	// A minute's slack so it doesn't land the instant DND lifts
+	postAt := r.until.Add(time.Minute)
+	_, scheduledID, err := api.ScheduleMessageContext(ctx, channelID, strconv.FormatInt(postAt.Unix(), 10), options...)
+	if err != nil {
+		log.Printf("Failed to schedule message: %v", err)
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("%s %s, and scheduling the message failed: %v", r.name, r.reason, err),
+			Data:     r.data(),
+			Guidance: "⚠️ Send it later, or resend with force=true if it can't wait",
+		}, nil
+	}
+
+	data := r.data()
+	data["channel"] = channel
+	data["channelId"] = channelID
+	data["threadTs"] = threadTs
+	data["message"] = message
+	data["scheduled"] = true
+	data["scheduledId"] = scheduledID
+	data["postAt"] = postAt.Format(time.RFC3339)
+	return &FeatureResult{
+		Success:  true,
+		Message:  fmt.Sprintf("%s %s: message scheduled for %s", r.name, r.reason, postAt.Local().Format("Mon 15:04")),
+		Data:     data,
+		Guidance: "🎧 The message will be delivered when they're expected back. Scheduled messages can be edited or cancelled from Slack.",
+	}, nil
+}
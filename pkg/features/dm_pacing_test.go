@@ -0,0 +1,39 @@
+package features
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+func TestUnavailability(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	later := int(now.Add(time.Hour).Unix())
+
+	snoozed := &slack.DNDStatus{SnoozeInfo: slack.SnoozeInfo{SnoozeEnabled: true, SnoozeEndTime: later}}
+	if reason, until := unavailability(snoozed, nil, now); reason == "" || until.Unix() != int64(later) {
+		t.Errorf("snoozed = %q %v", reason, until)
+	}
+
+	dndHours := &slack.DNDStatus{Enabled: true, NextStartTimestamp: int(now.Add(-time.Hour).Unix()), NextEndTimestamp: later}
+	if reason, _ := unavailability(dndHours, nil, now); reason == "" {
+		t.Error("inside DND hours but reachable")
+	}
+	dndHours.NextStartTimestamp = int(now.Add(30 * time.Minute).Unix())
+	if reason, _ := unavailability(dndHours, nil, now); reason != "" {
+		t.Errorf("before DND hours = %q", reason)
+	}
+
+	focusing := &slack.UserProfile{StatusEmoji: ":headphones:", StatusText: "Focusing", StatusExpiration: later}
+	if reason, until := unavailability(nil, focusing, now); reason == "" || until.Unix() != int64(later) {
+		t.Errorf("focusing = %q %v", reason, until)
+	}
+	ooo := &slack.UserProfile{StatusText: "OOO until Monday"}
+	if reason, until := unavailability(nil, ooo, now); reason == "" || !until.IsZero() {
+		t.Errorf("OOO = %q %v", reason, until)
+	}
+	if reason, _ := unavailability(nil, &slack.UserProfile{StatusEmoji: ":coffee:", StatusText: "Having coffee"}, now); reason != "" {
+		t.Errorf("coffee = %q", reason)
+	}
+}
@@ -3,6 +3,7 @@ package features
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -153,8 +154,15 @@ func downloadFileHandler(ctx context.Context, params map[string]interface{}) (*F
 		}, nil
 	}
 
-	internal := apiProvider.ProvideInternalClient()
-	n, err := internal.DownloadFile(ctx, downloadURL, out)
+	var n int64
+	if internal := apiProvider.ProvideInternalClient(); internal != nil {
+		n, err = internal.DownloadFile(ctx, downloadURL, out)
+	} else {
+		// App tokens download with the bearer token on the official client
+		counter := &countingWriter{w: out}
+		err = api.GetFileContext(ctx, downloadURL, counter)
+		n = counter.n
+	}
 	if err != nil {
 		out.Close()
 		os.Remove(targetAbs)
@@ -186,3 +194,15 @@ func downloadFileHandler(ctx context.Context, params map[string]interface{}) (*F
 		},
 	}, nil
 }
+
+// countingWriter tallies the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
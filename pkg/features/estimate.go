@@ -0,0 +1,285 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// Expensive tools take estimate=true to report what a call would cost
+// before making it: API calls by method and how long they'd take, so the
+// agent can pick a cheaper plan. An estimate makes one small probe call to
+// size the work.
+
+// estimatedCallLatency is a typical Slack Web API round trip
+const estimatedCallLatency = 400 * time.Millisecond
+
+// Per-minute rate limits (Slack's tiers) of the methods estimates count.
+// Calls past a method's budget wait for the next minute.
+var methodRatePerMinute = map[string]int{
+	"conversations.history": 50,
+	"conversations.replies": 50,
+	"conversations.list":    20,
+	"search.messages":       20,
+	"chat.getPermalink":     100,
+	"client.counts":         50,
+}
+
+// estimateParam is the schema entry the estimating tools share
+var estimateParam = map[string]interface{}{
+	"type":        "boolean",
+	"description": "Don't run: report how many API calls this would take and how long, with cheaper alternatives",
+	"default":     false,
+}
+
+// costEstimate tallies the API calls a tool call would make
+type costEstimate struct {
+	calls   map[string]int
+	notes   []string
+	cheaper []string
+}
+
+func newCostEstimate() *costEstimate {
+	return &costEstimate{calls: map[string]int{}}
+}
+
+func (e *costEstimate) add(method string, n int) {
+	if n > 0 {
+		e.calls[method] += n
+	}
+}
+
+func (e *costEstimate) total() int {
+	n := 0
+	for _, c := range e.calls {
+		n += c
+	}
+	return n
+}
+
+// duration is the time the calls take back to back, or the time the rate
+// limits stretch them to if that's longer
+func (e *costEstimate) duration() time.Duration {
+	var d time.Duration
+	for method, n := range e.calls {
+		run := time.Duration(n) * estimatedCallLatency
+		if rate := methodRatePerMinute[method]; rate > 0 && n > rate {
+			run = max(run, time.Duration(n/rate)*time.Minute)
+		}
+		d += run
+	}
+	return d
+}
+
+func (e *costEstimate) result(tool string) *FeatureResult {
+	methods := make([]string, 0, len(e.calls))
+	for m := range e.calls {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+	calls := make([]map[string]interface{}, 0, len(methods))
+	for _, m := range methods {
+		calls = append(calls, map[string]interface{}{"method": m, "calls": e.calls[m]})
+	}
+
+	d := e.duration()
+	result := &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("%s would make about %d API calls, taking about %s", tool, e.total(), formatEstimate(d)),
+		Data: map[string]interface{}{
+			"estimate":         true,
+			"apiCalls":         e.total(),
+			"calls":            calls,
+			"expectedDuration": formatEstimate(d),
+			"expectedSeconds":  int(math.Ceil(d.Seconds())),
+			"notes":            e.notes,
+		},
+		Guidance:    "Nothing was run. Call again without estimate to go ahead, or try a cheaper option.",
+		NextActions: e.cheaper,
+	}
+	return result
+}
+
+// formatEstimate rounds a duration for display
+func formatEstimate(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return "under a second"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(math.Ceil(d.Seconds())))
+	default:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+}
+
+// estimateCatchUp sizes a catch-up from the newest page of history:
+// messages in the period are extrapolated from how far back that page
+// reaches, and the share worth surfacing or rolling up is taken from it
+func estimateCatchUp(ctx context.Context, ap *provider.ApiProvider, api *slack.Client, channel, channelID, since string,
+	oldest, latest time.Time, plan pagingPlan, adaptive, autoCursor, expandThreads bool, workflows string) *FeatureResult {
+	histParams := &slack.GetConversationHistoryParameters{
+		ChannelID: channelID,
+		Oldest:    fmt.Sprintf("%d", oldest.Unix()),
+		Limit:     100,
+	}
+	end := time.Now()
+	if !latest.IsZero() {
+		histParams.Latest = fmt.Sprintf("%d", latest.Unix())
+		end = latest
+	}
+	probe, err := api.GetConversationHistoryContext(ctx, histParams)
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Couldn't size the catch-up: %v", err),
+		}
+	}
+
+	n := len(probe.Messages)
+	total := n
+	if probe.HasMore && n > 0 {
+		covered := end.Sub(parseSlackTimestamp(probe.Messages[n-1].Timestamp))
+		if covered > 0 {
+			total = int(float64(n) * float64(end.Sub(oldest)) / float64(covered))
+		}
+		total = max(total, n+1)
+	}
+
+	// Page the way the catch-up would: the probe is the same size as an
+	// adaptive first page, so it sizes the rest
+	pages, read := 1, min(total, plan.pageSize)
+	switch {
+	case !autoCursor:
+	case adaptive && plan.maxPages > 1 && probe.HasMore && n > 0:
+		rest := planRemainingPages(plan, plan.maxPages, n, end, parseSlackTimestamp(probe.Messages[n-1].Timestamp), oldest)
+		pages = rest.maxPages
+		read = min(total, catchUpSamplePage+(pages-1)*rest.pageSize)
+	case !adaptive:
+		pages = max(1, min((total+plan.pageSize-1)/plan.pageSize, plan.maxPages))
+		read = min(total, pages*plan.pageSize)
+	}
+
+	usersMap := ap.ProvideUsersMap()
+	important, busy := 0, 0
+	for _, msg := range probe.Messages {
+		if analyzeMessage(msg, usersMap, workflows) != nil {
+			important++
+			if msg.ReplyCount > rollupMinReplies {
+				busy++
+			}
+		}
+	}
+	scale := func(k int) int {
+		if n == 0 {
+			return 0
+		}
+		return int(math.Ceil(float64(k) * float64(read) / float64(n)))
+	}
+
+	e := newCostEstimate()
+	e.add("conversations.history", pages)
+	if expandThreads {
+		e.add("conversations.replies", min(scale(busy), rollupMaxThreads))
+	}
+	e.add("chat.getPermalink", scale(important))
+
+	approx := ""
+	if probe.HasMore {
+		approx = "about "
+	}
+	e.notes = append(e.notes, fmt.Sprintf("%s%d messages in the last %s (sized from the newest %d)", approx, total, since, n))
+	if read < total {
+		e.notes = append(e.notes, fmt.Sprintf("Only the newest %d would be read; the rest needs cursor paging", read))
+	}
+	if e.calls["chat.getPermalink"] > 0 {
+		e.notes = append(e.notes, "Permalinks already cached cost nothing, so that count is an upper bound")
+	}
+	e.notes = append(e.notes, "Sizing took 1 API call")
+
+	if !isRecentTimeframe(since) {
+		e.cheaper = append(e.cheaper, fmt.Sprintf("Shorter period: catch-up channel='%s' since='1d'", channel))
+	}
+	if expandThreads {
+		e.cheaper = append(e.cheaper, fmt.Sprintf("Skip thread replies: catch-up channel='%s' since='%s' expandThreads=false", channel, since))
+	}
+	if total > 200 {
+		e.cheaper = append(e.cheaper, fmt.Sprintf("Overview instead of every message: brief-me-on-channel channel='%s'", channel))
+	}
+	return e.result("catch-up")
+}
+
+// estimateMentions sizes check-mentions. Search mode probes the main query
+// for its match count; scan mode reads one history page per member channel.
+func estimateMentions(ctx context.Context, ap *provider.ApiProvider, api *slack.Client, self *provider.SelfInfo,
+	mode, timeframe string) *FeatureResult {
+	e := newCostEstimate()
+
+	if mode != "scan" {
+		sp := slack.NewSearchParameters()
+		sp.Count = 1
+		res, err := api.SearchMessagesContext(ctx, "@"+self.User+" "+parseTimeframeToDateFilter(timeframe), sp)
+		if err != nil {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Couldn't size the mention search: %v", err),
+			}
+		}
+		pages := max(1, min((res.Total+99)/100, mentionSearchPages))
+		// The mention query, to:@me, and from:@me for what you've answered
+		e.add("search.messages", pages+2)
+		if ap.TokenType() == "session" {
+			e.add("client.counts", 1)
+			unread := 0
+			if counts, _ := ap.CachedClientCounts(); counts != nil {
+				for _, ch := range counts.Channels {
+					if ch.MentionCount > 0 {
+						unread++
+					}
+				}
+				for _, ch := range counts.MPIMs {
+					if ch.MentionCount > 0 {
+						unread++
+					}
+				}
+			}
+			e.add("conversations.history", min(unread, mentionGapChannels))
+		}
+		e.notes = append(e.notes, fmt.Sprintf("About %d mentions in the last %s", res.Total, timeframe))
+		if res.Total > mentionSearchPages*100 {
+			e.notes = append(e.notes, fmt.Sprintf("Only the newest %d matches would be read", mentionSearchPages*100))
+		}
+		e.notes = append(e.notes, "Sizing took 1 API call")
+		if res.Total > 100 {
+			e.cheaper = append(e.cheaper, "Urgent ones only: check-mentions urgencyFilter='urgent'")
+		}
+		if timeframe != "1d" {
+			e.cheaper = append(e.cheaper, "Shorter period: check-mentions timeframe='1d'")
+		}
+		return e.result("check-mentions")
+	}
+
+	member := 0
+	for _, ch := range ap.GetCachedChannels() {
+		if (ch.IsMember || ch.IsIM || ch.IsMpIM) && !ch.IsArchived {
+			member++
+		}
+	}
+	e.add("conversations.list", 1)
+	e.add("conversations.history", member)
+	e.notes = append(e.notes,
+		fmt.Sprintf("One history read for each of your %d channels and DMs; it stops early once enough mentions are found", member),
+		"Threaded mentions each cost a conversations.replies call to check whether you answered")
+	if ap.TokenType() != "bot" {
+		e.cheaper = append(e.cheaper, fmt.Sprintf("Search instead (a few calls): check-mentions timeframe='%s' mode='search'", timeframe))
+	}
+	if !strings.HasSuffix(timeframe, "h") && timeframe != "1d" {
+		e.cheaper = append(e.cheaper, "Shorter period: check-mentions timeframe='1d'")
+	}
+	return e.result("check-mentions")
+}
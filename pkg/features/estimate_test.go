@@ -0,0 +1,39 @@
+package features
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCostEstimateDuration(t *testing.T) {
+	e := newCostEstimate()
+	e.add("conversations.history", 5)
+	if d := e.duration(); d != 5*estimatedCallLatency {
+		t.Fatalf("duration = %v, want %v", d, 5*estimatedCallLatency)
+	}
+
+	// Past the tier budget the rate limit sets the pace
+	e = newCostEstimate()
+	e.add("search.messages", 45)
+	if d := e.duration(); d != 2*time.Minute {
+		t.Fatalf("rate-limited duration = %v, want 2m", d)
+	}
+	e.add("chat.getPermalink", 0)
+	if _, ok := e.calls["chat.getPermalink"]; ok {
+		t.Fatal("zero calls shouldn't be listed")
+	}
+}
+
+func TestFormatEstimateResult(t *testing.T) {
+	e := newCostEstimate()
+	e.add("conversations.history", 3)
+	e.notes = []string{"about 250 messages"}
+	e.cheaper = []string{"catch-up since='1d'"}
+	out := FormatResult("catch-up", e.result("catch-up"))
+	for _, want := range []string{"Estimate:", "conversations.history × 3", "about 250 messages", "since='1d'"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
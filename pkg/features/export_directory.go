@@ -0,0 +1,240 @@
+package features
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/paths"
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+// ExportDirectory dumps the cached users and channels to CSV or JSON files
+// for org-chart and onboarding tooling. Only cached data is used, so it makes
+// no API calls.
+var ExportDirectory = &Feature{
+	Name:        "export-directory",
+	Description: "Export the workspace directory (users with title, email, timezone, status; channels with purpose and member count) to CSV or JSON files",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"format": map[string]interface{}{
+				"type":        "string",
+				"description": "File format: 'csv' or 'json'",
+				"enum":        []string{"csv", "json"},
+				"default":     "csv",
+			},
+			"include": map[string]interface{}{
+				"type":        "string",
+				"description": "What to export: 'users', 'channels', or 'all'",
+				"enum":        []string{"users", "channels", "all"},
+				"default":     "all",
+			},
+			"includeBots": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Include bot/app users (default false)",
+				"default":     false,
+			},
+			"destDir": map[string]interface{}{
+				"type":        "string",
+				"description": "Directory to write the export to. Defaults to ~/Downloads.",
+			},
+		},
+	},
+	Handler: exportDirectoryHandler,
+}
+
+var (
+	directoryUserColumns    = []string{"id", "username", "realName", "displayName", "title", "email", "timezone", "status"}
+	directoryChannelColumns = []string{"id", "name", "purpose", "topic", "members", "isPrivate", "isMember"}
+)
+
+func exportDirectoryHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	format := "csv"
+	if f, ok := params["format"].(string); ok && f != "" {
+		format = f
+	}
+	if format != "csv" && format != "json" {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Unknown format '%s'; use 'csv' or 'json'", format),
+		}, nil
+	}
+	include := "all"
+	if i, ok := params["include"].(string); ok && i != "" {
+		include = i
+	}
+	if include != "users" && include != "channels" && include != "all" {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Unknown include '%s'; use 'users', 'channels', or 'all'", include),
+		}, nil
+	}
+	includeBots, _ := params["includeBots"].(bool)
+	destDir, _ := params["destDir"].(string)
+	if strings.TrimSpace(destDir) == "" {
+		destDir = paths.DownloadsDir()
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	destAbs, err := filepath.Abs(destDir)
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Invalid destDir: %v", err),
+		}, nil
+	}
+	if err := os.MkdirAll(destAbs, 0o755); err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to create destDir %s: %v", destAbs, err),
+		}, nil
+	}
+
+	stamp := time.Now().Format("20060102-150405")
+	data := map[string]interface{}{"format": format}
+	var files []string
+
+	if include == "users" || include == "all" {
+		rows := directoryUsers(apiProvider, includeBots)
+		path, err := writeDirectoryExport(destAbs, "slack-users-"+stamp, format, directoryUserColumns, rows)
+		if err != nil {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Failed to write users export: %v", err),
+			}, nil
+		}
+		files = append(files, path)
+		data["users"] = len(rows)
+		data["usersPath"] = path
+	}
+
+	if include == "channels" || include == "all" {
+		rows := directoryChannels(apiProvider)
+		path, err := writeDirectoryExport(destAbs, "slack-channels-"+stamp, format, directoryChannelColumns, rows)
+		if err != nil {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Failed to write channels export: %v", err),
+			}, nil
+		}
+		files = append(files, path)
+		data["channels"] = len(rows)
+		data["channelsPath"] = path
+	}
+	data["files"] = files
+
+	result := &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Exported directory to %s", strings.Join(files, ", ")),
+		Data:    data,
+		Guidance: "Exported from the local cache. Emails appear only where the workspace makes them visible; " +
+			"refresh the cache first if the directory looks stale.",
+	}
+	return result, nil
+}
+
+// directoryUsers flattens cached users into export rows, sorted by username
+func directoryUsers(p *provider.ApiProvider, includeBots bool) []map[string]string {
+	var rows []map[string]string
+	for _, u := range p.ProvideUsersMap() {
+		if u.Deleted || (!includeBots && (u.IsBot || u.ID == "USLACKBOT")) {
+			continue
+		}
+		status := strings.TrimSpace(u.Profile.StatusEmoji + " " + u.Profile.StatusText)
+		rows = append(rows, map[string]string{
+			"id":          u.ID,
+			"username":    u.Name,
+			"realName":    u.RealName,
+			"displayName": u.Profile.DisplayName,
+			"title":       u.Profile.Title,
+			"email":       u.Profile.Email,
+			"timezone":    u.TZ,
+			"status":      status,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i]["username"] < rows[j]["username"] })
+	return rows
+}
+
+// directoryChannels flattens cached, unarchived channels into export rows
+func directoryChannels(p *provider.ApiProvider) []map[string]string {
+	var rows []map[string]string
+	for _, ch := range p.GetCachedChannels() {
+		if ch.IsArchived || ch.IsIM || ch.IsMpIM {
+			continue
+		}
+		rows = append(rows, map[string]string{
+			"id":        ch.ID,
+			"name":      ch.Name,
+			"purpose":   ch.Purpose.Value,
+			"topic":     ch.Topic.Value,
+			"members":   strconv.Itoa(ch.NumMembers),
+			"isPrivate": strconv.FormatBool(ch.IsPrivate),
+			"isMember":  strconv.FormatBool(ch.IsMember),
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i]["name"] < rows[j]["name"] })
+	return rows
+}
+
+// writeDirectoryExport writes rows as CSV (with a header in column order) or
+// as a JSON array, refusing to overwrite an existing file
+func writeDirectoryExport(dir, base, format string, columns []string, rows []map[string]string) (string, error) {
+	var buf bytes.Buffer
+	if format == "json" {
+		if rows == nil {
+			rows = []map[string]string{}
+		}
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rows); err != nil {
+			return "", err
+		}
+	} else {
+		w := csv.NewWriter(&buf)
+		w.Write(columns)
+		for _, row := range rows {
+			record := make([]string, len(columns))
+			for i, c := range columns {
+				record[i] = row[c]
+			}
+			w.Write(record)
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return "", err
+		}
+	}
+
+	path := filepath.Join(dir, base+"."+format)
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return "", err
+	}
+	if _, err := out.Write(buf.Bytes()); err != nil {
+		out.Close()
+		os.Remove(path)
+		return "", err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
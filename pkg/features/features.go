@@ -21,6 +21,35 @@ type FeatureResult struct {
 	Guidance    string      `json:"guidance,omitempty"`
 	ResultCount int         `json:"resultCount,omitempty"`
 	Pagination  *Pagination `json:"pagination,omitempty"`
+	// Partial is set when the tool stopped before covering everything it
+	// was asked for; Coverage says what was left out
+	Partial  bool       `json:"partial,omitempty"`
+	Coverage []Coverage `json:"coverage,omitempty"`
+}
+
+// Why a result stopped early
+const (
+	PartialTimeout     = "timeout"      // Hit the tool's time limit
+	PartialRateLimited = "rate_limited" // Slack rate limited a later request
+	PartialMaxPages    = "max_pages"    // Read as many pages as the tool allows
+	PartialScanLimit   = "scan_limit"   // Read as many channels as the tool allows
+	PartialError       = "error"        // A later request failed
+)
+
+// Coverage describes one way a partial result fell short: which channels
+// weren't read and what time range actually was
+type Coverage struct {
+	Reason      string   `json:"reason"`
+	Detail      string   `json:"detail,omitempty"`
+	Skipped     []string `json:"skipped,omitempty"`     // Channels not read
+	ScannedFrom string   `json:"scannedFrom,omitempty"` // Oldest time actually read
+	ScannedTo   string   `json:"scannedTo,omitempty"`   // Newest time actually read
+}
+
+// MarkPartial flags the result as incomplete and records why
+func (r *FeatureResult) MarkPartial(c Coverage) {
+	r.Partial = true
+	r.Coverage = append(r.Coverage, c)
 }
 
 // Pagination provides cursor-based pagination info
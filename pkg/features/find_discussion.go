@@ -23,16 +23,28 @@ var FindDiscussion = &Feature{
 			"from": map[string]interface{}{
 				"type":        "array",
 				"items":       map[string]interface{}{"type": "string"},
-				"description": "Specific people to search messages from (optional)",
+				"description": "Specific people to search messages from: usernames, real names, or email addresses (optional)",
 			},
 			"timeframe": map[string]interface{}{
 				"type":        "string",
 				"description": "Time period to search (e.g., '1w', '2w', '1m', '3d')",
 				"default":     "1w",
 			},
+			"filters": searchFiltersSchema,
+			"rank": map[string]interface{}{
+				"type":        "string",
+				"description": "Result order: 'recent' (newest first), 'relevance' (Slack's match score), or 'hybrid' (both merged, boosting threads, decisions, and priority contacts)",
+				"enum":        []string{"recent", "relevance", "hybrid"},
+				"default":     "recent",
+			},
+			"includeArchived": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Include results from archived channels (excluded by default)",
+				"default":     false,
+			},
 			"threadId": map[string]interface{}{
 				"type":        "string",
-				"description": "Specific thread ID to retrieve full context (optional)",
+				"description": "Thread ID to read in full (optional; prefer read-thread)",
 			},
 			"cursor": map[string]interface{}{
 				"type":        "string",
@@ -55,16 +67,17 @@ func findDiscussionHandler(ctx context.Context, params map[string]interface{}) (
 		threadId = t
 	}
 
-	// If threadId is provided, return full thread context
+	// threadId is kept for compatibility; read-thread is the thread reader
 	if threadId != "" {
-		return getThreadContextImpl(ctx, params, threadId)
+		return readThreadHandler(ctx, params)
 	}
 
 	// Otherwise, search for discussions
-	if query == "" {
+	_, hasFilters := params["filters"].(map[string]interface{})
+	if query == "" && !hasFilters {
 		return &FeatureResult{
 			Success: false,
-			Message: "Please provide either a search query or a threadId",
+			Message: "Please provide a search query, filters, or a threadId",
 		}, nil
 	}
 
@@ -23,120 +23,6 @@ func searchMessagesImpl(ctx context.Context, params map[string]interface{}, quer
 	return searchUsingOfficialAPI(ctx, apiProvider, query, params)
 }
 
-func getThreadContextImpl(ctx context.Context, params map[string]interface{}, threadId string) (*FeatureResult, error) {
-	// Parse thread ID (format: channelId:threadTs)
-	parts := strings.Split(threadId, ":")
-	if len(parts) != 2 {
-		return &FeatureResult{
-			Success: false,
-			Message: "Invalid threadId format. Expected: channelId:threadTs",
-		}, nil
-	}
-
-	channelId := parts[0]
-	threadTs := parts[1]
-
-	// Get the API provider
-	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
-	if !ok {
-		return &FeatureResult{
-			Success: false,
-			Message: "Internal error: provider not available",
-		}, nil
-	}
-
-	// Get channel info
-	channelInfo, err := apiProvider.GetChannelInfo(ctx, channelId)
-	if err != nil {
-		return &FeatureResult{
-			Success: false,
-			Message: fmt.Sprintf("Could not find channel: %v", err),
-		}, nil
-	}
-
-	channelName := channelInfo.Name
-	if channelName == "" {
-		channelName = channelId
-	}
-
-	// Get Slack client
-	api, err := apiProvider.Provide()
-	if err != nil {
-		return &FeatureResult{
-			Success: false,
-			Message: fmt.Sprintf("Failed to get Slack client: %v", err),
-		}, nil
-	}
-
-	// Get conversation replies
-	params_conv := &slack.GetConversationRepliesParameters{
-		ChannelID: channelId,
-		Timestamp: threadTs,
-		Limit:     100,
-	}
-
-	replies, _, _, err := api.GetConversationRepliesContext(ctx, params_conv)
-	if err != nil {
-		return &FeatureResult{
-			Success: false,
-			Message: fmt.Sprintf("Failed to get thread: %v", err),
-		}, nil
-	}
-
-	// Process messages
-	messages := []map[string]interface{}{}
-	usersMap := apiProvider.ProvideUsersMap()
-
-	for _, msg := range replies {
-		// Get user info
-		userName := "unknown"
-		if user, ok := usersMap[msg.User]; ok {
-			userName = user.Name
-			if user.RealName != "" {
-				userName = user.RealName
-			}
-		}
-
-		// Parse timestamp
-		msgTime := parseSlackTimestamp(msg.Timestamp)
-		timeAgo := formatTimestamp(msgTime)
-
-		message := map[string]interface{}{
-			"user":      userName,
-			"text":      msg.Text,
-			"timestamp": timeAgo,
-			"ts":        msg.Timestamp,
-		}
-
-		messages = append(messages, message)
-	}
-
-	result := &FeatureResult{
-		Success: true,
-		Data: map[string]interface{}{
-			"threadId": threadId,
-			"channel":  channelName,
-			"messages": messages,
-			"threadMeta": map[string]interface{}{
-				"messageCount": len(messages),
-				"participants": getUniqueParticipants(replies, usersMap),
-			},
-		},
-		Message:     fmt.Sprintf("Found thread with %d messages", len(messages)),
-		ResultCount: len(messages),
-	}
-
-	// Add next actions
-	result.NextActions = []string{
-		fmt.Sprintf("Reply to thread: send-message channel='%s' threadTs='%s'", channelName, threadTs),
-		fmt.Sprintf("View channel context: catch-up channel='%s'", channelName),
-		"Mark thread as read: mark-read channel='" + channelName + "'",
-	}
-	result.Guidance = "💬 Thread loaded. You can reply or explore the channel context."
-
-	return result, nil
-}
-
 // Helper functions
 func parseTimeframeToSearchFilter(timeframe string) string {
 	days := 30 // default
@@ -160,25 +46,6 @@ func parseTimeframeToSearchFilter(timeframe string) string {
 	return fmt.Sprintf("after:-%dd", days)
 }
 
-func extractThreadId(permalink string) string {
-	// Extract thread_ts from permalink if present
-	if strings.Contains(permalink, "thread_ts=") {
-		parts := strings.Split(permalink, "thread_ts=")
-		if len(parts) > 1 {
-			ts := strings.Split(parts[1], "&")[0]
-			// Extract channel ID from permalink
-			if strings.Contains(permalink, "/archives/") {
-				channelParts := strings.Split(permalink, "/archives/")
-				if len(channelParts) > 1 {
-					channelId := strings.Split(channelParts[1], "/")[0]
-					return channelId + ":" + ts
-				}
-			}
-		}
-	}
-	return ""
-}
-
 func extractKeyPoints(text string) []string {
 	// Simple key point extraction
 	points := []string{}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/aaronsb/slack-mcp/pkg/provider"
 	"github.com/slack-go/slack"
@@ -21,39 +22,36 @@ func searchUsingOfficialAPI(ctx context.Context, p *provider.ApiProvider, query
 		}, nil
 	}
 
-	// Build search parameters
-	searchParams := slack.NewSearchParameters()
-	searchParams.Sort = "timestamp"
-	searchParams.SortDirection = "desc"
-	searchParams.Count = 100
-	searchParams.Page = 1
+	// Compile structured filters; the top-level in/from params are shorthands
+	filters, err := parseSearchFilters(params["filters"])
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+	filters.In = append(filters.In, stringList(params["in"])...)
+	filters.From = append(filters.From, stringList(params["from"])...)
+	filters = resolveSearchFilters(ctx, filters, p)
+	query = buildSearchQuery(query, filters)
 
-	// Apply filters from params
-	if timeframe, ok := params["timeframe"].(string); ok {
+	if timeframe, ok := params["timeframe"].(string); ok && !filters.hasDate() {
 		// Convert our timeframe format to Slack's date filter
 		dateFilter := parseTimeframeToDateFilter(timeframe)
 		query = query + " " + dateFilter
 	}
 
-	if channels, ok := params["in"].([]string); ok && len(channels) > 0 {
-		// Convert channel names to IDs
-		channelIDs := []string{}
-		for _, ch := range channels {
-			channelID := p.ResolveChannelID(ch)
-			channelIDs = append(channelIDs, channelID)
-		}
-		query = query + " in:" + strings.Join(channelIDs, ",")
-	}
-
-	if users, ok := params["from"].([]string); ok && len(users) > 0 {
-		query = query + " from:" + strings.Join(users, ",")
-	}
-
 	// Log the search
 	log.Printf("Official API search query: %s", query)
 
+	rank := rankRecent
+	if r, ok := params["rank"].(string); ok && r != "" {
+		rank = r
+	}
+
 	// Perform search
-	messages, err := api.SearchMessagesContext(ctx, query, searchParams)
+	usersMap := p.ProvideUsersMap()
+	matches, total, err := runRankedSearch(ctx, api, query, rank, priorityContacts(usersMap))
 	if err != nil {
 		log.Printf("Official API search error: %v", err)
 		return &FeatureResult{
@@ -62,17 +60,25 @@ func searchUsingOfficialAPI(ctx context.Context, p *provider.ApiProvider, query
 		}, nil
 	}
 
-	log.Printf("Official API search results - Total: %d, Matches: %d", messages.Total, len(messages.Matches))
+	log.Printf("Official API search results (%s) - Total: %d, Matches: %d", rank, total, len(matches))
+
+	includeArchived, _ := params["includeArchived"].(bool)
+	archivedHidden := 0
 
 	// Convert to our format
 	discussions := []map[string]interface{}{}
-	usersMap := p.ProvideUsersMap()
 
-	for _, match := range messages.Matches {
+	for _, match := range matches {
 		// Get channel info
-		channelName := p.ResolveChannelName(ctx, match.Channel.ID)
-		if channelName == "" {
-			channelName = match.Channel.Name
+		channelName := match.Channel.Name
+		if info, err := p.GetChannelInfo(ctx, match.Channel.ID); err == nil {
+			if info.IsArchived && !includeArchived {
+				archivedHidden++
+				continue
+			}
+			if info.Name != "" {
+				channelName = info.Name
+			}
 		}
 
 		// Get user info
@@ -89,20 +95,33 @@ func searchUsingOfficialAPI(ctx context.Context, p *provider.ApiProvider, query
 			"channel":   channelName,
 			"channelId": match.Channel.ID,
 			"user":      userName,
-			"text":      match.Text,
+			"text":      messageBody(highlightToMarkdown(match.Text), match.Blocks, match.Attachments),
 			"timestamp": match.Timestamp,
 			"permalink": match.Permalink,
 		}
 
+		// Surrounding messages for the top matches so a hit can be read in context
+		if i := len(discussions); i < searchContextMatches {
+			if window := searchContextWindow(ctx, api, match, usersMap, i < searchContextFetches); len(window) > 0 {
+				discussion["context"] = window
+			}
+		}
+
 		if len(match.Attachments) > 0 {
 			discussion["hasAttachments"] = true
 		}
+		addAttachmentInfo(discussion, "text", nil, match.Attachments, match.Blocks)
 
 		// Check if it's part of a thread
 		if match.Previous.Timestamp != "" || match.Previous2.Timestamp != "" ||
 			match.Next.Timestamp != "" || match.Next2.Timestamp != "" {
 			discussion["type"] = "thread"
-			discussion["threadId"] = fmt.Sprintf("%s:%s", match.Channel.ID, match.Timestamp)
+			// Replies' permalinks carry the parent's thread_ts
+			ref, err := parseThreadRef(match.Permalink)
+			if err != nil {
+				ref = ThreadRef{ChannelID: match.Channel.ID, ThreadTs: match.Timestamp}
+			}
+			discussion["threadId"] = ref.String()
 		}
 
 		discussions = append(discussions, discussion)
@@ -115,13 +134,17 @@ func searchUsingOfficialAPI(ctx context.Context, p *provider.ApiProvider, query
 			"query":       query,
 			"discussions": discussions,
 			"searchMeta": map[string]interface{}{
-				"totalMatches": messages.Total,
+				"totalMatches": total,
+				"rank":         rank,
 				"returned":     len(discussions),
 				"timeframe":    params["timeframe"],
 			},
 		},
 		ResultCount: len(discussions),
 	}
+	if archivedHidden > 0 {
+		result.Data.(map[string]interface{})["searchMeta"].(map[string]interface{})["archivedHidden"] = archivedHidden
+	}
 
 	// Add next actions based on results
 	if len(discussions) > 0 {
@@ -153,6 +176,9 @@ func searchUsingOfficialAPI(ctx context.Context, p *provider.ApiProvider, query
 			"search query='<different_terms>'",
 		}
 	}
+	if archivedHidden > 0 {
+		result.Guidance += fmt.Sprintf(" %d results from archived channels were hidden; add includeArchived=true to include them.", archivedHidden)
+	}
 
 	return result, nil
 }
@@ -180,3 +206,108 @@ func parseTimeframeToDateFilter(timeframe string) string {
 
 	return "after:-30d"
 }
+
+const (
+	// searchContextMatches is how many top matches get a ±2 message context window
+	searchContextMatches = 5
+	// searchContextFetches is how many of those may fetch history when
+	// search didn't return the surrounding messages itself
+	searchContextFetches = 3
+)
+
+// searchContextWindow returns up to two messages before and after a search
+// match. search.messages usually embeds them; otherwise they are fetched from
+// channel history when fetch is set.
+func searchContextWindow(ctx context.Context, api *slack.Client, match slack.SearchMessage, usersMap map[string]slack.User, fetch bool) []map[string]interface{} {
+	var before, after []slack.CtxMessage
+	for _, m := range []slack.CtxMessage{match.Previous2, match.Previous} {
+		if m.Timestamp != "" {
+			before = append(before, m)
+		}
+	}
+	for _, m := range []slack.CtxMessage{match.Next, match.Next2} {
+		if m.Timestamp != "" {
+			after = append(after, m)
+		}
+	}
+
+	if len(before) == 0 && len(after) == 0 && fetch {
+		before, after = fetchSearchContext(ctx, api, match.Channel.ID, match.Timestamp)
+	}
+
+	window := []map[string]interface{}{}
+	add := func(m slack.CtxMessage, position string) {
+		userName := m.Username
+		if user, ok := usersMap[m.User]; ok {
+			userName = user.Name
+			if user.RealName != "" {
+				userName = user.RealName
+			}
+		}
+		if userName == "" {
+			userName = "unknown"
+		}
+		window = append(window, map[string]interface{}{
+			"position":  position,
+			"user":      userName,
+			"text":      highlightToMarkdown(m.Text),
+			"timestamp": m.Timestamp,
+		})
+	}
+	for _, m := range before {
+		add(m, "before")
+	}
+	for _, m := range after {
+		add(m, "after")
+	}
+	return window
+}
+
+// fetchSearchContext reads the two messages on either side of ts from
+// channel history, oldest first
+func fetchSearchContext(ctx context.Context, api *slack.Client, channelID, ts string) (before, after []slack.CtxMessage) {
+	toCtx := func(m slack.Message) slack.CtxMessage {
+		return slack.CtxMessage{User: m.User, Username: m.Username, Text: m.Text, Timestamp: m.Timestamp}
+	}
+
+	prev, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+		ChannelID: channelID,
+		Latest:    ts,
+		Limit:     2,
+	})
+	if err == nil {
+		for i := len(prev.Messages) - 1; i >= 0; i-- {
+			before = append(before, toCtx(prev.Messages[i]))
+		}
+	}
+
+	// History is returned newest first, so bound the window and keep the
+	// two messages closest to the match
+	latest := parseSlackTimestamp(ts).Add(24 * time.Hour)
+	next, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+		ChannelID: channelID,
+		Oldest:    ts,
+		Latest:    fmt.Sprintf("%d.000000", latest.Unix()),
+		Limit:     100,
+	})
+	if err == nil {
+		for i := len(next.Messages) - 1; i >= 0 && len(after) < 2; i-- {
+			after = append(after, toCtx(next.Messages[i]))
+		}
+	}
+	return before, after
+}
+
+// Slack wraps highlighted search terms in these private-use characters
+const (
+	highlightStart = "\ue000"
+	highlightEnd   = "\ue001"
+)
+
+// highlightToMarkdown turns Slack's search highlight markers into markdown bold
+func highlightToMarkdown(text string) string {
+	if !strings.Contains(text, highlightStart) {
+		return text
+	}
+	return strings.NewReplacer(highlightStart, "**", highlightEnd, "**").Replace(text)
+}
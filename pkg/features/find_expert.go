@@ -0,0 +1,211 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// FindExpert ranks people by how often and how recently they talk about a
+// topic, answering "who should I ask about X?"
+var FindExpert = &Feature{
+	Name:        "find-expert",
+	Description: "Find who knows about a topic: ranks people by how often and how recently they discuss it, with sample messages",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"topic": map[string]interface{}{
+				"type":        "string",
+				"description": "Topic to find people for (e.g., 'billing service', 'kubernetes upgrade')",
+			},
+			"timeframe": map[string]interface{}{
+				"type":        "string",
+				"description": "How far back to look (e.g., '1m', '3m', '2w')",
+				"default":     "3m",
+			},
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": "Number of people to return (default: 5)",
+				"default":     5,
+			},
+		},
+		"required": []string{"topic"},
+	},
+	Handler: findExpertHandler,
+}
+
+// expertHalfLife is how long until a message counts half as much
+const expertHalfLife = 30 * 24 * time.Hour
+
+func findExpertHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	topic, _ := params["topic"].(string)
+	if strings.TrimSpace(topic) == "" {
+		return &FeatureResult{
+			Success: false,
+			Message: "topic is required",
+		}, nil
+	}
+	timeframe := "3m"
+	if t, ok := params["timeframe"].(string); ok && t != "" {
+		timeframe = t
+	}
+	limit := 5
+	if l, ok := params["limit"].(float64); ok && l > 0 {
+		limit = int(l)
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	query := topic + " " + parseTimeframeToDateFilter(timeframe)
+	var matches []slack.SearchMessage
+	for page := 1; page <= 2; page++ {
+		sp := slack.NewSearchParameters()
+		sp.Sort = "score"
+		sp.Count = 100
+		sp.Page = page
+		res, err := api.SearchMessagesContext(ctx, query, sp)
+		if err != nil {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Search failed: %v", err),
+			}, nil
+		}
+		matches = append(matches, res.Matches...)
+		if res.Paging.Pages <= page {
+			break
+		}
+	}
+
+	selfID := ""
+	if id := apiProvider.ProvideIdentity(); id != nil {
+		selfID = id.UserID
+	}
+	usersMap := apiProvider.ProvideUsersMap()
+
+	type candidate struct {
+		userID   string
+		score    float64
+		messages int
+		channels map[string]bool
+		latest   time.Time
+		samples  []slack.SearchMessage
+	}
+	byUser := map[string]*candidate{}
+	now := time.Now()
+	for _, m := range matches {
+		if m.User == "" || m.User == selfID {
+			continue
+		}
+		if u, ok := usersMap[m.User]; ok && (u.IsBot || u.Deleted) {
+			continue
+		}
+		c, ok := byUser[m.User]
+		if !ok {
+			c = &candidate{userID: m.User, channels: map[string]bool{}}
+			byUser[m.User] = c
+		}
+		t := parseSlackTimestamp(m.Timestamp)
+		age := now.Sub(t)
+		c.score += math.Pow(0.5, float64(age)/float64(expertHalfLife))
+		c.messages++
+		c.channels[m.Channel.Name] = true
+		if t.After(c.latest) {
+			c.latest = t
+		}
+		if len(c.samples) < 2 {
+			c.samples = append(c.samples, m)
+		}
+	}
+
+	ranked := make([]*candidate, 0, len(byUser))
+	for _, c := range byUser {
+		// Discussing a topic across channels is a stronger signal than
+		// repeating it in one
+		c.score *= 1 + 0.1*float64(len(c.channels)-1)
+		ranked = append(ranked, c)
+	}
+	sort.Slice(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	experts := make([]map[string]interface{}, 0, len(ranked))
+	for _, c := range ranked {
+		channels := make([]string, 0, len(c.channels))
+		for ch := range c.channels {
+			channels = append(channels, ch)
+		}
+		sort.Strings(channels)
+
+		samples := make([]map[string]interface{}, 0, len(c.samples))
+		for _, s := range c.samples {
+			samples = append(samples, map[string]interface{}{
+				"channel":   s.Channel.Name,
+				"text":      s.Text,
+				"permalink": s.Permalink,
+			})
+		}
+
+		expert := map[string]interface{}{
+			"user":       userDisplayName(c.userID, usersMap),
+			"messages":   c.messages,
+			"channels":   channels,
+			"lastActive": formatTimestamp(c.latest),
+			"score":      fmt.Sprintf("%.2f", c.score),
+			"samples":    samples,
+		}
+		if u, ok := usersMap[c.userID]; ok {
+			expert["username"] = u.Name
+			if u.Profile.Title != "" {
+				expert["title"] = u.Profile.Title
+			}
+		}
+		experts = append(experts, expert)
+	}
+
+	result := &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Found %d people discussing '%s' (%d messages searched)", len(experts), topic, len(matches)),
+		Data: map[string]interface{}{
+			"topic":   topic,
+			"experts": experts,
+		},
+		ResultCount: len(experts),
+	}
+
+	if len(experts) > 0 {
+		top := experts[0]
+		result.NextActions = []string{
+			fmt.Sprintf("Ask them directly: send-message channel='%s'", top["username"]),
+			fmt.Sprintf("See the discussions: search query='%s' rank='relevance'", topic),
+		}
+		result.Guidance = "Ranked by message count weighted toward recent activity. Check the samples before reaching out."
+	} else {
+		result.Guidance = "Nobody has discussed this recently. Try broader terms or a longer timeframe."
+		result.NextActions = []string{
+			fmt.Sprintf("find-expert topic='%s' timeframe='12m'", topic),
+		}
+	}
+
+	return result, nil
+}
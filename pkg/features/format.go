@@ -12,6 +12,13 @@ func FormatResult(toolName string, result *FeatureResult) string {
 	if !result.Success {
 		return formatError(result)
 	}
+	return partialNotice(result) + formatBody(toolName, result)
+}
+
+func formatBody(toolName string, result *FeatureResult) string {
+	if data := dataMap(result); data != nil && data["estimate"] == true {
+		return formatEstimateResult(result)
+	}
 
 	switch toolName {
 	case "check-unreads":
@@ -24,22 +31,94 @@ func FormatResult(toolName string, result *FeatureResult) string {
 		return formatUsers(result)
 	case "catch-up":
 		return formatCatchUp(result)
-	case "get-context":
+	case "get-context", "read-messages", "read-thread":
 		return formatContext(result)
-	case "search":
+	case "get-reply-context":
+		return formatReplyContext(result)
+	case "search", "search-semantic":
 		return formatSearch(result)
 	case "send-message":
 		return formatSendMessage(result)
+	case "revise-draft":
+		return formatDraft(result)
+	case "post-snippet", "send-nudge", "quick-respond", "export-directory", "export-to-vault":
+		return result.Message + footer(result)
 	case "mark-read":
 		return formatMarkRead(result)
-	case "react":
+	case "react", "star-channel", "unstar-channel":
 		return formatReact(result)
 	case "check-timing":
 		return formatTiming(result)
+	case "channel-activity-profile":
+		return formatActivityProfile(result)
+	case "channel-health":
+		return formatChannelHealth(result)
+	case "find-expert":
+		return formatFindExpert(result)
+	case "check-message-reach":
+		return formatMessageReach(result)
+	case "check-reactions-to-me":
+		return formatReactionsToMe(result)
+	case "analyze-channel-overlap":
+		return formatChannelOverlap(result)
+	case "rank-my-channels":
+		return formatRankMyChannels(result)
+	case "suggest-channel":
+		return formatSuggestChannel(result)
+	case "discover-channels":
+		return formatDiscoverChannels(result)
+	case "list-dms":
+		return formatListDMs(result)
+	case "recent-activity-timeline":
+		return formatTimeline(result)
+	case "list-my-threads":
+		return formatMyThreads(result)
+	case "catch-up-on-person":
+		return formatCatchUpOnPerson(result)
+	case "prep-for-meeting":
+		return formatPrepForMeeting(result)
+	case "refresh-credentials":
+		return formatRefreshCredentials(result)
 	case "auth-setup":
 		return formatAuthSetup(result)
 	case "download-file":
 		return formatDownloadFile(result)
+	case "usage-stats":
+		return formatUsageStats(result)
+	case "workspace-overview":
+		return formatWorkspaceOverview(result)
+	case "debug-internal":
+		return formatDebugInternal(result)
+	case "capabilities":
+		return formatCapabilities(result)
+	case "pause-background-refresh":
+		return formatBackgroundRefresh(result)
+	case "purge-local-data":
+		return formatPurgeLocalData(result)
+	case "check-saved-searches":
+		return formatSavedSearches(result)
+	case "list-outbox", "flush-outbox":
+		return formatOutbox(result)
+	case "batch":
+		return formatBatch(result)
+	case "get-recent-events":
+		return formatRecentEvents(result)
+	case "set-meetings":
+		return formatMeetings(result)
+	case "log-decision":
+		return formatLogDecision(result)
+	case "generate-team-report":
+		return formatTeamReport(result)
+	case "brief-me-on-channel":
+		return formatChannelBrief(result)
+	case "topic-timeline":
+		return formatTopicTimeline(result)
+	case "collect-message":
+		return result.Message + footer(result)
+	case "list-collection":
+		return formatCollection(result)
+	case "create-ticket-from-thread":
+		return formatCreateTicket(result)
 	default:
 		return formatGeneric(result)
 	}
@@ -147,6 +226,53 @@ func footer(result *FeatureResult) string {
 	return "\n\n---\n" + strings.Join(parts, "\n")
 }
 
+// staleNotice flags results answered from cache
+func staleNotice(data map[string]interface{}) string {
+	if stale, ok := data["stale"].(bool); !ok || !stale {
+		return ""
+	}
+	if at := str(data, "cachedAt"); at != "" {
+		return fmt.Sprintf("_⏳ Cached as of %s — may be stale_\n\n", at)
+	}
+	return "_⏳ Cached — may be stale_\n\n"
+}
+
+// partialNotice heads a result that stopped early with what it left out,
+// so it isn't passed on as the whole picture
+func partialNotice(result *FeatureResult) string {
+	if !result.Partial {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("> ⚠️ **Partial result — not everything was covered.**\n")
+	for _, c := range result.Coverage {
+		line := "> - " + strings.ReplaceAll(c.Reason, "_", " ")
+		if c.Detail != "" {
+			line += ": " + c.Detail
+		}
+		switch {
+		case c.ScannedFrom != "" && c.ScannedTo != "":
+			line += fmt.Sprintf(" (read %s to %s)", c.ScannedFrom, c.ScannedTo)
+		case c.ScannedFrom != "":
+			line += fmt.Sprintf(" (read back to %s)", c.ScannedFrom)
+		}
+		if n := len(c.Skipped); n > 0 {
+			line += fmt.Sprintf("; %d channels not read: %s", n, nameList(c.Skipped, 10))
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// nameList joins up to max names, noting how many more there are
+func nameList(names []string, max int) string {
+	if len(names) <= max {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s, +%d more", strings.Join(names[:max], ", "), len(names)-max)
+}
+
 // --- Error ---
 
 func formatError(result *FeatureResult) string {
@@ -171,6 +297,7 @@ func formatUnreads(result *FeatureResult) string {
 	statsMap, _ := stats.(map[string]interface{})
 
 	b.WriteString(fmt.Sprintf("## Unreads\n\n"))
+	b.WriteString(staleNotice(data))
 
 	unreads, _ := data["unreads"].(map[string]interface{})
 	if unreads == nil {
@@ -189,7 +316,7 @@ func formatUnreads(result *FeatureResult) string {
 			if v, ok := dm["urgent"].(bool); ok && v {
 				urgent = " [URGENT]"
 			}
-			b.WriteString(fmt.Sprintf("**%s** (%d unread)%s\n", author, count, urgent))
+			b.WriteString(fmt.Sprintf("**%s** (%d unread)%s%s\n", author, count, urgent, starredTag(dm)))
 
 			messages := asList(dm["messages"])
 			limit := 5
@@ -204,6 +331,9 @@ func formatUnreads(result *FeatureResult) string {
 					text = "(attachment/empty)"
 				}
 				b.WriteString(fmt.Sprintf("  %s | %s: %s\n", ts, user, text))
+				if actions := asList(msg["actions"]); len(actions) > 0 {
+					b.WriteString("  🔘 " + actionLabels(actions) + "\n")
+				}
 			}
 			if len(messages) > 5 {
 				b.WriteString(fmt.Sprintf("  +%d more messages\n", len(messages)-5))
@@ -225,7 +355,11 @@ func formatUnreads(result *FeatureResult) string {
 			if v, ok := m["urgent"].(bool); ok && v {
 				urgent = " [URGENT]"
 			}
-			b.WriteString(fmt.Sprintf("#%s | %s | %s%s\n  %s\n\n", channel, author, ts, urgent, text))
+			b.WriteString(fmt.Sprintf("#%s | %s | %s%s%s\n  %s\n", channel, author, ts, urgent, starredTag(m), text))
+			if link := str(m, "permalink"); link != "" {
+				b.WriteString(fmt.Sprintf("  %s\n", link))
+			}
+			b.WriteString("\n")
 		}
 	}
 
@@ -238,10 +372,52 @@ func formatUnreads(result *FeatureResult) string {
 			lastMsg := truncate(str(ch, "lastMessage"), 100)
 			ts := str(ch, "timestamp")
 			if ts != "" {
-				b.WriteString(fmt.Sprintf("#%s | %s\n  %s\n\n", name, ts, lastMsg))
+				b.WriteString(fmt.Sprintf("#%s | %s%s\n  %s\n\n", name, ts, starredTag(ch), lastMsg))
 			} else {
-				b.WriteString(fmt.Sprintf("#%s\n  %s\n\n", name, lastMsg))
+				b.WriteString(fmt.Sprintf("#%s%s\n  %s\n\n", name, starredTag(ch), lastMsg))
+			}
+		}
+	}
+
+	// App DMs
+	appDMs := asList(unreads["appDMs"])
+	if len(appDMs) > 0 {
+		b.WriteString(fmt.Sprintf("### App DMs (%d)\n\n", len(appDMs)))
+		for _, dm := range appDMs {
+			urgent := ""
+			if v, ok := dm["urgent"].(bool); ok && v {
+				urgent = " [URGENT]"
 			}
+			header := fmt.Sprintf("**%s**", str(dm, "app"))
+			if count := num(dm, "unreadCount"); count > 0 {
+				header += fmt.Sprintf(" (%d unread)", count)
+			}
+			b.WriteString(header + urgent + starredTag(dm) + "\n")
+			messages := asList(dm["messages"])
+			shown := messages
+			if len(shown) > 5 {
+				shown = shown[:5]
+			}
+			for _, msg := range shown {
+				text := str(msg, "text")
+				if title := str(msg, "title"); title != "" && !strings.HasPrefix(text, title) {
+					text = title + " — " + text
+				}
+				if text == "" {
+					text = "(attachment/empty)"
+				}
+				b.WriteString(fmt.Sprintf("  %s | %s\n", str(msg, "timestamp"), truncate(strings.ReplaceAll(text, "\n", " "), 160)))
+				if link := firstNonEmpty(str(msg, "link"), str(msg, "permalink")); link != "" {
+					b.WriteString(fmt.Sprintf("  %s\n", link))
+				}
+				if actions := asList(msg["actions"]); len(actions) > 0 {
+					b.WriteString("  🔘 " + actionLabels(actions) + "\n")
+				}
+			}
+			if len(messages) > len(shown) {
+				b.WriteString(fmt.Sprintf("  +%d more messages\n", len(messages)-len(shown)))
+			}
+			b.WriteString("\n")
 		}
 	}
 
@@ -266,6 +442,14 @@ func formatUnreads(result *FeatureResult) string {
 	return b.String()
 }
 
+// starredTag marks an item from a starred conversation
+func starredTag(m map[string]interface{}) string {
+	if v, ok := m["starred"].(bool); ok && v {
+		return " [STARRED]"
+	}
+	return ""
+}
+
 // --- check-mentions ---
 
 func formatMentions(result *FeatureResult) string {
@@ -296,7 +480,21 @@ func formatMentions(result *FeatureResult) string {
 			tag = " [?]"
 		}
 
-		b.WriteString(fmt.Sprintf("#%s | %s | %s%s%s\n  %s\n\n", channel, author, ts, tag, responded, text))
+		b.WriteString(fmt.Sprintf("#%s | %s | %s%s%s%s\n  %s\n", channel, author, ts, tag, starredTag(m), responded, text))
+		if link := str(m, "permalink"); link != "" {
+			b.WriteString(fmt.Sprintf("  %s\n", link))
+		}
+		b.WriteString("\n")
+	}
+
+	// Scans say which channels they read; search covers everything
+	if summary, ok := data["summary"].(map[string]interface{}); ok {
+		if covered := stringList(summary["covered"]); len(covered) > 0 {
+			b.WriteString(fmt.Sprintf("Scanned %d channels: %s\n", len(covered), nameList(covered, 15)))
+		}
+		if notFound := stringList(summary["notFound"]); len(notFound) > 0 {
+			b.WriteString(fmt.Sprintf("Not found: %s\n", strings.Join(notFound, ", ")))
+		}
 	}
 
 	b.WriteString(footer(result))
@@ -314,9 +512,27 @@ func formatChannels(result *FeatureResult) string {
 	var b strings.Builder
 	channels := asList(data["channels"])
 
-	b.WriteString(fmt.Sprintf("## Channels (%d)\n\n", len(channels)))
+	if section := str(data, "section"); section != "" {
+		b.WriteString(fmt.Sprintf("## %s (%d)\n\n", section, len(channels)))
+	} else {
+		b.WriteString(fmt.Sprintf("## Channels (%d)\n\n", len(channels)))
+	}
+	b.WriteString(staleNotice(data))
 
+	groupBy := str(data, "groupBy")
+	grouped := groupBy == "category" || groupBy == "section"
+	group := "-"
 	for _, ch := range channels {
+		if category := str(ch, groupBy); grouped && category != group {
+			group = category
+			if group == "" && groupBy == "section" {
+				b.WriteString("\n### other\n")
+			} else if group == "" {
+				b.WriteString("\n### uncategorized\n")
+			} else {
+				b.WriteString(fmt.Sprintf("\n### %s\n", group))
+			}
+		}
 		display := str(ch, "displayName")
 		purpose := truncate(str(ch, "purpose"), 60)
 		member := ""
@@ -405,11 +621,12 @@ func formatCatchUp(result *FeatureResult) string {
 		}
 
 		b.WriteString(fmt.Sprintf("**%s** (%s)%s\n%s\n", author, ts, tags, text))
-		for _, f := range asList(item["files"]) {
-			name := str(f, "name")
-			id := str(f, "id")
-			mime := str(f, "mimetype")
-			b.WriteString(fmt.Sprintf("📎 %s (%s, id=%s) — download-file fileId='%s'\n", name, mime, id, id))
+		if rollup, ok := item["rollup"].(map[string]interface{}); ok {
+			b.WriteString(formatThreadRollup(rollup))
+		}
+		formatAttachments(&b, item)
+		if link := str(item, "permalink"); link != "" {
+			b.WriteString(link + "\n")
 		}
 		b.WriteString("\n")
 	}
@@ -418,6 +635,19 @@ func formatCatchUp(result *FeatureResult) string {
 	return b.String()
 }
 
+func formatThreadRollup(rollup map[string]interface{}) string {
+	var b strings.Builder
+	participants, _ := rollup["participants"].([]string)
+	b.WriteString(fmt.Sprintf("  🧵 %d replies from %s\n", num(rollup, "replyCount"), strings.Join(participants, ", ")))
+	if last, ok := rollup["last"].(map[string]interface{}); ok {
+		b.WriteString(fmt.Sprintf("  Latest: %s: %s\n", str(last, "author"), truncate(str(last, "text"), 200)))
+	}
+	if d, ok := rollup["decisionMessage"].(map[string]interface{}); ok {
+		b.WriteString(fmt.Sprintf("  ✅ Decision: %s: %s\n", str(d, "author"), truncate(str(d, "text"), 200)))
+	}
+	return b.String()
+}
+
 // --- get-context ---
 
 func formatContext(result *FeatureResult) string {
@@ -436,6 +666,26 @@ func formatContext(result *FeatureResult) string {
 	}
 	b.WriteString(fmt.Sprintf("## %s (%d messages)\n\n", header, len(messages)))
 
+	if participants := asList(data["participation"]); len(participants) > 0 {
+		parts := make([]string, 0, len(participants))
+		for _, p := range participants {
+			name := str(p, "user")
+			if v, ok := p["isYou"].(bool); ok && v {
+				name = "you"
+			}
+			part := fmt.Sprintf("%s (%d)", name, num(p, "messages"))
+			if v, ok := p["hasResponded"].(bool); ok && !v {
+				part += " — hasn't replied"
+			}
+			parts = append(parts, part)
+		}
+		label := "Participants"
+		if v, ok := data["participationComplete"].(bool); ok && !v {
+			label += " (this page)"
+		}
+		b.WriteString(fmt.Sprintf("**%s:** %s\n\n", label, strings.Join(parts, ", ")))
+	}
+
 	for _, msg := range messages {
 		user := str(msg, "user")
 		text := str(msg, "text")
@@ -454,11 +704,13 @@ func formatContext(result *FeatureResult) string {
 		}
 
 		b.WriteString(fmt.Sprintf("**%s** (%s)%s\n%s\n", user, ts, replyTag, text))
-		for _, f := range asList(msg["files"]) {
-			name := str(f, "name")
-			id := str(f, "id")
-			mime := str(f, "mimetype")
-			b.WriteString(fmt.Sprintf("📎 %s (%s, id=%s) — download-file fileId='%s'\n", name, mime, id, id))
+		formatAttachments(&b, msg)
+		if reactions := asList(msg["reactions"]); len(reactions) > 0 {
+			parts := make([]string, 0, len(reactions))
+			for _, r := range reactions {
+				parts = append(parts, fmt.Sprintf(":%s: %d", str(r, "emoji"), num(r, "count")))
+			}
+			b.WriteString(strings.Join(parts, "  ") + "\n")
 		}
 		b.WriteString("\n")
 	}
@@ -467,6 +719,52 @@ func formatContext(result *FeatureResult) string {
 	return b.String()
 }
 
+// formatAttachments renders the files and attachments of a message entry
+func formatAttachments(b *strings.Builder, msg map[string]interface{}) {
+	for _, f := range asList(msg["files"]) {
+		name := str(f, "name")
+		id := str(f, "id")
+		kind := str(f, "type")
+		if kind == "" {
+			kind = str(f, "mimetype")
+		}
+		b.WriteString(fmt.Sprintf("📎 %s (%s, %s, id=%s) — download-file fileId='%s'\n", name, kind, formatFileSize(num(f, "size")), id, id))
+	}
+	for _, a := range asList(msg["attachments"]) {
+		title := str(a, "title")
+		if title == "" {
+			title = truncate(str(a, "text"), 150)
+		}
+		if title == "" {
+			continue
+		}
+		if link := str(a, "link"); link != "" {
+			b.WriteString(fmt.Sprintf("🔗 %s — %s\n", title, link))
+		} else {
+			b.WriteString(fmt.Sprintf("🔗 %s\n", title))
+		}
+	}
+	if actions := asList(msg["actions"]); len(actions) > 0 {
+		b.WriteString("🔘 Actions (use in Slack): " + actionLabels(actions) + "\n")
+	}
+}
+
+// actionLabels renders a message's buttons and menus on one line
+func actionLabels(actions []map[string]interface{}) string {
+	labels := make([]string, 0, len(actions))
+	for _, a := range actions {
+		label := fmt.Sprintf("[%s]", str(a, "label"))
+		if kind := str(a, "type"); kind != "button" {
+			label += " (" + kind + ")"
+		}
+		if url := str(a, "url"); url != "" {
+			label += " " + url
+		}
+		labels = append(labels, label)
+	}
+	return strings.Join(labels, ", ")
+}
+
 // --- search ---
 
 func formatSearch(result *FeatureResult) string {
@@ -475,6 +773,11 @@ func formatSearch(result *FeatureResult) string {
 		return formatGeneric(result)
 	}
 
+	// search threadId= delegates to read-thread
+	if _, ok := data["parent"]; ok {
+		return formatContext(result)
+	}
+
 	var b strings.Builder
 	query := str(data, "query")
 
@@ -513,7 +816,19 @@ func formatSearch(result *FeatureResult) string {
 			attachTag = " 📎"
 		}
 
-		b.WriteString(fmt.Sprintf("#%s | %s | %s%s%s\n%s\n", channel, user, ts, threadTag, attachTag, text))
+		contextLines := asList(msg["context"])
+		b.WriteString(fmt.Sprintf("#%s | %s | %s%s%s\n", channel, user, ts, threadTag, attachTag))
+		for _, c := range contextLines {
+			if str(c, "position") == "before" {
+				b.WriteString(fmt.Sprintf("  ↑ %s: %s\n", str(c, "user"), truncate(str(c, "text"), 150)))
+			}
+		}
+		b.WriteString(text + "\n")
+		for _, c := range contextLines {
+			if str(c, "position") == "after" {
+				b.WriteString(fmt.Sprintf("  ↓ %s: %s\n", str(c, "user"), truncate(str(c, "text"), 150)))
+			}
+		}
 		if attachTag != "" {
 			b.WriteString(fmt.Sprintf("  (has attachments — get-context channel='%s' messageTs='%s' for file IDs)\n", channel, ts))
 		}
@@ -536,6 +851,11 @@ func formatSendMessage(result *FeatureResult) string {
 	}
 
 	channel := str(data, "channel")
+	scheduled, _ := data["scheduled"].(bool)
+	queued, _ := data["queued"].(bool)
+	if scheduled || queued || num(data, "parts") > 1 {
+		return result.Message + footer(result)
+	}
 	s := fmt.Sprintf("Message sent to %s.", channel)
 	s += footer(result)
 	return s
@@ -544,7 +864,29 @@ func formatSendMessage(result *FeatureResult) string {
 // --- mark-read ---
 
 func formatMarkRead(result *FeatureResult) string {
-	return result.Message + footer(result)
+	data := dataMap(result)
+	if data == nil || data["preview"] != true {
+		return result.Message + footer(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(result.Message + "\n\n")
+	for _, c := range asList(data["wouldMark"]) {
+		line := fmt.Sprintf("- %s (%s)", str(c, "name"), str(c, "id"))
+		if id := str(c, "threadId"); id != "" {
+			line = fmt.Sprintf("- thread in %s (%s) — %d unread replies", str(c, "name"), id, num(c, "unreadReplies"))
+		}
+		if n := num(c, "mentionCount"); n > 0 {
+			line += fmt.Sprintf(" — %d mentions", n)
+		}
+		b.WriteString(line + "\n")
+	}
+	if excluded, ok := data["excluded"].([]string); ok && len(excluded) > 0 {
+		b.WriteString("\nExcluded: " + strings.Join(excluded, ", ") + "\n")
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
 }
 
 // --- react ---
@@ -582,93 +924,1299 @@ func formatTiming(result *FeatureResult) string {
 	return b.String()
 }
 
-// --- auth-setup ---
+// --- channel-activity-profile ---
 
-func formatAuthSetup(result *FeatureResult) string {
+func formatActivityProfile(result *FeatureResult) string {
 	data := dataMap(result)
 	if data == nil {
-		return result.Message
+		return formatGeneric(result)
 	}
 
-	state := str(data, "state")
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## Activity — #%s (last %d weeks, %d messages, %s)\n\n",
+		str(data, "channel"), num(data, "weeks"), num(data, "messages"), str(data, "timezone")))
 
-	// Legacy status field (from clear action)
-	if state == "" {
-		status := str(data, "status")
-		switch status {
-		case "cleared":
-			return "Credentials cleared." + footer(result)
-		case "no_credentials":
-			return "No credentials stored." + footer(result)
-		default:
-			return result.Message + footer(result)
+	bar := func(count, max int) string {
+		if max == 0 {
+			return ""
 		}
+		return strings.Repeat("█", (count*20+max-1)/max)
 	}
 
-	var s strings.Builder
+	days := asList(data["byDay"])
+	maxDay := 0
+	for _, d := range days {
+		if c := num(d, "count"); c > maxDay {
+			maxDay = c
+		}
+	}
+	b.WriteString("**By weekday**\n```\n")
+	for _, d := range days {
+		b.WriteString(fmt.Sprintf("%s %5d %s\n", str(d, "day"), num(d, "count"), bar(num(d, "count"), maxDay)))
+	}
+	b.WriteString("```\n")
 
-	switch state {
-	case "complete":
-		s.WriteString(fmt.Sprintf("Connected to **%s** as **%s**.", str(data, "team"), str(data, "user")))
+	hours := asList(data["byHour"])
+	maxHour := 0
+	for _, h := range hours {
+		if c := num(h, "count"); c > maxHour {
+			maxHour = c
+		}
+	}
+	b.WriteString("**By hour**\n```\n")
+	for _, h := range hours {
+		if c := num(h, "count"); c > 0 {
+			b.WriteString(fmt.Sprintf("%02d:00 %5d %s\n", num(h, "hour"), c, bar(c, maxHour)))
+		}
+	}
+	b.WriteString("```\n")
 
-	case "browser_choice":
-		s.WriteString("## Setup — Choose Browser\n\n")
-		s.WriteString(result.Message + "\n\n")
-		if browsers, ok := data["browsers"]; ok {
-			formatMapList(&s, browsers, func(m map[string]string) string {
-				return fmt.Sprintf("- **%s** (`%s`)\n", m["display_name"], m["name"])
-			})
+	if peaks := asList(data["peaks"]); len(peaks) > 0 {
+		var parts []string
+		for _, p := range peaks {
+			parts = append(parts, fmt.Sprintf("%s (%d)", str(p, "slot"), num(p, "count")))
 		}
+		b.WriteString("**Peak slots:** " + strings.Join(parts, ", ") + "\n")
+	}
 
-	case "profile_choice":
-		s.WriteString("## Setup — Choose Profile\n\n")
-		s.WriteString(result.Message + "\n\n")
-		if profiles, ok := data["profiles"]; ok {
-			formatMapList(&s, profiles, func(m map[string]string) string {
-				email := ""
-				if e := m["email"]; e != "" {
-					email = fmt.Sprintf(" (%s)", e)
-				}
-				return fmt.Sprintf("- **%s**%s — dir: `%s`\n", m["display_name"], email, m["dir_name"])
-			})
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- find-expert ---
+
+func formatFindExpert(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	experts := asList(data["experts"])
+	b.WriteString(fmt.Sprintf("## Who knows about \"%s\" (%d people)\n\n", str(data, "topic"), len(experts)))
+
+	for i, e := range experts {
+		title := ""
+		if t := str(e, "title"); t != "" {
+			title = " — " + t
+		}
+		channels, _ := e["channels"].([]string)
+		b.WriteString(fmt.Sprintf("%d. **%s** (@%s)%s\n   %d messages in %s, last %s\n",
+			i+1, str(e, "user"), str(e, "username"), title, num(e, "messages"),
+			strings.Join(channels, ", "), str(e, "lastActive")))
+		for _, s := range asList(e["samples"]) {
+			b.WriteString(fmt.Sprintf("   > #%s: %s\n", str(s, "channel"), truncate(str(s, "text"), 150)))
+			if link := str(s, "permalink"); link != "" {
+				b.WriteString(fmt.Sprintf("     %s\n", link))
+			}
 		}
+	}
 
-	case "profile_locked":
-		s.WriteString("## Setup — Browser Locked\n\n")
-		s.WriteString(result.Message)
+	b.WriteString(footer(result))
+	return b.String()
+}
 
-	case "firefox_ext_written":
-		s.WriteString("## Setup — Firefox Extension\n\n")
-		s.WriteString(result.Message)
+// --- suggest-channel ---
 
-	case "waiting_for_callback":
-		s.WriteString("Waiting for tokens from browser...")
+func formatSuggestChannel(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
 
-	case "failed":
-		s.WriteString("## Setup — Failed\n\n")
-		s.WriteString(result.Message)
+	var b strings.Builder
+	suggestions := asList(data["suggestions"])
+	b.WriteString(fmt.Sprintf("## Suggested channels (%d)\n\n", len(suggestions)))
+	for i, s := range suggestions {
+		member := ""
+		if v, ok := s["isMember"].(bool); ok && v {
+			member = " [member]"
+		}
+		b.WriteString(fmt.Sprintf("%d. #%s%s", i+1, str(s, "channel"), member))
+		if purpose := truncate(str(s, "purpose"), 80); purpose != "" {
+			b.WriteString(" — " + purpose)
+		}
+		b.WriteString("\n")
+		if reasons, ok := s["reasons"].([]string); ok && len(reasons) > 0 {
+			b.WriteString("   " + strings.Join(reasons, "; ") + "\n")
+		}
+	}
 
-	default:
-		s.WriteString(result.Message)
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- discover-channels ---
+
+func formatDiscoverChannels(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
 	}
 
-	s.WriteString(footer(result))
-	return s.String()
+	var b strings.Builder
+	channels := asList(data["channels"])
+	terms, _ := data["terms"].([]string)
+	b.WriteString(fmt.Sprintf("## Channels discussing %s (%d)\n\n", strings.Join(terms, ", "), len(channels)))
+	for i, ch := range channels {
+		member := ""
+		if v, ok := ch["isMember"].(bool); ok && v {
+			member = " [member]"
+		}
+		b.WriteString(fmt.Sprintf("%d. #%s%s (%d members)", i+1, str(ch, "channel"), member, num(ch, "members")))
+		if about := truncate(firstNonEmpty(str(ch, "purpose"), str(ch, "topic")), 80); about != "" {
+			b.WriteString(" — " + about)
+		}
+		b.WriteString("\n")
+		if reasons, ok := ch["reasons"].([]string); ok && len(reasons) > 0 {
+			b.WriteString("   " + strings.Join(reasons, "; ") + "\n")
+		}
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
 }
 
-// --- download-file ---
+// --- list-dms ---
 
-func formatDownloadFile(result *FeatureResult) string {
-	s := result.Message
-	if data := dataMap(result); data != nil {
-		if mt := str(data, "mimetype"); mt != "" {
-			s += fmt.Sprintf(" [%s]", mt)
+func formatListDMs(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(staleNotice(data))
+	b.WriteString(fmt.Sprintf("## Direct messages (%d)", num(data, "total")))
+	if n := num(data, "totalUnread"); n > 0 {
+		b.WriteString(fmt.Sprintf(" — %d unread", n))
+	}
+	b.WriteString("\n\n")
+	for _, dm := range asList(data["dms"]) {
+		marker := "  "
+		if v, ok := dm["hasUnreads"].(bool); ok && v {
+			marker = "● "
 		}
-		if path := str(data, "path"); path != "" {
-			s += fmt.Sprintf("\nPath: `%s`", path)
+		b.WriteString(marker + str(dm, "with"))
+		if str(dm, "type") == "group-dm" {
+			b.WriteString(" (group)")
+		}
+		if n := num(dm, "unread"); n > 0 {
+			b.WriteString(fmt.Sprintf(" — %d unread", n))
+		}
+		if at := str(dm, "lastActivity"); at != "" {
+			b.WriteString(" · " + at)
+		}
+		b.WriteString(fmt.Sprintf(" `%s`\n", str(dm, "id")))
+		if msg := str(dm, "lastMessage"); msg != "" {
+			b.WriteString(fmt.Sprintf("   %s: %s\n", str(dm, "lastFrom"), strings.ReplaceAll(msg, "\n", " ")))
 		}
 	}
-	return s + footer(result)
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- recent-activity-timeline ---
+
+func formatTimeline(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	entries := asList(data["timeline"])
+	b.WriteString(fmt.Sprintf("## Unread timeline (%d conversations)\n\n", num(data, "total")))
+	for _, e := range entries {
+		tag := ""
+		if n := num(e, "mentions"); n > 0 {
+			tag = fmt.Sprintf(" [%d @]", n)
+		}
+		b.WriteString(fmt.Sprintf("%s | %s | %s%s%s `%s`\n  %s\n", str(e, "timestamp"), str(e, "conversation"), str(e, "author"),
+			tag, starredTag(e), str(e, "channelId"), truncate(strings.ReplaceAll(str(e, "message"), "\n", " "), 140)))
+		formatAttachments(&b, e)
+		if link := str(e, "permalink"); link != "" {
+			b.WriteString(fmt.Sprintf("  %s\n", link))
+		}
+	}
+	if len(entries) > 0 {
+		b.WriteString("\n")
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- get-reply-context ---
+
+func formatReplyContext(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	asker, _ := data["asker"].(map[string]interface{})
+	b.WriteString(fmt.Sprintf("## Replying to %s in %s\n\n", str(asker, "name"), str(data, "channel")))
+
+	if reply, ok := data["replyTo"].(map[string]interface{}); ok {
+		for _, line := range strings.Split(str(reply, "text"), "\n") {
+			b.WriteString("> " + line + "\n")
+		}
+		b.WriteString(fmt.Sprintf("— %s, %s\n", str(reply, "user"), str(reply, "time")))
+		if link := str(reply, "permalink"); link != "" {
+			b.WriteString(link + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	var about []string
+	for _, key := range []string{"title", "localTime", "presence", "status"} {
+		if v := str(asker, key); v != "" {
+			if key == "localTime" {
+				v = "local time " + v
+			}
+			about = append(about, v)
+		}
+	}
+	if len(about) > 0 {
+		b.WriteString(fmt.Sprintf("**%s:** %s\n\n", str(asker, "name"), strings.Join(about, " · ")))
+	}
+
+	thread := asList(data["thread"])
+	b.WriteString(fmt.Sprintf("### Thread (%d messages)\n", len(thread)))
+	for _, m := range thread {
+		b.WriteString(fmt.Sprintf("**%s** (%s): %s\n", str(m, "user"), str(m, "time"), strings.ReplaceAll(str(m, "text"), "\n", " ")))
+	}
+
+	if related := asList(data["related"]); len(related) > 0 {
+		b.WriteString(fmt.Sprintf("\n### What %s said elsewhere\n", str(asker, "name")))
+		for _, r := range related {
+			b.WriteString(fmt.Sprintf("- %s %s: %s", str(r, "channel"), str(r, "timestamp"), truncate(strings.ReplaceAll(str(r, "text"), "\n", " "), 160)))
+			if link := str(r, "permalink"); link != "" {
+				b.WriteString(" — " + link)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if pins := asList(data["pins"]); len(pins) > 0 {
+		b.WriteString("\n### Relevant pins\n")
+		for _, p := range pins {
+			line := "- " + truncate(strings.ReplaceAll(str(p, "text"), "\n", " "), 160)
+			if author := str(p, "author"); author != "" {
+				line += " (" + author + ")"
+			}
+			if link := str(p, "permalink"); link != "" {
+				line += " — " + link
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- revise-draft ---
+
+func formatDraft(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return result.Message + footer(result)
+	}
+	// confirm-send answers as send-message does
+	if _, sent := data["channelId"]; sent {
+		return formatSendMessage(result)
+	}
+
+	var b strings.Builder
+	if drafts, ok := data["drafts"]; ok {
+		list := asList(drafts)
+		b.WriteString(fmt.Sprintf("## Drafts (%d)\n\n", len(list)))
+		for _, d := range list {
+			b.WriteString(fmt.Sprintf("`%s` → %s, revision %d · %s\n  %s\n", str(d, "draftId"), str(d, "channel"),
+				num(d, "revision"), str(d, "updated"), truncate(strings.ReplaceAll(str(d, "text"), "\n", " "), 120)))
+		}
+		b.WriteString(footer(result))
+		return b.String()
+	}
+
+	d, _ := data["draft"].(map[string]interface{})
+	if d == nil {
+		return result.Message + footer(result)
+	}
+	b.WriteString(result.Message + "\n\n")
+	b.WriteString(fmt.Sprintf("## Draft `%s` → %s (revision %d)\n\n", str(d, "draftId"), str(d, "channel"), num(d, "revision")))
+	for _, line := range strings.Split(str(d, "text"), "\n") {
+		b.WriteString("> " + line + "\n")
+	}
+	if pending := stringList(d["pendingInstructions"]); len(pending) > 0 {
+		b.WriteString("\n**To apply:**\n")
+		for _, p := range pending {
+			b.WriteString("- " + p + "\n")
+		}
+	}
+	if history := asList(d["history"]); len(history) > 1 {
+		b.WriteString("\n**History:**\n")
+		for _, r := range history {
+			line := fmt.Sprintf("%d. %s", num(r, "revision"), str(r, "at"))
+			if instruction := str(r, "instruction"); instruction != "" {
+				line += " — " + instruction
+			}
+			b.WriteString(line + "\n   " + truncate(strings.ReplaceAll(str(r, "text"), "\n", " "), 120) + "\n")
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- list-my-threads ---
+
+func formatMyThreads(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## Threads (%d with new replies, %d unread replies)\n\n", num(data, "withUnreads"), num(data, "totalUnreadReplies")))
+	for _, t := range asList(data["threads"]) {
+		marker := "  "
+		if num(t, "unreadReplies") > 0 {
+			marker = "● "
+		}
+		b.WriteString(fmt.Sprintf("%s%s | %s | %s `%s`\n", marker, str(t, "conversation"), str(t, "author"), str(t, "timestamp"), str(t, "threadId")))
+		b.WriteString(fmt.Sprintf("  %s\n", truncate(strings.ReplaceAll(str(t, "text"), "\n", " "), 140)))
+		b.WriteString(fmt.Sprintf("  %d replies", num(t, "replyCount")))
+		if n := num(t, "unreadReplies"); n > 0 {
+			b.WriteString(fmt.Sprintf(", %d unread", n))
+		}
+		b.WriteString("\n")
+		for _, r := range asList(t["replies"]) {
+			new := ""
+			if v, ok := r["unread"].(bool); ok && v {
+				new = " [new]"
+			}
+			b.WriteString(fmt.Sprintf("  ↳ %s (%s)%s: %s\n", str(r, "author"), str(r, "timestamp"), new,
+				truncate(strings.ReplaceAll(str(r, "text"), "\n", " "), 120)))
+		}
+		if link := str(t, "permalink"); link != "" {
+			b.WriteString(fmt.Sprintf("  %s\n", link))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- catch-up-on-person ---
+
+func formatCatchUpOnPerson(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## %s", str(data, "person")))
+	if title := str(data, "title"); title != "" {
+		b.WriteString(" — " + title)
+	}
+	b.WriteString(fmt.Sprintf("\n%d messages in the last %s", num(data, "total"), str(data, "since")))
+	if n := num(data, "mentionsYou"); n > 0 {
+		b.WriteString(fmt.Sprintf(", %d mentioning you", n))
+	}
+	b.WriteString("\n")
+	if v, ok := data["awaitingReply"].(bool); ok && v {
+		b.WriteString("**Waiting on your reply in your DM**\n")
+	}
+
+	if convos := asList(data["conversations"]); len(convos) > 0 {
+		b.WriteString("\n**Where:** ")
+		parts := make([]string, 0, len(convos))
+		for _, c := range convos {
+			parts = append(parts, fmt.Sprintf("%s (%d)", str(c, "channel"), num(c, "messages")))
+		}
+		b.WriteString(strings.Join(parts, ", ") + "\n")
+	}
+
+	b.WriteString("\n")
+	for _, m := range asList(data["messages"]) {
+		var flags []string
+		if v, ok := m["mentionsYou"].(bool); ok && v {
+			flags = append(flags, "@you")
+		}
+		if v, ok := m["question"].(bool); ok && v {
+			flags = append(flags, "question")
+		}
+		if v, ok := m["inThread"].(bool); ok && v {
+			flags = append(flags, "thread")
+		}
+		b.WriteString(fmt.Sprintf("- **%s** · %s", str(m, "channel"), str(m, "time")))
+		if len(flags) > 0 {
+			b.WriteString(" [" + strings.Join(flags, ", ") + "]")
+		}
+		b.WriteString("\n  " + truncate(strings.ReplaceAll(str(m, "text"), "\n", " "), 200) + "\n")
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- prep-for-meeting ---
+
+func formatPrepForMeeting(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString("## Meeting prep")
+	if attendees, ok := data["attendees"].([]string); ok && len(attendees) > 0 {
+		b.WriteString(" — " + strings.Join(attendees, ", "))
+	}
+	if ch := str(data, "channel"); ch != "" {
+		b.WriteString(" in #" + ch)
+	}
+	b.WriteString(fmt.Sprintf("\n_Last %s_\n", str(data, "since")))
+
+	if questions := asList(data["questions"]); len(questions) > 0 {
+		b.WriteString(fmt.Sprintf("\n### Open questions to you (%d)\n", len(questions)))
+		for _, q := range questions {
+			b.WriteString(fmt.Sprintf("- **%s** in #%s: %s\n  threadId: `%s`\n", str(q, "author"), str(q, "channel"), truncate(str(q, "message"), 160), str(q, "threadId")))
+		}
+	}
+
+	if items := asList(data["actionItems"]); len(items) > 0 {
+		b.WriteString(fmt.Sprintf("\n### Open action items (%d)\n", len(items)))
+		for _, item := range items {
+			b.WriteString(fmt.Sprintf("- **%s** in %s · %s: %s\n", str(item, "author"), str(item, "channel"), str(item, "time"), truncate(str(item, "text"), 160)))
+		}
+	}
+
+	if threads := asList(data["threads"]); len(threads) > 0 {
+		b.WriteString(fmt.Sprintf("\n### Recent threads (%d)\n", len(threads)))
+		for i, t := range threads {
+			b.WriteString(fmt.Sprintf("%d. %s — %s: %s\n", i+1, str(t, "channel"), str(t, "started"), truncate(str(t, "text"), 120)))
+			line := fmt.Sprintf("   %d replies · %s", num(t, "replies"), str(t, "lastActivity"))
+			if people, ok := t["participants"].([]string); ok && len(people) > 0 {
+				line += " · " + strings.Join(people, ", ")
+			}
+			b.WriteString(line + "\n")
+			if last := str(t, "lastReply"); last != "" {
+				b.WriteString("   Latest: " + last + "\n")
+			}
+		}
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- channel-health ---
+
+var healthIcons = map[string]string{"heated": "🔥", "stalled": "🐢", "healthy": "✅", "quiet": "💤"}
+
+func formatChannelHealth(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## Channel health — last %d weeks\n\n", num(data, "weeks")))
+	for _, ch := range asList(data["channels"]) {
+		status := str(ch, "status")
+		b.WriteString(fmt.Sprintf("### %s #%s — %s\n", healthIcons[status], str(ch, "channel"), status))
+		if reasons, ok := ch["reasons"].([]string); ok {
+			for _, r := range reasons {
+				b.WriteString("- " + r + "\n")
+			}
+		}
+		b.WriteString("| Week of | Msgs | Reactions/msg | Negative | Intensity | Unanswered ?s | Median response |\n")
+		b.WriteString("|---|---|---|---|---|---|---|\n")
+		for _, w := range asList(ch["weeks"]) {
+			b.WriteString(fmt.Sprintf("| %s | %d | %s | %s | %s | %d/%d | %s |\n",
+				str(w, "week"), num(w, "messages"), str(w, "reactionsPerMessage"), firstNonEmpty(str(w, "negativeReactions"), "—"),
+				str(w, "intensity"), num(w, "unanswered"), num(w, "questions"), firstNonEmpty(str(w, "medianResponse"), "—")))
+		}
+		if v, ok := ch["truncated"].(bool); ok && v {
+			b.WriteString("_Only the most recent messages were read_\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- list-outbox / flush-outbox ---
+
+func formatOutbox(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## %s\n\n", result.Message))
+	for _, s := range asList(data["sent"]) {
+		b.WriteString(fmt.Sprintf("- ✅ `%s` to %s: %s\n", str(s, "id"), str(s, "channel"), truncate(str(s, "message"), 200)))
+	}
+	waiting := asList(data["messages"])
+	if waiting == nil {
+		waiting = asList(data["remaining"])
+	}
+	for _, m := range waiting {
+		line := fmt.Sprintf("- 📤 `%s` to %s (queued %s, %d attempts", str(m, "id"), str(m, "channel"), str(m, "queuedAt"), num(m, "attempts"))
+		if gaveUp, _ := m["gaveUp"].(bool); gaveUp {
+			line += ", given up"
+		} else {
+			line += ", next " + str(m, "nextAttempt")
+		}
+		line += "): " + truncate(str(m, "message"), 200)
+		if e := str(m, "lastError"); e != "" {
+			line += fmt.Sprintf(" — last error: %s", e)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- batch ---
+
+var batchIcons = map[string]string{
+	"ok":      "✅",
+	"failed":  "❌",
+	"skipped": "⏭️",
+}
+
+func formatBatch(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## %s\n\n", result.Message))
+	for _, op := range asList(data["operations"]) {
+		status := str(op, "status")
+		b.WriteString(fmt.Sprintf("### %d. %s %s\n", num(op, "index"), batchIcons[status], str(op, "tool")))
+		if out := strings.TrimSpace(str(op, "output")); out != "" {
+			b.WriteString(out + "\n")
+		} else if status == "skipped" {
+			b.WriteString("Skipped\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- estimate mode ---
+
+func formatEstimateResult(result *FeatureResult) string {
+	data := dataMap(result)
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## Estimate: %s\n\n", result.Message))
+	for _, c := range asList(data["calls"]) {
+		b.WriteString(fmt.Sprintf("- %s × %d\n", str(c, "method"), num(c, "calls")))
+	}
+	if notes, ok := data["notes"].([]string); ok && len(notes) > 0 {
+		b.WriteString("\n")
+		for _, n := range notes {
+			b.WriteString(fmt.Sprintf("> %s\n", n))
+		}
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- check-message-reach ---
+
+func formatMessageReach(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## Reach — #%s by %s\n> %s\n\n", str(data, "channel"), str(data, "author"), truncate(str(data, "text"), 200)))
+	b.WriteString(fmt.Sprintf("**Engaged:** %d people", num(data, "engaged")))
+	if rate := str(data, "engagementRate"); rate != "" {
+		b.WriteString(fmt.Sprintf(" of %d (%s)", num(data, "audience"), rate))
+	}
+	b.WriteString("\n")
+	for _, r := range asList(data["reactions"]) {
+		users, _ := r["users"].([]string)
+		b.WriteString(fmt.Sprintf(":%s: %d — %s\n", str(r, "emoji"), num(r, "count"), strings.Join(users, ", ")))
+	}
+	if n := num(data, "replyCount"); n > 0 {
+		repliers, _ := data["repliers"].([]string)
+		b.WriteString(fmt.Sprintf("**Replies:** %d from %s\n", n, strings.Join(repliers, ", ")))
+	}
+	b.WriteString("**Views:** not available from Slack\n")
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- check-reactions-to-me ---
+
+func formatReactionsToMe(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## %s\n\n", result.Message))
+	if summary, ok := data["summary"].(map[string]interface{}); ok {
+		var top []string
+		for _, e := range asList(summary["topEmoji"]) {
+			top = append(top, fmt.Sprintf(":%s: %d", str(e, "emoji"), num(e, "count")))
+			if len(top) == 5 {
+				break
+			}
+		}
+		if len(top) > 0 {
+			b.WriteString("**Top reactions:** " + strings.Join(top, " · ") + "\n\n")
+		}
+	}
+	for _, m := range asList(data["messages"]) {
+		b.WriteString(fmt.Sprintf("### #%s — %s (%d)\n> %s\n", str(m, "channel"), str(m, "timestamp"),
+			num(m, "total"), truncate(str(m, "message"), 200)))
+		for _, r := range asList(m["reactions"]) {
+			users, _ := r["users"].([]string)
+			b.WriteString(fmt.Sprintf(":%s: %d — %s\n", str(r, "emoji"), num(r, "count"), strings.Join(users, ", ")))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- analyze-channel-overlap ---
+
+func formatChannelOverlap(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## Channel overlap (active since %s)\n\n", str(data, "since")))
+	for _, c := range asList(data["channels"]) {
+		b.WriteString(fmt.Sprintf("- #%s: %d members", str(c, "channel"), num(c, "members")))
+		if _, ok := c["activeParticipants"]; ok {
+			b.WriteString(fmt.Sprintf(", %d active", num(c, "activeParticipants")))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	for _, p := range asList(data["pairs"]) {
+		names, _ := p["channels"].([]string)
+		b.WriteString(fmt.Sprintf("**%s** — %d shared members (%s similar)\n", strings.Join(names, " ↔ "), num(p, "sharedMembers"), str(p, "similarity")))
+		if active, ok := p["sharedActive"].([]string); ok && len(active) > 0 {
+			b.WriteString("   active in both: " + strings.Join(active, ", ") + "\n")
+		}
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- rank-my-channels ---
+
+func formatRankMyChannels(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## Channel importance (activity since %s)\n\n", str(data, "since")))
+	for _, c := range asList(data["channels"]) {
+		score, _ := c["score"].(float64)
+		marker := " "
+		if v, ok := c["important"].(bool); ok && v {
+			marker = "★"
+		}
+		b.WriteString(fmt.Sprintf("%s %d. #%s — %.2f (%d posts, %d mentions", marker, num(c, "rank"), str(c, "channel"), score, num(c, "posts"), num(c, "mentions")))
+		if p, _ := c["priority"].(float64); p != 0 {
+			b.WriteString(fmt.Sprintf(", priority %+.1f", p))
+		}
+		if last := str(c, "lastActivity"); last != "" {
+			b.WriteString(", last active " + last)
+		}
+		b.WriteString(")\n")
+	}
+	if w, ok := data["weights"].(map[string]interface{}); ok {
+		b.WriteString(fmt.Sprintf("\nWeights: posts=%v mentions=%v priority=%v recency=%v; ★ = important (score ≥ %v)\n",
+			w["posts"], w["mentions"], w["priority"], w["recency"], data["threshold"]))
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- auth-setup ---
+
+func formatAuthSetup(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return result.Message
+	}
+
+	state := str(data, "state")
+
+	// Legacy status field (from clear action)
+	if state == "" {
+		status := str(data, "status")
+		switch status {
+		case "cleared":
+			return "Credentials cleared." + footer(result)
+		case "no_credentials":
+			return "No credentials stored." + footer(result)
+		default:
+			return result.Message + footer(result)
+		}
+	}
+
+	var s strings.Builder
+
+	switch state {
+	case "complete":
+		s.WriteString(fmt.Sprintf("Connected to **%s** as **%s**.", str(data, "team"), str(data, "user")))
+
+	case "browser_choice":
+		s.WriteString("## Setup — Choose Browser\n\n")
+		s.WriteString(result.Message + "\n\n")
+		if browsers, ok := data["browsers"]; ok {
+			formatMapList(&s, browsers, func(m map[string]string) string {
+				return fmt.Sprintf("- **%s** (`%s`)\n", m["display_name"], m["name"])
+			})
+		}
+
+	case "profile_choice":
+		s.WriteString("## Setup — Choose Profile\n\n")
+		s.WriteString(result.Message + "\n\n")
+		if profiles, ok := data["profiles"]; ok {
+			formatMapList(&s, profiles, func(m map[string]string) string {
+				email := ""
+				if e := m["email"]; e != "" {
+					email = fmt.Sprintf(" (%s)", e)
+				}
+				return fmt.Sprintf("- **%s**%s — dir: `%s`\n", m["display_name"], email, m["dir_name"])
+			})
+		}
+
+	case "profile_locked":
+		s.WriteString("## Setup — Browser Locked\n\n")
+		s.WriteString(result.Message)
+
+	case "firefox_ext_written":
+		s.WriteString("## Setup — Firefox Extension\n\n")
+		s.WriteString(result.Message)
+
+	case "waiting_for_callback":
+		s.WriteString("Waiting for tokens from browser...")
+
+	case "failed":
+		s.WriteString("## Setup — Failed\n\n")
+		s.WriteString(result.Message)
+
+	default:
+		s.WriteString(result.Message)
+	}
+
+	s.WriteString(footer(result))
+	return s.String()
+}
+
+// --- download-file ---
+
+func formatDownloadFile(result *FeatureResult) string {
+	s := result.Message
+	if data := dataMap(result); data != nil {
+		if mt := str(data, "mimetype"); mt != "" {
+			s += fmt.Sprintf(" [%s]", mt)
+		}
+		if path := str(data, "path"); path != "" {
+			s += fmt.Sprintf("\nPath: `%s`", path)
+		}
+	}
+	return s + footer(result)
+}
+
+// --- refresh-credentials ---
+
+func formatRefreshCredentials(result *FeatureResult) string {
+	var b strings.Builder
+	b.WriteString(result.Message + "\n")
+	if data := dataMap(result); data != nil {
+		if tried, _ := data["tried"].([]string); len(tried) > 0 {
+			b.WriteString("\n**Profiles checked:**\n")
+			for _, t := range tried {
+				b.WriteString("- " + t + "\n")
+			}
+		}
+	}
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- usage-stats ---
+
+func formatUsageStats(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString("## Usage\n\n")
+
+	days := asList(data["days"])
+	if len(days) > 0 {
+		b.WriteString("| Date | Sent | Reactions | Caught up | Marked read | Tool calls | API calls |\n")
+		b.WriteString("|---|---|---|---|---|---|---|\n")
+		for _, d := range days {
+			b.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %d | %d | %d (+%d internal) |\n",
+				str(d, "date"), num(d, "messagesSent"), num(d, "reactions"),
+				num(d, "channelsCaughtUp"), num(d, "markedRead"), num(d, "toolCalls"),
+				num(d, "apiCalls"), num(d, "internalApiCalls")))
+		}
+	}
+
+	if methods := asList(data["topMethods"]); len(methods) > 0 {
+		var parts []string
+		for _, m := range methods {
+			parts = append(parts, fmt.Sprintf("%s %d", str(m, "name"), num(m, "count")))
+		}
+		b.WriteString(fmt.Sprintf("\n**Top API methods today:** %s\n", strings.Join(parts, ", ")))
+	}
+
+	if tools := asList(data["byTool"]); len(tools) > 0 {
+		var parts []string
+		for _, t := range tools {
+			parts = append(parts, fmt.Sprintf("%s %d", str(t, "name"), num(t, "count")))
+		}
+		b.WriteString(fmt.Sprintf("**Tools today:** %s\n", strings.Join(parts, ", ")))
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- workspace-overview ---
+
+func formatWorkspaceOverview(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	if team, ok := data["team"].(map[string]interface{}); ok {
+		b.WriteString(fmt.Sprintf("## %s\n\n", str(team, "name")))
+		if domain := str(team, "domain"); domain != "" {
+			b.WriteString(fmt.Sprintf("%s.slack.com", domain))
+			if email := str(team, "emailDomain"); email != "" {
+				b.WriteString(fmt.Sprintf(" · email domain %s", email))
+			}
+			b.WriteString("\n\n")
+		}
+	} else {
+		b.WriteString("## Workspace\n\n")
+	}
+
+	if m, ok := data["members"].(map[string]interface{}); ok {
+		b.WriteString(fmt.Sprintf("**Members:** %d people (%d admins), %d guests, %d bots, %d deactivated\n",
+			num(m, "people"), num(m, "admins"), num(m, "guests"), num(m, "bots"), num(m, "deactivated")))
+	}
+	if c, ok := data["channels"].(map[string]interface{}); ok {
+		b.WriteString(fmt.Sprintf("**Channels:** %d public, %d private, %d archived; you're in %d",
+			num(c, "public"), num(c, "private"), num(c, "archived"), num(c, "joined")))
+		if refreshed := str(data, "directoryRefreshed"); refreshed != "" {
+			b.WriteString(fmt.Sprintf(" (directory refreshed %s)", refreshed))
+		}
+		b.WriteString("\n")
+	}
+	if a, ok := data["activity"].(map[string]interface{}); ok {
+		b.WriteString(fmt.Sprintf("**Your conversations:** %d, %d active today, %d this week; %d unread, %d mentions, %d unread thread replies\n",
+			num(a, "conversations"), num(a, "activeDay"), num(a, "activeWeek"),
+			num(a, "unread"), num(a, "mentions"), num(a, "threadUnreads")))
+	}
+	if notes, ok := data["notes"].([]string); ok && len(notes) > 0 {
+		b.WriteString("\n")
+		for _, n := range notes {
+			b.WriteString(fmt.Sprintf("_%s_\n", n))
+		}
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- debug-internal ---
+
+func formatDebugInternal(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## %s\n\n", result.Message))
+
+	for _, p := range asList(data["probes"]) {
+		b.WriteString(fmt.Sprintf("**%s**", str(p, "endpoint")))
+		if e := str(p, "error"); e != "" {
+			b.WriteString(fmt.Sprintf(" ❌ %s", e))
+		}
+		b.WriteString("\n")
+		if shape, ok := p["shape"].([]string); ok && len(shape) > 0 {
+			b.WriteString(fmt.Sprintf("- %s\n", strings.Join(shape, ", ")))
+		}
+		b.WriteString("\n")
+	}
+
+	if issues := asList(data["issues"]); len(issues) > 0 {
+		b.WriteString("| Endpoint | Kind | Detail | Seen |\n")
+		b.WriteString("|---|---|---|---|\n")
+		for _, issue := range issues {
+			b.WriteString(fmt.Sprintf("| %s | %s | %s | %d× |\n",
+				str(issue, "endpoint"), str(issue, "kind"), str(issue, "detail"), num(issue, "count")))
+		}
+		b.WriteString("\n")
+	}
+
+	if files, ok := data["files"].([]string); ok && len(files) > 0 {
+		b.WriteString("**Saved:**\n")
+		for _, f := range files {
+			b.WriteString(fmt.Sprintf("- %s\n", f))
+		}
+	}
+	if e := str(data, "dumpError"); e != "" {
+		b.WriteString(fmt.Sprintf("Couldn't save the dump: %s\n", e))
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- capabilities ---
+
+func formatCapabilities(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## %s\n\n", result.Message))
+	if user := str(data, "user"); user != "" {
+		b.WriteString(fmt.Sprintf("Signed in as **%s** on %s\n\n", user, str(data, "team")))
+	}
+	b.WriteString("| Capability | | Detail |\n")
+	b.WriteString("|---|---|---|\n")
+	for _, c := range asList(data["capabilities"]) {
+		mark := "✅"
+		if ok, _ := c["available"].(bool); !ok {
+			mark = "❌"
+		}
+		b.WriteString(fmt.Sprintf("| %s | %s | %s |\n", str(c, "name"), mark, str(c, "detail")))
+	}
+	if tools, _ := data["unavailableTools"].([]string); len(tools) > 0 {
+		b.WriteString(fmt.Sprintf("\n**Unavailable tools:** %s\n", strings.Join(tools, ", ")))
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- pause-background-refresh ---
+
+func formatBackgroundRefresh(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## %s\n\n", result.Message))
+	if until := str(data, "pausedUntil"); until != "" {
+		b.WriteString(fmt.Sprintf("⏸️ Paused until %s\n\n", until))
+	}
+
+	schedule, _ := data["schedule"].(map[string]interface{})
+	skipped, _ := data["skipped"].(map[string]int)
+	b.WriteString("| Directory | Every | Last | Next | Skipped |\n")
+	b.WriteString("|---|---|---|---|---|\n")
+	for _, kind := range []string{"channels", "users"} {
+		entry, _ := schedule[kind].(map[string]interface{})
+		if entry == nil {
+			entry = map[string]interface{}{}
+		}
+		last := str(entry, "last")
+		if last == "" {
+			last = "—"
+		}
+		next := str(entry, "next")
+		if next == "" {
+			next = "—"
+		}
+		b.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %d |\n",
+			kind, str(data, kind+"Every"), last, next, skipped[kind]))
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- check-saved-searches ---
+
+func formatSavedSearches(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil || data["searches"] == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## %s\n\n", result.Message))
+	for _, s := range asList(data["searches"]) {
+		b.WriteString(fmt.Sprintf("### %s (%d new)\n", str(s, "name"), num(s, "newCount")))
+		meta := fmt.Sprintf("Query: `%s`", str(s, "query"))
+		if last := str(s, "lastRun"); last != "" {
+			meta += " · last run " + last
+		}
+		b.WriteString(meta + "\n")
+		if e := str(s, "error"); e != "" {
+			b.WriteString(fmt.Sprintf("⚠️ Last run failed: %s\n", e))
+		}
+		for _, m := range asList(s["matches"]) {
+			line := fmt.Sprintf("- **#%s** %s (%s): %s", str(m, "channel"), str(m, "author"),
+				str(m, "timestamp"), truncate(str(m, "message"), 200))
+			if link := str(m, "permalink"); link != "" {
+				line += " — " + link
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- get-recent-events ---
+
+var eventIcons = map[string]string{
+	"mention":      "📣",
+	"dm":           "💬",
+	"thread_reply": "🧵",
+	"reaction":     "👍",
+}
+
+func formatRecentEvents(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## %s\n\n", result.Message))
+	for _, e := range asList(data["events"]) {
+		kind := str(e, "type")
+		line := fmt.Sprintf("- %s **%s** in #%s", eventIcons[kind], kind, str(e, "channel"))
+		if author := str(e, "author"); author != "" {
+			line += " from " + author
+		}
+		line += fmt.Sprintf(" (%s)", str(e, "timestamp"))
+		if r := str(e, "reaction"); r != "" {
+			line += fmt.Sprintf(" :%s: on", r)
+		}
+		if msg := str(e, "message"); msg != "" {
+			line += ": " + truncate(msg, 200)
+		}
+		line += fmt.Sprintf(" [threadId: %s]", str(e, "threadId"))
+		b.WriteString(line + "\n")
+	}
+	if next := str(data, "nextSince"); next != "" {
+		b.WriteString(fmt.Sprintf("\n**nextSince:** `%s`\n", next))
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- set-meetings ---
+
+func formatMeetings(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## %s\n\n", result.Message))
+	for _, m := range asList(data["meetings"]) {
+		title := str(m, "title")
+		if title == "" {
+			title = "(untitled)"
+		}
+		b.WriteString(fmt.Sprintf("- **%s** %s – %s\n", title, str(m, "start"), str(m, "end")))
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- log-decision ---
+
+func formatLogDecision(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## %s\n\n", result.Message))
+	b.WriteString("```\n" + str(data, "entry") + "\n```\n")
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- generate-team-report ---
+
+func formatTeamReport(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil || str(data, "title") == "" {
+		return formatGeneric(result)
+	}
+	return renderTeamReport(data) + footer(result)
+}
+
+// --- brief-me-on-channel ---
+
+func formatChannelBrief(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil || str(data, "channel") == "" {
+		return formatGeneric(result)
+	}
+	return renderChannelBrief(data) + footer(result)
+}
+
+// --- topic-timeline ---
+
+func formatTopicTimeline(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## %s\n", result.Message))
+	day := ""
+	for _, e := range asList(data["timeline"]) {
+		if d := str(e, "date"); d != day {
+			day = d
+			b.WriteString(fmt.Sprintf("\n### %s\n\n", day))
+		}
+		line := fmt.Sprintf("- %s #%s **%s**", str(e, "time"), str(e, "channel"), str(e, "author"))
+		if e["inThread"] == true {
+			line += " (in thread)"
+		}
+		if e["decision"] == true {
+			line += " ✅"
+		}
+		line += ": " + truncate(str(e, "text"), 200)
+		line += fmt.Sprintf(" [threadId: %s]", str(e, "threadId"))
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- list-collection ---
+
+func formatCollection(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## %s\n\n", result.Message))
+	for _, c := range asList(data["collections"]) {
+		b.WriteString(fmt.Sprintf("- **%s**: %d message(s), last added %s\n", str(c, "name"), num(c, "count"), str(c, "lastAdded")))
+	}
+	for _, m := range asList(data["messages"]) {
+		b.WriteString(fmt.Sprintf("- #%s **%s** (%s): %s [threadId: %s]\n",
+			str(m, "channel"), str(m, "author"), str(m, "timestamp"), truncate(str(m, "text"), 200), str(m, "threadId")))
+		if note := str(m, "note"); note != "" {
+			b.WriteString(fmt.Sprintf("  - Note: %s\n", note))
+		}
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- create-ticket-from-thread ---
+
+func formatCreateTicket(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## %s\n\n", result.Message))
+	if payload := str(data, "payload"); payload != "" {
+		b.WriteString("```json\n" + payload + "\n```\n")
+	}
+
+	b.WriteString(footer(result))
+	return b.String()
+}
+
+// --- purge-local-data ---
+
+func formatPurgeLocalData(result *FeatureResult) string {
+	data := dataMap(result)
+	if data == nil {
+		return formatGeneric(result)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## %s\n\n", result.Message))
+	for _, f := range asList(data["files"]) {
+		b.WriteString(fmt.Sprintf("- %s (%d bytes)\n", str(f, "path"), num(f, "size")))
+	}
+	if failed, ok := data["failed"].([]string); ok && len(failed) > 0 {
+		b.WriteString("\n**Could not remove:**\n")
+		for _, f := range failed {
+			b.WriteString("- " + f + "\n")
+		}
+	}
+	b.WriteString(footer(result))
+	return b.String()
 }
 
 // --- Generic fallback ---
@@ -146,46 +146,7 @@ func getContextHandler(ctx context.Context, params map[string]interface{}) (*Fea
 	// Format messages (reverse to oldest-first)
 	formatted := make([]map[string]interface{}, 0, len(messages))
 	for i := len(messages) - 1; i >= 0; i-- {
-		msg := messages[i]
-		userName := msg.User
-		if user, ok := usersMap[msg.User]; ok {
-			if user.RealName != "" {
-				userName = user.RealName
-			} else {
-				userName = user.Name
-			}
-		}
-
-		entry := map[string]interface{}{
-			"ts":   msg.Timestamp,
-			"user": userName,
-			"text": msg.Text,
-			"time": formatTimestamp(parseSlackTimestamp(msg.Timestamp)),
-		}
-
-		if msg.ThreadTimestamp != "" && msg.ThreadTimestamp != msg.Timestamp {
-			entry["thread_ts"] = msg.ThreadTimestamp
-			entry["is_reply"] = true
-		}
-
-		if msg.ReplyCount > 0 {
-			entry["reply_count"] = msg.ReplyCount
-		}
-
-		if len(msg.Files) > 0 {
-			files := make([]map[string]interface{}, 0, len(msg.Files))
-			for _, f := range msg.Files {
-				files = append(files, map[string]interface{}{
-					"id":       f.ID,
-					"name":     f.Name,
-					"mimetype": f.Mimetype,
-					"size":     f.Size,
-				})
-			}
-			entry["files"] = files
-		}
-
-		formatted = append(formatted, entry)
+		formatted = append(formatted, messageEntry(messages[i], usersMap))
 	}
 
 	// Resolve channel name for display
@@ -222,3 +183,36 @@ func resolveChannelName(ctx context.Context, apiProvider *provider.ApiProvider,
 	}
 	return fallback
 }
+
+// messageEntry converts a message to the name-resolved map shared by the
+// message-reading tools
+func messageEntry(msg slack.Message, usersMap map[string]slack.User) map[string]interface{} {
+	userName := msg.User
+	if user, ok := usersMap[msg.User]; ok {
+		if user.RealName != "" {
+			userName = user.RealName
+		} else {
+			userName = user.Name
+		}
+	}
+
+	entry := map[string]interface{}{
+		"ts":   msg.Timestamp,
+		"user": userName,
+		"text": msg.Text,
+		"time": formatTimestamp(parseSlackTimestamp(msg.Timestamp)),
+	}
+
+	if msg.ThreadTimestamp != "" && msg.ThreadTimestamp != msg.Timestamp {
+		entry["thread_ts"] = msg.ThreadTimestamp
+		entry["is_reply"] = true
+	}
+
+	if msg.ReplyCount > 0 {
+		entry["reply_count"] = msg.ReplyCount
+	}
+
+	addAttachmentInfo(entry, "text", msg.Files, msg.Attachments, msg.Blocks)
+
+	return entry
+}
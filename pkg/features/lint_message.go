@@ -0,0 +1,116 @@
+package features
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/slack-go/slack"
+)
+
+// slackMessageLimit is the longest message text Slack renders in one post
+const slackMessageLimit = 4000
+
+var (
+	mdBoldPattern    = regexp.MustCompile(`\*\*[^*\n]+\*\*`)
+	mdLinkPattern    = regexp.MustCompile(`\[[^\]\n]+\]\((https?://[^)\s]+)\)`)
+	mdHeadingPattern = regexp.MustCompile(`(?m)^#{1,6} \S`)
+	mdStrikePattern  = regexp.MustCompile(`~~[^~\n]+~~`)
+	atNamePattern    = regexp.MustCompile(`(^|[\s(])@([\w.\-]+)`)
+	slackTokenRegexp = regexp.MustCompile(`<[^>\n]+>`)
+	codePattern      = regexp.MustCompile("(?s)```.*?```|`[^`\n]+`")
+)
+
+// broadcastNames are @-words Slack understands without a user ID
+var broadcastNames = map[string]string{
+	"here":     "<!here>",
+	"channel":  "<!channel>",
+	"everyone": "<!everyone>",
+}
+
+// resolveMentions rewrites plain-text @name references into real Slack
+// mentions, since the API doesn't link them and nobody would be notified.
+// Code spans and blocks are left as written. Returns the rewritten message
+// and any names that matched no user.
+func resolveMentions(message string, usersMap map[string]slack.User) (string, []string) {
+	byName := make(map[string]string, len(usersMap)*2)
+	for uid, u := range usersMap {
+		if u.Deleted {
+			continue
+		}
+		if u.Name != "" {
+			byName[strings.ToLower(u.Name)] = uid
+		}
+		if u.Profile.DisplayName != "" {
+			byName[strings.ToLower(u.Profile.DisplayName)] = uid
+		}
+	}
+
+	var unresolved []string
+	seen := make(map[string]bool)
+	rewrite := func(m string) string {
+		sub := atNamePattern.FindStringSubmatch(m)
+		prefix, name := sub[1], sub[2]
+		// Trailing punctuation belongs to the sentence, not the name
+		trimmed := strings.TrimRight(name, ".-")
+		rest := name[len(trimmed):]
+		lower := strings.ToLower(trimmed)
+
+		if b, ok := broadcastNames[lower]; ok {
+			return prefix + b + rest
+		}
+		if uid, ok := byName[lower]; ok {
+			return prefix + "<@" + uid + ">" + rest
+		}
+		if !seen[lower] {
+			seen[lower] = true
+			unresolved = append(unresolved, "@"+trimmed)
+		}
+		return m
+	}
+
+	var out strings.Builder
+	last := 0
+	for _, loc := range codePattern.FindAllStringIndex(message, -1) {
+		out.WriteString(atNamePattern.ReplaceAllStringFunc(message[last:loc[0]], rewrite))
+		out.WriteString(message[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	out.WriteString(atNamePattern.ReplaceAllStringFunc(message[last:], rewrite))
+	return out.String(), unresolved
+}
+
+// lintMessage checks an outgoing message for problems the API would
+// reject or that would render badly in Slack. The length check is skipped
+// when the message will be split into a thread.
+func lintMessage(message string, unresolved []string, splitting bool) []string {
+	var warnings []string
+
+	if n := utf8.RuneCountInString(message); n > slackMessageLimit && !splitting {
+		warnings = append(warnings, fmt.Sprintf("Message is %d characters, over Slack's %d-character limit; shorten it or resend with splitLongMessages=true to post it as a thread", n, slackMessageLimit))
+	}
+
+	var md []string
+	if mdBoldPattern.MatchString(message) {
+		md = append(md, "**bold** (use *bold*)")
+	}
+	if mdLinkPattern.MatchString(message) {
+		md = append(md, "[text](url) links (use <url|text>)")
+	}
+	if mdHeadingPattern.MatchString(message) {
+		md = append(md, "# headings (use a *bold* line)")
+	}
+	if mdStrikePattern.MatchString(message) {
+		md = append(md, "~~strike~~ (use ~strike~)")
+	}
+	if len(md) > 0 {
+		warnings = append(warnings, "Markdown that Slack won't render: "+strings.Join(md, ", "))
+	}
+
+	if len(unresolved) > 0 {
+		warnings = append(warnings, fmt.Sprintf("Unresolved mentions that won't notify anyone: %s", strings.Join(unresolved, ", ")))
+	}
+
+	return warnings
+}
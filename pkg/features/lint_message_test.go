@@ -0,0 +1,53 @@
+package features
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestResolveMentions(t *testing.T) {
+	users := map[string]slack.User{
+		"U1": {ID: "U1", Name: "alice"},
+		"U2": {ID: "U2", Name: "bob.smith", Profile: slack.UserProfile{DisplayName: "Bobby"}},
+	}
+
+	got, unresolved := resolveMentions("@alice and @bobby, see bob@example.com. cc @here @nobody.", users)
+	want := "<@U1> and <@U2>, see bob@example.com. cc <!here> @nobody."
+	if got != want {
+		t.Errorf("resolveMentions = %q, want %q", got, want)
+	}
+	if len(unresolved) != 1 || unresolved[0] != "@nobody" {
+		t.Errorf("unresolved = %v, want [@nobody]", unresolved)
+	}
+}
+
+func TestResolveMentionsSkipsCode(t *testing.T) {
+	users := map[string]slack.User{"U1": {ID: "U1", Name: "alice"}}
+
+	msg := "@alice try `@alice` or\n```\n@Override\nvoid run() { @alice }\n```\nthen ping @alice"
+	got, unresolved := resolveMentions(msg, users)
+	want := "<@U1> try `@alice` or\n```\n@Override\nvoid run() { @alice }\n```\nthen ping <@U1>"
+	if got != want {
+		t.Errorf("resolveMentions = %q, want %q", got, want)
+	}
+	if len(unresolved) != 0 {
+		t.Errorf("names in code were flagged: %v", unresolved)
+	}
+}
+
+func TestLintMessage(t *testing.T) {
+	if w := lintMessage("Deploy is *done*, see <https://example.com|the log>", nil, false); len(w) != 0 {
+		t.Errorf("clean message got warnings: %v", w)
+	}
+	if w := lintMessage(strings.Repeat("x", slackMessageLimit+1), nil, false); len(w) != 1 {
+		t.Errorf("long message warnings = %v, want 1", w)
+	}
+	if w := lintMessage(strings.Repeat("x", slackMessageLimit+1), nil, true); len(w) != 0 {
+		t.Errorf("long message being split got warnings: %v", w)
+	}
+	if w := lintMessage("## Status\n**done**, see [log](https://example.com)", nil, false); len(w) != 1 || !strings.Contains(w[0], "Markdown") {
+		t.Errorf("markdown warnings = %v", w)
+	}
+}
@@ -2,12 +2,17 @@ package features
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
 )
 
 // ListChannels provides channel listing with smart caching
@@ -19,12 +24,12 @@ var ListChannels = &Feature{
 		"properties": map[string]interface{}{
 			"filter": map[string]interface{}{
 				"type":        "string",
-				"description": "Filter channels by type: 'member' (default — your channels only), 'all', 'public', 'private', 'dm', 'group-dm'",
+				"description": "Filter channels by type: 'member' (default — your channels only), 'all', 'public', 'private', 'dm', 'group-dm', 'starred'",
 				"default":     "member",
 			},
 			"search": map[string]interface{}{
 				"type":        "string",
-				"description": "Search for channels by name or purpose (partial match). When provided, searches all channels regardless of filter.",
+				"description": "Search for channels by name, purpose, or topic (partial match). When provided, searches all channels regardless of filter and ranks results: exact name, then prefix, then substring, then purpose/topic matches, with recently active channels first among equals.",
 			},
 			"forceRefresh": map[string]interface{}{
 				"type":        "boolean",
@@ -36,11 +41,30 @@ var ListChannels = &Feature{
 				"description": "Include archived channels",
 				"default":     false,
 			},
+			"groupBy": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"none", "category", "section"},
+				"description": "Group channels by naming-convention category (incident, project, low, ...) or by your sidebar sections, in sidebar order (browser session token only)",
+				"default":     "none",
+			},
+			"section": map[string]interface{}{
+				"type":        "string",
+				"description": "Only conversations in this sidebar section (e.g. 'Projects', 'Muted', 'Direct messages'); browser session token only",
+			},
 			"limit": map[string]interface{}{
 				"type":        "number",
 				"description": "Maximum channels to return (default: 50, max: 500)",
 				"default":     50,
 			},
+			"cursor": map[string]interface{}{
+				"type":        "string",
+				"description": "Pagination cursor from previous request; pass the same filter, search, groupBy, section, and includeArchived",
+			},
+			"cacheOnly": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Answer instantly from cached data without calling Slack (results may be stale). Used automatically while the server is still connecting.",
+				"default":     false,
+			},
 		},
 		"required": []string{},
 	},
@@ -68,8 +92,12 @@ func listChannelsHandler(ctx context.Context, params map[string]interface{}) (*F
 		search = strings.ToLower(strings.TrimSpace(s))
 	}
 
-	// When searching, look across all channels unless explicitly filtered
-	if search != "" && filter == "member" {
+	section, _ := params["section"].(string)
+	section = strings.TrimSpace(section)
+
+	// When searching or listing a section, look across all channels unless
+	// explicitly filtered; a section holds DMs as well as channels
+	if (search != "" || section != "") && filter == "member" {
 		filter = "all"
 	}
 
@@ -83,6 +111,11 @@ func listChannelsHandler(ctx context.Context, params map[string]interface{}) (*F
 		includeArchived = i
 	}
 
+	groupBy := "none"
+	if g, ok := params["groupBy"].(string); ok && g != "" {
+		groupBy = g
+	}
+
 	limit := 50
 	if l, ok := params["limit"].(float64); ok {
 		limit = int(l)
@@ -94,8 +127,13 @@ func listChannelsHandler(ctx context.Context, params map[string]interface{}) (*F
 		}
 	}
 
+	cursor, _ := params["cursor"].(string)
+
+	// Cache-only reads never trigger a refresh
+	cacheOnly := useCacheOnly(params, apiProvider)
+
 	// Handle cache refresh if requested
-	if forceRefresh {
+	if forceRefresh && !cacheOnly {
 		refreshResult, err := apiProvider.RefreshChannelCache(ctx)
 		if err != nil {
 			return &FeatureResult{
@@ -122,31 +160,86 @@ func listChannelsHandler(ctx context.Context, params map[string]interface{}) (*F
 	// Get channels from cache
 	channels := apiProvider.GetCachedChannels()
 	cacheInfo := apiProvider.GetCacheInfo()
+	users := apiProvider.ProvideUsersMap()
+	taxonomy := loadChannelTaxonomy()
+	grouped := groupBy == "category" || groupBy == "section"
+
+	// Sidebar sections, for grouping by or listing one of them
+	var sidebar *provider.Sidebar
+	sectionIdx := -1
+	if groupBy == "section" || section != "" {
+		var failed *FeatureResult
+		if sidebar, sectionIdx, failed = loadSidebar(ctx, apiProvider, section, cacheOnly); failed != nil {
+			return failed, nil
+		}
+	}
 
-	// Filter and process channels
-	filteredChannels := []map[string]interface{}{}
+	var starred map[string]bool
+	if filter == "starred" && cacheOnly {
+		if starred = apiProvider.CachedStarredConversations(); starred == nil {
+			return &FeatureResult{
+				Success:     false,
+				Message:     "No cached starred conversations yet",
+				Guidance:    "Stars are cached after the first live lookup. Retry without cacheOnly once Slack is connected.",
+				NextActions: []string{"list-channels filter='starred'"},
+			}, nil
+		}
+	} else if filter == "starred" {
+		var err error
+		if starred, err = apiProvider.StarredConversations(ctx); err != nil {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Couldn't read your starred conversations: %v", err),
+			}, nil
+		}
+	}
+
+	var recent map[string]time.Time
+	if search != "" && !cacheOnly {
+		recent = recentChannelActivity(ctx, apiProvider)
+	}
 
-	for _, ch := range channels {
+	// Filter to lightweight rows first; the full entries are only built for
+	// the page returned, which matters with tens of thousands of channels
+	rows := make([]channelRow, 0, len(channels))
+	typeCounts := map[string]int{
+		"public":   0,
+		"private":  0,
+		"dm":       0,
+		"group-dm": 0,
+		"archived": 0,
+	}
+	categoryCounts := map[string]int{}
+	for i := range channels {
+		ch := &channels[i]
 		// Skip archived if not requested
 		if ch.IsArchived && !includeArchived {
 			continue
 		}
+		if sectionIdx >= 0 && !sidebar.InSection(sectionIdx, ch.ID, ch.IsIM || ch.IsMpIM) {
+			continue
+		}
+
+		dmUser, hasDMUser := slack.User{}, false
+		if ch.IsIM && ch.User != "" {
+			dmUser, hasDMUser = users[ch.User]
+		}
 
-		// Apply search filter
+		// Apply search filter, scoring how well each channel matches
+		score := 0
 		if search != "" {
-			nameMatch := strings.Contains(strings.ToLower(ch.Name), search)
-			purposeMatch := strings.Contains(strings.ToLower(ch.Purpose.Value), search)
+			names := []string{ch.Name}
 			// For DMs, also match against the user's name
-			dmNameMatch := false
-			if ch.IsIM && ch.User != "" {
-				if user, ok := apiProvider.ProvideUsersMap()[ch.User]; ok {
-					dmNameMatch = strings.Contains(strings.ToLower(user.RealName), search) ||
-						strings.Contains(strings.ToLower(user.Name), search)
-				}
+			if hasDMUser {
+				names = append(names, dmUser.RealName, dmUser.Name)
 			}
-			if !nameMatch && !purposeMatch && !dmNameMatch {
+			if score = searchRelevance(search, names, ch.Purpose.Value, ch.Topic.Value); score == 0 {
 				continue
 			}
+			score += activityBoost(recent[ch.ID], time.Now())
+			if ch.IsMember {
+				score += relevanceMemberBoost
+			}
 		}
 
 		// Apply type filter
@@ -162,6 +255,8 @@ func listChannelsHandler(ctx context.Context, params map[string]interface{}) (*F
 			skip = !ch.IsIM
 		case "group-dm":
 			skip = !ch.IsMpIM
+		case "starred":
+			skip = !starred[ch.ID]
 		case "all":
 			// no filter
 		}
@@ -170,34 +265,84 @@ func listChannelsHandler(ctx context.Context, params map[string]interface{}) (*F
 			continue
 		}
 
-		// Build channel info
-		channelType := "public"
-		displayName := fmt.Sprintf("#%s", ch.Name)
-
+		row := channelRow{ch: ch, channelType: "public", displayName: fmt.Sprintf("#%s", ch.Name), score: score}
 		if ch.IsIM {
-			channelType = "dm"
+			row.channelType = "dm"
 			// Try to resolve DM name
-			if ch.User != "" {
-				if user, ok := apiProvider.ProvideUsersMap()[ch.User]; ok {
-					name := user.RealName
-					if name == "" {
-						name = user.Name
-					}
-					displayName = fmt.Sprintf("DM: %s", name)
+			if hasDMUser {
+				name := dmUser.RealName
+				if name == "" {
+					name = dmUser.Name
 				}
+				row.displayName = fmt.Sprintf("DM: %s", name)
 			}
 		} else if ch.IsMpIM {
-			channelType = "group-dm"
-			displayName = fmt.Sprintf("Group: %s", ch.Name)
+			row.channelType = "group-dm"
+			row.displayName = fmt.Sprintf("Group: %s", ch.Name)
 		} else if ch.IsPrivate {
-			channelType = "private"
-			displayName = fmt.Sprintf("🔒#%s", ch.Name)
+			row.channelType = "private"
+			row.displayName = fmt.Sprintf("🔒#%s", ch.Name)
+		}
+		switch {
+		case groupBy == "section":
+			row.rank = len(sidebar.Sections)
+			if i := sidebar.SectionOf(ch.ID, ch.IsIM || ch.IsMpIM); i >= 0 {
+				row.rank, row.category = i, sidebar.Sections[i].Name
+			}
+		case !ch.IsIM && !ch.IsMpIM:
+			row.category = channelCategory(taxonomy, ch.Name)
 		}
 
+		typeCounts[row.channelType]++
+		if ch.IsArchived {
+			typeCounts["archived"]++
+		}
+		if row.category != "" {
+			categoryCounts[row.category]++
+		}
+		rows = append(rows, row)
+	}
+
+	// Sort by relevance when searching, then by name, within categories
+	// when grouping; uncategorized last. The ID breaks ties so the order,
+	// and so the cursors, are stable.
+	sort.Slice(rows, func(i, j int) bool { return rows[i].less(rows[j], grouped) })
+
+	// Resume after the cursor's position rather than at an offset, so
+	// channels added or removed by a cache refresh don't shift pages
+	query := channelQueryKey(filter, search+"\x00"+strings.ToLower(section), groupBy, includeArchived)
+	start := 0
+	if cursor != "" {
+		after, err := decodeChannelCursor(cursor, query)
+		if err != nil {
+			return &FeatureResult{
+				Success:  false,
+				Message:  err.Error(),
+				Guidance: "Cursors only work with the same filter, search, groupBy, section, and includeArchived; start again without one",
+			}, nil
+		}
+		start = sort.Search(len(rows), func(i int) bool { return after.less(rows[i], grouped) })
+	}
+
+	// Apply limit
+	totalFound := len(rows)
+	page := rows[start:]
+	hasMore := len(page) > limit
+	if hasMore {
+		page = page[:limit]
+	}
+	nextCursor := ""
+	if hasMore {
+		nextCursor = encodeChannelCursor(query, page[len(page)-1])
+	}
+
+	filteredChannels := make([]map[string]interface{}, 0, len(page))
+	for _, row := range page {
+		ch := row.ch
 		channelInfo := map[string]interface{}{
 			"name":        ch.Name,
-			"displayName": displayName,
-			"type":        channelType,
+			"displayName": row.displayName,
+			"type":        row.channelType,
 			"isMember":    ch.IsMember,
 			"isArchived":  ch.IsArchived,
 		}
@@ -209,57 +354,60 @@ func listChannelsHandler(ctx context.Context, params map[string]interface{}) (*F
 		if ch.NumMembers > 0 {
 			channelInfo["memberCount"] = ch.NumMembers
 		}
+		if row.category != "" {
+			if groupBy == "section" {
+				channelInfo["section"] = row.category
+			} else {
+				channelInfo["category"] = row.category
+			}
+		}
 
 		filteredChannels = append(filteredChannels, channelInfo)
 	}
 
-	// Sort by name
-	sort.Slice(filteredChannels, func(i, j int) bool {
-		return filteredChannels[i]["displayName"].(string) < filteredChannels[j]["displayName"].(string)
-	})
-
-	// Apply limit
-	totalFound := len(filteredChannels)
-	if len(filteredChannels) > limit {
-		filteredChannels = filteredChannels[:limit]
-	}
-
-	// Build summary
+	// Build summary; counts cover every match, not just this page
 	summary := map[string]interface{}{
 		"totalCached": len(channels),
 		"totalFound":  totalFound,
 		"returned":    len(filteredChannels),
 		"lastRefresh": cacheInfo.LastRefresh.Format(time.RFC3339),
 		"cacheAge":    fmt.Sprintf("%.0f minutes", time.Since(cacheInfo.LastRefresh).Minutes()),
+		"byType":      typeCounts,
 	}
-
-	// Count by type
-	typeCounts := map[string]int{
-		"public":   0,
-		"private":  0,
-		"dm":       0,
-		"group-dm": 0,
-		"archived": 0,
-	}
-
-	for _, ch := range filteredChannels {
-		typeCounts[ch["type"].(string)]++
-		if ch["isArchived"].(bool) {
-			typeCounts["archived"]++
+	if len(categoryCounts) > 0 {
+		if groupBy == "section" {
+			summary["bySection"] = categoryCounts
+		} else {
+			summary["byCategory"] = categoryCounts
 		}
 	}
-	summary["byType"] = typeCounts
 
+	sortedBy := "name"
+	if search != "" {
+		sortedBy = "relevance"
+	}
 	result := &FeatureResult{
 		Success: true,
 		Data: map[string]interface{}{
 			"channels": filteredChannels,
 			"filter":   filter,
+			"groupBy":  groupBy,
+			"section":  section,
+			"sortedBy": sortedBy,
 			"summary":  summary,
 		},
 		Message:     fmt.Sprintf("Found %d channels (showing %d)", totalFound, len(filteredChannels)),
 		ResultCount: len(filteredChannels),
 	}
+	if cursor != "" || hasMore {
+		result.Pagination = &Pagination{
+			Cursor:     cursor,
+			NextCursor: nextCursor,
+			HasMore:    hasMore,
+			PageSize:   len(filteredChannels),
+			TotalCount: totalFound,
+		}
+	}
 
 	// Add guidance
 	if len(filteredChannels) == 0 {
@@ -282,6 +430,162 @@ func listChannelsHandler(ctx context.Context, params map[string]interface{}) (*F
 			"Refresh cache: list-channels forceRefresh=true",
 		}
 	}
+	if section != "" {
+		result.NextActions = append([]string{fmt.Sprintf("Unreads in this section: check-unreads section='%s'", section)}, result.NextActions...)
+	}
+
+	if cacheOnly {
+		markStale(result, cacheInfo.LastRefresh)
+	}
 
 	return result, nil
 }
+
+// channelRow is a matched channel with just what sorting needs
+type channelRow struct {
+	ch          *slack.Channel
+	channelType string
+	displayName string
+	category    string // Group label: naming category, or sidebar section with groupBy='section'
+	rank        int    // Sidebar position of the section; 0 otherwise
+	score       int    // Search relevance; 0 when not searching
+}
+
+func (r channelRow) less(o channelRow, grouped bool) bool {
+	if grouped && r.rank != o.rank {
+		return r.rank < o.rank
+	}
+	if grouped && r.category != o.category {
+		return o.category == "" || (r.category != "" && r.category < o.category)
+	}
+	if r.score != o.score {
+		return r.score > o.score
+	}
+	if r.displayName != o.displayName {
+		return r.displayName < o.displayName
+	}
+	return r.ch.ID < o.ch.ID
+}
+
+// channelQueryKey identifies the parameters a cursor was issued for
+func channelQueryKey(filter, search, groupBy string, includeArchived bool) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%s\x00%t", filter, search, groupBy, includeArchived)))
+	return hex.EncodeToString(sum[:4])
+}
+
+// encodeChannelCursor records the last row returned, so the next page
+// starts after it
+func encodeChannelCursor(query string, last channelRow) string {
+	raw := strings.Join([]string{"c2", query, last.category, strconv.Itoa(last.score), last.displayName, last.ch.ID, strconv.Itoa(last.rank)}, "\x00")
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeChannelCursor(cursor, query string) (channelRow, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	parts := strings.Split(string(raw), "\x00")
+	if err != nil || len(parts) != 7 || parts[0] != "c2" {
+		return channelRow{}, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	score, err := strconv.Atoi(parts[3])
+	rank, rankErr := strconv.Atoi(parts[6])
+	if err != nil || rankErr != nil {
+		return channelRow{}, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	if parts[1] != query {
+		return channelRow{}, fmt.Errorf("cursor was issued for a different query")
+	}
+	return channelRow{
+		ch:          &slack.Channel{GroupConversation: slack.GroupConversation{Conversation: slack.Conversation{ID: parts[5]}}},
+		category:    parts[2],
+		rank:        rank,
+		score:       score,
+		displayName: parts[4],
+	}, nil
+}
+
+// Search relevance: the best name match, or a purpose/topic match, plus
+// boosts for recent activity and membership
+const (
+	relevanceExact       = 100
+	relevancePrefix      = 70
+	relevanceWord        = 50 // Starts a word inside the name ("eng" in "team-eng")
+	relevanceSubstring   = 40
+	relevanceDescription = 15
+	relevanceMemberBoost = 5
+)
+
+// searchRelevance scores a channel against a lowercase query; 0 means no
+// match
+func searchRelevance(search string, names []string, purpose, topic string) int {
+	best := 0
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimPrefix(name, "#"))
+		score := 0
+		switch {
+		case name == "":
+		case name == search:
+			score = relevanceExact
+		case strings.HasPrefix(name, search):
+			score = relevancePrefix
+		case startsWord(name, search):
+			score = relevanceWord
+		case strings.Contains(name, search):
+			score = relevanceSubstring
+		}
+		best = max(best, score)
+	}
+	if best == 0 && (strings.Contains(strings.ToLower(purpose), search) || strings.Contains(strings.ToLower(topic), search)) {
+		best = relevanceDescription
+	}
+	return best
+}
+
+// startsWord reports whether search appears right after a separator in
+// name
+func startsWord(name, search string) bool {
+	for i := 0; i < len(name); i++ {
+		if strings.ContainsRune("-_. ", rune(name[i])) && strings.HasPrefix(name[i+1:], search) {
+			return true
+		}
+	}
+	return false
+}
+
+// activityBoost favors channels with recent messages
+func activityBoost(latest, now time.Time) int {
+	switch age := now.Sub(latest); {
+	case latest.IsZero():
+		return 0
+	case age < 24*time.Hour:
+		return 20
+	case age < 7*24*time.Hour:
+		return 12
+	case age < 30*24*time.Hour:
+		return 5
+	}
+	return 0
+}
+
+// recentChannelActivity maps conversation IDs to their latest message time
+// from client.counts; nil when internal endpoints aren't available
+func recentChannelActivity(ctx context.Context, ap *provider.ApiProvider) map[string]time.Time {
+	ic := ap.ProvideInternalClient()
+	if ic == nil {
+		return nil
+	}
+	counts, err := ic.GetClientCounts(ctx)
+	if err != nil || !counts.OK {
+		return nil
+	}
+	latest := make(map[string]time.Time, len(counts.Channels)+len(counts.MPIMs)+len(counts.IMs))
+	for _, ch := range counts.Channels {
+		latest[ch.ID] = parseSlackTimestamp(ch.Latest)
+	}
+	for _, ch := range counts.MPIMs {
+		latest[ch.ID] = parseSlackTimestamp(ch.Latest)
+	}
+	for _, ch := range counts.IMs {
+		latest[ch.ID] = parseSlackTimestamp(ch.Latest)
+	}
+	return latest
+}
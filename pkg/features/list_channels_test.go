@@ -0,0 +1,127 @@
+package features
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+func testRow(id, display, category string) channelRow {
+	ch := &slack.Channel{}
+	ch.ID = id
+	return channelRow{ch: ch, displayName: display, category: category}
+}
+
+func TestChannelCursor(t *testing.T) {
+	query := channelQueryKey("all", "", "none", false)
+	cursor := encodeChannelCursor(query, testRow("C2", "#eng", "team"))
+
+	after, err := decodeChannelCursor(cursor, query)
+	if err != nil || after.ch.ID != "C2" || after.displayName != "#eng" || after.category != "team" {
+		t.Fatalf("decode = %+v, %v", after, err)
+	}
+	if _, err := decodeChannelCursor(cursor, channelQueryKey("member", "", "none", false)); err == nil {
+		t.Error("cursor accepted for a different query")
+	}
+	if _, err := decodeChannelCursor("not-a-cursor", query); err == nil {
+		t.Error("garbage cursor accepted")
+	}
+}
+
+func TestChannelCursorStableAcrossRefresh(t *testing.T) {
+	rows := []channelRow{testRow("C1", "#alpha", ""), testRow("C2", "#bravo", ""), testRow("C3", "#delta", "")}
+	query := channelQueryKey("all", "", "none", false)
+	cursor := encodeChannelCursor(query, rows[1])
+
+	// A refresh adds a channel before the cursor and removes one after it
+	rows = []channelRow{testRow("C0", "#aardvark", ""), rows[0], rows[1], testRow("C4", "#charlie", "")}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].less(rows[j], false) })
+
+	after, _ := decodeChannelCursor(cursor, query)
+	start := sort.Search(len(rows), func(i int) bool { return after.less(rows[i], false) })
+	if start >= len(rows) || rows[start].ch.ID != "C4" {
+		t.Errorf("next page starts at %d, want #charlie", start)
+	}
+}
+
+func TestChannelRowOrder(t *testing.T) {
+	rows := []channelRow{
+		testRow("C3", "#zeta", ""),
+		testRow("C2", "#same", "project"),
+		testRow("C1", "#same", "project"),
+		testRow("C4", "#alpha", "incident"),
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].less(rows[j], true) })
+	var got []string
+	for _, r := range rows {
+		got = append(got, r.ch.ID)
+	}
+	if want := "C4 C1 C2 C3"; fmt.Sprint(got) != "["+want+"]" {
+		t.Errorf("order = %v, want %s", got, want)
+	}
+}
+
+func TestSearchRelevance(t *testing.T) {
+	cases := []struct {
+		names          []string
+		purpose, topic string
+		want           int
+	}{
+		{[]string{"platform"}, "", "", relevanceExact},
+		{[]string{"platform-alerts"}, "", "", relevancePrefix},
+		{[]string{"team-platform"}, "", "", relevanceWord},
+		{[]string{"dataplatform"}, "", "", relevanceSubstring},
+		{[]string{"infra"}, "", "Platform team standup", relevanceDescription},
+		{[]string{"D123", "Pat Platformer", "pat"}, "", "", relevanceWord},
+		{[]string{"random"}, "chatter", "", 0},
+	}
+	for _, c := range cases {
+		if got := searchRelevance("platform", c.names, c.purpose, c.topic); got != c.want {
+			t.Errorf("searchRelevance(%v) = %d, want %d", c.names, got, c.want)
+		}
+	}
+}
+
+func TestRelevanceOrder(t *testing.T) {
+	now := time.Now()
+	exact := testRow("C1", "#platform", "")
+	exact.score = relevanceExact
+	quiet := testRow("C2", "#platform-eng", "")
+	quiet.score = relevancePrefix
+	busy := testRow("C3", "#platform-alerts", "")
+	busy.score = relevancePrefix + activityBoost(now.Add(-time.Hour), now)
+
+	rows := []channelRow{quiet, busy, exact}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].less(rows[j], false) })
+	if rows[0].ch.ID != "C1" || rows[1].ch.ID != "C3" {
+		t.Errorf("order = %s %s %s", rows[0].ch.ID, rows[1].ch.ID, rows[2].ch.ID)
+	}
+
+	query := channelQueryKey("all", "platform", "none", false)
+	after, err := decodeChannelCursor(encodeChannelCursor(query, rows[1]), query)
+	if err != nil || after.score != busy.score {
+		t.Fatalf("cursor lost the score: %+v, %v", after, err)
+	}
+	if start := sort.Search(len(rows), func(i int) bool { return after.less(rows[i], false) }); rows[start].ch.ID != "C2" {
+		t.Errorf("next page starts at %s", rows[start].ch.ID)
+	}
+}
+
+func TestSectionRowOrder(t *testing.T) {
+	// Sections sort in sidebar order, not by name
+	rows := []channelRow{testRow("C1", "#alpha", "Zebra"), testRow("C2", "#beta", "Apple")}
+	rows[0].rank, rows[1].rank = 0, 1
+	sort.Slice(rows, func(i, j int) bool { return rows[i].less(rows[j], true) })
+	if rows[0].ch.ID != "C1" {
+		t.Errorf("order = %s %s", rows[0].ch.ID, rows[1].ch.ID)
+	}
+
+	query := channelQueryKey("all", "", "section", false)
+	after, err := decodeChannelCursor(encodeChannelCursor(query, rows[1]), query)
+	if err != nil || after.rank != 1 || after.category != "Apple" {
+		t.Fatalf("cursor lost the section: %+v, %v", after, err)
+	}
+}
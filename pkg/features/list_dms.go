@@ -0,0 +1,284 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// ListDMs lists open direct and group conversations, most recent first,
+// with unread counts and a preview of the last message
+var ListDMs = &Feature{
+	Name:        "list-dms",
+	Description: "List your open DMs and group DMs, most recently active first, with unread counts and a preview of the last message",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"unreadOnly": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Only conversations with unread messages",
+				"default":     false,
+			},
+			"includeGroups": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Include group DMs",
+				"default":     true,
+			},
+			"preview": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Fetch the last message of each conversation shown (one call each)",
+				"default":     true,
+			},
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": "Maximum conversations to return (default: 20, max: 100)",
+				"default":     20,
+			},
+			"cacheOnly": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Answer instantly from cached data without calling Slack (no previews; results may be stale)",
+				"default":     false,
+			},
+		},
+	},
+	Handler: listDMsHandler,
+}
+
+// Without client.counts, activity is learned by reading the last message of
+// each DM; this caps how many are read
+const listDMsScanCap = 50
+
+// dmEntry is one conversation being listed
+type dmEntry struct {
+	ch         slack.Channel
+	latest     time.Time
+	hasUnreads bool
+	unreads    int
+	last       *slack.Message
+}
+
+func listDMsHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	unreadOnly, _ := params["unreadOnly"].(bool)
+	includeGroups := true
+	if g, ok := params["includeGroups"].(bool); ok {
+		includeGroups = g
+	}
+	preview := true
+	if p, ok := params["preview"].(bool); ok {
+		preview = p
+	}
+	limit := 20
+	if l, ok := params["limit"].(float64); ok {
+		limit = max(1, min(int(l), 100))
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+	cacheOnly := useCacheOnly(params, apiProvider)
+
+	entries := map[string]*dmEntry{}
+	for _, ch := range apiProvider.GetCachedChannels() {
+		if (ch.IsIM || (ch.IsMpIM && includeGroups)) && !ch.IsArchived {
+			entries[ch.ID] = &dmEntry{ch: ch}
+		}
+	}
+
+	// Activity and unreads come from client.counts when it's available
+	var counts *provider.ClientCountsResponse
+	var countsAt time.Time
+	if cacheOnly {
+		counts, countsAt = apiProvider.CachedClientCounts()
+	} else if ic := apiProvider.ProvideInternalClient(); ic != nil {
+		if c, err := ic.GetClientCounts(ctx); err == nil && c.OK {
+			counts = c
+		}
+	}
+	if counts != nil {
+		apply := func(id, latest string, hasUnreads bool, mentions int, group bool) {
+			if group && !includeGroups {
+				return
+			}
+			e, ok := entries[id]
+			if !ok {
+				e = &dmEntry{}
+				e.ch.ID = id
+				e.ch.IsIM, e.ch.IsMpIM = !group, group
+				entries[id] = e
+			}
+			e.latest = parseSlackTimestamp(latest)
+			e.hasUnreads = hasUnreads
+			// Every DM message counts as a mention, so this is the unread count
+			e.unreads = mentions
+		}
+		for _, im := range counts.IMs {
+			apply(im.ID, im.Latest, im.HasUnreads, im.MentionCount, false)
+		}
+		for _, mp := range counts.MPIMs {
+			apply(mp.ID, mp.Latest, mp.HasUnreads, mp.MentionCount, true)
+		}
+	}
+
+	var api *slack.Client
+	if !cacheOnly {
+		api, _ = apiProvider.Provide()
+	}
+
+	// No counts: read the last message of each DM to order them
+	scanned := 0
+	if counts == nil && api != nil {
+		for _, e := range entries {
+			if scanned >= listDMsScanCap {
+				break
+			}
+			fetchLastMessage(ctx, api, e)
+			scanned++
+		}
+	}
+
+	list := make([]*dmEntry, 0, len(entries))
+	for _, e := range entries {
+		if unreadOnly && !e.hasUnreads {
+			continue
+		}
+		list = append(list, e)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].latest.Equal(list[j].latest) {
+			return list[i].latest.After(list[j].latest)
+		}
+		return list[i].ch.ID < list[j].ch.ID
+	})
+	total := len(list)
+	if len(list) > limit {
+		list = list[:limit]
+	}
+
+	usersMap := apiProvider.ProvideUsersMap()
+	var self *provider.SelfInfo
+	if api != nil {
+		self, _ = apiProvider.Self()
+	}
+	dms := make([]map[string]interface{}, 0, len(list))
+	totalUnread := 0
+	for _, e := range list {
+		if e.ch.Name == "" && e.ch.User == "" && !cacheOnly {
+			if info, err := apiProvider.GetChannelInfo(ctx, e.ch.ID); err == nil {
+				e.ch.Name, e.ch.User = info.Name, info.User
+			}
+		}
+		if preview && e.last == nil && api != nil {
+			fetchLastMessage(ctx, api, e)
+		}
+
+		dm := map[string]interface{}{
+			"id":         e.ch.ID,
+			"with":       dmDisplayName(e.ch, usersMap),
+			"type":       "dm",
+			"hasUnreads": e.hasUnreads,
+			"unread":     e.unreads,
+		}
+		if e.ch.IsMpIM {
+			dm["type"] = "group-dm"
+		}
+		if !e.latest.IsZero() && e.latest.Unix() > 0 {
+			dm["lastActivity"] = formatTimestamp(e.latest)
+		}
+		if e.last != nil {
+			from := getUserName(e.last.User, usersMap)
+			if self != nil && e.last.User == self.UserID {
+				from = "you"
+			}
+			dm["lastFrom"] = from
+			dm["lastMessage"] = truncateMessage(e.last.Text, 120)
+		}
+		totalUnread += e.unreads
+		dms = append(dms, dm)
+	}
+
+	result := &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("%d conversations (showing %d)", total, len(dms)),
+		Data: map[string]interface{}{
+			"dms":         dms,
+			"total":       total,
+			"totalUnread": totalUnread,
+			"hasCounts":   counts != nil,
+		},
+		ResultCount: len(dms),
+	}
+	switch {
+	case len(dms) == 0 && unreadOnly:
+		result.Guidance = "✅ No unread DMs"
+	case len(dms) == 0:
+		result.Guidance = "No open DMs in the cache; list-channels filter='dm' forceRefresh=true reloads them"
+	case counts == nil:
+		result.Guidance = fmt.Sprintf("Unread counts need a browser session token; ordered by the last message of %d DMs", scanned)
+	}
+	if len(dms) > 0 {
+		result.NextActions = []string{
+			fmt.Sprintf("Read one: read-messages channel='%s'", dms[0]["id"]),
+			"Reply: send-message channel='<id>' text='...'",
+		}
+	}
+	if cacheOnly {
+		markStale(result, countsAt)
+	}
+	return result, nil
+}
+
+// fetchLastMessage reads a DM's newest message, which also dates its last
+// activity
+func fetchLastMessage(ctx context.Context, api *slack.Client, e *dmEntry) {
+	resp, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+		ChannelID: e.ch.ID,
+		Limit:     1,
+	})
+	if err != nil || len(resp.Messages) == 0 {
+		return
+	}
+	m := resp.Messages[0]
+	e.last = &m
+	if t := parseSlackTimestamp(m.Timestamp); t.After(e.latest) {
+		e.latest = t
+	}
+}
+
+// dmDisplayName names a DM by the other person, or a group DM by its
+// members ("mpdm-ann--bob--cy-1" → "ann, bob, cy")
+func dmDisplayName(ch slack.Channel, usersMap map[string]slack.User) string {
+	if ch.IsIM && ch.User != "" {
+		return getUserName(ch.User, usersMap)
+	}
+	if name, ok := strings.CutPrefix(ch.Name, "mpdm-"); ok {
+		if i := strings.LastIndex(name, "-"); i > 0 {
+			name = name[:i]
+		}
+		return strings.Join(strings.Split(name, "--"), ", ")
+	}
+	if ch.Name != "" {
+		return ch.Name
+	}
+	return ch.ID
+}
+
+// conversationLabel names any conversation: "#name" for channels, the
+// person or people for DMs and group DMs
+func conversationLabel(ch slack.Channel, usersMap map[string]slack.User) string {
+	if ch.IsIM || ch.IsMpIM || strings.HasPrefix(ch.Name, "mpdm-") {
+		return dmDisplayName(ch, usersMap)
+	}
+	if ch.Name == "" {
+		return ch.ID
+	}
+	return "#" + ch.Name
+}
@@ -0,0 +1,29 @@
+package features
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestDMDisplayName(t *testing.T) {
+	users := map[string]slack.User{"U1": {ID: "U1", Name: "ann", RealName: "Ann Lee"}}
+
+	im := slack.Channel{}
+	im.ID, im.IsIM, im.User = "D1", true, "U1"
+	if got := dmDisplayName(im, users); got != "Ann Lee" {
+		t.Errorf("IM name = %q", got)
+	}
+
+	mpim := slack.Channel{}
+	mpim.ID, mpim.IsMpIM, mpim.Name = "G1", true, "mpdm-ann--bob--cy.d-1"
+	if got := dmDisplayName(mpim, users); got != "ann, bob, cy.d" {
+		t.Errorf("MPIM name = %q", got)
+	}
+
+	unknown := slack.Channel{}
+	unknown.ID, unknown.IsMpIM = "G2", true
+	if got := dmDisplayName(unknown, users); got != "G2" {
+		t.Errorf("unnamed DM = %q", got)
+	}
+}
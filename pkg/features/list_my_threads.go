@@ -0,0 +1,173 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// ListMyThreads mirrors Slack's Threads view: the threads the user follows,
+// most recently active first, with their newest replies
+var ListMyThreads = &Feature{
+	Name:        "list-my-threads",
+	Description: "Your Threads view: threads you follow with new replies, showing each root message, the newest replies, and unread counts",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"includeRead": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Also list followed threads with no new replies",
+				"default":     false,
+			},
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": fmt.Sprintf("Maximum threads to return (default: 20, max: %d)", threadViewLimit),
+				"default":     20,
+			},
+		},
+	},
+	Handler: listMyThreadsHandler,
+}
+
+// threadRepliesShown is how many of a thread's newest replies are listed
+const threadRepliesShown = 3
+
+func listMyThreadsHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	includeRead, _ := params["includeRead"].(bool)
+	limit := 20
+	if l, ok := params["limit"].(float64); ok {
+		limit = max(1, min(int(l), threadViewLimit))
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	ic := apiProvider.ProvideInternalClient()
+	if ic == nil {
+		return &FeatureResult{
+			Success:     false,
+			Message:     "The Threads view needs a browser session (xoxc) token",
+			Guidance:    "check-mentions finds replies that mention you with the official API",
+			NextActions: []string{"check-mentions"},
+		}, nil
+	}
+
+	// Read threads are filtered out here, so fetch a full page to fill limit
+	view, err := ic.GetThreadView(ctx, threadViewLimit)
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to load your threads: %v", err),
+		}, nil
+	}
+
+	usersMap := apiProvider.ProvideUsersMap()
+	labels := map[string]string{}
+	label := func(channelID string) string {
+		if l, ok := labels[channelID]; ok {
+			return l
+		}
+		l := channelID
+		if info, err := apiProvider.GetChannelInfo(ctx, channelID); err == nil {
+			l = conversationLabel(*info, usersMap)
+		} else {
+			log.Printf("Failed to get conversation info for %s: %v", channelID, err)
+		}
+		labels[channelID] = l
+		return l
+	}
+
+	var links permalinkBatch
+	threads := []map[string]interface{}{}
+	withUnreads := 0
+	more := view.HasMore
+	for _, t := range view.Threads {
+		if len(t.UnreadReplies) == 0 && !includeRead {
+			continue
+		}
+		if len(t.UnreadReplies) > 0 {
+			withUnreads++
+		}
+		if len(threads) >= limit {
+			more = true
+			continue
+		}
+
+		root := t.RootMsg
+		entry := map[string]interface{}{
+			"threadId":      ThreadRef{ChannelID: root.Channel, ThreadTs: root.Ts}.String(),
+			"conversation":  label(root.Channel),
+			"author":        getUserName(root.User, usersMap),
+			"text":          root.Text,
+			"timestamp":     formatTimestamp(parseSlackTimestamp(root.Ts)),
+			"replyCount":    root.ReplyCount,
+			"unreadReplies": len(t.UnreadReplies),
+			"replies":       newestReplies(t.LatestReplies, t.UnreadReplies, usersMap),
+		}
+		links.add(entry, root.Channel, root.Ts)
+		threads = append(threads, entry)
+	}
+	links.resolve(ctx, apiProvider)
+
+	result := &FeatureResult{
+		Success: true,
+		Data: map[string]interface{}{
+			"threads":            threads,
+			"withUnreads":        withUnreads,
+			"totalUnreadReplies": view.TotalUnreadReplies,
+			"hasMore":            more,
+		},
+		Message:     fmt.Sprintf("%d threads with new replies (%d unread replies)", withUnreads, view.TotalUnreadReplies),
+		ResultCount: len(threads),
+		NextActions: []string{
+			"Use 'read-thread' with a threadId to read the whole thread",
+			"Use 'mark-read' with target='thread:<threadId>', or target='everything' scope='threads-only' to clear all thread badges",
+		},
+	}
+	if len(threads) == 0 {
+		result.Guidance = "✅ No followed threads have new replies"
+		if includeRead {
+			result.Guidance = "You aren't following any threads"
+		}
+	} else if more {
+		result.Guidance = fmt.Sprintf("Showing the %d most recently active threads; mark some read or raise limit to see more", len(threads))
+	}
+	return result, nil
+}
+
+// newestReplies lists a thread's last few replies, oldest first as the
+// thread reads, flagging the unread ones
+func newestReplies(latest, unread []provider.ThreadReply, usersMap map[string]slack.User) []map[string]interface{} {
+	isUnread := make(map[string]bool, len(unread))
+	for _, r := range unread {
+		isUnread[r.Ts] = true
+	}
+	replies := latest
+	if len(replies) == 0 {
+		replies = unread
+	}
+	if len(replies) > threadRepliesShown {
+		replies = replies[len(replies)-threadRepliesShown:]
+	}
+	shown := make([]map[string]interface{}, 0, len(replies))
+	for _, r := range replies {
+		reply := map[string]interface{}{
+			"author":    getUserName(r.User, usersMap),
+			"text":      r.Text,
+			"timestamp": formatTimestamp(parseSlackTimestamp(r.Ts)),
+		}
+		if isUnread[r.Ts] {
+			reply["unread"] = true
+		}
+		shown = append(shown, reply)
+	}
+	return shown
+}
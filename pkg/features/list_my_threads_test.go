@@ -0,0 +1,35 @@
+package features
+
+import (
+	"testing"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+func TestNewestReplies(t *testing.T) {
+	users := map[string]slack.User{"U1": {Name: "ann"}, "U2": {Name: "bo"}}
+	latest := []provider.ThreadReply{
+		{Ts: "1700000001.000000", User: "U1", Text: "first"},
+		{Ts: "1700000002.000000", User: "U2", Text: "second"},
+		{Ts: "1700000003.000000", User: "U1", Text: "third"},
+		{Ts: "1700000004.000000", User: "U2", Text: "fourth"},
+	}
+	unread := []provider.ThreadReply{{Ts: "1700000004.000000", User: "U2", Text: "fourth"}}
+
+	got := newestReplies(latest, unread, users)
+	if len(got) != threadRepliesShown {
+		t.Fatalf("got %d replies, want %d", len(got), threadRepliesShown)
+	}
+	if got[0]["text"] != "second" || got[2]["text"] != "fourth" || got[2]["author"] != "bo" {
+		t.Errorf("replies = %v", got)
+	}
+	if got[2]["unread"] != true || got[1]["unread"] != nil {
+		t.Errorf("unread flags wrong: %v", got)
+	}
+
+	// Without latest replies the unread ones stand in
+	if got := newestReplies(nil, unread, users); len(got) != 1 || got[0]["unread"] != true {
+		t.Errorf("fallback = %v", got)
+	}
+}
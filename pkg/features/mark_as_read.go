@@ -3,7 +3,6 @@ package features
 import (
 	"context"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 
@@ -20,7 +19,7 @@ var MarkAsRead = &Feature{
 		"properties": map[string]interface{}{
 			"target": map[string]interface{}{
 				"type":        "string",
-				"description": "What to mark as read: 'channel:name', 'thread:id', 'dm:user', 'all-dms', 'all-channels', 'everything'",
+				"description": "What to mark as read: 'channel:name', 'thread:id', 'dm:user', 'all-dms' (including group DMs), 'all-channels', 'everything'",
 			},
 			"channel": map[string]interface{}{
 				"type":        "string",
@@ -32,14 +31,24 @@ var MarkAsRead = &Feature{
 			},
 			"scope": map[string]interface{}{
 				"type":        "string",
-				"description": "Scope of marking: 'messages-only', 'including-threads', 'threads-only'",
+				"description": "Scope of marking for channel and bulk targets: 'messages-only' (channel read pointer), 'including-threads' (also clears unread thread badges), 'threads-only'",
 				"default":     "including-threads",
 			},
 			"filter": map[string]interface{}{
 				"type":        "string",
-				"description": "Filter what to mark: 'all', 'non-important', 'older-than-1d', 'no-mentions'",
+				"description": "Filter what to mark: 'all', 'non-important' (channels scoring low in rank-my-channels), 'older-than-1d', 'no-mentions', 'low-priority' (channels whose names match a low-priority taxonomy rule, e.g. social-*)",
 				"default":     "all",
 			},
+			"preview": map[string]interface{}{
+				"type":        "boolean",
+				"description": "For all-dms, all-channels, and everything: list exactly what would be marked without marking anything",
+				"default":     false,
+			},
+			"exclude": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Conversations to leave unread in a bulk mark: channel names, @users for DMs, or IDs (e.g. from a preview)",
+			},
 		},
 		"required": []string{},
 	},
@@ -72,7 +81,7 @@ func markAsReadHandler(ctx context.Context, params map[string]interface{}) (*Fea
 		timestamp = ts
 	}
 
-	scope := "including-threads"
+	scope := scopeIncludingThreads
 	if s, ok := params["scope"].(string); ok {
 		scope = s
 	}
@@ -82,9 +91,12 @@ func markAsReadHandler(ctx context.Context, params map[string]interface{}) (*Fea
 		filter = f
 	}
 
+	preview, _ := params["preview"].(bool)
+	opts := newBulkMarkOptions(apiProvider, filter, scope, preview, stringList(params["exclude"]))
+
 	// Handle different target types
 	if target != "" {
-		return handleTargetMarkAsRead(ctx, apiProvider, target, scope, filter)
+		return handleTargetMarkAsRead(ctx, apiProvider, target, scope, opts)
 	} else if channel != "" {
 		return handleChannelMarkAsRead(ctx, apiProvider, channel, timestamp, scope)
 	} else {
@@ -93,7 +105,7 @@ func markAsReadHandler(ctx context.Context, params map[string]interface{}) (*Fea
 	}
 }
 
-func handleTargetMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvider, target, scope, filter string) (*FeatureResult, error) {
+func handleTargetMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvider, target, scope string, opts bulkMarkOptions) (*FeatureResult, error) {
 	parts := strings.SplitN(target, ":", 2)
 	targetType := target
 	targetValue := ""
@@ -114,13 +126,13 @@ func handleTargetMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvid
 		return handleDMMarkAsRead(ctx, apiProvider, targetValue)
 
 	case "all-dms":
-		return handleAllDMsMarkAsRead(ctx, apiProvider, filter)
+		return handleAllDMsMarkAsRead(ctx, apiProvider, opts)
 
 	case "all-channels":
-		return handleAllChannelsMarkAsRead(ctx, apiProvider, filter)
+		return handleAllChannelsMarkAsRead(ctx, apiProvider, opts)
 
 	case "everything":
-		return handleEverythingMarkAsRead(ctx, apiProvider, filter)
+		return handleEverythingMarkAsRead(ctx, apiProvider, opts)
 
 	default:
 		return &FeatureResult{
@@ -146,59 +158,93 @@ func handleChannelMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvi
 		}, nil
 	}
 
-	var client *slack.Client
+	internalClient := apiProvider.ProvideInternalClient()
+	if scope == scopeThreadsOnly && internalClient == nil {
+		return &FeatureResult{
+			Success:  false,
+			Message:  "Marking threads read requires internal client access",
+			Guidance: "⚠️ scope='threads-only' requires xoxc/xoxd tokens",
+		}, nil
+	}
+
+	data := map[string]interface{}{
+		"channel": channelInfo.Name,
+		"scope":   scope,
+	}
+	var done []string
 
-	// Get latest message timestamp if not provided
-	if timestamp == "" {
-		if client == nil {
-			client, err = apiProvider.Provide()
-			if err != nil {
+	if scope != scopeThreadsOnly {
+		client, err := apiProvider.Provide()
+		if err != nil {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Could not get client: %v", err),
+			}, nil
+		}
+
+		// Get latest message timestamp if not provided
+		if timestamp == "" {
+			history, err := client.GetConversationHistory(&slack.GetConversationHistoryParameters{
+				ChannelID: channelID,
+				Limit:     1,
+			})
+			if err != nil || len(history.Messages) == 0 {
 				return &FeatureResult{
 					Success: false,
-					Message: fmt.Sprintf("Could not get client: %v", err),
+					Message: "Could not get latest message timestamp",
 				}, nil
 			}
+			timestamp = history.Messages[0].Timestamp
 		}
-		history, err := client.GetConversationHistory(&slack.GetConversationHistoryParameters{
-			ChannelID: channelID,
-			Limit:     1,
-		})
-		if err != nil || len(history.Messages) == 0 {
+
+		// Mark channel as read
+		if err := client.MarkConversation(channelID, timestamp); err != nil {
 			return &FeatureResult{
 				Success: false,
-				Message: "Could not get latest message timestamp",
+				Message: fmt.Sprintf("Failed to mark channel as read: %v", err),
 			}, nil
 		}
-		timestamp = history.Messages[0].Timestamp
+		apiProvider.RecordAction(provider.UsageMarkedRead, 1)
+		data["markedUpTo"] = timestamp
+		done = append(done, "messages")
 	}
 
-	// Mark channel as read
-	if client == nil {
-		client, err = apiProvider.Provide()
-		if err != nil {
-			return &FeatureResult{
-				Success: false,
-				Message: fmt.Sprintf("Could not get client: %v", err),
-			}, nil
+	guidance := ""
+	if scope != scopeMessagesOnly {
+		if internalClient == nil {
+			guidance = "Threads were not marked: that needs xoxc/xoxd tokens. "
+		} else {
+			threads, failed, more, err := markUnreadThreads(ctx, internalClient, bulkMarkOptions{}, func(id string) bool {
+				return id == channelID
+			})
+			if err != nil {
+				if scope == scopeThreadsOnly {
+					return &FeatureResult{
+						Success: false,
+						Message: fmt.Sprintf("Failed to mark threads as read: %v", err),
+					}, nil
+				}
+				guidance = fmt.Sprintf("Threads were not marked: %v. ", err)
+			} else {
+				apiProvider.RecordAction(provider.UsageMarkedRead, len(threads))
+				data["threadsMarked"] = len(threads)
+				done = append(done, fmt.Sprintf("%d threads", len(threads)))
+				if len(failed) > 0 {
+					data["errors"] = failed
+					guidance = fmt.Sprintf("%d threads could not be marked; see errors. ", len(failed))
+				}
+				if more {
+					guidance += "More unread threads may remain beyond the first page; run again to continue. "
+				}
+			}
 		}
 	}
-	err = client.MarkConversation(channelID, timestamp)
-	if err != nil {
-		return &FeatureResult{
-			Success: false,
-			Message: fmt.Sprintf("Failed to mark channel as read: %v", err),
-		}, nil
-	}
 
 	result := &FeatureResult{
-		Success: true,
-		Data: map[string]interface{}{
-			"channel":    channelInfo.Name,
-			"markedUpTo": timestamp,
-			"scope":      scope,
-		},
-		Message:  fmt.Sprintf("Marked #%s as read", channelInfo.Name),
-		Guidance: "✅ Channel messages marked as read",
+		Success:  true,
+		Data:     data,
+		Message:  fmt.Sprintf("Marked #%s as read (%s)", channelInfo.Name, strings.Join(done, ", ")),
+		Guidance: guidance + "✅ Channel marked as read",
 	}
 
 	// Add next actions
@@ -211,27 +257,18 @@ func handleChannelMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvi
 }
 
 func handleThreadMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvider, threadId string) (*FeatureResult, error) {
-	// Parse thread ID (format: channelId.threadTs)
-	parts := strings.Split(threadId, ".")
-	if len(parts) != 2 {
+	ref, err := parseThreadRef(threadId)
+	if err != nil {
 		return &FeatureResult{
 			Success: false,
-			Message: "Invalid threadId format. Expected: channelId.threadTs",
+			Message: err.Error(),
 		}, nil
 	}
 
-	channelId := parts[0]
-	threadTs := parts[1]
+	channelId := ref.ChannelID
+	threadTs := ref.ThreadTs
+	threadId = ref.String()
 
-	// Mark thread as read using internal client if available
-	internalClient := apiProvider.ProvideInternalClient()
-	if internalClient != nil {
-		// Use internal endpoint for thread marking
-		// Note: This would require adding a new method to InternalClient
-		log.Printf("TODO: Implement internal thread marking for %s", threadId)
-	}
-
-	// Fallback: Mark the channel up to the thread timestamp
 	client, err := apiProvider.Provide()
 	if err != nil {
 		return &FeatureResult{
@@ -239,6 +276,44 @@ func handleThreadMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvid
 			Message: fmt.Sprintf("Could not get client: %v", err),
 		}, nil
 	}
+
+	// Threads have their own read pointer: move it to the latest reply
+	if internalClient := apiProvider.ProvideInternalClient(); internalClient != nil {
+		latest := threadTs
+		if parent, _, _, err := client.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+			ChannelID: channelId,
+			Timestamp: threadTs,
+			Limit:     1,
+		}); err == nil && len(parent) > 0 && parent[0].LatestReply != "" {
+			latest = parent[0].LatestReply
+		}
+		if err := internalClient.MarkThreadRead(ctx, channelId, threadTs, latest); err != nil {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Failed to mark thread as read: %v", err),
+			}, nil
+		}
+		apiProvider.RecordAction(provider.UsageMarkedRead, 1)
+
+		return &FeatureResult{
+			Success: true,
+			Data: map[string]interface{}{
+				"threadId":   threadId,
+				"channelId":  channelId,
+				"threadTs":   threadTs,
+				"markedUpTo": latest,
+			},
+			Message:  "Thread marked as read",
+			Guidance: "✅ Thread and its replies marked as read",
+			NextActions: []string{
+				"Check for more threads: check-unreads focus='threads'",
+				"Find related discussions: search",
+			},
+		}, nil
+	}
+
+	// Fallback without internal access: mark the channel up to the thread
+	// timestamp, which leaves the thread's own badge in place
 	err = client.MarkConversation(channelId, threadTs)
 	if err != nil {
 		return &FeatureResult{
@@ -246,6 +321,7 @@ func handleThreadMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvid
 			Message: fmt.Sprintf("Failed to mark thread as read: %v", err),
 		}, nil
 	}
+	apiProvider.RecordAction(provider.UsageMarkedRead, 1)
 
 	return &FeatureResult{
 		Success: true,
@@ -254,8 +330,8 @@ func handleThreadMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvid
 			"channelId": channelId,
 			"threadTs":  threadTs,
 		},
-		Message:  "Thread marked as read",
-		Guidance: "✅ Thread and its replies marked as read",
+		Message:  "Channel marked as read up to the thread",
+		Guidance: "⚠️ Without xoxc/xoxd tokens only the channel read pointer moves; the thread's unread badge may remain",
 		NextActions: []string{
 			"Check for more threads: check-unreads focus='threads'",
 			"Find related discussions: search",
@@ -264,50 +340,29 @@ func handleThreadMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvid
 }
 
 func handleDMMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvider, user string) (*FeatureResult, error) {
-	// Find DM channel with user
-	userID := user
-
-	// Try to resolve username to ID
 	usersMap := apiProvider.ProvideUsersMap()
-	for uid, u := range usersMap {
-		if u.Name == user || u.RealName == user || uid == user {
-			userID = uid
-			break
-		}
-	}
-
-	// Find IM channel
-	client, err := apiProvider.Provide()
-	if err != nil {
+	userID := resolveUserRef(ctx, apiProvider, user, usersMap)
+	if userID == "" {
 		return &FeatureResult{
 			Success: false,
-			Message: fmt.Sprintf("Could not get client: %v", err),
+			Message: fmt.Sprintf("User '%s' not found", user),
 		}, nil
 	}
-	// Get IM conversations
-	conversations, _, err := client.GetConversations(&slack.GetConversationsParameters{
-		Types: []string{"im"},
-		Limit: 1000,
-	})
+
+	client, err := apiProvider.Provide()
 	if err != nil {
 		return &FeatureResult{
 			Success: false,
-			Message: fmt.Sprintf("Could not get DM channels: %v", err),
+			Message: fmt.Sprintf("Could not get client: %v", err),
 		}, nil
 	}
 
-	var imChannel *slack.Channel
-	for _, conv := range conversations {
-		if conv.IsIM && conv.User == userID {
-			imChannel = &conv
-			break
-		}
-	}
-
-	if imChannel == nil {
+	// Cached IM map first; conversations.open only for users without one
+	imChannel, err := apiProvider.OpenDM(ctx, userID)
+	if err != nil {
 		return &FeatureResult{
 			Success: false,
-			Message: fmt.Sprintf("No DM channel found with user '%s'", user),
+			Message: fmt.Sprintf("No DM channel found with user '%s': %v", user, err),
 		}, nil
 	}
 
@@ -331,6 +386,7 @@ func handleDMMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvider,
 			Message: fmt.Sprintf("Failed to mark DM as read: %v", err),
 		}, nil
 	}
+	apiProvider.RecordAction(provider.UsageMarkedRead, 1)
 
 	// Get user's real name for display
 	userName := user
@@ -357,7 +413,8 @@ func handleDMMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvider,
 	}, nil
 }
 
-func handleAllDMsMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvider, filter string) (*FeatureResult, error) {
+func handleAllDMsMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvider, opts bulkMarkOptions) (*FeatureResult, error) {
+	filter := opts.filter
 	// Get unread counts
 	internalClient := apiProvider.ProvideInternalClient()
 	if internalClient == nil {
@@ -376,46 +433,61 @@ func handleAllDMsMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvid
 		}, nil
 	}
 
-	markedCount := 0
 	skippedCount := 0
-	errors := []string{}
-
-	// Process IMs
-	for _, im := range counts.IMs {
-		if !im.HasUnreads {
-			continue
+	var pending []map[string]interface{}
+	var excluded []string
+	var targets []provider.MarkTarget
+
+	// Process DMs and group DMs
+	consider := func(id, latest, kind string, hasUnreads bool, mentions int) {
+		if !hasUnreads {
+			return
 		}
 
-		// Apply filter
-		if filter == "no-mentions" && im.MentionCount > 0 {
+		// Apply filter; DMs have no naming convention, so are never low priority
+		if (filter == "no-mentions" && mentions > 0) || filter == "low-priority" {
 			skippedCount++
-			continue
+			return
 		}
 
 		if filter == "older-than-1d" {
 			// Check if latest message is older than 1 day
-			ts := parseSlackTimestamp(im.Latest)
+			ts := parseSlackTimestamp(latest)
 			if time.Since(ts) < 24*time.Hour {
 				skippedCount++
-				continue
+				return
 			}
 		}
 
-		// Mark as read
-		client, _ := apiProvider.Provide()
-		err := client.MarkConversation(im.ID, im.Latest)
-		if err != nil {
-			errors = append(errors, fmt.Sprintf("%s: %v", im.ID, err))
-		} else {
-			markedCount++
+		if opts.isExcluded(id) {
+			excluded = append(excluded, opts.label(id))
+			return
+		}
+		if opts.preview {
+			pending = append(pending, opts.entry(id, mentions))
+			return
 		}
+		targets = append(targets, provider.MarkTarget{ChannelID: id, Ts: latest, Kind: kind})
+	}
+	for _, im := range counts.IMs {
+		consider(im.ID, im.Latest, provider.MarkIM, im.HasUnreads, im.MentionCount)
+	}
+	for _, mp := range counts.MPIMs {
+		consider(mp.ID, mp.Latest, provider.MarkMPIM, mp.HasUnreads, mp.MentionCount)
 	}
+	if opts.preview {
+		return bulkMarkPreview("all-dms", opts, pending, skippedCount, excluded), nil
+	}
+
+	markedCount, errors := markTargets(ctx, internalClient, targets)
+	apiProvider.RecordAction(provider.UsageMarkedRead, markedCount)
 
 	result := &FeatureResult{
 		Success: true,
 		Data: map[string]interface{}{
 			"markedCount":  markedCount,
 			"skippedCount": skippedCount,
+			"excluded":     excluded,
 			"filter":       filter,
 			"errors":       errors,
 		},
@@ -437,7 +509,8 @@ func handleAllDMsMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvid
 	return result, nil
 }
 
-func handleAllChannelsMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvider, filter string) (*FeatureResult, error) {
+func handleAllChannelsMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvider, opts bulkMarkOptions) (*FeatureResult, error) {
+	filter := opts.filter
 	// Similar to DMs but for channels
 	internalClient := apiProvider.ProvideInternalClient()
 	if internalClient == nil {
@@ -456,35 +529,67 @@ func handleAllChannelsMarkAsRead(ctx context.Context, apiProvider *provider.ApiP
 		}, nil
 	}
 
-	markedCount := 0
 	skippedCount := 0
-	errors := []string{}
+	var pending []map[string]interface{}
+	var excluded []string
+	var targets []provider.MarkTarget
 
-	// Get list of important channels (you're actively participating in)
+	// Important channels by the rank-my-channels score
 	importantChannels := map[string]bool{}
 	if filter == "non-important" {
-		// Get channels where user has recently posted
-		// This is a simplified heuristic
-		client, _ := apiProvider.Provide()
-		channels, _, _ := client.GetConversations(&slack.GetConversationsParameters{
-			Limit: 100,
-			Types: []string{"public_channel", "private_channel"},
-		})
-		for _, ch := range channels {
-			if ch.IsMember && ch.NumMembers < 20 { // Small channels are likely important
-				importantChannels[ch.ID] = true
+		client, err := apiProvider.Provide()
+		if err != nil {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+			}, nil
+		}
+		ranked, err := scoreMyChannels(ctx, apiProvider, client, "30d")
+		if err != nil {
+			return &FeatureResult{
+				Success:  false,
+				Message:  fmt.Sprintf("Could not score channel importance: %v", err),
+				Guidance: "Nothing was marked. Use filter='no-mentions' or filter='low-priority' instead.",
+			}, nil
+		}
+		for _, c := range ranked {
+			if c.important() {
+				importantChannels[c.id] = true
+			}
+		}
+	}
+
+	// Channel categories from naming conventions
+	categories := map[string]string{}
+	if filter == "low-priority" {
+		rules := loadChannelTaxonomy()
+		for _, ch := range apiProvider.GetCachedChannels() {
+			if category := channelCategory(rules, ch.Name); category != "" {
+				categories[ch.ID] = category
 			}
 		}
 	}
 
+	// Channel filters that threads can follow too; thread unreads carry no
+	// mention or age details, so other filters leave threads alone
+	channelFilter := func(id string) bool {
+		switch filter {
+		case "non-important":
+			return !importantChannels[id]
+		case "low-priority":
+			return categories[id] == categoryLow
+		}
+		return true
+	}
+
 	// Process channels
 	for _, ch := range counts.Channels {
-		if !ch.HasUnreads {
+		if !ch.HasUnreads || opts.scope == scopeThreadsOnly {
 			continue
 		}
 
 		// Apply filter
-		if filter == "non-important" && importantChannels[ch.ID] {
+		if !channelFilter(ch.ID) {
 			skippedCount++
 			continue
 		}
@@ -494,25 +599,52 @@ func handleAllChannelsMarkAsRead(ctx context.Context, apiProvider *provider.ApiP
 			continue
 		}
 
-		// Mark as read
-		client, _ := apiProvider.Provide()
-		err := client.MarkConversation(ch.ID, ch.Latest)
+		if opts.isExcluded(ch.ID) {
+			excluded = append(excluded, opts.label(ch.ID))
+			continue
+		}
+		if opts.preview {
+			pending = append(pending, opts.entry(ch.ID, ch.MentionCount))
+			continue
+		}
+
+		targets = append(targets, provider.MarkTarget{ChannelID: ch.ID, Ts: ch.Latest, Kind: provider.MarkChannel})
+	}
+	markedCount, errors := markTargets(ctx, internalClient, targets)
+
+	// Thread subscriptions have their own read pointers
+	var threads []map[string]interface{}
+	threadsMore := false
+	if opts.scope != scopeMessagesOnly && (filter == "all" || filter == "non-important" || filter == "low-priority") {
+		var failed []string
+		threads, failed, threadsMore, err = markUnreadThreads(ctx, internalClient, opts, channelFilter)
 		if err != nil {
-			errors = append(errors, fmt.Sprintf("%s: %v", ch.ID, err))
-		} else {
-			markedCount++
+			errors = append(errors, fmt.Sprintf("threads: %v", err))
 		}
+		errors = append(errors, failed...)
+	}
+
+	if opts.preview {
+		pending = append(pending, threads...)
+		return bulkMarkPreview("all-channels", opts, pending, skippedCount, excluded), nil
 	}
+	apiProvider.RecordAction(provider.UsageMarkedRead, markedCount+len(threads))
 
 	result := &FeatureResult{
 		Success: true,
 		Data: map[string]interface{}{
-			"markedCount":  markedCount,
-			"skippedCount": skippedCount,
-			"filter":       filter,
-			"errors":       errors,
+			"markedCount":   markedCount,
+			"threadsMarked": len(threads),
+			"skippedCount":  skippedCount,
+			"excluded":      excluded,
+			"filter":        filter,
+			"scope":         opts.scope,
+			"errors":        errors,
 		},
-		Message: fmt.Sprintf("Marked %d channels as read", markedCount),
+		Message: fmt.Sprintf("Marked %d channels and %d threads as read", markedCount, len(threads)),
+	}
+	if threadsMore {
+		result.NextActions = append(result.NextActions, "More unread threads remain: run the same mark-read again")
 	}
 
 	if skippedCount > 0 {
@@ -522,51 +654,83 @@ func handleAllChannelsMarkAsRead(ctx context.Context, apiProvider *provider.ApiP
 		result.Guidance = "✅ All unread channels marked as read"
 	}
 
-	result.NextActions = []string{
+	result.NextActions = append(result.NextActions,
 		"Check what's left: check-unreads",
 		"Review important channels: catch-up channel='general'",
-	}
+	)
 
 	return result, nil
 }
 
-func handleEverythingMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvider, filter string) (*FeatureResult, error) {
-	// Mark both DMs and channels
-	dmResult, _ := handleAllDMsMarkAsRead(ctx, apiProvider, filter)
-	channelResult, _ := handleAllChannelsMarkAsRead(ctx, apiProvider, filter)
-
-	dmMarked := 0
-	channelMarked := 0
+func handleEverythingMarkAsRead(ctx context.Context, apiProvider *provider.ApiProvider, opts bulkMarkOptions) (*FeatureResult, error) {
+	filter := opts.filter
 
-	if dmData, ok := dmResult.Data.(map[string]interface{}); ok {
-		if count, ok := dmData["markedCount"].(int); ok {
-			dmMarked = count
+	// Mark both DMs and channels
+	dmResult, _ := handleAllDMsMarkAsRead(ctx, apiProvider, opts)
+	channelResult, _ := handleAllChannelsMarkAsRead(ctx, apiProvider, opts)
+
+	if opts.preview {
+		var pending []map[string]interface{}
+		var excluded []string
+		skipped := 0
+		for _, r := range []*FeatureResult{dmResult, channelResult} {
+			if !r.Success {
+				return r, nil
+			}
+			data, _ := r.Data.(map[string]interface{})
+			items, _ := data["wouldMark"].([]map[string]interface{})
+			pending = append(pending, items...)
+			ex, _ := data["excluded"].([]string)
+			excluded = append(excluded, ex...)
+			n, _ := data["skippedCount"].(int)
+			skipped += n
 		}
+		return bulkMarkPreview("everything", opts, pending, skipped, excluded), nil
 	}
 
-	if chData, ok := channelResult.Data.(map[string]interface{}); ok {
-		if count, ok := chData["markedCount"].(int); ok {
-			channelMarked = count
-		}
+	// Neither part could run, e.g. without xoxc tokens
+	if !dmResult.Success && !channelResult.Success {
+		return channelResult, nil
 	}
 
+	errors := []string{}
+	counts := func(r *FeatureResult, part string) (marked, threads int) {
+		if !r.Success {
+			errors = append(errors, fmt.Sprintf("%s: %s", part, r.Message))
+			return 0, 0
+		}
+		data, _ := r.Data.(map[string]interface{})
+		marked, _ = data["markedCount"].(int)
+		threads, _ = data["threadsMarked"].(int)
+		failed, _ := data["errors"].([]string)
+		errors = append(errors, failed...)
+		return marked, threads
+	}
+	dmMarked, _ := counts(dmResult, "DMs")
+	channelMarked, threadsMarked := counts(channelResult, "channels")
 	totalMarked := dmMarked + channelMarked
 
-	return &FeatureResult{
+	result := &FeatureResult{
 		Success: true,
 		Data: map[string]interface{}{
 			"totalMarked":    totalMarked,
 			"dmsMarked":      dmMarked,
 			"channelsMarked": channelMarked,
+			"threadsMarked":  threadsMarked,
 			"filter":         filter,
+			"errors":         errors,
 		},
-		Message:  fmt.Sprintf("Marked %d conversations as read", totalMarked),
-		Guidance: fmt.Sprintf("✅ Slack inbox cleared! (%d DMs, %d channels)", dmMarked, channelMarked),
+		Message:  fmt.Sprintf("Marked %d conversations and %d threads as read", totalMarked, threadsMarked),
+		Guidance: fmt.Sprintf("✅ Slack inbox cleared! (%d DMs, %d channels, %d threads)", dmMarked, channelMarked, threadsMarked),
 		NextActions: []string{
 			"See what's new: check-unreads",
 			"Catch up on important stuff: catch-up channel='general'",
 		},
-	}, nil
+	}
+	if len(errors) > 0 {
+		result.Guidance = fmt.Sprintf("⚠️ Marked %d DMs, %d channels, and %d threads, but %d could not be marked; see errors", dmMarked, channelMarked, threadsMarked, len(errors))
+	}
+	return result, nil
 }
 
 func showMarkAsReadOptions(ctx context.Context, apiProvider *provider.ApiProvider) (*FeatureResult, error) {
@@ -689,3 +853,131 @@ func showMarkAsReadOptions(ctx context.Context, apiProvider *provider.ApiProvide
 		},
 	}, nil
 }
+
+// bulkMarkOptions carries the filter, scope, preview mode, and exclusions
+// shared by the bulk targets (all-dms, all-channels, everything)
+type bulkMarkOptions struct {
+	filter   string
+	scope    string
+	preview  bool
+	labels   map[string]string // conversation ID → "#channel" or "@user"
+	excludes map[string]bool   // lowercased IDs, names, and handles
+}
+
+func newBulkMarkOptions(apiProvider *provider.ApiProvider, filter, scope string, preview bool, exclude []string) bulkMarkOptions {
+	opts := bulkMarkOptions{
+		filter:   filter,
+		scope:    scope,
+		preview:  preview,
+		labels:   map[string]string{},
+		excludes: map[string]bool{},
+	}
+	for _, e := range exclude {
+		opts.excludes[strings.ToLower(strings.TrimLeft(strings.TrimSpace(e), "#@"))] = true
+	}
+
+	if !preview && len(exclude) == 0 {
+		return opts
+	}
+	usersMap := apiProvider.ProvideUsersMap()
+	for _, ch := range apiProvider.GetCachedChannels() {
+		switch {
+		case ch.IsIM:
+			name := ch.User
+			if u, ok := usersMap[ch.User]; ok {
+				name = u.Name
+			}
+			opts.labels[ch.ID] = "@" + name
+		case ch.IsMpIM:
+			opts.labels[ch.ID] = ch.Name
+		default:
+			opts.labels[ch.ID] = "#" + ch.Name
+		}
+	}
+	return opts
+}
+
+// label names a conversation for display, falling back to its ID
+func (o bulkMarkOptions) label(id string) string {
+	if l, ok := o.labels[id]; ok {
+		return l
+	}
+	return id
+}
+
+// isExcluded reports whether the caller asked to leave a conversation unread,
+// by ID, channel name, or DM partner handle
+func (o bulkMarkOptions) isExcluded(id string) bool {
+	if len(o.excludes) == 0 {
+		return false
+	}
+	return o.excludes[strings.ToLower(id)] ||
+		o.excludes[strings.ToLower(strings.TrimLeft(o.label(id), "#@"))]
+}
+
+// entry describes a conversation a preview would mark
+func (o bulkMarkOptions) entry(id string, mentions int) map[string]interface{} {
+	e := map[string]interface{}{
+		"id":   id,
+		"name": o.label(id),
+	}
+	if mentions > 0 {
+		e["mentionCount"] = mentions
+	}
+	return e
+}
+
+// bulkMarkKinds names what each bulk target marks
+var bulkMarkKinds = map[string]string{
+	"all-dms":      "DMs",
+	"all-channels": "channels",
+	"everything":   "conversations",
+}
+
+// bulkMarkPreview reports what a bulk mark would do, without doing it
+func bulkMarkPreview(target string, opts bulkMarkOptions, pending []map[string]interface{}, skipped int, excluded []string) *FeatureResult {
+	result := &FeatureResult{
+		Success: true,
+		Data: map[string]interface{}{
+			"preview":      true,
+			"target":       target,
+			"wouldMark":    pending,
+			"skippedCount": skipped,
+			"excluded":     excluded,
+			"filter":       opts.filter,
+		},
+		Message:     fmt.Sprintf("Preview: would mark %d %s as read (nothing marked yet)", len(pending), bulkMarkKinds[target]),
+		ResultCount: len(pending),
+	}
+	if skipped > 0 {
+		result.Message += fmt.Sprintf(", %d skipped by filter '%s'", skipped, opts.filter)
+	}
+	if len(excluded) > 0 {
+		result.Message += fmt.Sprintf(", %d excluded", len(excluded))
+	}
+
+	if len(pending) == 0 {
+		result.Guidance = "Nothing would be marked"
+		return result
+	}
+	result.Guidance = "Review the list, then repeat the call without preview. Pass exclude=[...] with names or IDs to keep any of them unread."
+	result.NextActions = []string{
+		fmt.Sprintf("mark-read target='%s' filter='%s' exclude=['%s']", target, opts.filter, str(pending[0], "name")),
+	}
+	return result
+}
+
+// markTargets marks conversations read in one batch, returning how many
+// were marked and an error line for each that wasn't
+func markTargets(ctx context.Context, ic *provider.InternalClient, targets []provider.MarkTarget) (int, []string) {
+	marked := 0
+	errors := []string{}
+	for _, r := range ic.MarkConversations(ctx, targets) {
+		if r.Err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", r.ChannelID, r.Err))
+		} else {
+			marked++
+		}
+	}
+	return marked, errors
+}
@@ -0,0 +1,41 @@
+package features
+
+import "testing"
+
+func TestBulkMarkExclude(t *testing.T) {
+	opts := bulkMarkOptions{
+		labels: map[string]string{"C1": "#general", "D1": "@alice", "C2": "#random"},
+		excludes: map[string]bool{
+			"general": true,
+			"alice":   true,
+			"c9":      true,
+		},
+	}
+	cases := map[string]bool{"C1": true, "D1": true, "C2": false, "C9": true, "C3": false}
+	for id, want := range cases {
+		if got := opts.isExcluded(id); got != want {
+			t.Errorf("isExcluded(%s) = %v, want %v", id, got, want)
+		}
+	}
+	if got := opts.label("C3"); got != "C3" {
+		t.Errorf("unknown label = %q, want the ID", got)
+	}
+}
+
+func TestBulkMarkPreview(t *testing.T) {
+	opts := bulkMarkOptions{filter: "no-mentions", preview: true, labels: map[string]string{"C1": "#general"}}
+	pending := []map[string]interface{}{opts.entry("C1", 0), opts.entry("C2", 3)}
+
+	result := bulkMarkPreview("all-channels", opts, pending, 2, []string{"#random"})
+	data := result.Data.(map[string]interface{})
+	if data["preview"] != true || result.ResultCount != 2 {
+		t.Fatalf("preview result = %+v", result)
+	}
+	want := "Preview: would mark 2 channels as read (nothing marked yet), 2 skipped by filter 'no-mentions', 1 excluded"
+	if result.Message != want {
+		t.Errorf("message = %q, want %q", result.Message, want)
+	}
+	if pending[1]["name"] != "C2" || pending[1]["mentionCount"] != 3 {
+		t.Errorf("entry = %v", pending[1])
+	}
+}
@@ -0,0 +1,64 @@
+package features
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+// Mark-read scopes. Threads keep their own read pointers, separate from the
+// channel's, so clearing a thread badge takes subscriptions.thread.mark.
+const (
+	scopeMessagesOnly     = "messages-only"
+	scopeIncludingThreads = "including-threads"
+	scopeThreadsOnly      = "threads-only"
+)
+
+// threadViewLimit bounds how many subscribed threads one call inspects
+const threadViewLimit = 50
+
+// markUnreadThreads marks the unread threads from the Threads view as read
+// when include accepts their channel. Excluded conversations are left alone,
+// and in preview mode nothing is marked. Returns the affected threads, why
+// any of them couldn't be marked, and whether more unread threads remain
+// beyond the inspected page.
+func markUnreadThreads(ctx context.Context, ic *provider.InternalClient, opts bulkMarkOptions, include func(channelID string) bool) (threads []map[string]interface{}, failed []string, more bool, err error) {
+	view, err := ic.GetThreadView(ctx, threadViewLimit)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	for _, t := range view.Threads {
+		root := t.RootMsg
+		if len(t.UnreadReplies) == 0 || !include(root.Channel) || opts.isExcluded(root.Channel) {
+			continue
+		}
+
+		latest := ""
+		for _, r := range t.UnreadReplies {
+			if r.Ts > latest {
+				latest = r.Ts
+			}
+		}
+		for _, r := range t.LatestReplies {
+			if r.Ts > latest {
+				latest = r.Ts
+			}
+		}
+
+		entry := map[string]interface{}{
+			"threadId":      ThreadRef{ChannelID: root.Channel, ThreadTs: root.Ts}.String(),
+			"name":          opts.label(root.Channel),
+			"unreadReplies": len(t.UnreadReplies),
+		}
+		if !opts.preview {
+			if err := ic.MarkThreadRead(ctx, root.Channel, root.Ts, latest); err != nil {
+				failed = append(failed, fmt.Sprintf("thread %s: %v", entry["threadId"], err))
+				continue
+			}
+		}
+		threads = append(threads, entry)
+	}
+	return threads, failed, view.HasMore, nil
+}
@@ -0,0 +1,257 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+// SetMeetings records the user's meeting windows so catch-up can read
+// "since my last meeting" or "during my 2pm meeting". The server has no
+// calendar access; the user or a calendar MCP tool supplies the windows.
+var SetMeetings = &Feature{
+	Name:        "set-meetings",
+	Description: "Tell the server about your meetings (e.g. from a calendar tool) so catch-up can use since='last-meeting' or since='during:2pm'",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"meetings": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"title": map[string]interface{}{"type": "string"},
+						"start": map[string]interface{}{"type": "string", "description": "RFC3339 or 'YYYY-MM-DD HH:MM' local time"},
+						"end":   map[string]interface{}{"type": "string", "description": "RFC3339 or 'YYYY-MM-DD HH:MM' local time"},
+					},
+				},
+				"description": "Meeting windows with title, start, and end",
+			},
+			"replace": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Replace all known meetings instead of adding to them (default: true)",
+				"default":     true,
+			},
+			"clear": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Forget all meetings",
+				"default":     false,
+			},
+		},
+	},
+	Handler: setMeetingsHandler,
+}
+
+const (
+	meetingsCacheFile = "meetings.json"
+	// meetingRetention drops windows that are too old to be a catch-up anchor
+	meetingRetention = 7 * 24 * time.Hour
+)
+
+// meetingsMu serializes read-modify-write on the meetings file
+var meetingsMu sync.Mutex
+
+type meetingWindow struct {
+	Title string    `json:"title"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+var meetingTimeLayouts = []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02T15:04"}
+
+func parseMeetingTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range meetingTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q", s)
+}
+
+func setMeetingsHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	replace := true
+	if r, ok := params["replace"].(bool); ok {
+		replace = r
+	}
+	clear, _ := params["clear"].(bool)
+
+	var incoming []meetingWindow
+	raw, _ := params["meetings"].([]interface{})
+	for i, item := range raw {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		title, _ := m["title"].(string)
+		startStr, _ := m["start"].(string)
+		endStr, _ := m["end"].(string)
+		start, err := parseMeetingTime(startStr)
+		if err == nil {
+			var end time.Time
+			if end, err = parseMeetingTime(endStr); err == nil && !end.After(start) {
+				err = fmt.Errorf("end is not after start")
+			}
+			if err == nil {
+				incoming = append(incoming, meetingWindow{Title: strings.TrimSpace(title), Start: start, End: end})
+				continue
+			}
+		}
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Meeting %d: %v", i+1, err),
+			Guidance: "Give start and end as RFC3339 (2026-03-04T14:00:00-05:00) or 'YYYY-MM-DD HH:MM' local time",
+		}, nil
+	}
+	if !clear && len(incoming) == 0 {
+		return &FeatureResult{
+			Success:  false,
+			Message:  "No meetings given",
+			Guidance: "Pass meetings=[{title, start, end}], or clear=true to forget them",
+		}, nil
+	}
+
+	meetingsMu.Lock()
+	defer meetingsMu.Unlock()
+	var meetings []meetingWindow
+	if !clear && !replace {
+		meetings = loadMeetings(apiProvider)
+	}
+	meetings = pruneMeetings(append(meetings, incoming...), time.Now())
+	if store := apiProvider.Store(); store != nil {
+		if err := store.Save(meetingsCacheFile, meetings); err != nil {
+			log.Printf("Failed to save meetings: %v", err)
+		}
+	}
+
+	list := make([]map[string]interface{}, 0, len(meetings))
+	for _, m := range meetings {
+		list = append(list, map[string]interface{}{
+			"title": m.Title,
+			"start": formatTimestamp(m.Start),
+			"end":   formatTimestamp(m.End),
+		})
+	}
+	result := &FeatureResult{
+		Success:     true,
+		Data:        map[string]interface{}{"meetings": list},
+		Message:     fmt.Sprintf("%d meeting(s) known", len(meetings)),
+		ResultCount: len(meetings),
+	}
+	if len(meetings) > 0 {
+		result.Guidance = "catch-up accepts since='last-meeting' (since your last meeting ended) or since='during:2pm' / since='during:standup'"
+		result.NextActions = []string{"catch-up channel='...' since='last-meeting'"}
+	}
+	return result, nil
+}
+
+// loadMeetings reads the saved meeting windows. Caller must hold meetingsMu
+// when writing them back.
+func loadMeetings(p *provider.ApiProvider) []meetingWindow {
+	var meetings []meetingWindow
+	if store := p.Store(); store != nil {
+		_ = store.Load(meetingsCacheFile, &meetings)
+	}
+	return meetings
+}
+
+// pruneMeetings drops stale and duplicate windows and sorts by start
+func pruneMeetings(meetings []meetingWindow, now time.Time) []meetingWindow {
+	seen := map[string]bool{}
+	out := make([]meetingWindow, 0, len(meetings))
+	for _, m := range meetings {
+		key := m.Start.UTC().Format(time.RFC3339) + "|" + m.End.UTC().Format(time.RFC3339)
+		if seen[key] || now.Sub(m.End) > meetingRetention {
+			continue
+		}
+		seen[key] = true
+		out = append(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Start.Before(out[j].Start) })
+	return out
+}
+
+// meetingSince resolves calendar-relative catch-up windows:
+//
+//	last-meeting, since my last meeting   from the end of the last finished meeting
+//	during:2pm, during my 2pm meeting     the meeting starting at that time (most recent)
+//	during:standup                        the most recent meeting whose title matches
+//
+// ok is false when since isn't calendar-relative, so the caller falls back
+// to parseTimePeriod. latest is zero when the window is open-ended.
+func meetingSince(since string, meetings []meetingWindow, now time.Time) (oldest, latest time.Time, label string, ok bool, err error) {
+	s := strings.ToLower(strings.TrimSpace(since))
+	switch {
+	case strings.Contains(s, "last meeting") || strings.Contains(s, "last-meeting"):
+		for i := len(meetings) - 1; i >= 0; i-- {
+			if !meetings[i].End.After(now) {
+				m := meetings[i]
+				return m.End, time.Time{}, fmt.Sprintf("since %s ended", meetingLabel(m)), true, nil
+			}
+		}
+		return time.Time{}, time.Time{}, "", true, fmt.Errorf("no finished meeting is known")
+
+	case strings.HasPrefix(s, "during"):
+		q := strings.TrimSpace(strings.TrimPrefix(s, "during"))
+		q = strings.TrimSpace(strings.TrimPrefix(q, ":"))
+		q = strings.TrimSpace(strings.TrimPrefix(q, "my "))
+		q = strings.TrimSpace(strings.TrimSuffix(q, " meeting"))
+		if q == "" {
+			return time.Time{}, time.Time{}, "", true, fmt.Errorf("say which meeting, e.g. 'during:2pm'")
+		}
+		clock, clockErr := parseMeetingClock(q)
+		for i := len(meetings) - 1; i >= 0; i-- {
+			m := meetings[i]
+			if m.Start.After(now) {
+				continue
+			}
+			start := m.Start.In(now.Location())
+			match := strings.Contains(strings.ToLower(m.Title), q)
+			if clockErr == nil {
+				match = start.Hour()*60+start.Minute() == clock
+			}
+			if match {
+				latest := m.End
+				if latest.After(now) {
+					latest = time.Time{}
+				}
+				return m.Start, latest, fmt.Sprintf("during %s", meetingLabel(m)), true, nil
+			}
+		}
+		return time.Time{}, time.Time{}, "", true, fmt.Errorf("no known meeting matches %q", q)
+	}
+	return time.Time{}, time.Time{}, "", false, nil
+}
+
+// parseMeetingClock reads "2pm" or "2:30pm" as well as the "14:00" form
+// parseClock accepts, as minutes since midnight
+func parseMeetingClock(s string) (int, error) {
+	s = strings.ReplaceAll(s, " ", "")
+	for _, layout := range []string{"3pm", "3:04pm"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Hour()*60 + t.Minute(), nil
+		}
+	}
+	return parseClock(s)
+}
+
+func meetingLabel(m meetingWindow) string {
+	if m.Title != "" {
+		return fmt.Sprintf("'%s' (%s)", m.Title, m.Start.Format(time.Kitchen))
+	}
+	return "the " + m.Start.Format(time.Kitchen) + " meeting"
+}
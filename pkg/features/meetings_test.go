@@ -0,0 +1,55 @@
+package features
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMeetingSince(t *testing.T) {
+	day := time.Date(2026, 3, 4, 0, 0, 0, 0, time.Local)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	meetings := []meetingWindow{
+		{Title: "Standup", Start: at(9, 30), End: at(9, 45)},
+		{Title: "Design review", Start: at(14, 0), End: at(15, 0)},
+		{Title: "1:1", Start: at(16, 0), End: at(16, 30)},
+	}
+	now := at(16, 10)
+
+	oldest, latest, _, ok, err := meetingSince("since my last meeting", meetings, now)
+	if !ok || err != nil || !oldest.Equal(at(15, 0)) || !latest.IsZero() {
+		t.Fatalf("last meeting = %v, %v, %v, %v; want 15:00 open-ended", oldest, latest, ok, err)
+	}
+
+	oldest, latest, _, _, err = meetingSince("during my 2pm meeting", meetings, now)
+	if err != nil || !oldest.Equal(at(14, 0)) || !latest.Equal(at(15, 0)) {
+		t.Fatalf("during 2pm = %v-%v, %v", oldest, latest, err)
+	}
+
+	oldest, _, _, _, err = meetingSince("during:standup", meetings, now)
+	if err != nil || !oldest.Equal(at(9, 30)) {
+		t.Fatalf("during standup = %v, %v", oldest, err)
+	}
+
+	// A meeting still in progress has no upper bound yet
+	_, latest, _, _, err = meetingSince("during:16:00", meetings, now)
+	if err != nil || !latest.IsZero() {
+		t.Fatalf("during ongoing = %v, %v; want open-ended", latest, err)
+	}
+
+	if _, _, _, ok, err := meetingSince("during:3pm", meetings, now); !ok || err == nil {
+		t.Fatal("expected an error for a time with no meeting")
+	}
+	if _, _, _, ok, _ := meetingSince("3d", meetings, now); ok {
+		t.Fatal("'3d' is not calendar-relative")
+	}
+}
+
+func TestPruneMeetingsDropsStaleAndDuplicates(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	m := meetingWindow{Start: now.Add(-2 * time.Hour), End: now.Add(-time.Hour)}
+	old := meetingWindow{Start: now.AddDate(0, 0, -9), End: now.AddDate(0, 0, -9).Add(time.Hour)}
+	got := pruneMeetings([]meetingWindow{m, old, m}, now)
+	if len(got) != 1 {
+		t.Fatalf("pruneMeetings kept %d, want 1", len(got))
+	}
+}
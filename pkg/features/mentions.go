@@ -28,6 +28,17 @@ var CheckMyMentions = &Feature{
 				"description": "How far back to check (e.g., '1d', '3d', '1w')",
 				"default":     "3d",
 			},
+			"mode": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"search", "scan"},
+				"description": "'search' (default) finds mentions with a few search requests; 'scan' reads every channel's history",
+				"default":     "search",
+			},
+			"channels": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Only scan these channels (names or IDs); implies mode='scan'. Without it a scan reads your channels starred first, then by priority and recent activity.",
+			},
 			"cursor": map[string]interface{}{
 				"type":        "string",
 				"description": "Pagination cursor from previous request",
@@ -37,6 +48,17 @@ var CheckMyMentions = &Feature{
 				"description": "Maximum mentions per page (default: 20, max: 50)",
 				"default":     20,
 			},
+			"exportTasks": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"none", "ics", "json"},
+				"description": "Also write the open requests and questions on this page as action items: 'ics' (iCalendar VTODOs) or 'json' (tasks.json) for a task manager",
+				"default":     "none",
+			},
+			"exportDir": map[string]interface{}{
+				"type":        "string",
+				"description": "Directory for the task export. Defaults to ~/Downloads.",
+			},
+			"estimate": estimateParam,
 		},
 	},
 	Handler: checkMentionsReal,
@@ -62,7 +84,7 @@ func checkMentionsHandler(ctx context.Context, params map[string]interface{}) (*
 			"author":    "lead.dev",
 			"message":   "@you Can you review the auth PR before EOD? Blocking deployment",
 			"timestamp": "2 hours ago",
-			"threadId":  "1234.5678",
+			"threadId":  "C01ENGINEERING:1234.5678",
 			"responded": false,
 			"context":   "Part of critical security update discussion",
 		},
@@ -73,7 +95,7 @@ func checkMentionsHandler(ctx context.Context, params map[string]interface{}) (*
 			"author":    "pm.sarah",
 			"message":   "@you What's your estimate for the user dashboard feature?",
 			"timestamp": "Yesterday at 3:30 PM",
-			"threadId":  "1234.5679",
+			"threadId":  "C01PRODUCT:1234.5679",
 			"responded": false,
 			"context":   "Q2 planning thread with multiple stakeholders",
 		},
@@ -125,7 +147,7 @@ func checkMentionsHandler(ctx context.Context, params map[string]interface{}) (*
 		},
 		Message: fmt.Sprintf("You have %d unread mentions (%d urgent)", len(filteredMentions), urgentCount),
 		NextActions: []string{
-			"Use 'search' with threadId to see full context",
+			"Use 'read-thread' with threadId to see full context",
 			"Use 'catch-up' to see related discussions",
 		},
 		Guidance:    "🚨 You have 1 urgent mention about a blocking PR review that needs immediate attention",
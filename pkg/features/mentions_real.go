@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"github.com/aaronsb/slack-mcp/pkg/provider"
 	"github.com/slack-go/slack"
+	"sort"
 	"strings"
+	"time"
 )
 
 // checkMentionsReal provides real implementation by scanning channels
@@ -34,6 +36,17 @@ func checkMentionsReal(ctx context.Context, params map[string]interface{}) (*Fea
 		}
 	}
 
+	mode := "search"
+	if m, ok := params["mode"].(string); ok && m != "" {
+		mode = m
+	}
+
+	// Naming channels scopes the tool to a scan of just those
+	requested := stringList(params["channels"])
+	if len(requested) > 0 {
+		mode = "scan"
+	}
+
 	// Get the API provider
 	provider, ok := params["_provider"].(*provider.ApiProvider)
 	if !ok {
@@ -53,14 +66,19 @@ func checkMentionsReal(ctx context.Context, params map[string]interface{}) (*Fea
 	}
 
 	// Get current user info
-	authTest, err := api.AuthTest()
+	self, err := provider.Self()
 	if err != nil {
 		return &FeatureResult{
 			Success: false,
 			Message: fmt.Sprintf("Failed to get user info: %v", err),
 		}, nil
 	}
-	currentUserID := authTest.UserID
+	currentUserID := self.UserID
+
+	// Bot tokens can't search; scan the channels the bot is in instead
+	if mode == "search" && provider.TokenType() == "bot" {
+		mode = "scan"
+	}
 
 	// Parse time period
 	oldest, err := parseTimePeriod(timeframe)
@@ -71,20 +89,39 @@ func checkMentionsReal(ctx context.Context, params map[string]interface{}) (*Fea
 		}, nil
 	}
 
-	// Get list of channels user is member of
-	channels, _, err := api.GetConversations(&slack.GetConversationsParameters{
-		Types: []string{"public_channel", "private_channel", "mpim", "im"},
-		Limit: 200,
-	})
+	if estimate, _ := params["estimate"].(bool); estimate {
+		return estimateMentions(ctx, provider, api, self, mode, timeframe), nil
+	}
+
+	if mode != "scan" {
+		if result, ok := checkMentionsViaSearch(ctx, provider, api, self, timeframe, oldest, urgencyFilter, includeResolved, limit); ok {
+			return withTaskExport(result, params), nil
+		}
+	}
+
+	channels, notFound, err := mentionScanTargets(provider, api, requested)
 	if err != nil {
 		return &FeatureResult{
 			Success: false,
 			Message: fmt.Sprintf("Failed to get channels: %v", err),
 		}, nil
 	}
+	if len(channels) == 0 && len(notFound) > 0 {
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Could not find channels: %s", strings.Join(notFound, ", ")),
+			Guidance: "Use 'list-channels' to see available channels",
+		}, nil
+	}
+
+	// Scan order is fixed so the same channels make the cut each time:
+	// starred, then priority, then most recently active
+	starred, _ := provider.StarredConversations(ctx)
+	orderScanChannels(channels, starred, loadPriorityChannels(), loadChannelTaxonomy(), recentChannelActivity(ctx, provider))
 
 	// Scan channels for mentions
 	mentions := []map[string]interface{}{}
+	var links permalinkBatch
 	channelSet := make(map[string]bool)
 	urgentCount := 0
 	needsResponse := 0
@@ -93,10 +130,27 @@ func checkMentionsReal(ctx context.Context, params map[string]interface{}) (*Fea
 	usersMap := provider.ProvideUsersMap()
 	mentionPattern := fmt.Sprintf("<@%s>", currentUserID)
 
-	// Limit channels to scan based on activity
-	for _, channel := range channels {
-		if totalScanned >= 10 && len(mentions) >= limit {
-			break // Stop if we have enough mentions
+	// Channels read, and those left unread by when the scan stopped or a
+	// read failed
+	covered := []string{}
+	var unscanned []string
+	var unreadable skippedChannels
+	scanName := func(ch slack.Channel) string {
+		if ch.Name != "" {
+			return "#" + ch.Name
+		}
+		return ch.ID
+	}
+
+	for i, channel := range channels {
+		if totalScanned >= mentionScanMinChannels && len(mentions) >= limit {
+			// Stop if we have enough mentions
+			for _, rest := range channels[i:] {
+				if !rest.IsArchived {
+					unscanned = append(unscanned, scanName(rest))
+				}
+			}
+			break
 		}
 
 		// Skip archived channels
@@ -113,10 +167,13 @@ func checkMentionsReal(ctx context.Context, params map[string]interface{}) (*Fea
 
 		resp, err := api.GetConversationHistoryContext(ctx, histParams)
 		if err != nil {
-			continue // Skip channels we can't access
+			// Skip channels we can't access
+			unreadable.skip(provider, channel.ID, err)
+			continue
 		}
 
 		totalScanned++
+		covered = append(covered, scanName(channel))
 
 		// Look for mentions in messages
 		for _, msg := range resp.Messages {
@@ -175,16 +232,22 @@ func checkMentionsReal(ctx context.Context, params map[string]interface{}) (*Fea
 				"type":      msgType,
 				"channel":   channelName,
 				"author":    authorName,
-				"message":   msg.Text,
+				"message":   messageBody(msg.Text, msg.Blocks, msg.Attachments),
 				"timestamp": formatTimestamp(msgTime),
-				"threadId":  fmt.Sprintf("%s:%s", channel.ID, msg.Timestamp),
+				"threadId":  threadRefFor(channel.ID, msg.Timestamp, msg.ThreadTimestamp).String(),
+				"messageTs": msg.Timestamp,
 				"responded": responded,
 				"context":   fmt.Sprintf("Channel: #%s", channelName),
 			}
+			if starred[channel.ID] {
+				mention["starred"] = true
+			}
+			addAttachmentInfo(mention, "message", msg.Files, msg.Attachments, msg.Blocks)
 
 			// Apply urgency filter
 			if urgencyFilter == "all" || urgencyFilter == urgency {
 				mentions = append(mentions, mention)
+				links.add(mention, channel.ID, msg.Timestamp)
 				if len(mentions) >= limit {
 					break
 				}
@@ -192,23 +255,31 @@ func checkMentionsReal(ctx context.Context, params map[string]interface{}) (*Fea
 		}
 	}
 
+	links.resolve(ctx, provider)
+
 	// Build channels list
 	channelsList := []string{}
 	for ch := range channelSet {
 		channelsList = append(channelsList, ch)
 	}
 
+	summary := map[string]interface{}{
+		"total":           len(mentions),
+		"urgent":          urgentCount,
+		"needsResponse":   needsResponse,
+		"channels":        channelsList,
+		"channelsScanned": totalScanned,
+		"covered":         covered,
+		"skipped":         append(append([]string{}, unscanned...), unreadable.names...),
+	}
+	if len(notFound) > 0 {
+		summary["notFound"] = notFound
+	}
 	result := &FeatureResult{
 		Success: true,
 		Data: map[string]interface{}{
 			"mentions": mentions,
-			"summary": map[string]interface{}{
-				"total":           len(mentions),
-				"urgent":          urgentCount,
-				"needsResponse":   needsResponse,
-				"channels":        channelsList,
-				"channelsScanned": totalScanned,
-			},
+			"summary":  summary,
 		},
 		Message:     fmt.Sprintf("Found %d mentions across %d channels", len(mentions), totalScanned),
 		ResultCount: len(mentions),
@@ -224,16 +295,27 @@ func checkMentionsReal(ctx context.Context, params map[string]interface{}) (*Fea
 	}
 
 	result.NextActions = []string{
-		"Use 'search' with threadId to see full thread context",
+		"Use 'read-thread' with threadId to see full thread context",
 		"Use 'catch-up' to see activity in specific channels",
 	}
 
 	if totalScanned < len(channels) {
 		result.NextActions = append(result.NextActions,
-			fmt.Sprintf("Note: Scanned %d of %d channels. Some mentions might be in unscanned channels.", totalScanned, len(channels)))
+			fmt.Sprintf("Note: Scanned %d of %d channels. Scope the scan with channels=['...'] to cover the rest.", totalScanned, len(channels)))
+	}
+	if len(notFound) > 0 {
+		result.Guidance = strings.TrimSpace(result.Guidance + " Couldn't find: " + strings.Join(notFound, ", "))
 	}
+	if len(unscanned) > 0 {
+		result.MarkPartial(Coverage{
+			Reason:  PartialScanLimit,
+			Detail:  fmt.Sprintf("stopped after %d channels once %d mentions were found", totalScanned, limit),
+			Skipped: unscanned,
+		}.scannedRange(oldest, time.Now()))
+	}
+	unreadable.mark(result, "some channels couldn't be read")
 
-	return result, nil
+	return withTaskExport(result, params), nil
 }
 
 // checkIfUserReplied checks if user has replied in a thread
@@ -326,3 +408,84 @@ func categorizeMessageType(text string) string {
 
 	return "mention"
 }
+
+// mentionScanMinChannels is how many channels a scan reads before it may
+// stop for having found enough mentions
+const mentionScanMinChannels = 10
+
+// mentionScanTargets lists the conversations a mention scan reads: the
+// requested ones, else every open conversation the user belongs to.
+// Requested names that don't resolve are returned as notFound.
+func mentionScanTargets(ap *provider.ApiProvider, api *slack.Client, requested []string) (targets []slack.Channel, notFound []string, err error) {
+	cached := ap.GetCachedChannels()
+	if len(requested) > 0 {
+		byID := make(map[string]slack.Channel, len(cached))
+		for _, ch := range cached {
+			byID[ch.ID] = ch
+		}
+		seen := map[string]bool{}
+		for _, name := range requested {
+			id := ap.ResolveChannelID(strings.TrimPrefix(name, "#"))
+			if !isChannelID(id) {
+				notFound = append(notFound, name)
+				continue
+			}
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			ch, ok := byID[id]
+			if !ok {
+				ch.ID = id
+			}
+			targets = append(targets, ch)
+		}
+		return targets, notFound, nil
+	}
+
+	for _, ch := range cached {
+		if (ch.IsMember || ch.IsIM || ch.IsMpIM) && !ch.IsArchived {
+			targets = append(targets, ch)
+		}
+	}
+	if len(targets) > 0 {
+		return targets, nil, nil
+	}
+
+	// Channel cache not loaded yet
+	targets, _, err = api.GetConversations(&slack.GetConversationsParameters{
+		Types:           []string{"public_channel", "private_channel", "mpim", "im"},
+		Limit:           200,
+		ExcludeArchived: true,
+	})
+	return targets, nil, err
+}
+
+// orderScanChannels sorts scan targets starred first, then by priority
+// (SLACK_MCP_PRIORITY_CHANNELS and the channel taxonomy), then by latest
+// activity, then by name, so a scan that stops early is repeatable
+func orderScanChannels(channels []slack.Channel, starred, priorityChannels map[string]bool,
+	taxonomy []taxonomyRule, activity map[string]time.Time) {
+	priority := make(map[string]float64, len(channels))
+	for _, ch := range channels {
+		if ch.Name != "" {
+			priority[ch.ID] = channelPriority(ch.Name, priorityChannels, taxonomy)
+		}
+	}
+	sort.SliceStable(channels, func(i, j int) bool {
+		a, b := channels[i], channels[j]
+		if starred[a.ID] != starred[b.ID] {
+			return starred[a.ID]
+		}
+		if priority[a.ID] != priority[b.ID] {
+			return priority[a.ID] > priority[b.ID]
+		}
+		if ta, tb := activity[a.ID], activity[b.ID]; !ta.Equal(tb) {
+			return ta.After(tb)
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+}
@@ -0,0 +1,40 @@
+package features
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+func TestOrderScanChannels(t *testing.T) {
+	ch := func(id, name string) slack.Channel {
+		var c slack.Channel
+		c.ID, c.Name = id, name
+		return c
+	}
+	now := time.Now()
+	rules, _ := parseChannelTaxonomy("inc-*=incident,social-*=low")
+	channels := []slack.Channel{
+		ch("C6", "social-pets"),
+		ch("C5", "beta"),
+		ch("C4", "alpha"),
+		ch("C3", "busy"),
+		ch("C2", "inc-db"),
+		ch("C1", "favorite"),
+		ch("D1", ""),
+	}
+	activity := map[string]time.Time{
+		"C3": now,
+		"D1": now.Add(-time.Hour),
+		"C6": now,
+	}
+	orderScanChannels(channels, map[string]bool{"C1": true}, map[string]bool{}, rules, activity)
+
+	want := []string{"C1", "C2", "C3", "D1", "C4", "C5", "C6"}
+	for i, c := range channels {
+		if c.ID != want[i] {
+			t.Fatalf("position %d: got %s, want %s (order %v)", i, c.ID, want[i], channels)
+		}
+	}
+}
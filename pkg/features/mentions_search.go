@@ -0,0 +1,328 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+const (
+	// mentionSearchPages bounds search.messages calls per query
+	mentionSearchPages = 2
+	// mentionGapChannels caps history fetches for channels whose unread
+	// mentions the search index hasn't caught up with yet
+	mentionGapChannels = 5
+)
+
+// mentionCandidate is a message mentioning the user, from search or history
+type mentionCandidate struct {
+	channelID   string
+	channelName string
+	user        string
+	text        string
+	ts          string
+	threadTs    string
+	permalink   string
+	blocks      slack.Blocks
+	attachments []slack.Attachment
+	files       []slack.File
+}
+
+// checkMentionsViaSearch finds mentions with search.messages ("@handle" and
+// "to:@me") instead of scanning channel histories, then merges in unread
+// state from client.counts: mentions past a channel's read pointer are
+// flagged unread, and channels with unread mentions the search index hasn't
+// picked up yet get a targeted history read. Returns false if search is
+// unavailable so the caller can fall back to scanning.
+func checkMentionsViaSearch(ctx context.Context, ap *provider.ApiProvider, api *slack.Client, self *provider.SelfInfo,
+	timeframe string, oldest time.Time, urgencyFilter string, includeResolved bool, limit int) (*FeatureResult, bool) {
+	window := parseTimeframeToDateFilter(timeframe)
+
+	var candidates []mentionCandidate
+	seen := map[string]bool{}
+	searches := 0
+	var coverage []Coverage
+	collect := func(query string) error {
+		for page := 1; page <= mentionSearchPages; page++ {
+			sp := slack.NewSearchParameters()
+			sp.Sort = "timestamp"
+			sp.Count = 100
+			sp.Page = page
+			res, err := api.SearchMessagesContext(ctx, query, sp)
+			searches++
+			if err != nil {
+				return err
+			}
+			for _, m := range res.Matches {
+				key := m.Channel.ID + "/" + m.Timestamp
+				if seen[key] || m.User == self.UserID || parseSlackTimestamp(m.Timestamp).Before(oldest) {
+					continue
+				}
+				seen[key] = true
+				c := mentionCandidate{
+					channelID:   m.Channel.ID,
+					channelName: m.Channel.Name,
+					user:        m.User,
+					text:        m.Text,
+					ts:          m.Timestamp,
+					permalink:   m.Permalink,
+					blocks:      m.Blocks,
+					attachments: m.Attachments,
+				}
+				if ref, err := parseThreadRef(m.Permalink); err == nil && ref.ThreadTs != m.Timestamp {
+					c.threadTs = ref.ThreadTs
+				}
+				candidates = append(candidates, c)
+			}
+			if res.Paging.Pages <= page {
+				break
+			}
+			if page == mentionSearchPages {
+				c := Coverage{
+					Reason: PartialMaxPages,
+					Detail: fmt.Sprintf("read %d of %d search pages for %q", page, res.Paging.Pages, query),
+				}
+				if n := len(res.Matches); n > 0 {
+					c = c.scannedRange(parseSlackTimestamp(res.Matches[n-1].Timestamp), time.Now())
+				}
+				coverage = append(coverage, c)
+			}
+		}
+		return nil
+	}
+
+	if err := collect("@" + self.User + " " + window); err != nil {
+		log.Printf("Mention search failed, falling back to channel scan: %v", err)
+		return nil, false
+	}
+	if err := collect("to:@me " + window); err != nil {
+		log.Printf("DM mention search failed: %v", err)
+		coverage = append(coverage, Coverage{Reason: stopReason(err), Detail: "the DM mention search failed, so direct messages may be missing"})
+	}
+
+	// Unread state: read pointers and channels with unread mentions
+	lastRead := map[string]string{}
+	unreadMentions := map[string]int{}
+	if ic := ap.ProvideInternalClient(); ic != nil {
+		if counts, err := ic.GetClientCounts(ctx); err == nil && counts.OK {
+			for _, ch := range counts.Channels {
+				lastRead[ch.ID] = ch.LastRead
+				unreadMentions[ch.ID] = ch.MentionCount
+			}
+			for _, ch := range counts.MPIMs {
+				lastRead[ch.ID] = ch.LastRead
+				unreadMentions[ch.ID] = ch.MentionCount
+			}
+			for _, ch := range counts.IMs {
+				lastRead[ch.ID] = ch.LastRead
+			}
+		}
+	}
+
+	found := map[string]bool{}
+	for _, c := range candidates {
+		found[c.channelID] = true
+	}
+	var gaps []string
+	for id, n := range unreadMentions {
+		if n > 0 && !found[id] {
+			gaps = append(gaps, id)
+		}
+	}
+	sort.Strings(gaps)
+	if len(gaps) > mentionGapChannels {
+		var skipped []string
+		for _, id := range gaps[mentionGapChannels:] {
+			skipped = append(skipped, channelLabel(ap, id))
+		}
+		coverage = append(coverage, Coverage{
+			Reason:  PartialScanLimit,
+			Detail:  fmt.Sprintf("unread mentions not yet in search were only looked up in %d channels", mentionGapChannels),
+			Skipped: skipped,
+		})
+		gaps = gaps[:mentionGapChannels]
+	}
+	var unreadable skippedChannels
+	mentionPattern := fmt.Sprintf("<@%s>", self.UserID)
+	for _, id := range gaps {
+		resp, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: id,
+			Oldest:    lastRead[id],
+			Limit:     50,
+		})
+		if err != nil {
+			unreadable.skip(ap, id, err)
+			continue
+		}
+		name := ap.ResolveChannelName(ctx, id)
+		for _, msg := range resp.Messages {
+			if msg.User == self.UserID || !strings.Contains(msg.Text, mentionPattern) {
+				continue
+			}
+			candidates = append(candidates, mentionCandidate{
+				channelID:   id,
+				channelName: name,
+				user:        msg.User,
+				text:        msg.Text,
+				ts:          msg.Timestamp,
+				threadTs:    msg.ThreadTimestamp,
+				blocks:      msg.Blocks,
+				attachments: msg.Attachments,
+				files:       msg.Files,
+			})
+		}
+	}
+
+	// My own posts tell which mentions were answered: a reply in the
+	// mentioning thread, or a later message in the same DM
+	replied := map[string]bool{}
+	lastInDM := map[string]string{}
+	sp := slack.NewSearchParameters()
+	sp.Sort = "timestamp"
+	sp.Count = 100
+	if mine, err := api.SearchMessagesContext(ctx, "from:@me "+window, sp); err == nil {
+		searches++
+		for _, m := range mine.Matches {
+			if ref, err := parseThreadRef(m.Permalink); err == nil && ref.ThreadTs != m.Timestamp {
+				replied[m.Channel.ID+"/"+ref.ThreadTs] = true
+			}
+			if strings.HasPrefix(m.Channel.ID, "D") && m.Timestamp > lastInDM[m.Channel.ID] {
+				lastInDM[m.Channel.ID] = m.Timestamp
+			}
+		}
+	}
+
+	// Mentions in starred conversations lead, newest first within each
+	starred, _ := ap.StarredConversations(ctx)
+	sort.Slice(candidates, func(i, j int) bool {
+		if si, sj := starred[candidates[i].channelID], starred[candidates[j].channelID]; si != sj {
+			return si
+		}
+		return candidates[i].ts > candidates[j].ts
+	})
+
+	usersMap := ap.ProvideUsersMap()
+	mentions := []map[string]interface{}{}
+	var links permalinkBatch
+	channelSet := map[string]bool{}
+	urgentCount, needsResponse, unreadCount := 0, 0, 0
+
+	for _, c := range candidates {
+		if len(mentions) >= limit {
+			break
+		}
+		threadKey := c.threadTs
+		if threadKey == "" {
+			threadKey = c.ts
+		}
+		responded := replied[c.channelID+"/"+threadKey] || lastInDM[c.channelID] > c.ts
+		if responded && !includeResolved {
+			continue
+		}
+
+		authorName := "unknown"
+		isBot := false
+		if user, ok := usersMap[c.user]; ok {
+			authorName = user.Name
+			if user.RealName != "" {
+				authorName = user.RealName
+			}
+			isBot = user.IsBot
+		}
+		channelName := c.channelName
+		if channelName == "" {
+			channelName = ap.ResolveChannelName(ctx, c.channelID)
+		}
+
+		urgency := categorizeUrgencyForUser(c.text, isBot)
+		msgType := categorizeMessageType(c.text)
+		if urgencyFilter != "all" && urgencyFilter != urgency {
+			continue
+		}
+		if urgency == "high" {
+			urgentCount++
+		}
+		if !responded && (msgType == "direct_question" || msgType == "request") {
+			needsResponse++
+		}
+		unread := false
+		if lr, ok := lastRead[c.channelID]; ok && c.ts > lr {
+			unread = true
+			unreadCount++
+		}
+
+		mention := map[string]interface{}{
+			"urgency":   urgency,
+			"type":      msgType,
+			"channel":   channelName,
+			"author":    authorName,
+			"message":   messageBody(c.text, c.blocks, c.attachments),
+			"timestamp": formatTimestamp(parseSlackTimestamp(c.ts)),
+			"threadId":  threadRefFor(c.channelID, c.ts, c.threadTs).String(),
+			"messageTs": c.ts,
+			"responded": responded,
+			"unread":    unread,
+			"context":   fmt.Sprintf("Channel: #%s", channelName),
+		}
+		if starred[c.channelID] {
+			mention["starred"] = true
+		}
+		addAttachmentInfo(mention, "message", c.files, c.attachments, c.blocks)
+		if c.permalink != "" {
+			mention["permalink"] = c.permalink
+		} else {
+			links.add(mention, c.channelID, c.ts)
+		}
+		channelSet[channelName] = true
+		mentions = append(mentions, mention)
+	}
+	links.resolve(ctx, ap)
+
+	channelsList := make([]string, 0, len(channelSet))
+	for ch := range channelSet {
+		channelsList = append(channelsList, ch)
+	}
+	sort.Strings(channelsList)
+
+	result := &FeatureResult{
+		Success: true,
+		Data: map[string]interface{}{
+			"mentions": mentions,
+			"summary": map[string]interface{}{
+				"total":           len(mentions),
+				"urgent":          urgentCount,
+				"needsResponse":   needsResponse,
+				"unread":          unreadCount,
+				"channels":        channelsList,
+				"source":          "search",
+				"searchRequests":  searches,
+				"channelsScanned": len(gaps),
+			},
+		},
+		Message:     fmt.Sprintf("Found %d mentions across %d channels", len(mentions), len(channelsList)),
+		ResultCount: len(mentions),
+	}
+
+	if urgentCount > 0 {
+		result.Guidance = fmt.Sprintf("🚨 You have %d urgent mention(s) that need immediate attention", urgentCount)
+	} else if needsResponse > 0 {
+		result.Guidance = fmt.Sprintf("📋 You have %d mention(s) that need a response", needsResponse)
+	} else if len(mentions) == 0 {
+		result.Guidance = "✅ No pending mentions found"
+	}
+	result.NextActions = []string{
+		"Use 'read-thread' with threadId to see full thread context",
+		"Use 'catch-up' to see activity in specific channels",
+	}
+	for _, c := range coverage {
+		result.MarkPartial(c)
+	}
+	unreadable.mark(result, "channels with unread mentions couldn't be read")
+	return result, true
+}
@@ -0,0 +1,172 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// CheckMessageReach reports how far a message travelled: reactions,
+// replies, and how much of the channel engaged with it. Slack exposes no
+// per-message view counts, so engagement is measured against membership.
+var CheckMessageReach = &Feature{
+	Name:        "check-message-reach",
+	Description: "See whether a message you sent was noticed: reactions, replies, who engaged, and what share of the channel that is",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel": map[string]interface{}{
+				"type":        "string",
+				"description": "Channel name or ID containing the message",
+			},
+			"messageTs": map[string]interface{}{
+				"type":        "string",
+				"description": "Timestamp of the message",
+			},
+		},
+		"required": []string{"channel", "messageTs"},
+	},
+	Handler: checkMessageReachHandler,
+}
+
+func checkMessageReachHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	channel, _ := params["channel"].(string)
+	messageTs, _ := params["messageTs"].(string)
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	channelID := resolveChannelForSending(apiProvider, api, channel)
+	if channelID == "" {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Could not find channel '%s'", channel),
+		}, nil
+	}
+
+	// conversations.replies returns the message itself first, whether it's a
+	// thread root, a reply, or a plain message
+	msgs, _, _, err := api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: channelID,
+		Timestamp: messageTs,
+		Limit:     200,
+	})
+	if err != nil || len(msgs) == 0 {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Could not load message %s: %v", messageTs, err),
+		}, nil
+	}
+	msg := msgs[0]
+	if msg.Timestamp != messageTs {
+		// The ts was a reply; find it among the thread
+		for _, m := range msgs {
+			if m.Timestamp == messageTs {
+				msg = m
+				break
+			}
+		}
+	}
+
+	usersMap := apiProvider.ProvideUsersMap()
+	engaged := map[string]bool{}
+
+	reactions := make([]map[string]interface{}, 0, len(msg.Reactions))
+	totalReactions := 0
+	for _, r := range msg.Reactions {
+		names := make([]string, 0, len(r.Users))
+		for _, u := range r.Users {
+			engaged[u] = true
+			names = append(names, userDisplayName(u, usersMap))
+		}
+		totalReactions += r.Count
+		reactions = append(reactions, map[string]interface{}{
+			"emoji": r.Name,
+			"count": r.Count,
+			"users": names,
+		})
+	}
+	sort.Slice(reactions, func(i, j int) bool {
+		return reactions[i]["count"].(int) > reactions[j]["count"].(int)
+	})
+
+	replyCount := 0
+	var repliers []string
+	seenRepliers := map[string]bool{}
+	if msg.Timestamp == msg.ThreadTimestamp || msg.ThreadTimestamp == "" {
+		for _, m := range msgs[1:] {
+			replyCount++
+			if m.User != msg.User && !seenRepliers[m.User] {
+				seenRepliers[m.User] = true
+				engaged[m.User] = true
+				repliers = append(repliers, userDisplayName(m.User, usersMap))
+			}
+		}
+	}
+	delete(engaged, msg.User)
+
+	data := map[string]interface{}{
+		"channel":    apiProvider.ResolveChannelName(ctx, channelID),
+		"messageTs":  msg.Timestamp,
+		"text":       msg.Text,
+		"author":     userDisplayName(msg.User, usersMap),
+		"reactions":  reactions,
+		"replyCount": replyCount,
+		"repliers":   repliers,
+		"engaged":    len(engaged),
+		"views":      "unavailable",
+	}
+
+	members := 0
+	if info, err := apiProvider.GetChannelInfo(ctx, channelID); err == nil {
+		members = info.NumMembers
+	}
+	if members > 1 {
+		audience := members - 1
+		data["audience"] = audience
+		data["engagementRate"] = fmt.Sprintf("%.0f%%", 100*float64(len(engaged))/float64(audience))
+	}
+
+	result := &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("%d reactions, %d replies, %d people engaged", totalReactions, replyCount, len(engaged)),
+		Data:    data,
+	}
+
+	if id := apiProvider.ProvideIdentity(); id != nil && msg.User != id.UserID {
+		result.Guidance = "Note: this message wasn't sent by you. "
+	}
+	switch {
+	case len(engaged) == 0:
+		result.Guidance += "No visible engagement yet. Slack doesn't expose view counts, so silence doesn't mean unseen — consider a reminder in the thread or pinging key people."
+		result.NextActions = []string{
+			fmt.Sprintf("Follow up in thread: send-message channel='%s' threadTs='%s'", channel, msg.Timestamp),
+		}
+	case members > 1 && float64(len(engaged))/float64(members-1) < 0.1:
+		result.Guidance += "Some engagement, but from a small share of the channel."
+	default:
+		result.Guidance += "The message is getting attention."
+	}
+	if replyCount > 0 {
+		result.NextActions = append(result.NextActions,
+			fmt.Sprintf("Read replies: read-thread threadId='%s'", ThreadRef{ChannelID: channelID, ThreadTs: msg.Timestamp}))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,62 @@
+package features
+
+import (
+	"log"
+	"os"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// Workflow Builder posts as bot_message, so without special handling its
+// form submissions and reminders look like any other integration noise.
+//
+//	SLACK_MCP_WORKFLOW_MESSAGES="normal"   "important", "normal" (default), or "skip"
+//
+// "important" always surfaces workflow messages in catch-up, "skip" never
+// does, and "normal" scores them like any other message.
+const (
+	workflowImportant = "important"
+	workflowNormal    = "normal"
+	workflowSkip      = "skip"
+)
+
+// Message sources reported by classifySource
+const (
+	sourceWorkflow = "workflow"
+	sourceBot      = "bot"
+)
+
+// workflowPolicy returns the configured handling of workflow messages,
+// letting a per-call value override the environment
+func workflowPolicy(override string) string {
+	for _, v := range []string{override, os.Getenv("SLACK_MCP_WORKFLOW_MESSAGES")} {
+		switch v := strings.ToLower(strings.TrimSpace(v)); v {
+		case workflowImportant, workflowNormal, workflowSkip:
+			return v
+		case "":
+		default:
+			log.Printf("Ignoring unknown workflow message policy %q", v)
+		}
+	}
+	return workflowNormal
+}
+
+// classifySource reports whether a message came from a workflow, another
+// bot or integration, or a person (""). The second value names the
+// workflow or bot when Slack provides it.
+func classifySource(msg slack.Message) (string, string) {
+	name := msg.Username
+	if msg.BotProfile != nil && msg.BotProfile.Name != "" {
+		name = msg.BotProfile.Name
+	}
+
+	if strings.Contains(strings.ToLower(msg.Metadata.EventType), "workflow") ||
+		strings.Contains(strings.ToLower(name), "workflow") {
+		return sourceWorkflow, name
+	}
+	if msg.SubType == slack.MsgSubTypeBotMessage || msg.BotID != "" {
+		return sourceBot, name
+	}
+	return "", ""
+}
@@ -0,0 +1,63 @@
+package features
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestClassifySource(t *testing.T) {
+	cases := []struct {
+		name       string
+		msg        slack.Message
+		wantSource string
+		wantName   string
+	}{
+		{"person", slack.Message{Msg: slack.Msg{User: "U1", Text: "hi"}}, "", ""},
+		{"bot", slack.Message{Msg: slack.Msg{SubType: "bot_message", BotID: "B1", Username: "deploy-bot"}}, sourceBot, "deploy-bot"},
+		{"workflow by profile", slack.Message{Msg: slack.Msg{BotID: "B2", BotProfile: &slack.BotProfile{Name: "Workflow Builder"}}}, sourceWorkflow, "Workflow Builder"},
+		{"workflow by metadata", slack.Message{Msg: slack.Msg{BotID: "B3", Username: "Standup", Metadata: slack.SlackMetadata{EventType: "workflow_step_completed"}}}, sourceWorkflow, "Standup"},
+	}
+	for _, c := range cases {
+		source, name := classifySource(c.msg)
+		if source != c.wantSource || name != c.wantName {
+			t.Errorf("%s: classifySource = %q, %q; want %q, %q", c.name, source, name, c.wantSource, c.wantName)
+		}
+	}
+}
+
+func TestWorkflowPolicy(t *testing.T) {
+	t.Setenv("SLACK_MCP_WORKFLOW_MESSAGES", "skip")
+	if got := workflowPolicy(""); got != workflowSkip {
+		t.Errorf("env policy = %q, want skip", got)
+	}
+	if got := workflowPolicy("Important"); got != workflowImportant {
+		t.Errorf("override = %q, want important", got)
+	}
+
+	t.Setenv("SLACK_MCP_WORKFLOW_MESSAGES", "bogus")
+	if got := workflowPolicy(""); got != workflowNormal {
+		t.Errorf("invalid env = %q, want normal", got)
+	}
+}
+
+func TestAnalyzeMessageWorkflowPolicy(t *testing.T) {
+	msg := slack.Message{Msg: slack.Msg{
+		Timestamp:  "1712345678.000100",
+		BotID:      "B2",
+		BotProfile: &slack.BotProfile{Name: "Workflow Builder"},
+	}}
+
+	if item := analyzeMessage(msg, nil, workflowNormal); item != nil {
+		t.Errorf("text-less workflow message surfaced under normal policy: %v", item)
+	}
+	item := analyzeMessage(msg, nil, workflowImportant)
+	if item == nil || item["type"] != "workflow" || item["author"] != "Workflow Builder" {
+		t.Errorf("important policy item = %v", item)
+	}
+
+	msg.Text = "Incident form submitted"
+	if item := analyzeMessage(msg, nil, workflowSkip); item != nil {
+		t.Errorf("skip policy surfaced %v", item)
+	}
+}
@@ -0,0 +1,80 @@
+package features
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// splitReserve leaves room in each chunk for the continuation marker and
+// any code fence that has to be closed and reopened across a split
+const splitReserve = 40
+
+// splitMessage breaks a long message into chunks that each fit within limit
+// characters, preferring paragraph, then line, then word boundaries. Code
+// fences left open by a split are closed and reopened in the next chunk.
+// Chunks after the first carry an "(n/total)" marker; the first notes that
+// the message continues in the thread.
+func splitMessage(message string, limit int) []string {
+	if utf8.RuneCountInString(message) <= limit {
+		return []string{message}
+	}
+
+	size := limit - splitReserve
+	var chunks []string
+	rest := message
+	reopenFence := false
+	for rest != "" {
+		if reopenFence {
+			rest = "```\n" + rest
+		}
+		if utf8.RuneCountInString(rest) <= size {
+			chunks = append(chunks, rest)
+			break
+		}
+
+		cut := splitPoint(rest, size)
+		chunk := strings.TrimRight(rest[:cut], " \n")
+		rest = strings.TrimLeft(rest[cut:], " \n")
+
+		reopenFence = strings.Count(chunk, "```")%2 == 1
+		if reopenFence {
+			chunk += "\n```"
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	total := len(chunks)
+	for i := range chunks {
+		if i == 0 {
+			chunks[i] += fmt.Sprintf("\n_(1/%d, continued in thread)_", total)
+		} else {
+			chunks[i] += fmt.Sprintf("\n_(%d/%d)_", i+1, total)
+		}
+	}
+	return chunks
+}
+
+// splitPoint returns a byte offset at most size runes into s, at the best
+// available boundary
+func splitPoint(s string, size int) int {
+	max := len(s)
+	n := 0
+	for i := range s {
+		if n == size {
+			max = i
+			break
+		}
+		n++
+	}
+	window := s[:max]
+
+	// Don't accept a boundary that leaves a tiny first chunk
+	floor := max / 2
+	for _, sep := range []string{"\n\n", "\n", " "} {
+		if i := strings.LastIndex(window, sep); i > floor {
+			return i + len(sep)
+		}
+	}
+	return max
+}
@@ -0,0 +1,31 @@
+package features
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitMessage(t *testing.T) {
+	if got := splitMessage("short", 100); len(got) != 1 || got[0] != "short" {
+		t.Errorf("short message split = %q", got)
+	}
+
+	para := strings.Repeat("word ", 30) // 150 chars
+	msg := strings.Join([]string{para, para, "```\n" + para + "\n" + para + "\n```", para}, "\n\n")
+	chunks := splitMessage(msg, 200)
+	if len(chunks) < 3 {
+		t.Fatalf("got %d chunks, want at least 3", len(chunks))
+	}
+	for i, c := range chunks {
+		if n := utf8.RuneCountInString(c); n > 200 {
+			t.Errorf("chunk %d is %d chars, over limit", i, n)
+		}
+		if strings.Count(c, "```")%2 != 0 {
+			t.Errorf("chunk %d has an unbalanced code fence:\n%s", i, c)
+		}
+	}
+	if !strings.Contains(chunks[0], "continued in thread") {
+		t.Errorf("first chunk missing continuation marker: %q", chunks[0])
+	}
+}
@@ -0,0 +1,220 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// ListOutbox shows messages queued after Slack was unreachable or rate limiting
+var ListOutbox = &Feature{
+	Name:        "list-outbox",
+	Description: "List messages queued for retry because Slack was unreachable or rate limiting when they were sent",
+	Schema: map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	},
+	Handler: listOutboxHandler,
+}
+
+// FlushOutbox retries queued messages now, or discards them
+var FlushOutbox = &Feature{
+	Name:        "flush-outbox",
+	Description: "Retry queued messages now instead of waiting for the next automatic retry, or discard them unsent",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"ids": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Queued message IDs from list-outbox (default: all)",
+			},
+			"discard": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Drop the messages without sending them",
+				"default":     false,
+			},
+		},
+	},
+	Handler: flushOutboxHandler,
+}
+
+// queueForRetry puts a message whose send failed in the outbox, reporting
+// it as accepted rather than failed
+func queueForRetry(ap *provider.ApiProvider, channel, channelID, threadTs, message string, force bool, cause error) *FeatureResult {
+	queued, err := ap.QueueOutbox(channelID, channel, threadTs, message, force, cause)
+	if err != nil {
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Failed to send message: %v, and it couldn't be queued: %v", cause, err),
+			Guidance: "⚠️ Slack is unreachable or rate limiting. Try again later, or flush-outbox to clear the queue.",
+		}
+	}
+	return &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Slack is unavailable (%v): message to %s queued for retry", cause, channel),
+		Data: map[string]interface{}{
+			"channel":     channel,
+			"channelId":   channelID,
+			"threadTs":    threadTs,
+			"message":     message,
+			"queued":      true,
+			"outboxId":    queued.ID,
+			"nextAttempt": queued.NextAttempt.Format(time.RFC3339),
+		},
+		Guidance: "📤 It will be retried automatically with backoff. It hasn't been delivered yet, so don't tell anyone it was sent.",
+		NextActions: []string{
+			"See what's waiting: list-outbox",
+			fmt.Sprintf("Retry now: flush-outbox ids=['%s']", queued.ID),
+		},
+	}
+}
+
+// HoldQueuedMessage puts a queued message through the checks a live send
+// gets: quiet hours, then DM pacing unless it was sent with force. Set as
+// provider.OutboxHold.
+func HoldQueuedMessage(ctx context.Context, ap *provider.ApiProvider, api *slack.Client, m provider.OutboxMessage) (string, time.Time) {
+	now := time.Now()
+	if qh := loadQuietHours(); qh != nil && qh.active(now) {
+		return fmt.Sprintf("quiet hours (%s)", qh.spec), qh.endAfter(now)
+	}
+	if mode := dmPacingMode(); !m.Force && mode != dmPacingOff && strings.HasPrefix(m.ChannelID, "D") {
+		if r := checkDMRecipient(ctx, ap, api, m.ChannelID); r != nil && r.reason != "" {
+			return fmt.Sprintf("%s %s", r.name, r.reason), r.until
+		}
+	}
+	return "", time.Time{}
+}
+
+func outboxEntries(msgs []provider.OutboxMessage) []map[string]interface{} {
+	entries := make([]map[string]interface{}, 0, len(msgs))
+	for _, m := range msgs {
+		e := map[string]interface{}{
+			"id":        m.ID,
+			"channel":   m.Channel,
+			"channelId": m.ChannelID,
+			"message":   m.Text,
+			"queuedAt":  formatTimestamp(m.QueuedAt),
+			"attempts":  m.Attempts,
+			"lastError": m.LastError,
+		}
+		if m.ThreadTs != "" {
+			e["threadTs"] = m.ThreadTs
+		}
+		if m.Held != "" {
+			e["heldFor"] = m.Held
+		}
+		if m.GaveUp {
+			e["gaveUp"] = true
+		} else {
+			e["nextAttempt"] = formatTimestamp(m.NextAttempt)
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func listOutboxHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	msgs := apiProvider.Outbox()
+	result := &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("%d messages waiting to be sent", len(msgs)),
+		Data: map[string]interface{}{
+			"messages": outboxEntries(msgs),
+			"enabled":  apiProvider.OutboxEnabled(),
+		},
+		ResultCount: len(msgs),
+	}
+	switch {
+	case !apiProvider.OutboxEnabled():
+		result.Guidance = "Queueing is off (SLACK_MCP_OUTBOX=off): failed sends are reported as errors"
+	case len(msgs) > 0:
+		result.NextActions = []string{"Retry them now: flush-outbox", "Drop them: flush-outbox discard=true"}
+	}
+	for _, m := range msgs {
+		if m.GaveUp {
+			result.Guidance = "⚠️ Some messages are no longer retried automatically. Flush them by hand or discard them."
+			break
+		}
+	}
+	return result, nil
+}
+
+func flushOutboxHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+	ids := stringList(params["ids"])
+
+	if discard, _ := params["discard"].(bool); discard {
+		if len(ids) == 0 {
+			for _, m := range apiProvider.Outbox() {
+				ids = append(ids, m.ID)
+			}
+		}
+		var dropped, missing []string
+		for _, id := range ids {
+			if apiProvider.RemoveOutbox(id) {
+				dropped = append(dropped, id)
+			} else {
+				missing = append(missing, id)
+			}
+		}
+		result := &FeatureResult{
+			Success: true,
+			Message: fmt.Sprintf("Discarded %d queued messages", len(dropped)),
+			Data:    map[string]interface{}{"discarded": dropped},
+		}
+		if len(missing) > 0 {
+			result.Guidance = fmt.Sprintf("Not in the outbox: %v", missing)
+		}
+		return result, nil
+	}
+
+	sent, remaining, err := apiProvider.FlushOutbox(ctx, ids...)
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	sentList := make([]map[string]interface{}, 0, len(sent))
+	for _, s := range sent {
+		sentList = append(sentList, map[string]interface{}{
+			"id":        s.ID,
+			"channel":   s.Channel,
+			"message":   s.Text,
+			"timestamp": s.Timestamp,
+		})
+	}
+	result := &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Sent %d queued messages, %d still waiting", len(sent), len(remaining)),
+		Data: map[string]interface{}{
+			"sent":      sentList,
+			"remaining": outboxEntries(remaining),
+		},
+		ResultCount: len(sent),
+	}
+	if len(remaining) > 0 {
+		result.Guidance = "Sending stops at the first failure so messages stay in order. The rest will be retried automatically."
+	}
+	return result, nil
+}
@@ -0,0 +1,70 @@
+package features
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// stopReason classifies the error that cut a multi-request tool short
+func stopReason(err error) string {
+	var rl *slack.RateLimitedError
+	switch {
+	case errors.As(err, &rl):
+		return PartialRateLimited
+	case errors.Is(err, context.DeadlineExceeded):
+		return PartialTimeout
+	}
+	return PartialError
+}
+
+// scannedRange fills in the time range a partial result actually read
+func (c Coverage) scannedRange(from, to time.Time) Coverage {
+	if !from.IsZero() {
+		c.ScannedFrom = from.Format(time.RFC3339)
+	}
+	if !to.IsZero() {
+		c.ScannedTo = to.Format(time.RFC3339)
+	}
+	return c
+}
+
+// skippedChannels collects the conversations a tool meant to read but
+// couldn't, and the last error it got
+type skippedChannels struct {
+	names []string
+	seen  map[string]bool
+	err   error
+}
+
+func (s *skippedChannels) skip(ap *provider.ApiProvider, channelID string, err error) {
+	if s.seen == nil {
+		s.seen = make(map[string]bool)
+	}
+	if s.seen[channelID] {
+		return
+	}
+	s.seen[channelID] = true
+	s.names = append(s.names, channelLabel(ap, channelID))
+	s.err = err
+}
+
+// channelLabel names a channel for a coverage report from the cache, so a
+// rate-limited tool doesn't make more calls to say what it missed
+func channelLabel(ap *provider.ApiProvider, channelID string) string {
+	if name := ap.CachedChannelName(channelID); name != "" {
+		return "#" + name
+	}
+	return channelID
+}
+
+// mark records the skipped conversations on result, if there were any
+func (s *skippedChannels) mark(result *FeatureResult, detail string) {
+	if len(s.names) == 0 {
+		return
+	}
+	result.MarkPartial(Coverage{Reason: stopReason(s.err), Detail: detail, Skipped: s.names})
+}
@@ -0,0 +1,56 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+func TestStopReason(t *testing.T) {
+	cases := []struct {
+		err  error
+		want string
+	}{
+		{&slack.RateLimitedError{RetryAfter: time.Second}, PartialRateLimited},
+		{fmt.Errorf("history: %w", context.DeadlineExceeded), PartialTimeout},
+		{fmt.Errorf("channel_not_found"), PartialError},
+	}
+	for _, c := range cases {
+		if got := stopReason(c.err); got != c.want {
+			t.Errorf("stopReason(%v) = %s, want %s", c.err, got, c.want)
+		}
+	}
+}
+
+func TestFormatResultPartialNotice(t *testing.T) {
+	result := &FeatureResult{Success: true, Message: "Reacted"}
+	if got := FormatResult("react", result); strings.Contains(got, "Partial") {
+		t.Errorf("complete result got a partial notice: %q", got)
+	}
+
+	from := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	skipped := make([]string, 12)
+	for i := range skipped {
+		skipped[i] = fmt.Sprintf("#c%d", i)
+	}
+	result.MarkPartial(Coverage{Reason: PartialMaxPages, Detail: "read 10 pages"}.scannedRange(from, from.Add(time.Hour)))
+	result.MarkPartial(Coverage{Reason: PartialRateLimited, Skipped: skipped})
+
+	got := FormatResult("react", result)
+	if !strings.HasPrefix(got, "> ⚠️ **Partial result") {
+		t.Errorf("notice should lead the output:\n%s", got)
+	}
+	for _, want := range []string{
+		"max pages: read 10 pages (read 2026-03-02T09:00:00Z to 2026-03-02T10:00:00Z)",
+		"rate limited; 12 channels not read: #c0,",
+		"#c9, +2 more",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("notice missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package features
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+// permalinkWorkers bounds concurrent chat.getPermalink calls per batch
+const permalinkWorkers = 4
+
+// permalinkBatch collects result items that need a permalink and fills
+// them in together once the items are built
+type permalinkBatch struct {
+	items []map[string]interface{}
+	refs  []ThreadRef
+}
+
+// add queues item to receive the permalink of the message at channelID/ts
+func (b *permalinkBatch) add(item map[string]interface{}, channelID, ts string) {
+	if channelID == "" || ts == "" {
+		return
+	}
+	b.items = append(b.items, item)
+	b.refs = append(b.refs, ThreadRef{ChannelID: channelID, ThreadTs: ts})
+}
+
+// resolve sets item["permalink"] for every queued item. Failures are logged
+// and leave the item without a link.
+func (b *permalinkBatch) resolve(ctx context.Context, p *provider.ApiProvider) {
+	if len(b.items) == 0 {
+		return
+	}
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		next = make(chan int)
+	)
+	for w := 0; w < permalinkWorkers && w < len(b.items); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				link, err := p.Permalink(ctx, b.refs[i].ChannelID, b.refs[i].ThreadTs)
+				if err != nil {
+					log.Printf("Permalink for %s failed: %v", b.refs[i], err)
+					continue
+				}
+				mu.Lock()
+				b.items[i]["permalink"] = link
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range b.items {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
@@ -0,0 +1,181 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// PostSnippet uploads code or log output as a Slack snippet, which renders
+// with syntax highlighting and collapses long content instead of flooding
+// the channel with an inline code block.
+var PostSnippet = &Feature{
+	Name:        "post-snippet",
+	Description: "Post long code, logs, or config as a Slack snippet file with syntax highlighting instead of an inline code block",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel": map[string]interface{}{
+				"type":        "string",
+				"description": "Channel name, DM username, or channel/DM ID to post to",
+			},
+			"content": map[string]interface{}{
+				"type":        "string",
+				"description": "The code or text content of the snippet",
+			},
+			"language": map[string]interface{}{
+				"type":        "string",
+				"description": "Syntax type for highlighting (e.g., 'go', 'python', 'javascript', 'json', 'yaml', 'shell', 'text'). Default: text",
+			},
+			"title": map[string]interface{}{
+				"type":        "string",
+				"description": "Snippet title (optional)",
+			},
+			"comment": map[string]interface{}{
+				"type":        "string",
+				"description": "Message text posted with the snippet (optional)",
+			},
+			"threadTs": map[string]interface{}{
+				"type":        "string",
+				"description": "Thread timestamp to post into (optional)",
+			},
+		},
+		"required": []string{"channel", "content"},
+	},
+	Handler: postSnippetHandler,
+}
+
+// snippetExtensions maps common language names to a filename extension so
+// Slack picks the right highlighter when the snippet is opened
+var snippetExtensions = map[string]string{
+	"go":         "go",
+	"python":     "py",
+	"javascript": "js",
+	"typescript": "ts",
+	"json":       "json",
+	"yaml":       "yaml",
+	"shell":      "sh",
+	"bash":       "sh",
+	"sql":        "sql",
+	"java":       "java",
+	"rust":       "rs",
+	"ruby":       "rb",
+	"html":       "html",
+	"css":        "css",
+	"markdown":   "md",
+	"diff":       "diff",
+	"text":       "txt",
+}
+
+var snippetTitleUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func postSnippetHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	channel, _ := params["channel"].(string)
+	content, _ := params["content"].(string)
+	if channel == "" || strings.TrimSpace(content) == "" {
+		return &FeatureResult{
+			Success: false,
+			Message: "channel and content are required",
+		}, nil
+	}
+
+	language, _ := params["language"].(string)
+	language = strings.ToLower(strings.TrimSpace(language))
+	if language == "" {
+		language = "text"
+	}
+	title, _ := params["title"].(string)
+	comment, _ := params["comment"].(string)
+	threadTs, _ := params["threadTs"].(string)
+
+	if qh := loadQuietHours(); qh != nil && qh.active(time.Now()) {
+		return quietHoursBlocked(qh, "posting", time.Now()), nil
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	channelID := resolveChannelForSending(apiProvider, api, channel)
+	if channelID == "" {
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Could not find channel or user '%s'", channel),
+			Guidance: "💡 Use 'list-channels' to see available channels or provide a username for DMs",
+		}, nil
+	}
+
+	comment, _ = resolveMentions(comment, apiProvider.ProvideUsersMap())
+
+	file, err := api.UploadFileContext(ctx, slack.UploadFileParameters{
+		Content:         content,
+		FileSize:        len(content),
+		Filename:        snippetFilename(title, language),
+		Title:           title,
+		InitialComment:  comment,
+		Channel:         channelID,
+		ThreadTimestamp: threadTs,
+		SnippetType:     language,
+	})
+	if err != nil {
+		log.Printf("Failed to upload snippet: %v", err)
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Failed to post snippet: %v", err),
+			Guidance: "⚠️ Check if you have permission to post files in this channel",
+		}, nil
+	}
+
+	apiProvider.RecordAction(provider.UsageMessagesSent, 1)
+
+	lines := strings.Count(content, "\n") + 1
+	return &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Posted %d-line %s snippet to %s", lines, language, channel),
+		Data: map[string]interface{}{
+			"channel":  channel,
+			"fileId":   file.ID,
+			"title":    file.Title,
+			"language": language,
+			"lines":    lines,
+			"threadTs": threadTs,
+		},
+		NextActions: []string{
+			fmt.Sprintf("See the conversation: catch-up channel='%s' since='1h'", channel),
+		},
+	}, nil
+}
+
+// snippetFilename builds a filename from the title with an extension for the language
+func snippetFilename(title, language string) string {
+	ext, ok := snippetExtensions[language]
+	if !ok {
+		ext = "txt"
+	}
+	base := strings.Trim(snippetTitleUnsafe.ReplaceAllString(title, "-"), "-.")
+	if base == "" {
+		base = "snippet"
+	}
+	if len(base) > 60 {
+		base = base[:60]
+	}
+	return base + "." + ext
+}
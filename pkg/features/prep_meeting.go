@@ -0,0 +1,430 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// PrepForMeeting compiles a pre-meeting brief from what the attendees have
+// been discussing: the threads they were in, what they've asked you that's
+// still unanswered, and action items nobody has closed out
+var PrepForMeeting = &Feature{
+	Name:        "prep-for-meeting",
+	Description: "Pre-meeting brief: recent threads involving the attendees (or in a channel), their open questions to you, and unresolved action items",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"attendees": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "People in the meeting: '@alice', names, user IDs, or emails",
+			},
+			"channel": map[string]interface{}{
+				"type":        "string",
+				"description": "The meeting's channel, if it has one; narrows the brief to it",
+			},
+			"since": map[string]interface{}{
+				"type":        "string",
+				"description": "How far back to look (e.g., '3d', '7d', '2w')",
+				"default":     "7d",
+			},
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": "Threads to include (default: 5, max: 20)",
+				"default":     5,
+			},
+		},
+	},
+	Handler: prepForMeetingHandler,
+}
+
+const (
+	// prepMaxAttendees bounds the per-attendee searches
+	prepMaxAttendees = 8
+	// prepMaxReplyFetches bounds conversations.replies calls per brief
+	prepMaxReplyFetches = 8
+)
+
+// actionItemPattern spots commitments and asks that make for action items
+var actionItemPattern = regexp.MustCompile(`(?i)\b(action items?|todo|to-do|follow[- ]up|next steps?|i'll|i will|will (send|share|update|look into|check|fix|write|review)|by (eod|eow|tomorrow|monday|tuesday|wednesday|thursday|friday))\b`)
+
+// closedPattern marks a later reply that settles an action item
+var closedPattern = regexp.MustCompile(`(?i)\b(done|fixed|shipped|merged|sent|completed|closed|resolved)\b`)
+
+// prepMessage is a message in a thread under consideration
+type prepMessage struct {
+	user      string
+	text      string
+	ts        string
+	permalink string
+}
+
+// prepThread is a conversation the attendees took part in
+type prepThread struct {
+	channelID  string
+	channel    string
+	ts         string // Root timestamp
+	replyCount int
+	people     map[string]bool
+	msgs       []prepMessage
+	latest     string
+}
+
+func prepForMeetingHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	names := stringList(params["attendees"])
+	channel, _ := params["channel"].(string)
+	channel = strings.TrimPrefix(strings.TrimSpace(channel), "#")
+	if len(names) == 0 && channel == "" {
+		return &FeatureResult{
+			Success: false,
+			Message: "Provide attendees, a channel, or both",
+		}, nil
+	}
+	if len(names) > prepMaxAttendees {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Brief on at most %d attendees at a time", prepMaxAttendees),
+		}, nil
+	}
+	since := "7d"
+	if s, ok := params["since"].(string); ok && s != "" {
+		since = s
+	}
+	limit := 5
+	if l, ok := params["limit"].(float64); ok {
+		limit = max(1, min(int(l), 20))
+	}
+	oldest, err := parseTimePeriod(since)
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Invalid time period: %v", err),
+		}, nil
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	usersMap := apiProvider.ProvideUsersMap()
+	attendees := map[string]bool{}
+	attendeeNames := map[string]bool{}
+	var resolved, unresolved []string
+	for _, n := range names {
+		id := resolveUserRef(ctx, apiProvider, n, usersMap)
+		if id == "" {
+			unresolved = append(unresolved, n)
+			continue
+		}
+		if !attendees[id] {
+			attendees[id] = true
+			attendeeNames[userDisplayName(id, usersMap)] = true
+			resolved = append(resolved, userDisplayName(id, usersMap))
+		}
+	}
+	if len(names) > 0 && len(attendees) == 0 && channel == "" {
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("None of the attendees matched a user: %s", strings.Join(unresolved, ", ")),
+			Guidance: "Use list-users to find their usernames",
+		}, nil
+	}
+
+	channelID, channelName := "", ""
+	if channel != "" {
+		channelID = apiProvider.ResolveChannelID(channel)
+		if !isChannelID(channelID) {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Channel '%s' not found. Use list-channels to see available channels.", channel),
+			}, nil
+		}
+		channelName = apiProvider.ResolveChannelName(ctx, channelID)
+	}
+
+	threads := map[string]*prepThread{}
+	note := func(chID, chName, rootTs string, replyCount int, m prepMessage, replyUsers []string) {
+		key := chID + "/" + rootTs
+		t, ok := threads[key]
+		if !ok {
+			t = &prepThread{channelID: chID, channel: chName, ts: rootTs, people: map[string]bool{}}
+			threads[key] = t
+		}
+		for _, existing := range t.msgs {
+			if existing.ts == m.ts {
+				return
+			}
+		}
+		t.msgs = append(t.msgs, m)
+		t.replyCount = max(t.replyCount, replyCount)
+		t.people[m.user] = true
+		for _, u := range replyUsers {
+			t.people[u] = true
+		}
+		if m.ts > t.latest {
+			t.latest = m.ts
+		}
+	}
+
+	// The channel's own history, when there is one
+	if channelID != "" {
+		cursor := ""
+		for page := 0; page < 2; page++ {
+			resp, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+				ChannelID: channelID,
+				Oldest:    fmt.Sprintf("%d", oldest.Unix()),
+				Limit:     100,
+				Cursor:    cursor,
+			})
+			if err != nil {
+				return &FeatureResult{
+					Success: false,
+					Message: fmt.Sprintf("Failed to read #%s: %v", channelName, err),
+				}, nil
+			}
+			for _, msg := range resp.Messages {
+				note(channelID, channelName, msg.Timestamp, msg.ReplyCount,
+					prepMessage{user: msg.User, text: msg.Text, ts: msg.Timestamp}, msg.ReplyUsers)
+				if msg.LatestReply > threads[channelID+"/"+msg.Timestamp].latest {
+					threads[channelID+"/"+msg.Timestamp].latest = msg.LatestReply
+				}
+			}
+			if !resp.HasMore || resp.ResponseMetaData.NextCursor == "" {
+				break
+			}
+			cursor = resp.ResponseMetaData.NextCursor
+		}
+	}
+
+	// What each attendee said elsewhere, or in the channel when given.
+	// Bot tokens can't search and rely on the channel alone.
+	searched := apiProvider.TokenType() != "bot"
+	if searched {
+		after := oldest.AddDate(0, 0, -1).Format("2006-01-02")
+		for id := range attendees {
+			query := fmt.Sprintf("from:<@%s> after:%s", id, after)
+			if channelID != "" {
+				query += fmt.Sprintf(" in:<#%s>", channelID)
+			}
+			sp := slack.NewSearchParameters()
+			sp.Sort = "timestamp"
+			sp.Count = 50
+			res, err := api.SearchMessagesContext(ctx, query, sp)
+			if err != nil {
+				searched = false
+				break
+			}
+			for _, m := range res.Matches {
+				if parseSlackTimestamp(m.Timestamp).Before(oldest) {
+					continue
+				}
+				rootTs := m.Timestamp
+				if ref, err := parseThreadRef(m.Permalink); err == nil && ref.ThreadTs != "" {
+					rootTs = ref.ThreadTs
+				}
+				name := m.Channel.Name
+				if strings.HasPrefix(m.Channel.ID, "D") {
+					name = "DM"
+				}
+				note(m.Channel.ID, name, rootTs, 0,
+					prepMessage{user: m.User, text: m.Text, ts: m.Timestamp, permalink: m.Permalink}, nil)
+			}
+		}
+	}
+
+	// Threads the attendees were in, most attendees first, then most recent
+	ranked := make([]*prepThread, 0, len(threads))
+	involved := func(t *prepThread) int {
+		n := 0
+		for id := range attendees {
+			if t.people[id] {
+				n++
+			}
+		}
+		return n
+	}
+	for _, t := range threads {
+		if len(attendees) == 0 || involved(t) > 0 {
+			ranked = append(ranked, t)
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if a, b := involved(ranked[i]), involved(ranked[j]); a != b {
+			return a > b
+		}
+		if a, b := ranked[i].replyCount+len(ranked[i].msgs), ranked[j].replyCount+len(ranked[j].msgs); a != b && len(attendees) == 0 {
+			return a > b
+		}
+		return ranked[i].latest > ranked[j].latest
+	})
+
+	// Read the busiest top threads in full so action items and their
+	// follow-ups in replies are seen
+	fetched := 0
+	for _, t := range ranked[:min(len(ranked), limit)] {
+		if t.replyCount == 0 || fetched >= prepMaxReplyFetches {
+			continue
+		}
+		replies, _, _, err := api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+			ChannelID: t.channelID,
+			Timestamp: t.ts,
+			Limit:     200,
+		})
+		fetched++
+		if err != nil {
+			continue
+		}
+		t.msgs = t.msgs[:0]
+		for _, r := range replies {
+			t.msgs = append(t.msgs, prepMessage{user: r.User, text: r.Text, ts: r.Timestamp})
+			t.people[r.User] = true
+		}
+	}
+
+	var links permalinkBatch
+	threadList := make([]map[string]interface{}, 0, limit)
+	var actionItems []map[string]interface{}
+	for i, t := range ranked {
+		sort.Slice(t.msgs, func(a, b int) bool { return t.msgs[a].ts < t.msgs[b].ts })
+
+		// An action item stays open until a later message closes it out
+		for j, m := range t.msgs {
+			if !actionItemPattern.MatchString(m.text) {
+				continue
+			}
+			closed := false
+			for _, later := range t.msgs[j+1:] {
+				if closedPattern.MatchString(later.text) || decisionPattern.MatchString(later.text) {
+					closed = true
+					break
+				}
+			}
+			if closed {
+				continue
+			}
+			item := map[string]interface{}{
+				"channel":  t.channel,
+				"author":   userDisplayName(m.user, usersMap),
+				"text":     truncateMessage(m.text, 200),
+				"time":     formatTimestamp(parseSlackTimestamp(m.ts)),
+				"threadId": threadRefFor(t.channelID, m.ts, t.ts).String(),
+			}
+			if m.permalink != "" {
+				item["permalink"] = m.permalink
+			} else {
+				links.add(item, t.channelID, m.ts)
+			}
+			actionItems = append(actionItems, item)
+		}
+
+		if i >= limit {
+			continue
+		}
+		var people []string
+		for id := range t.people {
+			if id != "" {
+				people = append(people, userDisplayName(id, usersMap))
+			}
+		}
+		sort.Strings(people)
+		root := t.msgs[0]
+		thread := map[string]interface{}{
+			"channel":      t.channel,
+			"started":      userDisplayName(root.user, usersMap),
+			"text":         truncateMessage(root.text, 200),
+			"replies":      max(t.replyCount, len(t.msgs)-1),
+			"participants": people,
+			"lastActivity": formatTimestamp(parseSlackTimestamp(t.latest)),
+			"threadId":     threadRefFor(t.channelID, t.ts, "").String(),
+		}
+		if last := t.msgs[len(t.msgs)-1]; len(t.msgs) > 1 {
+			thread["lastReply"] = fmt.Sprintf("%s: %s", userDisplayName(last.user, usersMap), truncateMessage(last.text, 120))
+		}
+		threadList = append(threadList, thread)
+	}
+	if len(actionItems) > limit*3 {
+		actionItems = actionItems[:limit*3]
+	}
+	links.resolve(ctx, apiProvider)
+
+	// Questions and requests to you from the attendees, still unanswered
+	var questions []map[string]interface{}
+	if searched {
+		if self, err := apiProvider.Self(); err == nil {
+			if res, ok := checkMentionsViaSearch(ctx, apiProvider, api, self, since, oldest, "all", false, 100); ok {
+				if data, ok := res.Data.(map[string]interface{}); ok {
+					for _, m := range asList(data["mentions"]) {
+						kind := str(m, "type")
+						if kind != "direct_question" && kind != "request" {
+							continue
+						}
+						if len(attendees) > 0 && !attendeeNames[str(m, "author")] {
+							continue
+						}
+						if channelName != "" && len(attendees) == 0 && str(m, "channel") != channelName {
+							continue
+						}
+						questions = append(questions, m)
+					}
+				}
+			}
+		}
+	}
+
+	label := strings.Join(resolved, ", ")
+	if channelName != "" {
+		if label != "" {
+			label += " in "
+		}
+		label += "#" + channelName
+	}
+	result := &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Brief for %s: %d threads, %d open questions to you, %d open action items", label, len(ranked), len(questions), len(actionItems)),
+		Data: map[string]interface{}{
+			"attendees":   resolved,
+			"channel":     channelName,
+			"since":       since,
+			"threads":     threadList,
+			"questions":   questions,
+			"actionItems": actionItems,
+		},
+		ResultCount: len(threadList) + len(questions) + len(actionItems),
+	}
+	if len(unresolved) > 0 {
+		result.Data.(map[string]interface{})["unresolved"] = unresolved
+	}
+	switch {
+	case len(unresolved) > 0:
+		result.Guidance = fmt.Sprintf("No user matched %s; they're left out of the brief", strings.Join(unresolved, ", "))
+	case !searched:
+		result.Guidance = "Search wasn't available, so the brief covers the channel only and skips open questions"
+	case len(ranked) == 0:
+		result.Guidance = fmt.Sprintf("Nothing involving the attendees in the last %s; try a longer since", since)
+	}
+	if len(questions) > 0 {
+		result.NextActions = append(result.NextActions, fmt.Sprintf("Answer before the meeting: read-thread threadId='%s'", str(questions[0], "threadId")))
+	}
+	if len(threadList) > 0 {
+		result.NextActions = append(result.NextActions, fmt.Sprintf("Read the top thread: read-thread threadId='%s'", threadList[0]["threadId"]))
+	}
+	return result, nil
+}
@@ -0,0 +1,24 @@
+package features
+
+import "testing"
+
+func TestActionItemPatterns(t *testing.T) {
+	for _, text := range []string{
+		"I'll send the draft over",
+		"Action items: update the runbook",
+		"can someone follow up with legal",
+		"need this by Friday",
+	} {
+		if !actionItemPattern.MatchString(text) {
+			t.Errorf("not an action item: %q", text)
+		}
+	}
+	for _, text := range []string{"thanks all", "the review went well", "sounds good"} {
+		if actionItemPattern.MatchString(text) {
+			t.Errorf("false action item: %q", text)
+		}
+	}
+	if !closedPattern.MatchString("Sent it, take a look") || closedPattern.MatchString("still waiting on it") {
+		t.Error("closedPattern misclassified a reply")
+	}
+}
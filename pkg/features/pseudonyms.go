@@ -0,0 +1,299 @@
+package features
+
+import (
+	"hash/fnv"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/aaronsb/slack-mcp/pkg/cache"
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// Pseudonymization replaces every user's name, handle, and ID in tool
+// output with a stable fake name, and strips email addresses, for
+// workspaces piloting an assistant under data-protection review.
+//
+//	SLACK_MCP_PSEUDONYMIZE=on
+//
+// Pseudonyms are derived from the user ID and kept in pseudonyms.json in
+// the data directory, which maps each one back to the real user. Tool
+// arguments are translated back, so "send-message to Amber Falcon" reaches
+// the right person.
+const pseudonymsCacheFile = "pseudonyms.json"
+
+var pseudonymAdjectives = []string{
+	"Amber", "Azure", "Brisk", "Calm", "Cedar", "Coral", "Crimson", "Dusky",
+	"Ember", "Fern", "Frost", "Gentle", "Golden", "Hazel", "Indigo", "Ivory",
+	"Jade", "Lunar", "Maple", "Misty", "Noble", "Olive", "Quiet", "Rapid",
+	"Rustic", "Sage", "Silver", "Solar", "Swift", "Teal", "Velvet", "Willow",
+}
+
+var pseudonymAnimals = []string{
+	"Badger", "Bison", "Crane", "Falcon", "Ferret", "Finch", "Fox", "Gecko",
+	"Heron", "Ibis", "Jackal", "Koala", "Lark", "Lemur", "Lynx", "Marten",
+	"Moose", "Newt", "Otter", "Owl", "Panda", "Puffin", "Quail", "Raven",
+	"Robin", "Seal", "Stoat", "Swan", "Tapir", "Vole", "Walrus", "Wren",
+}
+
+// pseudonymEntry is one user in the local mapping file
+type pseudonymEntry struct {
+	Pseudonym string `json:"pseudonym"`
+	Name      string `json:"name"`
+}
+
+// pseudonymizer translates between real identities and pseudonyms. It is
+// rebuilt when the user list changes.
+type pseudonymizer struct {
+	users      uint64            // usersFingerprint of the list it was built from
+	toFake     map[string]string // real name, handle, or ID -> pseudonym
+	toReal     map[string]string // pseudonym -> handle
+	realNames  *regexp.Regexp
+	fakeNames  *regexp.Regexp
+	emailRegex *regexp.Regexp
+}
+
+// pseudonymsCache holds a pseudonymizer per store directory: each identity
+// has its own users and its own mapping file
+var (
+	pseudonymsMu    sync.Mutex
+	pseudonymsCache = map[string]*pseudonymizer{}
+)
+
+// pseudonymsEnabled reports whether SLACK_MCP_PSEUDONYMIZE is on
+func pseudonymsEnabled() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("SLACK_MCP_PSEUDONYMIZE"))) {
+	case "on", "true", "1", "yes":
+		return true
+	}
+	return false
+}
+
+// loadPseudonymizer returns the pseudonymizer for the current user list,
+// assigning and saving pseudonyms for users seen for the first time
+func loadPseudonymizer(p *provider.ApiProvider) *pseudonymizer {
+	return storePseudonymizer(p.Store(), p.ProvideUsersMap())
+}
+
+// storePseudonymizer returns the cached pseudonymizer for a store, building
+// it again when the users or their names have changed
+func storePseudonymizer(store *cache.Store, usersMap map[string]slack.User) *pseudonymizer {
+	key := ""
+	if store != nil {
+		key = store.Dir()
+	}
+	users := usersFingerprint(usersMap)
+
+	pseudonymsMu.Lock()
+	defer pseudonymsMu.Unlock()
+	if ps := pseudonymsCache[key]; ps != nil && ps.users == users {
+		return ps
+	}
+
+	saved := map[string]pseudonymEntry{}
+	if store != nil {
+		_ = store.Load(pseudonymsCacheFile, &saved)
+	}
+	mapping, changed := assignPseudonyms(usersMap, saved)
+	if changed && store != nil {
+		if err := store.Save(pseudonymsCacheFile, mapping); err != nil {
+			log.Printf("Failed to save pseudonyms: %v", err)
+		}
+	}
+	ps := newPseudonymizer(usersMap, mapping)
+	ps.users = users
+	pseudonymsCache[key] = ps
+	return ps
+}
+
+// usersFingerprint identifies a user list by each user's ID and the names
+// that get replaced, whatever the map order
+func usersFingerprint(usersMap map[string]slack.User) uint64 {
+	var sum uint64
+	for id, u := range usersMap {
+		h := fnv.New64a()
+		for _, s := range []string{id, u.Name, u.RealName, u.Profile.RealName, u.Profile.DisplayName, u.Profile.FirstName} {
+			h.Write([]byte(s))
+			h.Write([]byte{0})
+		}
+		sum += h.Sum64()
+	}
+	return sum
+}
+
+// assignPseudonyms keeps every saved pseudonym and gives new users one
+// derived from their ID, numbering on collision
+func assignPseudonyms(usersMap map[string]slack.User, saved map[string]pseudonymEntry) (map[string]pseudonymEntry, bool) {
+	mapping := make(map[string]pseudonymEntry, len(usersMap))
+	taken := map[string]bool{}
+	for id, e := range saved {
+		mapping[id] = e
+		taken[e.Pseudonym] = true
+	}
+
+	ids := make([]string, 0, len(usersMap))
+	for id := range usersMap {
+		if _, ok := mapping[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		name := basePseudonym(id)
+		for n := 2; taken[name]; n++ {
+			name = basePseudonym(id) + " " + strconv.Itoa(n)
+		}
+		taken[name] = true
+		mapping[id] = pseudonymEntry{Pseudonym: name, Name: usersMap[id].Name}
+	}
+	return mapping, len(ids) > 0
+}
+
+func basePseudonym(id string) string {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	sum := h.Sum32()
+	return pseudonymAdjectives[sum%uint32(len(pseudonymAdjectives))] + " " +
+		pseudonymAnimals[(sum/uint32(len(pseudonymAdjectives)))%uint32(len(pseudonymAnimals))]
+}
+
+func newPseudonymizer(usersMap map[string]slack.User, mapping map[string]pseudonymEntry) *pseudonymizer {
+	ps := &pseudonymizer{
+		toFake:     map[string]string{},
+		toReal:     map[string]string{},
+		emailRegex: redactRuleSets["emails"][0].pattern,
+	}
+
+	// First names only identify someone when nobody else shares them
+	firstNames := map[string]int{}
+	for _, u := range usersMap {
+		if f := u.Profile.FirstName; f != "" {
+			firstNames[f]++
+		}
+	}
+
+	for id, u := range usersMap {
+		fake := mapping[id].Pseudonym
+		if fake == "" {
+			continue
+		}
+		ps.toReal[fake] = u.Name
+		for _, name := range []string{u.ID, u.Name, u.RealName, u.Profile.RealName, u.Profile.DisplayName} {
+			if pseudonymizable(name) {
+				ps.toFake[name] = fake
+			}
+		}
+		if f := u.Profile.FirstName; firstNames[f] == 1 && pseudonymizable(f) {
+			if _, ok := ps.toFake[f]; !ok {
+				ps.toFake[f] = fake
+			}
+		}
+	}
+	ps.realNames = alternation(ps.toFake)
+	ps.fakeNames = alternation(ps.toReal)
+	return ps
+}
+
+// pseudonymizable skips names too short to replace without mangling
+// ordinary words
+func pseudonymizable(name string) bool {
+	return utf8.RuneCountInString(strings.TrimSpace(name)) >= 3
+}
+
+// alternation matches any key, longest first
+func alternation(m map[string]string) *regexp.Regexp {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, regexp.QuoteMeta(k))
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return regexp.MustCompile(strings.Join(keys, "|"))
+}
+
+// replaceWords replaces whole-word matches of re using m
+func replaceWords(s string, re *regexp.Regexp, m map[string]string) string {
+	if re == nil {
+		return s
+	}
+	var b strings.Builder
+	last := 0
+	for _, loc := range re.FindAllStringIndex(s, -1) {
+		if !wordBoundary(s, loc[0], loc[1]) {
+			continue
+		}
+		b.WriteString(s[last:loc[0]])
+		b.WriteString(m[s[loc[0]:loc[1]]])
+		last = loc[1]
+	}
+	if last == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// wordBoundary reports whether s[start:end] isn't part of a longer word
+func wordBoundary(s string, start, end int) bool {
+	isWord := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' }
+	if r, _ := utf8.DecodeLastRuneInString(s[:start]); start > 0 && isWord(r) {
+		return false
+	}
+	if r, _ := utf8.DecodeRuneInString(s[end:]); end < len(s) && isWord(r) {
+		return false
+	}
+	return true
+}
+
+// text swaps real identities for pseudonyms and hides email addresses
+func (ps *pseudonymizer) text(s string) string {
+	s = ps.emailRegex.ReplaceAllString(s, "[email hidden]")
+	return replaceWords(s, ps.realNames, ps.toFake)
+}
+
+// Pseudonymize replaces user identities in tool output when
+// SLACK_MCP_PSEUDONYMIZE is on
+func Pseudonymize(p *provider.ApiProvider, text string) string {
+	if p == nil || !pseudonymsEnabled() {
+		return text
+	}
+	return loadPseudonymizer(p).text(text)
+}
+
+// Depseudonymize translates pseudonyms in tool arguments back to the real
+// users' handles, so the assistant can refer to people by the names it saw
+func Depseudonymize(p *provider.ApiProvider, params map[string]interface{}) {
+	if p == nil || !pseudonymsEnabled() {
+		return
+	}
+	ps := loadPseudonymizer(p)
+	for k, v := range params {
+		if strings.HasPrefix(k, "_") {
+			continue
+		}
+		switch t := v.(type) {
+		case string:
+			params[k] = replaceWords(t, ps.fakeNames, ps.toReal)
+		case []interface{}:
+			for i, item := range t {
+				if s, ok := item.(string); ok {
+					t[i] = replaceWords(s, ps.fakeNames, ps.toReal)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package features
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aaronsb/slack-mcp/pkg/cache"
+	"github.com/slack-go/slack"
+)
+
+func TestAssignPseudonyms(t *testing.T) {
+	users := map[string]slack.User{
+		"U1": {ID: "U1", Name: "ann"},
+		"U2": {ID: "U2", Name: "bob"},
+	}
+	saved := map[string]pseudonymEntry{"U1": {Pseudonym: basePseudonym("U2"), Name: "ann"}}
+
+	mapping, changed := assignPseudonyms(users, saved)
+	if !changed {
+		t.Error("a new user should change the mapping")
+	}
+	if mapping["U1"].Pseudonym != basePseudonym("U2") {
+		t.Errorf("saved pseudonym not kept: %v", mapping["U1"])
+	}
+	if mapping["U2"].Pseudonym != basePseudonym("U2")+" 2" {
+		t.Errorf("collision not numbered: %v", mapping["U2"])
+	}
+
+	again, changed := assignPseudonyms(users, mapping)
+	if changed || again["U2"] != mapping["U2"] {
+		t.Errorf("mapping should be stable: %v", again)
+	}
+}
+
+func TestPseudonymizerText(t *testing.T) {
+	users := map[string]slack.User{
+		"U1": {ID: "U1", Name: "asmith", RealName: "Ann Smith", Profile: slack.UserProfile{FirstName: "Ann", DisplayName: "annie"}},
+		"U2": {ID: "U2", Name: "bjones", RealName: "Bob Jones", Profile: slack.UserProfile{FirstName: "Bob"}},
+		"U3": {ID: "U3", Name: "bking", RealName: "Bob King", Profile: slack.UserProfile{FirstName: "Bob"}},
+	}
+	mapping, _ := assignPseudonyms(users, nil)
+	ps := newPseudonymizer(users, mapping)
+	ann := mapping["U1"].Pseudonym
+
+	got := ps.text("Ann Smith (@asmith, ann@example.com) asked annie and Bob about Annual plans")
+	for _, leak := range []string{"Ann Smith", "asmith", "ann@example.com", "annie"} {
+		if strings.Contains(got, leak) {
+			t.Errorf("%q leaked in %q", leak, got)
+		}
+	}
+	if !strings.Contains(got, "@"+ann) || !strings.Contains(got, "Annual") || !strings.Contains(got, "Bob") {
+		t.Errorf("unexpected output %q", got)
+	}
+
+	if back := replaceWords("ping "+ann+" today", ps.fakeNames, ps.toReal); back != "ping asmith today" {
+		t.Errorf("reverse = %q", back)
+	}
+}
+
+func TestStorePseudonymizerPerStore(t *testing.T) {
+	alice, _ := cache.NewStoreIn(filepath.Join(t.TempDir(), "alice"))
+	bob, _ := cache.NewStoreIn(filepath.Join(t.TempDir(), "bob"))
+	aliceUsers := map[string]slack.User{"U1": {ID: "U1", Name: "ann"}}
+	bobUsers := map[string]slack.User{"U9": {ID: "U9", Name: "zed"}}
+
+	// Same number of users, different people: each store keeps its own
+	a := storePseudonymizer(alice, aliceUsers)
+	b := storePseudonymizer(bob, bobUsers)
+	if a.toReal[basePseudonym("U1")] != "ann" || b.toReal[basePseudonym("U9")] != "zed" {
+		t.Fatalf("stores share a pseudonymizer: %v, %v", a.toReal, b.toReal)
+	}
+	if storePseudonymizer(alice, aliceUsers) != a {
+		t.Error("unchanged users should reuse the cached pseudonymizer")
+	}
+
+	// A rename with the same count is a change
+	renamed := map[string]slack.User{"U1": {ID: "U1", Name: "annie"}}
+	if ps := storePseudonymizer(alice, renamed); ps == a || ps.toReal[basePseudonym("U1")] != "annie" {
+		t.Errorf("rename not picked up: %v", ps.toReal)
+	}
+}
@@ -0,0 +1,102 @@
+package features
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aaronsb/slack-mcp/pkg/cache"
+	"github.com/aaronsb/slack-mcp/pkg/paths"
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/aaronsb/slack-mcp/pkg/setup"
+)
+
+// PurgeLocalData deletes everything the server has stored on this machine,
+// for data-subject requests or before handing a laptop back
+var PurgeLocalData = &Feature{
+	Name:        "purge-local-data",
+	Description: "Delete all locally stored Slack data (caches, event and usage logs, collections, search indexes, pseudonym mappings, the log file) and report exactly what was removed. On a server shared by several identities, only the acting identity's data is removed. Without confirm=true it only lists what would go.",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"confirm": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Actually delete. Without it, list what would be deleted.",
+				"default":     false,
+			},
+			"includeCredentials": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Also delete stored Slack credentials; auth-setup is needed afterwards",
+				"default":     false,
+			},
+		},
+	},
+	Handler: purgeLocalDataHandler,
+}
+
+func purgeLocalDataHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	confirm, _ := params["confirm"].(bool)
+	credentials, _ := params["includeCredentials"].(bool)
+
+	// Purging works before auth too, so the provider is optional here
+	var store *cache.Store
+	if apiProvider, ok := params["_provider"].(*provider.ApiProvider); ok && apiProvider != nil {
+		store = apiProvider.Store()
+	}
+
+	// With several identities a call clears only the acting one's data;
+	// the rest, and the shared log, belong to other people
+	identity, _ := params["_identity"].(string)
+	var report *cache.PurgeReport
+	if identity != "" {
+		if store == nil {
+			return &FeatureResult{Success: false, Message: fmt.Sprintf("Identity %q has no local data store", identity)}, nil
+		}
+		report = cache.PurgeStore(store, !confirm)
+		if credentials && confirm {
+			if err := setup.RemoveIdentity(identity); err != nil {
+				report.Failed = append(report.Failed, "credentials: "+err.Error())
+			}
+		}
+	} else {
+		report = cache.PurgeLocalData(store, credentials, !confirm)
+	}
+
+	files := make([]map[string]interface{}, 0, len(report.Files))
+	for _, f := range report.Files {
+		files = append(files, map[string]interface{}{"path": f.Path, "size": int(f.Size)})
+	}
+	data := map[string]interface{}{
+		"files":       files,
+		"bytes":       int(report.Bytes),
+		"dryRun":      report.DryRun,
+		"failed":      report.Failed,
+		"credentials": credentials,
+	}
+	if identity != "" {
+		data["identity"] = identity
+	}
+
+	if report.DryRun {
+		return &FeatureResult{
+			Success:     true,
+			Message:     fmt.Sprintf("Would remove %d file(s), %d bytes", len(report.Files), report.Bytes),
+			Data:        data,
+			NextActions: []string{"purge-local-data confirm=true"},
+		}, nil
+	}
+
+	result := &FeatureResult{
+		Success: len(report.Failed) == 0,
+		Message: fmt.Sprintf("Removed %d file(s), %d bytes", len(report.Files), report.Bytes),
+		Data:    data,
+		Guidance: "Nothing more is written to disk until the server restarts; in-memory caches are dropped on restart. " +
+			"Files were overwritten before removal, but SSDs and copy-on-write filesystems may keep old blocks.",
+	}
+	switch {
+	case !credentials:
+		result.Guidance += fmt.Sprintf(" Credentials in %s were kept.", paths.ConfigDir())
+	case identity != "":
+		result.Guidance += fmt.Sprintf(" The stored token for %q was removed; it still works for this session until the server restarts.", identity)
+	}
+	return result, nil
+}
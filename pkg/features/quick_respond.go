@@ -0,0 +1,211 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// QuickRespond answers a message with a shortcut: a reaction for the
+// intent, and optionally a one-line reply in its thread. Shortcuts are
+// configured with SLACK_MCP_QUICK_RESPONSES.
+var QuickRespond = &Feature{
+	Name:        "quick-respond",
+	Description: "Triage shortcut: react to a message for an intent (ack → 👀, done → ✅, approve → 👍 by default) and optionally post a one-line reply in its thread",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel": map[string]interface{}{
+				"type":        "string",
+				"description": "Channel name or ID containing the message",
+			},
+			"messageTs": map[string]interface{}{
+				"type":        "string",
+				"description": "Timestamp of the message to respond to",
+			},
+			"intent": map[string]interface{}{
+				"type":        "string",
+				"description": "Shortcut to apply: 'ack', 'done', 'approve', or one configured in SLACK_MCP_QUICK_RESPONSES",
+			},
+			"reply": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Also post the shortcut's one-line reply in the message's thread",
+				"default":     false,
+			},
+			"text": map[string]interface{}{
+				"type":        "string",
+				"description": "One-line reply to post instead of the shortcut's own (implies reply=true)",
+			},
+		},
+		"required": []string{"channel", "messageTs", "intent"},
+	},
+	Handler: quickRespondHandler,
+}
+
+// quickResponse is what a shortcut does: react with emoji, and reply with
+// text when a reply is asked for
+type quickResponse struct {
+	emoji string
+	reply string
+}
+
+var defaultQuickResponses = map[string]quickResponse{
+	"ack":     {emoji: "eyes", reply: "Looking into this"},
+	"done":    {emoji: "white_check_mark", reply: "Done"},
+	"approve": {emoji: "+1", reply: "Approved"},
+}
+
+// loadQuickResponses reads SLACK_MCP_QUICK_RESPONSES over the defaults.
+// Entries are separated by ';' and look like "intent=emoji" or
+// "intent=emoji|reply text"; "intent=" drops a default.
+func loadQuickResponses() map[string]quickResponse {
+	shortcuts := make(map[string]quickResponse, len(defaultQuickResponses))
+	for intent, r := range defaultQuickResponses {
+		shortcuts[intent] = r
+	}
+	for _, part := range strings.Split(os.Getenv("SLACK_MCP_QUICK_RESPONSES"), ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		intent, spec, ok := strings.Cut(part, "=")
+		intent = strings.ToLower(strings.TrimSpace(intent))
+		if !ok || intent == "" {
+			log.Printf("Ignoring SLACK_MCP_QUICK_RESPONSES entry %q", part)
+			continue
+		}
+		emoji, reply, _ := strings.Cut(spec, "|")
+		emoji = strings.Trim(strings.TrimSpace(emoji), ":")
+		if emoji == "" {
+			delete(shortcuts, intent)
+			continue
+		}
+		shortcuts[intent] = quickResponse{emoji: emoji, reply: oneLine(reply)}
+	}
+	return shortcuts
+}
+
+// oneLine collapses text to a single line
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func quickRespondHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	channel, _ := params["channel"].(string)
+	messageTs, _ := params["messageTs"].(string)
+	intent, _ := params["intent"].(string)
+	intent = strings.ToLower(strings.TrimSpace(intent))
+	text, _ := params["text"].(string)
+	text = oneLine(text)
+	reply, _ := params["reply"].(bool)
+	reply = reply || text != ""
+
+	shortcuts := loadQuickResponses()
+	shortcut, ok := shortcuts[intent]
+	if !ok {
+		intents := make([]string, 0, len(shortcuts))
+		for name, r := range shortcuts {
+			intents = append(intents, fmt.Sprintf("%s (:%s:)", name, r.emoji))
+		}
+		sort.Strings(intents)
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Unknown intent '%s'", intent),
+			Guidance: "Available shortcuts: " + strings.Join(intents, ", ") + ". Add more with SLACK_MCP_QUICK_RESPONSES.",
+		}, nil
+	}
+	if reply && text == "" {
+		text = shortcut.reply
+		if text == "" {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Shortcut '%s' has no reply text; pass text", intent),
+			}, nil
+		}
+	}
+
+	if qh := loadQuietHours(); qh != nil && qh.active(time.Now()) {
+		return quietHoursBlocked(qh, "responding", time.Now()), nil
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	channelID := resolveChannelForSending(apiProvider, api, channel)
+	if channelID == "" {
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Could not find channel '%s'", channel),
+			Guidance: "Use 'list-channels' to see available channels",
+		}, nil
+	}
+	channelName := resolveChannelName(ctx, apiProvider, channelID, channel)
+
+	// A reaction that's already there counts as done, so a retried
+	// shortcut still posts its reply
+	err = api.AddReactionContext(ctx, shortcut.emoji, slack.NewRefToMessage(channelID, messageTs))
+	if err != nil && err.Error() != "already_reacted" {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to add reaction: %v", err),
+		}, nil
+	}
+	if err == nil {
+		apiProvider.RecordAction(provider.UsageReactions, 1)
+	}
+
+	data := map[string]interface{}{
+		"intent":    intent,
+		"emoji":     shortcut.emoji,
+		"channel":   channelName,
+		"channelId": channelID,
+		"messageTs": messageTs,
+	}
+	result := &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Reacted :%s: in %s", shortcut.emoji, channelName),
+		Data:    data,
+	}
+	if !reply {
+		return result, nil
+	}
+
+	// Reply in the message's thread, or start one
+	threadTs := messageTs
+	if original, err := fetchMessage(ctx, api, channelID, messageTs); err == nil && original.ThreadTimestamp != "" {
+		threadTs = original.ThreadTimestamp
+	}
+	_, replyTs, err := api.PostMessageContext(ctx, channelID, slack.MsgOptionText(text, false), slack.MsgOptionTS(threadTs))
+	if err != nil {
+		result.Success = false
+		result.Message = fmt.Sprintf("Reacted :%s: in %s, but the reply failed: %v", shortcut.emoji, channelName, err)
+		result.Guidance = "The reaction stays; retry only the reply"
+		result.NextActions = []string{fmt.Sprintf("send-message channel='%s' threadTs='%s' message='%s'", channelID, threadTs, text)}
+		return result, nil
+	}
+	apiProvider.RecordAction(provider.UsageMessagesSent, 1)
+
+	data["reply"] = text
+	data["replyTs"] = replyTs
+	result.Message = fmt.Sprintf("Reacted :%s: and replied \"%s\" in %s", shortcut.emoji, text, channelName)
+	return result, nil
+}
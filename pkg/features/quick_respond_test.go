@@ -0,0 +1,24 @@
+package features
+
+import "testing"
+
+func TestLoadQuickResponses(t *testing.T) {
+	t.Setenv("SLACK_MCP_QUICK_RESPONSES", "blocked=:no_entry:|Blocked,  see\nthread; approve=shipit; done=; bogus")
+	got := loadQuickResponses()
+
+	if r := got["blocked"]; r.emoji != "no_entry" || r.reply != "Blocked, see thread" {
+		t.Errorf("blocked = %+v", r)
+	}
+	if r := got["approve"]; r.emoji != "shipit" || r.reply != "" {
+		t.Errorf("approve = %+v, want the emoji replaced and no reply", r)
+	}
+	if _, ok := got["done"]; ok {
+		t.Error("done should be dropped by an empty emoji")
+	}
+	if r := got["ack"]; r != defaultQuickResponses["ack"] {
+		t.Errorf("ack = %+v, want the default", r)
+	}
+	if _, ok := got["bogus"]; ok {
+		t.Error("entry without '=' should be ignored")
+	}
+}
@@ -0,0 +1,118 @@
+package features
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Quiet hours keep an unattended agent from pinging people overnight.
+//
+//	SLACK_MCP_QUIET_HOURS="22:00-07:00"     local-time window, may wrap midnight
+//	SLACK_MCP_QUIET_HOURS_MODE="schedule"   "block" (default) or "schedule"
+//
+// In block mode send-message and react are refused during the window. In
+// schedule mode messages are handed to chat.scheduleMessage for the end of
+// the window; reactions cannot be scheduled and are always refused.
+const (
+	quietModeBlock    = "block"
+	quietModeSchedule = "schedule"
+)
+
+type quietHours struct {
+	start int // minutes after local midnight
+	end   int
+	mode  string
+	spec  string
+}
+
+// loadQuietHours reads the quiet-hours window from the environment.
+// Returns nil when quiet hours are not configured or the spec is invalid.
+func loadQuietHours() *quietHours {
+	spec := strings.TrimSpace(os.Getenv("SLACK_MCP_QUIET_HOURS"))
+	if spec == "" {
+		return nil
+	}
+	q, err := parseQuietHours(spec)
+	if err != nil {
+		log.Printf("Ignoring SLACK_MCP_QUIET_HOURS: %v", err)
+		return nil
+	}
+	if strings.EqualFold(os.Getenv("SLACK_MCP_QUIET_HOURS_MODE"), quietModeSchedule) {
+		q.mode = quietModeSchedule
+	}
+	return q
+}
+
+// parseQuietHours parses "HH:MM-HH:MM" into a window
+func parseQuietHours(spec string) (*quietHours, error) {
+	parts := strings.Split(spec, "-")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("expected HH:MM-HH:MM, got %q", spec)
+	}
+	start, err := parseClock(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseClock(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	if start == end {
+		return nil, fmt.Errorf("start and end are the same in %q", spec)
+	}
+	return &quietHours{start: start, end: end, mode: quietModeBlock, spec: spec}, nil
+}
+
+func parseClock(s string) (int, error) {
+	hm := strings.Split(strings.TrimSpace(s), ":")
+	if len(hm) != 2 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	h, err := strconv.Atoi(hm[0])
+	if err != nil || h < 0 || h > 23 {
+		return 0, fmt.Errorf("invalid hour in %q", s)
+	}
+	m, err := strconv.Atoi(hm[1])
+	if err != nil || m < 0 || m > 59 {
+		return 0, fmt.Errorf("invalid minute in %q", s)
+	}
+	return h*60 + m, nil
+}
+
+// active reports whether t falls inside the quiet window
+func (q *quietHours) active(t time.Time) bool {
+	min := t.Hour()*60 + t.Minute()
+	if q.start < q.end {
+		return min >= q.start && min < q.end
+	}
+	// Window wraps midnight, e.g. 22:00-07:00
+	return min >= q.start || min < q.end
+}
+
+// endAfter returns the next time the quiet window ends after t
+func (q *quietHours) endAfter(t time.Time) time.Time {
+	end := time.Date(t.Year(), t.Month(), t.Day(), q.end/60, q.end%60, 0, 0, t.Location())
+	if !end.After(t) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+// quietHoursBlocked builds the refusal returned when an action is held
+func quietHoursBlocked(q *quietHours, action string, now time.Time) *FeatureResult {
+	resume := q.endAfter(now)
+	return &FeatureResult{
+		Success: false,
+		Message: fmt.Sprintf("Quiet hours are in effect (%s): %s is blocked until %s",
+			q.spec, action, resume.Format("Mon 15:04")),
+		Data: map[string]interface{}{
+			"quietHours": q.spec,
+			"resumesAt":  resume.Format(time.RFC3339),
+		},
+		Guidance: "🌙 Hold this until quiet hours end, or set SLACK_MCP_QUIET_HOURS_MODE=schedule to queue messages automatically",
+	}
+}
@@ -0,0 +1,56 @@
+package features
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseQuietHours(t *testing.T) {
+	for _, spec := range []string{"", "22:00", "25:00-07:00", "22:00-07:60", "08:00-08:00"} {
+		if _, err := parseQuietHours(spec); err == nil {
+			t.Errorf("parseQuietHours(%q) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestQuietHoursActive(t *testing.T) {
+	at := func(h, m int) time.Time {
+		return time.Date(2026, 3, 10, h, m, 0, 0, time.Local)
+	}
+
+	overnight, err := parseQuietHours("22:00-07:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	daytime, err := parseQuietHours("12:00-13:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		q    *quietHours
+		t    time.Time
+		want bool
+	}{
+		{overnight, at(23, 0), true},
+		{overnight, at(3, 0), true},
+		{overnight, at(7, 0), false},
+		{overnight, at(21, 59), false},
+		{daytime, at(12, 0), true},
+		{daytime, at(13, 29), true},
+		{daytime, at(13, 30), false},
+		{daytime, at(3, 0), false},
+	}
+	for _, tt := range tests {
+		if got := tt.q.active(tt.t); got != tt.want {
+			t.Errorf("%s active at %s = %v, want %v", tt.q.spec, tt.t.Format("15:04"), got, tt.want)
+		}
+	}
+
+	if end := overnight.endAfter(at(23, 0)); !end.Equal(at(7, 0).AddDate(0, 0, 1)) {
+		t.Errorf("endAfter 23:00 = %v, want next day 07:00", end)
+	}
+	if end := overnight.endAfter(at(3, 0)); !end.Equal(at(7, 0)) {
+		t.Errorf("endAfter 03:00 = %v, want same day 07:00", end)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aaronsb/slack-mcp/pkg/provider"
 	"github.com/slack-go/slack"
@@ -49,6 +50,13 @@ func reactHandler(ctx context.Context, params map[string]interface{}) (*FeatureR
 		remove = r
 	}
 
+	// Removing a reaction doesn't notify anyone, so only adds are held
+	if !remove {
+		if qh := loadQuietHours(); qh != nil && qh.active(time.Now()) {
+			return quietHoursBlocked(qh, "reacting", time.Now()), nil
+		}
+	}
+
 	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
 	if !ok {
 		return &FeatureResult{
@@ -95,6 +103,10 @@ func reactHandler(ctx context.Context, params map[string]interface{}) (*FeatureR
 		}, nil
 	}
 
+	if !remove {
+		apiProvider.RecordAction(provider.UsageReactions, 1)
+	}
+
 	channelName := resolveChannelName(ctx, apiProvider, channelID, channel)
 
 	return &FeatureResult{
@@ -0,0 +1,194 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// CheckReactionsToMe reports the reactions other people left on the
+// user's recent messages, a response signal no other tool surfaces
+var CheckReactionsToMe = &Feature{
+	Name:        "check-reactions-to-me",
+	Description: "See the reactions others left on your recent messages: who reacted with what, and which posts drew the most response",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"timeframe": map[string]interface{}{
+				"type":        "string",
+				"description": "How far back to look at your messages (e.g., '1d', '7d', '2w')",
+				"default":     "7d",
+			},
+			"channel": map[string]interface{}{
+				"type":        "string",
+				"description": "Only messages in this channel",
+			},
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": "How many of your most recent messages to check (default: 20, max: 50)",
+				"default":     20,
+			},
+			"includeUnreacted": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Also list messages nobody reacted to",
+				"default":     false,
+			},
+		},
+	},
+	Handler: checkReactionsToMeHandler,
+}
+
+func checkReactionsToMeHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	timeframe := "7d"
+	if t, ok := params["timeframe"].(string); ok && t != "" {
+		timeframe = t
+	}
+	limit := 20
+	if l, ok := params["limit"].(float64); ok && l > 0 {
+		limit = int(l)
+		if limit > 50 {
+			limit = 50
+		}
+	}
+	includeUnreacted, _ := params["includeUnreacted"].(bool)
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+	self, err := apiProvider.Self()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get user info: %v", err),
+		}, nil
+	}
+
+	query := "from:@me " + parseTimeframeToDateFilter(timeframe)
+	if channel, ok := params["channel"].(string); ok && channel != "" {
+		query += " in:" + channel
+	}
+	sp := slack.NewSearchParameters()
+	sp.Sort = "timestamp"
+	sp.Count = limit
+	res, err := api.SearchMessagesContext(ctx, query, sp)
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to find your messages: %v", err),
+		}, nil
+	}
+
+	usersMap := apiProvider.ProvideUsersMap()
+	messages := []map[string]interface{}{}
+	emojiTotals := map[string]int{}
+	reactors := map[string]bool{}
+	totalReactions := 0
+	checked := 0
+
+	for _, m := range res.Matches {
+		if m.User != self.UserID {
+			continue
+		}
+		checked++
+		item, err := api.GetReactionsContext(ctx, slack.NewRefToMessage(m.Channel.ID, m.Timestamp), slack.GetReactionsParameters{Full: true})
+		if err != nil {
+			continue
+		}
+
+		reactions := []map[string]interface{}{}
+		count := 0
+		for _, r := range item.Reactions {
+			var names []string
+			for _, u := range r.Users {
+				if u == self.UserID {
+					continue
+				}
+				reactors[u] = true
+				names = append(names, userDisplayName(u, usersMap))
+			}
+			if len(names) == 0 {
+				continue
+			}
+			count += len(names)
+			emojiTotals[r.Name] += len(names)
+			reactions = append(reactions, map[string]interface{}{
+				"emoji": r.Name,
+				"count": len(names),
+				"users": names,
+			})
+		}
+		if count == 0 && !includeUnreacted {
+			continue
+		}
+		sort.Slice(reactions, func(i, j int) bool {
+			return reactions[i]["count"].(int) > reactions[j]["count"].(int)
+		})
+		totalReactions += count
+
+		channelName := m.Channel.Name
+		if channelName == "" || isChannelID(channelName) {
+			channelName = apiProvider.ResolveChannelName(ctx, m.Channel.ID)
+		}
+		messages = append(messages, map[string]interface{}{
+			"channel":   channelName,
+			"message":   m.Text,
+			"timestamp": formatTimestamp(parseSlackTimestamp(m.Timestamp)),
+			"messageTs": m.Timestamp,
+			"reactions": reactions,
+			"total":     count,
+			"permalink": m.Permalink,
+		})
+	}
+
+	// Most-reacted first; recency breaks ties
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i]["total"].(int) > messages[j]["total"].(int)
+	})
+
+	topEmoji := make([]map[string]interface{}, 0, len(emojiTotals))
+	for name, n := range emojiTotals {
+		topEmoji = append(topEmoji, map[string]interface{}{"emoji": name, "count": n})
+	}
+	sort.Slice(topEmoji, func(i, j int) bool {
+		if topEmoji[i]["count"].(int) != topEmoji[j]["count"].(int) {
+			return topEmoji[i]["count"].(int) > topEmoji[j]["count"].(int)
+		}
+		return topEmoji[i]["emoji"].(string) < topEmoji[j]["emoji"].(string)
+	})
+
+	result := &FeatureResult{
+		Success: true,
+		Data: map[string]interface{}{
+			"messages": messages,
+			"summary": map[string]interface{}{
+				"messagesChecked": checked,
+				"totalReactions":  totalReactions,
+				"reactors":        len(reactors),
+				"topEmoji":        topEmoji,
+			},
+		},
+		Message:     fmt.Sprintf("%d reactions from %d people on %d of your messages", totalReactions, len(reactors), checked),
+		ResultCount: len(messages),
+	}
+	if totalReactions == 0 {
+		result.Guidance = fmt.Sprintf("No reactions on your last %d messages. Use check-message-reach on a specific post to see replies too.", checked)
+	} else {
+		result.Guidance = "Reactions are a lightweight acknowledgement; use check-message-reach for replies and engagement rate on one post."
+	}
+	return result, nil
+}
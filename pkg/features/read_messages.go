@@ -0,0 +1,221 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// ReadMessages exposes conversations.history directly, without catch-up's
+// importance heuristics, for agents that want precise control over the
+// window they read
+var ReadMessages = &Feature{
+	Name:        "read-messages",
+	Description: "Read a channel's messages exactly as stored, with precise oldest/latest bounds and cursor paging. No filtering or summarizing; use catch-up for that. Does NOT mark messages as read.",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel": map[string]interface{}{
+				"type":        "string",
+				"description": "Channel name or ID (or a username for a DM)",
+			},
+			"oldest": map[string]interface{}{
+				"type":        "string",
+				"description": "Only messages after this point: a message timestamp, a relative period ('6h', '2d'), or an RFC3339 time",
+			},
+			"latest": map[string]interface{}{
+				"type":        "string",
+				"description": "Only messages before this point, in the same formats as oldest",
+			},
+			"inclusive": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Include messages exactly at oldest/latest",
+				"default":     false,
+			},
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": "Messages per page (default 50, max 200)",
+				"default":     50,
+			},
+			"cursor": map[string]interface{}{
+				"type":        "string",
+				"description": "Pagination cursor from previous request",
+			},
+		},
+		"required": []string{"channel"},
+	},
+	Handler: readMessagesHandler,
+}
+
+func readMessagesHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	channel, _ := params["channel"].(string)
+	cursor, _ := params["cursor"].(string)
+	inclusive, _ := params["inclusive"].(bool)
+
+	limit := 50
+	if l, ok := params["limit"].(float64); ok {
+		limit = int(l)
+		if limit < 1 {
+			limit = 1
+		}
+		if limit > 200 {
+			limit = 200
+		}
+	}
+
+	oldest, err := parseMessageBound(params["oldest"])
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Invalid oldest: %v", err),
+		}, nil
+	}
+	latest, err := parseMessageBound(params["latest"])
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Invalid latest: %v", err),
+		}, nil
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	channelID := resolveChannelForSending(apiProvider, api, channel)
+	if channelID == "" {
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Could not find channel or user '%s'", channel),
+			Guidance: "Use 'list-channels' to see available channels, or provide a username for DMs",
+		}, nil
+	}
+
+	resp, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+		ChannelID: channelID,
+		Oldest:    oldest,
+		Latest:    latest,
+		Inclusive: inclusive,
+		Limit:     limit,
+		Cursor:    cursor,
+	})
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to fetch messages: %v", err),
+		}, nil
+	}
+
+	// Slack returns newest-first; present oldest-first like get-context
+	usersMap := apiProvider.ProvideUsersMap()
+	messages := make([]map[string]interface{}, 0, len(resp.Messages))
+	for i := len(resp.Messages) - 1; i >= 0; i-- {
+		msg := resp.Messages[i]
+		entry := messageEntry(msg, usersMap)
+		if msg.SubType != "" {
+			entry["subtype"] = msg.SubType
+		}
+		if msg.BotID != "" {
+			entry["bot"] = true
+		}
+		if reactions := reactionEntries(msg, usersMap); len(reactions) > 0 {
+			entry["reactions"] = reactions
+		}
+		messages = append(messages, entry)
+	}
+
+	channelName := resolveChannelName(ctx, apiProvider, channelID, channel)
+	nextCursor := resp.ResponseMetaData.NextCursor
+
+	result := &FeatureResult{
+		Success:     true,
+		Message:     fmt.Sprintf("Read %d messages from %s", len(messages), channelName),
+		ResultCount: len(messages),
+		Data: map[string]interface{}{
+			"channel":      channelName,
+			"channelId":    channelID,
+			"messages":     messages,
+			"messageCount": len(messages),
+			"oldest":       oldest,
+			"latest":       latest,
+		},
+		Pagination: &Pagination{
+			Cursor:     cursor,
+			NextCursor: nextCursor,
+			HasMore:    resp.HasMore && nextCursor != "",
+			PageSize:   len(messages),
+		},
+	}
+
+	if len(messages) > 0 {
+		result.NextActions = []string{
+			fmt.Sprintf("Open a thread: get-context channel='%s' messageTs='<ts>'", channel),
+		}
+	}
+	if resp.HasMore && nextCursor != "" {
+		result.Guidance = "More messages are available; pages go backwards in time from latest."
+		result.NextActions = append(result.NextActions,
+			fmt.Sprintf("Next page: read-messages channel='%s' cursor='%s'", channel, nextCursor))
+	}
+
+	return result, nil
+}
+
+// parseMessageBound accepts a Slack timestamp, a relative period, or an
+// RFC3339 time and returns a Slack timestamp ("" when unset)
+func parseMessageBound(v interface{}) (string, error) {
+	s, _ := v.(string)
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", nil
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return s, nil
+	}
+	if last := s[len(s)-1]; last == 'h' || last == 'd' || last == 'w' {
+		t, err := parseTimePeriod(s)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(t.Unix(), 10), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return "", fmt.Errorf("%q is not a timestamp, relative period, or RFC3339 time", s)
+	}
+	return strconv.FormatInt(t.Unix(), 10), nil
+}
+
+// reactionEntries lists a message's reactions with the names of who reacted
+func reactionEntries(msg slack.Message, usersMap map[string]slack.User) []map[string]interface{} {
+	reactions := make([]map[string]interface{}, 0, len(msg.Reactions))
+	for _, r := range msg.Reactions {
+		users := make([]string, 0, len(r.Users))
+		for _, u := range r.Users {
+			users = append(users, userDisplayName(u, usersMap))
+		}
+		reactions = append(reactions, map[string]interface{}{
+			"emoji": r.Name,
+			"count": r.Count,
+			"users": users,
+		})
+	}
+	return reactions
+}
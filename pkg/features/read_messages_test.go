@@ -0,0 +1,37 @@
+package features
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseMessageBound(t *testing.T) {
+	for _, in := range []interface{}{nil, "", "  "} {
+		if got, err := parseMessageBound(in); err != nil || got != "" {
+			t.Errorf("parseMessageBound(%q) = %q, %v; want empty", in, got, err)
+		}
+	}
+
+	if got, _ := parseMessageBound("1712345678.123456"); got != "1712345678.123456" {
+		t.Errorf("timestamp not passed through: %q", got)
+	}
+
+	got, err := parseMessageBound("2024-04-05T12:00:00Z")
+	if err != nil || got != "1712318400" {
+		t.Errorf("RFC3339 = %q, %v; want 1712318400", got, err)
+	}
+
+	got, err = parseMessageBound("2d")
+	if err != nil {
+		t.Fatalf("relative: %v", err)
+	}
+	sec, _ := strconv.ParseInt(got, 10, 64)
+	if d := time.Since(time.Unix(sec, 0)); d < 47*time.Hour || d > 49*time.Hour {
+		t.Errorf("2d resolved to %v ago", d)
+	}
+
+	if _, err := parseMessageBound("yesterday"); err == nil {
+		t.Error("expected an error for an unparseable bound")
+	}
+}
@@ -0,0 +1,225 @@
+package features
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// ReadThread exposes conversations.replies with paging and full message
+// bodies: the parent's metadata plus every reply with its reactions
+var ReadThread = &Feature{
+	Name:        "read-thread",
+	Description: "Read a full thread: parent message metadata and every reply with full text and reactions, paged with a cursor. Does NOT mark messages as read.",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"threadId": map[string]interface{}{
+				"type":        "string",
+				"description": "Thread ID as returned by other tools (channelId:threadTs), or a Slack message permalink",
+			},
+			"channel": map[string]interface{}{
+				"type":        "string",
+				"description": "Channel name or ID (alternative to threadId, with threadTs)",
+			},
+			"threadTs": map[string]interface{}{
+				"type":        "string",
+				"description": "Timestamp of the thread's parent message (with channel)",
+			},
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": "Replies per page (default 100, max 200)",
+				"default":     100,
+			},
+			"cursor": map[string]interface{}{
+				"type":        "string",
+				"description": "Pagination cursor from previous request",
+			},
+		},
+	},
+	Handler: readThreadHandler,
+}
+
+func readThreadHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	channel, _ := params["channel"].(string)
+	threadTs, _ := params["threadTs"].(string)
+	if threadID, _ := params["threadId"].(string); threadID != "" {
+		ref, err := parseThreadRef(threadID)
+		if err != nil {
+			return &FeatureResult{
+				Success: false,
+				Message: err.Error(),
+			}, nil
+		}
+		channel, threadTs = ref.ChannelID, ref.ThreadTs
+	}
+	if channel == "" || threadTs == "" {
+		return &FeatureResult{
+			Success: false,
+			Message: "Provide threadId, or channel and threadTs",
+		}, nil
+	}
+	cursor, _ := params["cursor"].(string)
+
+	limit := 100
+	if l, ok := params["limit"].(float64); ok {
+		limit = int(l)
+		if limit < 1 {
+			limit = 1
+		}
+		if limit > 200 {
+			limit = 200
+		}
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	channelID := resolveChannelForSending(apiProvider, api, channel)
+	if channelID == "" {
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Could not find channel or user '%s'", channel),
+			Guidance: "Use 'list-channels' to see available channels",
+		}, nil
+	}
+
+	msgs, hasMore, nextCursor, err := api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: channelID,
+		Timestamp: threadTs,
+		Limit:     limit,
+		Cursor:    cursor,
+	})
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get thread: %v", err),
+		}, nil
+	}
+
+	usersMap := apiProvider.ProvideUsersMap()
+	entry := func(msg slack.Message) map[string]interface{} {
+		e := messageEntry(msg, usersMap)
+		if reactions := reactionEntries(msg, usersMap); len(reactions) > 0 {
+			e["reactions"] = reactions
+		}
+		return e
+	}
+
+	// Slack repeats the parent at the head of every page
+	var parent map[string]interface{}
+	replies := make([]map[string]interface{}, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg.Timestamp == threadTs {
+			parent = entry(msg)
+			parent["reply_count"] = msg.ReplyCount
+			if len(msg.ReplyUsers) > 0 {
+				names := make([]string, 0, len(msg.ReplyUsers))
+				for _, u := range msg.ReplyUsers {
+					names = append(names, userDisplayName(u, usersMap))
+				}
+				parent["participants"] = names
+			}
+			if msg.LatestReply != "" {
+				parent["latestReply"] = formatTimestamp(parseSlackTimestamp(msg.LatestReply))
+			}
+			continue
+		}
+		replies = append(replies, entry(msg))
+	}
+
+	channelName := resolveChannelName(ctx, apiProvider, channelID, channel)
+	threadID := ThreadRef{ChannelID: channelID, ThreadTs: threadTs}.String()
+
+	// The parent leads the first page so the thread reads top to bottom
+	messages := replies
+	if parent != nil && cursor == "" {
+		messages = append([]map[string]interface{}{parent}, replies...)
+	}
+
+	// Participation covers the messages on this page; it's the whole
+	// thread only when there's a single page
+	selfID := ""
+	if self, err := apiProvider.Self(); err == nil {
+		selfID = self.UserID
+	}
+	stats := threadParticipation(msgs, threadTs)
+	participation := make([]map[string]interface{}, 0, len(stats))
+	for _, p := range stats {
+		entry := map[string]interface{}{
+			"user":         userDisplayName(p.UserID, usersMap),
+			"messages":     p.Messages,
+			"hasResponded": p.Messages > 0,
+		}
+		if p.First != "" {
+			entry["firstActivity"] = formatTimestamp(parseSlackTimestamp(p.First))
+			entry["lastActivity"] = formatTimestamp(parseSlackTimestamp(p.Last))
+		}
+		if p.Started {
+			entry["startedThread"] = true
+		}
+		if p.Mentioned {
+			entry["mentioned"] = true
+		}
+		if p.UserID == selfID {
+			entry["isYou"] = true
+		}
+		participation = append(participation, entry)
+	}
+	wholeThread := cursor == "" && !(hasMore && nextCursor != "")
+
+	result := &FeatureResult{
+		Success:     true,
+		Message:     fmt.Sprintf("Read %d replies in thread %s", len(replies), threadID),
+		ResultCount: len(replies),
+		Data: map[string]interface{}{
+			"threadId":              threadID,
+			"channel":               channelName,
+			"channelId":             channelID,
+			"threadTs":              threadTs,
+			"isThread":              true,
+			"parent":                parent,
+			"messages":              messages,
+			"participation":         participation,
+			"participationComplete": wholeThread,
+		},
+		Pagination: &Pagination{
+			Cursor:     cursor,
+			NextCursor: nextCursor,
+			HasMore:    hasMore && nextCursor != "",
+			PageSize:   len(replies),
+		},
+		NextActions: []string{
+			fmt.Sprintf("Reply to thread: send-message channel='%s' threadTs='%s'", channelID, threadTs),
+		},
+	}
+
+	if hasMore && nextCursor != "" {
+		result.NextActions = append(result.NextActions,
+			fmt.Sprintf("More replies: read-thread threadId='%s' cursor='%s'", threadID, nextCursor))
+	} else {
+		result.Guidance = "💬 Full thread loaded."
+	}
+	if wholeThread && selfID != "" {
+		if hint := participationGuidance(stats, selfID); hint != "" {
+			result.Guidance = "👤 " + hint + " " + result.Guidance
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,245 @@
+package features
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// RecentActivityTimeline merges the newest unread message of every
+// conversation into one stream, like Slack's "All unreads" view
+var RecentActivityTimeline = &Feature{
+	Name:        "recent-activity-timeline",
+	Description: "Skim everything new in one stream: the latest unread message from every channel, DM, and group DM, newest first, paginated",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": "Conversations per page (default: 20, max: 50)",
+				"default":     20,
+			},
+			"cursor": map[string]interface{}{
+				"type":        "string",
+				"description": "Pagination cursor from previous request",
+			},
+		},
+	},
+	Handler: recentActivityTimelineHandler,
+}
+
+// timelineItem is one conversation with unreads, as client.counts reports it
+type timelineItem struct {
+	id       string
+	kind     string // "channel", "dm", or "group-dm"
+	latest   string
+	mentions int
+}
+
+func (t timelineItem) before(o timelineItem) bool {
+	ta, tb := parseSlackTimestamp(t.latest), parseSlackTimestamp(o.latest)
+	if !ta.Equal(tb) {
+		return ta.After(tb)
+	}
+	return t.id < o.id
+}
+
+// unreadTimeline lists the conversations with unreads, newest first
+func unreadTimeline(counts *provider.ClientCountsResponse) []timelineItem {
+	var items []timelineItem
+	for _, ch := range counts.Channels {
+		if ch.HasUnreads {
+			items = append(items, timelineItem{ch.ID, "channel", ch.Latest, ch.MentionCount})
+		}
+	}
+	for _, ch := range counts.IMs {
+		if ch.HasUnreads {
+			items = append(items, timelineItem{ch.ID, "dm", ch.Latest, ch.MentionCount})
+		}
+	}
+	for _, ch := range counts.MPIMs {
+		if ch.HasUnreads {
+			items = append(items, timelineItem{ch.ID, "group-dm", ch.Latest, ch.MentionCount})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].before(items[j])
+	})
+	return items
+}
+
+// encodeTimelineCursor records the last conversation returned, so the next
+// page starts after it even if new messages reorder the stream
+func encodeTimelineCursor(last timelineItem) string {
+	raw := strings.Join([]string{"t1", last.latest, last.id}, "\x00")
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeTimelineCursor(cursor string) (timelineItem, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	parts := strings.Split(string(raw), "\x00")
+	if err != nil || len(parts) != 3 || parts[0] != "t1" {
+		return timelineItem{}, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	return timelineItem{latest: parts[1], id: parts[2]}, nil
+}
+
+// timelinePage returns up to limit items after the cursor position, and
+// whether more follow
+func timelinePage(items []timelineItem, after *timelineItem, limit int) ([]timelineItem, bool) {
+	start := 0
+	if after != nil {
+		start = sort.Search(len(items), func(i int) bool {
+			return after.before(items[i])
+		})
+	}
+	end := min(start+limit, len(items))
+	return items[start:end], end < len(items)
+}
+
+func recentActivityTimelineHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	limit := 20
+	if l, ok := params["limit"].(float64); ok {
+		limit = max(1, min(int(l), 50))
+	}
+	cursor, _ := params["cursor"].(string)
+	var after *timelineItem
+	if cursor != "" {
+		c, err := decodeTimelineCursor(cursor)
+		if err != nil {
+			return &FeatureResult{
+				Success:  false,
+				Message:  err.Error(),
+				Guidance: "Start again without a cursor",
+			}, nil
+		}
+		after = &c
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	internalClient := apiProvider.ProvideInternalClient()
+	if internalClient == nil {
+		return &FeatureResult{
+			Success:     false,
+			Message:     "The unread timeline needs a browser session (xoxc) token",
+			Guidance:    "check-unreads works with the official API and groups unreads by kind instead",
+			NextActions: []string{"check-unreads"},
+		}, nil
+	}
+	counts, err := internalClient.GetClientCounts(ctx)
+	if err != nil || !counts.OK {
+		if err == nil {
+			err = fmt.Errorf("%s", counts.Error)
+		}
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get unread counts: %v", err),
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	items := unreadTimeline(counts)
+	page, hasMore := timelinePage(items, after, limit)
+	starred := apiProvider.CachedStarredConversations()
+	usersMap := apiProvider.ProvideUsersMap()
+
+	var links permalinkBatch
+	var unreadable skippedChannels
+	entries := []map[string]interface{}{}
+	for _, item := range page {
+		info, err := apiProvider.GetChannelInfo(ctx, item.id)
+		if err != nil {
+			log.Printf("Failed to get conversation info for %s: %v", item.id, err)
+			unreadable.skip(apiProvider, item.id, err)
+			continue
+		}
+		resp, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: item.id,
+			Limit:     1,
+		})
+		if err != nil {
+			log.Printf("Failed to get history for %s: %v", item.id, err)
+			unreadable.skip(apiProvider, item.id, err)
+			continue
+		}
+		if len(resp.Messages) == 0 {
+			continue
+		}
+		msg := resp.Messages[0]
+
+		entry := map[string]interface{}{
+			"type":         item.kind,
+			"conversation": conversationLabel(*info, usersMap),
+			"channelId":    item.id,
+			"author":       getUserName(msg.User, usersMap),
+			"message":      messageBody(msg.Text, msg.Blocks, msg.Attachments),
+			"timestamp":    formatTimestamp(parseSlackTimestamp(msg.Timestamp)),
+			"threadId":     threadRefFor(item.id, msg.Timestamp, msg.ThreadTimestamp).String(),
+		}
+		if item.mentions > 0 {
+			entry["mentions"] = item.mentions
+		}
+		if starred[item.id] {
+			entry["starred"] = true
+		}
+		addAttachmentInfo(entry, "message", msg.Files, msg.Attachments, msg.Blocks)
+		links.add(entry, item.id, msg.Timestamp)
+		entries = append(entries, entry)
+	}
+	links.resolve(ctx, apiProvider)
+
+	result := &FeatureResult{
+		Success: true,
+		Data: map[string]interface{}{
+			"timeline": entries,
+			"total":    len(items),
+		},
+		Message:     fmt.Sprintf("%d conversations with unreads, newest first (showing %d)", len(items), len(entries)),
+		ResultCount: len(entries),
+		NextActions: []string{
+			"Use 'read-messages' on a channelId to read the rest of its unreads",
+			"Use 'mark-read' once you've skimmed a conversation",
+		},
+	}
+	if cursor != "" || hasMore {
+		result.Pagination = &Pagination{
+			Cursor:     cursor,
+			HasMore:    hasMore,
+			PageSize:   len(entries),
+			TotalCount: len(items),
+		}
+		if hasMore {
+			result.Pagination.NextCursor = encodeTimelineCursor(page[len(page)-1])
+		}
+	}
+	if len(items) == 0 {
+		result.Guidance = "✅ Nothing unread"
+	}
+	unreadable.mark(result, "some conversations with unreads couldn't be read")
+
+	if counts.Threads.UnreadCount > 0 {
+		result.NextActions = append(result.NextActions,
+			fmt.Sprintf("%d thread replies are unread too; they aren't in this timeline", counts.Threads.UnreadCount))
+	}
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package features
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+func TestUnreadTimelinePaging(t *testing.T) {
+	var counts provider.ClientCountsResponse
+	raw := `{"ok":true,
+		"channels":[
+			{"id":"C1","latest":"1700000300.000000","has_unreads":true,"mention_count":2},
+			{"id":"C2","latest":"1700000900.000000","has_unreads":false},
+			{"id":"C3","latest":"1700000100.000000","has_unreads":true}],
+		"ims":[{"id":"D1","latest":"1700000500.000000","has_unreads":true}],
+		"mpims":[{"id":"G1","latest":"1700000300.000000","has_unreads":true}]}`
+	if err := json.Unmarshal([]byte(raw), &counts); err != nil {
+		t.Fatal(err)
+	}
+
+	items := unreadTimeline(&counts)
+	var ids []string
+	for _, it := range items {
+		ids = append(ids, it.id)
+	}
+	want := []string{"D1", "C1", "G1", "C3"}
+	if len(ids) != len(want) {
+		t.Fatalf("got %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("got %v, want %v", ids, want)
+		}
+	}
+	if items[1].kind != "channel" || items[1].mentions != 2 || items[0].kind != "dm" || items[2].kind != "group-dm" {
+		t.Errorf("unexpected kinds or mentions: %+v", items)
+	}
+
+	page, more := timelinePage(items, nil, 2)
+	if len(page) != 2 || !more {
+		t.Fatalf("first page: %+v more=%v", page, more)
+	}
+	after, err := decodeTimelineCursor(encodeTimelineCursor(page[1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, more = timelinePage(items, &after, 2)
+	if len(page) != 2 || more || page[0].id != "G1" || page[1].id != "C3" {
+		t.Errorf("second page: %+v more=%v", page, more)
+	}
+
+	if _, err := decodeTimelineCursor("not-a-cursor"); err == nil {
+		t.Error("bad cursor decoded")
+	}
+}
@@ -0,0 +1,135 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+// GetRecentEvents reads the rolling event log so an agent can poll for
+// what happened since its last look instead of re-deriving unread state
+var GetRecentEvents = &Feature{
+	Name:        "get-recent-events",
+	Description: "Poll the local event log of mentions, DMs, thread replies, and reactions to your messages. Pass the returned nextSince back as since to get only newer events.",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"since": map[string]interface{}{
+				"type":        "string",
+				"description": "nextSince from the previous call, or a period like '1h' or '1d'. Omit for the whole log.",
+			},
+			"types": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string", "enum": []string{"mention", "dm", "thread_reply", "reaction"}},
+				"description": "Only these event types (default: all)",
+			},
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": "Maximum events to return, oldest first (default: 50, max: 200)",
+				"default":     50,
+			},
+		},
+	},
+	Handler: getRecentEventsHandler,
+}
+
+func getRecentEventsHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	since, _ := params["since"].(string)
+	since = strings.TrimSpace(since)
+	if since != "" && !strings.Contains(since, ".") {
+		oldest, err := parseTimePeriod(since)
+		if err != nil {
+			return &FeatureResult{
+				Success:  false,
+				Message:  fmt.Sprintf("Invalid since '%s'", since),
+				Guidance: "Pass nextSince from the previous call, or a period like '1h'",
+			}, nil
+		}
+		// Log positions are observation times, so a period maps onto them
+		since = fmt.Sprintf("%d.000000", oldest.Unix())
+	}
+
+	limit := 50
+	if l, ok := params["limit"].(float64); ok && l > 0 {
+		limit = int(l)
+		if limit > 200 {
+			limit = 200
+		}
+	}
+
+	events, collectErr := apiProvider.RecentEvents(ctx, since, stringList(params["types"]))
+	hasMore := len(events) > limit
+	if hasMore {
+		events = events[:limit]
+	}
+
+	usersMap := apiProvider.ProvideUsersMap()
+	channelNames := map[string]string{}
+	list := make([]map[string]interface{}, 0, len(events))
+	nextSince := since
+	for _, e := range events {
+		name, ok := channelNames[e.ChannelID]
+		if !ok {
+			name = apiProvider.ResolveChannelName(ctx, e.ChannelID)
+			channelNames[e.ChannelID] = name
+		}
+		entry := map[string]interface{}{
+			"id":        e.ID,
+			"type":      e.Type,
+			"channel":   name,
+			"timestamp": formatTimestamp(parseSlackTimestamp(e.Ts)),
+			"threadId":  threadRefFor(e.ChannelID, e.Ts, e.ThreadTs).String(),
+		}
+		if e.User != "" {
+			entry["author"] = getUserName(e.User, usersMap)
+		}
+		if e.Text != "" {
+			entry["message"] = e.Text
+		}
+		if e.Reaction != "" {
+			entry["reaction"] = e.Reaction
+		}
+		if e.Permalink != "" {
+			entry["permalink"] = e.Permalink
+		}
+		list = append(list, entry)
+		nextSince = e.ID
+	}
+
+	result := &FeatureResult{
+		Success: true,
+		Data: map[string]interface{}{
+			"events":    list,
+			"nextSince": nextSince,
+			"hasMore":   hasMore,
+			"pollEvery": refreshEvery(apiProvider.EventPollInterval()),
+		},
+		Message:     fmt.Sprintf("%d event(s)", len(list)),
+		ResultCount: len(list),
+	}
+	switch {
+	case hasMore:
+		result.Guidance = "More events are waiting. Call again with since set to nextSince."
+	case len(list) == 0:
+		result.Guidance = "Nothing new. Call again later with the same since."
+	default:
+		result.Guidance = "Pass nextSince as since on the next call to get only newer events."
+	}
+	if collectErr != nil {
+		result.Guidance += fmt.Sprintf(" Collection failed (%v); showing what was already logged.", collectErr)
+	}
+	if nextSince != "" {
+		result.NextActions = []string{fmt.Sprintf("get-recent-events since='%s'", nextSince)}
+	}
+	return result, nil
+}
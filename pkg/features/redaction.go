@@ -0,0 +1,281 @@
+package features
+
+import (
+	"context"
+	"log"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+// Output redaction. Everything a tool returns goes to the MCP client, and
+// from there usually to a hosted model, so secrets and confidential
+// channels are scrubbed on the way out.
+//
+//	SLACK_MCP_REDACT="secrets,phones"              # built-in rules; "all" or "off"
+//	SLACK_MCP_REDACT_PATTERNS='ACME-[0-9]{6}|PRJ-\w+'
+//	SLACK_MCP_CONFIDENTIAL_CHANNELS="legal,hr-*,C0123ABCD"
+//	SLACK_MCP_CONTENT_ALLOWLIST="eng-*,general,@alice"   # privacy mode
+//
+// Built-in secret rules are on by default. Messages from confidential
+// channels (names, globs, or IDs) are withheld entirely: the result still
+// says a message exists and where, but not what it says. With an allowlist
+// set, that applies to every conversation not on it; "dms" allows all
+// direct messages.
+const (
+	redactedConfidential = "[withheld: confidential channel]"
+	withheldByPolicy     = "[content withheld by policy]"
+	defaultRedactRules   = "secrets"
+)
+
+type redactRule struct {
+	name    string
+	pattern *regexp.Regexp
+	// replace is the regexp replacement; rules that keep a prefix (such as
+	// "password=") use a template
+	replace string
+}
+
+// redactRuleSets are the built-in rules, by the name used in SLACK_MCP_REDACT
+var redactRuleSets = map[string][]redactRule{
+	"secrets": {
+		{"private-key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`), "[redacted private key]"},
+		{"slack-token", regexp.MustCompile(`\b(?:xox[abcdeoprs]|xapp)-[A-Za-z0-9-]{10,}`), "[redacted slack token]"},
+		{"aws-key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`), "[redacted aws key]"},
+		{"github-token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{40,})\b`), "[redacted github token]"},
+		{"bearer", regexp.MustCompile(`(?i)\b(bearer\s+)[A-Za-z0-9._~+/-]{16,}=*`), "${1}[redacted]"},
+		{"assignment", regexp.MustCompile(`(?i)\b((?:api[_-]?key|secret|token|password|passwd)\s*[:=]\s*["']?)[^\s"'<>]{6,}`), "${1}[redacted]"},
+	},
+	"phones": {
+		{"phone", regexp.MustCompile(`(?:\+\d{1,3}[\s.-]?)?\(?\b\d{3}\)?[\s.-]\d{3}[\s.-]\d{4}\b`), "[redacted phone]"},
+	},
+	"emails": {
+		{"email", regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`), "[redacted email]"},
+	},
+}
+
+// redactTextKeys are the result fields that carry message content
+var redactTextKeys = map[string]bool{
+	"text": true, "message": true, "preview": true, "latest": true,
+	"lastMessage": true, "decision": true, "content": true, "note": true,
+}
+
+// redactExemptTools return no Slack content, so their status messages
+// aren't mistaken for it
+var redactExemptTools = map[string]bool{"auth-setup": true}
+
+// redactChannelKeys are the result fields that identify a channel
+var redactChannelKeys = []string{"channel", "channelId", "channelName", "channel_id"}
+
+type redactionPolicy struct {
+	rules        []redactRule
+	confidential []string // lowercase names, globs, or IDs
+	// allowlist, when set, is the only conversations whose content may be
+	// shown; everything else appears as counts and metadata
+	allowlist []string
+}
+
+// loadRedactionPolicy reads the policy from the environment. Unknown rule
+// names and bad patterns are logged and skipped rather than disabling
+// redaction altogether.
+func loadRedactionPolicy() *redactionPolicy {
+	p := &redactionPolicy{}
+	spec := strings.TrimSpace(os.Getenv("SLACK_MCP_REDACT"))
+	if spec == "" {
+		spec = defaultRedactRules
+	}
+	if !strings.EqualFold(spec, "off") {
+		for _, name := range stringList(strings.ToLower(spec)) {
+			if name == "all" {
+				for _, set := range []string{"secrets", "phones", "emails"} {
+					p.rules = append(p.rules, redactRuleSets[set]...)
+				}
+				continue
+			}
+			set, ok := redactRuleSets[name]
+			if !ok {
+				log.Printf("Ignoring unknown SLACK_MCP_REDACT rule %q", name)
+				continue
+			}
+			p.rules = append(p.rules, set...)
+		}
+	}
+	if expr := strings.TrimSpace(os.Getenv("SLACK_MCP_REDACT_PATTERNS")); expr != "" {
+		if re, err := regexp.Compile(expr); err != nil {
+			log.Printf("Ignoring SLACK_MCP_REDACT_PATTERNS: %v", err)
+		} else {
+			p.rules = append(p.rules, redactRule{name: "custom", pattern: re, replace: "[redacted]"})
+		}
+	}
+	for _, ch := range stringList(os.Getenv("SLACK_MCP_CONFIDENTIAL_CHANNELS")) {
+		p.confidential = append(p.confidential, strings.ToLower(strings.TrimPrefix(ch, "#")))
+	}
+	if spec := strings.TrimSpace(os.Getenv("SLACK_MCP_CONTENT_ALLOWLIST")); spec != "" {
+		p.allowlist = []string{}
+		for _, ch := range stringList(spec) {
+			p.allowlist = append(p.allowlist, strings.ToLower(strings.TrimPrefix(ch, "#")))
+		}
+	}
+	return p
+}
+
+// isConfidential reports whether a channel name or ID matches the
+// confidential list
+func (p *redactionPolicy) isConfidential(channel string) bool {
+	return matchChannelList(p.confidential, channel)
+}
+
+// verdict returns the marker that replaces content from a channel known by
+// the given names and IDs, or "" when its content may be shown
+func (p *redactionPolicy) verdict(channel ...string) string {
+	allowed := p.allowlist == nil
+	for _, c := range channel {
+		if p.isConfidential(c) {
+			return redactedConfidential
+		}
+		allowed = allowed || matchChannelList(p.allowlist, c)
+	}
+	if !allowed {
+		return withheldByPolicy
+	}
+	return ""
+}
+
+// active reports whether the policy withholds any channel content
+func (p *redactionPolicy) active() bool {
+	return len(p.confidential) > 0 || p.allowlist != nil
+}
+
+// matchChannelList matches a channel name, "@user" DM label, or ID against
+// globs; "dms" matches any direct message
+func matchChannelList(list []string, channel string) bool {
+	channel = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(channel), "#"))
+	if channel == "" {
+		return false
+	}
+	for _, pattern := range list {
+		if pattern == "dms" && isDMLabel(channel) {
+			return true
+		}
+		if ok, _ := path.Match(pattern, channel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isDMLabel recognizes the lowercased forms a DM takes in results: its
+// D-prefixed ID, "@name", or "dm: name"
+func isDMLabel(channel string) bool {
+	return strings.HasPrefix(channel, "@") || strings.HasPrefix(channel, "dm:") ||
+		(strings.HasPrefix(channel, "d") && isChannelID(strings.ToUpper(channel)))
+}
+
+// text applies the pattern rules
+func (p *redactionPolicy) text(s string) string {
+	for _, r := range p.rules {
+		s = r.pattern.ReplaceAllString(s, r.replace)
+	}
+	return s
+}
+
+// data replaces message content with a marker wherever the conversation it
+// came from is confidential or not allowlisted. A map that names its
+// channel decides for itself and everything nested under it; content with
+// no channel anywhere above it is withheld while an allowlist is set.
+func (p *redactionPolicy) data(v interface{}, marker string, known bool) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if channel, ok := mapChannel(t); ok {
+			marker, known = p.verdict(channel), true
+		}
+		if !known && p.allowlist != nil {
+			marker = withheldByPolicy
+		}
+		for k, val := range t {
+			if s, ok := val.(string); ok {
+				if marker != "" && redactTextKeys[k] && s != "" {
+					t[k] = marker
+				}
+				continue
+			}
+			t[k] = p.data(val, marker, known)
+		}
+	case []map[string]interface{}:
+		for _, m := range t {
+			p.data(m, marker, known)
+		}
+	case []interface{}:
+		for i, item := range t {
+			t[i] = p.data(item, marker, known)
+		}
+	}
+	return v
+}
+
+// mapChannel finds the channel a result map belongs to, from its channel
+// fields or a channelId:threadTs thread reference
+func mapChannel(m map[string]interface{}) (string, bool) {
+	for _, k := range redactChannelKeys {
+		if s, ok := m[k].(string); ok && s != "" {
+			return s, true
+		}
+	}
+	if id, ok := m["threadId"].(string); ok {
+		if ch, _, found := strings.Cut(id, ":"); found && ch != "" {
+			return ch, true
+		}
+	}
+	return "", false
+}
+
+// RedactResult withholds content from confidential and non-allowlisted
+// channels in a tool result before it is formatted
+func RedactResult(name string, result *FeatureResult) *FeatureResult {
+	p := loadRedactionPolicy()
+	if result == nil || !p.active() || redactExemptTools[name] {
+		return result
+	}
+	result.Data = p.data(result.Data, "", false)
+	return result
+}
+
+// RedactText applies the secret and pattern rules to formatted output
+func RedactText(text string) string {
+	return loadRedactionPolicy().text(text)
+}
+
+// RedactEvents replaces the text of events from confidential and
+// non-allowlisted channels
+func RedactEvents(ctx context.Context, ap *provider.ApiProvider, events []provider.Event) []provider.Event {
+	p := loadRedactionPolicy()
+	if !p.active() {
+		return events
+	}
+	markers := map[string]string{}
+	for i, e := range events {
+		marker, seen := markers[e.ChannelID]
+		if !seen {
+			marker = p.verdict(e.ChannelID, ap.ResolveChannelName(ctx, e.ChannelID))
+			markers[e.ChannelID] = marker
+		}
+		if marker != "" && e.Text != "" {
+			events[i].Text = marker
+		}
+	}
+	return events
+}
+
+// ScrubForReport applies every built-in rule regardless of configuration,
+// for text bound for bug reports
+func ScrubForReport(text string) string {
+	for _, set := range []string{"secrets", "phones", "emails"} {
+		for _, r := range redactRuleSets[set] {
+			text = r.pattern.ReplaceAllString(text, r.replace)
+		}
+	}
+	return text
+}
@@ -0,0 +1,155 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/aaronsb/slack-mcp/pkg/setup"
+)
+
+// RefreshCredentials picks up a fresh xoxd cookie from the local browser
+// profiles when Slack starts rejecting the stored one
+var RefreshCredentials = &Feature{
+	Name:        "refresh-credentials",
+	Description: "Check the browser-session credentials and, if Slack rejects them (the xoxd cookie expires periodically), re-read the cookie from Chrome/Edge/Chromium or Firefox profiles on disk without launching a browser",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"force": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Re-read the cookie even if the current credentials still work",
+				"default":     false,
+			},
+			"workspace": map[string]interface{}{
+				"type":        "string",
+				"description": "Workspace name from the config file (default: the default workspace)",
+			},
+		},
+	},
+	Handler: refreshCredentialsHandler,
+}
+
+func refreshCredentialsHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	force, _ := params["force"].(bool)
+	workspace, _ := params["workspace"].(string)
+	if identity, _ := params["_identity"].(string); identity != "" {
+		return reloadIdentityCredentials(params, identity, force)
+	}
+
+	res, err := setup.RefreshCredentials(workspace, force)
+	if err != nil {
+		result := &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Credential refresh failed: %v", err),
+			Guidance: "Log into Slack in a browser on this machine and try again, or run auth-setup for a guided setup",
+		}
+		if res != nil && len(res.Tried) > 0 {
+			result.Data = map[string]interface{}{"tried": res.Tried}
+		}
+		return result, nil
+	}
+
+	// The config may have been refreshed from the command line while this
+	// server kept the rejected cookie
+	running, _ := params["_provider"].(*provider.ApiProvider)
+	stale := running != nil && running.TokenType() == "session"
+	if stale {
+		_, err := running.Provide()
+		stale = err != nil
+	}
+
+	if !res.Refreshed && !stale {
+		return &FeatureResult{
+			Success: true,
+			Message: fmt.Sprintf("Credentials for %s still work (signed in as %s); nothing to refresh", res.Workspace, res.User),
+			Data: map[string]interface{}{
+				"workspace": res.Workspace,
+				"refreshed": false,
+			},
+			Guidance: "Use force=true to re-read the cookie anyway",
+		}, nil
+	}
+
+	// Swap in a provider built from the new cookie, as auth-setup does
+	if setProvider, ok := params["_setProvider"].(func(*provider.ApiProvider)); ok {
+		cfg, err := setup.LoadConfig()
+		if err != nil {
+			log.Printf("Warning: credentials refreshed but failed to reload config: %v", err)
+		} else if ws, ok := cfg.Workspaces[res.Workspace]; ok && res.Workspace == cfg.ActiveWorkspace() {
+			setProvider(provider.NewWithTokens(ws.XoxcToken, ws.XoxdToken))
+		}
+	}
+
+	message := fmt.Sprintf("Refreshed credentials for %s from %s", res.Workspace, res.Source)
+	if !res.Refreshed {
+		message = fmt.Sprintf("Reloaded the working credentials for %s from %s", res.Workspace, setup.ConfigPath())
+	}
+	return &FeatureResult{
+		Success: true,
+		Message: message,
+		Data: map[string]interface{}{
+			"workspace": res.Workspace,
+			"refreshed": res.Refreshed,
+			"source":    res.Source,
+			"user":      res.User,
+		},
+		Guidance: "Slack tools are usable again; the new cookie is saved in " + setup.ConfigPath(),
+	}, nil
+}
+
+// reloadIdentityCredentials swaps in a provider built from the identity's
+// stored credentials when the running one is rejected. Browser profiles
+// aren't read: on a shared server they may belong to someone else.
+func reloadIdentityCredentials(params map[string]interface{}, identity string, force bool) (*FeatureResult, error) {
+	running, _ := params["_provider"].(*provider.ApiProvider)
+	if running != nil && !force {
+		if _, err := running.Provide(); err == nil {
+			return &FeatureResult{
+				Success: true,
+				Message: fmt.Sprintf("Credentials for identity %s still work; nothing to refresh", identity),
+				Data: map[string]interface{}{
+					"identity":  identity,
+					"refreshed": false,
+				},
+				Guidance: "Use force=true to reload them from the config file anyway",
+			}, nil
+		}
+	}
+
+	cfg, err := setup.LoadConfig()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to read the config: %v", err),
+		}, nil
+	}
+	ws, ok := cfg.Identities[identity]
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Identity %s is no longer in %s", identity, setup.ConfigPath()),
+		}, nil
+	}
+	token, cookie := ws.Credentials()
+	fresh, err := provider.NewIdentity(identity, token, cookie)
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+	if setProvider, ok := params["_setProvider"].(func(*provider.ApiProvider)); ok {
+		setProvider(fresh)
+	}
+	return &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Reloaded the credentials for identity %s from %s", identity, setup.ConfigPath()),
+		Data: map[string]interface{}{
+			"identity":  identity,
+			"refreshed": true,
+		},
+		Guidance: "If Slack still rejects them, run `slack-mcp setup` as that person, then `slack-mcp identity add " + identity + "`",
+	}, nil
+}
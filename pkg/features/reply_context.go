@@ -0,0 +1,370 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// GetReplyContext bundles what drafting a reply takes: the thread, who's
+// asking and where they are, what else they've said on the subject lately,
+// and the channel's pins that bear on it
+var GetReplyContext = &Feature{
+	Name:        "get-reply-context",
+	Description: "Everything needed to draft a good reply to a mention or thread in one call: the full thread, the asker's profile and local time, their related recent messages, and relevant pinned docs",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"threadId": map[string]interface{}{
+				"type":        "string",
+				"description": "Thread ID as returned by other tools (channelId:threadTs), or a Slack message permalink",
+			},
+			"channel": map[string]interface{}{
+				"type":        "string",
+				"description": "Channel name or ID (alternative to threadId, with messageTs)",
+			},
+			"messageTs": map[string]interface{}{
+				"type":        "string",
+				"description": "The message being replied to, when it isn't the thread's newest mention of you",
+			},
+			"since": map[string]interface{}{
+				"type":        "string",
+				"description": "How far back to look for the asker's related messages (e.g., '3d', '1w')",
+				"default":     "7d",
+			},
+		},
+	},
+	Handler: getReplyContextHandler,
+}
+
+const (
+	// replyContextThreadLimit bounds the thread messages read
+	replyContextThreadLimit = 200
+	// replyContextRelated bounds the asker's related messages and the pins
+	replyContextRelated = 5
+)
+
+// pickReplyTarget chooses the message a reply answers: the one asked for,
+// else the newest in the thread that mentions the user, else the newest
+// not by them, else the parent
+func pickReplyTarget(msgs []slack.Message, ts, selfID string) slack.Message {
+	if ts != "" {
+		for _, m := range msgs {
+			if m.Timestamp == ts {
+				return m
+			}
+		}
+	}
+	var mention, other *slack.Message
+	for i := range msgs {
+		m := &msgs[i]
+		if m.User == selfID || m.User == "" {
+			continue
+		}
+		if selfID != "" && strings.Contains(m.Text, "<@"+selfID+">") {
+			mention = m
+		}
+		other = m
+	}
+	switch {
+	case mention != nil:
+		return *mention
+	case other != nil:
+		return *other
+	case len(msgs) > 0:
+		return msgs[0]
+	}
+	return slack.Message{}
+}
+
+// localTime is now on a user's clock, from their profile's time zone
+func localTime(u slack.User, now time.Time) (time.Time, bool) {
+	if u.TZ != "" {
+		if loc, err := time.LoadLocation(u.TZ); err == nil {
+			return now.In(loc), true
+		}
+	}
+	if u.TZLabel != "" || u.TZOffset != 0 {
+		return now.In(time.FixedZone(u.TZLabel, u.TZOffset)), true
+	}
+	return time.Time{}, false
+}
+
+// matchedTerms returns the terms that occur in text
+func matchedTerms(text string, terms []string) []string {
+	lower := strings.ToLower(text)
+	var found []string
+	for _, t := range terms {
+		if strings.Contains(lower, t) {
+			found = append(found, t)
+		}
+	}
+	return found
+}
+
+func getReplyContextHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	channel, _ := params["channel"].(string)
+	messageTs, _ := params["messageTs"].(string)
+	threadTs := messageTs
+	if threadID, _ := params["threadId"].(string); threadID != "" {
+		ref, err := parseThreadRef(threadID)
+		if err != nil {
+			return &FeatureResult{
+				Success: false,
+				Message: err.Error(),
+			}, nil
+		}
+		channel, threadTs = ref.ChannelID, ref.ThreadTs
+		// A reply's permalink names the reply itself as well as its thread
+		if m := permalinkPathPattern.FindStringSubmatch(threadID); m != nil && messageTs == "" {
+			messageTs = m[2][:len(m[2])-6] + "." + m[2][len(m[2])-6:]
+		}
+	}
+	if channel == "" || threadTs == "" {
+		return &FeatureResult{
+			Success: false,
+			Message: "Provide threadId, or channel and messageTs",
+		}, nil
+	}
+	since := "7d"
+	if s, ok := params["since"].(string); ok && s != "" {
+		since = s
+	}
+	oldest, err := parseTimePeriod(since)
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Invalid time period: %v", err),
+		}, nil
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	channelID := resolveChannelForSending(apiProvider, api, channel)
+	if channelID == "" {
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Could not find channel or user '%s'", channel),
+			Guidance: "Use 'list-channels' to see available channels",
+		}, nil
+	}
+
+	// A reply's own timestamp leads to its parent
+	if msg, err := fetchMessage(ctx, api, channelID, threadTs); err == nil && msg.ThreadTimestamp != "" {
+		threadTs = msg.ThreadTimestamp
+	}
+	msgs, hasMore, _, err := api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: channelID,
+		Timestamp: threadTs,
+		Limit:     replyContextThreadLimit,
+	})
+	if err != nil || len(msgs) == 0 {
+		if err == nil {
+			err = fmt.Errorf("message not found")
+		}
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get thread: %v", err),
+		}, nil
+	}
+
+	selfID := ""
+	if id := apiProvider.ProvideIdentity(); id != nil {
+		selfID = id.UserID
+	}
+	usersMap := apiProvider.ProvideUsersMap()
+	channelName := resolveChannelName(ctx, apiProvider, channelID, channel)
+	threadID := ThreadRef{ChannelID: channelID, ThreadTs: threadTs}.String()
+
+	thread := make([]map[string]interface{}, 0, len(msgs))
+	inThread := make(map[string]bool, len(msgs))
+	var threadText strings.Builder
+	for _, m := range msgs {
+		thread = append(thread, messageEntry(m, usersMap))
+		inThread[m.Timestamp] = true
+		threadText.WriteString(m.Text + "\n")
+	}
+	// Mentions and links would match on IDs and URLs, not the subject
+	target := pickReplyTarget(msgs, messageTs, selfID)
+	terms := keyTerms(slackTokenRegexp.ReplaceAllString(target.Text, " "))
+	if len(terms) == 0 {
+		terms = keyTerms(slackTokenRegexp.ReplaceAllString(threadText.String(), " "))
+	}
+
+	var links permalinkBatch
+	targetEntry := messageEntry(target, usersMap)
+	links.add(targetEntry, channelID, target.Timestamp)
+
+	// The asker: who they are and what time it is for them
+	askerID := target.User
+	asker := map[string]interface{}{
+		"name":   userDisplayName(askerID, usersMap),
+		"userId": askerID,
+	}
+	if u, ok := usersMap[askerID]; ok {
+		asker["username"] = u.Name
+		if u.Profile.Title != "" {
+			asker["title"] = u.Profile.Title
+		}
+		if u.TZ != "" {
+			asker["timezone"] = u.TZ
+		}
+		if t, ok := localTime(u, time.Now()); ok {
+			asker["localTime"] = t.Format("Mon 15:04 MST")
+		}
+		if u.IsBot {
+			asker["isBot"] = true
+		}
+	}
+	var partial []Coverage
+	if askerID != "" {
+		if profile, err := api.GetUserProfileContext(ctx, &slack.GetUserProfileParameters{UserID: askerID}); err == nil {
+			if status := strings.TrimSpace(profile.StatusEmoji + " " + profile.StatusText); status != "" {
+				asker["status"] = status
+			}
+		}
+		if p, err := api.GetUserPresenceContext(ctx, askerID); err == nil {
+			asker["presence"] = p.Presence
+		}
+	}
+
+	// What else the asker has said on the subject lately. Bot tokens can't
+	// search, which leaves only the thread.
+	related := []map[string]interface{}{}
+	if askerID != "" && askerID != selfID && len(terms) > 0 && apiProvider.TokenType() != "bot" {
+		query := fmt.Sprintf("from:<@%s> after:%s", askerID, oldest.AddDate(0, 0, -1).Format("2006-01-02"))
+		sp := slack.NewSearchParameters()
+		sp.Sort = "timestamp"
+		sp.Count = 100
+		res, err := api.SearchMessagesContext(ctx, query, sp)
+		if err != nil {
+			partial = append(partial, Coverage{Reason: stopReason(err), Detail: "couldn't search the asker's recent messages"})
+		} else {
+			type scored struct {
+				m     slack.SearchMessage
+				terms []string
+			}
+			var hits []scored
+			for _, m := range res.Matches {
+				if (m.Channel.ID == channelID && inThread[m.Timestamp]) || parseSlackTimestamp(m.Timestamp).Before(oldest) {
+					continue
+				}
+				if found := matchedTerms(m.Text, terms); len(found) > 0 {
+					hits = append(hits, scored{m, found})
+				}
+			}
+			// Most shared terms first; search order (newest) breaks ties
+			sort.SliceStable(hits, func(i, j int) bool { return len(hits[i].terms) > len(hits[j].terms) })
+			for _, h := range hits[:min(len(hits), replyContextRelated)] {
+				where := "#" + h.m.Channel.Name
+				if strings.HasPrefix(h.m.Channel.ID, "D") {
+					where = "DM"
+				}
+				entry := map[string]interface{}{
+					"channel":   where,
+					"text":      readableText(h.m.Text, usersMap),
+					"timestamp": formatTimestamp(parseSlackTimestamp(h.m.Timestamp)),
+					"matched":   h.terms,
+				}
+				if h.m.Permalink != "" {
+					entry["permalink"] = h.m.Permalink
+				}
+				related = append(related, entry)
+			}
+		}
+	}
+
+	// The channel's pins that share terms with the question
+	pins := []map[string]interface{}{}
+	pinsChecked := 0
+	if len(terms) > 0 && !strings.HasPrefix(channelID, "D") {
+		items, _, err := api.ListPinsContext(ctx, channelID)
+		if err != nil {
+			partial = append(partial, Coverage{Reason: stopReason(err), Detail: "couldn't read the channel's pins"})
+		}
+		pinsChecked = len(items)
+		for _, item := range items {
+			if len(pins) >= replyContextRelated {
+				break
+			}
+			switch {
+			case item.Message != nil:
+				body := messageBody(item.Message.Text, item.Message.Blocks, item.Message.Attachments)
+				found := matchedTerms(body, terms)
+				if len(found) == 0 {
+					continue
+				}
+				pin := map[string]interface{}{
+					"type":    "message",
+					"author":  userDisplayName(item.Message.User, usersMap),
+					"text":    readableText(body, usersMap),
+					"matched": found,
+				}
+				links.add(pin, channelID, item.Message.Timestamp)
+				pins = append(pins, pin)
+			case item.File != nil:
+				found := matchedTerms(item.File.Title+" "+item.File.Name, terms)
+				if len(found) == 0 {
+					continue
+				}
+				pins = append(pins, map[string]interface{}{
+					"type":      "file",
+					"text":      item.File.Title,
+					"permalink": item.File.Permalink,
+					"matched":   found,
+				})
+			}
+		}
+	}
+	links.resolve(ctx, apiProvider)
+
+	result := &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Reply context for %s in %s: %d thread messages, %d related messages, %d relevant pins",
+			userDisplayName(askerID, usersMap), channelName, len(thread), len(related), len(pins)),
+		ResultCount: len(thread),
+		Data: map[string]interface{}{
+			"threadId":       threadID,
+			"channel":        channelName,
+			"channelId":      channelID,
+			"replyTo":        targetEntry,
+			"thread":         thread,
+			"threadComplete": !hasMore,
+			"asker":          asker,
+			"related":        related,
+			"pins":           pins,
+			"pinsChecked":    pinsChecked,
+			"keyTerms":       terms[:min(len(terms), 8)],
+		},
+		NextActions: []string{
+			fmt.Sprintf("Reply in the thread: send-message channel='%s' threadTs='%s'", channelID, threadTs),
+			fmt.Sprintf("Or work on the wording first: revise-draft channel='%s' threadTs='%s' text='...'", channelID, threadTs),
+		},
+	}
+	if hasMore {
+		result.Guidance = fmt.Sprintf("The thread is longer than %d messages; read-thread threadId='%s' pages through the rest", replyContextThreadLimit, threadID)
+	}
+	for _, c := range partial {
+		result.MarkPartial(c)
+	}
+	return result, nil
+}
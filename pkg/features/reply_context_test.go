@@ -0,0 +1,64 @@
+package features
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+func TestPickReplyTarget(t *testing.T) {
+	msg := func(ts, user, text string) slack.Message {
+		var m slack.Message
+		m.Timestamp, m.User, m.Text = ts, user, text
+		return m
+	}
+	thread := []slack.Message{
+		msg("1.0", "U1", "Deploy plan for Friday?"),
+		msg("2.0", "U2", "<@UME> can you confirm the window?"),
+		msg("3.0", "UME", "Checking"),
+		msg("4.0", "U3", "Also the rollback steps"),
+	}
+
+	if got := pickReplyTarget(thread, "", "UME"); got.Timestamp != "2.0" {
+		t.Errorf("newest mention: got %s", got.Timestamp)
+	}
+	if got := pickReplyTarget(thread, "1.0", "UME"); got.Timestamp != "1.0" {
+		t.Errorf("explicit message: got %s", got.Timestamp)
+	}
+	if got := pickReplyTarget(thread[:1], "", "UME"); got.Timestamp != "1.0" {
+		t.Errorf("standalone message: got %s", got.Timestamp)
+	}
+	noMention := []slack.Message{thread[0], thread[2], thread[3]}
+	if got := pickReplyTarget(noMention, "", "UME"); got.Timestamp != "4.0" {
+		t.Errorf("newest from someone else: got %s", got.Timestamp)
+	}
+	if got := pickReplyTarget([]slack.Message{thread[2]}, "", "UME"); got.Timestamp != "3.0" {
+		t.Errorf("only your own message: got %s", got.Timestamp)
+	}
+}
+
+func TestLocalTime(t *testing.T) {
+	now := time.Date(2026, 1, 15, 17, 0, 0, 0, time.UTC)
+
+	named := slack.User{TZ: "Asia/Tokyo"}
+	if got, ok := localTime(named, now); !ok || got.Hour() != 2 {
+		t.Errorf("Tokyo: %v %v", got, ok)
+	}
+
+	offset := slack.User{TZ: "Nowhere/Unknown", TZLabel: "Pacific Standard Time", TZOffset: -8 * 3600}
+	if got, ok := localTime(offset, now); !ok || got.Hour() != 9 {
+		t.Errorf("offset fallback: %v %v", got, ok)
+	}
+
+	if _, ok := localTime(slack.User{}, now); ok {
+		t.Error("no time zone should report none")
+	}
+}
+
+func TestMatchedTerms(t *testing.T) {
+	got := matchedTerms("Rollback runbook is pinned", []string{"rollback", "deploy", "runbook"})
+	if len(got) != 2 || got[0] != "rollback" || got[1] != "runbook" {
+		t.Errorf("matched = %v", got)
+	}
+}
@@ -0,0 +1,403 @@
+package features
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+// ReviseDraft holds a message draft server-side while agent and human
+// iterate on its wording, keeping every revision until it's sent
+var ReviseDraft = &Feature{
+	Name:        "revise-draft",
+	Description: "Iterate on a message before sending: start a draft, record revision instructions, save reworded revisions with their history, revert, and confirm-send when it's right",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"action": map[string]interface{}{
+				"type": "string",
+				"enum": []string{"start", "revise", "instruct", "show", "list", "revert", "confirm-send", "discard"},
+				"description": "'start' a draft, 'revise' it with new text, 'instruct' to note a change still to make, 'show' it with its history, " +
+					"'list' open drafts, 'revert' to an earlier revision, 'confirm-send' the latest revision, or 'discard' it. " +
+					"Inferred when omitted: text without draftId starts, text with draftId revises, instruction alone instructs, draftId alone shows.",
+			},
+			"draftId": map[string]interface{}{
+				"type":        "string",
+				"description": "Draft to work on, as returned by start",
+			},
+			"channel": map[string]interface{}{
+				"type":        "string",
+				"description": "Where the message will go (start only): channel name, DM username, or ID",
+			},
+			"threadTs": map[string]interface{}{
+				"type":        "string",
+				"description": "Thread to reply in when sent (start only)",
+			},
+			"text": map[string]interface{}{
+				"type":        "string",
+				"description": "The draft's wording: the first version for start, the reworded version for revise",
+			},
+			"instruction": map[string]interface{}{
+				"type":        "string",
+				"description": "What to change (e.g. 'shorter, and mention the deadline'). With revise it's recorded as the reason for the revision.",
+			},
+			"revision": map[string]interface{}{
+				"type":        "number",
+				"description": "Revision number to go back to (revert only)",
+			},
+			"force": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Send even if send-message's pre-send checks raise warnings (confirm-send only)",
+				"default":     false,
+			},
+		},
+	},
+	Handler: reviseDraftHandler,
+}
+
+const draftsCacheFile = "drafts.json"
+
+// draftsMu serializes read-modify-write on the drafts file
+var draftsMu sync.Mutex
+
+// messageDraft is a message being worded, with every revision kept
+type messageDraft struct {
+	ID        string          `json:"id"`
+	Channel   string          `json:"channel"`
+	ThreadTs  string          `json:"threadTs,omitempty"`
+	Revisions []draftRevision `json:"revisions"`
+	Pending   []string        `json:"pending,omitempty"` // Instructions not yet applied
+	Created   time.Time       `json:"created"`
+	Updated   time.Time       `json:"updated"`
+}
+
+// draftRevision is one version of a draft's text and why it was made
+type draftRevision struct {
+	Number      int       `json:"number"`
+	Text        string    `json:"text"`
+	Instruction string    `json:"instruction,omitempty"`
+	At          time.Time `json:"at"`
+}
+
+func (d *messageDraft) latest() draftRevision {
+	return d.Revisions[len(d.Revisions)-1]
+}
+
+// revise adds a revision, taking the pending instructions as its reason
+// unless one is given
+func (d *messageDraft) revise(text, instruction string, now time.Time) draftRevision {
+	if instruction == "" {
+		instruction = strings.Join(d.Pending, "; ")
+	}
+	r := draftRevision{Number: len(d.Revisions) + 1, Text: text, Instruction: instruction, At: now}
+	d.Revisions = append(d.Revisions, r)
+	d.Pending = nil
+	d.Updated = now
+	return r
+}
+
+// draftAction works out what a call means when no action is given
+func draftAction(action, draftID, text, instruction string) string {
+	switch {
+	case action != "":
+		return action
+	case draftID == "" && text != "":
+		return "start"
+	case draftID == "":
+		return "list"
+	case text != "":
+		return "revise"
+	case instruction != "":
+		return "instruct"
+	}
+	return "show"
+}
+
+func loadDrafts(p *provider.ApiProvider) map[string]*messageDraft {
+	drafts := map[string]*messageDraft{}
+	if store := p.Store(); store != nil {
+		_ = store.Load(draftsCacheFile, &drafts)
+	}
+	return drafts
+}
+
+func saveDrafts(p *provider.ApiProvider, drafts map[string]*messageDraft) {
+	if store := p.Store(); store != nil {
+		if err := store.Save(draftsCacheFile, drafts); err != nil {
+			log.Printf("Failed to save drafts: %v", err)
+		}
+	}
+}
+
+// draftEntry renders a draft; history adds every revision
+func draftEntry(d *messageDraft, history bool) map[string]interface{} {
+	cur := d.latest()
+	entry := map[string]interface{}{
+		"draftId":   d.ID,
+		"channel":   d.Channel,
+		"revision":  cur.Number,
+		"text":      cur.Text,
+		"updated":   formatTimestamp(d.Updated),
+		"revisions": len(d.Revisions),
+	}
+	if d.ThreadTs != "" {
+		entry["threadTs"] = d.ThreadTs
+	}
+	if len(d.Pending) > 0 {
+		entry["pendingInstructions"] = d.Pending
+	}
+	if history {
+		revs := make([]map[string]interface{}, 0, len(d.Revisions))
+		for _, r := range d.Revisions {
+			rev := map[string]interface{}{
+				"revision": r.Number,
+				"text":     r.Text,
+				"at":       formatTimestamp(r.At),
+			}
+			if r.Instruction != "" {
+				rev["instruction"] = r.Instruction
+			}
+			revs = append(revs, rev)
+		}
+		entry["history"] = revs
+	}
+	return entry
+}
+
+func reviseDraftHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	draftID, _ := params["draftId"].(string)
+	draftID = strings.TrimSpace(draftID)
+	text, _ := params["text"].(string)
+	text = strings.TrimSpace(text)
+	instruction, _ := params["instruction"].(string)
+	instruction = strings.TrimSpace(instruction)
+	action, _ := params["action"].(string)
+	action = draftAction(strings.TrimSpace(action), draftID, text, instruction)
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	draftsMu.Lock()
+	defer draftsMu.Unlock()
+	drafts := loadDrafts(apiProvider)
+	now := time.Now()
+
+	if action == "list" {
+		return listDrafts(drafts), nil
+	}
+
+	if action == "start" {
+		channel, _ := params["channel"].(string)
+		channel = strings.TrimSpace(channel)
+		if channel == "" || text == "" {
+			return &FeatureResult{
+				Success: false,
+				Message: "Starting a draft needs channel and text",
+			}, nil
+		}
+		threadTs, _ := params["threadTs"].(string)
+		id := make([]byte, 4)
+		_, _ = rand.Read(id)
+		d := &messageDraft{
+			ID:       hex.EncodeToString(id),
+			Channel:  channel,
+			ThreadTs: threadTs,
+			Created:  now,
+		}
+		d.revise(text, "", now)
+		if instruction != "" {
+			d.Pending = []string{instruction}
+		}
+		drafts[d.ID] = d
+		saveDrafts(apiProvider, drafts)
+		return &FeatureResult{
+			Success:  true,
+			Message:  fmt.Sprintf("Draft %s started for %s", d.ID, channel),
+			Data:     map[string]interface{}{"draft": draftEntry(d, false)},
+			Guidance: "Nothing has been sent. Revise it until it reads right, then confirm-send.",
+			NextActions: []string{
+				fmt.Sprintf("revise-draft draftId='%s' text='...' instruction='...'", d.ID),
+				fmt.Sprintf("revise-draft draftId='%s' action='confirm-send'", d.ID),
+			},
+		}, nil
+	}
+
+	d, ok := drafts[draftID]
+	if !ok {
+		return &FeatureResult{
+			Success:     false,
+			Message:     fmt.Sprintf("No draft '%s'", draftID),
+			Guidance:    "List open drafts with revise-draft action='list'",
+			NextActions: []string{"revise-draft action='list'"},
+		}, nil
+	}
+
+	var message string
+	switch action {
+	case "show":
+		return &FeatureResult{
+			Success: true,
+			Message: fmt.Sprintf("Draft %s for %s, revision %d of %d", d.ID, d.Channel, d.latest().Number, len(d.Revisions)),
+			Data:    map[string]interface{}{"draft": draftEntry(d, true)},
+		}, nil
+
+	case "instruct":
+		if instruction == "" {
+			return &FeatureResult{
+				Success: false,
+				Message: "instruction is required",
+			}, nil
+		}
+		d.Pending = append(d.Pending, instruction)
+		d.Updated = now
+		message = fmt.Sprintf("Noted for draft %s: %s", d.ID, instruction)
+
+	case "revise":
+		if text == "" {
+			return &FeatureResult{
+				Success:  false,
+				Message:  "text is required: pass the reworded draft",
+				Guidance: "To record a change still to make, use action='instruct'",
+			}, nil
+		}
+		if text == d.latest().Text {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("That's the same as revision %d", d.latest().Number),
+			}, nil
+		}
+		r := d.revise(text, instruction, now)
+		message = fmt.Sprintf("Draft %s is now at revision %d", d.ID, r.Number)
+
+	case "revert":
+		n := 0
+		if v, ok := params["revision"].(float64); ok {
+			n = int(v)
+		}
+		if n < 1 || n > len(d.Revisions) {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Revision must be between 1 and %d", len(d.Revisions)),
+			}, nil
+		}
+		if n == d.latest().Number {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Revision %d is already the latest", n),
+			}, nil
+		}
+		// Reverting adds a revision, so nothing in the history is lost
+		r := d.revise(d.Revisions[n-1].Text, fmt.Sprintf("reverted to revision %d", n), now)
+		message = fmt.Sprintf("Draft %s reverted to revision %d (now revision %d)", d.ID, n, r.Number)
+
+	case "discard":
+		delete(drafts, d.ID)
+		saveDrafts(apiProvider, drafts)
+		return &FeatureResult{
+			Success: true,
+			Message: fmt.Sprintf("Draft %s discarded after %d revisions", d.ID, len(d.Revisions)),
+		}, nil
+
+	case "confirm-send":
+		return sendDraft(ctx, params, apiProvider, drafts, d)
+
+	default:
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Unknown action '%s'", action),
+		}, nil
+	}
+
+	saveDrafts(apiProvider, drafts)
+	result := &FeatureResult{
+		Success: true,
+		Message: message,
+		Data:    map[string]interface{}{"draft": draftEntry(d, false)},
+		NextActions: []string{
+			fmt.Sprintf("revise-draft draftId='%s' action='show'", d.ID),
+			fmt.Sprintf("revise-draft draftId='%s' action='confirm-send'", d.ID),
+		},
+	}
+	if len(d.Pending) > 0 {
+		result.Guidance = "Apply the pending instructions to the latest text and save it with revise"
+	}
+	return result, nil
+}
+
+// sendDraft posts the latest revision through send-message, with its
+// pre-send checks, and drops the draft once it's out
+func sendDraft(ctx context.Context, params map[string]interface{}, ap *provider.ApiProvider, drafts map[string]*messageDraft, d *messageDraft) (*FeatureResult, error) {
+	if len(d.Pending) > 0 {
+		if force, _ := params["force"].(bool); !force {
+			return &FeatureResult{
+				Success:  false,
+				Message:  fmt.Sprintf("Draft %s has instructions not yet applied: %s", d.ID, strings.Join(d.Pending, "; ")),
+				Guidance: "Revise it first, or confirm-send with force=true to send the latest revision as is",
+			}, nil
+		}
+	}
+
+	sendParams := map[string]interface{}{
+		"_provider": ap,
+		"channel":   d.Channel,
+		"message":   d.latest().Text,
+	}
+	if d.ThreadTs != "" {
+		sendParams["threadTs"] = d.ThreadTs
+	}
+	if force, ok := params["force"].(bool); ok {
+		sendParams["force"] = force
+	}
+	result, err := writeMessageHandler(ctx, sendParams)
+	if err != nil || !result.Success {
+		if result != nil {
+			result.NextActions = append(result.NextActions,
+				fmt.Sprintf("The draft is kept: revise-draft draftId='%s' text='...'", d.ID))
+		}
+		return result, err
+	}
+
+	// Queued messages still go out, so the draft is done either way
+	delete(drafts, d.ID)
+	saveDrafts(ap, drafts)
+	if data, ok := result.Data.(map[string]interface{}); ok {
+		data["draftId"] = d.ID
+		data["revisions"] = len(d.Revisions)
+	}
+	return result, nil
+}
+
+func listDrafts(drafts map[string]*messageDraft) *FeatureResult {
+	sorted := make([]*messageDraft, 0, len(drafts))
+	for _, d := range drafts {
+		sorted = append(sorted, d)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Updated.After(sorted[j].Updated) })
+	list := make([]map[string]interface{}, 0, len(sorted))
+	for _, d := range sorted {
+		list = append(list, draftEntry(d, false))
+	}
+	result := &FeatureResult{
+		Success:     true,
+		Message:     fmt.Sprintf("%d open draft(s)", len(list)),
+		Data:        map[string]interface{}{"drafts": list},
+		ResultCount: len(list),
+	}
+	if len(list) == 0 {
+		result.Guidance = "Start one with revise-draft channel='...' text='...'"
+	}
+	return result
+}
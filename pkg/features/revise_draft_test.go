@@ -0,0 +1,51 @@
+package features
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDraftAction(t *testing.T) {
+	cases := []struct {
+		action, id, text, instruction, want string
+	}{
+		{"", "", "hello", "", "start"},
+		{"", "", "", "", "list"},
+		{"", "d1", "hello again", "", "revise"},
+		{"", "d1", "", "shorter", "instruct"},
+		{"", "d1", "", "", "show"},
+		{"confirm-send", "d1", "", "", "confirm-send"},
+	}
+	for _, c := range cases {
+		if got := draftAction(c.action, c.id, c.text, c.instruction); got != c.want {
+			t.Errorf("draftAction(%q, %q, %q, %q) = %q, want %q", c.action, c.id, c.text, c.instruction, got, c.want)
+		}
+	}
+}
+
+func TestDraftRevisions(t *testing.T) {
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	d := &messageDraft{ID: "d1", Channel: "#eng", Created: now}
+	d.revise("Ship it Friday", "", now)
+
+	// Pending instructions become the next revision's reason
+	d.Pending = []string{"shorter", "mention the deadline"}
+	r := d.revise("Shipping Friday, deadline 5pm", "", now.Add(time.Minute))
+	if r.Number != 2 || r.Instruction != "shorter; mention the deadline" || len(d.Pending) != 0 {
+		t.Errorf("revision 2 = %+v, pending %v", r, d.Pending)
+	}
+
+	// An explicit instruction wins
+	r = d.revise("Shipping Friday 5pm", "tighter", now.Add(2*time.Minute))
+	if r.Number != 3 || r.Instruction != "tighter" || d.latest().Text != "Shipping Friday 5pm" {
+		t.Errorf("revision 3 = %+v", r)
+	}
+
+	entry := draftEntry(d, true)
+	if entry["revision"] != 3 || len(entry["history"].([]map[string]interface{})) != 3 {
+		t.Errorf("entry = %v", entry)
+	}
+	if !d.Updated.Equal(now.Add(2 * time.Minute)) {
+		t.Errorf("updated = %v", d.Updated)
+	}
+}
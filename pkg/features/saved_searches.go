@@ -0,0 +1,205 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+// CheckSavedSearches manages named queries that run in the background and
+// reports the matches that arrived since the last check
+var CheckSavedSearches = &Feature{
+	Name:        "check-saved-searches",
+	Description: "Register named saved searches (full Slack query syntax) that run in the background, and see what's new for each since the last check",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"action": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"check", "add", "remove"},
+				"description": "'check' (default) shows new matches, 'add' saves a query under a name, 'remove' deletes one",
+				"default":     "check",
+			},
+			"name": map[string]interface{}{
+				"type":        "string",
+				"description": "Saved search name (e.g., 'project-x mentions'). With 'check', limits the report to this search.",
+			},
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": "Search query for 'add' (e.g., 'project-x', 'from:@manager urgent')",
+			},
+			"refresh": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Run the searches now before reporting instead of waiting for the background run",
+				"default":     false,
+			},
+			"markSeen": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Clear the reported matches so the next check only shows newer ones (default: true)",
+				"default":     true,
+			},
+		},
+	},
+	Handler: checkSavedSearchesHandler,
+}
+
+func checkSavedSearchesHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	action := "check"
+	if a, ok := params["action"].(string); ok && a != "" {
+		action = a
+	}
+	name, _ := params["name"].(string)
+	name = strings.TrimSpace(name)
+
+	switch action {
+	case "add":
+		query, _ := params["query"].(string)
+		saved, err := apiProvider.AddSavedSearch(name, query)
+		if err != nil {
+			return &FeatureResult{
+				Success:  false,
+				Message:  fmt.Sprintf("Could not save search: %v", err),
+				Guidance: "Pass both name and query, e.g. action='add' name='project-x' query='project-x in:#eng'",
+			}, nil
+		}
+		// The first run records a baseline so only later matches count as new
+		if err := apiProvider.RunSavedSearches(ctx, saved.Name); err != nil {
+			return &FeatureResult{
+				Success:  false,
+				Message:  fmt.Sprintf("Saved '%s' but could not run it yet: %v", saved.Name, err),
+				Guidance: "The search will run with the next background pass",
+			}, nil
+		}
+		return &FeatureResult{
+			Success: true,
+			Message: fmt.Sprintf("Saved search '%s' added", saved.Name),
+			Data: map[string]interface{}{
+				"name":  saved.Name,
+				"query": saved.Query,
+				"every": refreshEvery(apiProvider.SavedSearchInterval()),
+			},
+			Guidance:    "Matches from now on are collected in the background. Check back with check-saved-searches.",
+			NextActions: []string{fmt.Sprintf("check-saved-searches name='%s'", saved.Name)},
+		}, nil
+
+	case "remove":
+		if !apiProvider.RemoveSavedSearch(name) {
+			return &FeatureResult{
+				Success:  false,
+				Message:  fmt.Sprintf("No saved search named '%s'", name),
+				Guidance: "Use check-saved-searches to list saved searches",
+			}, nil
+		}
+		return &FeatureResult{
+			Success: true,
+			Message: fmt.Sprintf("Saved search '%s' removed", name),
+		}, nil
+
+	case "check":
+	default:
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Unknown action '%s'", action),
+			Guidance: "Use action='check', 'add', or 'remove'",
+		}, nil
+	}
+
+	refresh, _ := params["refresh"].(bool)
+	markSeen := true
+	if m, ok := params["markSeen"].(bool); ok {
+		markSeen = m
+	}
+	if refresh || apiProvider.SavedSearchInterval() == 0 {
+		var names []string
+		if name != "" {
+			names = []string{name}
+		}
+		if err := apiProvider.RunSavedSearches(ctx, names...); err != nil {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Failed to run saved searches: %v", err),
+			}, nil
+		}
+	}
+
+	usersMap := apiProvider.ProvideUsersMap()
+	searches := []map[string]interface{}{}
+	totalNew := 0
+	for _, s := range apiProvider.SavedSearches(markSeen) {
+		if name != "" && !strings.EqualFold(s.Name, name) {
+			continue
+		}
+		hits := []map[string]interface{}{}
+		for _, h := range s.Pending {
+			author := getUserName(h.User, usersMap)
+			if h.User == "" && h.Username != "" {
+				author = h.Username
+			}
+			channel := h.ChannelName
+			if strings.HasPrefix(h.ChannelID, "D") {
+				channel = "@" + getUserName(h.ChannelName, usersMap)
+			} else if channel == "" {
+				channel = apiProvider.ResolveChannelName(ctx, h.ChannelID)
+			}
+			hits = append(hits, map[string]interface{}{
+				"channel":   channel,
+				"author":    author,
+				"message":   h.Text,
+				"timestamp": formatTimestamp(parseSlackTimestamp(h.Timestamp)),
+				"permalink": h.Permalink,
+			})
+		}
+		entry := map[string]interface{}{
+			"name":     s.Name,
+			"query":    s.Query,
+			"newCount": len(hits),
+			"matches":  hits,
+		}
+		if !s.LastRun.IsZero() {
+			entry["lastRun"] = formatTimestamp(s.LastRun)
+		}
+		if s.LastError != "" {
+			entry["error"] = s.LastError
+		}
+		totalNew += len(hits)
+		searches = append(searches, entry)
+	}
+
+	if name != "" && len(searches) == 0 {
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("No saved search named '%s'", name),
+			Guidance: "Use check-saved-searches without a name to list saved searches",
+		}, nil
+	}
+
+	result := &FeatureResult{
+		Success: true,
+		Data: map[string]interface{}{
+			"searches": searches,
+			"totalNew": totalNew,
+			"every":    refreshEvery(apiProvider.SavedSearchInterval()),
+		},
+		Message:     fmt.Sprintf("%d new match(es) across %d saved search(es)", totalNew, len(searches)),
+		ResultCount: totalNew,
+	}
+	switch {
+	case len(searches) == 0:
+		result.Guidance = "No saved searches yet. Add one with action='add' name='...' query='...'"
+	case totalNew == 0:
+		result.Guidance = "Nothing new since the last check"
+	case markSeen:
+		result.Guidance = "These matches are now marked seen; the next check shows only newer ones"
+	}
+	return result, nil
+}
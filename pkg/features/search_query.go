@@ -0,0 +1,257 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// searchFilters is the structured form of Slack's search operators. Each
+// field compiles to one or more operators; list fields repeat the operator
+// rather than comma-joining values, which Slack doesn't understand.
+type searchFilters struct {
+	Phrase  string   // "exact phrase"
+	In      []string // in:#channel / in:@user
+	From    []string // from:@user
+	Has     []string // has:reaction, has:link, has:pin, ...
+	Is      []string // is:thread, is:saved, ...
+	Before  string   // before:YYYY-MM-DD
+	After   string   // after:YYYY-MM-DD
+	On      string   // on:YYYY-MM-DD
+	During  string   // during:month / during:YYYY-MM
+	Exclude []string // -word
+}
+
+var searchDatePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// searchFiltersSchema describes the filters object for tool schemas
+var searchFiltersSchema = map[string]interface{}{
+	"type":        "object",
+	"description": "Structured Slack search operators, compiled into a correct query string",
+	"properties": map[string]interface{}{
+		"phrase": map[string]interface{}{
+			"type":        "string",
+			"description": "Exact phrase to match",
+		},
+		"in": map[string]interface{}{
+			"type":        "array",
+			"items":       map[string]interface{}{"type": "string"},
+			"description": "Channels (or @users for DMs) to search in",
+		},
+		"from": map[string]interface{}{
+			"type":        "array",
+			"items":       map[string]interface{}{"type": "string"},
+			"description": "People whose messages to include (username, real name, or email)",
+		},
+		"has": map[string]interface{}{
+			"type":        "array",
+			"items":       map[string]interface{}{"type": "string"},
+			"description": "Message properties: reaction, link, pin, star, file",
+		},
+		"is": map[string]interface{}{
+			"type":        "array",
+			"items":       map[string]interface{}{"type": "string"},
+			"description": "Message kinds: thread, saved, dm",
+		},
+		"before": map[string]interface{}{
+			"type":        "string",
+			"description": "Only messages before this date (YYYY-MM-DD)",
+		},
+		"after": map[string]interface{}{
+			"type":        "string",
+			"description": "Only messages after this date (YYYY-MM-DD)",
+		},
+		"on": map[string]interface{}{
+			"type":        "string",
+			"description": "Only messages on this date (YYYY-MM-DD)",
+		},
+		"during": map[string]interface{}{
+			"type":        "string",
+			"description": "Only messages during a month or year (e.g. 'march', '2026-03', '2026')",
+		},
+		"exclude": map[string]interface{}{
+			"type":        "array",
+			"items":       map[string]interface{}{"type": "string"},
+			"description": "Words to exclude from results",
+		},
+	},
+}
+
+// parseSearchFilters reads the filters object from tool params
+func parseSearchFilters(raw interface{}) (searchFilters, error) {
+	var f searchFilters
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return f, nil
+	}
+
+	f.Phrase, _ = m["phrase"].(string)
+	f.In = stringList(m["in"])
+	f.From = stringList(m["from"])
+	f.Has = stringList(m["has"])
+	f.Is = stringList(m["is"])
+	f.Exclude = stringList(m["exclude"])
+	f.During, _ = m["during"].(string)
+
+	for key, dst := range map[string]*string{"before": &f.Before, "after": &f.After, "on": &f.On} {
+		v, _ := m[key].(string)
+		v = strings.TrimSpace(v)
+		if v != "" && !searchDatePattern.MatchString(v) {
+			return f, fmt.Errorf("filters.%s must be a date in YYYY-MM-DD format, got %q", key, v)
+		}
+		*dst = v
+	}
+	return f, nil
+}
+
+// hasDate reports whether the filters constrain the date range, in which
+// case the tool's default timeframe should not be applied
+func (f searchFilters) hasDate() bool {
+	return f.Before != "" || f.After != "" || f.On != "" || f.During != ""
+}
+
+// stringList accepts a JSON array or a single comma-separated string
+func stringList(v interface{}) []string {
+	var out []string
+	add := func(s string) {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	switch t := v.(type) {
+	case []interface{}:
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				add(s)
+			}
+		}
+	case []string:
+		for _, s := range t {
+			add(s)
+		}
+	case string:
+		for _, s := range strings.Split(t, ",") {
+			add(s)
+		}
+	}
+	return out
+}
+
+// resolveSearchFilters rewrites channel IDs and people's display names to
+// the channel names and handles Slack search expects
+func resolveSearchFilters(ctx context.Context, f searchFilters, p *provider.ApiProvider) searchFilters {
+	usersMap := p.ProvideUsersMap()
+
+	in := make([]string, 0, len(f.In))
+	for _, ch := range f.In {
+		if strings.HasPrefix(ch, "@") {
+			in = append(in, "@"+searchUserHandle(ctx, p, ch, usersMap))
+			continue
+		}
+		name := strings.TrimPrefix(ch, "#")
+		if isChannelID(name) {
+			if resolved := p.ResolveChannelName(ctx, name); resolved != "" {
+				name = resolved
+			}
+		}
+		in = append(in, "#"+name)
+	}
+	f.In = in
+
+	from := make([]string, 0, len(f.From))
+	for _, u := range f.From {
+		from = append(from, searchUserHandle(ctx, p, u, usersMap))
+	}
+	f.From = from
+	return f
+}
+
+// searchUserHandle is searchHandle for values that may be email addresses,
+// which search operators don't accept; those are resolved to a username
+func searchUserHandle(ctx context.Context, p *provider.ApiProvider, name string, usersMap map[string]slack.User) string {
+	if email := strings.TrimPrefix(strings.TrimSpace(name), "@"); strings.Contains(email, "@") {
+		if u, err := p.ResolveUserByEmail(ctx, email); err == nil {
+			return u.Name
+		}
+	}
+	return searchHandle(name, usersMap)
+}
+
+// searchHandle maps a username, display name, real name or user ID to the
+// username search operators accept; unknown values are passed through
+func searchHandle(name string, usersMap map[string]slack.User) string {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "@")
+	if u, ok := usersMap[name]; ok {
+		return u.Name
+	}
+	for _, u := range usersMap {
+		if strings.EqualFold(u.Name, name) || strings.EqualFold(u.RealName, name) ||
+			strings.EqualFold(u.Profile.DisplayName, name) {
+			return u.Name
+		}
+	}
+	return name
+}
+
+// buildSearchQuery compiles free text and structured filters into a Slack
+// search query string
+func buildSearchQuery(text string, f searchFilters) string {
+	var parts []string
+	if t := strings.TrimSpace(text); t != "" {
+		parts = append(parts, t)
+	}
+	if f.Phrase != "" {
+		parts = append(parts, `"`+strings.ReplaceAll(f.Phrase, `"`, "")+`"`)
+	}
+
+	for _, ch := range f.In {
+		if strings.HasPrefix(ch, "@") {
+			parts = append(parts, "in:"+searchToken(ch))
+		} else {
+			parts = append(parts, "in:#"+searchToken(strings.TrimPrefix(ch, "#")))
+		}
+	}
+	for _, u := range f.From {
+		parts = append(parts, "from:@"+searchToken(strings.TrimPrefix(u, "@")))
+	}
+	for _, h := range f.Has {
+		parts = append(parts, "has:"+searchToken(strings.Trim(h, ":")))
+	}
+	for _, i := range f.Is {
+		parts = append(parts, "is:"+searchToken(i))
+	}
+	if f.Before != "" {
+		parts = append(parts, "before:"+f.Before)
+	}
+	if f.After != "" {
+		parts = append(parts, "after:"+f.After)
+	}
+	if f.On != "" {
+		parts = append(parts, "on:"+f.On)
+	}
+	if f.During != "" {
+		parts = append(parts, "during:"+searchToken(f.During))
+	}
+	for _, w := range f.Exclude {
+		parts = append(parts, "-"+searchToken(w))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// searchToken makes a value safe to use as an operator argument: operators
+// take a single token, so whitespace and commas are collapsed to dashes
+func searchToken(s string) string {
+	s = strings.TrimSpace(s)
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '\n', ',', '"':
+			return '-'
+		}
+		return r
+	}, s)
+}
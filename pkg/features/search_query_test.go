@@ -0,0 +1,31 @@
+package features
+
+import "testing"
+
+func TestBuildSearchQuery(t *testing.T) {
+	f, err := parseSearchFilters(map[string]interface{}{
+		"phrase":  `rollout "plan"`,
+		"in":      []interface{}{"#eng-infra", "ops"},
+		"from":    []interface{}{"@alice"},
+		"has":     []interface{}{"reaction", ":link:"},
+		"is":      []interface{}{"thread"},
+		"after":   "2026-01-05",
+		"exclude": []interface{}{"draft"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := buildSearchQuery("deploy", f)
+	want := `deploy "rollout plan" in:#eng-infra in:#ops from:@alice has:reaction has:link is:thread after:2026-01-05 -draft`
+	if got != want {
+		t.Errorf("buildSearchQuery =\n  %s\nwant\n  %s", got, want)
+	}
+	if !f.hasDate() {
+		t.Error("hasDate = false with after set")
+	}
+
+	if _, err := parseSearchFilters(map[string]interface{}{"before": "last week"}); err == nil {
+		t.Error("expected error for non-date before filter")
+	}
+}
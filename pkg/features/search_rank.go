@@ -0,0 +1,134 @@
+package features
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// Search ranking modes for the search tool
+const (
+	rankRecent    = "recent"
+	rankRelevance = "relevance"
+	rankHybrid    = "hybrid"
+)
+
+// rrfK dampens reciprocal-rank fusion so the top few ranks of either list
+// don't dominate the merged order
+const rrfK = 60
+
+var decisionPattern = regexp.MustCompile(`(?i)\b(decided|decision|agreed|we'll go with|going with|approved|final call|resolved)\b`)
+
+// runRankedSearch runs search.messages sorted for the requested rank mode.
+// Hybrid fetches both score- and time-sorted results and fuses them.
+func runRankedSearch(ctx context.Context, api *slack.Client, query, rank string, priority map[string]bool) ([]slack.SearchMessage, int, error) {
+	search := func(sort string) (*slack.SearchMessages, error) {
+		sp := slack.NewSearchParameters()
+		sp.Sort = sort
+		sp.SortDirection = "desc"
+		sp.Count = 100
+		sp.Page = 1
+		sp.Highlight = true
+		return api.SearchMessagesContext(ctx, query, sp)
+	}
+
+	switch rank {
+	case rankRelevance:
+		res, err := search("score")
+		if err != nil {
+			return nil, 0, err
+		}
+		return res.Matches, res.Total, nil
+	case rankHybrid:
+		byScore, err := search("score")
+		if err != nil {
+			return nil, 0, err
+		}
+		byTime, err := search("timestamp")
+		if err != nil {
+			return nil, 0, err
+		}
+		total := byScore.Total
+		if byTime.Total > total {
+			total = byTime.Total
+		}
+		return fuseSearchResults(byScore.Matches, byTime.Matches, priority), total, nil
+	default:
+		res, err := search("timestamp")
+		if err != nil {
+			return nil, 0, err
+		}
+		return res.Matches, res.Total, nil
+	}
+}
+
+// fuseSearchResults merges two ranked lists with reciprocal-rank fusion,
+// then boosts threads, decisions, and messages from priority contacts
+func fuseSearchResults(byScore, byTime []slack.SearchMessage, priority map[string]bool) []slack.SearchMessage {
+	type scored struct {
+		msg   slack.SearchMessage
+		score float64
+	}
+	merged := map[string]*scored{}
+	var order []string
+
+	add := func(list []slack.SearchMessage) {
+		for i, m := range list {
+			key := m.Channel.ID + ":" + m.Timestamp
+			s, ok := merged[key]
+			if !ok {
+				s = &scored{msg: m}
+				merged[key] = s
+				order = append(order, key)
+			}
+			s.score += 1.0 / float64(rrfK+i+1)
+		}
+	}
+	add(byScore)
+	add(byTime)
+
+	out := make([]*scored, 0, len(order))
+	for _, key := range order {
+		s := merged[key]
+		if strings.Contains(s.msg.Permalink, "thread_ts=") {
+			s.score *= 1.2
+		}
+		if decisionPattern.MatchString(s.msg.Text) {
+			s.score *= 1.3
+		}
+		if priority[s.msg.User] {
+			s.score *= 1.3
+		}
+		out = append(out, s)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].score > out[j].score
+	})
+
+	matches := make([]slack.SearchMessage, len(out))
+	for i, s := range out {
+		matches[i] = s.msg
+	}
+	return matches
+}
+
+// priorityContacts returns the user IDs listed in SLACK_MCP_PRIORITY_CONTACTS
+// (comma-separated usernames, display names or IDs)
+func priorityContacts(usersMap map[string]slack.User) map[string]bool {
+	contacts := map[string]bool{}
+	for _, name := range stringList(os.Getenv("SLACK_MCP_PRIORITY_CONTACTS")) {
+		handle := searchHandle(name, usersMap)
+		for uid, u := range usersMap {
+			if u.Name == handle {
+				contacts[uid] = true
+				break
+			}
+		}
+	}
+	return contacts
+}
@@ -0,0 +1,386 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/cache"
+	"github.com/aaronsb/slack-mcp/pkg/embeddings"
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// SearchSemantic finds conceptually related messages using embeddings from
+// a configurable endpoint, for when keyword search misses rephrasings
+var SearchSemantic = &Feature{
+	Name:        "search-semantic",
+	Description: "Find conceptually related discussions even when keywords differ, using a local embeddings index (requires SLACK_MCP_EMBEDDINGS_URL)",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": "What you're looking for, in plain language",
+			},
+			"channels": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Channels to index and search (default: your most recently active channels)",
+			},
+			"days": map[string]interface{}{
+				"type":        "number",
+				"description": "How far back to index messages (default: 14)",
+				"default":     14,
+			},
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": "Maximum results (default: 10)",
+				"default":     10,
+			},
+		},
+		"required": []string{"query"},
+	},
+	Handler: searchSemanticHandler,
+}
+
+const (
+	embeddingsCacheFile     = "embeddings.json"
+	embeddingsEncryptedFile = "embeddings.json.enc"
+	semanticMaxEntries      = 5000
+	semanticMaxNewPerCall   = 1000
+	semanticBatchSize       = 64
+	semanticAutoChannels    = 15
+	semanticMinScore        = 0.3
+	semanticMinTextLength   = 20
+)
+
+// semanticMu serializes index load/update/save across concurrent calls
+var semanticMu sync.Mutex
+
+func searchSemanticHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	query, _ := params["query"].(string)
+	if strings.TrimSpace(query) == "" {
+		return &FeatureResult{
+			Success: false,
+			Message: "query is required",
+		}, nil
+	}
+
+	days := 14
+	if d, ok := params["days"].(float64); ok && d > 0 {
+		days = int(d)
+	}
+	limit := 10
+	if l, ok := params["limit"].(float64); ok && l > 0 {
+		limit = int(l)
+	}
+
+	client := embeddings.FromEnv()
+	if client == nil {
+		return &FeatureResult{
+			Success:  false,
+			Message:  "Semantic search is not configured",
+			Guidance: "Set SLACK_MCP_EMBEDDINGS_URL to an OpenAI-compatible embeddings endpoint (e.g. http://localhost:11434/v1 for Ollama) and optionally SLACK_MCP_EMBEDDINGS_MODEL. Use 'search' for keyword search meanwhile.",
+		}, nil
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	channelIDs := semanticChannels(ctx, apiProvider, stringList(params["channels"]))
+	if len(channelIDs) == 0 {
+		return &FeatureResult{
+			Success:  false,
+			Message:  "No channels to search",
+			Guidance: "Pass channels=['name'] or join some channels first",
+		}, nil
+	}
+
+	semanticMu.Lock()
+	defer semanticMu.Unlock()
+
+	index := loadEmbeddingsIndex(apiProvider, client.Model())
+	added, err := updateEmbeddingsIndex(ctx, api, client, index, channelIDs, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		log.Printf("Semantic index update failed: %v", err)
+		if len(index.Entries) == 0 {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Could not build the embeddings index: %v", err),
+			}, nil
+		}
+	}
+	if added > 0 {
+		index.Trim(semanticMaxEntries)
+		saveEmbeddingsIndex(apiProvider, index)
+	}
+
+	vectors, err := client.Embed(ctx, []string{query})
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to embed query: %v", err),
+		}, nil
+	}
+
+	// Only search the requested channels, even though the index holds more
+	inScope := map[string]bool{}
+	for _, id := range channelIDs {
+		inScope[id] = true
+	}
+	scoped := &embeddings.Index{Model: index.Model}
+	for _, e := range index.Entries {
+		if inScope[e.ChannelID] {
+			scoped.Entries = append(scoped.Entries, e)
+		}
+	}
+	matches := scoped.Search(vectors[0], limit, semanticMinScore)
+
+	usersMap := apiProvider.ProvideUsersMap()
+	discussions := []map[string]interface{}{}
+	for _, m := range matches {
+		userName := "unknown"
+		if user, ok := usersMap[m.User]; ok {
+			userName = user.Name
+			if user.RealName != "" {
+				userName = user.RealName
+			}
+		}
+		discussions = append(discussions, map[string]interface{}{
+			"channel":   apiProvider.ResolveChannelName(ctx, m.ChannelID),
+			"user":      userName,
+			"text":      m.Text,
+			"timestamp": m.Timestamp,
+			"score":     fmt.Sprintf("%.2f", m.Score),
+		})
+	}
+
+	result := &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Found %d related messages for '%s'", len(discussions), query),
+		Data: map[string]interface{}{
+			"query":       query,
+			"discussions": discussions,
+			"searchMeta": map[string]interface{}{
+				"indexed":      len(scoped.Entries),
+				"newlyIndexed": added,
+				"channels":     len(channelIDs),
+			},
+		},
+		ResultCount: len(discussions),
+	}
+
+	if len(discussions) > 0 {
+		first := discussions[0]
+		result.NextActions = []string{
+			fmt.Sprintf("Full message: get-context channel='%s' messageTs='%s'", first["channel"], first["timestamp"]),
+			fmt.Sprintf("Keyword search: search query='%s'", query),
+		}
+		result.Guidance = "Results are ordered by similarity score, not time."
+	} else {
+		result.Guidance = "Nothing related found. Try other channels, a longer days window, or keyword search."
+		result.NextActions = []string{
+			fmt.Sprintf("search query='%s'", query),
+		}
+	}
+
+	return result, nil
+}
+
+// semanticChannels resolves requested channels, or picks the member
+// channels with the most recent activity
+func semanticChannels(ctx context.Context, p *provider.ApiProvider, requested []string) []string {
+	var ids []string
+	if len(requested) > 0 {
+		for _, ch := range requested {
+			id := p.ResolveChannelID(strings.TrimPrefix(ch, "#"))
+			if isChannelID(id) {
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+
+	if ic := p.ProvideInternalClient(); ic != nil {
+		if counts, err := ic.GetClientCounts(ctx); err == nil && counts.OK {
+			chans := counts.Channels
+			sort.Slice(chans, func(i, j int) bool {
+				return chans[i].Latest > chans[j].Latest
+			})
+			for _, ch := range chans {
+				if len(ids) >= semanticAutoChannels {
+					break
+				}
+				ids = append(ids, ch.ID)
+			}
+			return ids
+		}
+	}
+
+	for _, ch := range p.GetCachedChannels() {
+		if ch.IsMember && !ch.IsArchived && !ch.IsIM && len(ids) < semanticAutoChannels {
+			ids = append(ids, ch.ID)
+		}
+	}
+	return ids
+}
+
+func loadEmbeddingsIndex(p *provider.ApiProvider, model string) *embeddings.Index {
+	index := &embeddings.Index{Model: model}
+	store := p.Store()
+	if store == nil {
+		return index
+	}
+	var saved embeddings.Index
+	var err error
+	if indexEncryptionEnabled() {
+		var key *cache.Key
+		if key, err = cache.LoadKey(); err == nil {
+			err = store.LoadEncrypted(embeddingsEncryptedFile, key, &saved)
+			if store.Exists(embeddingsCacheFile) {
+				err = encryptPlaintextIndex(store, key, &saved, err)
+			}
+		}
+	} else {
+		err = store.Load(embeddingsCacheFile, &saved)
+	}
+	if err == nil && saved.Model == model {
+		return &saved
+	}
+	// Missing, unreadable, undecryptable, or built with another model:
+	// start over
+	return index
+}
+
+// encryptPlaintextIndex migrates an index saved before encryption was
+// turned on: it's re-saved encrypted, unless an encrypted one already
+// loaded (loadErr nil), and the plaintext copy is removed. Returns the
+// error loading saved, as LoadEncrypted does.
+func encryptPlaintextIndex(store *cache.Store, key *cache.Key, saved *embeddings.Index, loadErr error) error {
+	if loadErr != nil {
+		var plain embeddings.Index
+		if err := store.Load(embeddingsCacheFile, &plain); err == nil {
+			if err := store.SaveEncrypted(embeddingsEncryptedFile, key, &plain); err != nil {
+				// Keep the plaintext rather than lose the index
+				log.Printf("Failed to encrypt the embeddings index: %v", err)
+				return err
+			}
+			*saved, loadErr = plain, nil
+		}
+	}
+	if err := store.Remove(embeddingsCacheFile); err != nil {
+		log.Printf("Failed to remove the plaintext embeddings index: %v", err)
+	} else {
+		log.Printf("Removed the plaintext embeddings index; it's kept encrypted now")
+	}
+	return loadErr
+}
+
+// indexEncryptionEnabled reports whether SLACK_MCP_ENCRYPT_INDEX is on
+func indexEncryptionEnabled() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("SLACK_MCP_ENCRYPT_INDEX"))) {
+	case "on", "true", "1", "yes":
+		return true
+	}
+	return false
+}
+
+// saveEmbeddingsIndex writes the index, encrypted when configured. Turning
+// encryption on removes the plaintext copy.
+func saveEmbeddingsIndex(p *provider.ApiProvider, index *embeddings.Index) {
+	store := p.Store()
+	if store == nil {
+		return
+	}
+	if !indexEncryptionEnabled() {
+		if err := store.Save(embeddingsCacheFile, index); err != nil {
+			log.Printf("Failed to save embeddings index: %v", err)
+		}
+		return
+	}
+	key, err := cache.LoadKey()
+	if err != nil {
+		log.Printf("Not saving embeddings index, no encryption key: %v", err)
+		return
+	}
+	if err := store.SaveEncrypted(embeddingsEncryptedFile, key, index); err != nil {
+		log.Printf("Failed to save embeddings index: %v", err)
+		return
+	}
+	_ = store.Remove(embeddingsCacheFile)
+}
+
+// updateEmbeddingsIndex embeds messages newer than oldest that aren't
+// indexed yet. Returns how many entries were added.
+func updateEmbeddingsIndex(ctx context.Context, api *slack.Client, client *embeddings.Client, index *embeddings.Index, channelIDs []string, oldest time.Time) (int, error) {
+	known := index.Keys()
+	var pending []embeddings.Entry
+
+	for _, channelID := range channelIDs {
+		if len(pending) >= semanticMaxNewPerCall {
+			break
+		}
+		resp, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: channelID,
+			Oldest:    fmt.Sprintf("%d", oldest.Unix()),
+			Limit:     200,
+		})
+		if err != nil {
+			log.Printf("Semantic index: skipping %s: %v", channelID, err)
+			continue
+		}
+		for _, msg := range resp.Messages {
+			if msg.SubType != "" || len(msg.Text) < semanticMinTextLength {
+				continue
+			}
+			e := embeddings.Entry{ChannelID: channelID, Timestamp: msg.Timestamp, User: msg.User, Text: msg.Text}
+			if known[e.Key()] {
+				continue
+			}
+			pending = append(pending, e)
+		}
+	}
+
+	added := 0
+	for start := 0; start < len(pending); start += semanticBatchSize {
+		end := start + semanticBatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		batch := pending[start:end]
+
+		texts := make([]string, len(batch))
+		for i, e := range batch {
+			texts[i] = e.Text
+		}
+		vectors, err := client.Embed(ctx, texts)
+		if err != nil {
+			return added, err
+		}
+		for i := range batch {
+			batch[i].Vector = vectors[i]
+			index.Entries = append(index.Entries, batch[i])
+			added++
+		}
+	}
+	return added, nil
+}
@@ -0,0 +1,36 @@
+package features
+
+import (
+	"testing"
+
+	"github.com/aaronsb/slack-mcp/pkg/embeddings"
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+func TestLoadEmbeddingsIndexEncryptsPlaintext(t *testing.T) {
+	t.Setenv("SLACK_MCP_DATA_DIR", t.TempDir())
+	p := provider.NewWithTokens("xoxp-test", "")
+	store := p.Store()
+	plain := embeddings.Index{Model: "m", Entries: []embeddings.Entry{{ChannelID: "C1", Timestamp: "1.0", Text: "secret plans"}}}
+	if err := store.Save(embeddingsCacheFile, &plain); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("SLACK_MCP_ENCRYPT_INDEX", "on")
+	t.Setenv("SLACK_MCP_INDEX_KEY", "correct horse")
+	index := loadEmbeddingsIndex(p, "m")
+	if len(index.Entries) != 1 {
+		t.Fatalf("entries = %+v, want the migrated index", index.Entries)
+	}
+	if store.Exists(embeddingsCacheFile) {
+		t.Error("plaintext index should be removed")
+	}
+	if !store.Exists(embeddingsEncryptedFile) {
+		t.Error("encrypted index should be written")
+	}
+
+	// The encrypted copy is what loads from now on
+	if index := loadEmbeddingsIndex(p, "m"); len(index.Entries) != 1 {
+		t.Errorf("reloaded entries = %+v", index.Entries)
+	}
+}
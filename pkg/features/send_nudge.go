@@ -0,0 +1,305 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// SendNudge composes a polite follow-up on an earlier message, quoting and
+// linking it, and posts it in the thread or as a DM. A cooldown keeps the
+// same person from being nudged more than once a day.
+var SendNudge = &Feature{
+	Name:        "send-nudge",
+	Description: "Politely follow up on an earlier message: quotes and links the original, posts in its thread or as a DM, at most once a day per person",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel": map[string]interface{}{
+				"type":        "string",
+				"description": "Channel name or ID containing the original message",
+			},
+			"messageTs": map[string]interface{}{
+				"type":        "string",
+				"description": "Timestamp of the message to follow up on",
+			},
+			"user": map[string]interface{}{
+				"type":        "string",
+				"description": "Person to nudge (username, real name, email, or user ID). Required for DM nudges.",
+			},
+			"via": map[string]interface{}{
+				"type":        "string",
+				"description": "Where to post the nudge: 'thread' (reply to the original) or 'dm'",
+				"enum":        []string{"thread", "dm"},
+				"default":     "thread",
+			},
+			"note": map[string]interface{}{
+				"type":        "string",
+				"description": "Optional extra sentence to add to the nudge",
+			},
+		},
+		"required": []string{"channel", "messageTs"},
+	},
+	Handler: sendNudgeHandler,
+}
+
+const (
+	nudgesCacheFile = "nudges.json"
+	nudgeCooldown   = 24 * time.Hour
+	nudgeQuoteLimit = 300
+)
+
+// nudgeMu serializes cooldown read-modify-write on the nudges file
+var nudgeMu sync.Mutex
+
+func sendNudgeHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	channel, _ := params["channel"].(string)
+	messageTs, _ := params["messageTs"].(string)
+	userParam, _ := params["user"].(string)
+	note, _ := params["note"].(string)
+	via := "thread"
+	if v, ok := params["via"].(string); ok && v != "" {
+		via = v
+	}
+	if via != "thread" && via != "dm" {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Unknown via '%s'; use 'thread' or 'dm'", via),
+		}, nil
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	channelID := resolveChannelForSending(apiProvider, api, channel)
+	if channelID == "" {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Could not find channel '%s'", channel),
+		}, nil
+	}
+
+	usersMap := apiProvider.ProvideUsersMap()
+	userID := ""
+	if userParam != "" {
+		userID = resolveUserRef(ctx, apiProvider, userParam, usersMap)
+		if userID == "" {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Could not find user '%s'", userParam),
+			}, nil
+		}
+	} else if via == "dm" {
+		return &FeatureResult{
+			Success: false,
+			Message: "user is required when nudging via DM",
+		}, nil
+	}
+
+	original, err := fetchMessage(ctx, api, channelID, messageTs)
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Could not load message %s: %v", messageTs, err),
+		}, nil
+	}
+
+	// Cooldown is per person, or per message when nobody in particular is nudged
+	cooldownKey := userID
+	if cooldownKey == "" {
+		cooldownKey = channelID + ":" + messageTs
+	}
+	nudgeMu.Lock()
+	defer nudgeMu.Unlock()
+	history := loadNudges(apiProvider)
+	if last, ok := history[cooldownKey]; ok && time.Since(last) < nudgeCooldown {
+		who := "this message"
+		if userID != "" {
+			who = userDisplayName(userID, usersMap)
+		}
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Already nudged %s (%s); next nudge allowed after %s", who, formatTimestamp(last), last.Add(nudgeCooldown).Format("Jan 2 at 3:04 PM")),
+			Guidance: "Give people time to respond. Check whether anything happened since: check-message-reach",
+			NextActions: []string{
+				fmt.Sprintf("check-message-reach channel='%s' messageTs='%s'", channel, messageTs),
+			},
+		}, nil
+	}
+
+	if qh := loadQuietHours(); qh != nil && qh.active(time.Now()) {
+		return quietHoursBlocked(qh, "sending", time.Now()), nil
+	}
+
+	permalink, err := api.GetPermalinkContext(ctx, &slack.PermalinkParameters{Channel: channelID, Ts: original.Timestamp})
+	if err != nil {
+		log.Printf("Failed to get permalink for %s:%s: %v", channelID, original.Timestamp, err)
+	}
+
+	text := composeNudge(via, userID, original.Text, permalink, note)
+	targetID := channelID
+	threadTs := ""
+	if via == "dm" {
+		ch, _, _, err := api.OpenConversationContext(ctx, &slack.OpenConversationParameters{Users: []string{userID}})
+		if err != nil {
+			return &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Failed to open DM: %v", err),
+			}, nil
+		}
+		targetID = ch.ID
+	} else {
+		threadTs = original.ThreadTimestamp
+		if threadTs == "" {
+			threadTs = original.Timestamp
+		}
+	}
+
+	options := []slack.MsgOption{slack.MsgOptionText(text, false)}
+	if threadTs != "" {
+		options = append(options, slack.MsgOptionTS(threadTs))
+	}
+	_, timestamp, err := api.PostMessageContext(ctx, targetID, options...)
+	if err != nil {
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Failed to send nudge: %v", err),
+			Guidance: "⚠️ Check if you have permission to post here",
+		}, nil
+	}
+	apiProvider.RecordAction(provider.UsageMessagesSent, 1)
+
+	history[cooldownKey] = time.Now()
+	if store := apiProvider.Store(); store != nil {
+		if err := store.Save(nudgesCacheFile, history); err != nil {
+			log.Printf("Failed to save nudge history: %v", err)
+		}
+	}
+
+	result := &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Nudge sent via %s", via),
+		Data: map[string]interface{}{
+			"channelId": targetID,
+			"timestamp": timestamp,
+			"threadTs":  threadTs,
+			"message":   text,
+			"permalink": permalink,
+		},
+		Guidance: "Nudge sent. The same person can't be nudged again for 24 hours.",
+		NextActions: []string{
+			fmt.Sprintf("Check later for a response: check-message-reach channel='%s' messageTs='%s'", channel, original.Timestamp),
+		},
+	}
+	return result, nil
+}
+
+// composeNudge builds the follow-up text. Thread nudges sit right under the
+// original, so only DMs quote and link it.
+func composeNudge(via, userID, original, permalink, note string) string {
+	var b strings.Builder
+	greeting := "Hi"
+	if userID != "" {
+		greeting = fmt.Sprintf("Hi <@%s>", userID)
+	}
+	if via == "thread" {
+		b.WriteString(greeting + ", gently bumping this in case it got buried.")
+	} else {
+		b.WriteString(greeting + ", following up on this when you have a moment:\n")
+		quote := truncate(strings.TrimSpace(original), nudgeQuoteLimit)
+		for _, line := range strings.Split(quote, "\n") {
+			b.WriteString("> " + line + "\n")
+		}
+		if permalink != "" {
+			b.WriteString(permalink)
+		}
+	}
+	if note = strings.TrimSpace(note); note != "" {
+		b.WriteString("\n" + note)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// fetchMessage loads a single message by timestamp, including thread replies
+func fetchMessage(ctx context.Context, api *slack.Client, channelID, ts string) (slack.Message, error) {
+	msgs, _, _, err := api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: channelID,
+		Timestamp: ts,
+		Limit:     1,
+	})
+	if err != nil {
+		return slack.Message{}, err
+	}
+	for _, m := range msgs {
+		if m.Timestamp == ts {
+			return m, nil
+		}
+	}
+	if len(msgs) > 0 {
+		return msgs[0], nil
+	}
+	return slack.Message{}, fmt.Errorf("message not found")
+}
+
+// resolveUserID maps a username, display name, real name or user ID to a
+// user ID, or "" when nobody matches
+func resolveUserID(name string, usersMap map[string]slack.User) string {
+	if _, ok := usersMap[name]; ok {
+		return name
+	}
+	for uid, u := range usersMap {
+		if strings.EqualFold(u.Name, name) || strings.EqualFold(u.RealName, name) ||
+			strings.EqualFold(u.Profile.DisplayName, name) {
+			return uid
+		}
+	}
+	return ""
+}
+
+// resolveUserRef is resolveUserID that also accepts "@handle" and email
+// addresses, which are looked up with users.lookupByEmail on a cache miss
+func resolveUserRef(ctx context.Context, p *provider.ApiProvider, name string, usersMap map[string]slack.User) string {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "@")
+	if id := resolveUserID(name, usersMap); id != "" {
+		return id
+	}
+	if strings.Contains(name, "@") {
+		if user, err := p.ResolveUserByEmail(ctx, name); err == nil {
+			return user.ID
+		}
+	}
+	return ""
+}
+
+func loadNudges(p *provider.ApiProvider) map[string]time.Time {
+	history := map[string]time.Time{}
+	if store := p.Store(); store != nil {
+		_ = store.Load(nudgesCacheFile, &history)
+	}
+	// Drop entries past the cooldown so the file doesn't grow forever
+	for k, t := range history {
+		if time.Since(t) >= nudgeCooldown {
+			delete(history, k)
+		}
+	}
+	return history
+}
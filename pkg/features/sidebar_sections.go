@@ -0,0 +1,69 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+// loadSidebar reads the user's sidebar sections and, when section is set,
+// finds that one (-1 otherwise). On failure it returns the result to hand
+// back. Cache-only reads use the last fetched sidebar.
+func loadSidebar(ctx context.Context, ap *provider.ApiProvider, section string, cacheOnly bool) (*provider.Sidebar, int, *FeatureResult) {
+	var sidebar *provider.Sidebar
+	var err error
+	if cacheOnly {
+		if sidebar = ap.CachedSidebar(); sidebar == nil {
+			err = fmt.Errorf("not fetched yet")
+		}
+	} else {
+		sidebar, err = ap.Sidebar(ctx)
+	}
+	if err != nil {
+		return nil, -1, &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Couldn't read your sidebar sections: %v", err),
+			Guidance: "Sidebar sections need a browser session (xoxc) token. Group by naming convention instead: list-channels groupBy='category'",
+		}
+	}
+	if section == "" {
+		return sidebar, -1, nil
+	}
+	idx := sidebar.FindSection(section)
+	if idx < 0 {
+		return nil, -1, &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("No sidebar section named '%s'", section),
+			Guidance: fmt.Sprintf("Your sections: %s", strings.Join(sidebar.SectionNames(), ", ")),
+		}
+	}
+	return sidebar, idx, nil
+}
+
+// countsInSection narrows a client.counts response to the conversations
+// shown in one sidebar section. The shared response is copied, not
+// modified.
+func countsInSection(counts *provider.ClientCountsResponse, sidebar *provider.Sidebar, idx int) *provider.ClientCountsResponse {
+	filtered := *counts
+	filtered.Channels = filtered.Channels[:0:0]
+	for _, ch := range counts.Channels {
+		if sidebar.InSection(idx, ch.ID, false) {
+			filtered.Channels = append(filtered.Channels, ch)
+		}
+	}
+	filtered.MPIMs = filtered.MPIMs[:0:0]
+	for _, ch := range counts.MPIMs {
+		if sidebar.InSection(idx, ch.ID, true) {
+			filtered.MPIMs = append(filtered.MPIMs, ch)
+		}
+	}
+	filtered.IMs = filtered.IMs[:0:0]
+	for _, ch := range counts.IMs {
+		if sidebar.InSection(idx, ch.ID, true) {
+			filtered.IMs = append(filtered.IMs, ch)
+		}
+	}
+	return &filtered
+}
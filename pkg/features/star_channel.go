@@ -0,0 +1,131 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+// StarChannel and UnstarChannel add a conversation to, or take it out of,
+// the user's starred list. Starred conversations rank first in
+// check-unreads and check-mentions.
+var StarChannel = &Feature{
+	Name:        "star-channel",
+	Description: "Star a channel or DM so it shows in your Starred sidebar section and ranks first in check-unreads and check-mentions",
+	Schema:      starSchema,
+	Handler: func(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+		return starHandler(ctx, params, true)
+	},
+}
+
+var UnstarChannel = &Feature{
+	Name:        "unstar-channel",
+	Description: "Unstar a channel or DM",
+	Schema:      starSchema,
+	Handler: func(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+		return starHandler(ctx, params, false)
+	},
+}
+
+var starSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"channel": map[string]interface{}{
+			"type":        "string",
+			"description": "Channel name or ID, or a person ('@alice' or their name) for your DM with them",
+		},
+	},
+	"required": []string{"channel"},
+}
+
+func starHandler(ctx context.Context, params map[string]interface{}, star bool) (*FeatureResult, error) {
+	channel, _ := params["channel"].(string)
+	if channel == "" {
+		return &FeatureResult{
+			Success: false,
+			Message: "channel is required",
+		}, nil
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	channelID := resolveChannelForSending(apiProvider, api, channel)
+	if channelID == "" {
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Could not find channel '%s'", channel),
+			Guidance: "Use 'list-channels' to see available channels",
+		}, nil
+	}
+	channelName := resolveChannelName(ctx, apiProvider, channelID, channel)
+
+	action, verb := "starred", "star"
+	if !star {
+		action, verb = "unstarred", "unstar"
+	}
+	if err := apiProvider.SetStarred(ctx, channelID, star); err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to %s %s: %v", verb, channelName, err),
+		}, nil
+	}
+
+	return &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("%s %s", channelName, action),
+		Data: map[string]interface{}{
+			"action":    action,
+			"channel":   channelName,
+			"channelId": channelID,
+		},
+		NextActions: []string{"See your starred conversations: list-channels filter='starred'"},
+	}, nil
+}
+
+// starredFirst reorders a client.counts response so starred conversations
+// come first in each list, keeping the order otherwise. The shared response
+// is copied, not modified.
+func starredFirst(counts *provider.ClientCountsResponse, starred map[string]bool) *provider.ClientCountsResponse {
+	if len(starred) == 0 {
+		return counts
+	}
+	sorted := *counts
+	sorted.Channels = append(counts.Channels[:0:0], counts.Channels...)
+	sort.SliceStable(sorted.Channels, func(i, j int) bool {
+		return starred[sorted.Channels[i].ID] && !starred[sorted.Channels[j].ID]
+	})
+	sorted.MPIMs = append(counts.MPIMs[:0:0], counts.MPIMs...)
+	sort.SliceStable(sorted.MPIMs, func(i, j int) bool {
+		return starred[sorted.MPIMs[i].ID] && !starred[sorted.MPIMs[j].ID]
+	})
+	sorted.IMs = append(counts.IMs[:0:0], counts.IMs...)
+	sort.SliceStable(sorted.IMs, func(i, j int) bool {
+		return starred[sorted.IMs[i].ID] && !starred[sorted.IMs[j].ID]
+	})
+	return &sorted
+}
+
+// markStarred flags the items that came from a starred conversation
+func markStarred(items []map[string]interface{}, starred map[string]bool) {
+	for _, item := range items {
+		if id, _ := item["channelId"].(string); starred[id] {
+			item["starred"] = true
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package features
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+func TestStarredFirst(t *testing.T) {
+	var counts provider.ClientCountsResponse
+	body := `{"ok":true,
+		"channels":[{"id":"C1"},{"id":"C2"},{"id":"C3"},{"id":"C4"}],
+		"ims":[{"id":"D1"},{"id":"D2"}]}`
+	if err := json.Unmarshal([]byte(body), &counts); err != nil {
+		t.Fatal(err)
+	}
+
+	sorted := starredFirst(&counts, map[string]bool{"C3": true, "C4": true, "D2": true})
+	var ids []string
+	for _, ch := range sorted.Channels {
+		ids = append(ids, ch.ID)
+	}
+	for _, im := range sorted.IMs {
+		ids = append(ids, im.ID)
+	}
+	if fmt.Sprint(ids) != "[C3 C4 C1 C2 D2 D1]" {
+		t.Errorf("order = %v", ids)
+	}
+	// The shared response is left alone
+	if counts.Channels[0].ID != "C1" || counts.IMs[0].ID != "D1" {
+		t.Error("starredFirst reordered the original counts")
+	}
+}
@@ -0,0 +1,198 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/aaronsb/slack-mcp/pkg/text"
+	"github.com/slack-go/slack"
+)
+
+// SuggestChannel recommends where a message belongs by matching it against
+// channel names, purposes and topics, and where similar terms have been
+// discussed before
+var SuggestChannel = &Feature{
+	Name:        "suggest-channel",
+	Description: "Recommend the best channels for a draft message or topic, based on channel names, purposes, and where similar things were discussed",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"message": map[string]interface{}{
+				"type":        "string",
+				"description": "Draft message or topic to find a home for",
+			},
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": "Number of channels to suggest (default: 5)",
+				"default":     5,
+			},
+		},
+		"required": []string{"message"},
+	},
+	Handler: suggestChannelHandler,
+}
+
+var termPattern = regexp.MustCompile(`[a-z0-9][a-z0-9_-]{2,}`)
+
+// suggestMaxTerms limits the search query built from the draft
+const suggestMaxTerms = 6
+
+func suggestChannelHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	message, _ := params["message"].(string)
+	limit := 5
+	if l, ok := params["limit"].(float64); ok && l > 0 {
+		limit = int(l)
+	}
+
+	terms := keyTerms(message)
+	if len(terms) == 0 {
+		return &FeatureResult{
+			Success: false,
+			Message: "The message has no distinctive terms to match channels against",
+		}, nil
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	type candidate struct {
+		ch      slack.Channel
+		score   float64
+		reasons []string
+		history int
+	}
+	candidates := map[string]*candidate{}
+
+	// Metadata matching against every cached channel
+	for _, ch := range apiProvider.GetCachedChannels() {
+		if ch.IsArchived || ch.IsIM || ch.IsMpIM {
+			continue
+		}
+		c := &candidate{ch: ch}
+		name := strings.ToLower(ch.Name)
+		about := strings.ToLower(ch.Purpose.Value + " " + ch.Topic.Value)
+		var nameHits, aboutHits []string
+		for _, t := range terms {
+			if strings.Contains(name, t) {
+				nameHits = append(nameHits, t)
+			} else if strings.Contains(about, t) {
+				aboutHits = append(aboutHits, t)
+			}
+		}
+		c.score = 3*float64(len(nameHits)) + 1.5*float64(len(aboutHits))
+		if len(nameHits) > 0 {
+			c.reasons = append(c.reasons, "name matches "+strings.Join(nameHits, ", "))
+		}
+		if len(aboutHits) > 0 {
+			c.reasons = append(c.reasons, "purpose/topic mentions "+strings.Join(aboutHits, ", "))
+		}
+		candidates[ch.ID] = c
+	}
+
+	// Historical discussion: where have these terms come up recently?
+	if api, err := apiProvider.Provide(); err == nil {
+		sp := slack.NewSearchParameters()
+		sp.Sort = "score"
+		sp.Count = 100
+		q := strings.Join(terms[:min(len(terms), suggestMaxTerms)], " OR ") + " " + parseTimeframeToDateFilter("3m")
+		if res, err := api.SearchMessagesContext(ctx, q, sp); err == nil {
+			for _, m := range res.Matches {
+				if m.Channel.IsMPIM || strings.HasPrefix(m.Channel.ID, "D") {
+					continue
+				}
+				c, ok := candidates[m.Channel.ID]
+				if !ok {
+					c = &candidate{ch: slack.Channel{GroupConversation: slack.GroupConversation{
+						Name:         m.Channel.Name,
+						Conversation: slack.Conversation{ID: m.Channel.ID},
+					}}}
+					candidates[m.Channel.ID] = c
+				}
+				c.history++
+			}
+		}
+	}
+
+	ranked := make([]*candidate, 0, len(candidates))
+	for _, c := range candidates {
+		if c.history > 0 {
+			c.score += 2 * math.Log1p(float64(c.history))
+			c.reasons = append(c.reasons, fmt.Sprintf("%d recent messages on similar terms", c.history))
+		}
+		if c.score == 0 {
+			continue
+		}
+		if c.ch.IsMember {
+			c.score *= 1.1
+		}
+		ranked = append(ranked, c)
+	}
+	sort.Slice(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	suggestions := make([]map[string]interface{}, 0, len(ranked))
+	for _, c := range ranked {
+		name := c.ch.Name
+		if name == "" {
+			name = apiProvider.ResolveChannelName(ctx, c.ch.ID)
+		}
+		suggestions = append(suggestions, map[string]interface{}{
+			"channel":  name,
+			"purpose":  c.ch.Purpose.Value,
+			"isMember": c.ch.IsMember,
+			"members":  c.ch.NumMembers,
+			"score":    fmt.Sprintf("%.1f", c.score),
+			"reasons":  c.reasons,
+		})
+	}
+
+	result := &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Suggested %d channels for terms: %s", len(suggestions), strings.Join(terms, ", ")),
+		Data: map[string]interface{}{
+			"terms":       terms,
+			"suggestions": suggestions,
+		},
+		ResultCount: len(suggestions),
+	}
+
+	if len(suggestions) > 0 {
+		best := suggestions[0]["channel"]
+		result.NextActions = []string{
+			fmt.Sprintf("Check recent activity: catch-up channel='%s' since='1d'", best),
+			fmt.Sprintf("Post it: send-message channel='%s'", best),
+		}
+		result.Guidance = "Check the channel's recent activity before posting; a non-member channel may expect you to join first."
+	} else {
+		result.Guidance = "No channel looks like a good fit. Consider asking in a general help channel or DMing someone: find-expert"
+	}
+
+	return result, nil
+}
+
+// keyTerms extracts distinctive lowercase terms from a draft, dropping
+// stopwords and duplicates, in first-seen order
+func keyTerms(message string) []string {
+	cleaned := strings.ToLower(text.ProcessText(strings.ToLower(message)))
+	seen := map[string]bool{}
+	var terms []string
+	for _, t := range termPattern.FindAllString(cleaned, -1) {
+		if !seen[t] {
+			seen[t] = true
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
@@ -0,0 +1,187 @@
+package features
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/paths"
+)
+
+// actionItem is a mention that asks something of the user and has no reply
+// from them yet
+type actionItem struct {
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	Notes    string    `json:"notes"`
+	URL      string    `json:"url,omitempty"`
+	Priority string    `json:"priority"`
+	Created  time.Time `json:"created"`
+	Source   string    `json:"source"`
+	ThreadID string    `json:"threadId"`
+}
+
+// icsPriority maps mention urgency onto the iCalendar 1-9 scale
+var icsPriority = map[string]int{"high": 1, "medium": 5, "low": 9}
+
+// actionItemsFromMentions picks the open requests and questions out of a
+// check-mentions result
+func actionItemsFromMentions(mentions []map[string]interface{}) []actionItem {
+	var items []actionItem
+	for _, m := range mentions {
+		kind := str(m, "type")
+		if (kind != "request" && kind != "direct_question") || m["responded"] == true {
+			continue
+		}
+		text := strings.TrimSpace(str(m, "message"))
+		title := fmt.Sprintf("Reply to %s in #%s", str(m, "author"), str(m, "channel"))
+		if kind == "request" {
+			title = fmt.Sprintf("%s (from %s)", truncate(text, 80), str(m, "author"))
+		}
+		ts := str(m, "messageTs")
+		items = append(items, actionItem{
+			ID:       strings.NewReplacer(":", "-", ".", "-").Replace(str(m, "threadId")+"-"+ts) + "@slack-mcp",
+			Title:    title,
+			Notes:    fmt.Sprintf("%s in #%s:\n%s", str(m, "author"), str(m, "channel"), text),
+			URL:      str(m, "permalink"),
+			Priority: str(m, "urgency"),
+			Created:  parseSlackTimestamp(ts),
+			Source:   "slack",
+			ThreadID: str(m, "threadId"),
+		})
+	}
+	return items
+}
+
+// renderICS writes the items as an iCalendar file of VTODOs
+func renderICS(items []actionItem, now time.Time) string {
+	var b strings.Builder
+	line := func(s string) {
+		b.WriteString(icsFold(s) + "\r\n")
+	}
+	stamp := now.UTC().Format("20060102T150405Z")
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//slack-mcp//action items//EN")
+	for _, it := range items {
+		line("BEGIN:VTODO")
+		line("UID:" + it.ID)
+		line("DTSTAMP:" + stamp)
+		if !it.Created.IsZero() {
+			line("CREATED:" + it.Created.UTC().Format("20060102T150405Z"))
+		}
+		line("SUMMARY:" + icsEscape(it.Title))
+		line("DESCRIPTION:" + icsEscape(it.Notes))
+		if it.URL != "" {
+			line("URL:" + it.URL)
+		}
+		if p, ok := icsPriority[it.Priority]; ok {
+			line(fmt.Sprintf("PRIORITY:%d", p))
+		}
+		line("STATUS:NEEDS-ACTION")
+		line("CATEGORIES:Slack")
+		line("END:VTODO")
+	}
+	line("END:VCALENDAR")
+	return b.String()
+}
+
+// icsEscape escapes TEXT values per RFC 5545
+func icsEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// icsFold wraps content lines at 75 octets without splitting a UTF-8
+// sequence; continuation lines start with a space
+func icsFold(s string) string {
+	if len(s) <= 75 {
+		return s
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		n := len(string(r))
+		if width+n > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += n
+	}
+	return b.String()
+}
+
+// writeTaskExport writes the items as tasks.ics or tasks.json in dir,
+// refusing to overwrite an existing file
+func writeTaskExport(dir, format string, items []actionItem, now time.Time) (string, error) {
+	if strings.TrimSpace(dir) == "" {
+		dir = paths.DownloadsDir()
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	var content []byte
+	switch format {
+	case "ics":
+		content = []byte(renderICS(items, now))
+	case "json":
+		if items == nil {
+			items = []actionItem{}
+		}
+		content, err = json.MarshalIndent(map[string]interface{}{
+			"version":  1,
+			"exported": now.UTC().Format(time.RFC3339),
+			"tasks":    items,
+		}, "", "  ")
+		if err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("unknown export format %q; use 'ics' or 'json'", format)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("slack-tasks-%s.%s", now.Format("20060102-150405"), format))
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return "", err
+	}
+	if _, err := out.Write(content); err != nil {
+		out.Close()
+		os.Remove(path)
+		return "", err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+// withTaskExport writes a check-mentions result's open action items to a
+// file when the caller asked for one, noting the outcome on the result
+func withTaskExport(result *FeatureResult, params map[string]interface{}) *FeatureResult {
+	format, _ := params["exportTasks"].(string)
+	if format == "" || format == "none" || result == nil || !result.Success {
+		return result
+	}
+	data, _ := result.Data.(map[string]interface{})
+	items := actionItemsFromMentions(asList(data["mentions"]))
+	dir, _ := params["exportDir"].(string)
+	path, err := writeTaskExport(dir, format, items, time.Now())
+	if err != nil {
+		result.Guidance = strings.TrimSpace(result.Guidance + fmt.Sprintf(" ⚠️ Task export failed: %v", err))
+		return result
+	}
+	data["taskExport"] = map[string]interface{}{"path": path, "format": format, "tasks": len(items)}
+	result.NextActions = append(result.NextActions,
+		fmt.Sprintf("Import %d action item(s) from %s into your task manager", len(items), path))
+	return result
+}
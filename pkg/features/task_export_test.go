@@ -0,0 +1,59 @@
+package features
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestActionItemsFromMentions(t *testing.T) {
+	mentions := []map[string]interface{}{
+		{"type": "request", "message": "Please review the PR", "author": "Alice", "channel": "eng", "urgency": "high", "threadId": "C1:1.1", "messageTs": "1700000000.000100", "permalink": "https://x/p1"},
+		{"type": "direct_question", "message": "Are we on for Friday?", "author": "Bob", "channel": "team", "urgency": "medium", "threadId": "C2:2.2", "messageTs": "1700000001.000200"},
+		{"type": "direct_question", "message": "Done?", "author": "Cy", "channel": "team", "responded": true},
+		{"type": "fyi", "message": "FYI the deploy is out", "author": "Dee", "channel": "eng"},
+	}
+
+	items := actionItemsFromMentions(mentions)
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2: %+v", len(items), items)
+	}
+	if items[0].Title != "Please review the PR (from Alice)" || items[0].URL != "https://x/p1" || items[0].Priority != "high" {
+		t.Errorf("request item = %+v", items[0])
+	}
+	if items[1].Title != "Reply to Bob in #team" || items[1].Created.Unix() != 1700000001 {
+		t.Errorf("question item = %+v", items[1])
+	}
+	if items[0].ID != "C1-1-1-1700000000-000100@slack-mcp" {
+		t.Errorf("id = %q", items[0].ID)
+	}
+}
+
+func TestRenderICS(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	ics := renderICS([]actionItem{{
+		ID:       "C1-1-1@slack-mcp",
+		Title:    "Reply to Bob, today; please",
+		Notes:    "line one\nline two " + strings.Repeat("é", 60),
+		Priority: "high",
+		Created:  now.Add(-time.Hour),
+	}}, now)
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"BEGIN:VTODO\r\nUID:C1-1-1@slack-mcp\r\nDTSTAMP:20260304T120000Z\r\nCREATED:20260304T110000Z\r\n",
+		`SUMMARY:Reply to Bob\, today\; please` + "\r\n",
+		"PRIORITY:1\r\n",
+		"STATUS:NEEDS-ACTION\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("ics missing %q:\n%s", want, ics)
+		}
+	}
+	for _, line := range strings.Split(ics, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("unfolded line (%d octets): %q", len(line), line)
+		}
+	}
+}
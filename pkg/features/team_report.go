@@ -0,0 +1,442 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// GenerateTeamReport rolls a week (or any period) of a team's channels up
+// into a Markdown report: the big threads, decisions, what shipped, open
+// questions, and a highlight per member
+var GenerateTeamReport = &Feature{
+	Name:        "generate-team-report",
+	Description: "Summarize a team's channels over a period as a Markdown report: major threads, decisions, shipped announcements, open questions, and per-member highlights",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channels": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Channels to cover (default: SLACK_MCP_TEAM_CHANNELS)",
+			},
+			"period": map[string]interface{}{
+				"type":        "string",
+				"description": "How far back to look (e.g., '7d', '2w')",
+				"default":     "7d",
+			},
+			"members": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Only give highlights for these people (names, handles, or emails)",
+			},
+			"title": map[string]interface{}{
+				"type":        "string",
+				"description": "Report heading (default: 'Team report')",
+			},
+		},
+	},
+	Handler: generateTeamReportHandler,
+}
+
+const (
+	// teamReportMaxPages bounds history fetching per channel
+	teamReportMaxPages = 5
+	// teamReportSectionLimit caps items per report section
+	teamReportSectionLimit = 8
+)
+
+// shippedPattern spots release and launch announcements
+var shippedPattern = regexp.MustCompile(`(?i)\b(shipped|released|launched|deployed|rolled out|went live|is (now )?live|now available)\b`)
+
+// teamReportSections are the report sections in display order
+var teamReportSections = []struct{ key, heading string }{
+	{"threads", "Major threads"},
+	{"decisions", "Decisions"},
+	{"shipped", "Shipped"},
+	{"openQuestions", "Open questions"},
+}
+
+func generateTeamReportHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	channels := stringList(params["channels"])
+	if len(channels) == 0 {
+		channels = stringList(os.Getenv("SLACK_MCP_TEAM_CHANNELS"))
+	}
+	if len(channels) == 0 {
+		return &FeatureResult{
+			Success:  false,
+			Message:  "No channels to report on",
+			Guidance: "Pass channels=['#team', '#team-eng'], or set SLACK_MCP_TEAM_CHANNELS",
+		}, nil
+	}
+	period := "7d"
+	if p, ok := params["period"].(string); ok && p != "" {
+		period = p
+	}
+	title := "Team report"
+	if t, ok := params["title"].(string); ok && strings.TrimSpace(t) != "" {
+		title = strings.TrimSpace(t)
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	usersMap := apiProvider.ProvideUsersMap()
+	var onlyMembers map[string]bool
+	if names := stringList(params["members"]); len(names) > 0 {
+		onlyMembers = map[string]bool{}
+		for _, name := range names {
+			if id := resolveUserRef(ctx, apiProvider, name, usersMap); id != "" {
+				onlyMembers[id] = true
+			}
+		}
+	}
+
+	oldest, err := parseTimePeriod(period)
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Invalid period '%s': %v", period, err),
+		}, nil
+	}
+
+	type rootMsg struct {
+		channelID, channel string
+		msg                slack.Message
+	}
+	var (
+		roots     []rootMsg
+		covered   []string
+		skipped   []string
+		readErr   error
+		truncated []string
+		total     int
+		byMember  = map[string]*memberTally{}
+	)
+	for _, ch := range channels {
+		channelID := apiProvider.ResolveChannelID(strings.TrimPrefix(ch, "#"))
+		if !isChannelID(channelID) {
+			skipped = append(skipped, ch)
+			continue
+		}
+		name := apiProvider.ResolveChannelName(ctx, channelID)
+		msgs, more, err := fetchReportHistory(ctx, api, channelID, oldest)
+		if err != nil {
+			skipped = append(skipped, ch)
+			readErr = err
+			continue
+		}
+		covered = append(covered, name)
+		if more {
+			truncated = append(truncated, name)
+		}
+		for _, msg := range msgs {
+			if msg.SubType == "channel_join" || msg.SubType == "channel_leave" {
+				continue
+			}
+			total++
+			roots = append(roots, rootMsg{channelID, name, msg})
+			if msg.User == "" || msg.BotID != "" || (onlyMembers != nil && !onlyMembers[msg.User]) {
+				continue
+			}
+			t := byMember[msg.User]
+			if t == nil {
+				t = &memberTally{channels: map[string]bool{}}
+				byMember[msg.User] = t
+			}
+			t.messages++
+			t.channels[name] = true
+			if score := messageScore(msg); t.top == nil || score > t.topScore {
+				t.top, t.topScore, t.topChannel, t.topChannelID = &msg, score, name, channelID
+			}
+		}
+	}
+	if len(covered) == 0 {
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("None of the channels could be read: %s", strings.Join(skipped, ", ")),
+			Guidance: "Use list-channels to check the names; you must be a member of private channels",
+		}, nil
+	}
+
+	var links permalinkBatch
+	entry := func(channelID, channel string, msg slack.Message) map[string]interface{} {
+		item := map[string]interface{}{
+			"channel":   channel,
+			"author":    userDisplayName(msg.User, usersMap),
+			"text":      readableText(messageBody(msg.Text, msg.Blocks, msg.Attachments), usersMap),
+			"timestamp": formatTimestamp(parseSlackTimestamp(msg.Timestamp)),
+			"replies":   msg.ReplyCount,
+			"reactions": reactionCount(msg),
+		}
+		links.add(item, channelID, msg.Timestamp)
+		return item
+	}
+
+	// Busiest threads first; their replies are where decisions usually land
+	sort.SliceStable(roots, func(i, j int) bool { return roots[i].msg.ReplyCount > roots[j].msg.ReplyCount })
+	threads := []map[string]interface{}{}
+	decisions := []map[string]interface{}{}
+	decided := map[string]bool{}
+	for _, r := range roots {
+		if r.msg.ReplyCount < rollupMinReplies || len(threads) >= rollupMaxThreads {
+			break
+		}
+		item := entry(r.channelID, r.channel, r.msg)
+		if rollup := threadRollup(ctx, api, r.channelID, r.msg, usersMap); rollup != nil {
+			item["participants"] = rollup["participants"]
+			if d, ok := rollup["decisionMessage"].(map[string]interface{}); ok {
+				ts, _ := d["timestamp"].(string)
+				text, _ := d["text"].(string)
+				decided[r.channelID+":"+r.msg.Timestamp] = true
+				decision := map[string]interface{}{
+					"channel":   r.channel,
+					"author":    d["author"],
+					"text":      readableText(text, usersMap),
+					"timestamp": formatTimestamp(parseSlackTimestamp(ts)),
+				}
+				links.add(decision, r.channelID, ts)
+				decisions = append(decisions, decision)
+			}
+		}
+		threads = append(threads, item)
+	}
+
+	// Everything else in posting order
+	sort.SliceStable(roots, func(i, j int) bool { return roots[i].msg.Timestamp < roots[j].msg.Timestamp })
+	announced := []map[string]interface{}{}
+	shipped := []map[string]interface{}{}
+	questions := []map[string]interface{}{}
+	for _, r := range roots {
+		text := messageBody(r.msg.Text, r.msg.Blocks, r.msg.Attachments)
+		switch {
+		case decisionPattern.MatchString(text) && !decided[r.channelID+":"+r.msg.Timestamp]:
+			announced = append(announced, entry(r.channelID, r.channel, r.msg))
+		case shippedPattern.MatchString(text):
+			shipped = append(shipped, entry(r.channelID, r.channel, r.msg))
+		case isOpenQuestion(r.msg):
+			questions = append(questions, entry(r.channelID, r.channel, r.msg))
+		}
+	}
+	// Decisions reached in the major threads come first
+	decisions = append(decisions, lastN(announced, teamReportSectionLimit-len(decisions))...)
+	shipped = lastN(shipped, teamReportSectionLimit)
+	questions = lastN(questions, teamReportSectionLimit)
+
+	members := []map[string]interface{}{}
+	for id, t := range byMember {
+		chans := make([]string, 0, len(t.channels))
+		for c := range t.channels {
+			chans = append(chans, c)
+		}
+		sort.Strings(chans)
+		members = append(members, map[string]interface{}{
+			"name":      userDisplayName(id, usersMap),
+			"messages":  t.messages,
+			"channels":  chans,
+			"highlight": entry(t.topChannelID, t.topChannel, *t.top),
+		})
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if members[i]["messages"].(int) != members[j]["messages"].(int) {
+			return members[i]["messages"].(int) > members[j]["messages"].(int)
+		}
+		return members[i]["name"].(string) < members[j]["name"].(string)
+	})
+
+	links.resolve(ctx, apiProvider)
+
+	// The Markdown is rendered by the formatter, after redaction
+	data := map[string]interface{}{
+		"title":         title,
+		"since":         oldest.Format("2006-01-02"),
+		"until":         time.Now().Format("2006-01-02"),
+		"channels":      covered,
+		"messages":      total,
+		"threads":       threads,
+		"decisions":     decisions,
+		"shipped":       shipped,
+		"openQuestions": questions,
+		"members":       members,
+	}
+
+	result := &FeatureResult{
+		Success:     true,
+		Data:        data,
+		Message:     fmt.Sprintf("%s: %d messages across %d channel(s) since %s", title, total, len(covered), oldest.Format("Jan 2")),
+		ResultCount: total,
+		NextActions: []string{
+			"Post it as a file: post-snippet channel='...' content='<report>' language='markdown'",
+		},
+	}
+	var notes []string
+	if len(skipped) > 0 {
+		notes = append(notes, fmt.Sprintf("Skipped (not found or not readable): %s.", strings.Join(skipped, ", ")))
+	}
+	if len(truncated) > 0 {
+		notes = append(notes, fmt.Sprintf("Only the most recent %d messages were read in %s; use a shorter period for full coverage.",
+			teamReportMaxPages*200, strings.Join(truncated, ", ")))
+	}
+	result.Guidance = strings.Join(notes, " ")
+	if len(skipped) > 0 {
+		reason := PartialError
+		if readErr != nil {
+			reason = stopReason(readErr)
+		}
+		result.MarkPartial(Coverage{Reason: reason, Detail: "some channels weren't found or couldn't be read", Skipped: skipped})
+	}
+	if len(truncated) > 0 {
+		result.MarkPartial(Coverage{
+			Reason: PartialMaxPages,
+			Detail: fmt.Sprintf("only the newest %d messages were read in %s", teamReportMaxPages*200, strings.Join(truncated, ", ")),
+		})
+	}
+	return result, nil
+}
+
+type memberTally struct {
+	messages     int
+	channels     map[string]bool
+	top          *slack.Message
+	topScore     int
+	topChannel   string
+	topChannelID string
+}
+
+// fetchReportHistory reads a channel's top-level messages since oldest,
+// newest first, reporting whether it stopped at the page limit
+func fetchReportHistory(ctx context.Context, api *slack.Client, channelID string, oldest time.Time) ([]slack.Message, bool, error) {
+	var msgs []slack.Message
+	cursor := ""
+	for page := 0; page < teamReportMaxPages; page++ {
+		resp, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: channelID,
+			Oldest:    fmt.Sprintf("%d", oldest.Unix()),
+			Limit:     200,
+			Cursor:    cursor,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		msgs = append(msgs, resp.Messages...)
+		cursor = resp.ResponseMetaData.NextCursor
+		if !resp.HasMore || cursor == "" {
+			return msgs, false, nil
+		}
+	}
+	return msgs, true, nil
+}
+
+// messageScore ranks a message for member highlights by the response it drew
+func messageScore(msg slack.Message) int {
+	return 2*msg.ReplyCount + reactionCount(msg)
+}
+
+func reactionCount(msg slack.Message) int {
+	n := 0
+	for _, r := range msg.Reactions {
+		n += r.Count
+	}
+	return n
+}
+
+// isOpenQuestion reports a person's question that nobody has answered in a
+// thread yet
+func isOpenQuestion(msg slack.Message) bool {
+	return msg.BotID == "" && msg.User != "" && msg.ReplyCount == 0 && strings.Contains(msg.Text, "?")
+}
+
+// readableText swaps user mention tokens for names so the report reads
+// outside Slack
+func readableText(text string, usersMap map[string]slack.User) string {
+	return mentionIDPattern.ReplaceAllStringFunc(text, func(tok string) string {
+		m := mentionIDPattern.FindStringSubmatch(tok)
+		return "@" + userDisplayName(m[1], usersMap)
+	})
+}
+
+// lastN keeps the most recent n items of a chronological list
+func lastN(items []map[string]interface{}, n int) []map[string]interface{} {
+	if n <= 0 {
+		return nil
+	}
+	if len(items) > n {
+		return items[len(items)-n:]
+	}
+	return items
+}
+
+// renderTeamReport lays the report data out as Markdown
+func renderTeamReport(data map[string]interface{}) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("# %s\n\n", str(data, "title")))
+	b.WriteString(fmt.Sprintf("_%s to %s · %s · %d messages_\n",
+		str(data, "since"), str(data, "until"), channelList(data["channels"]), num(data, "messages")))
+
+	line := func(item map[string]interface{}) string {
+		text := truncate(str(item, "text"), 160)
+		if link := str(item, "permalink"); link != "" {
+			text = fmt.Sprintf("[%s](%s)", text, link)
+		}
+		return fmt.Sprintf("- %s — %s in #%s", text, str(item, "author"), str(item, "channel"))
+	}
+
+	for _, section := range teamReportSections {
+		items := asList(data[section.key])
+		if len(items) == 0 {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("\n## %s\n\n", section.heading))
+		for _, item := range items {
+			b.WriteString(line(item))
+			if section.key == "threads" {
+				b.WriteString(fmt.Sprintf(" (%d replies)", num(item, "replies")))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if members := asList(data["members"]); len(members) > 0 {
+		b.WriteString("\n## Team highlights\n\n")
+		for _, m := range members {
+			b.WriteString(fmt.Sprintf("- **%s** (%d messages)", str(m, "name"), num(m, "messages")))
+			if h, ok := m["highlight"].(map[string]interface{}); ok && str(h, "text") != "" {
+				text := truncate(str(h, "text"), 120)
+				if link := str(h, "permalink"); link != "" {
+					text = fmt.Sprintf("[%s](%s)", text, link)
+				}
+				b.WriteString(": " + text)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+func channelList(v interface{}) string {
+	names := stringList(v)
+	for i, n := range names {
+		names[i] = "#" + n
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,53 @@
+package features
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestRenderTeamReport(t *testing.T) {
+	data := map[string]interface{}{
+		"title":    "Eng weekly",
+		"since":    "2026-03-01",
+		"until":    "2026-03-08",
+		"channels": []string{"eng", "releases"},
+		"messages": 42,
+		"threads": []map[string]interface{}{
+			{"text": "Migration plan", "author": "Alice", "channel": "eng", "replies": 12, "permalink": "https://x/p1"},
+		},
+		"decisions": []map[string]interface{}{
+			{"text": "Going with Postgres", "author": "Bob", "channel": "eng"},
+		},
+		"shipped":       []map[string]interface{}{},
+		"openQuestions": []map[string]interface{}{{"text": "Who owns on-call?", "author": "Cy", "channel": "eng"}},
+		"members": []map[string]interface{}{
+			{"name": "Alice", "messages": 9, "highlight": map[string]interface{}{"text": "Migration plan", "permalink": "https://x/p1"}},
+		},
+	}
+
+	report := renderTeamReport(data)
+	for _, want := range []string{
+		"# Eng weekly",
+		"#eng, #releases · 42 messages",
+		"## Major threads\n\n- [Migration plan](https://x/p1) — Alice in #eng (12 replies)",
+		"## Decisions\n\n- Going with Postgres — Bob in #eng\n",
+		"## Open questions",
+		"- **Alice** (9 messages): [Migration plan](https://x/p1)",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "## Shipped") {
+		t.Errorf("empty section rendered:\n%s", report)
+	}
+}
+
+func TestReadableText(t *testing.T) {
+	users := map[string]slack.User{"U1": {ID: "U1", RealName: "Alice"}}
+	if got := readableText("ping <@U1> and <@U2|bob>", users); got != "ping @Alice and @U2" {
+		t.Errorf("readableText = %q", got)
+	}
+}
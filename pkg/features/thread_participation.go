@@ -0,0 +1,108 @@
+package features
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/slack-go/slack"
+)
+
+// participantStats summarizes one person's part in a thread
+type participantStats struct {
+	UserID    string
+	Messages  int
+	First     string // ts of their first message
+	Last      string // ts of their latest message
+	Started   bool   // wrote the parent
+	Mentioned bool   // named in the parent
+}
+
+var mentionIDPattern = regexp.MustCompile(`<@([UW][A-Z0-9]+)(?:\|[^>]*)?>`)
+
+// threadParticipation tallies who posted in a thread and when, in order of
+// first appearance. People mentioned in the parent are included even if
+// they haven't posted, so the ones who haven't weighed in show up too.
+func threadParticipation(msgs []slack.Message, threadTs string) []*participantStats {
+	byUser := map[string]*participantStats{}
+	var order []*participantStats
+	get := func(id string) *participantStats {
+		p, ok := byUser[id]
+		if !ok {
+			p = &participantStats{UserID: id}
+			byUser[id] = p
+			order = append(order, p)
+		}
+		return p
+	}
+
+	for _, msg := range msgs {
+		if msg.User == "" {
+			continue
+		}
+		p := get(msg.User)
+		p.Messages++
+		if p.First == "" || msg.Timestamp < p.First {
+			p.First = msg.Timestamp
+		}
+		if msg.Timestamp > p.Last {
+			p.Last = msg.Timestamp
+		}
+		if msg.Timestamp == threadTs {
+			p.Started = true
+			for _, m := range mentionIDPattern.FindAllStringSubmatch(msg.Text, -1) {
+				get(m[1]).Mentioned = true
+			}
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		if (order[i].First == "") != (order[j].First == "") {
+			return order[j].First == ""
+		}
+		return order[i].First < order[j].First
+	})
+	return order
+}
+
+// participationGuidance points out where the user stands in the thread:
+// the only one asked who hasn't answered, asked but silent, or behind on
+// replies since their own last message
+func participationGuidance(stats []*participantStats, selfID string) string {
+	var self *participantStats
+	asked, answered := 0, 0
+	for _, p := range stats {
+		if p.UserID == selfID {
+			self = p
+			continue
+		}
+		if p.Mentioned {
+			asked++
+			if p.Messages > 0 {
+				answered++
+			}
+		}
+	}
+	if self == nil {
+		return ""
+	}
+
+	if self.Mentioned && self.Messages == 0 {
+		if asked > 0 && answered == asked {
+			return "You're the only one mentioned in this thread who hasn't weighed in."
+		}
+		return "You were mentioned in this thread but haven't replied."
+	}
+	if self.Messages > 0 {
+		since := 0
+		for _, p := range stats {
+			if p.UserID != selfID && p.Last > self.Last {
+				since++
+			}
+		}
+		if since > 0 {
+			return fmt.Sprintf("%d other participant(s) posted after your last message.", since)
+		}
+	}
+	return ""
+}
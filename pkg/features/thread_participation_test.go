@@ -0,0 +1,53 @@
+package features
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func threadMsg(user, ts, text string) slack.Message {
+	var m slack.Message
+	m.User = user
+	m.Timestamp = ts
+	m.Text = text
+	return m
+}
+
+func TestThreadParticipation(t *testing.T) {
+	msgs := []slack.Message{
+		threadMsg("U1", "100.000001", "<@U2> <@UME> thoughts on the rollout?"),
+		threadMsg("U2", "100.000002", "looks good"),
+		threadMsg("U1", "100.000003", "thanks"),
+	}
+	stats := threadParticipation(msgs, "100.000001")
+	if len(stats) != 3 {
+		t.Fatalf("got %d participants, want 3 (two posters plus a silent mention)", len(stats))
+	}
+	if p := stats[0]; p.UserID != "U1" || p.Messages != 2 || !p.Started || p.Last != "100.000003" {
+		t.Fatalf("starter stats wrong: %+v", p)
+	}
+	if p := stats[2]; p.UserID != "UME" || p.Messages != 0 || !p.Mentioned {
+		t.Fatalf("silent mention should sort last: %+v", p)
+	}
+
+	if got := participationGuidance(stats, "UME"); !strings.Contains(got, "only one") {
+		t.Fatalf("guidance = %q, want the only-one-left hint", got)
+	}
+}
+
+func TestParticipationGuidanceRepliesSinceMine(t *testing.T) {
+	msgs := []slack.Message{
+		threadMsg("UME", "100.000001", "proposal"),
+		threadMsg("U2", "100.000002", "+1"),
+		threadMsg("U3", "100.000003", "one concern"),
+	}
+	got := participationGuidance(threadParticipation(msgs, "100.000001"), "UME")
+	if !strings.Contains(got, "2 other participant(s)") {
+		t.Fatalf("guidance = %q", got)
+	}
+	if got := participationGuidance(threadParticipation(msgs, "100.000001"), "U9"); got != "" {
+		t.Fatalf("non-participant got guidance %q", got)
+	}
+}
@@ -0,0 +1,89 @@
+package features
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ThreadRef identifies a thread by its channel and parent message timestamp.
+// Every tool emits thread IDs in its canonical "channelId:threadTs" form and
+// accepts any of the formats parsed by parseThreadRef.
+type ThreadRef struct {
+	ChannelID string
+	ThreadTs  string
+}
+
+func (r ThreadRef) String() string {
+	return r.ChannelID + ":" + r.ThreadTs
+}
+
+// threadRefFor builds the reference for a message: replies point at their
+// parent, everything else at itself
+func threadRefFor(channelID, ts, threadTs string) ThreadRef {
+	if threadTs != "" {
+		ts = threadTs
+	}
+	return ThreadRef{ChannelID: channelID, ThreadTs: ts}
+}
+
+var (
+	slackTsPattern       = regexp.MustCompile(`^\d+\.\d+$`)
+	permalinkPathPattern = regexp.MustCompile(`/archives/([A-Z0-9]+)/p(\d{7,})`)
+)
+
+// parseThreadRef accepts:
+//   - channelId:threadTs (canonical)
+//   - channelId.threadTs and channelId/threadTs (legacy)
+//   - Slack permalinks, preferring the thread_ts query parameter over the
+//     message's own p-timestamp so a reply's link resolves to its thread
+func parseThreadRef(s string) (ThreadRef, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ThreadRef{}, fmt.Errorf("empty thread ID")
+	}
+
+	if strings.Contains(s, "/archives/") {
+		return parsePermalinkRef(s)
+	}
+
+	// channelId:ts, channelId/ts
+	for _, sep := range []string{":", "/"} {
+		if i := strings.Index(s, sep); i > 0 {
+			ref := ThreadRef{ChannelID: s[:i], ThreadTs: s[i+1:]}
+			if isChannelID(ref.ChannelID) && slackTsPattern.MatchString(ref.ThreadTs) {
+				return ref, nil
+			}
+		}
+	}
+
+	// channelId.ts: the timestamp carries its own dot, so split on the first
+	if i := strings.Index(s, "."); i > 0 {
+		ref := ThreadRef{ChannelID: s[:i], ThreadTs: s[i+1:]}
+		if isChannelID(ref.ChannelID) && slackTsPattern.MatchString(ref.ThreadTs) {
+			return ref, nil
+		}
+	}
+
+	return ThreadRef{}, fmt.Errorf("invalid thread ID %q: expected channelId:threadTs or a Slack permalink", s)
+}
+
+func parsePermalinkRef(link string) (ThreadRef, error) {
+	m := permalinkPathPattern.FindStringSubmatch(link)
+	if m == nil {
+		return ThreadRef{}, fmt.Errorf("invalid permalink %q", link)
+	}
+	p := m[2]
+	ref := ThreadRef{ChannelID: m[1], ThreadTs: p[:len(p)-6] + "." + p[len(p)-6:]}
+
+	if u, err := url.Parse(link); err == nil {
+		if ts := u.Query().Get("thread_ts"); slackTsPattern.MatchString(ts) {
+			ref.ThreadTs = ts
+		}
+		if cid := u.Query().Get("cid"); isChannelID(cid) {
+			ref.ChannelID = cid
+		}
+	}
+	return ref, nil
+}
@@ -0,0 +1,39 @@
+package features
+
+import "testing"
+
+func TestParseThreadRef(t *testing.T) {
+	want := ThreadRef{ChannelID: "C024BE91L", ThreadTs: "1712345678.123456"}
+	inputs := []string{
+		"C024BE91L:1712345678.123456",
+		"C024BE91L.1712345678.123456",
+		"C024BE91L/1712345678.123456",
+		" C024BE91L:1712345678.123456 ",
+		"https://acme.slack.com/archives/C024BE91L/p1712345678123456",
+		// A reply's permalink resolves to its parent
+		"https://acme.slack.com/archives/C024BE91L/p1712349999000001?thread_ts=1712345678.123456&cid=C024BE91L",
+	}
+	for _, in := range inputs {
+		got, err := parseThreadRef(in)
+		if err != nil {
+			t.Errorf("parseThreadRef(%q) error: %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("parseThreadRef(%q) = %+v, want %+v", in, got, want)
+		}
+	}
+
+	for _, in := range []string{"", "1712345678.123456", "general:1712345678.123456", "C024BE91L:abc", "https://acme.slack.com/archives/C024BE91L"} {
+		if _, err := parseThreadRef(in); err == nil {
+			t.Errorf("parseThreadRef(%q) succeeded, want error", in)
+		}
+	}
+
+	if s := want.String(); s != "C024BE91L:1712345678.123456" {
+		t.Errorf("String() = %q", s)
+	}
+	if got := threadRefFor("C1", "2.0", "1.0"); got.ThreadTs != "1.0" {
+		t.Errorf("reply should reference its parent, got %+v", got)
+	}
+}
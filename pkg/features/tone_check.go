@@ -0,0 +1,112 @@
+package features
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Tone check is a cheap safety net for agent-composed messages. It is off by
+// default; SLACK_MCP_TONE_CHECK=on enables it for every send, a call's
+// toneCheck parameter turns it on or off for that send, and
+// SLACK_MCP_TONE_WORDS adds comma-separated words to the built-in profanity
+// list.
+
+var defaultToneWords = []string{
+	"fuck", "fucking", "shit", "bullshit", "damn", "crap", "asshole",
+	"idiot", "idiotic", "stupid", "moron", "dumb", "pathetic", "useless",
+}
+
+var aggressivePhrases = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\bwhat the hell\b`),
+	regexp.MustCompile(`(?i)\bare you (serious|kidding)\b`),
+	regexp.MustCompile(`(?i)\bhow many times\b`),
+	regexp.MustCompile(`(?i)\bi already (told|said|explained)\b`),
+	regexp.MustCompile(`(?i)\bas i (said|mentioned) before\b`),
+	regexp.MustCompile(`(?i)\bper my last (message|email)\b`),
+	regexp.MustCompile(`(?i)\bobviously\b`),
+	regexp.MustCompile(`(?i)\bshut up\b`),
+	regexp.MustCompile(`[!?]{3,}`),
+}
+
+var wordPattern = regexp.MustCompile(`[\p{L}']+`)
+
+// toneCheckEnabled reports whether a send's tone is checked: its toneCheck
+// parameter when given, else SLACK_MCP_TONE_CHECK
+func toneCheckEnabled(params map[string]interface{}) bool {
+	if on, ok := params["toneCheck"].(bool); ok {
+		return on
+	}
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("SLACK_MCP_TONE_CHECK"))) {
+	case "on", "true", "1", "yes":
+		return true
+	}
+	return false
+}
+
+func toneWords() map[string]bool {
+	words := make(map[string]bool, len(defaultToneWords))
+	for _, w := range defaultToneWords {
+		words[w] = true
+	}
+	for _, w := range strings.Split(os.Getenv("SLACK_MCP_TONE_WORDS"), ",") {
+		if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+			words[w] = true
+		}
+	}
+	return words
+}
+
+// checkTone returns human-readable warnings about a message's tone.
+// An empty result means nothing looked off.
+func checkTone(message string) []string {
+	var warnings []string
+
+	// Mentions, links and channel references aren't the author's words
+	message = slackTokenRegexp.ReplaceAllString(message, "")
+
+	if shoutingRatio(message) > 0.7 {
+		warnings = append(warnings, "Message is mostly ALL CAPS and may read as shouting")
+	}
+
+	profane := toneWords()
+	var found []string
+	seen := make(map[string]bool)
+	for _, w := range wordPattern.FindAllString(strings.ToLower(message), -1) {
+		if profane[w] && !seen[w] {
+			seen[w] = true
+			found = append(found, w)
+		}
+	}
+	if len(found) > 0 {
+		warnings = append(warnings, fmt.Sprintf("Contains words that may come across as rude: %s", strings.Join(found, ", ")))
+	}
+
+	for _, re := range aggressivePhrases {
+		if m := re.FindString(message); m != "" {
+			warnings = append(warnings, fmt.Sprintf("Phrasing may sound aggressive: %q", m))
+		}
+	}
+
+	return warnings
+}
+
+// shoutingRatio is the share of uppercase letters among all letters,
+// ignoring short messages where caps are usually acronyms
+func shoutingRatio(message string) float64 {
+	letters, upper := 0, 0
+	for _, r := range message {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	if letters < 12 {
+		return 0
+	}
+	return float64(upper) / float64(letters)
+}
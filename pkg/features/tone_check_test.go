@@ -0,0 +1,49 @@
+package features
+
+import "testing"
+
+func TestCheckTone(t *testing.T) {
+	t.Setenv("SLACK_MCP_TONE_WORDS", "frobnicate")
+
+	clean := []string{
+		"Thanks, I'll take a look at the PR this afternoon.",
+		"LGTM, ship it",
+		"Can we sync on the API design tomorrow?",
+	}
+	for _, msg := range clean {
+		if w := checkTone(msg); len(w) != 0 {
+			t.Errorf("checkTone(%q) = %v, want no warnings", msg, w)
+		}
+	}
+
+	flagged := []string{
+		"THIS IS COMPLETELY BROKEN AGAIN",
+		"That's a stupid idea",
+		"How many times do I need to say this",
+		"Why is this failing???",
+		"Please don't frobnicate the config",
+	}
+	for _, msg := range flagged {
+		if w := checkTone(msg); len(w) == 0 {
+			t.Errorf("checkTone(%q) returned no warnings", msg)
+		}
+	}
+}
+
+func TestToneCheckIsOptIn(t *testing.T) {
+	t.Setenv("SLACK_MCP_TONE_CHECK", "")
+	if toneCheckEnabled(map[string]interface{}{}) {
+		t.Error("tone check should be off by default")
+	}
+	if !toneCheckEnabled(map[string]interface{}{"toneCheck": true}) {
+		t.Error("toneCheck=true should enable it")
+	}
+
+	t.Setenv("SLACK_MCP_TONE_CHECK", "on")
+	if !toneCheckEnabled(map[string]interface{}{}) {
+		t.Error("SLACK_MCP_TONE_CHECK=on should enable it")
+	}
+	if toneCheckEnabled(map[string]interface{}{"toneCheck": false}) {
+		t.Error("toneCheck=false should override the environment")
+	}
+}
@@ -0,0 +1,318 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// TopicTimeline reconstructs how a topic evolved: search hits across
+// channels plus the threads they sit in, merged into one chronological list
+var TopicTimeline = &Feature{
+	Name:        "topic-timeline",
+	Description: "Build a chronological timeline of a topic across channels, merging search hits with the threads they belong to, to see how a discussion or decision evolved",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": "The topic to trace (e.g., 'pricing change', 'postgres migration')",
+			},
+			"in": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Limit to these channels (optional)",
+			},
+			"timeframe": map[string]interface{}{
+				"type":        "string",
+				"description": "How far back to look (e.g., '2w', '3m')",
+				"default":     "3m",
+			},
+			"filters": searchFiltersSchema,
+			"includeThreads": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Pull in the full threads that search hits belong to",
+				"default":     true,
+			},
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": "Maximum timeline entries (default: 100, max: 300)",
+				"default":     100,
+			},
+		},
+		"required": []string{"query"},
+	},
+	Handler: topicTimelineHandler,
+}
+
+const (
+	// timelineSearchPages bounds search pages fetched per timeline
+	timelineSearchPages = 3
+	// timelineMaxThreads caps replies fetches per timeline
+	timelineMaxThreads = 10
+)
+
+// timelineEntry is one message on a topic timeline
+type timelineEntry struct {
+	ChannelID string
+	Channel   string
+	Ts        string
+	ThreadTs  string
+	User      string
+	Text      string
+	Source    string // "search" or "thread"
+	Permalink string
+}
+
+func topicTimelineHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	query, _ := params["query"].(string)
+	if strings.TrimSpace(query) == "" {
+		return &FeatureResult{
+			Success: false,
+			Message: "query is required",
+		}, nil
+	}
+	timeframe := "3m"
+	if t, ok := params["timeframe"].(string); ok && t != "" {
+		timeframe = t
+	}
+	includeThreads := true
+	if it, ok := params["includeThreads"].(bool); ok {
+		includeThreads = it
+	}
+	limit := 100
+	if l, ok := params["limit"].(float64); ok && l > 0 {
+		limit = int(l)
+		if limit > 300 {
+			limit = 300
+		}
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	api, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}, nil
+	}
+
+	filters, err := parseSearchFilters(params["filters"])
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+	filters.In = append(filters.In, stringList(params["in"])...)
+	filters = resolveSearchFilters(ctx, filters, apiProvider)
+	q := buildSearchQuery(query, filters)
+	if !filters.hasDate() {
+		q += " " + parseTimeframeToDateFilter(timeframe)
+	}
+
+	var entries []timelineEntry
+	threadHits := map[ThreadRef]int{}
+	hits := 0
+	for page := 1; page <= timelineSearchPages; page++ {
+		sp := slack.NewSearchParameters()
+		sp.Sort = "timestamp"
+		sp.SortDirection = "desc"
+		sp.Count = 100
+		sp.Page = page
+		res, err := api.SearchMessagesContext(ctx, q, sp)
+		if err != nil {
+			if page == 1 {
+				return &FeatureResult{
+					Success: false,
+					Message: fmt.Sprintf("Search failed: %v", err),
+				}, nil
+			}
+			break
+		}
+		for _, m := range res.Matches {
+			hits++
+			ref := ThreadRef{ChannelID: m.Channel.ID, ThreadTs: m.Timestamp}
+			if r, err := parsePermalinkRef(m.Permalink); err == nil && r.ChannelID == m.Channel.ID {
+				ref = r
+			}
+			threadHits[ref]++
+			threadTs := ""
+			if ref.ThreadTs != m.Timestamp {
+				threadTs = ref.ThreadTs
+			}
+			entries = append(entries, timelineEntry{
+				ChannelID: m.Channel.ID,
+				Channel:   m.Channel.Name,
+				Ts:        m.Timestamp,
+				ThreadTs:  threadTs,
+				User:      m.User,
+				Text:      m.Text,
+				Source:    "search",
+				Permalink: m.Permalink,
+			})
+		}
+		if res.Paging.Page >= res.Paging.Pages {
+			break
+		}
+	}
+
+	// Expand the threads with the most hits; a hit that isn't in a thread
+	// comes back as a lone message and adds nothing
+	expanded := 0
+	if includeThreads {
+		refs := make([]ThreadRef, 0, len(threadHits))
+		for ref := range threadHits {
+			refs = append(refs, ref)
+		}
+		sort.Slice(refs, func(i, j int) bool {
+			if threadHits[refs[i]] != threadHits[refs[j]] {
+				return threadHits[refs[i]] > threadHits[refs[j]]
+			}
+			return refs[i].ThreadTs > refs[j].ThreadTs
+		})
+		for _, ref := range refs {
+			if expanded >= timelineMaxThreads {
+				break
+			}
+			replies, _, _, err := api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+				ChannelID: ref.ChannelID,
+				Timestamp: ref.ThreadTs,
+				Limit:     200,
+			})
+			if err != nil {
+				log.Printf("Timeline thread %s failed: %v", ref, err)
+				continue
+			}
+			if len(replies) < 2 {
+				continue
+			}
+			expanded++
+			for _, msg := range replies {
+				threadTs := ""
+				if msg.Timestamp != ref.ThreadTs {
+					threadTs = ref.ThreadTs
+				}
+				entries = append(entries, timelineEntry{
+					ChannelID: ref.ChannelID,
+					Ts:        msg.Timestamp,
+					ThreadTs:  threadTs,
+					User:      msg.User,
+					Text:      messageBody(msg.Text, msg.Blocks, msg.Attachments),
+					Source:    "thread",
+				})
+			}
+		}
+	}
+
+	entries = mergeTimeline(entries)
+	truncated := len(entries) > limit
+	if truncated {
+		// Keep the latest entries: the end of a discussion matters most
+		entries = entries[len(entries)-limit:]
+	}
+
+	usersMap := apiProvider.ProvideUsersMap()
+	channelNames := map[string]string{}
+	timeline := make([]map[string]interface{}, 0, len(entries))
+	channelsSeen := map[string]bool{}
+	decisions := 0
+	for _, e := range entries {
+		name := e.Channel
+		if name == "" || isChannelID(name) {
+			if n, ok := channelNames[e.ChannelID]; ok {
+				name = n
+			} else {
+				name = apiProvider.ResolveChannelName(ctx, e.ChannelID)
+				channelNames[e.ChannelID] = name
+			}
+		}
+		channelsSeen[name] = true
+		t := parseSlackTimestamp(e.Ts).Local()
+		item := map[string]interface{}{
+			"date":      t.Format("Mon Jan 2, 2006"),
+			"time":      t.Format("15:04"),
+			"timestamp": formatTimestamp(t),
+			"channel":   name,
+			"author":    userDisplayName(e.User, usersMap),
+			"text":      readableText(e.Text, usersMap),
+			"source":    e.Source,
+			"threadId":  threadRefFor(e.ChannelID, e.Ts, e.ThreadTs).String(),
+			"messageTs": e.Ts,
+		}
+		if e.ThreadTs != "" {
+			item["inThread"] = true
+		}
+		if e.Permalink != "" {
+			item["permalink"] = e.Permalink
+		}
+		if decisionPattern.MatchString(e.Text) {
+			item["decision"] = true
+			decisions++
+		}
+		timeline = append(timeline, item)
+	}
+
+	summary := map[string]interface{}{
+		"query":           q,
+		"searchHits":      hits,
+		"threadsExpanded": expanded,
+		"channels":        len(channelsSeen),
+		"decisions":       decisions,
+	}
+	if len(entries) > 0 {
+		summary["first"] = formatTimestamp(parseSlackTimestamp(entries[0].Ts))
+		summary["last"] = formatTimestamp(parseSlackTimestamp(entries[len(entries)-1].Ts))
+	}
+
+	result := &FeatureResult{
+		Success: true,
+		Data: map[string]interface{}{
+			"timeline": timeline,
+			"summary":  summary,
+		},
+		Message:     fmt.Sprintf("%d messages about '%s' across %d channel(s)", len(timeline), query, len(channelsSeen)),
+		ResultCount: len(timeline),
+	}
+	switch {
+	case len(timeline) == 0:
+		result.Guidance = "Nothing found. Try broader terms or a longer timeframe."
+	case truncated:
+		result.Guidance = fmt.Sprintf("Showing the latest %d entries; narrow the timeframe or add filters to see the start of the story.", limit)
+	case decisions > 0:
+		result.Guidance = fmt.Sprintf("%d entries read like decisions (marked ✅); log one with log-decision.", decisions)
+	}
+	return result, nil
+}
+
+// mergeTimeline drops duplicate messages, keeping the search hit (it has a
+// permalink), and orders the rest oldest first
+func mergeTimeline(entries []timelineEntry) []timelineEntry {
+	byKey := map[string]int{}
+	out := make([]timelineEntry, 0, len(entries))
+	for _, e := range entries {
+		key := e.ChannelID + ":" + e.Ts
+		if i, ok := byKey[key]; ok {
+			if out[i].Source != "search" && e.Source == "search" {
+				out[i] = e
+			}
+			continue
+		}
+		byKey[key] = len(out)
+		out = append(out, e)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Ts < out[j].Ts })
+	return out
+}
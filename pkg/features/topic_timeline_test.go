@@ -0,0 +1,29 @@
+package features
+
+import "testing"
+
+func TestMergeTimeline(t *testing.T) {
+	entries := []timelineEntry{
+		{ChannelID: "C1", Ts: "1700000300.000000", Source: "search", Permalink: "https://x/p3"},
+		{ChannelID: "C1", Ts: "1700000100.000000", Source: "thread"},
+		{ChannelID: "C1", Ts: "1700000300.000000", Source: "thread"},
+		{ChannelID: "C2", Ts: "1700000100.000000", Source: "thread"},
+		{ChannelID: "C1", Ts: "1700000200.000000", Source: "thread"},
+		{ChannelID: "C1", Ts: "1700000200.000000", Source: "search", Permalink: "https://x/p2"},
+	}
+
+	got := mergeTimeline(entries)
+	if len(got) != 4 {
+		t.Fatalf("got %d entries, want 4: %+v", len(got), got)
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i].Ts < got[i-1].Ts {
+			t.Fatalf("not chronological: %+v", got)
+		}
+	}
+	for _, e := range got {
+		if e.ChannelID == "C1" && e.Ts != "1700000100.000000" && (e.Source != "search" || e.Permalink == "") {
+			t.Errorf("duplicate should keep the search hit: %+v", e)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+// UsageStats summarizes what the agent has done in Slack through this server
+var UsageStats = &Feature{
+	Name:        "usage-stats",
+	Description: "Audit the agent's own Slack activity through this server: messages sent, channels caught up on, conversations marked read, and API calls consumed today",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"days": map[string]interface{}{
+				"type":        "number",
+				"description": "Number of days of history to include, newest first (default: 1 = today only, max: 30)",
+				"default":     1,
+			},
+		},
+	},
+	Handler: usageStatsHandler,
+}
+
+func usageStatsHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	days := 1
+	if d, ok := params["days"].(float64); ok {
+		days = int(d)
+		if days < 1 {
+			days = 1
+		}
+		if days > 30 {
+			days = 30
+		}
+	}
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	history := apiProvider.UsageHistory(days)
+	if len(history) == 0 {
+		return &FeatureResult{
+			Success: false,
+			Message: "Usage tracking is not available",
+		}, nil
+	}
+
+	dayEntries := make([]map[string]interface{}, 0, len(history))
+	for _, d := range history {
+		dayEntries = append(dayEntries, map[string]interface{}{
+			"date":             d.Date,
+			"apiCalls":         d.APICalls,
+			"internalApiCalls": d.InternalAPICalls,
+			"messagesSent":     d.Actions[provider.UsageMessagesSent],
+			"reactions":        d.Actions[provider.UsageReactions],
+			"channelsCaughtUp": d.Actions[provider.UsageChannelsCaughtUp],
+			"markedRead":       d.Actions[provider.UsageMarkedRead],
+			"toolCalls":        sumCounts(d.ToolCalls),
+		})
+	}
+
+	today := history[0]
+	result := &FeatureResult{
+		Success: true,
+		Data: map[string]interface{}{
+			"today":      dayEntries[0],
+			"days":       dayEntries,
+			"topMethods": topCounts(today.Methods, 10),
+			"byTool":     topCounts(today.ToolCalls, 0),
+		},
+		Message: fmt.Sprintf("Today: %d messages sent, %d channels caught up, %d conversations marked read, %d API calls",
+			today.Actions[provider.UsageMessagesSent],
+			today.Actions[provider.UsageChannelsCaughtUp],
+			today.Actions[provider.UsageMarkedRead],
+			today.APICalls+today.InternalAPICalls),
+		ResultCount: len(dayEntries),
+		NextActions: []string{
+			"See a longer history: usage-stats days=7",
+		},
+	}
+
+	if today.Actions[provider.UsageMessagesSent] > 20 {
+		result.Guidance = "⚠️ The agent has posted a lot of messages today. Review what was sent before continuing."
+	} else {
+		result.Guidance = "Counts cover activity through this server only, not your own Slack usage."
+	}
+
+	return result, nil
+}
+
+func sumCounts(m map[string]int) int {
+	total := 0
+	for _, v := range m {
+		total += v
+	}
+	return total
+}
+
+// topCounts returns name/count pairs sorted by count desc. A max of 0 means no limit.
+func topCounts(m map[string]int, max int) []map[string]interface{} {
+	names := make([]string, 0, len(m))
+	for k := range m {
+		names = append(names, k)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if m[names[i]] != m[names[j]] {
+			return m[names[i]] > m[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if max > 0 && len(names) > max {
+		names = names[:max]
+	}
+
+	out := make([]map[string]interface{}, 0, len(names))
+	for _, n := range names {
+		out = append(out, map[string]interface{}{
+			"name":  n,
+			"count": m[n],
+		})
+	}
+	return out
+}
@@ -0,0 +1,449 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/paths"
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// ExportToVault writes collections, threads, and digests as Markdown notes
+// with YAML frontmatter, the layout Obsidian and Logseq vaults expect
+var ExportToVault = &Feature{
+	Name:        "export-to-vault",
+	Description: "Write a collection, a thread, a team report, or a channel brief as a Markdown note with frontmatter (channel, participants, permalink, date) into an Obsidian/Logseq vault folder",
+	Schema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"what": map[string]interface{}{
+				"type":        "string",
+				"description": "What to export",
+				"enum":        []string{"collection", "thread", "team-report", "channel-brief"},
+			},
+			"collection": map[string]interface{}{
+				"type":        "string",
+				"description": "Collection name (what='collection')",
+			},
+			"threadId": map[string]interface{}{
+				"type":        "string",
+				"description": "Thread to export (what='thread'): channelId:threadTs or a permalink",
+			},
+			"channel": map[string]interface{}{
+				"type":        "string",
+				"description": "Channel to brief (what='channel-brief')",
+			},
+			"channels": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Channels to report on (what='team-report'; default: SLACK_MCP_TEAM_CHANNELS)",
+			},
+			"period": map[string]interface{}{
+				"type":        "string",
+				"description": "Report period (what='team-report', default: 7d)",
+			},
+			"vault": map[string]interface{}{
+				"type":        "string",
+				"description": "Vault directory (default: SLACK_MCP_VAULT_DIR, or ~/Downloads/slack-vault)",
+			},
+			"folder": map[string]interface{}{
+				"type":        "string",
+				"description": "Subfolder inside the vault (default: 'Slack')",
+				"default":     "Slack",
+			},
+			"overwrite": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Replace an existing note with the same name",
+				"default":     false,
+			},
+		},
+		"required": []string{"what"},
+	},
+	Handler: exportToVaultHandler,
+}
+
+// vaultNote is a rendered note: frontmatter fields in order, then the body
+type vaultNote struct {
+	Title  string
+	Fields []vaultField
+	Body   string
+}
+
+type vaultField struct {
+	Key   string
+	Value interface{} // string, int, or []string
+}
+
+// vaultDir returns the configured vault, falling back to a folder in
+// Downloads
+func vaultDir() string {
+	if dir := strings.TrimSpace(os.Getenv("SLACK_MCP_VAULT_DIR")); dir != "" {
+		return dir
+	}
+	return filepath.Join(paths.DownloadsDir(), "slack-vault")
+}
+
+func exportToVaultHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	what, _ := params["what"].(string)
+	vault, _ := params["vault"].(string)
+	if strings.TrimSpace(vault) == "" {
+		vault = vaultDir()
+	}
+	folder := "Slack"
+	if f, ok := params["folder"].(string); ok {
+		folder = strings.TrimSpace(f)
+	}
+	overwrite, _ := params["overwrite"].(bool)
+
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	var (
+		note *vaultNote
+		fail *FeatureResult
+	)
+	switch what {
+	case "collection":
+		note, fail = collectionNote(apiProvider, params)
+	case "thread":
+		note, fail = threadNote(ctx, apiProvider, params)
+	case "team-report", "channel-brief":
+		note, fail = digestNote(ctx, what, params)
+	default:
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Unknown what '%s'; use 'collection', 'thread', 'team-report', or 'channel-brief'", what),
+		}, nil
+	}
+	if fail != nil {
+		return fail, nil
+	}
+
+	dir, err := filepath.Abs(filepath.Join(vault, folder))
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Invalid vault: %v", err),
+		}, nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to create %s: %v", dir, err),
+		}, nil
+	}
+	path := filepath.Join(dir, vaultFileName(note.Title)+".md")
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if overwrite {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	out, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		result := &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to write %s: %v", path, err),
+		}
+		if os.IsExist(err) {
+			result.Guidance = "A note with this name exists; pass overwrite=true to replace it"
+		}
+		return result, nil
+	}
+	if _, err := out.WriteString(note.render()); err != nil {
+		out.Close()
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to write %s: %v", path, err),
+		}, nil
+	}
+	if err := out.Close(); err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to write %s: %v", path, err),
+		}, nil
+	}
+
+	return &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Wrote %s", path),
+		Data: map[string]interface{}{
+			"path":  path,
+			"title": note.Title,
+			"what":  what,
+		},
+	}, nil
+}
+
+// collectionNote renders a local collection, one section per message
+func collectionNote(p *provider.ApiProvider, params map[string]interface{}) (*vaultNote, *FeatureResult) {
+	name, _ := params["collection"].(string)
+	name = collectionName(name)
+	if name == "" {
+		return nil, &FeatureResult{
+			Success: false,
+			Message: "collection is required when what='collection'",
+		}
+	}
+	collectionsMu.Lock()
+	items, ok := loadCollections(p)[name]
+	collectionsMu.Unlock()
+	if !ok {
+		return nil, &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("No collection named '%s'", name),
+			Guidance: "Call list-collection to see your collections",
+		}
+	}
+
+	var b strings.Builder
+	var authors, channels []string
+	seen := map[string]bool{}
+	for _, it := range items {
+		when := parseSlackTimestamp(it.Ts).Local()
+		heading := fmt.Sprintf("%s in #%s, %s", it.Author, it.Channel, when.Format("2006-01-02 15:04"))
+		b.WriteString("## " + heading + "\n\n")
+		b.WriteString(vaultQuote(it.Text) + "\n\n")
+		if it.Note != "" {
+			b.WriteString("Note: " + it.Note + "\n\n")
+		}
+		if it.Permalink != "" {
+			b.WriteString(fmt.Sprintf("[Open in Slack](%s)\n\n", it.Permalink))
+		}
+		if !seen["u:"+it.Author] {
+			seen["u:"+it.Author] = true
+			authors = append(authors, it.Author)
+		}
+		if !seen["c:"+it.Channel] {
+			seen["c:"+it.Channel] = true
+			channels = append(channels, it.Channel)
+		}
+	}
+
+	return &vaultNote{
+		Title: "Collection - " + name,
+		Fields: []vaultField{
+			{"type", "slack-collection"},
+			{"collection", name},
+			{"channels", channels},
+			{"participants", authors},
+			{"count", len(items)},
+			{"date", time.Now().Format("2006-01-02")},
+			{"tags", []string{"slack", "slack/collection"}},
+		},
+		Body: b.String(),
+	}, nil
+}
+
+// threadNote renders a full thread as a transcript
+func threadNote(ctx context.Context, p *provider.ApiProvider, params map[string]interface{}) (*vaultNote, *FeatureResult) {
+	threadID, _ := params["threadId"].(string)
+	ref, err := parseThreadRef(threadID)
+	if err != nil {
+		return nil, &FeatureResult{
+			Success: false,
+			Message: err.Error(),
+		}
+	}
+	api, err := p.Provide()
+	if err != nil {
+		return nil, &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to connect to Slack: %v", err),
+		}
+	}
+
+	var msgs []slack.Message
+	cursor := ""
+	for page := 0; page < 5; page++ {
+		replies, hasMore, next, err := api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+			ChannelID: ref.ChannelID,
+			Timestamp: ref.ThreadTs,
+			Limit:     200,
+			Cursor:    cursor,
+		})
+		if err != nil {
+			return nil, &FeatureResult{
+				Success: false,
+				Message: fmt.Sprintf("Could not load thread %s: %v", ref, err),
+			}
+		}
+		msgs = append(msgs, replies...)
+		if !hasMore || next == "" {
+			break
+		}
+		cursor = next
+	}
+	if len(msgs) == 0 {
+		return nil, &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Thread %s is empty", ref),
+		}
+	}
+
+	usersMap := p.ProvideUsersMap()
+	channel := p.ResolveChannelName(ctx, ref.ChannelID)
+	root := msgs[0]
+	started := parseSlackTimestamp(root.Timestamp).Local()
+	rootText := readableText(messageBody(root.Text, root.Blocks, root.Attachments), usersMap)
+
+	var b strings.Builder
+	day := ""
+	for _, msg := range msgs {
+		t := parseSlackTimestamp(msg.Timestamp).Local()
+		if d := t.Format("2006-01-02"); d != day {
+			day = d
+			b.WriteString("### " + d + "\n\n")
+		}
+		b.WriteString(fmt.Sprintf("**%s** %s\n", userDisplayName(msg.User, usersMap), t.Format("15:04")))
+		b.WriteString(readableText(messageBody(msg.Text, msg.Blocks, msg.Attachments), usersMap) + "\n\n")
+	}
+
+	fields := []vaultField{
+		{"type", "slack-thread"},
+		{"channel", channel},
+		{"participants", getUniqueParticipants(msgs, usersMap)},
+	}
+	if link, err := p.Permalink(ctx, ref.ChannelID, ref.ThreadTs); err == nil {
+		fields = append(fields, vaultField{"permalink", link})
+	}
+	fields = append(fields,
+		vaultField{"date", started.Format("2006-01-02")},
+		vaultField{"replies", len(msgs) - 1},
+		vaultField{"threadId", ref.String()},
+		vaultField{"tags", []string{"slack", "slack/thread"}},
+	)
+
+	return &vaultNote{
+		Title:  fmt.Sprintf("%s %s - %s", started.Format("2006-01-02"), channel, truncate(rootText, 60)),
+		Fields: fields,
+		Body:   b.String(),
+	}, nil
+}
+
+// digestNote runs the team report or channel brief and files its Markdown
+func digestNote(ctx context.Context, what string, params map[string]interface{}) (*vaultNote, *FeatureResult) {
+	sub := map[string]interface{}{"_provider": params["_provider"]}
+	for _, k := range []string{"channel", "channels", "period"} {
+		if v, ok := params[k]; ok {
+			sub[k] = v
+		}
+	}
+
+	tool, handler, render := "generate-team-report", generateTeamReportHandler, renderTeamReport
+	if what == "channel-brief" {
+		tool, handler, render = "brief-me-on-channel", briefMeOnChannelHandler, renderChannelBrief
+	}
+	result, err := handler(ctx, sub)
+	if err != nil || !result.Success {
+		if result == nil {
+			result = &FeatureResult{Success: false, Message: err.Error()}
+		}
+		return nil, result
+	}
+	// Notes leave the server too, so they get the same redaction as output
+	data, _ := RedactResult(tool, result).Data.(map[string]interface{})
+	body := render(data)
+	// The note title replaces the digest's own top-level heading
+	if i := strings.Index(body, "\n"); strings.HasPrefix(body, "# ") && i > 0 {
+		body = strings.TrimLeft(body[i+1:], "\n")
+	}
+
+	today := time.Now().Format("2006-01-02")
+	if what == "channel-brief" {
+		channel := str(data, "channel")
+		return &vaultNote{
+			Title: fmt.Sprintf("%s %s - brief", today, channel),
+			Fields: []vaultField{
+				{"type", "slack-channel-brief"},
+				{"channel", channel},
+				{"participants", namesOf(data["contributors"])},
+				{"date", today},
+				{"tags", []string{"slack", "slack/brief"}},
+			},
+			Body: body,
+		}, nil
+	}
+	return &vaultNote{
+		Title: fmt.Sprintf("%s %s", today, str(data, "title")),
+		Fields: []vaultField{
+			{"type", "slack-team-report"},
+			{"channels", stringList(data["channels"])},
+			{"participants", namesOf(data["members"])},
+			{"date", today},
+			{"since", str(data, "since")},
+			{"tags", []string{"slack", "slack/report"}},
+		},
+		Body: body,
+	}, nil
+}
+
+func namesOf(v interface{}) []string {
+	var names []string
+	for _, m := range asList(v) {
+		names = append(names, str(m, "name"))
+	}
+	return names
+}
+
+// render writes the note as YAML frontmatter followed by the body
+func (n *vaultNote) render() string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	b.WriteString("title: " + strconv.Quote(n.Title) + "\n")
+	for _, f := range n.Fields {
+		switch v := f.Value.(type) {
+		case []string:
+			if len(v) == 0 {
+				b.WriteString(f.Key + ": []\n")
+				continue
+			}
+			b.WriteString(f.Key + ":\n")
+			for _, s := range v {
+				b.WriteString("  - " + strconv.Quote(s) + "\n")
+			}
+		case int:
+			b.WriteString(fmt.Sprintf("%s: %d\n", f.Key, v))
+		default:
+			b.WriteString(f.Key + ": " + strconv.Quote(fmt.Sprint(v)) + "\n")
+		}
+	}
+	b.WriteString("---\n\n")
+	b.WriteString("# " + n.Title + "\n\n")
+	b.WriteString(strings.TrimRight(n.Body, "\n") + "\n")
+	return b.String()
+}
+
+var vaultUnsafe = regexp.MustCompile(`[\\/:*?"<>|#^\[\]\x00-\x1f]+`)
+
+// vaultFileName makes a title safe as a note file name on every platform
+// and as an Obsidian link target
+func vaultFileName(title string) string {
+	name := strings.Join(strings.Fields(vaultUnsafe.ReplaceAllString(title, " ")), " ")
+	name = strings.Trim(name, ". ")
+	if r := []rune(name); len(r) > 120 {
+		name = strings.TrimSpace(string(r[:120]))
+	}
+	if name == "" {
+		name = "slack-" + time.Now().Format("20060102-150405")
+	}
+	return name
+}
+
+func vaultQuote(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i, l := range lines {
+		lines[i] = "> " + l
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,46 @@
+package features
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVaultNoteRender(t *testing.T) {
+	n := &vaultNote{
+		Title: `2026-03-04 eng - "Q2" plan`,
+		Fields: []vaultField{
+			{"channel", "eng"},
+			{"participants", []string{"Alice", "Bob"}},
+			{"replies", 3},
+			{"tags", []string{}},
+		},
+		Body: "body text\n\n",
+	}
+	want := "---\n" +
+		`title: "2026-03-04 eng - \"Q2\" plan"` + "\n" +
+		`channel: "eng"` + "\n" +
+		"participants:\n  - \"Alice\"\n  - \"Bob\"\n" +
+		"replies: 3\n" +
+		"tags: []\n" +
+		"---\n\n" +
+		`# 2026-03-04 eng - "Q2" plan` + "\n\nbody text\n"
+	if got := n.render(); got != want {
+		t.Errorf("render =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestVaultFileName(t *testing.T) {
+	cases := map[string]string{
+		"2026-03-04 eng - Plan: v2/v3?": "2026-03-04 eng - Plan v2 v3",
+		"#eng [draft] ...":              "eng draft",
+		"../../etc":                     "etc",
+	}
+	for in, want := range cases {
+		if got := vaultFileName(in); got != want {
+			t.Errorf("vaultFileName(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := vaultFileName(strings.Repeat("x", 200)); len(got) != 120 {
+		t.Errorf("long name not capped: %d", len(got))
+	}
+}
@@ -113,7 +113,7 @@ func (wm *WorkflowManager) GetNextActions(toolName string, result *FeatureResult
 						break
 					}
 					if threadId, ok := disc["threadId"].(string); ok && threadId != "" {
-						suggest(fmt.Sprintf("search threadId='%s'", threadId))
+						suggest(fmt.Sprintf("read-thread threadId='%s'", threadId))
 					}
 				}
 
@@ -146,7 +146,7 @@ func (wm *WorkflowManager) GetNextActions(toolName string, result *FeatureResult
 
 				// If it's a thread
 				if mention := mentions[0]; mention["threadId"] != nil {
-					suggest(fmt.Sprintf("search threadId='%s'", mention["threadId"]))
+					suggest(fmt.Sprintf("read-thread threadId='%s'", mention["threadId"]))
 				}
 			}
 		}
@@ -0,0 +1,218 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+// WorkspaceOverview gives an agent basic context about the workspace at the
+// start of a session: who's in it, how many channels, and how busy the
+// user's own conversations are
+var WorkspaceOverview = &Feature{
+	Name:        "workspace-overview",
+	Description: "Workspace context for the start of a session: team name and domain, member counts (people, guests, bots), channel counts, and with a browser session token, how active your conversations have been in the last day and week",
+	Schema: map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	},
+	Handler: workspaceOverviewHandler,
+}
+
+// memberStats counts the workspace's users by kind
+type memberStats struct {
+	People      int
+	Guests      int
+	Bots        int
+	Deactivated int
+	Admins      int
+}
+
+func countMembers(users map[string]slack.User) memberStats {
+	var s memberStats
+	for _, u := range users {
+		switch {
+		case u.Deleted:
+			s.Deactivated++
+		case u.IsBot || u.IsAppUser || u.ID == "USLACKBOT":
+			s.Bots++
+		case u.IsRestricted || u.IsUltraRestricted:
+			s.Guests++
+		default:
+			s.People++
+			if u.IsAdmin || u.IsOwner {
+				s.Admins++
+			}
+		}
+	}
+	return s
+}
+
+// channelStats counts the cached channel directory
+type channelStats struct {
+	Public   int
+	Private  int
+	Archived int
+	Joined   int
+}
+
+func countChannels(channels []slack.Channel) channelStats {
+	var s channelStats
+	for _, ch := range channels {
+		switch {
+		case ch.IsIM || ch.IsMpIM:
+			continue
+		case ch.IsArchived:
+			s.Archived++
+			continue
+		case ch.IsPrivate:
+			s.Private++
+		default:
+			s.Public++
+		}
+		if ch.IsMember {
+			s.Joined++
+		}
+	}
+	return s
+}
+
+// activityStats summarizes the user's conversations from client.counts
+type activityStats struct {
+	Conversations int
+	ActiveDay     int
+	ActiveWeek    int
+	Unread        int
+	Mentions      int
+	ThreadUnreads int
+}
+
+func countActivity(counts *provider.ClientCountsResponse, now time.Time) activityStats {
+	var s activityStats
+	tally := func(latest string, unread bool, mentions int) {
+		s.Conversations++
+		if latest != "" {
+			t := parseSlackTimestamp(latest)
+			if now.Sub(t) < 24*time.Hour {
+				s.ActiveDay++
+			}
+			if now.Sub(t) < 7*24*time.Hour {
+				s.ActiveWeek++
+			}
+		}
+		if unread {
+			s.Unread++
+		}
+		s.Mentions += mentions
+	}
+	for _, ch := range counts.Channels {
+		tally(ch.Latest, ch.HasUnreads, ch.MentionCount)
+	}
+	for _, ch := range counts.MPIMs {
+		tally(ch.Latest, ch.HasUnreads, ch.MentionCount)
+	}
+	for _, ch := range counts.IMs {
+		tally(ch.Latest, ch.HasUnreads, ch.MentionCount)
+	}
+	s.ThreadUnreads = counts.Threads.UnreadCount
+	return s
+}
+
+func workspaceOverviewHandler(ctx context.Context, params map[string]interface{}) (*FeatureResult, error) {
+	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
+	if !ok {
+		return &FeatureResult{
+			Success: false,
+			Message: "Internal error: provider not available",
+		}, nil
+	}
+
+	client, err := apiProvider.Provide()
+	if err != nil {
+		return &FeatureResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to get Slack client: %v", err),
+		}, nil
+	}
+
+	data := map[string]interface{}{}
+	var notes []string
+
+	// team.info needs team:read on app tokens; fall back to what boot saw
+	teamName := ""
+	if team, err := client.GetTeamInfoContext(ctx); err == nil {
+		teamName = team.Name
+		data["team"] = map[string]interface{}{
+			"id":          team.ID,
+			"name":        team.Name,
+			"domain":      team.Domain,
+			"emailDomain": team.EmailDomain,
+		}
+	} else if self, selfErr := apiProvider.Self(); selfErr == nil {
+		teamName = self.Team
+		data["team"] = map[string]interface{}{"id": self.TeamID, "name": self.Team}
+		notes = append(notes, fmt.Sprintf("team.info unavailable (%v); showing the team from sign-in", err))
+	}
+
+	members := countMembers(apiProvider.ProvideUsersMap())
+	data["members"] = map[string]interface{}{
+		"people":      members.People,
+		"guests":      members.Guests,
+		"bots":        members.Bots,
+		"deactivated": members.Deactivated,
+		"admins":      members.Admins,
+	}
+
+	channels := countChannels(apiProvider.GetCachedChannels())
+	data["channels"] = map[string]interface{}{
+		"public":   channels.Public,
+		"private":  channels.Private,
+		"archived": channels.Archived,
+		"joined":   channels.Joined,
+	}
+	if info := apiProvider.GetCacheInfo(); !info.LastRefresh.IsZero() {
+		data["directoryRefreshed"] = formatTimestamp(info.LastRefresh)
+	}
+	notes = append(notes, "Private channels count only the ones you can see")
+
+	// Activity comes from client.counts, which only session tokens reach
+	if ic := apiProvider.ProvideInternalClient(); ic != nil {
+		if counts, err := ic.GetClientCounts(ctx); err == nil && counts.OK {
+			a := countActivity(counts, time.Now())
+			data["activity"] = map[string]interface{}{
+				"conversations": a.Conversations,
+				"activeDay":     a.ActiveDay,
+				"activeWeek":    a.ActiveWeek,
+				"unread":        a.Unread,
+				"mentions":      a.Mentions,
+				"threadUnreads": a.ThreadUnreads,
+			}
+		} else if err != nil {
+			notes = append(notes, fmt.Sprintf("Activity unavailable: %v", err))
+		}
+	} else {
+		notes = append(notes, "Activity needs a browser session (xoxc) token")
+	}
+	data["notes"] = notes
+
+	name := teamName
+	if name == "" {
+		name = "Workspace"
+	}
+	result := &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("%s: %d people, %d public channels", name, members.People, channels.Public),
+		Data:    data,
+		NextActions: []string{
+			"See what's unread: check-unreads",
+			"Find channels worth joining: discover-channels",
+		},
+	}
+	if _, ok := data["activity"]; ok {
+		result.NextActions = append([]string{"Rank your channels by importance: rank-my-channels"}, result.NextActions...)
+	}
+	return result, nil
+}
@@ -0,0 +1,67 @@
+package features
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+	"github.com/slack-go/slack"
+)
+
+func TestCountMembers(t *testing.T) {
+	users := map[string]slack.User{
+		"U1":        {ID: "U1"},
+		"U2":        {ID: "U2", IsAdmin: true},
+		"U3":        {ID: "U3", IsRestricted: true},
+		"U4":        {ID: "U4", IsBot: true},
+		"U5":        {ID: "U5", Deleted: true, IsBot: true},
+		"USLACKBOT": {ID: "USLACKBOT"},
+	}
+	got := countMembers(users)
+	want := memberStats{People: 2, Guests: 1, Bots: 2, Deactivated: 1, Admins: 1}
+	if got != want {
+		t.Errorf("countMembers = %+v, want %+v", got, want)
+	}
+}
+
+func TestCountChannels(t *testing.T) {
+	ch := func(mod func(*slack.Channel)) slack.Channel {
+		var c slack.Channel
+		mod(&c)
+		return c
+	}
+	channels := []slack.Channel{
+		ch(func(c *slack.Channel) { c.IsMember = true }),
+		ch(func(c *slack.Channel) {}),
+		ch(func(c *slack.Channel) { c.IsPrivate = true; c.IsMember = true }),
+		ch(func(c *slack.Channel) { c.IsArchived = true; c.IsMember = true }),
+		ch(func(c *slack.Channel) { c.IsIM = true }),
+	}
+	got := countChannels(channels)
+	want := channelStats{Public: 2, Private: 1, Archived: 1, Joined: 2}
+	if got != want {
+		t.Errorf("countChannels = %+v, want %+v", got, want)
+	}
+}
+
+func TestCountActivity(t *testing.T) {
+	now := time.Now()
+	ts := func(ago time.Duration) string { return fmt.Sprintf("%d.000100", now.Add(-ago).Unix()) }
+	body := fmt.Sprintf(`{"ok":true,
+		"channels":[{"id":"C1","latest":%q,"has_unreads":true,"mention_count":2},{"id":"C2","latest":%q}],
+		"mpims":[{"id":"G1","latest":""}],
+		"ims":[{"id":"D1","latest":%q,"has_unreads":true,"mention_count":1}],
+		"threads":{"unread_count":4}}`,
+		ts(time.Hour), ts(3*24*time.Hour), ts(30*24*time.Hour))
+	var counts provider.ClientCountsResponse
+	if err := json.Unmarshal([]byte(body), &counts); err != nil {
+		t.Fatal(err)
+	}
+	got := countActivity(&counts, now)
+	want := activityStats{Conversations: 4, ActiveDay: 1, ActiveWeek: 2, Unread: 2, Mentions: 3, ThreadUnreads: 4}
+	if got != want {
+		t.Errorf("countActivity = %+v, want %+v", got, want)
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"github.com/aaronsb/slack-mcp/pkg/provider"
 	"github.com/slack-go/slack"
 	"log"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // WriteMessage sends a message to a channel or DM
@@ -18,7 +20,7 @@ var WriteMessage = &Feature{
 		"properties": map[string]interface{}{
 			"channel": map[string]interface{}{
 				"type":        "string",
-				"description": "Channel name, DM username, or channel/DM ID to send to",
+				"description": "Channel name, DM username or email address, or channel/DM ID to send to",
 			},
 			"message": map[string]interface{}{
 				"type":        "string",
@@ -28,6 +30,20 @@ var WriteMessage = &Feature{
 				"type":        "string",
 				"description": "Thread timestamp to reply to (optional)",
 			},
+			"splitLongMessages": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Split messages over Slack's length limit: the first part goes to the channel, the rest as replies in its thread",
+				"default":     false,
+			},
+			"toneCheck": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Also check the message's tone (all caps, profanity, aggressive phrasing) before sending (default: SLACK_MCP_TONE_CHECK, off unless set)",
+			},
+			"force": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Send even if the pre-send checks (tone, length, formatting, mentions, recipient availability) raised warnings",
+				"default":     false,
+			},
 		},
 		"required": []string{"channel", "message"},
 	},
@@ -43,6 +59,16 @@ func writeMessageHandler(ctx context.Context, params map[string]interface{}) (*F
 		threadTs = ts
 	}
 
+	splitLong := false
+	if sp, ok := params["splitLongMessages"].(bool); ok {
+		splitLong = sp
+	}
+
+	force := false
+	if f, ok := params["force"].(bool); ok {
+		force = f
+	}
+
 	// Get the API provider
 	apiProvider, ok := params["_provider"].(*provider.ApiProvider)
 	if !ok {
@@ -71,9 +97,34 @@ func writeMessageHandler(ctx context.Context, params map[string]interface{}) (*F
 		}, nil
 	}
 
+	// Turn plain @names into real mentions, then run the pre-send checks.
+	// The caller must resend with force=true to override any warnings.
+	message, unresolved := resolveMentions(message, apiProvider.ProvideUsersMap())
+	if !force {
+		warnings := lintMessage(message, unresolved, splitLong)
+		if toneCheckEnabled(params) {
+			warnings = append(warnings, checkTone(message)...)
+		}
+		if len(warnings) > 0 {
+			return &FeatureResult{
+				Success: false,
+				Message: "Message not sent, pre-send checks flagged it:\n- " + strings.Join(warnings, "\n- "),
+				Data: map[string]interface{}{
+					"warnings": warnings,
+				},
+				Guidance: "✋ Fix the issues above, or call send-message again with force=true to send it as written",
+			}, nil
+		}
+	}
+
+	chunks := []string{message}
+	if splitLong {
+		chunks = splitMessage(message, slackMessageLimit)
+	}
+
 	// Prepare message options
 	options := []slack.MsgOption{
-		slack.MsgOptionText(message, false),
+		slack.MsgOptionText(chunks[0], false),
 	}
 
 	// Add thread timestamp if replying to a thread
@@ -81,16 +132,62 @@ func writeMessageHandler(ctx context.Context, params map[string]interface{}) (*F
 		options = append(options, slack.MsgOptionTS(threadTs))
 	}
 
+	// Hold or schedule the message during quiet hours
+	if qh := loadQuietHours(); qh != nil && qh.active(time.Now()) {
+		if qh.mode != quietModeSchedule || len(chunks) > 1 {
+			// Split parts thread onto the first part's timestamp, which a
+			// scheduled message doesn't have yet
+			return quietHoursBlocked(qh, "sending", time.Now()), nil
+		}
+		return scheduleForQuietHours(ctx, api, qh, channel, channelID, threadTs, message, options)
+	}
+
+	// Hold DMs to people who've asked not to be interrupted right now
+	if mode := dmPacingMode(); !force && mode != dmPacingOff && strings.HasPrefix(channelID, "D") {
+		if r := checkDMRecipient(ctx, apiProvider, api, channelID); r != nil && r.reason != "" {
+			if mode == dmPacingSchedule && !r.until.IsZero() && len(chunks) == 1 {
+				return scheduleForRecipient(ctx, api, r, channel, channelID, threadTs, message, options)
+			}
+			return recipientBusy(r, channel), nil
+		}
+	}
+
 	// Send the message
-	channelID, timestamp, err := api.PostMessageContext(ctx, channelID, options...)
+	postedID, timestamp, err := api.PostMessageContext(ctx, channelID, options...)
 	if err != nil {
 		log.Printf("Failed to send message: %v", err)
+		// Slack being down or busy shouldn't lose the message: queue it
+		// and retry in the background
+		if len(chunks) == 1 && apiProvider.OutboxEnabled() && provider.IsTransientSendError(err) {
+			return queueForRetry(apiProvider, channel, channelID, threadTs, message, force, err), nil
+		}
 		return &FeatureResult{
 			Success:  false,
 			Message:  fmt.Sprintf("Failed to send message: %v", err),
 			Guidance: "⚠️ Check if you have permission to post in this channel",
 		}, nil
 	}
+	channelID = postedID
+
+	// Remaining parts go into the thread: the caller's, or the one started by the first part
+	replyTs := threadTs
+	if replyTs == "" {
+		replyTs = timestamp
+	}
+	sent := 1
+	for _, chunk := range chunks[1:] {
+		_, _, err := api.PostMessageContext(ctx, channelID,
+			slack.MsgOptionText(chunk, false),
+			slack.MsgOptionTS(replyTs),
+		)
+		if err != nil {
+			log.Printf("Failed to send message part %d/%d: %v", sent+1, len(chunks), err)
+			break
+		}
+		sent++
+	}
+
+	apiProvider.RecordAction(provider.UsageMessagesSent, sent)
 
 	// Build response with message details
 	result := &FeatureResult{
@@ -104,20 +201,28 @@ func writeMessageHandler(ctx context.Context, params map[string]interface{}) (*F
 			"message":   message,
 		},
 	}
+	if len(chunks) > 1 {
+		result.Message = fmt.Sprintf("Message sent to %s in %d parts (the rest as thread replies)", channel, len(chunks))
+		if sent < len(chunks) {
+			result.Message = fmt.Sprintf("Message to %s only partly sent: %d of %d parts posted before an error", channel, sent, len(chunks))
+		}
+		result.Data.(map[string]interface{})["parts"] = len(chunks)
+		result.Data.(map[string]interface{})["partsSent"] = sent
+	}
 
 	// Add next actions with semantic flow
 	if threadTs == "" {
 		// New message - provide context-aware follow-ups
 		result.NextActions = []string{
 			fmt.Sprintf("Read conversation context: catch-up channel='%s' since='1h'", channel),
-			fmt.Sprintf("Monitor for responses: search threadId='%s:%s'", channelID, timestamp),
+			fmt.Sprintf("Monitor for responses: read-thread threadId='%s'", ThreadRef{ChannelID: channelID, ThreadTs: timestamp}),
 			fmt.Sprintf("Reply to your message: send-message channel='%s' threadTs='%s'", channel, timestamp),
 		}
 		result.Guidance = "💡 Your message was sent. Use catch-up to see recent context or monitor for responses."
 	} else {
 		// Thread reply - focus on thread context
 		result.NextActions = []string{
-			fmt.Sprintf("Read full thread: search threadId='%s:%s'", channelID, threadTs),
+			fmt.Sprintf("Read full thread: read-thread threadId='%s'", ThreadRef{ChannelID: channelID, ThreadTs: threadTs}),
 			fmt.Sprintf("Continue thread: send-message channel='%s' threadTs='%s'", channel, threadTs),
 			fmt.Sprintf("See channel context: catch-up channel='%s' since='4h'", channel),
 		}
@@ -200,3 +305,33 @@ func resolveChannelForSending(apiProvider *provider.ApiProvider, api *slack.Clie
 
 	return ""
 }
+
+// scheduleForQuietHours queues the message with chat.scheduleMessage for the end of quiet hours
+func scheduleForQuietHours(ctx context.Context, api *slack.Client, qh *quietHours, channel, channelID, threadTs, message string, options []slack.MsgOption) (*FeatureResult, error) {
+	postAt := qh.endAfter(time.Now())
+	_, scheduledID, err := api.ScheduleMessageContext(ctx, channelID, strconv.FormatInt(postAt.Unix(), 10), options...)
+	if err != nil {
+		log.Printf("Failed to schedule message: %v", err)
+		return &FeatureResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Quiet hours are in effect and scheduling the message failed: %v", err),
+			Guidance: "⚠️ Try again after quiet hours end",
+		}, nil
+	}
+
+	return &FeatureResult{
+		Success: true,
+		Message: fmt.Sprintf("Quiet hours are in effect (%s): message to %s scheduled for %s",
+			qh.spec, channel, postAt.Format("Mon 15:04")),
+		Data: map[string]interface{}{
+			"channel":     channel,
+			"channelId":   channelID,
+			"threadTs":    threadTs,
+			"message":     message,
+			"scheduled":   true,
+			"scheduledId": scheduledID,
+			"postAt":      postAt.Format(time.RFC3339),
+		},
+		Guidance: "🌙 The message will be delivered when quiet hours end. Scheduled messages can be edited or cancelled from Slack.",
+	}, nil
+}
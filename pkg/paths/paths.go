@@ -1,22 +1,34 @@
 package paths
 
 import (
+	"io"
+	"log"
 	"os"
 	"path/filepath"
+	"runtime"
 )
 
 const AppName = "slack-mcp"
 
-// ConfigDir returns the XDG config directory: $XDG_CONFIG_HOME/slack-mcp
+// Directory overrides, checked before the platform defaults
+const (
+	envConfigDir = "SLACK_MCP_CONFIG_DIR"
+	envDataDir   = "SLACK_MCP_DATA_DIR"
+	envLogFile   = "SLACK_MCP_LOG_FILE"
+)
+
+// ConfigDir returns where credentials and settings live:
+// $SLACK_MCP_CONFIG_DIR, then $XDG_CONFIG_HOME/slack-mcp, then the
+// platform default (~/.config on Linux, ~/Library/Application Support on
+// macOS, %AppData% on Windows)
 func ConfigDir() string {
+	if dir := os.Getenv(envConfigDir); dir != "" {
+		return dir
+	}
 	if base := os.Getenv("XDG_CONFIG_HOME"); base != "" {
 		return filepath.Join(base, AppName)
 	}
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return filepath.Join(".config", AppName)
-	}
-	return filepath.Join(home, ".config", AppName)
+	return platformDir(runtime.GOOS, "config")
 }
 
 // ConfigPath returns the config file path
@@ -24,6 +36,168 @@ func ConfigPath() string {
 	return filepath.Join(ConfigDir(), "config.json")
 }
 
+// DataDir returns where caches and local state live: $SLACK_MCP_DATA_DIR,
+// then $XDG_DATA_HOME/slack-mcp, then the platform default
+// (~/.local/share on Linux, ~/Library/Caches on macOS, %LocalAppData% on
+// Windows)
+func DataDir() string {
+	if dir := os.Getenv(envDataDir); dir != "" {
+		return dir
+	}
+	if base := os.Getenv("XDG_DATA_HOME"); base != "" {
+		return filepath.Join(base, AppName)
+	}
+	return platformDir(runtime.GOOS, "data")
+}
+
+// LogPath returns the log file used by the stdio transport:
+// $SLACK_MCP_LOG_FILE, then $XDG_STATE_HOME/slack-mcp/slack-mcp.log, then
+// the platform default (~/.local/state on Linux, ~/Library/Logs on macOS,
+// %LocalAppData%\slack-mcp\logs on Windows)
+func LogPath() string {
+	if path := os.Getenv(envLogFile); path != "" {
+		return path
+	}
+	if base := os.Getenv("XDG_STATE_HOME"); base != "" {
+		return filepath.Join(base, AppName, AppName+".log")
+	}
+	return filepath.Join(platformDir(runtime.GOOS, "log"), AppName+".log")
+}
+
+// LegacyLogPath is where logs went before LogPath was platform-aware
+func LegacyLogPath() string {
+	return filepath.Join(os.TempDir(), AppName+".log")
+}
+
+// platformDir returns the default directory of a kind (config, data, or
+// log) for an OS
+func platformDir(goos, kind string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		// Headless with no $HOME: stay out of the working directory
+		return filepath.Join(os.TempDir(), AppName, kind)
+	}
+	switch goos {
+	case "darwin":
+		switch kind {
+		case "config":
+			return filepath.Join(home, "Library", "Application Support", AppName)
+		case "data":
+			return filepath.Join(home, "Library", "Caches", AppName)
+		default:
+			return filepath.Join(home, "Library", "Logs", AppName)
+		}
+	case "windows":
+		roaming := os.Getenv("AppData")
+		if roaming == "" {
+			roaming = filepath.Join(home, "AppData", "Roaming")
+		}
+		local := os.Getenv("LocalAppData")
+		if local == "" {
+			local = filepath.Join(home, "AppData", "Local")
+		}
+		switch kind {
+		case "config":
+			return filepath.Join(roaming, AppName)
+		case "data":
+			return filepath.Join(local, AppName)
+		default:
+			return filepath.Join(local, AppName, "logs")
+		}
+	}
+	switch kind {
+	case "config":
+		return filepath.Join(home, ".config", AppName)
+	case "data":
+		return filepath.Join(home, ".local", "share", AppName)
+	default:
+		return filepath.Join(home, ".local", "state", AppName)
+	}
+}
+
+// MigrateLegacyDirs moves config and data from the XDG-style locations
+// earlier versions used on every platform to this platform's defaults. A
+// directory is only moved when the new one doesn't exist yet, and nothing
+// happens when an override or XDG variable picks the location.
+func MigrateLegacyDirs() {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+	moves := []struct {
+		overrides []string
+		old, new  string
+	}{
+		{[]string{envConfigDir, "XDG_CONFIG_HOME"}, filepath.Join(home, ".config", AppName), ConfigDir()},
+		{[]string{envDataDir, "XDG_DATA_HOME"}, filepath.Join(home, ".local", "share", AppName), DataDir()},
+	}
+	for _, m := range moves {
+		if os.Getenv(m.overrides[0]) != "" || os.Getenv(m.overrides[1]) != "" {
+			continue
+		}
+		if err := migrateDir(m.old, m.new); err != nil {
+			log.Printf("Could not migrate %s to %s: %v", m.old, m.new, err)
+		}
+	}
+}
+
+// migrateDir moves old to new, copying when a rename isn't possible (e.g.
+// across volumes)
+func migrateDir(old, new string) error {
+	if filepath.Clean(old) == filepath.Clean(new) {
+		return nil
+	}
+	if info, err := os.Stat(old); err != nil || !info.IsDir() {
+		return nil
+	}
+	if _, err := os.Stat(new); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(new), 0o700); err != nil {
+		return err
+	}
+	if err := os.Rename(old, new); err == nil {
+		log.Printf("Migrated %s to %s", old, new)
+		return nil
+	}
+	if err := copyDir(old, new); err != nil {
+		os.RemoveAll(new)
+		return err
+	}
+	log.Printf("Copied %s to %s", old, new)
+	return os.RemoveAll(old)
+}
+
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0o700)
+		}
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
+
 // DownloadsDir returns the user's downloads directory.
 //
 // Order of preference:
@@ -45,15 +219,3 @@ func DownloadsDir() string {
 	}
 	return filepath.Join(home, "Downloads")
 }
-
-// DataDir returns the XDG data directory: $XDG_DATA_HOME/slack-mcp
-func DataDir() string {
-	if base := os.Getenv("XDG_DATA_HOME"); base != "" {
-		return filepath.Join(base, AppName)
-	}
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return filepath.Join(".local", "share", AppName)
-	}
-	return filepath.Join(home, ".local", "share", AppName)
-}
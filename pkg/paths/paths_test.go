@@ -0,0 +1,66 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPlatformDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("AppData", `C:\Users\ann\AppData\Roaming`)
+	t.Setenv("LocalAppData", `C:\Users\ann\AppData\Local`)
+
+	cases := []struct{ goos, kind, want string }{
+		{"linux", "config", filepath.Join(home, ".config", AppName)},
+		{"linux", "log", filepath.Join(home, ".local", "state", AppName)},
+		{"darwin", "data", filepath.Join(home, "Library", "Caches", AppName)},
+		{"windows", "config", filepath.Join(`C:\Users\ann\AppData\Roaming`, AppName)},
+		{"windows", "log", filepath.Join(`C:\Users\ann\AppData\Local`, AppName, "logs")},
+	}
+	for _, c := range cases {
+		if got := platformDir(c.goos, c.kind); got != c.want {
+			t.Errorf("platformDir(%s, %s) = %s, want %s", c.goos, c.kind, got, c.want)
+		}
+	}
+}
+
+func TestOverrides(t *testing.T) {
+	t.Setenv("SLACK_MCP_DATA_DIR", "/srv/slack-mcp")
+	t.Setenv("SLACK_MCP_LOG_FILE", "/var/log/slack-mcp.log")
+	if got := DataDir(); got != "/srv/slack-mcp" {
+		t.Errorf("DataDir = %s", got)
+	}
+	if got := LogPath(); got != "/var/log/slack-mcp.log" {
+		t.Errorf("LogPath = %s", got)
+	}
+}
+
+func TestMigrateDir(t *testing.T) {
+	root := t.TempDir()
+	old := filepath.Join(root, "old")
+	os.MkdirAll(filepath.Join(old, "sub"), 0o700)
+	os.WriteFile(filepath.Join(old, "sub", "config.json"), []byte("{}"), 0o600)
+
+	dst := filepath.Join(root, "new", AppName)
+	if err := migrateDir(old, dst); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "sub", "config.json")); err != nil {
+		t.Errorf("not migrated: %v", err)
+	}
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Error("old directory left behind")
+	}
+
+	// An existing destination is never overwritten
+	os.MkdirAll(old, 0o700)
+	os.WriteFile(filepath.Join(old, "config.json"), []byte("stale"), 0o600)
+	if err := migrateDir(old, dst); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "config.json")); !os.IsNotExist(err) {
+		t.Error("migrated over an existing directory")
+	}
+}
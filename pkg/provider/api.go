@@ -4,46 +4,81 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/aaronsb/slack-mcp/pkg/cache"
+	"github.com/aaronsb/slack-mcp/pkg/paths"
 	"github.com/aaronsb/slack-mcp/pkg/transport"
 	"github.com/slack-go/slack"
 )
 
-// Cache file names in XDG data dir
+// Cache file names in XDG data dir. These are gzip-compressed, checksummed
+// envelopes; see cache.Store.SaveVersioned.
 const (
-	channelsCacheFile = "channels.json"
-	usersCacheFile    = "users.json"
-	dmMapCacheFile    = "dm-map.json"
+	channelsCacheFile = "channels.json.gz"
+	usersCacheFile    = "users.json.gz"
+	dmMapCacheFile    = "dm-map.json.gz"
 	flushInterval     = 5 * time.Minute
+
+	// cacheSchemaVersion is bumped whenever a cached struct changes shape.
+	// Files from another version are discarded and rebuilt from the API.
+	cacheSchemaVersion = 1
 )
 
+// legacyCacheFiles maps the plain JSON caches written by earlier releases
+// to their compressed replacements
+var legacyCacheFiles = map[string]string{
+	"channels.json":     channelsCacheFile,
+	"users.json":        usersCacheFile,
+	"dm-map.json":       dmMapCacheFile,
+	"channel-meta.json": channelMetaCacheFile,
+}
+
 type ApiProvider struct {
-	bootOnce       sync.Once
-	boot           func() *slack.Client
-	client         *slack.Client
+	boot           func(ctx context.Context) (*slack.Client, *slack.AuthTestResponse, error)
+	bootState      bootState
+	client         atomic.Pointer[slack.Client]
 	internalClient *InternalClient
+	sidebar        sidebarCache
+	tokenType      string
+
+	// App tokens can be rotated while running; see RotateToken
+	tokenMu sync.Mutex
+	token   string
 
 	users      map[string]slack.User
 	usersMutex sync.RWMutex
 
-	channels      map[string]slack.Channel // Channel ID -> Channel info
+	channels      map[string]slack.Channel // Channel ID -> full info (member channels, DMs)
+	channelMeta   map[string]ChannelMeta   // Channel ID -> slim info (everything else)
 	channelNames  map[string]string        // Channel name/display name -> Channel ID
+	interned      stringPool
 	channelsMutex sync.RWMutex
 
+	// Keyword index over channel names, topics, and purposes, rebuilt on
+	// the next search after any channel changes
+	keywords      *keywordIndex
+	keywordsMu    sync.Mutex
+	keywordsStale atomic.Bool
+
 	// DM channel map: user name/ID -> DM channel ID
 	dmMap      map[string]string
 	dmMapMutex sync.RWMutex
 
 	// Authenticated user identity
+	selfMutex  sync.Mutex
 	selfUserID string
 	selfUser   string
 	selfTeam   string
@@ -52,6 +87,35 @@ type ApiProvider struct {
 	// Cache persistence
 	store *cache.Store
 
+	// Activity counters for usage-stats
+	usage *UsageTracker
+
+	// Memoized chat.getPermalink results
+	permalinks *permalinkCache
+
+	// Deduplicates in-flight conversations.info calls
+	channelFlight flightGroup
+
+	// Scheduled channel/user directory refreshes
+	refresh *refreshScheduler
+
+	// Named queries run in the background
+	saved *savedSearches
+
+	// Messages waiting to be retried after a failed send
+	outbox *outbox
+
+	// Results of writes, by idempotency key, for replaying retries
+	idempotency *idempotencyLog
+
+	// Rolling log of mentions, DMs, thread replies, and reactions
+	events *eventLog
+
+	// Background work runs under this context until Close
+	lifetime  context.Context
+	stop      context.CancelFunc
+	closeOnce sync.Once
+
 	// Cache management
 	lastChannelRefresh time.Time
 	refreshCalls       int
@@ -75,112 +139,228 @@ func New() *ApiProvider {
 	return NewWithTokens(token, cookie)
 }
 
-// NewWithTokens creates a provider with explicit tokens
+// NewWithTokens creates a provider with explicit tokens: a browser session
+// token (xoxc) with its d cookie (xoxd), or a Slack app's bot (xoxb) or
+// user (xoxp) token with an empty cookie. App tokens only reach the
+// official API; features that need internal endpoints fall back or report
+// that they're unavailable.
 func NewWithTokens(token, cookie string) *ApiProvider {
 	// Initialize XDG cache store
 	store, err := cache.NewStore()
 	if err != nil {
 		log.Printf("Warning: could not create cache store: %v", err)
 	}
+	return newWithStore(token, cookie, store)
+}
 
-	ap := &ApiProvider{
-		boot: func() *slack.Client {
-			api := slack.New(token,
-				withHTTPClientOption(cookie),
-			)
+// NewIdentity creates a provider for a named identity. Each identity keeps
+// its caches, usage counters, and audit log in its own subdirectory of the
+// data directory, so nothing one account sees leaks into another's state.
+func NewIdentity(name, token, cookie string) (*ApiProvider, error) {
+	if !ValidIdentityName(name) {
+		return nil, fmt.Errorf("invalid identity name %q: use letters, digits, '-' and '_'", name)
+	}
+	store, err := cache.NewStoreIn(filepath.Join(paths.DataDir(), "identities", name))
+	if err != nil {
+		log.Printf("Warning: could not create cache store for identity %q: %v", name, err)
+	}
+	return newWithStore(token, cookie, store), nil
+}
+
+var identityNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// ValidIdentityName reports whether name can be used as an identity (it
+// becomes a directory name)
+func ValidIdentityName(name string) bool {
+	return identityNamePattern.MatchString(name)
+}
+
+func newWithStore(token, cookie string, store *cache.Store) *ApiProvider {
+	usage := newUsageTracker(store)
+	tokenType := tokenKind(token)
+	var internalClient *InternalClient
+	if tokenType == "session" {
+		internalClient = NewInternalClient(token, cookie)
+		internalClient.usage = usage
+	}
 
-			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-			defer cancel()
+	lifetime, stop := context.WithCancel(context.Background())
 
-			res, err := api.AuthTestContext(ctx)
+	// Declared first so boot can read a token rotated before it runs
+	var ap *ApiProvider
+	ap = &ApiProvider{
+		boot: func(ctx context.Context) (*slack.Client, *slack.AuthTestResponse, error) {
+			httpClient, err := newHTTPClient(cookie, usage)
 			if err != nil {
-				log.Printf("ERROR: Slack authentication failed: %v", err)
-				log.Printf("Please check your tokens")
-				return api
+				return nil, nil, err
 			}
+			token := ap.currentToken()
 
+			res, err := slack.New(token, slack.OptionHTTPClient(httpClient)).AuthTestContext(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
 			log.Printf("Authenticated as: %s\n", res)
 
-			api = slack.New(token,
-				withHTTPClientOption(cookie),
+			if tokenType != "session" {
+				return slack.New(token, slack.OptionHTTPClient(httpClient)), res, nil
+			}
+
+			// xoxc tokens must talk to the team's own endpoint, internal
+			// endpoints included
+			api := slack.New(token,
+				slack.OptionHTTPClient(httpClient),
 				withTeamEndpointOption(res.URL),
 			)
-
-			return api
+			if internalClient != nil {
+				if err := internalClient.SetBaseURL(internalBaseURL(res)); err != nil {
+					log.Printf("Internal endpoints stay on %s: %v", internalClient.BaseURL(), err)
+				}
+			}
+			return api, res, nil
 		},
-		internalClient: NewInternalClient(token, cookie),
+		internalClient: internalClient,
+		tokenType:      tokenType,
+		token:          token,
 		users:          make(map[string]slack.User),
 		channels:       make(map[string]slack.Channel),
+		channelMeta:    make(map[string]ChannelMeta),
+		interned:       make(stringPool),
 		channelNames:   make(map[string]string),
 		dmMap:          make(map[string]string),
 		store:          store,
-	}
+		usage:          usage,
+		permalinks:     newPermalinkCache(),
+		refresh:        newRefreshScheduler(),
+		saved:          newSavedSearches(),
+		outbox:         newOutbox(),
+		idempotency:    newIdempotencyLog(),
+		events:         newEventLog(),
+		lifetime:       lifetime,
+		stop:           stop,
+	}
+	ap.loadCachedState()
 
 	return ap
 }
 
-func (ap *ApiProvider) Provide() (*slack.Client, error) {
-	var bootErr error
-	ap.bootOnce.Do(func() {
-		ap.client = ap.boot()
-		ap.captureIdentity()
-		bootErr = ap.bootstrapDependencies(context.Background())
+// Close stops the provider's background work — directory refreshes, saved
+// searches, outbox retries, event polling, and cache flushes — and saves
+// its caches. Call it on a provider that has been replaced, so two don't
+// work the same store.
+func (ap *ApiProvider) Close() {
+	ap.closeOnce.Do(func() {
+		if ap.stop != nil {
+			ap.stop()
+		}
+		if ap.store == nil {
+			return
+		}
+		ap.store.Stop()
+		if err := ap.flushCaches(); err != nil {
+			log.Printf("Failed to save caches on close: %v", err)
+		}
 	})
-	if bootErr != nil {
-		return nil, bootErr
+}
+
+// Done is closed once the provider has been closed
+func (ap *ApiProvider) Done() <-chan struct{} {
+	if ap.lifetime == nil {
+		return nil
 	}
-	return ap.client, nil
+	return ap.lifetime.Done()
 }
 
-func (ap *ApiProvider) captureIdentity() {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	res, err := ap.client.AuthTestContext(ctx)
-	if err != nil {
-		log.Printf("Could not capture identity: %v", err)
+// loadCachedState reads the on-disk caches. It touches no network, so
+// cache-only reads can be answered while the provider is still booting.
+func (ap *ApiProvider) loadCachedState() {
+	if ap.store == nil {
 		return
 	}
-	ap.selfUserID = res.UserID
-	ap.selfUser = res.User
-	ap.selfTeam = res.Team
-	ap.selfTeamID = res.TeamID
-}
 
-func (ap *ApiProvider) bootstrapDependencies(ctx context.Context) error {
 	// Migrate old CWD cache files to XDG
-	if ap.store != nil {
-		ap.store.MigrateFromCWD(map[string]string{
-			".users_cache.json":    usersCacheFile,
-			".channels_cache.json": channelsCacheFile,
-		})
-	}
+	ap.store.MigrateFromCWD(map[string]string{
+		".users_cache.json":    "users.json",
+		".channels_cache.json": "channels.json",
+	})
+	ap.upgradeLegacyCaches()
 
-	// Load users from cache
 	ap.loadUsersFromCache()
+	ap.loadChannelsFromCache()
+	ap.loadCountsFromCache()
+	ap.loadSavedSearches()
+	ap.loadOutbox()
+	ap.loadIdempotency()
+	ap.loadEvents()
+}
 
-	if len(ap.users) == 0 {
-		// No cached users, fetch from API
+func (ap *ApiProvider) bootstrapDependencies(ctx context.Context) {
+	ap.usersMutex.RLock()
+	haveUsers := len(ap.users) > 0
+	ap.usersMutex.RUnlock()
+	if !haveUsers {
+		// No cached users, fetch from API. Not fatal: names resolve to IDs
+		// until the scheduled user refresh succeeds.
 		if err := ap.fetchAndCacheUsers(ctx); err != nil {
 			log.Printf("Failed to fetch users: %v", err)
-			return err
 		}
 	}
 
-	// Load channels from cache
-	ap.loadChannelsFromCache()
-
-	// Fetch member channels (fast — only channels user belongs to)
-	go ap.loadMemberChannels(ctx)
+	// Warm member channels from one client.boot call, or page through
+	// them with the public API (fast — only channels user belongs to)
+	go func() {
+		if !ap.warmFromClientBoot(ctx) {
+			ap.loadMemberChannels(ctx)
+		}
+	}()
 
 	// Start background backfill on relaxed schedule
 	go ap.backgroundBackfill(ctx)
 
+	// Keep the directories fresh on the configured cadence
+	ap.startBackgroundRefresh(ctx)
+	ap.startSavedSearches(ctx)
+	ap.startOutbox(ctx)
+	ap.startEventCollector(ctx)
+
 	// Start periodic cache flush
 	if ap.store != nil {
 		ap.store.StartPeriodicFlush(flushInterval, ap.flushCaches)
 	}
+}
+
+// upgradeLegacyCaches rewrites plain JSON caches in the compressed format
+// once and removes the originals. Unreadable ones are simply dropped.
+func (ap *ApiProvider) upgradeLegacyCaches() {
+	for legacy, current := range legacyCacheFiles {
+		if !ap.store.Exists(legacy) {
+			continue
+		}
+		var raw json.RawMessage
+		if !ap.store.Exists(current) && ap.store.Load(legacy, &raw) == nil {
+			if err := ap.store.SaveVersioned(current, cacheSchemaVersion, raw); err != nil {
+				log.Printf("Failed to upgrade %s: %v", legacy, err)
+				continue
+			}
+			log.Printf("Upgraded %s to %s", legacy, current)
+		}
+		_ = ap.store.Remove(legacy)
+	}
+}
 
-	return nil
+// loadCache reads a versioned cache file. Corrupt files and files from
+// another schema version are removed so they are rebuilt rather than
+// reported again on every boot.
+func (ap *ApiProvider) loadCache(filename string, dest interface{}) bool {
+	err := ap.store.LoadVersioned(filename, cacheSchemaVersion, dest)
+	if err == nil {
+		return true
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Rebuilding %s: %v", filename, err)
+		_ = ap.store.Remove(filename)
+	}
+	return false
 }
 
 // loadUsersFromCache loads users from XDG cache file
@@ -190,7 +370,7 @@ func (ap *ApiProvider) loadUsersFromCache() {
 	}
 
 	var cachedUsers []slack.User
-	if err := ap.store.Load(usersCacheFile, &cachedUsers); err != nil {
+	if !ap.loadCache(usersCacheFile, &cachedUsers) {
 		return
 	}
 
@@ -204,7 +384,7 @@ func (ap *ApiProvider) loadUsersFromCache() {
 
 // fetchAndCacheUsers fetches all users and saves to cache
 func (ap *ApiProvider) fetchAndCacheUsers(ctx context.Context) error {
-	users, err := ap.client.GetUsersContext(ctx, slack.GetUsersOptionLimit(1000))
+	users, err := ap.client.Load().GetUsersContext(ctx, slack.GetUsersOptionLimit(1000))
 	if err != nil {
 		return err
 	}
@@ -215,8 +395,11 @@ func (ap *ApiProvider) fetchAndCacheUsers(ctx context.Context) error {
 	}
 	ap.usersMutex.Unlock()
 
+	// DMs indexed before these users were known have no name mappings yet
+	ap.indexDMNames()
+
 	if ap.store != nil {
-		if err := ap.store.Save(usersCacheFile, users); err != nil {
+		if err := ap.store.SaveVersioned(usersCacheFile, cacheSchemaVersion, users); err != nil {
 			log.Printf("Failed to save users cache: %v", err)
 		} else {
 			log.Printf("Saved %d users to cache", len(users))
@@ -233,40 +416,47 @@ func (ap *ApiProvider) loadChannelsFromCache() {
 	}
 
 	var cachedChannels []slack.Channel
-	if err := ap.store.Load(channelsCacheFile, &cachedChannels); err != nil {
+	if !ap.loadCache(channelsCacheFile, &cachedChannels) {
 		return
 	}
 
+	// Older caches hold every channel in full; putChannel slims them
+	var cachedMeta []ChannelMeta
+	ap.loadCache(channelMetaCacheFile, &cachedMeta)
+
 	ap.channelsMutex.Lock()
 	for _, ch := range cachedChannels {
-		ap.channels[ch.ID] = ch
-		ap.indexChannel(ch)
+		ap.putChannel(ch)
+	}
+	for _, m := range cachedMeta {
+		if !ap.hasChannel(m.ID) {
+			ap.putChannel(m.Channel())
+		}
 	}
 	ap.lastChannelRefresh = time.Now()
 	ap.channelsMutex.Unlock()
 
-	// Index DM mappings outside channelsMutex
-	for _, ch := range cachedChannels {
-		ap.indexChannelDM(ch)
-	}
-
-	// Load DM map
+	// Load DM map, then let the cached IM channels override stale entries
 	var dmMap map[string]string
-	if err := ap.store.Load(dmMapCacheFile, &dmMap); err == nil {
+	if ap.loadCache(dmMapCacheFile, &dmMap) {
 		ap.dmMapMutex.Lock()
-		ap.dmMap = dmMap
+		for userID, dmID := range dmMap {
+			ap.dmMap[userID] = dmID
+		}
 		ap.dmMapMutex.Unlock()
 	}
 
-	log.Printf("Loaded %d channels from cache", len(cachedChannels))
+	// Index DM mappings outside channelsMutex
+	for _, ch := range cachedChannels {
+		ap.indexChannelDM(ch)
+	}
+
+	log.Printf("Loaded %d channels from cache", len(cachedChannels)+len(cachedMeta))
 }
 
 // indexChannel adds name mappings for a channel (caller must hold channelsMutex write lock)
 func (ap *ApiProvider) indexChannel(ch slack.Channel) {
-	if ch.Name != "" {
-		ap.channelNames[ch.Name] = ch.ID
-		ap.channelNames[strings.ToLower(ch.Name)] = ch.ID
-	}
+	ap.indexChannelName(ch.Name, ch.ID)
 
 	// For DMs, map by user's real name and username.
 	// Note: we do NOT acquire usersMutex or dmMapMutex here to avoid
@@ -275,6 +465,20 @@ func (ap *ApiProvider) indexChannel(ch slack.Channel) {
 	// The name mappings for DMs are best-effort from cached user data.
 }
 
+// indexChannelName maps a name and its lowercase form to a channel ID,
+// sharing interned strings (caller must hold channelsMutex write lock)
+func (ap *ApiProvider) indexChannelName(name, id string) {
+	if name == "" {
+		return
+	}
+	name = ap.interned.intern(name)
+	id = ap.interned.intern(id)
+	ap.channelNames[name] = id
+	if lower := strings.ToLower(name); lower != name {
+		ap.channelNames[ap.interned.intern(lower)] = id
+	}
+}
+
 // indexChannelDM adds DM-specific mappings (user name → channel ID, DM map).
 // Must be called WITHOUT channelsMutex held to avoid lock ordering issues.
 func (ap *ApiProvider) indexChannelDM(ch slack.Channel) {
@@ -304,6 +508,25 @@ func (ap *ApiProvider) indexChannelDM(ch slack.Channel) {
 	ap.dmMapMutex.Unlock()
 }
 
+// indexDMNames re-derives person name → DM channel mappings from the
+// user→IM index. Must be called WITHOUT channelsMutex held.
+func (ap *ApiProvider) indexDMNames() {
+	ap.dmMapMutex.RLock()
+	dms := make(map[string]string, len(ap.dmMap))
+	for userID, dmID := range ap.dmMap {
+		dms[userID] = dmID
+	}
+	ap.dmMapMutex.RUnlock()
+
+	for userID, dmID := range dms {
+		ap.indexChannelDM(slack.Channel{
+			GroupConversation: slack.GroupConversation{
+				Conversation: slack.Conversation{ID: dmID, IsIM: true, User: userID},
+			},
+		})
+	}
+}
+
 // loadMemberChannels fetches channels the user is a member of (fast startup)
 func (ap *ApiProvider) loadMemberChannels(ctx context.Context) {
 	log.Println("Loading member channels...")
@@ -311,7 +534,7 @@ func (ap *ApiProvider) loadMemberChannels(ctx context.Context) {
 	count := 0
 
 	for {
-		channels, nextCursor, err := ap.client.GetConversationsForUser(&slack.GetConversationsForUserParameters{
+		channels, nextCursor, err := ap.client.Load().GetConversationsForUser(&slack.GetConversationsForUserParameters{
 			Cursor:          cursor,
 			Limit:           100,
 			Types:           []string{"public_channel", "private_channel", "mpim", "im"},
@@ -330,8 +553,7 @@ func (ap *ApiProvider) loadMemberChannels(ctx context.Context) {
 		ap.channelsMutex.Lock()
 		for i := range channels {
 			channels[i].IsMember = true
-			ap.channels[channels[i].ID] = channels[i]
-			ap.indexChannel(channels[i])
+			ap.putFullChannel(channels[i])
 		}
 		ap.channelsMutex.Unlock()
 
@@ -370,7 +592,7 @@ func (ap *ApiProvider) backgroundBackfill(ctx context.Context) {
 	batchCount := 0
 
 	for {
-		channels, nextCursor, err := ap.client.GetConversations(&slack.GetConversationsParameters{
+		channels, nextCursor, err := ap.client.Load().GetConversations(&slack.GetConversationsParameters{
 			Cursor: cursor,
 			Limit:  100,
 			Types:  []string{"public_channel", "private_channel", "mpim", "im"},
@@ -388,9 +610,8 @@ func (ap *ApiProvider) backgroundBackfill(ctx context.Context) {
 		var newDMs []slack.Channel
 		ap.channelsMutex.Lock()
 		for _, ch := range channels {
-			if _, exists := ap.channels[ch.ID]; !exists {
-				ap.channels[ch.ID] = ch
-				ap.indexChannel(ch)
+			if !ap.hasChannel(ch.ID) {
+				ap.putChannel(ch)
 				if ch.IsIM {
 					newDMs = append(newDMs, ch)
 				}
@@ -446,11 +667,18 @@ func (ap *ApiProvider) flushCaches() error {
 	for _, ch := range ap.channels {
 		channels = append(channels, ch)
 	}
+	metas := make([]ChannelMeta, 0, len(ap.channelMeta))
+	for _, m := range ap.channelMeta {
+		metas = append(metas, m)
+	}
 	ap.channelsMutex.RUnlock()
 
-	if err := ap.store.Save(channelsCacheFile, channels); err != nil {
+	if err := ap.store.SaveVersioned(channelsCacheFile, cacheSchemaVersion, channels); err != nil {
 		return fmt.Errorf("flush channels: %w", err)
 	}
+	if err := ap.store.SaveVersioned(channelMetaCacheFile, cacheSchemaVersion, metas); err != nil {
+		return fmt.Errorf("flush channel meta: %w", err)
+	}
 
 	// Save users
 	ap.usersMutex.RLock()
@@ -460,7 +688,7 @@ func (ap *ApiProvider) flushCaches() error {
 	}
 	ap.usersMutex.RUnlock()
 
-	if err := ap.store.Save(usersCacheFile, users); err != nil {
+	if err := ap.store.SaveVersioned(usersCacheFile, cacheSchemaVersion, users); err != nil {
 		return fmt.Errorf("flush users: %w", err)
 	}
 
@@ -472,11 +700,14 @@ func (ap *ApiProvider) flushCaches() error {
 	}
 	ap.dmMapMutex.RUnlock()
 
-	if err := ap.store.Save(dmMapCacheFile, dmMapCopy); err != nil {
+	if err := ap.store.SaveVersioned(dmMapCacheFile, cacheSchemaVersion, dmMapCopy); err != nil {
 		return fmt.Errorf("flush dm-map: %w", err)
 	}
 
-	log.Printf("Flushed caches: %d channels, %d users, %d DM mappings", len(channels), len(users), len(dmMapCopy))
+	ap.flushCounts()
+	ap.flushUsage()
+
+	log.Printf("Flushed caches: %d channels, %d users, %d DM mappings", len(channels)+len(metas), len(users), len(dmMapCopy))
 	return nil
 }
 
@@ -492,6 +723,42 @@ func (ap *ApiProvider) ProvideUsersMap() map[string]slack.User {
 	return copy
 }
 
+// SelfInfo is the authenticated user and team as reported by auth.test
+type SelfInfo struct {
+	UserID string
+	User   string
+	Team   string
+	TeamID string
+}
+
+// Self returns the authenticated user, captured once at boot, so tools
+// don't spend an auth.test round trip on every call
+func (ap *ApiProvider) Self() (*SelfInfo, error) {
+	client, err := ap.Provide()
+	if err != nil {
+		return nil, err
+	}
+
+	ap.selfMutex.Lock()
+	defer ap.selfMutex.Unlock()
+	if ap.selfUserID == "" {
+		res, err := client.AuthTest()
+		if err != nil {
+			return nil, err
+		}
+		ap.selfUserID = res.UserID
+		ap.selfUser = res.User
+		ap.selfTeam = res.Team
+		ap.selfTeamID = res.TeamID
+	}
+	return &SelfInfo{
+		UserID: ap.selfUserID,
+		User:   ap.selfUser,
+		Team:   ap.selfTeam,
+		TeamID: ap.selfTeamID,
+	}, nil
+}
+
 // Identity returns information about the authenticated user
 type Identity struct {
 	UserID      string `json:"userId"`
@@ -505,16 +772,17 @@ type Identity struct {
 
 // ProvideIdentity returns the authenticated user's identity
 func (ap *ApiProvider) ProvideIdentity() *Identity {
-	if ap.selfUserID == "" {
-		return nil
-	}
-
+	ap.selfMutex.Lock()
 	id := &Identity{
 		UserID:   ap.selfUserID,
 		Username: ap.selfUser,
 		Team:     ap.selfTeam,
 		TeamID:   ap.selfTeamID,
 	}
+	ap.selfMutex.Unlock()
+	if id.UserID == "" {
+		return nil
+	}
 
 	// Enrich from users cache
 	ap.usersMutex.RLock()
@@ -542,6 +810,59 @@ func (ap *ApiProvider) ProvideInternalClient() *InternalClient {
 	return ap.internalClient
 }
 
+// TokenType reports the kind of token the provider authenticates with:
+// "session" (browser xoxc), "user" (xoxp), "bot" (xoxb), or "unknown"
+func (ap *ApiProvider) TokenType() string {
+	return ap.tokenType
+}
+
+// RotateToken switches an app-token provider to a refreshed token of the
+// same kind. Caches and identity carry over; a provider that hasn't booted
+// yet boots with the new token.
+func (ap *ApiProvider) RotateToken(token string) error {
+	if ap.tokenType == "session" || tokenKind(token) != ap.tokenType {
+		return fmt.Errorf("can't rotate a %s token to a %s token", ap.tokenType, tokenKind(token))
+	}
+	ap.tokenMu.Lock()
+	ap.token = token
+	ap.tokenMu.Unlock()
+	if !ap.bootState.done.Load() {
+		return nil
+	}
+
+	httpClient, err := newHTTPClient("", ap.usage)
+	if err != nil {
+		return err
+	}
+	ap.client.Store(slack.New(token, slack.OptionHTTPClient(httpClient)))
+	log.Printf("Rotated %s token", ap.tokenType)
+	return nil
+}
+
+func (ap *ApiProvider) currentToken() string {
+	ap.tokenMu.Lock()
+	defer ap.tokenMu.Unlock()
+	return ap.token
+}
+
+func tokenKind(token string) string {
+	switch {
+	case strings.HasPrefix(token, "xoxc-"):
+		return "session"
+	case strings.HasPrefix(token, "xoxp-"):
+		return "user"
+	case strings.HasPrefix(token, "xoxb-"):
+		return "bot"
+	}
+	return "unknown"
+}
+
+// Store returns the cache store so features can persist their own state
+// files alongside the provider caches. Nil if the data dir is unavailable.
+func (ap *ApiProvider) Store() *cache.Store {
+	return ap.store
+}
+
 // GetChannelInfo gets channel info with on-demand resolution.
 // On cache miss, fetches from API and patches the cache.
 func (ap *ApiProvider) GetChannelInfo(ctx context.Context, channelIDOrName string) (*slack.Channel, error) {
@@ -562,8 +883,18 @@ func (ap *ApiProvider) GetChannelInfo(ctx context.Context, channelIDOrName strin
 		ap.channelsMutex.RUnlock()
 		return &ch, nil
 	}
+	meta, slim := ap.channelMeta[channelID]
 	ap.channelsMutex.RUnlock()
 
+	// Non-member channels are cached slim; fetch full metadata on first use
+	if slim {
+		if ch, err := ap.fetchAndCacheChannel(ctx, channelID); err == nil {
+			return ch, nil
+		}
+		ch := meta.Channel()
+		return &ch, nil
+	}
+
 	// Cache miss — try on-demand resolution
 
 	// If the input doesn't look like a channel ID, try display name resolution
@@ -583,67 +914,92 @@ func (ap *ApiProvider) GetChannelInfo(ctx context.Context, channelIDOrName strin
 	return nil, fmt.Errorf("channel_not_found: %s", channelIDOrName)
 }
 
-// resolveByDisplayName tries to resolve a display name to a DM channel.
-// It searches users by real name, then opens a DM via conversations.open.
+// resolveByDisplayName tries to resolve a person to a DM channel. It
+// accepts "@handle", a real or display name, an email address, or a user
+// ID, and goes through the user→IM index so it works whichever cache
+// happened to load first.
 func (ap *ApiProvider) resolveByDisplayName(ctx context.Context, name string) (*slack.Channel, error) {
-	client, err := ap.Provide()
-	if err != nil {
-		return nil, err
-	}
-
-	// Search users for matching real name or username
 	nameLower := strings.ToLower(name)
 
-	ap.usersMutex.RLock()
-	var matchedUserID string
-	for _, user := range ap.users {
-		if strings.ToLower(user.RealName) == nameLower || strings.ToLower(user.Name) == nameLower {
+	person := strings.TrimPrefix(name, "@")
+	matchedUserID := ap.findUserID(person)
+	if matchedUserID == "" && looksLikeEmail(person) {
+		if user, err := ap.ResolveUserByEmail(ctx, person); err == nil {
 			matchedUserID = user.ID
-			break
 		}
 	}
-	ap.usersMutex.RUnlock()
-
 	if matchedUserID == "" {
 		return nil, fmt.Errorf("no user matching %q", name)
 	}
 
-	// Check DM map first
+	dmChannel, err := ap.OpenDM(ctx, matchedUserID)
+	if err != nil {
+		return nil, fmt.Errorf("open DM for %q: %w", name, err)
+	}
+
+	ap.channelsMutex.Lock()
+	ap.channelNames[name] = dmChannel.ID
+	ap.channelNames[nameLower] = dmChannel.ID
+	ap.channelsMutex.Unlock()
+	return dmChannel, nil
+}
+
+// findUserID matches a user ID, username, real name, display name, or
+// email against the user cache, case-insensitively
+func (ap *ApiProvider) findUserID(name string) string {
+	ap.usersMutex.RLock()
+	defer ap.usersMutex.RUnlock()
+
+	if _, ok := ap.users[name]; ok {
+		return name
+	}
+	for _, user := range ap.users {
+		if strings.EqualFold(user.Name, name) || strings.EqualFold(user.RealName, name) ||
+			strings.EqualFold(user.Profile.DisplayName, name) ||
+			(user.Profile.Email != "" && strings.EqualFold(user.Profile.Email, name)) {
+			return user.ID
+		}
+	}
+	return ""
+}
+
+// OpenDM returns the DM channel with a user. Known DMs come from the cached
+// IM map; otherwise conversations.open creates or returns the DM and the
+// cache is patched, so no IM listing is ever needed.
+func (ap *ApiProvider) OpenDM(ctx context.Context, userID string) (*slack.Channel, error) {
 	ap.dmMapMutex.RLock()
-	if dmID, ok := ap.dmMap[matchedUserID]; ok {
-		ap.dmMapMutex.RUnlock()
-		// We have the DM channel ID, fetch info
+	dmID, ok := ap.dmMap[userID]
+	ap.dmMapMutex.RUnlock()
+	if ok {
 		ap.channelsMutex.RLock()
-		if ch, ok := ap.channels[dmID]; ok {
-			ap.channelsMutex.RUnlock()
+		ch, cached := ap.channels[dmID]
+		ap.channelsMutex.RUnlock()
+		if cached {
 			return &ch, nil
 		}
-		ap.channelsMutex.RUnlock()
 		return ap.fetchAndCacheChannel(ctx, dmID)
 	}
-	ap.dmMapMutex.RUnlock()
+
+	client, err := ap.Provide()
+	if err != nil {
+		return nil, err
+	}
 
 	// Open DM conversation (creates if needed, returns existing if already open)
 	dmChannel, _, _, err := client.OpenConversationContext(ctx, &slack.OpenConversationParameters{
-		Users: []string{matchedUserID},
+		Users: []string{userID},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("open DM for %q: %w", name, err)
+		return nil, err
 	}
+	// conversations.open omits the user on some workspaces
+	if dmChannel.User == "" {
+		dmChannel.User = userID
+	}
+	dmChannel.IsIM = true
 
-	dmChannelID := dmChannel.ID
-
-	// Patch DM map
-	ap.dmMapMutex.Lock()
-	ap.dmMap[matchedUserID] = dmChannelID
-	ap.dmMapMutex.Unlock()
-
-	// Cache the channel info we already have
 	ap.channelsMutex.Lock()
-	ap.channels[dmChannelID] = *dmChannel
-	ap.indexChannel(*dmChannel)
-	ap.channelNames[name] = dmChannelID
-	ap.channelNames[nameLower] = dmChannelID
+	ap.putFullChannel(*dmChannel)
 	ap.channelsMutex.Unlock()
 
 	ap.indexChannelDM(*dmChannel)
@@ -658,8 +1014,11 @@ func (ap *ApiProvider) fetchAndCacheChannel(ctx context.Context, channelID strin
 		return nil, err
 	}
 
-	info, err := client.GetConversationInfo(&slack.GetConversationInfoInput{
-		ChannelID: channelID,
+	// Concurrent misses for the same channel share one conversations.info
+	info, err := ap.channelFlight.do(channelID, func() (*slack.Channel, error) {
+		return client.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
+			ChannelID: channelID,
+		})
 	})
 	if err != nil {
 		return nil, err
@@ -667,8 +1026,7 @@ func (ap *ApiProvider) fetchAndCacheChannel(ctx context.Context, channelID strin
 
 	// Patch the cache
 	ap.channelsMutex.Lock()
-	ap.channels[channelID] = *info
-	ap.indexChannel(*info)
+	ap.putFullChannel(*info)
 	ap.channelsMutex.Unlock()
 
 	ap.indexChannelDM(*info)
@@ -676,6 +1034,14 @@ func (ap *ApiProvider) fetchAndCacheChannel(ctx context.Context, channelID strin
 	return info, nil
 }
 
+// CachedChannelName returns a channel's name from the cache without
+// calling Slack, or "" if it isn't cached
+func (ap *ApiProvider) CachedChannelName(channelID string) string {
+	ap.channelsMutex.RLock()
+	defer ap.channelsMutex.RUnlock()
+	return ap.channels[channelID].Name
+}
+
 // ResolveChannelName resolves a channel ID to a name using cache,
 // fetching from API on cache miss.
 func (ap *ApiProvider) ResolveChannelName(ctx context.Context, channelID string) string {
@@ -713,6 +1079,71 @@ func (ap *ApiProvider) ResolveChannelID(channelNameOrID string) string {
 	return ch.ID
 }
 
+// FindChannelIncludingArchived resolves a channel name or ID, falling back
+// to a conversations.list scan that includes archived channels. The fast
+// member-channel load skips archived channels, so they are often missing
+// from the cache until the background backfill finishes.
+func (ap *ApiProvider) FindChannelIncludingArchived(ctx context.Context, channelNameOrID string) (*slack.Channel, error) {
+	if looksLikeChannelID(channelNameOrID) {
+		return ap.GetChannelInfo(ctx, channelNameOrID)
+	}
+
+	ap.channelsMutex.RLock()
+	id, ok := ap.channelNames[channelNameOrID]
+	if !ok {
+		id, ok = ap.channelNames[strings.ToLower(channelNameOrID)]
+	}
+	if ch, cached := ap.channels[id]; ok && cached {
+		ap.channelsMutex.RUnlock()
+		return &ch, nil
+	}
+	if m, cached := ap.channelMeta[id]; ok && cached {
+		ap.channelsMutex.RUnlock()
+		ch := m.Channel()
+		return &ch, nil
+	}
+	ap.channelsMutex.RUnlock()
+
+	client, err := ap.Provide()
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.ToLower(channelNameOrID)
+	cursor := ""
+	for {
+		channels, nextCursor, err := client.GetConversationsContext(ctx, &slack.GetConversationsParameters{
+			Cursor:          cursor,
+			Limit:           1000,
+			Types:           []string{"public_channel", "private_channel"},
+			ExcludeArchived: false,
+		})
+		if err != nil {
+			if rateLimitErr, ok := err.(*slack.RateLimitedError); ok {
+				time.Sleep(rateLimitErr.RetryAfter)
+				continue
+			}
+			return nil, err
+		}
+		for _, ch := range channels {
+			if strings.ToLower(ch.Name) != name {
+				continue
+			}
+			ap.channelsMutex.Lock()
+			ap.putChannel(ch)
+			ap.channelsMutex.Unlock()
+			ap.markDirty()
+			return &ch, nil
+		}
+		if nextCursor == "" {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	return nil, fmt.Errorf("channel_not_found: %s", channelNameOrID)
+}
+
 // ResolveUser resolves a user ID to user info, fetching on cache miss.
 func (ap *ApiProvider) ResolveUser(ctx context.Context, userID string) (*slack.User, error) {
 	ap.usersMutex.RLock()
@@ -803,15 +1234,19 @@ func (ap *ApiProvider) RefreshChannelCache(ctx context.Context) (*RefreshResult,
 	}, nil
 }
 
-// GetCachedChannels returns all cached channels
+// GetCachedChannels returns all cached channels. Non-member channels carry
+// only the ChannelMeta fields.
 func (ap *ApiProvider) GetCachedChannels() []slack.Channel {
 	ap.channelsMutex.RLock()
 	defer ap.channelsMutex.RUnlock()
 
-	channels := make([]slack.Channel, 0, len(ap.channels))
+	channels := make([]slack.Channel, 0, len(ap.channels)+len(ap.channelMeta))
 	for _, ch := range ap.channels {
 		channels = append(channels, ch)
 	}
+	for _, m := range ap.channelMeta {
+		channels = append(channels, m.Channel())
+	}
 	return channels
 }
 
@@ -822,7 +1257,7 @@ func (ap *ApiProvider) GetCacheInfo() CacheInfo {
 
 	return CacheInfo{
 		LastRefresh:  ap.lastChannelRefresh,
-		ChannelCount: len(ap.channels),
+		ChannelCount: len(ap.channels) + len(ap.channelMeta),
 		RefreshCount: ap.refreshCalls,
 	}
 }
@@ -846,60 +1281,72 @@ func looksLikeChannelID(s string) bool {
 	return true
 }
 
-func withHTTPClientOption(cookie string) func(c *slack.Client) {
-	return func(c *slack.Client) {
-		var proxy func(*http.Request) (*url.URL, error)
-		if proxyURL := os.Getenv("SLACK_MCP_PROXY"); proxyURL != "" {
-			parsed, err := url.Parse(proxyURL)
-			if err != nil {
-				log.Fatalf("Failed to parse proxy URL: %v", err)
-			}
-			proxy = http.ProxyURL(parsed)
-		} else {
-			proxy = nil
+// newHTTPClient builds the cookie-carrying HTTP client shared by every
+// slack.Client the provider constructs. Bad proxy or CA settings are
+// reported as errors so boot can fail without taking the process down.
+func newHTTPClient(cookie string, usage *UsageTracker) (*http.Client, error) {
+	var proxy func(*http.Request) (*url.URL, error)
+	if proxyURL := os.Getenv("SLACK_MCP_PROXY"); proxyURL != "" {
+		parsed, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("parse SLACK_MCP_PROXY: %w", err)
 		}
+		proxy = http.ProxyURL(parsed)
+	}
 
-		rootCAs, _ := x509.SystemCertPool()
-		if rootCAs == nil {
-			rootCAs = x509.NewCertPool()
-		}
+	rootCAs, _ := x509.SystemCertPool()
+	if rootCAs == nil {
+		rootCAs = x509.NewCertPool()
+	}
 
-		if localCertFile := os.Getenv("SLACK_MCP_SERVER_CA"); localCertFile != "" {
-			certs, err := os.ReadFile(localCertFile)
-			if err != nil {
-				log.Fatalf("Failed to append %q to RootCAs: %v", localCertFile, err)
-			}
-			if ok := rootCAs.AppendCertsFromPEM(certs); !ok {
-				log.Println("No certs appended, using system certs only")
-			}
+	if localCertFile := os.Getenv("SLACK_MCP_SERVER_CA"); localCertFile != "" {
+		certs, err := os.ReadFile(localCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("append %q to RootCAs: %w", localCertFile, err)
 		}
-
-		insecure := false
-		if os.Getenv("SLACK_MCP_SERVER_CA_INSECURE") != "" {
-			if localCertFile := os.Getenv("SLACK_MCP_SERVER_CA"); localCertFile != "" {
-				log.Fatalf("Variable SLACK_MCP_SERVER_CA is at the same time with SLACK_MCP_SERVER_CA_INSECURE")
-			}
-			insecure = true
+		if ok := rootCAs.AppendCertsFromPEM(certs); !ok {
+			log.Println("No certs appended, using system certs only")
 		}
+	}
 
-		customHTTPTransport := &http.Transport{
-			Proxy: proxy,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: insecure,
-				RootCAs:            rootCAs,
-			},
+	insecure := false
+	if os.Getenv("SLACK_MCP_SERVER_CA_INSECURE") != "" {
+		if localCertFile := os.Getenv("SLACK_MCP_SERVER_CA"); localCertFile != "" {
+			return nil, fmt.Errorf("SLACK_MCP_SERVER_CA and SLACK_MCP_SERVER_CA_INSECURE are mutually exclusive")
 		}
+		insecure = true
+	}
 
-		client := &http.Client{
-			Transport: transport.New(
+	customHTTPTransport := &http.Transport{
+		Proxy: proxy,
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: insecure,
+			RootCAs:            rootCAs,
+		},
+	}
+
+	return &http.Client{
+		Transport: &countingTransport{
+			next: transport.New(
 				customHTTPTransport,
 				"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/133.0.0.0 Safari/537.36",
 				cookie,
 			),
-		}
+			usage: usage,
+		},
+	}, nil
+}
 
-		slack.OptionHTTPClient(client)(c)
+// internalBaseURL picks the host for internal endpoints: the team URL from
+// auth.test, or on Enterprise Grid the organization's URL when set with
+// SLACK_MCP_ENTERPRISE_URL (e.g. https://acme.enterprise.slack.com)
+func internalBaseURL(res *slack.AuthTestResponse) string {
+	if res.EnterpriseID != "" {
+		if u := os.Getenv("SLACK_MCP_ENTERPRISE_URL"); u != "" {
+			return u
+		}
 	}
+	return res.URL
 }
 
 func withTeamEndpointOption(url string) slack.Option {
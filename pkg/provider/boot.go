@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// Boot is lazy and non-fatal: the first Provide call authenticates, and a
+// failure is returned to that caller instead of ending the process. Later
+// calls retry once the backoff has elapsed, so a network blip at startup
+// heals itself on the next tool call.
+const (
+	bootTimeout    = 10 * time.Second
+	bootBackoffMin = 2 * time.Second
+	bootBackoffMax = 2 * time.Minute
+)
+
+type bootState struct {
+	mu       sync.Mutex
+	done     atomic.Bool
+	booting  atomic.Bool
+	err      error
+	failures int
+	retryAt  time.Time
+}
+
+// Provide returns the authenticated client, booting it on first use. Only
+// authentication holds the boot lock; the caller that completes it then
+// loads the directories, which takes network calls, while other callers
+// already get the client.
+func (ap *ApiProvider) Provide() (*slack.Client, error) {
+	if ap.bootState.done.Load() {
+		return ap.client.Load(), nil
+	}
+
+	client, first, err := ap.authenticate()
+	if err != nil {
+		return nil, err
+	}
+	if first {
+		ap.bootstrapDependencies(ap.lifetime)
+		ap.bootState.booting.Store(false)
+	}
+	return client, nil
+}
+
+// authenticate calls auth.test once per backoff window. first is set for
+// the call that succeeded; the provider counts as booting until that
+// caller has loaded the directories.
+func (ap *ApiProvider) authenticate() (client *slack.Client, first bool, err error) {
+	b := &ap.bootState
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.done.Load() {
+		return ap.client.Load(), false, nil
+	}
+	if wait := time.Until(b.retryAt); b.err != nil && wait > 0 {
+		return nil, false, fmt.Errorf("%w (next retry in %s)", b.err, wait.Round(time.Second))
+	}
+
+	b.booting.Store(true)
+	ctx, cancel := context.WithTimeout(context.Background(), bootTimeout)
+	client, res, err := ap.boot(ctx)
+	cancel()
+	if err != nil {
+		b.booting.Store(false)
+		b.failures++
+		b.err = bootError(err)
+		b.retryAt = time.Now().Add(bootBackoff(b.failures))
+		log.Printf("Provider boot failed (attempt %d): %v", b.failures, err)
+		return nil, false, b.err
+	}
+
+	ap.client.Store(client)
+	ap.selfMutex.Lock()
+	ap.selfUserID = res.UserID
+	ap.selfUser = res.User
+	ap.selfTeam = res.Team
+	ap.selfTeamID = res.TeamID
+	ap.selfMutex.Unlock()
+
+	b.err = nil
+	b.failures = 0
+	b.done.Store(true)
+	return client, true, nil
+}
+
+// bootBackoff doubles from bootBackoffMin per failure, capped at bootBackoffMax
+func bootBackoff(failures int) time.Duration {
+	d := bootBackoffMin
+	for i := 1; i < failures && d < bootBackoffMax; i++ {
+		d *= 2
+	}
+	if d > bootBackoffMax {
+		d = bootBackoffMax
+	}
+	return d
+}
+
+// bootError tells rejected credentials apart from transient failures so
+// the caller knows whether re-authenticating will help
+func bootError(err error) error {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "invalid_auth"), strings.Contains(msg, "not_authed"),
+		strings.Contains(msg, "token_revoked"), strings.Contains(msg, "account_inactive"):
+		return fmt.Errorf("slack rejected the credentials (%s); run refresh-credentials to pick up a fresh browser cookie, or auth-setup to re-authenticate", msg)
+	default:
+		return fmt.Errorf("could not reach Slack: %w", err)
+	}
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/slack-go/slack"
+)
+
+// warmFromClientBoot seeds the member channels, IM map, identity, and
+// unread counts from a single client.boot (plus client.counts) call instead
+// of paginating conversations.list. Returns false when the snapshot is
+// unavailable so the caller falls back to the public API.
+func (ap *ApiProvider) warmFromClientBoot(ctx context.Context) bool {
+	if ap.internalClient == nil {
+		return false
+	}
+	boot, err := ap.internalClient.GetClientBoot(ctx)
+	if err != nil || !boot.OK {
+		if err == nil {
+			err = errors.New(boot.Error)
+		}
+		log.Printf("client.boot snapshot unavailable, using conversations API: %v", err)
+		return false
+	}
+	if len(boot.Channels) == 0 && len(boot.IMs) == 0 {
+		return false
+	}
+
+	ap.selfMutex.Lock()
+	if ap.selfUserID == "" && boot.Self.ID != "" {
+		ap.selfUserID = boot.Self.ID
+		ap.selfUser = boot.Self.Name
+		ap.selfTeam = boot.Team.Name
+		ap.selfTeamID = boot.Team.ID
+	}
+	ap.selfMutex.Unlock()
+
+	var channels []slack.Channel
+	for _, c := range boot.Channels {
+		var ch slack.Channel
+		ch.ID = c.ID
+		ch.Name = c.Name
+		ch.IsChannel = c.IsChannel
+		ch.IsGroup = c.IsGroup
+		ch.IsIM = c.IsIM
+		ch.IsMpIM = c.IsMpim
+		ch.IsPrivate = c.IsPrivate || c.IsGroup
+		ch.IsArchived = c.IsArchived
+		ch.Topic.Value = c.Topic.Value
+		ch.Purpose.Value = c.Purpose.Value
+		ch.IsMember = true
+		channels = append(channels, ch)
+	}
+	for _, im := range boot.IMs {
+		var ch slack.Channel
+		ch.ID = im.ID
+		ch.User = im.User
+		ch.IsIM = true
+		ch.IsMember = true
+		channels = append(channels, ch)
+	}
+
+	ap.channelsMutex.Lock()
+	for _, ch := range channels {
+		// Keep richer cached metadata; the snapshot only confirms membership
+		if existing, ok := ap.channels[ch.ID]; ok {
+			existing.IsMember = true
+			ap.channels[ch.ID] = existing
+			continue
+		}
+		ap.putFullChannel(ch)
+	}
+	ap.channelsMutex.Unlock()
+
+	for _, ch := range channels {
+		ap.indexChannelDM(ch)
+	}
+
+	// Seed unread state for cache-only reads
+	if _, err := ap.internalClient.GetClientCounts(ctx); err != nil {
+		log.Printf("client.counts during warm-up failed: %v", err)
+	}
+
+	log.Printf("Warmed %d conversations from client.boot", len(channels))
+	ap.markDirty()
+	return true
+}
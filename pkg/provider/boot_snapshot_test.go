@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestWarmFromClientBoot(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/client.boot":
+			w.Write([]byte(`{"ok":true,
+				"self":{"id":"U1","name":"me"},
+				"team":{"id":"T1","name":"Acme"},
+				"channels":[{"id":"C1","name":"general","is_channel":true},
+				            {"id":"C2","name":"ops","is_channel":true,"is_private":true}],
+				"ims":[{"id":"D1","user":"U2"}]}`))
+		case "/api/client.counts":
+			w.Write([]byte(`{"ok":true,"channels":[{"id":"C1","mention_count":2,"has_unreads":true}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	ic := NewInternalClient("xoxc-test", "xoxd-test")
+	ic.baseURL = srv.URL
+	ap := newTestProvider()
+	ap.internalClient = ic
+	ap.dmMap = make(map[string]string)
+	ap.users = make(map[string]slack.User)
+
+	// Cached metadata survives; the snapshot only confirms membership
+	cached := testChannel("C1", "general", false)
+	cached.Purpose.Value = "company-wide"
+	ap.channels["C1"] = cached
+
+	if !ap.warmFromClientBoot(context.Background()) {
+		t.Fatal("expected warm-up to succeed")
+	}
+	if ch := ap.channels["C1"]; !ch.IsMember || ch.Purpose.Value != "company-wide" {
+		t.Errorf("cached channel not preserved: %+v", ch)
+	}
+	if ch, ok := ap.channels["C2"]; !ok || !ch.IsPrivate || !ch.IsMember {
+		t.Errorf("private channel not warmed: %+v", ch)
+	}
+	if ap.dmMap["U2"] != "D1" {
+		t.Errorf("IM map not warmed: %v", ap.dmMap)
+	}
+	if ap.selfUserID != "U1" || ap.selfTeam != "Acme" {
+		t.Errorf("identity not captured: %q %q", ap.selfUserID, ap.selfTeam)
+	}
+	if counts, _ := ap.CachedClientCounts(); counts == nil || counts.Channels[0].MentionCount != 2 {
+		t.Error("unread counts not seeded")
+	}
+}
+
+func TestWarmFromClientBootFallsBack(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":false,"error":"not_allowed"}`))
+	}))
+	defer srv.Close()
+
+	ic := NewInternalClient("xoxc-test", "xoxd-test")
+	ic.baseURL = srv.URL
+	ap := newTestProvider()
+	ap.internalClient = ic
+
+	if ap.warmFromClientBoot(context.Background()) {
+		t.Error("expected fallback when client.boot fails")
+	}
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+func TestBootBackoff(t *testing.T) {
+	cases := map[int]time.Duration{
+		1:  2 * time.Second,
+		2:  4 * time.Second,
+		4:  16 * time.Second,
+		20: 2 * time.Minute,
+	}
+	for failures, want := range cases {
+		if got := bootBackoff(failures); got != want {
+			t.Errorf("bootBackoff(%d) = %v, want %v", failures, got, want)
+		}
+	}
+}
+
+func TestProvideFailsWithoutPanicAndBacksOff(t *testing.T) {
+	calls := 0
+	ap := &ApiProvider{
+		boot: func(ctx context.Context) (*slack.Client, *slack.AuthTestResponse, error) {
+			calls++
+			return nil, nil, slack.SlackErrorResponse{Err: "invalid_auth"}
+		},
+	}
+
+	_, err := ap.Provide()
+	if err == nil || !strings.Contains(err.Error(), "auth-setup") {
+		t.Fatalf("expected an auth-setup hint, got %v", err)
+	}
+
+	// Within the backoff window the last error is reported without retrying
+	_, err = ap.Provide()
+	if err == nil || !strings.Contains(err.Error(), "next retry") || calls != 1 {
+		t.Errorf("expected a backed-off error after 1 call, got %v after %d calls", err, calls)
+	}
+
+	// Once the window has passed, boot is attempted again
+	ap.bootState.retryAt = time.Now().Add(-time.Second)
+	_, _ = ap.Provide()
+	if calls != 2 {
+		t.Errorf("expected a retry after the backoff, got %d calls", calls)
+	}
+}
+
+func TestBootErrorDistinguishesTransientFailures(t *testing.T) {
+	err := bootError(errors.New("dial tcp: i/o timeout"))
+	if !strings.Contains(err.Error(), "could not reach Slack") {
+		t.Errorf("transient error misreported: %v", err)
+	}
+}
+
+func TestCloseEndsBackgroundWork(t *testing.T) {
+	t.Setenv("SLACK_MCP_DATA_DIR", t.TempDir())
+	ap := NewWithTokens("xoxp-test", "")
+	select {
+	case <-ap.Done():
+		t.Fatal("a new provider should be running")
+	default:
+	}
+
+	ap.Close()
+	ap.Close() // Safe to repeat
+	select {
+	case <-ap.Done():
+	default:
+		t.Fatal("Close should end the provider's background loops")
+	}
+}
+
+func TestProvideDoesNotWaitForDirectoryLoad(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "users.list") {
+			<-release
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"ok":false,"error":"not_allowed"}`)
+	}))
+	defer srv.Close()
+	var once sync.Once
+	releaseAll := func() { once.Do(func() { close(release) }) }
+	defer releaseAll()
+
+	t.Setenv("SLACK_MCP_DATA_DIR", t.TempDir())
+	ap := NewWithTokens("xoxp-test", "")
+	defer ap.Close()
+	ap.boot = func(ctx context.Context) (*slack.Client, *slack.AuthTestResponse, error) {
+		return slack.New("xoxp-test", slack.OptionAPIURL(srv.URL+"/")), &slack.AuthTestResponse{UserID: "U1"}, nil
+	}
+
+	first := make(chan struct{})
+	go func() {
+		_, _ = ap.Provide()
+		close(first)
+	}()
+	for deadline := time.Now().Add(time.Second); !ap.bootState.done.Load(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("auth never completed")
+		}
+	}
+	if !ap.Booting() {
+		t.Error("should count as booting while the directories load")
+	}
+
+	// The users fetch is stuck, but the client is already available
+	second := make(chan error, 1)
+	go func() {
+		_, err := ap.Provide()
+		second <- err
+	}()
+	select {
+	case err := <-second:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Provide waited for the first caller's directory load")
+	}
+
+	releaseAll()
+	<-first
+	if ap.Booting() {
+		t.Error("booting should end once the directories are loaded")
+	}
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"log"
+	"time"
+)
+
+// countsCacheFile keeps the last client.counts response across restarts so
+// check-unreads can answer from cache before the first live call returns
+const countsCacheFile = "counts.json.gz"
+
+type countsSnapshot struct {
+	Counts    *ClientCountsResponse `json:"counts"`
+	FetchedAt time.Time             `json:"fetchedAt"`
+}
+
+// Booting reports whether a boot attempt is in flight. Read tools answer
+// from cache during this window instead of queueing behind it.
+func (ap *ApiProvider) Booting() bool {
+	return ap.bootState.booting.Load()
+}
+
+// CachedClientCounts returns the last client.counts response, live or
+// loaded from disk, and when it was fetched. Nil if none is available.
+func (ap *ApiProvider) CachedClientCounts() (*ClientCountsResponse, time.Time) {
+	if ap.internalClient == nil {
+		return nil, time.Time{}
+	}
+	return ap.internalClient.CachedCounts()
+}
+
+// ChannelCacheAge returns when the channel cache was last refreshed
+func (ap *ApiProvider) ChannelCacheAge() time.Time {
+	ap.channelsMutex.RLock()
+	defer ap.channelsMutex.RUnlock()
+	return ap.lastChannelRefresh
+}
+
+func (ap *ApiProvider) loadCountsFromCache() {
+	if ap.internalClient == nil {
+		return
+	}
+	var snap countsSnapshot
+	if !ap.loadCache(countsCacheFile, &snap) || snap.Counts == nil {
+		return
+	}
+	ic := ap.internalClient
+	ic.countsMu.Lock()
+	if ic.lastCounts == nil {
+		ic.lastCounts = snap.Counts
+		ic.lastCountsAt = snap.FetchedAt
+	}
+	ic.countsMu.Unlock()
+}
+
+func (ap *ApiProvider) flushCounts() {
+	counts, at := ap.CachedClientCounts()
+	if counts == nil || ap.store == nil {
+		return
+	}
+	if err := ap.store.SaveVersioned(countsCacheFile, cacheSchemaVersion, countsSnapshot{Counts: counts, FetchedAt: at}); err != nil {
+		log.Printf("Failed to save counts cache: %v", err)
+	}
+}
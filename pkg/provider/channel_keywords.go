@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Field weights in the channel keyword index: a term in the name says more
+// about what a channel is for than one in its topic or purpose
+const (
+	keywordWeightName  = 3.0
+	keywordWeightAbout = 1.5
+	// A query term that only prefixes an indexed term ("bill" → "billing")
+	keywordPrefixFactor = 0.5
+)
+
+// Words too common in channel purposes to say anything about them
+var keywordStopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "for": true, "from": true, "in": true,
+	"is": true, "it": true, "of": true, "on": true, "or": true, "our": true, "the": true,
+	"this": true, "to": true, "we": true, "with": true, "about": true, "all": true,
+	"channel": true, "channels": true, "team": true, "please": true, "here": true,
+}
+
+// keywordIndex is an inverted index from terms in channel names, topics,
+// and purposes to the channels that use them
+type keywordIndex struct {
+	postings map[string][]keywordPosting
+	terms    []string // Sorted, for prefix lookups
+	channels int
+}
+
+type keywordPosting struct {
+	id     string
+	weight float64
+}
+
+// ChannelKeywordMatch is a channel found in the keyword index
+type ChannelKeywordMatch struct {
+	ID    string
+	Score float64
+	Terms []string // Indexed terms that matched
+}
+
+// keywordTokens splits text into lowercase index terms, dropping stopwords
+// and single characters
+func keywordTokens(s string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	out := fields[:0]
+	for _, f := range fields {
+		if len(f) > 1 && !keywordStopwords[f] {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// buildKeywordIndex indexes every non-DM channel
+func buildKeywordIndex(channels []ChannelMeta) *keywordIndex {
+	idx := &keywordIndex{postings: make(map[string][]keywordPosting)}
+	for _, ch := range channels {
+		weights := map[string]float64{}
+		for _, t := range keywordTokens(ch.Topic + " " + ch.Purpose) {
+			weights[t] = keywordWeightAbout
+		}
+		for _, t := range keywordTokens(ch.Name) {
+			weights[t] = keywordWeightName
+		}
+		for t, w := range weights {
+			idx.postings[t] = append(idx.postings[t], keywordPosting{id: ch.ID, weight: w})
+		}
+		idx.channels++
+	}
+	idx.terms = make([]string, 0, len(idx.postings))
+	for t := range idx.postings {
+		idx.terms = append(idx.terms, t)
+	}
+	sort.Strings(idx.terms)
+	return idx
+}
+
+// search scores channels by the query terms they contain, weighting rare
+// terms above common ones
+func (idx *keywordIndex) search(query []string) []ChannelKeywordMatch {
+	byID := map[string]*ChannelKeywordMatch{}
+	add := func(term string, factor float64) {
+		postings := idx.postings[term]
+		idf := 1 + math.Log(float64(idx.channels+1)/float64(len(postings)+1))
+		for _, p := range postings {
+			m, ok := byID[p.id]
+			if !ok {
+				m = &ChannelKeywordMatch{ID: p.id}
+				byID[p.id] = m
+			}
+			m.Score += p.weight * idf * factor
+			m.Terms = append(m.Terms, term)
+		}
+	}
+	for _, q := range query {
+		q = strings.ToLower(q)
+		if _, ok := idx.postings[q]; ok {
+			add(q, 1)
+		}
+		for i := sort.SearchStrings(idx.terms, q); i < len(idx.terms) && strings.HasPrefix(idx.terms[i], q); i++ {
+			if idx.terms[i] != q {
+				add(idx.terms[i], keywordPrefixFactor)
+			}
+		}
+	}
+
+	matches := make([]ChannelKeywordMatch, 0, len(byID))
+	for _, m := range byID {
+		matches = append(matches, *m)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].ID < matches[j].ID
+	})
+	return matches
+}
+
+// SearchChannelKeywords finds channels whose name, topic, or purpose use
+// the query terms. The index is rebuilt lazily after the channel cache
+// changes.
+func (ap *ApiProvider) SearchChannelKeywords(terms []string) []ChannelKeywordMatch {
+	ap.keywordsMu.Lock()
+	defer ap.keywordsMu.Unlock()
+	if ap.keywords == nil || ap.keywordsStale.Swap(false) {
+		ap.keywords = buildKeywordIndex(ap.indexableChannels())
+	}
+	return ap.keywords.search(terms)
+}
+
+// indexableChannels lists the channels, in slim form, that discovery
+// considers: everything but DMs
+func (ap *ApiProvider) indexableChannels() []ChannelMeta {
+	ap.channelsMutex.RLock()
+	defer ap.channelsMutex.RUnlock()
+	out := make([]ChannelMeta, 0, len(ap.channels)+len(ap.channelMeta))
+	for _, ch := range ap.channels {
+		if !ch.IsIM && !ch.IsMpIM {
+			out = append(out, ChannelMeta{ID: ch.ID, Name: ch.Name, Topic: ch.Topic.Value, Purpose: ch.Purpose.Value})
+		}
+	}
+	for _, m := range ap.channelMeta {
+		out = append(out, m)
+	}
+	return out
+}
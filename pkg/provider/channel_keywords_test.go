@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeywordTokens(t *testing.T) {
+	got := keywordTokens("Billing & invoices for the EU team (v2)")
+	if want := []string{"billing", "invoices", "eu", "v2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("keywordTokens = %v, want %v", got, want)
+	}
+}
+
+func TestSearchChannelKeywords(t *testing.T) {
+	ap := newTestProvider()
+	billing := testChannel("C1", "billing-ops", false)
+	billing.Purpose.Value = "Invoices and refunds"
+	ap.putChannel(billing)
+	about := testChannel("C2", "finance", false)
+	about.Purpose.Value = "Budget, billing questions"
+	ap.putChannel(about)
+	ap.putChannel(testChannel("C3", "random", true))
+
+	matches := ap.SearchChannelKeywords([]string{"billing"})
+	if len(matches) != 2 || matches[0].ID != "C1" || matches[1].ID != "C2" {
+		t.Fatalf("matches = %+v, want name match first", matches)
+	}
+
+	// Prefixes match at a discount
+	if matches := ap.SearchChannelKeywords([]string{"refund"}); len(matches) != 1 || matches[0].Terms[0] != "refunds" {
+		t.Errorf("prefix matches = %+v", matches)
+	}
+
+	// The index picks up channel changes on the next search
+	renamed := testChannel("C3", "billing-alerts", true)
+	ap.putChannel(renamed)
+	if matches := ap.SearchChannelKeywords([]string{"billing"}); len(matches) != 3 {
+		t.Errorf("after update = %+v", matches)
+	}
+}
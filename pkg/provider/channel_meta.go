@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"github.com/slack-go/slack"
+)
+
+// ChannelMeta is the slim form kept for channels the user is not in. On
+// large workspaces most of the directory is non-member channels, and the
+// full slack.Channel (members, creator, properties, latest message) costs
+// far more than the handful of fields listings and suggestions read. Full
+// metadata is fetched lazily the first time such a channel is looked up.
+type ChannelMeta struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Topic      string `json:"topic,omitempty"`
+	Purpose    string `json:"purpose,omitempty"`
+	NumMembers int    `json:"num_members,omitempty"`
+	IsPrivate  bool   `json:"is_private,omitempty"`
+	IsArchived bool   `json:"is_archived,omitempty"`
+}
+
+// channelMetaCacheFile holds the slim non-member channels; the channels
+// cache keeps only member channels and conversations with full metadata
+const channelMetaCacheFile = "channel-meta.json.gz"
+
+// keepsFullMetadata reports whether a channel is stored in full. Member
+// channels and DMs are read often and in detail; the rest stay slim.
+func keepsFullMetadata(ch slack.Channel) bool {
+	return ch.IsMember || ch.IsIM || ch.IsMpIM
+}
+
+// newChannelMeta slims a channel, interning its strings through pool
+func newChannelMeta(ch slack.Channel, pool stringPool) ChannelMeta {
+	return ChannelMeta{
+		ID:         pool.intern(ch.ID),
+		Name:       pool.intern(ch.Name),
+		Topic:      pool.intern(ch.Topic.Value),
+		Purpose:    pool.intern(ch.Purpose.Value),
+		NumMembers: ch.NumMembers,
+		IsPrivate:  ch.IsPrivate,
+		IsArchived: ch.IsArchived,
+	}
+}
+
+// Channel expands the slim form back into a slack.Channel for callers that
+// iterate the cache
+func (m ChannelMeta) Channel() slack.Channel {
+	var ch slack.Channel
+	ch.ID = m.ID
+	ch.Name = m.Name
+	ch.Topic.Value = m.Topic
+	ch.Purpose.Value = m.Purpose
+	ch.NumMembers = m.NumMembers
+	ch.IsPrivate = m.IsPrivate
+	ch.IsArchived = m.IsArchived
+	ch.IsChannel = true
+	return ch
+}
+
+// stringPool interns repeated strings (names, topics, purposes) so the
+// channel maps and name index share one copy. Guarded by channelsMutex.
+type stringPool map[string]string
+
+func (p stringPool) intern(s string) string {
+	if s == "" {
+		return ""
+	}
+	if v, ok := p[s]; ok {
+		return v
+	}
+	p[s] = s
+	return s
+}
+
+// putChannel stores a channel in full or slim form depending on membership
+// and indexes its names. A channel already held in full is only replaced by
+// another full copy, so lazily fetched metadata isn't thrown away by the
+// next directory sync. Caller must hold the channelsMutex write lock.
+func (ap *ApiProvider) putChannel(ch slack.Channel) {
+	ap.keywordsStale.Store(true)
+	if keepsFullMetadata(ch) {
+		ap.putFullChannel(ch)
+		return
+	}
+	if _, full := ap.channels[ch.ID]; full {
+		return
+	}
+	meta := newChannelMeta(ch, ap.interned)
+	ap.channelMeta[meta.ID] = meta
+	ap.indexChannelName(meta.Name, meta.ID)
+}
+
+// putFullChannel stores full metadata, dropping any slim copy. Caller must
+// hold the channelsMutex write lock.
+func (ap *ApiProvider) putFullChannel(ch slack.Channel) {
+	ap.keywordsStale.Store(true)
+	delete(ap.channelMeta, ch.ID)
+	ap.channels[ch.ID] = ch
+	ap.indexChannel(ch)
+}
+
+// hasChannel reports whether a channel is cached in either form. Caller
+// must hold channelsMutex.
+func (ap *ApiProvider) hasChannel(id string) bool {
+	if _, ok := ap.channels[id]; ok {
+		return true
+	}
+	_, ok := ap.channelMeta[id]
+	return ok
+}
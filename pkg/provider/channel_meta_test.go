@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func newTestProvider() *ApiProvider {
+	return &ApiProvider{
+		channels:     make(map[string]slack.Channel),
+		channelMeta:  make(map[string]ChannelMeta),
+		channelNames: make(map[string]string),
+		interned:     make(stringPool),
+	}
+}
+
+func testChannel(id, name string, member bool) slack.Channel {
+	var ch slack.Channel
+	ch.ID = id
+	ch.Name = name
+	ch.IsMember = member
+	ch.Purpose.Value = "purpose of " + name
+	ch.NumMembers = 12
+	return ch
+}
+
+func TestPutChannelSlimsNonMembers(t *testing.T) {
+	ap := newTestProvider()
+	ap.putChannel(testChannel("C1", "general", true))
+	ap.putChannel(testChannel("C2", "Design-Reviews", false))
+
+	if _, ok := ap.channels["C1"]; !ok {
+		t.Error("member channel should be kept in full")
+	}
+	m, ok := ap.channelMeta["C2"]
+	if !ok {
+		t.Fatal("non-member channel should be kept slim")
+	}
+	if m.Purpose != "purpose of Design-Reviews" || m.NumMembers != 12 {
+		t.Errorf("slim channel lost fields: %+v", m)
+	}
+	if ap.channelNames["design-reviews"] != "C2" || ap.channelNames["Design-Reviews"] != "C2" {
+		t.Errorf("slim channel not indexed by name: %v", ap.channelNames)
+	}
+	if len(ap.channelNames) != 3 {
+		t.Errorf("expected lowercase names to share one key, got %v", ap.channelNames)
+	}
+}
+
+func TestPutChannelKeepsFullMetadata(t *testing.T) {
+	ap := newTestProvider()
+	ap.putChannel(testChannel("C2", "design", false))
+
+	// Lazy fetch promotes the channel to full metadata
+	full := testChannel("C2", "design", false)
+	full.Creator = "U1"
+	ap.putFullChannel(full)
+	if _, ok := ap.channelMeta["C2"]; ok {
+		t.Error("promoted channel should drop its slim copy")
+	}
+
+	// A later directory sync must not demote it again
+	ap.putChannel(testChannel("C2", "design", false))
+	if ch, ok := ap.channels["C2"]; !ok || ch.Creator != "U1" {
+		t.Error("full metadata was replaced by a slim copy")
+	}
+	if _, ok := ap.channelMeta["C2"]; ok {
+		t.Error("channel cached in both forms")
+	}
+}
+
+func TestChannelMetaRoundTrip(t *testing.T) {
+	ch := testChannel("C3", "ops", false)
+	ch.IsArchived = true
+	ch.Topic.Value = "pager rotation"
+	got := newChannelMeta(ch, make(stringPool)).Channel()
+	if got.ID != "C3" || got.Name != "ops" || !got.IsArchived || got.Topic.Value != "pager rotation" ||
+		got.Purpose.Value != ch.Purpose.Value || got.NumMembers != 12 || got.IsMember {
+		t.Errorf("round trip mismatch: %+v", got)
+	}
+}
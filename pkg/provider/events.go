@@ -0,0 +1,324 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// The event log is a rolling record of things that happened to the user:
+// mentions, DMs, thread replies, and reactions to their messages. There is
+// no realtime connection, so a collector polls the cheapest sources on a
+// schedule and whenever the log is read:
+//
+//	SLACK_MCP_EVENT_POLL="5m"   collection cadence (default 5m; "off" collects only on read)
+//
+// Each event gets a log position (ID) in Slack timestamp form that only
+// grows, so a reader can ask for everything after the last ID it saw.
+const (
+	eventsFile          = "events.json"
+	defaultEventPoll    = 5 * time.Minute
+	maxEvents           = 500
+	eventRetention      = 7 * 24 * time.Hour
+	eventLookback       = 24 * time.Hour
+	eventMinCollectGap  = time.Minute
+	eventSearchCount    = 20
+	eventReactionProbes = 10
+	eventThreadLimit    = 20
+)
+
+// Event types
+const (
+	EventMention     = "mention"
+	EventDM          = "dm"
+	EventThreadReply = "thread_reply"
+	EventReaction    = "reaction"
+)
+
+// Event is one entry in the rolling event log
+type Event struct {
+	ID        string    `json:"id"` // log position, increases monotonically
+	Type      string    `json:"type"`
+	ChannelID string    `json:"channel_id"`
+	User      string    `json:"user,omitempty"`
+	Ts        string    `json:"ts"`
+	ThreadTs  string    `json:"thread_ts,omitempty"`
+	Text      string    `json:"text,omitempty"`
+	Reaction  string    `json:"reaction,omitempty"`
+	Permalink string    `json:"permalink,omitempty"`
+	SeenAt    time.Time `json:"seen_at"`
+}
+
+func (e Event) key() string {
+	return strings.Join([]string{e.Type, e.ChannelID, e.Ts, e.Reaction, e.User}, "/")
+}
+
+type eventLog struct {
+	mu          sync.Mutex
+	every       time.Duration
+	events      []Event
+	keys        map[string]bool
+	lastID      string
+	lastCollect time.Time
+	collecting  sync.Mutex
+}
+
+func newEventLog() *eventLog {
+	return &eventLog{
+		every: loadRefreshInterval("SLACK_MCP_EVENT_POLL", defaultEventPoll),
+		keys:  map[string]bool{},
+	}
+}
+
+// nextID returns a log position later than any handed out before. Caller
+// must hold mu.
+func (l *eventLog) nextID(now time.Time) string {
+	id := fmt.Sprintf("%d.%06d", now.Unix(), now.Nanosecond()/1000)
+	if id <= l.lastID {
+		var sec, usec int64
+		fmt.Sscanf(l.lastID, "%d.%d", &sec, &usec)
+		usec++
+		if usec >= 1000000 {
+			sec, usec = sec+1, 0
+		}
+		id = fmt.Sprintf("%d.%06d", sec, usec)
+	}
+	l.lastID = id
+	return id
+}
+
+// add appends events not already logged and trims the log by age and
+// size. Returns how many were new. Caller must hold mu.
+func (l *eventLog) add(now time.Time, events []Event) int {
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Ts < events[j].Ts })
+	added := 0
+	for _, e := range events {
+		k := e.key()
+		if l.keys[k] {
+			continue
+		}
+		e.ID = l.nextID(now)
+		e.SeenAt = now
+		l.keys[k] = true
+		l.events = append(l.events, e)
+		added++
+	}
+
+	cut := 0
+	for cut < len(l.events) && now.Sub(l.events[cut].SeenAt) > eventRetention {
+		cut++
+	}
+	if over := len(l.events) - cut - maxEvents; over > 0 {
+		cut += over
+	}
+	for _, e := range l.events[:cut] {
+		delete(l.keys, e.key())
+	}
+	l.events = l.events[cut:]
+	return added
+}
+
+// since returns logged events after the given log position. Caller must
+// hold mu.
+func (l *eventLog) since(id string, types []string) []Event {
+	var out []Event
+	for _, e := range l.events {
+		if e.ID <= id {
+			continue
+		}
+		if len(types) > 0 && !containsFold(types, e.Type) {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+func (ap *ApiProvider) loadEvents() {
+	if ap.store == nil {
+		return
+	}
+	var events []Event
+	if err := ap.store.Load(eventsFile, &events); err != nil {
+		return
+	}
+	ap.events.mu.Lock()
+	defer ap.events.mu.Unlock()
+	ap.events.events = events
+	for _, e := range events {
+		ap.events.keys[e.key()] = true
+		if e.ID > ap.events.lastID {
+			ap.events.lastID = e.ID
+		}
+	}
+}
+
+// EventPollInterval returns the collection cadence; 0 means off
+func (ap *ApiProvider) EventPollInterval() time.Duration {
+	return ap.events.every
+}
+
+// RecentEvents returns logged events after the log position since (empty
+// for all), optionally limited to some types, after collecting new ones
+// when the last collection is more than a minute old
+func (ap *ApiProvider) RecentEvents(ctx context.Context, since string, types []string) ([]Event, error) {
+	ap.events.mu.Lock()
+	stale := time.Since(ap.events.lastCollect) >= eventMinCollectGap
+	ap.events.mu.Unlock()
+
+	var err error
+	if stale {
+		err = ap.CollectEvents(ctx)
+	}
+
+	ap.events.mu.Lock()
+	defer ap.events.mu.Unlock()
+	return ap.events.since(since, types), err
+}
+
+// CollectEvents polls for new events and appends them to the log
+func (ap *ApiProvider) CollectEvents(ctx context.Context) error {
+	// One collection at a time; a concurrent caller just waits for it
+	ap.events.collecting.Lock()
+	defer ap.events.collecting.Unlock()
+
+	api, err := ap.Provide()
+	if err != nil {
+		return err
+	}
+	self, err := ap.Self()
+	if err != nil {
+		return err
+	}
+
+	cutoff := fmt.Sprintf("%d", time.Now().Add(-eventLookback).Unix())
+	var found []Event
+
+	// Mentions and DMs, newest first
+	for _, query := range []string{"@" + self.User, "to:@me"} {
+		sp := slack.NewSearchParameters()
+		sp.Sort = "timestamp"
+		sp.Count = eventSearchCount
+		res, err := api.SearchMessagesContext(ctx, query, sp)
+		if err != nil {
+			log.Printf("Event collection: search %q failed: %v", query, err)
+			continue
+		}
+		for _, m := range res.Matches {
+			if m.User == self.UserID || m.Timestamp < cutoff {
+				continue
+			}
+			kind := EventMention
+			if strings.HasPrefix(m.Channel.ID, "D") {
+				kind = EventDM
+			} else if !strings.Contains(m.Text, "<@"+self.UserID+">") {
+				continue
+			}
+			found = append(found, Event{
+				Type:      kind,
+				ChannelID: m.Channel.ID,
+				User:      m.User,
+				Ts:        m.Timestamp,
+				Text:      m.Text,
+				Permalink: m.Permalink,
+			})
+		}
+	}
+
+	// Unread replies in threads the user follows
+	if ap.internalClient != nil {
+		if view, err := ap.internalClient.GetThreadView(ctx, eventThreadLimit); err == nil {
+			for _, th := range view.Threads {
+				for _, r := range th.UnreadReplies {
+					if r.Ts < cutoff {
+						continue
+					}
+					found = append(found, Event{
+						Type:      EventThreadReply,
+						ChannelID: th.RootMsg.Channel,
+						Ts:        r.Ts,
+						ThreadTs:  th.RootMsg.Ts,
+						Text:      th.RootMsg.Text,
+					})
+				}
+			}
+		} else {
+			log.Printf("Event collection: thread view failed: %v", err)
+		}
+	}
+
+	// Reactions on the user's most recent messages
+	sp := slack.NewSearchParameters()
+	sp.Sort = "timestamp"
+	sp.Count = eventReactionProbes
+	if mine, err := api.SearchMessagesContext(ctx, "from:@me", sp); err == nil {
+		for _, m := range mine.Matches {
+			if m.Timestamp < cutoff {
+				continue
+			}
+			item, err := api.GetReactionsContext(ctx, slack.NewRefToMessage(m.Channel.ID, m.Timestamp), slack.GetReactionsParameters{Full: true})
+			if err != nil {
+				continue
+			}
+			for _, r := range item.Reactions {
+				for _, u := range r.Users {
+					if u == self.UserID {
+						continue
+					}
+					found = append(found, Event{
+						Type:      EventReaction,
+						ChannelID: m.Channel.ID,
+						User:      u,
+						Ts:        m.Timestamp,
+						Text:      m.Text,
+						Reaction:  r.Name,
+						Permalink: m.Permalink,
+					})
+				}
+			}
+		}
+	}
+
+	now := time.Now()
+	ap.events.mu.Lock()
+	added := ap.events.add(now, found)
+	ap.events.lastCollect = now
+	snapshot := append([]Event(nil), ap.events.events...)
+	ap.events.mu.Unlock()
+
+	if added > 0 && ap.store != nil {
+		if err := ap.store.Save(eventsFile, snapshot); err != nil {
+			log.Printf("Failed to save event log: %v", err)
+		}
+	}
+	return nil
+}
+
+// startEventCollector polls for events on the configured cadence, holding
+// off while background refresh is paused
+func (ap *ApiProvider) startEventCollector(ctx context.Context) {
+	if ap.events.every <= 0 {
+		return
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(jittered(ap.events.every)):
+			}
+			if !ap.refreshPausedUntil().IsZero() {
+				continue
+			}
+			if err := ap.CollectEvents(ctx); err != nil {
+				log.Printf("Event collection failed: %v", err)
+			}
+		}
+	}()
+}
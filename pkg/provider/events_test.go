@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestEventLogDedupesAndOrdersIDs(t *testing.T) {
+	l := newEventLog()
+	now := time.Unix(1700000000, 0)
+
+	added := l.add(now, []Event{
+		{Type: EventMention, ChannelID: "C1", Ts: "1699999990.000200"},
+		{Type: EventDM, ChannelID: "D1", Ts: "1699999980.000100"},
+	})
+	if added != 2 {
+		t.Fatalf("added = %d, want 2", added)
+	}
+	// Same instant again: IDs must still increase
+	added = l.add(now, []Event{
+		{Type: EventMention, ChannelID: "C1", Ts: "1699999990.000200"},
+		{Type: EventReaction, ChannelID: "C1", Ts: "1699999990.000200", Reaction: "+1", User: "U2"},
+	})
+	if added != 1 {
+		t.Fatalf("duplicate was logged again: added = %d", added)
+	}
+	for i := 1; i < len(l.events); i++ {
+		if l.events[i].ID <= l.events[i-1].ID {
+			t.Fatalf("IDs not increasing: %q then %q", l.events[i-1].ID, l.events[i].ID)
+		}
+	}
+
+	first := l.events[0].ID
+	if got := l.since(first, nil); len(got) != 2 {
+		t.Fatalf("since(first) = %d events, want 2", len(got))
+	}
+	if got := l.since("", []string{"dm"}); len(got) != 1 || got[0].Type != EventDM {
+		t.Fatalf("type filter returned %+v", got)
+	}
+}
+
+func TestEventLogTrimsByAgeAndSize(t *testing.T) {
+	l := newEventLog()
+	start := time.Unix(1700000000, 0)
+	l.add(start, []Event{{Type: EventDM, ChannelID: "D1", Ts: "1699999999.000000"}})
+
+	later := start.Add(eventRetention + time.Hour)
+	var batch []Event
+	for i := 0; i < maxEvents+5; i++ {
+		batch = append(batch, Event{Type: EventMention, ChannelID: "C1", Ts: fmt.Sprintf("%d.000000", 1700000000+i)})
+	}
+	l.add(later, batch)
+	if len(l.events) != maxEvents {
+		t.Fatalf("len = %d, want %d", len(l.events), maxEvents)
+	}
+	if l.keys[(Event{Type: EventDM, ChannelID: "D1", Ts: "1699999999.000000"}).key()] {
+		t.Fatal("expired event key was kept")
+	}
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"sync"
+
+	"github.com/slack-go/slack"
+)
+
+// flightGroup coalesces concurrent lookups of the same key into a single
+// call whose result every waiter shares, so a burst of tool calls missing
+// the cache for one channel costs one API request rather than one each.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done chan struct{}
+	ch   *slack.Channel
+	err  error
+}
+
+func (g *flightGroup) do(key string, fn func() (*slack.Channel, error)) (*slack.Channel, error) {
+	g.mu.Lock()
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		<-c.done
+		return c.ch, c.err
+	}
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	c := &flightCall{done: make(chan struct{})}
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.ch, c.err = fn()
+	close(c.done)
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	return c.ch, c.err
+}
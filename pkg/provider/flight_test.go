@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+func TestFlightGroupCoalesces(t *testing.T) {
+	var g flightGroup
+	var calls atomic.Int32
+	inFlight := make(chan struct{})
+	release := make(chan struct{})
+	fetch := func() (*slack.Channel, error) {
+		if calls.Add(1) == 1 {
+			close(inFlight)
+		}
+		<-release
+		ch := testChannel("C1", "general", true)
+		return &ch, nil
+	}
+
+	const waiters = 10
+	var wg sync.WaitGroup
+	results := make([]*slack.Channel, waiters)
+	call := func(i int) {
+		defer wg.Done()
+		results[i], _ = g.do("C1", fetch)
+	}
+
+	// The first lookup is in flight before the others arrive
+	wg.Add(waiters)
+	go call(0)
+	<-inFlight
+	for i := 1; i < waiters; i++ {
+		go call(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected 1 call for %d callers, got %d", waiters, n)
+	}
+	for i, ch := range results {
+		if ch == nil || ch.ID != "C1" {
+			t.Errorf("caller %d got %v", i, ch)
+		}
+	}
+
+	// Once finished, the key is free for a fresh lookup
+	if _, err := g.do("C1", func() (*slack.Channel, error) { calls.Add(1); return nil, nil }); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.calls) != 0 {
+		t.Errorf("finished flights should be forgotten, %d left", len(g.calls))
+	}
+}
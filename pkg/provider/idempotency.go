@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Idempotency keys let an agent retry a write after a timeout without
+// doing it twice: a call that reuses a key within the window gets the
+// first call's result back instead of running again.
+//
+//	SLACK_MCP_IDEMPOTENCY_WINDOW="24h"   how long keys are remembered (default 24h; "off" ignores keys)
+const (
+	idempotencyFile          = "idempotency.json"
+	defaultIdempotencyWindow = 24 * time.Hour
+	maxIdempotencyRecords    = 1000
+)
+
+// idempotencyRecord is the outcome of one keyed call
+type idempotencyRecord struct {
+	Fingerprint string          `json:"fingerprint"` // Identifies the call's arguments
+	Result      json.RawMessage `json:"result"`
+	At          time.Time       `json:"at"`
+}
+
+type idempotencyLog struct {
+	mu       sync.Mutex
+	window   time.Duration
+	records  map[string]*idempotencyRecord // By tool and key
+	inflight map[string]chan struct{}
+}
+
+func newIdempotencyLog() *idempotencyLog {
+	return &idempotencyLog{
+		window:   loadRefreshInterval("SLACK_MCP_IDEMPOTENCY_WINDOW", defaultIdempotencyWindow),
+		records:  make(map[string]*idempotencyRecord),
+		inflight: make(map[string]chan struct{}),
+	}
+}
+
+func (ap *ApiProvider) loadIdempotency() {
+	if ap.store == nil {
+		return
+	}
+	records := map[string]*idempotencyRecord{}
+	if err := ap.store.Load(idempotencyFile, &records); err != nil {
+		return
+	}
+	ap.idempotency.mu.Lock()
+	ap.idempotency.records = records
+	ap.idempotency.prune(time.Now())
+	ap.idempotency.mu.Unlock()
+}
+
+// prune drops expired records, then the oldest beyond the cap. Caller
+// must hold mu.
+func (l *idempotencyLog) prune(now time.Time) {
+	for k, r := range l.records {
+		if now.Sub(r.At) > l.window {
+			delete(l.records, k)
+		}
+	}
+	for len(l.records) > maxIdempotencyRecords {
+		var oldestKey string
+		var oldest time.Time
+		for k, r := range l.records {
+			if oldestKey == "" || r.At.Before(oldest) {
+				oldestKey, oldest = k, r.At
+			}
+		}
+		delete(l.records, oldestKey)
+	}
+}
+
+// IdempotencyEnabled reports whether idempotency keys are honoured
+func (ap *ApiProvider) IdempotencyEnabled() bool {
+	return ap.idempotency != nil && ap.idempotency.window > 0
+}
+
+// BeginIdempotent claims a tool call's idempotency key. When the key was
+// already used for the same call within the window, replay holds that
+// call's result. Otherwise the caller runs the call and must call done
+// exactly once with the result to remember, or nil to remember nothing.
+// A call still running under the key is waited for. Reusing a key for
+// different arguments is an error.
+func (ap *ApiProvider) BeginIdempotent(ctx context.Context, tool, key, fingerprint string) (replay json.RawMessage, done func(json.RawMessage), err error) {
+	l := ap.idempotency
+	id := tool + "/" + key
+	for {
+		l.mu.Lock()
+		if r, ok := l.records[id]; ok && time.Since(r.At) <= l.window {
+			l.mu.Unlock()
+			if r.Fingerprint != fingerprint {
+				return nil, nil, fmt.Errorf("idempotencyKey %q was already used for a different %s call", key, tool)
+			}
+			return r.Result, nil, nil
+		}
+		wait, running := l.inflight[id]
+		if !running {
+			finished := make(chan struct{})
+			l.inflight[id] = finished
+			l.mu.Unlock()
+			var once sync.Once
+			return nil, func(result json.RawMessage) {
+				once.Do(func() { ap.finishIdempotent(id, fingerprint, result, finished) })
+			}, nil
+		}
+		l.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return nil, nil, fmt.Errorf("an earlier %s call with idempotencyKey %q is still running", tool, key)
+		}
+	}
+}
+
+func (ap *ApiProvider) finishIdempotent(id, fingerprint string, result json.RawMessage, finished chan struct{}) {
+	l := ap.idempotency
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.inflight, id)
+	close(finished)
+	if result == nil {
+		return
+	}
+	l.records[id] = &idempotencyRecord{Fingerprint: fingerprint, Result: result, At: time.Now()}
+	l.prune(time.Now())
+	if ap.store != nil {
+		if err := ap.store.Save(idempotencyFile, l.records); err != nil {
+			log.Printf("Failed to save idempotency keys: %v", err)
+		}
+	}
+}
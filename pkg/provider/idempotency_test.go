@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func testIdempotencyProvider() *ApiProvider {
+	l := newIdempotencyLog()
+	l.window = time.Hour
+	return &ApiProvider{idempotency: l}
+}
+
+func TestBeginIdempotentReplays(t *testing.T) {
+	ap := testIdempotencyProvider()
+	ctx := context.Background()
+
+	replay, done, err := ap.BeginIdempotent(ctx, "send-message", "k1", "fp")
+	if err != nil || replay != nil || done == nil {
+		t.Fatalf("first call should run: replay=%s err=%v", replay, err)
+	}
+	done(json.RawMessage(`{"success":true}`))
+
+	replay, done, err = ap.BeginIdempotent(ctx, "send-message", "k1", "fp")
+	if err != nil || done != nil || string(replay) != `{"success":true}` {
+		t.Fatalf("retry should replay: replay=%s err=%v", replay, err)
+	}
+
+	// The same key for different arguments is refused
+	if _, _, err := ap.BeginIdempotent(ctx, "send-message", "k1", "other"); err == nil {
+		t.Fatal("expected a reused key with different arguments to fail")
+	}
+	// Keys are per tool
+	if replay, done, _ := ap.BeginIdempotent(ctx, "react", "k1", "fp"); replay != nil || done == nil {
+		t.Fatal("a key used by another tool should run")
+	} else {
+		done(nil)
+	}
+}
+
+func TestBeginIdempotentForgetsFailures(t *testing.T) {
+	ap := testIdempotencyProvider()
+	ctx := context.Background()
+
+	_, done, _ := ap.BeginIdempotent(ctx, "send-message", "k1", "fp")
+	done(nil)
+	if replay, done, _ := ap.BeginIdempotent(ctx, "send-message", "k1", "fp"); replay != nil || done == nil {
+		t.Fatal("a call that recorded nothing should run again")
+	}
+}
+
+func TestBeginIdempotentWaitsForRunningCall(t *testing.T) {
+	ap := testIdempotencyProvider()
+
+	_, done, _ := ap.BeginIdempotent(context.Background(), "send-message", "k1", "fp")
+
+	// A retry gives up when its context ends while the first call runs
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err := ap.BeginIdempotent(ctx, "send-message", "k1", "fp"); err == nil {
+		t.Fatal("expected the retry to report the running call")
+	}
+
+	got := make(chan json.RawMessage)
+	go func() {
+		replay, _, _ := ap.BeginIdempotent(context.Background(), "send-message", "k1", "fp")
+		got <- replay
+	}()
+	time.Sleep(10 * time.Millisecond)
+	done(json.RawMessage(`{"success":true}`))
+	if replay := <-got; string(replay) != `{"success":true}` {
+		t.Fatalf("waiting retry should replay, got %s", replay)
+	}
+}
+
+func TestIdempotencyPrune(t *testing.T) {
+	l := newIdempotencyLog()
+	l.window = time.Hour
+	now := time.Now()
+	l.records["send-message/old"] = &idempotencyRecord{At: now.Add(-2 * time.Hour)}
+	l.records["send-message/new"] = &idempotencyRecord{At: now}
+	l.prune(now)
+	if _, ok := l.records["send-message/old"]; ok || len(l.records) != 1 {
+		t.Fatalf("expired record not pruned: %v", l.records)
+	}
+}
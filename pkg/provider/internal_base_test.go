@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestSetBaseURL(t *testing.T) {
+	c := NewInternalClient("xoxc-test", "xoxd-test")
+	if c.BaseURL() != "https://slack.com" {
+		t.Fatalf("default base = %q", c.BaseURL())
+	}
+	if err := c.SetBaseURL("https://acme.slack.com/"); err != nil {
+		t.Fatal(err)
+	}
+	if c.BaseURL() != "https://acme.slack.com" {
+		t.Errorf("base = %q, want https://acme.slack.com", c.BaseURL())
+	}
+	for _, bad := range []string{"http://acme.slack.com/", "https://slack.com.evil.test/", "https://evilslack.com/", ""} {
+		if err := c.SetBaseURL(bad); err == nil {
+			t.Errorf("SetBaseURL(%q) accepted", bad)
+		}
+	}
+	if c.BaseURL() != "https://acme.slack.com" {
+		t.Errorf("rejected URL changed base to %q", c.BaseURL())
+	}
+}
+
+func TestInternalBaseURL(t *testing.T) {
+	t.Setenv("SLACK_MCP_ENTERPRISE_URL", "https://acme.enterprise.slack.com/")
+	team := &slack.AuthTestResponse{URL: "https://acme.slack.com/"}
+	if got := internalBaseURL(team); got != team.URL {
+		t.Errorf("non-Grid workspace got %q", got)
+	}
+	grid := &slack.AuthTestResponse{URL: "https://acme-eng.slack.com/", EnterpriseID: "E123"}
+	if got := internalBaseURL(grid); got != "https://acme.enterprise.slack.com/" {
+		t.Errorf("Grid workspace got %q", got)
+	}
+	t.Setenv("SLACK_MCP_ENTERPRISE_URL", "")
+	if got := internalBaseURL(grid); got != grid.URL {
+		t.Errorf("Grid without override got %q", got)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -18,9 +20,27 @@ type InternalClient struct {
 	httpClient *http.Client
 	xoxcToken  string
 	xoxdToken  string
-	baseURL    string
+	usage      *UsageTracker
+	schema     *schemaMonitor
+
+	// https://slack.com until boot learns the team's own URL
+	baseMu  sync.RWMutex
+	baseURL string
+
+	// Last successful client.counts, for cache-only reads. Live reads
+	// reuse it until countsFresh passes or a mark invalidates it.
+	countsMu     sync.Mutex
+	lastCounts   *ClientCountsResponse
+	lastCountsAt time.Time
+	countsFresh  time.Time
+	countsGen    int
+	countsFetch  chan struct{}
 }
 
+// countsTTL is how long a client.counts response answers live reads, so
+// the features a triage session runs back to back share one call
+const countsTTL = 10 * time.Second
+
 // NewInternalClient creates a client for internal Slack endpoints
 func NewInternalClient(xoxcToken, xoxdToken string) *InternalClient {
 	return &InternalClient{
@@ -29,10 +49,35 @@ func NewInternalClient(xoxcToken, xoxdToken string) *InternalClient {
 		},
 		xoxcToken: xoxcToken,
 		xoxdToken: xoxdToken,
+		schema:    newSchemaMonitor(),
 		baseURL:   "https://slack.com",
 	}
 }
 
+// SetBaseURL points internal calls at the team's (or on Enterprise Grid,
+// the organization's) own URL, as the web client does after auth.test.
+// Anything that isn't an https Slack URL is ignored.
+func (c *InternalClient) SetBaseURL(rawURL string) error {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return fmt.Errorf("invalid team URL: %w", err)
+	}
+	if u.Scheme != "https" || (u.Hostname() != "slack.com" && !isSlackSubdomain(u.Hostname())) {
+		return fmt.Errorf("refusing non-Slack team URL %q", rawURL)
+	}
+	c.baseMu.Lock()
+	c.baseURL = "https://" + u.Host
+	c.baseMu.Unlock()
+	return nil
+}
+
+// BaseURL returns where internal calls go
+func (c *InternalClient) BaseURL() string {
+	c.baseMu.RLock()
+	defer c.baseMu.RUnlock()
+	return c.baseURL
+}
+
 // ClientCountsResponse represents the response from /api/client.counts
 type ClientCountsResponse struct {
 	OK    bool   `json:"ok"`
@@ -102,11 +147,66 @@ type ClientCountsResponse struct {
 	CountsLastFetched int64 `json:"counts_last_fetched"`
 }
 
-// GetClientCounts fetches unread counts using the internal client.counts endpoint
+// GetClientCounts fetches unread counts using the internal client.counts
+// endpoint. A response under countsTTL old is reused, and concurrent
+// callers share one fetch. Callers must not modify the result.
 func (c *InternalClient) GetClientCounts(ctx context.Context) (*ClientCountsResponse, error) {
-	result := &ClientCountsResponse{}
-	err := c.callInternalAPI(ctx, "/api/client.counts", nil, result)
-	return result, err
+	for {
+		c.countsMu.Lock()
+		if c.lastCounts != nil && time.Now().Before(c.countsFresh) {
+			result := c.lastCounts
+			c.countsMu.Unlock()
+			return result, nil
+		}
+		if wait := c.countsFetch; wait != nil {
+			c.countsMu.Unlock()
+			select {
+			case <-wait:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		done := make(chan struct{})
+		c.countsFetch = done
+		gen := c.countsGen
+		c.countsMu.Unlock()
+
+		result := &ClientCountsResponse{}
+		err := c.callInternalAPI(ctx, "/api/client.counts", nil, result)
+
+		c.countsMu.Lock()
+		if err == nil && result.OK {
+			c.lastCounts = result
+			c.lastCountsAt = time.Now()
+			// A mark during the fetch may not be reflected in it
+			if gen == c.countsGen {
+				c.countsFresh = c.lastCountsAt.Add(countsTTL)
+			}
+		}
+		c.countsFetch = nil
+		close(done)
+		c.countsMu.Unlock()
+		return result, err
+	}
+}
+
+// InvalidateCounts makes the next GetClientCounts fetch afresh, after
+// something changed what's unread. CachedCounts still returns the old
+// response.
+func (c *InternalClient) InvalidateCounts() {
+	c.countsMu.Lock()
+	c.countsFresh = time.Time{}
+	c.countsGen++
+	c.countsMu.Unlock()
+}
+
+// CachedCounts returns the last successful client.counts response and when
+// it was fetched, or nil if there hasn't been one
+func (c *InternalClient) CachedCounts() (*ClientCountsResponse, time.Time) {
+	c.countsMu.Lock()
+	defer c.countsMu.Unlock()
+	return c.lastCounts, c.lastCountsAt
 }
 
 // ClientBootResponse represents a subset of the /api/client.boot response
@@ -120,19 +220,28 @@ type ClientBootResponse struct {
 	} `json:"self"`
 
 	Team struct {
-		ID     string `json:"id"`
-		Name   string `json:"name"`
-		Domain string `json:"domain"`
+		ID     string          `json:"id"`
+		Name   string          `json:"name"`
+		Domain string          `json:"domain"`
+		Prefs  json.RawMessage `json:"prefs,omitempty"`
 	} `json:"team"`
 
 	// Channels with unread info
 	Channels []struct {
-		ID                 string          `json:"id"`
-		Name               string          `json:"name"`
-		IsChannel          bool            `json:"is_channel"`
-		IsGroup            bool            `json:"is_group"`
-		IsIM               bool            `json:"is_im"`
-		IsMpim             bool            `json:"is_mpim"`
+		ID         string `json:"id"`
+		Name       string `json:"name"`
+		IsChannel  bool   `json:"is_channel"`
+		IsGroup    bool   `json:"is_group"`
+		IsIM       bool   `json:"is_im"`
+		IsMpim     bool   `json:"is_mpim"`
+		IsPrivate  bool   `json:"is_private"`
+		IsArchived bool   `json:"is_archived"`
+		Topic      struct {
+			Value string `json:"value"`
+		} `json:"topic"`
+		Purpose struct {
+			Value string `json:"value"`
+		} `json:"purpose"`
 		UnreadCount        int             `json:"unread_count"`
 		UnreadCountDisplay int             `json:"unread_count_display"`
 		LastRead           string          `json:"last_read"`
@@ -162,6 +271,183 @@ func (c *InternalClient) GetClientBoot(ctx context.Context) (*ClientBootResponse
 	return result, err
 }
 
+// ThreadViewResponse represents the response from /api/subscriptions.thread.getView
+type ThreadViewResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+
+	Threads []struct {
+		RootMsg struct {
+			Channel    string `json:"channel"`
+			Ts         string `json:"ts"`
+			User       string `json:"user"`
+			Text       string `json:"text"`
+			ReplyCount int    `json:"reply_count"`
+		} `json:"root_msg"`
+		LatestReplies []ThreadReply `json:"latest_replies"`
+		UnreadReplies []ThreadReply `json:"unread_replies"`
+	} `json:"threads"`
+
+	HasMore            bool `json:"has_more"`
+	TotalUnreadReplies int  `json:"total_unread_replies"`
+}
+
+// ThreadReply is a reply as the Threads view lists it
+type ThreadReply struct {
+	Ts   string `json:"ts"`
+	User string `json:"user"`
+	Text string `json:"text"`
+}
+
+// GetThreadView fetches the subscribed threads shown in Slack's Threads view,
+// most recently active first
+func (c *InternalClient) GetThreadView(ctx context.Context, limit int) (*ThreadViewResponse, error) {
+	params := url.Values{
+		"limit":      {fmt.Sprintf("%d", limit)},
+		"current_ts": {fmt.Sprintf("%d", time.Now().Unix())},
+	}
+
+	result := &ThreadViewResponse{}
+	err := c.callInternalAPI(ctx, "/api/subscriptions.thread.getView", params, result)
+	if err == nil && !result.OK {
+		err = fmt.Errorf("subscriptions.thread.getView: %s", result.Error)
+	}
+	return result, err
+}
+
+// MarkThreadRead moves a thread subscription's read pointer to ts, which
+// clears its unread badge independently of the channel's read pointer
+func (c *InternalClient) MarkThreadRead(ctx context.Context, channelID, threadTs, ts string) error {
+	params := url.Values{
+		"channel":   {channelID},
+		"thread_ts": {threadTs},
+		"ts":        {ts},
+		"read":      {"1"},
+	}
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error,omitempty"`
+	}
+	defer c.InvalidateCounts()
+	if err := c.callInternalAPI(ctx, "/api/subscriptions.thread.mark", params, &result); err != nil {
+		return err
+	}
+	if !result.OK {
+		return fmt.Errorf("subscriptions.thread.mark: %s", result.Error)
+	}
+	return nil
+}
+
+// Conversation kinds, which decide the legacy mark endpoint to fall back on
+const (
+	MarkChannel = "channel" // Public or private channel
+	MarkIM      = "im"
+	MarkMPIM    = "mpim"
+)
+
+// MarkTarget is a conversation to mark read up to Ts
+type MarkTarget struct {
+	ChannelID string
+	Ts        string
+	Kind      string
+}
+
+// MarkResult is the outcome of marking one conversation
+type MarkResult struct {
+	MarkTarget
+	Err error
+}
+
+// markWorkers bounds concurrent mark calls in MarkConversations
+const markWorkers = 4
+
+// legacyMarkEndpoints are the per-type endpoints Slack still serves for
+// conversations conversations.mark rejects, tried in order
+var legacyMarkEndpoints = map[string][]string{
+	MarkChannel: {"/api/channels.mark", "/api/groups.mark"},
+	MarkIM:      {"/api/im.mark"},
+	MarkMPIM:    {"/api/mpim.mark"},
+}
+
+// markRejected reports whether conversations.mark turned a conversation
+// down because of its type, rather than failing outright
+func markRejected(code string) bool {
+	switch code {
+	case "method_not_supported_for_channel_type", "invalid_channel_type", "channel_not_found", "not_in_channel", "method_deprecated":
+		return true
+	}
+	return false
+}
+
+// MarkConversation moves a conversation's read pointer to ts with
+// conversations.mark, falling back to the endpoint for its kind when
+// conversations.mark rejects that kind
+func (c *InternalClient) MarkConversation(ctx context.Context, target MarkTarget) error {
+	code, err := c.mark(ctx, "/api/conversations.mark", target)
+	if err != nil || code == "" {
+		return err
+	}
+	if !markRejected(code) {
+		return fmt.Errorf("conversations.mark: %s", code)
+	}
+	first := code
+	for _, endpoint := range legacyMarkEndpoints[target.Kind] {
+		code, err = c.mark(ctx, endpoint, target)
+		if err != nil || code == "" {
+			return err
+		}
+	}
+	return fmt.Errorf("conversations.mark: %s", first)
+}
+
+// mark calls one mark endpoint, returning Slack's error code if it refused
+func (c *InternalClient) mark(ctx context.Context, endpoint string, target MarkTarget) (string, error) {
+	params := url.Values{
+		"channel": {target.ChannelID},
+		"ts":      {target.Ts},
+	}
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error,omitempty"`
+	}
+	defer c.InvalidateCounts()
+	if err := c.callInternalAPI(ctx, endpoint, params, &result); err != nil {
+		return "", err
+	}
+	if !result.OK {
+		if result.Error == "" {
+			return "unknown_error", nil
+		}
+		return result.Error, nil
+	}
+	return "", nil
+}
+
+// MarkConversations marks many conversations read. Slack's client has no
+// bulk mark, so they're marked a few at a time. Results are in the order
+// of targets.
+func (c *InternalClient) MarkConversations(ctx context.Context, targets []MarkTarget) []MarkResult {
+	results := make([]MarkResult, len(targets))
+	var wg sync.WaitGroup
+	next := make(chan int)
+	for w := 0; w < markWorkers && w < len(targets); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = MarkResult{MarkTarget: targets[i], Err: c.MarkConversation(ctx, targets[i])}
+			}
+		}()
+	}
+	for i := range targets {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return results
+}
+
 // SearchModulesResponse represents search results from internal search
 type SearchModulesResponse struct {
 	OK    bool   `json:"ok"`
@@ -213,8 +499,10 @@ func (c *InternalClient) SearchMessages(ctx context.Context, query string, extra
 
 // callInternalAPI is a helper to call internal Slack endpoints
 func (c *InternalClient) callInternalAPI(ctx context.Context, endpoint string, params url.Values, result interface{}) error {
+	c.usage.recordInternalCall(endpoint)
+
 	// Build URL
-	u, err := url.Parse(c.baseURL + endpoint)
+	u, err := url.Parse(c.BaseURL() + endpoint)
 	if err != nil {
 		return fmt.Errorf("invalid endpoint: %w", err)
 	}
@@ -256,12 +544,7 @@ func (c *InternalClient) callInternalAPI(ctx context.Context, endpoint string, p
 		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
 	}
 
-	// Parse JSON
-	if err := json.Unmarshal(body, result); err != nil {
-		return fmt.Errorf("parsing response: %w", err)
-	}
-
-	return nil
+	return c.decode(ctx, endpoint, body, result)
 }
 
 // MaxDownloadBytes caps how much a single DownloadFile call will read.
@@ -333,6 +616,8 @@ func isSlackSubdomain(host string) bool {
 
 // PostInternalAPI calls internal endpoints with POST method
 func (c *InternalClient) PostInternalAPI(ctx context.Context, endpoint string, payload interface{}, result interface{}) error {
+	c.usage.recordInternalCall(endpoint)
+
 	// Encode payload
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
@@ -340,7 +625,7 @@ func (c *InternalClient) PostInternalAPI(ctx context.Context, endpoint string, p
 	}
 
 	// Build URL
-	u := c.baseURL + endpoint
+	u := c.BaseURL() + endpoint
 
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, "POST", u, bytes.NewReader(jsonData))
@@ -375,10 +660,5 @@ func (c *InternalClient) PostInternalAPI(ctx context.Context, endpoint string, p
 		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
 	}
 
-	// Parse JSON
-	if err := json.Unmarshal(body, result); err != nil {
-		return fmt.Errorf("parsing response: %w", err)
-	}
-
-	return nil
+	return c.decode(ctx, endpoint, body, result)
 }
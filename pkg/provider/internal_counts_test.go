@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// countsServer answers client.counts and mark calls, counting the former
+func countsServer(t *testing.T) (*InternalClient, *atomic.Int32) {
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/client.counts" {
+			fetches.Add(1)
+		}
+		fmt.Fprint(w, `{"ok":true}`)
+	}))
+	t.Cleanup(srv.Close)
+	c := NewInternalClient("xoxc-test", "xoxd-test")
+	c.baseURL = srv.URL
+	return c, &fetches
+}
+
+func TestClientCountsReusedWhileFresh(t *testing.T) {
+	c, fetches := countsServer(t)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if counts, err := c.GetClientCounts(ctx); err != nil || !counts.OK {
+				t.Errorf("GetClientCounts = %+v, %v", counts, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if _, err := c.GetClientCounts(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("client.counts fetched %d times, want 1", n)
+	}
+}
+
+func TestClientCountsInvalidatedByMark(t *testing.T) {
+	c, fetches := countsServer(t)
+	ctx := context.Background()
+
+	c.GetClientCounts(ctx)
+	if err := c.MarkConversation(ctx, MarkTarget{ChannelID: "C1", Ts: "1.0", Kind: MarkChannel}); err != nil {
+		t.Fatal(err)
+	}
+	if counts, _ := c.CachedCounts(); counts == nil {
+		t.Error("invalidation dropped the cached response for cache-only reads")
+	}
+	c.GetClientCounts(ctx)
+	if n := fetches.Load(); n != 2 {
+		t.Errorf("client.counts fetched %d times, want 2 after marking", n)
+	}
+}
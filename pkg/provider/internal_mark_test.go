@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// markServer answers mark calls, refusing conversations.mark for the
+// channels in rejected, and records which endpoint marked what
+func markServer(t *testing.T, rejected map[string]string) (*InternalClient, func() map[string]string) {
+	var mu sync.Mutex
+	marked := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ch := r.URL.Query().Get("channel")
+		if code, ok := rejected[ch]; ok && r.URL.Path == "/api/conversations.mark" {
+			fmt.Fprintf(w, `{"ok":false,"error":%q}`, code)
+			return
+		}
+		mu.Lock()
+		marked[ch] = r.URL.Path
+		mu.Unlock()
+		fmt.Fprint(w, `{"ok":true}`)
+	}))
+	t.Cleanup(srv.Close)
+	c := NewInternalClient("xoxc-test", "xoxd-test")
+	c.baseURL = srv.URL
+	return c, func() map[string]string {
+		mu.Lock()
+		defer mu.Unlock()
+		return marked
+	}
+}
+
+func TestMarkConversationFallsBackByKind(t *testing.T) {
+	c, marked := markServer(t, map[string]string{
+		"D1": "method_not_supported_for_channel_type",
+		"C2": "ratelimited",
+	})
+	ctx := context.Background()
+
+	if err := c.MarkConversation(ctx, MarkTarget{ChannelID: "C1", Ts: "1.0", Kind: MarkChannel}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.MarkConversation(ctx, MarkTarget{ChannelID: "D1", Ts: "1.0", Kind: MarkIM}); err != nil {
+		t.Fatal(err)
+	}
+	// Other refusals aren't retried elsewhere
+	if err := c.MarkConversation(ctx, MarkTarget{ChannelID: "C2", Ts: "1.0", Kind: MarkChannel}); err == nil {
+		t.Fatal("expected ratelimited to fail")
+	}
+
+	got := marked()
+	if got["C1"] != "/api/conversations.mark" || got["D1"] != "/api/im.mark" {
+		t.Fatalf("marked = %v", got)
+	}
+	if _, ok := got["C2"]; ok {
+		t.Fatal("C2 shouldn't have been marked")
+	}
+}
+
+func TestMarkConversationsKeepsOrder(t *testing.T) {
+	c, _ := markServer(t, map[string]string{"C3": "channel_not_found"})
+	var targets []MarkTarget
+	for i := 0; i < 10; i++ {
+		targets = append(targets, MarkTarget{ChannelID: fmt.Sprintf("C%d", i), Ts: "1.0", Kind: MarkMPIM})
+	}
+	results := c.MarkConversations(context.Background(), targets)
+	for i, r := range results {
+		if r.ChannelID != targets[i].ChannelID || r.Err != nil {
+			t.Fatalf("result %d = %+v", i, r)
+		}
+	}
+}
@@ -0,0 +1,388 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Slack changes internal response shapes without notice. Responses are
+// decoded tolerantly: a field of an unexpected type is skipped and the rest
+// kept, and what didn't match is recorded for debug-internal. With
+// SLACK_MCP_DEBUG on, every response is also checked for fields the client
+// doesn't model, and the last raw payload of each endpoint is kept.
+
+const (
+	// maxSchemaIssues bounds the issues kept since startup
+	maxSchemaIssues = 200
+	// maxUnknownFields bounds the unmodeled fields reported per response
+	maxUnknownFields = 25
+	// maxCaptureBytes bounds one captured raw payload
+	maxCaptureBytes = 2 << 20
+)
+
+// Schema issue kinds
+const (
+	SchemaTypeMismatch = "type-mismatch"
+	SchemaUnparseable  = "unparseable"
+	SchemaUnknownField = "unknown-field"
+)
+
+// SchemaIssue is one way an internal response didn't match what the client
+// expects
+type SchemaIssue struct {
+	Endpoint  string    `json:"endpoint"`
+	Kind      string    `json:"kind"`
+	Detail    string    `json:"detail"`
+	Count     int       `json:"count"`
+	FirstSeen time.Time `json:"firstSeen"`
+	LastSeen  time.Time `json:"lastSeen"`
+}
+
+// SchemaError reports an internal response that couldn't be parsed at all
+type SchemaError struct {
+	Endpoint string
+	Err      error
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%s returned a response in an unexpected format (Slack may have changed it; debug-internal dump=true shows what came back): %v",
+		strings.TrimPrefix(e.Endpoint, "/api/"), e.Err)
+}
+
+func (e *SchemaError) Unwrap() error { return e.Err }
+
+// RawCapture is a raw internal response kept for diagnosis
+type RawCapture struct {
+	Endpoint string    `json:"endpoint"`
+	At       time.Time `json:"at"`
+	Body     []byte    `json:"-"`
+}
+
+// schemaMonitor records what internal responses looked like
+type schemaMonitor struct {
+	mu       sync.Mutex
+	debug    bool
+	issues   map[string]*SchemaIssue
+	captures map[string]RawCapture
+}
+
+func newSchemaMonitor() *schemaMonitor {
+	return &schemaMonitor{
+		debug:    debugEnabled(),
+		issues:   make(map[string]*SchemaIssue),
+		captures: make(map[string]RawCapture),
+	}
+}
+
+// debugEnabled reports whether SLACK_MCP_DEBUG is on
+func debugEnabled() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("SLACK_MCP_DEBUG"))) {
+	case "on", "true", "1", "yes":
+		return true
+	}
+	return false
+}
+
+// captureKey marks a context whose responses are captured whatever the
+// debug setting, for debug-internal dumps
+type captureKey struct{}
+
+func withCapture(ctx context.Context) context.Context {
+	return context.WithValue(ctx, captureKey{}, true)
+}
+
+func (m *schemaMonitor) verbose(ctx context.Context) bool {
+	forced, _ := ctx.Value(captureKey{}).(bool)
+	return m.debug || forced
+}
+
+// record notes an issue, logging it the first time it's seen
+func (m *schemaMonitor) record(endpoint, kind, detail string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := endpoint + "\x00" + kind + "\x00" + detail
+	now := time.Now()
+	if issue, ok := m.issues[key]; ok {
+		issue.Count++
+		issue.LastSeen = now
+		return
+	}
+	if len(m.issues) >= maxSchemaIssues {
+		return
+	}
+	m.issues[key] = &SchemaIssue{Endpoint: endpoint, Kind: kind, Detail: detail, Count: 1, FirstSeen: now, LastSeen: now}
+	log.Printf("Internal API schema drift on %s (%s): %s", endpoint, kind, detail)
+}
+
+func (m *schemaMonitor) capture(endpoint string, body []byte) {
+	if len(body) > maxCaptureBytes {
+		body = body[:maxCaptureBytes]
+	}
+	m.mu.Lock()
+	m.captures[endpoint] = RawCapture{Endpoint: endpoint, At: time.Now(), Body: append([]byte(nil), body...)}
+	m.mu.Unlock()
+}
+
+// decode unmarshals an internal response into result. A field with an
+// unexpected type is left at its zero value and recorded rather than
+// failing the call; only a body that isn't JSON at all is an error.
+func (c *InternalClient) decode(ctx context.Context, endpoint string, body []byte, result interface{}) error {
+	m := c.schema
+	verbose := m.verbose(ctx)
+	if verbose {
+		m.capture(endpoint, body)
+	}
+
+	err := json.Unmarshal(body, result)
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case err == nil:
+	case errors.As(err, &typeErr):
+		// Unmarshal fills everything it can before reporting the first
+		// mismatch, so the rest of the response is usable
+		field := typeErr.Field
+		if field == "" {
+			field = "(top level)"
+		}
+		m.record(endpoint, SchemaTypeMismatch, fmt.Sprintf("%s is a JSON %s, expected %s", field, typeErr.Value, typeErr.Type))
+	default:
+		m.record(endpoint, SchemaUnparseable, err.Error())
+		return &SchemaError{Endpoint: endpoint, Err: err}
+	}
+
+	if verbose {
+		var raw interface{}
+		if json.Unmarshal(body, &raw) == nil {
+			var unknown []string
+			unknownFields(raw, reflect.TypeOf(result), "", &unknown)
+			for _, f := range unknown {
+				m.record(endpoint, SchemaUnknownField, f)
+			}
+		}
+	}
+	return nil
+}
+
+// unknownFields collects paths in raw that t has no field for, stopping at
+// maxUnknownFields. Array elements are checked against the element type
+// and reported as name[].
+func unknownFields(raw interface{}, t reflect.Type, path string, out *[]string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if len(*out) >= maxUnknownFields {
+		return
+	}
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Map:
+			for k, child := range v {
+				unknownFields(child, t.Elem(), path+"."+k, out)
+			}
+		case reflect.Struct:
+			if t == reflect.TypeOf(time.Time{}) {
+				return
+			}
+			fields := jsonFields(t)
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				ft, ok := fields[strings.ToLower(k)]
+				if !ok {
+					if len(*out) < maxUnknownFields {
+						*out = append(*out, strings.TrimPrefix(path+"."+k, "."))
+					}
+					continue
+				}
+				unknownFields(v[k], ft, path+"."+k, out)
+			}
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return
+		}
+		// Report each unknown path once, not once per element
+		seen := map[string]bool{}
+		for _, item := range v {
+			var found []string
+			unknownFields(item, t.Elem(), path+"[]", &found)
+			for _, f := range found {
+				if !seen[f] && len(*out) < maxUnknownFields {
+					seen[f] = true
+					*out = append(*out, f)
+				}
+			}
+		}
+	}
+}
+
+// jsonFields maps a struct's JSON field names, lowercased as encoding/json
+// matches them, to their types. Embedded structs contribute their fields.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for k, v := range jsonFields(ft) {
+					fields[k] = v
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+	return fields
+}
+
+// SchemaIssues returns the schema issues seen since startup, most recent
+// first
+func (c *InternalClient) SchemaIssues() []SchemaIssue {
+	m := c.schema
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	issues := make([]SchemaIssue, 0, len(m.issues))
+	for _, issue := range m.issues {
+		issues = append(issues, *issue)
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].LastSeen.After(issues[j].LastSeen) })
+	return issues
+}
+
+// SchemaDebug reports whether raw payloads are captured on every call
+func (c *InternalClient) SchemaDebug() bool {
+	return c.schema.debug
+}
+
+// Captures returns the raw payloads kept, by endpoint
+func (c *InternalClient) Captures() []RawCapture {
+	m := c.schema
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	captures := make([]RawCapture, 0, len(m.captures))
+	for _, capture := range m.captures {
+		captures = append(captures, capture)
+	}
+	sort.Slice(captures, func(i, j int) bool { return captures[i].Endpoint < captures[j].Endpoint })
+	return captures
+}
+
+// ProbeResult is how one read-only internal endpoint answered a probe
+type ProbeResult struct {
+	Endpoint string
+	Error    string
+	Shape    map[string]string
+}
+
+// ProbeSchemas calls the read-only internal endpoints with capture on, so
+// their current shapes and any drift can be inspected
+func (c *InternalClient) ProbeSchemas(ctx context.Context) []ProbeResult {
+	ctx = withCapture(ctx)
+	probes := []struct {
+		endpoint string
+		call     func() error
+	}{
+		{"/api/client.counts", func() error { _, err := c.GetClientCounts(ctx); return err }},
+		{"/api/client.boot", func() error { _, err := c.GetClientBoot(ctx); return err }},
+		{"/api/subscriptions.thread.getView", func() error { _, err := c.GetThreadView(ctx, 5); return err }},
+	}
+
+	results := make([]ProbeResult, 0, len(probes))
+	for _, p := range probes {
+		r := ProbeResult{Endpoint: p.endpoint}
+		if err := p.call(); err != nil {
+			r.Error = err.Error()
+		}
+		c.schema.mu.Lock()
+		capture, ok := c.schema.captures[p.endpoint]
+		c.schema.mu.Unlock()
+		if ok {
+			r.Shape = topLevelShape(capture.Body)
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// topLevelShape lists a payload's top-level keys and their JSON types
+func topLevelShape(body []byte) map[string]string {
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(body, &obj) != nil {
+		return nil
+	}
+	shape := make(map[string]string, len(obj))
+	for k, v := range obj {
+		shape[k] = jsonKind(v)
+	}
+	return shape
+}
+
+func jsonKind(v json.RawMessage) string {
+	s := strings.TrimSpace(string(v))
+	if s == "" {
+		return "empty"
+	}
+	switch s[0] {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case '"':
+		return "string"
+	case 't', 'f':
+		return "boolean"
+	case 'n':
+		return "null"
+	}
+	return "number"
+}
+
+// WriteCaptures saves the kept raw payloads as files in dir, one per
+// endpoint, readable only by the owner. They hold message content.
+func (c *InternalClient) WriteCaptures(dir string) ([]string, error) {
+	captures := c.Captures()
+	if len(captures) == 0 {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, capture := range captures {
+		name := strings.ReplaceAll(strings.TrimPrefix(capture.Endpoint, "/api/"), "/", "_") + ".json"
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, capture.Body, 0600); err != nil {
+			return files, err
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
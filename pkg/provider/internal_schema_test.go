@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestDecodeKeepsRestOnTypeMismatch(t *testing.T) {
+	c := NewInternalClient("xoxc-test", "xoxd-test")
+	var counts ClientCountsResponse
+	body := []byte(`{"ok":true,"channels":[{"id":"C1","mention_count":"2","has_unreads":true}],"ims":[{"id":"D1"}]}`)
+	if err := c.decode(context.Background(), "/api/client.counts", body, &counts); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if !counts.OK || len(counts.Channels) != 1 || counts.Channels[0].ID != "C1" || len(counts.IMs) != 1 {
+		t.Errorf("lost the well-formed fields: %+v", counts)
+	}
+	issues := c.SchemaIssues()
+	if len(issues) != 1 || issues[0].Kind != SchemaTypeMismatch {
+		t.Fatalf("issues = %+v, want one type mismatch", issues)
+	}
+}
+
+func TestDecodeUnparseable(t *testing.T) {
+	c := NewInternalClient("xoxc-test", "xoxd-test")
+	var counts ClientCountsResponse
+	err := c.decode(context.Background(), "/api/client.counts", []byte(`<html>sign in</html>`), &counts)
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) || schemaErr.Endpoint != "/api/client.counts" {
+		t.Fatalf("err = %v, want a SchemaError", err)
+	}
+	if issues := c.SchemaIssues(); len(issues) != 1 || issues[0].Kind != SchemaUnparseable {
+		t.Errorf("issues = %+v", issues)
+	}
+}
+
+func TestDecodeUnknownFieldsOnlyWhenVerbose(t *testing.T) {
+	c := NewInternalClient("xoxc-test", "xoxd-test")
+	body := []byte(`{"ok":true,"renamed":1,"threads":[{"root_msg":{"ts":"1","new_field":true}},{"root_msg":{"new_field":false}}]}`)
+
+	var view ThreadViewResponse
+	if err := c.decode(context.Background(), "/api/subscriptions.thread.getView", body, &view); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.SchemaIssues()) != 0 || len(c.Captures()) != 0 {
+		t.Fatal("unknown fields checked or payload kept without debug")
+	}
+
+	if err := c.decode(withCapture(context.Background()), "/api/subscriptions.thread.getView", body, &view); err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]bool{}
+	for _, issue := range c.SchemaIssues() {
+		if issue.Kind == SchemaUnknownField {
+			got[issue.Detail] = true
+		}
+	}
+	if len(got) != 2 || !got["renamed"] || !got["threads[].root_msg.new_field"] {
+		t.Errorf("unknown fields = %v", got)
+	}
+	if captures := c.Captures(); len(captures) != 1 || string(captures[0].Body) != string(body) {
+		t.Errorf("captures = %+v", captures)
+	}
+}
+
+func TestProbeSchemasWritesDump(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok":true,"channels":[],"threads":[]}`)
+	}))
+	defer srv.Close()
+	c := NewInternalClient("xoxc-test", "xoxd-test")
+	c.baseURL = srv.URL
+
+	probes := c.ProbeSchemas(context.Background())
+	if len(probes) != 3 {
+		t.Fatalf("probes = %d", len(probes))
+	}
+	for _, p := range probes {
+		if p.Error != "" || p.Shape["ok"] != "boolean" || p.Shape["channels"] != "array" {
+			t.Errorf("%s: %+v", p.Endpoint, p)
+		}
+	}
+	files, err := c.WriteCaptures(t.TempDir())
+	if err != nil || len(files) != 3 {
+		t.Fatalf("files = %v, err = %v", files, err)
+	}
+	info, err := os.Stat(files[0])
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("dump file mode = %v, err = %v", info.Mode(), err)
+	}
+}
@@ -0,0 +1,401 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// The outbox holds messages that couldn't be sent because Slack was
+// unreachable or rate limiting, and retries them with backoff.
+//
+//	SLACK_MCP_OUTBOX="1h"   how long a queued message keeps being retried (default 1h; "off" disables queueing)
+//
+// A message still unsent when its time is up is kept, marked as given up,
+// until it's flushed by hand or removed, so nothing disappears silently.
+// Messages to the same conversation and thread go out strictly in the order
+// they were queued.
+const (
+	outboxFile          = "outbox.json"
+	defaultOutboxMaxAge = time.Hour
+	maxOutbox           = 100
+	outboxBaseDelay     = 15 * time.Second
+	outboxMaxDelay      = 10 * time.Minute
+)
+
+// OutboxMessage is a message waiting to be sent
+type OutboxMessage struct {
+	ID          string    `json:"id"`
+	ChannelID   string    `json:"channel_id"`
+	Channel     string    `json:"channel"` // As the caller named it
+	ThreadTs    string    `json:"thread_ts,omitempty"`
+	Text        string    `json:"text"`
+	Force       bool      `json:"force,omitempty"` // Sent past DM pacing
+	QueuedAt    time.Time `json:"queued_at"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error,omitempty"`
+	Held        string    `json:"held,omitempty"` // Why the last retry waited instead of sending
+	GaveUp      bool      `json:"gave_up,omitempty"`
+}
+
+// conversation identifies the channel and thread a message goes to
+func (m *OutboxMessage) conversation() string {
+	return m.ChannelID + "/" + m.ThreadTs
+}
+
+// OutboxHold, when set, is asked before a queued message is posted, so
+// retries follow the same rules as live sends. It returns why the message
+// must wait and until when; a zero time means check again later. The
+// features package, which owns quiet hours and DM pacing, sets it.
+var OutboxHold func(ctx context.Context, ap *ApiProvider, api *slack.Client, m OutboxMessage) (string, time.Time)
+
+// OutboxSent is a queued message that has now been posted
+type OutboxSent struct {
+	OutboxMessage
+	Timestamp string
+}
+
+type outbox struct {
+	mu     sync.Mutex
+	send   sync.Mutex // Held while posting, so a flush and a retry can't both send a message
+	maxAge time.Duration
+	items  []*OutboxMessage
+	wake   chan struct{}
+}
+
+func newOutbox() *outbox {
+	return &outbox{
+		maxAge: loadRefreshInterval("SLACK_MCP_OUTBOX", defaultOutboxMaxAge),
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// IsTransientSendError reports whether a failed post is worth retrying:
+// rate limiting, Slack server errors, and network failures that show the
+// request never reached Slack. A cancelled or timed-out call, or a
+// connection that failed after the request was sent, isn't, since the
+// message may have gone out.
+func IsTransientSendError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var rateLimited *slack.RateLimitedError
+	if errors.As(err, &rateLimited) {
+		return true
+	}
+	var status slack.StatusCodeError
+	if errors.As(err, &status) && status.Code >= 500 {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	switch err.Error() {
+	case "service_unavailable", "internal_error", "fatal_error", "request_timeout", "ratelimited":
+		return true
+	}
+	return false
+}
+
+// outboxDelay is the wait before the given retry: exponential from
+// outboxBaseDelay, capped, and never sooner than Slack asked for
+func outboxDelay(attempts int, err error) time.Duration {
+	d := outboxBaseDelay
+	for i := 1; i < attempts && d < outboxMaxDelay; i++ {
+		d *= 2
+	}
+	d = min(d, outboxMaxDelay)
+	var rateLimited *slack.RateLimitedError
+	if errors.As(err, &rateLimited) && rateLimited.RetryAfter > d {
+		d = rateLimited.RetryAfter
+	}
+	return d
+}
+
+func (ap *ApiProvider) loadOutbox() {
+	if ap.store == nil {
+		return
+	}
+	var items []*OutboxMessage
+	if err := ap.store.Load(outboxFile, &items); err != nil {
+		return
+	}
+	ap.outbox.mu.Lock()
+	ap.outbox.items = items
+	ap.outbox.mu.Unlock()
+}
+
+// persistOutbox writes the queue to disk. Caller must hold outbox.mu.
+func (ap *ApiProvider) persistOutbox() {
+	if ap.store == nil {
+		return
+	}
+	if err := ap.store.Save(outboxFile, ap.outbox.items); err != nil {
+		log.Printf("Failed to save outbox: %v", err)
+	}
+}
+
+// OutboxEnabled reports whether failed sends may be queued
+func (ap *ApiProvider) OutboxEnabled() bool {
+	return ap.outbox.maxAge > 0
+}
+
+// QueueOutbox queues a message whose send failed with cause, for retry in
+// the background
+func (ap *ApiProvider) QueueOutbox(channelID, channel, threadTs, text string, force bool, cause error) (OutboxMessage, error) {
+	if !ap.OutboxEnabled() {
+		return OutboxMessage{}, fmt.Errorf("the outbox is disabled")
+	}
+	id := make([]byte, 4)
+	_, _ = rand.Read(id)
+	now := time.Now()
+	m := &OutboxMessage{
+		ID:          hex.EncodeToString(id),
+		ChannelID:   channelID,
+		Channel:     channel,
+		ThreadTs:    threadTs,
+		Text:        text,
+		Force:       force,
+		QueuedAt:    now,
+		Attempts:    1,
+		NextAttempt: now.Add(outboxDelay(1, cause)),
+		LastError:   cause.Error(),
+	}
+
+	ap.outbox.mu.Lock()
+	defer ap.outbox.mu.Unlock()
+	if len(ap.outbox.items) >= maxOutbox {
+		return OutboxMessage{}, fmt.Errorf("the outbox is full (%d messages)", maxOutbox)
+	}
+	ap.outbox.items = append(ap.outbox.items, m)
+	ap.persistOutbox()
+	select {
+	case ap.outbox.wake <- struct{}{}:
+	default:
+	}
+	return *m, nil
+}
+
+// Outbox returns copies of the queued messages, oldest first
+func (ap *ApiProvider) Outbox() []OutboxMessage {
+	ap.outbox.mu.Lock()
+	defer ap.outbox.mu.Unlock()
+	out := make([]OutboxMessage, 0, len(ap.outbox.items))
+	for _, m := range ap.outbox.items {
+		out = append(out, *m)
+	}
+	return out
+}
+
+// RemoveOutbox drops a queued message unsent, reporting whether it existed
+func (ap *ApiProvider) RemoveOutbox(id string) bool {
+	ap.outbox.mu.Lock()
+	defer ap.outbox.mu.Unlock()
+	for i, m := range ap.outbox.items {
+		if m.ID == id {
+			ap.outbox.items = append(ap.outbox.items[:i], ap.outbox.items[i+1:]...)
+			ap.persistOutbox()
+			return true
+		}
+	}
+	return false
+}
+
+// FlushOutbox tries the named queued messages now, or all of them when no
+// IDs are given, including ones given up on. Returns what was sent and
+// what's still queued.
+func (ap *ApiProvider) FlushOutbox(ctx context.Context, ids ...string) ([]OutboxSent, []OutboxMessage, error) {
+	want := map[string]bool{}
+	for _, id := range ids {
+		want[id] = true
+	}
+	return ap.sendOutbox(ctx, func(m *OutboxMessage, _ time.Time) bool {
+		return len(want) == 0 || want[m.ID]
+	})
+}
+
+// retryDueOutbox tries the messages whose backoff has elapsed. In each
+// conversation it stops at the first message that isn't due, so nothing
+// overtakes a message still waiting.
+func (ap *ApiProvider) retryDueOutbox(ctx context.Context) {
+	waiting := map[string]bool{}
+	sent, _, err := ap.sendOutbox(ctx, func(m *OutboxMessage, now time.Time) bool {
+		if waiting[m.conversation()] || m.GaveUp || m.NextAttempt.After(now) {
+			waiting[m.conversation()] = true
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		log.Printf("Outbox retry failed: %v", err)
+	}
+	for _, s := range sent {
+		log.Printf("Outbox: sent queued message %s to %s after %d attempts", s.ID, s.Channel, s.Attempts)
+	}
+}
+
+// sendOutbox posts the selected messages in the order they were queued,
+// stopping at the first failure so replies don't overtake what they answer.
+// A message held by OutboxHold waits, and so does the rest of its
+// conversation. Sends run one at a time; a message another send just
+// posted is gone from the queue by the time this one picks.
+func (ap *ApiProvider) sendOutbox(ctx context.Context, pick func(*OutboxMessage, time.Time) bool) ([]OutboxSent, []OutboxMessage, error) {
+	api, err := ap.Provide()
+	if err != nil {
+		return nil, ap.Outbox(), err
+	}
+
+	ap.outbox.send.Lock()
+	defer ap.outbox.send.Unlock()
+
+	ap.outbox.mu.Lock()
+	now := time.Now()
+	var batch []OutboxMessage
+	for _, m := range ap.outbox.items {
+		if pick(m, now) {
+			batch = append(batch, *m)
+		}
+	}
+	ap.outbox.mu.Unlock()
+
+	var sent []OutboxSent
+	held := map[string]bool{}
+	for _, m := range batch {
+		if held[m.conversation()] {
+			continue
+		}
+		if reason, until := ap.holdOutbox(ctx, api, m); reason != "" {
+			held[m.conversation()] = true
+			if until.IsZero() {
+				until = time.Now().Add(outboxMaxDelay)
+			}
+			ap.outbox.mu.Lock()
+			if cur := ap.findOutbox(m.ID); cur != nil {
+				cur.Held = reason
+				cur.NextAttempt = until
+				ap.persistOutbox()
+			}
+			ap.outbox.mu.Unlock()
+			continue
+		}
+
+		options := []slack.MsgOption{slack.MsgOptionText(m.Text, false)}
+		if m.ThreadTs != "" {
+			options = append(options, slack.MsgOptionTS(m.ThreadTs))
+		}
+		_, ts, postErr := api.PostMessageContext(ctx, m.ChannelID, options...)
+
+		ap.outbox.mu.Lock()
+		cur := ap.findOutbox(m.ID)
+		switch {
+		case cur == nil:
+			// Removed while sending
+		case postErr == nil:
+			ap.removeOutboxLocked(m.ID)
+			m.Attempts++
+			sent = append(sent, OutboxSent{OutboxMessage: m, Timestamp: ts})
+		default:
+			cur.Attempts++
+			cur.Held = ""
+			cur.LastError = postErr.Error()
+			cur.NextAttempt = time.Now().Add(outboxDelay(cur.Attempts, postErr))
+			if !IsTransientSendError(postErr) || time.Since(cur.QueuedAt) > ap.outbox.maxAge {
+				cur.GaveUp = true
+			}
+		}
+		ap.persistOutbox()
+		ap.outbox.mu.Unlock()
+
+		if postErr != nil {
+			break
+		}
+	}
+	if len(sent) > 0 {
+		ap.RecordAction(UsageMessagesSent, len(sent))
+	}
+	return sent, ap.Outbox(), nil
+}
+
+// holdOutbox asks OutboxHold whether a message must wait
+func (ap *ApiProvider) holdOutbox(ctx context.Context, api *slack.Client, m OutboxMessage) (string, time.Time) {
+	if OutboxHold == nil {
+		return "", time.Time{}
+	}
+	return OutboxHold(ctx, ap, api, m)
+}
+
+// findOutbox returns the queued message with the ID. Caller must hold outbox.mu.
+func (ap *ApiProvider) findOutbox(id string) *OutboxMessage {
+	for _, m := range ap.outbox.items {
+		if m.ID == id {
+			return m
+		}
+	}
+	return nil
+}
+
+// removeOutboxLocked drops a message. Caller must hold outbox.mu.
+func (ap *ApiProvider) removeOutboxLocked(id string) {
+	for i, m := range ap.outbox.items {
+		if m.ID == id {
+			ap.outbox.items = append(ap.outbox.items[:i], ap.outbox.items[i+1:]...)
+			return
+		}
+	}
+}
+
+// nextOutboxAttempt returns when the next retry is due, or zero if none is.
+// Only the first message in each conversation counts; the rest wait for it.
+func (ap *ApiProvider) nextOutboxAttempt() time.Time {
+	ap.outbox.mu.Lock()
+	defer ap.outbox.mu.Unlock()
+	var next time.Time
+	seen := map[string]bool{}
+	for _, m := range ap.outbox.items {
+		if seen[m.conversation()] {
+			continue
+		}
+		seen[m.conversation()] = true
+		if !m.GaveUp && (next.IsZero() || m.NextAttempt.Before(next)) {
+			next = m.NextAttempt
+		}
+	}
+	return next
+}
+
+// startOutbox retries queued messages as their backoff elapses
+func (ap *ApiProvider) startOutbox(ctx context.Context) {
+	if !ap.OutboxEnabled() {
+		return
+	}
+	go func() {
+		for {
+			wait := time.Hour
+			if next := ap.nextOutboxAttempt(); !next.IsZero() {
+				wait = max(time.Until(next), 0)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ap.outbox.wake:
+				continue
+			case <-time.After(wait):
+			}
+			ap.retryDueOutbox(ctx)
+		}
+	}()
+}
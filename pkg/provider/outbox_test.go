@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+func TestIsTransientSendError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{&slack.RateLimitedError{RetryAfter: time.Second}, true},
+		{slack.StatusCodeError{Code: 503, Status: "503 Service Unavailable"}, true},
+		{slack.StatusCodeError{Code: 404, Status: "404 Not Found"}, false},
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{fmt.Errorf("post: %w", &net.DNSError{Err: "no such host"}), true},
+		{&net.OpError{Op: "read", Err: os.ErrDeadlineExceeded}, false},
+		{fmt.Errorf("post: %w", &net.OpError{Op: "write", Err: errors.New("broken pipe")}), false},
+		{errors.New("service_unavailable"), true},
+		{errors.New("channel_not_found"), false},
+		{errors.New("not_in_channel"), false},
+		{context.Canceled, false},
+		{context.DeadlineExceeded, false},
+		{nil, false},
+	}
+	for _, c := range cases {
+		if got := IsTransientSendError(c.err); got != c.want {
+			t.Errorf("IsTransientSendError(%v) = %v, want %v", c.err, got, c.want)
+		}
+	}
+}
+
+func TestOutboxDelay(t *testing.T) {
+	if d := outboxDelay(1, errors.New("x")); d != outboxBaseDelay {
+		t.Fatalf("first delay = %v, want %v", d, outboxBaseDelay)
+	}
+	if d := outboxDelay(3, errors.New("x")); d != 4*outboxBaseDelay {
+		t.Fatalf("third delay = %v, want %v", d, 4*outboxBaseDelay)
+	}
+	if d := outboxDelay(40, errors.New("x")); d != outboxMaxDelay {
+		t.Fatalf("delay should cap at %v, got %v", outboxMaxDelay, d)
+	}
+	// Slack's Retry-After wins when it's longer
+	if d := outboxDelay(1, &slack.RateLimitedError{RetryAfter: 2 * time.Minute}); d != 2*time.Minute {
+		t.Fatalf("rate-limited delay = %v, want 2m", d)
+	}
+}
+
+func TestQueueOutboxCapsAndRemoves(t *testing.T) {
+	ap := &ApiProvider{outbox: &outbox{maxAge: time.Hour, wake: make(chan struct{}, 1)}}
+	cause := errors.New("service_unavailable")
+
+	first, err := ap.QueueOutbox("C1", "#general", "", "hello", false, cause)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < maxOutbox; i++ {
+		if _, err := ap.QueueOutbox("C1", "#general", "", "hi", false, cause); err != nil {
+			t.Fatalf("queue %d: %v", i, err)
+		}
+	}
+	if _, err := ap.QueueOutbox("C1", "#general", "", "one too many", false, cause); err == nil {
+		t.Fatal("expected a full outbox to refuse")
+	}
+	if got := ap.Outbox(); len(got) != maxOutbox || got[0].ID != first.ID {
+		t.Fatalf("outbox should keep queue order, got %d items", len(got))
+	}
+	if !ap.RemoveOutbox(first.ID) || ap.RemoveOutbox(first.ID) {
+		t.Fatal("RemoveOutbox should remove once")
+	}
+
+	ap.outbox.maxAge = 0
+	if _, err := ap.QueueOutbox("C1", "#general", "", "hi", false, cause); err == nil {
+		t.Fatal("expected a disabled outbox to refuse")
+	}
+}
+
+func TestNextOutboxAttemptWaitsForHead(t *testing.T) {
+	now := time.Now()
+	ap := &ApiProvider{outbox: &outbox{maxAge: time.Hour, items: []*OutboxMessage{
+		{ID: "a", ChannelID: "C1", ThreadTs: "1.0", NextAttempt: now.Add(time.Hour)},
+		{ID: "b", ChannelID: "C1", ThreadTs: "1.0", NextAttempt: now.Add(-time.Minute)},
+		{ID: "c", ChannelID: "C2", NextAttempt: now.Add(10 * time.Minute)},
+	}}}
+	// b is overdue but must not overtake a
+	if next := ap.nextOutboxAttempt(); !next.Equal(now.Add(10 * time.Minute)) {
+		t.Errorf("next attempt = %v, want C2's", next)
+	}
+	ap.outbox.items[2].GaveUp = true
+	if next := ap.nextOutboxAttempt(); !next.Equal(now.Add(time.Hour)) {
+		t.Errorf("next attempt = %v, want the thread head's", next)
+	}
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	"github.com/slack-go/slack"
+)
+
+// maxCachedPermalinks bounds the in-memory permalink cache; permalinks never
+// change, so the cache is simply dropped when it fills up
+const maxCachedPermalinks = 5000
+
+// permalinkCache memoizes chat.getPermalink results by channel and timestamp
+type permalinkCache struct {
+	mu    sync.RWMutex
+	links map[string]string
+}
+
+func newPermalinkCache() *permalinkCache {
+	return &permalinkCache{links: make(map[string]string)}
+}
+
+func (c *permalinkCache) get(key string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	link, ok := c.links[key]
+	return link, ok
+}
+
+func (c *permalinkCache) put(key, link string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.links) >= maxCachedPermalinks {
+		c.links = make(map[string]string)
+	}
+	c.links[key] = link
+}
+
+// Permalink returns the permalink for a message, calling chat.getPermalink
+// only on a cache miss
+func (ap *ApiProvider) Permalink(ctx context.Context, channelID, ts string) (string, error) {
+	key := channelID + ":" + ts
+	if link, ok := ap.permalinks.get(key); ok {
+		return link, nil
+	}
+
+	client, err := ap.Provide()
+	if err != nil {
+		return "", err
+	}
+	link, err := client.GetPermalinkContext(ctx, &slack.PermalinkParameters{Channel: channelID, Ts: ts})
+	if err != nil {
+		return "", err
+	}
+	ap.permalinks.put(key, link)
+	return link, nil
+}
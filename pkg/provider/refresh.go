@@ -0,0 +1,243 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Background refresh cadence.
+//
+//	SLACK_MCP_REFRESH_CHANNELS="6h"   channel directory (default 6h)
+//	SLACK_MCP_REFRESH_USERS="1d"      user directory (default 1d)
+//
+// Intervals accept Go durations plus a "d" suffix for days; "off" disables
+// that refresh. Each wait is jittered by ±refreshJitter so several servers
+// started together don't hit Slack in lockstep. A refresh is skipped when
+// client.boot shows the workspace unchanged since the last one.
+const (
+	refreshKindChannels = "channels"
+	refreshKindUsers    = "users"
+
+	defaultChannelRefresh = 6 * time.Hour
+	defaultUserRefresh    = 24 * time.Hour
+	refreshJitter         = 0.1
+)
+
+// RefreshStatus describes the background refresh schedule
+type RefreshStatus struct {
+	ChannelsEvery time.Duration
+	UsersEvery    time.Duration
+	PausedUntil   time.Time
+	Last          map[string]time.Time
+	Next          map[string]time.Time
+	Skipped       map[string]int
+}
+
+type refreshScheduler struct {
+	mu          sync.Mutex
+	every       map[string]time.Duration
+	pausedUntil time.Time
+	last        map[string]time.Time
+	next        map[string]time.Time
+	skipped     map[string]int
+	hashes      map[string]string
+}
+
+func newRefreshScheduler() *refreshScheduler {
+	return &refreshScheduler{
+		every: map[string]time.Duration{
+			refreshKindChannels: loadRefreshInterval("SLACK_MCP_REFRESH_CHANNELS", defaultChannelRefresh),
+			refreshKindUsers:    loadRefreshInterval("SLACK_MCP_REFRESH_USERS", defaultUserRefresh),
+		},
+		last:    map[string]time.Time{},
+		next:    map[string]time.Time{},
+		skipped: map[string]int{},
+		hashes:  map[string]string{},
+	}
+}
+
+// loadRefreshInterval reads an interval from the environment; 0 means off
+func loadRefreshInterval(env string, def time.Duration) time.Duration {
+	spec := strings.TrimSpace(os.Getenv(env))
+	if spec == "" {
+		return def
+	}
+	if strings.EqualFold(spec, "off") {
+		return 0
+	}
+	d, err := parseRefreshInterval(spec)
+	if err != nil {
+		log.Printf("Ignoring %s: %v", env, err)
+		return def
+	}
+	return d
+}
+
+// parseRefreshInterval parses a Go duration or a whole number of days ("2d")
+func parseRefreshInterval(spec string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(spec, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid interval %q", spec)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(spec)
+	if err != nil || d < time.Minute {
+		return 0, fmt.Errorf("invalid interval %q (minimum 1m)", spec)
+	}
+	return d, nil
+}
+
+// jittered spreads d by ±refreshJitter
+func jittered(d time.Duration) time.Duration {
+	spread := float64(d) * refreshJitter
+	return d + time.Duration((rand.Float64()*2-1)*spread)
+}
+
+// startBackgroundRefresh runs one refresh loop per configured kind
+func (ap *ApiProvider) startBackgroundRefresh(ctx context.Context) {
+	for _, kind := range []string{refreshKindChannels, refreshKindUsers} {
+		if ap.refresh.every[kind] > 0 {
+			go ap.refreshLoop(ctx, kind)
+		}
+	}
+}
+
+func (ap *ApiProvider) refreshLoop(ctx context.Context, kind string) {
+	s := ap.refresh
+	for {
+		wait := jittered(s.every[kind])
+		s.mu.Lock()
+		s.next[kind] = time.Now().Add(wait)
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		if until := ap.refreshPausedUntil(); !until.IsZero() {
+			log.Printf("Background %s refresh paused until %s", kind, until.Format(time.Kitchen))
+			ap.skipRefresh(kind)
+			continue
+		}
+		if !ap.workspaceChanged(ctx, kind) {
+			log.Printf("Skipping background %s refresh: workspace unchanged", kind)
+			ap.skipRefresh(kind)
+			continue
+		}
+
+		switch kind {
+		case refreshKindChannels:
+			ap.backfillMutex.Lock()
+			ap.backfillDone = false
+			ap.backfillMutex.Unlock()
+			ap.backgroundBackfill(ctx)
+		case refreshKindUsers:
+			if err := ap.fetchAndCacheUsers(ctx); err != nil {
+				log.Printf("Background user refresh failed: %v", err)
+			}
+		}
+
+		s.mu.Lock()
+		s.last[kind] = time.Now()
+		s.mu.Unlock()
+	}
+}
+
+func (ap *ApiProvider) skipRefresh(kind string) {
+	ap.refresh.mu.Lock()
+	ap.refresh.skipped[kind]++
+	ap.refresh.mu.Unlock()
+}
+
+// workspaceChanged compares a hash of the team prefs and conversation list
+// from client.boot with the one seen at the last refresh of this kind. It
+// errs on the side of refreshing when client.boot is unavailable.
+func (ap *ApiProvider) workspaceChanged(ctx context.Context, kind string) bool {
+	if ap.internalClient == nil {
+		return true
+	}
+	boot, err := ap.internalClient.GetClientBoot(ctx)
+	if err != nil || !boot.OK {
+		return true
+	}
+
+	ids := make([]string, 0, len(boot.Channels)+len(boot.IMs))
+	for _, ch := range boot.Channels {
+		ids = append(ids, ch.ID)
+	}
+	for _, im := range boot.IMs {
+		ids = append(ids, im.ID)
+	}
+	sort.Strings(ids)
+	h := sha256.New()
+	h.Write(boot.Team.Prefs)
+	h.Write([]byte(strings.Join(ids, ",")))
+	sum := hex.EncodeToString(h.Sum(nil))
+
+	ap.refresh.mu.Lock()
+	defer ap.refresh.mu.Unlock()
+	changed := ap.refresh.hashes[kind] != sum
+	ap.refresh.hashes[kind] = sum
+	return changed
+}
+
+// PauseBackgroundRefresh suspends scheduled refreshes for d; d <= 0 resumes
+func (ap *ApiProvider) PauseBackgroundRefresh(d time.Duration) {
+	ap.refresh.mu.Lock()
+	defer ap.refresh.mu.Unlock()
+	if d <= 0 {
+		ap.refresh.pausedUntil = time.Time{}
+		return
+	}
+	ap.refresh.pausedUntil = time.Now().Add(d)
+}
+
+func (ap *ApiProvider) refreshPausedUntil() time.Time {
+	ap.refresh.mu.Lock()
+	defer ap.refresh.mu.Unlock()
+	if time.Now().After(ap.refresh.pausedUntil) {
+		return time.Time{}
+	}
+	return ap.refresh.pausedUntil
+}
+
+// BackgroundRefreshStatus reports the refresh schedule and pause state
+func (ap *ApiProvider) BackgroundRefreshStatus() RefreshStatus {
+	paused := ap.refreshPausedUntil()
+
+	s := ap.refresh
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := RefreshStatus{
+		ChannelsEvery: s.every[refreshKindChannels],
+		UsersEvery:    s.every[refreshKindUsers],
+		PausedUntil:   paused,
+		Last:          map[string]time.Time{},
+		Next:          map[string]time.Time{},
+		Skipped:       map[string]int{},
+	}
+	for k, v := range s.last {
+		status.Last[k] = v
+	}
+	for k, v := range s.next {
+		status.Next[k] = v
+	}
+	for k, v := range s.skipped {
+		status.Skipped[k] = v
+	}
+	return status
+}
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRefreshInterval(t *testing.T) {
+	cases := map[string]time.Duration{
+		"6h":  6 * time.Hour,
+		"90m": 90 * time.Minute,
+		"2d":  48 * time.Hour,
+	}
+	for spec, want := range cases {
+		got, err := parseRefreshInterval(spec)
+		if err != nil || got != want {
+			t.Errorf("parseRefreshInterval(%q) = %v, %v; want %v", spec, got, err, want)
+		}
+	}
+	for _, spec := range []string{"", "0d", "-1d", "30s", "soon"} {
+		if _, err := parseRefreshInterval(spec); err == nil {
+			t.Errorf("parseRefreshInterval(%q): expected an error", spec)
+		}
+	}
+}
+
+func TestJitteredStaysInBounds(t *testing.T) {
+	d := time.Hour
+	for i := 0; i < 100; i++ {
+		got := jittered(d)
+		if got < 54*time.Minute || got > 66*time.Minute {
+			t.Fatalf("jittered(%v) = %v, outside ±10%%", d, got)
+		}
+	}
+}
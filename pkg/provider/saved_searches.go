@@ -0,0 +1,286 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// Saved searches are named queries run in the background so new matches
+// pile up between checks.
+//
+//	SLACK_MCP_SAVED_SEARCH_INTERVAL="15m"   run cadence (default 15m)
+//
+// The interval accepts the same forms as the refresh settings; "off"
+// disables background runs and searches only run when checked.
+const (
+	savedSearchesFile          = "saved-searches.json"
+	defaultSavedSearchInterval = 15 * time.Minute
+	maxSavedSearches           = 20
+	maxSavedSearchPending      = 100
+	savedSearchPageSize        = 50
+)
+
+// SavedSearch is a named query with the matches not yet surfaced
+type SavedSearch struct {
+	Name      string           `json:"name"`
+	Query     string           `json:"query"`
+	CreatedAt time.Time        `json:"created_at"`
+	LastRun   time.Time        `json:"last_run,omitempty"`
+	LastError string           `json:"last_error,omitempty"`
+	LastSeen  string           `json:"last_seen,omitempty"` // newest match timestamp already recorded
+	Pending   []SavedSearchHit `json:"pending,omitempty"`
+}
+
+// SavedSearchHit is one new match of a saved search
+type SavedSearchHit struct {
+	ChannelID   string `json:"channel_id"`
+	ChannelName string `json:"channel_name"`
+	User        string `json:"user"`
+	Username    string `json:"username,omitempty"`
+	Text        string `json:"text"`
+	Timestamp   string `json:"ts"`
+	Permalink   string `json:"permalink,omitempty"`
+}
+
+type savedSearches struct {
+	mu       sync.Mutex
+	every    time.Duration
+	searches map[string]*SavedSearch
+}
+
+func newSavedSearches() *savedSearches {
+	return &savedSearches{
+		every:    loadRefreshInterval("SLACK_MCP_SAVED_SEARCH_INTERVAL", defaultSavedSearchInterval),
+		searches: map[string]*SavedSearch{},
+	}
+}
+
+func (ap *ApiProvider) loadSavedSearches() {
+	if ap.store == nil {
+		return
+	}
+	var list []*SavedSearch
+	if err := ap.store.Load(savedSearchesFile, &list); err != nil {
+		return
+	}
+	ap.saved.mu.Lock()
+	defer ap.saved.mu.Unlock()
+	for _, s := range list {
+		ap.saved.searches[strings.ToLower(s.Name)] = s
+	}
+}
+
+// persistSavedSearches writes the searches to disk. Caller must hold
+// saved.mu.
+func (ap *ApiProvider) persistSavedSearches() {
+	if ap.store == nil {
+		return
+	}
+	list := make([]*SavedSearch, 0, len(ap.saved.searches))
+	for _, s := range ap.saved.searches {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	if err := ap.store.Save(savedSearchesFile, list); err != nil {
+		log.Printf("Failed to save saved searches: %v", err)
+	}
+}
+
+// SavedSearchInterval returns the background run cadence; 0 means off
+func (ap *ApiProvider) SavedSearchInterval() time.Duration {
+	return ap.saved.every
+}
+
+// AddSavedSearch registers a named query, replacing one with the same name.
+// Its first run only records a baseline; later runs collect new matches.
+func (ap *ApiProvider) AddSavedSearch(name, query string) (SavedSearch, error) {
+	name, query = strings.TrimSpace(name), strings.TrimSpace(query)
+	if name == "" || query == "" {
+		return SavedSearch{}, fmt.Errorf("a saved search needs a name and a query")
+	}
+	ap.saved.mu.Lock()
+	defer ap.saved.mu.Unlock()
+	key := strings.ToLower(name)
+	if _, exists := ap.saved.searches[key]; !exists && len(ap.saved.searches) >= maxSavedSearches {
+		return SavedSearch{}, fmt.Errorf("at most %d saved searches are allowed", maxSavedSearches)
+	}
+	s := &SavedSearch{Name: name, Query: query, CreatedAt: time.Now()}
+	ap.saved.searches[key] = s
+	ap.persistSavedSearches()
+	return *s, nil
+}
+
+// RemoveSavedSearch deletes a saved search, reporting whether it existed
+func (ap *ApiProvider) RemoveSavedSearch(name string) bool {
+	ap.saved.mu.Lock()
+	defer ap.saved.mu.Unlock()
+	key := strings.ToLower(strings.TrimSpace(name))
+	if _, ok := ap.saved.searches[key]; !ok {
+		return false
+	}
+	delete(ap.saved.searches, key)
+	ap.persistSavedSearches()
+	return true
+}
+
+// SavedSearches returns copies of the saved searches sorted by name. With
+// markSeen the pending matches are cleared after being returned.
+func (ap *ApiProvider) SavedSearches(markSeen bool) []SavedSearch {
+	ap.saved.mu.Lock()
+	defer ap.saved.mu.Unlock()
+	out := make([]SavedSearch, 0, len(ap.saved.searches))
+	cleared := false
+	for _, s := range ap.saved.searches {
+		cp := *s
+		cp.Pending = append([]SavedSearchHit(nil), s.Pending...)
+		out = append(out, cp)
+		if markSeen && len(s.Pending) > 0 {
+			s.Pending = nil
+			cleared = true
+		}
+	}
+	if cleared {
+		ap.persistSavedSearches()
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// RunSavedSearches runs the named saved searches now, or all of them when
+// no names are given
+func (ap *ApiProvider) RunSavedSearches(ctx context.Context, names ...string) error {
+	api, err := ap.Provide()
+	if err != nil {
+		return err
+	}
+
+	ap.saved.mu.Lock()
+	var queue []SavedSearch
+	for key, s := range ap.saved.searches {
+		if len(names) > 0 && !containsFold(names, key) {
+			continue
+		}
+		queue = append(queue, *s)
+	}
+	ap.saved.mu.Unlock()
+
+	for _, s := range queue {
+		hits, runErr := searchForHits(ctx, api, s.Query)
+
+		ap.saved.mu.Lock()
+		cur, ok := ap.saved.searches[strings.ToLower(s.Name)]
+		if ok && cur.Query == s.Query {
+			cur.LastError = ""
+			if runErr != nil {
+				cur.LastError = runErr.Error()
+			} else {
+				mergeSavedSearchHits(cur, hits)
+			}
+			cur.LastRun = time.Now()
+		}
+		ap.saved.mu.Unlock()
+	}
+
+	ap.saved.mu.Lock()
+	ap.persistSavedSearches()
+	ap.saved.mu.Unlock()
+	return nil
+}
+
+func searchForHits(ctx context.Context, api *slack.Client, query string) ([]SavedSearchHit, error) {
+	sp := slack.NewSearchParameters()
+	sp.Sort = "timestamp"
+	sp.Count = savedSearchPageSize
+	res, err := api.SearchMessagesContext(ctx, query, sp)
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]SavedSearchHit, 0, len(res.Matches))
+	for _, m := range res.Matches {
+		hits = append(hits, SavedSearchHit{
+			ChannelID:   m.Channel.ID,
+			ChannelName: m.Channel.Name,
+			User:        m.User,
+			Username:    m.Username,
+			Text:        m.Text,
+			Timestamp:   m.Timestamp,
+			Permalink:   m.Permalink,
+		})
+	}
+	return hits, nil
+}
+
+// mergeSavedSearchHits records matches newer than the last one seen. The
+// first run of a search only sets that baseline, so a new subscription
+// doesn't report the whole history as new. Pending is kept newest first
+// and capped.
+func mergeSavedSearchHits(s *SavedSearch, hits []SavedSearchHit) {
+	newest := s.LastSeen
+	baseline := s.LastSeen == "" && s.LastRun.IsZero()
+	for _, h := range hits {
+		if h.Timestamp > newest {
+			newest = h.Timestamp
+		}
+	}
+	if !baseline {
+		known := map[string]bool{}
+		for _, h := range s.Pending {
+			known[h.ChannelID+"/"+h.Timestamp] = true
+		}
+		for _, h := range hits {
+			if h.Timestamp > s.LastSeen && !known[h.ChannelID+"/"+h.Timestamp] {
+				s.Pending = append(s.Pending, h)
+			}
+		}
+		sort.Slice(s.Pending, func(i, j int) bool { return s.Pending[i].Timestamp > s.Pending[j].Timestamp })
+		if len(s.Pending) > maxSavedSearchPending {
+			s.Pending = s.Pending[:maxSavedSearchPending]
+		}
+	}
+	s.LastSeen = newest
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(strings.TrimSpace(v), s) {
+			return true
+		}
+	}
+	return false
+}
+
+// startSavedSearches runs all saved searches on the configured cadence,
+// holding off while background refresh is paused
+func (ap *ApiProvider) startSavedSearches(ctx context.Context) {
+	if ap.saved.every <= 0 {
+		return
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(jittered(ap.saved.every)):
+			}
+			if !ap.refreshPausedUntil().IsZero() {
+				continue
+			}
+			ap.saved.mu.Lock()
+			empty := len(ap.saved.searches) == 0
+			ap.saved.mu.Unlock()
+			if empty {
+				continue
+			}
+			if err := ap.RunSavedSearches(ctx); err != nil {
+				log.Printf("Saved search run failed: %v", err)
+			}
+		}
+	}()
+}
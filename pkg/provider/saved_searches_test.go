@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMergeSavedSearchHitsBaselineThenDeltas(t *testing.T) {
+	s := &SavedSearch{Name: "project-x", Query: "project-x"}
+
+	mergeSavedSearchHits(s, []SavedSearchHit{
+		{ChannelID: "C1", Timestamp: "1700000002.000000"},
+		{ChannelID: "C1", Timestamp: "1700000001.000000"},
+	})
+	if len(s.Pending) != 0 {
+		t.Fatalf("first run should only set a baseline, got %d pending", len(s.Pending))
+	}
+	if s.LastSeen != "1700000002.000000" {
+		t.Fatalf("LastSeen = %q", s.LastSeen)
+	}
+	s.LastRun = time.Now()
+
+	mergeSavedSearchHits(s, []SavedSearchHit{
+		{ChannelID: "C2", Timestamp: "1700000004.000000"},
+		{ChannelID: "C1", Timestamp: "1700000003.000000"},
+		{ChannelID: "C1", Timestamp: "1700000002.000000"},
+	})
+	if len(s.Pending) != 2 || s.Pending[0].Timestamp != "1700000004.000000" {
+		t.Fatalf("expected two new hits newest first, got %+v", s.Pending)
+	}
+
+	// The same matches returned again aren't duplicated
+	mergeSavedSearchHits(s, []SavedSearchHit{{ChannelID: "C2", Timestamp: "1700000004.000000"}})
+	if len(s.Pending) != 2 {
+		t.Fatalf("expected no duplicates, got %d pending", len(s.Pending))
+	}
+}
+
+func TestMergeSavedSearchHitsCapsPending(t *testing.T) {
+	s := &SavedSearch{LastSeen: "1600000000.000000", LastRun: time.Now()}
+	var hits []SavedSearchHit
+	for i := 0; i < maxSavedSearchPending+20; i++ {
+		hits = append(hits, SavedSearchHit{ChannelID: "C1", Timestamp: fmt.Sprintf("%d.000000", 1700000000+i)})
+	}
+	mergeSavedSearchHits(s, hits)
+	if len(s.Pending) != maxSavedSearchPending {
+		t.Fatalf("pending = %d, want %d", len(s.Pending), maxSavedSearchPending)
+	}
+}
@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The user's sidebar: the sections they sort conversations into and the
+// channels they've muted, read from the same internal endpoints the web
+// client uses. Only browser session tokens can reach them.
+
+// sidebarTTL is how long a fetched sidebar is reused; people rearrange
+// sections rarely
+const sidebarTTL = 5 * time.Minute
+
+// Section types Slack uses for its built-in sections
+const (
+	SectionStandard       = "standard" // Created by the user
+	SectionChannels       = "channels"
+	SectionDirectMessages = "direct_messages"
+	SectionStars          = "stars"
+	SectionMuted          = "muted" // Not Slack's; muted channels gathered into one
+)
+
+// SidebarSection is one section of the sidebar, in sidebar order
+type SidebarSection struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Emoji      string   `json:"emoji,omitempty"`
+	ChannelIDs []string `json:"channelIds"`
+	// More channels than Slack listed inline
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Sidebar is the user's sidebar organization
+type Sidebar struct {
+	Sections  []SidebarSection
+	Muted     map[string]bool
+	FetchedAt time.Time
+
+	// Section index by conversation, and Slack's default sections for
+	// conversations in none
+	byChannel       map[string]int
+	defaultChannels int
+	defaultDMs      int
+}
+
+// newSidebar indexes sections, adding muted channels as a trailing Muted
+// section that takes them over from wherever else they're listed
+func newSidebar(sections []SidebarSection, muted map[string]bool) *Sidebar {
+	if len(muted) > 0 {
+		ids := make([]string, 0, len(muted))
+		for id := range muted {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		sections = append(sections, SidebarSection{ID: SectionMuted, Name: "Muted", Type: SectionMuted, ChannelIDs: ids})
+	}
+	s := &Sidebar{
+		Sections:        sections,
+		Muted:           muted,
+		FetchedAt:       time.Now(),
+		byChannel:       make(map[string]int),
+		defaultChannels: -1,
+		defaultDMs:      -1,
+	}
+	// Walk backwards so a conversation listed twice lands in the first
+	// section, except that Muted, last, wins
+	for i := len(sections) - 1; i >= 0; i-- {
+		sec := sections[i]
+		switch sec.Type {
+		case SectionChannels:
+			s.defaultChannels = i
+		case SectionDirectMessages:
+			s.defaultDMs = i
+		}
+		for _, id := range sec.ChannelIDs {
+			if j, ok := s.byChannel[id]; !ok || sections[j].Type != SectionMuted {
+				s.byChannel[id] = i
+			}
+		}
+	}
+	return s
+}
+
+// channelSectionsResponse is /api/users.channelSections.list
+type channelSectionsResponse struct {
+	OK       bool   `json:"ok"`
+	Error    string `json:"error,omitempty"`
+	Sections []struct {
+		ID             string `json:"channel_section_id"`
+		Name           string `json:"name"`
+		Type           string `json:"type"`
+		Emoji          string `json:"emoji"`
+		Next           string `json:"next_channel_section_id"`
+		ChannelIDsPage struct {
+			ChannelIDs []string `json:"channel_ids"`
+			Count      int      `json:"count"`
+		} `json:"channel_ids_page"`
+	} `json:"channel_sections"`
+}
+
+// GetChannelSections fetches the user's sidebar sections in the order the
+// sidebar shows them
+func (c *InternalClient) GetChannelSections(ctx context.Context) ([]SidebarSection, error) {
+	var result channelSectionsResponse
+	if err := c.callInternalAPI(ctx, "/api/users.channelSections.list", nil, &result); err != nil {
+		return nil, err
+	}
+	if !result.OK {
+		return nil, fmt.Errorf("users.channelSections.list: %s", result.Error)
+	}
+
+	// Sections form a linked list through next_channel_section_id; the
+	// head is the one no other section points at
+	byID := make(map[string]int, len(result.Sections))
+	pointedAt := make(map[string]bool, len(result.Sections))
+	for i, s := range result.Sections {
+		byID[s.ID] = i
+		pointedAt[s.Next] = true
+	}
+	order := make([]int, 0, len(result.Sections))
+	seen := make(map[int]bool, len(result.Sections))
+	for i, s := range result.Sections {
+		if pointedAt[s.ID] {
+			continue
+		}
+		for j, ok := i, true; ok && !seen[j]; j, ok = byID[result.Sections[j].Next] {
+			seen[j] = true
+			order = append(order, j)
+		}
+	}
+	// Anything a broken chain left out keeps Slack's order at the end
+	for i := range result.Sections {
+		if !seen[i] {
+			order = append(order, i)
+		}
+	}
+
+	sections := make([]SidebarSection, 0, len(order))
+	for _, i := range order {
+		s := result.Sections[i]
+		sections = append(sections, SidebarSection{
+			ID:         s.ID,
+			Name:       sectionName(s.Name, s.Type),
+			Type:       s.Type,
+			Emoji:      s.Emoji,
+			ChannelIDs: s.ChannelIDsPage.ChannelIDs,
+			Truncated:  s.ChannelIDsPage.Count > len(s.ChannelIDsPage.ChannelIDs),
+		})
+	}
+	return sections, nil
+}
+
+// sectionName names Slack's built-in sections, which come back unnamed
+func sectionName(name, kind string) string {
+	if name != "" {
+		return name
+	}
+	switch kind {
+	case SectionChannels:
+		return "Channels"
+	case SectionDirectMessages:
+		return "Direct messages"
+	case SectionStars:
+		return "Starred"
+	}
+	return strings.ReplaceAll(kind, "_", " ")
+}
+
+// GetMutedChannels reads the muted_channels preference
+func (c *InternalClient) GetMutedChannels(ctx context.Context) (map[string]bool, error) {
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error,omitempty"`
+		Prefs struct {
+			MutedChannels string `json:"muted_channels"`
+		} `json:"prefs"`
+	}
+	params := url.Values{"prefs": {"muted_channels"}}
+	if err := c.callInternalAPI(ctx, "/api/users.prefs.get", params, &result); err != nil {
+		return nil, err
+	}
+	if !result.OK {
+		return nil, fmt.Errorf("users.prefs.get: %s", result.Error)
+	}
+	muted := make(map[string]bool)
+	for _, id := range strings.Split(result.Prefs.MutedChannels, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			muted[id] = true
+		}
+	}
+	return muted, nil
+}
+
+// sidebarCache holds the last fetched sidebar, and for app tokens, the
+// last stars.list
+type sidebarCache struct {
+	mu      sync.Mutex
+	sidebar *Sidebar
+	stars   map[string]bool
+	starsAt time.Time
+}
+
+// Sidebar returns the user's sidebar sections, with muted channels
+// gathered into a trailing Muted section. A fetch under sidebarTTL old is
+// reused.
+func (ap *ApiProvider) Sidebar(ctx context.Context) (*Sidebar, error) {
+	if ap.internalClient == nil {
+		return nil, fmt.Errorf("sidebar sections need a browser session (xoxc) token")
+	}
+	ap.sidebar.mu.Lock()
+	defer ap.sidebar.mu.Unlock()
+	if s := ap.sidebar.sidebar; s != nil && time.Since(s.FetchedAt) < sidebarTTL {
+		return s, nil
+	}
+
+	sections, err := ap.internalClient.GetChannelSections(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Sections still work without mute state
+	muted, err := ap.internalClient.GetMutedChannels(ctx)
+	if err != nil {
+		muted = map[string]bool{}
+	}
+	s := newSidebar(sections, muted)
+	ap.sidebar.sidebar = s
+	return s, nil
+}
+
+// CachedSidebar returns the last fetched sidebar without calling Slack,
+// or nil
+func (ap *ApiProvider) CachedSidebar() *Sidebar {
+	ap.sidebar.mu.Lock()
+	defer ap.sidebar.mu.Unlock()
+	return ap.sidebar.sidebar
+}
+
+// SectionOf returns the index of the section a conversation is shown in.
+// Muted channels go to the Muted section; conversations in no section
+// fall into Slack's default Channels or Direct messages section. -1 if
+// there's nowhere to put it.
+func (s *Sidebar) SectionOf(channelID string, isDM bool) int {
+	if i, ok := s.byChannel[channelID]; ok {
+		return i
+	}
+	if isDM {
+		return s.defaultDMs
+	}
+	return s.defaultChannels
+}
+
+// FindSection returns the index of the section with the given name
+// (ignoring case) or ID, or -1
+func (s *Sidebar) FindSection(name string) int {
+	name = strings.TrimSpace(name)
+	for i, sec := range s.Sections {
+		if strings.EqualFold(sec.Name, name) || sec.ID == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// InSection reports whether a conversation is shown in section i
+func (s *Sidebar) InSection(i int, channelID string, isDM bool) bool {
+	return i >= 0 && s.SectionOf(channelID, isDM) == i
+}
+
+// SectionNames lists the section names in sidebar order
+func (s *Sidebar) SectionNames() []string {
+	names := make([]string, 0, len(s.Sections))
+	for _, sec := range s.Sections {
+		names = append(names, sec.Name)
+	}
+	return names
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetChannelSectionsSidebarOrder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Slack lists sections in any order, linked by next_channel_section_id
+		fmt.Fprint(w, `{"ok":true,"channel_sections":[
+			{"channel_section_id":"L3","name":"","type":"direct_messages","next_channel_section_id":""},
+			{"channel_section_id":"L1","name":"Projects","type":"standard","next_channel_section_id":"L2",
+			 "channel_ids_page":{"channel_ids":["C1","C2"],"count":3}},
+			{"channel_section_id":"L2","name":"","type":"channels","next_channel_section_id":"L3"}]}`)
+	}))
+	defer srv.Close()
+	c := NewInternalClient("xoxc-test", "xoxd-test")
+	c.baseURL = srv.URL
+
+	sections, err := c.GetChannelSections(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, s := range sections {
+		names = append(names, s.Name)
+	}
+	if fmt.Sprint(names) != "[Projects Channels Direct messages]" {
+		t.Errorf("sections = %v", names)
+	}
+	if !sections[0].Truncated || sections[1].Truncated {
+		t.Error("truncation not reported from the channel count")
+	}
+}
+
+func TestSidebarSectionOf(t *testing.T) {
+	s := newSidebar([]SidebarSection{
+		{ID: "L1", Name: "Projects", Type: SectionStandard, ChannelIDs: []string{"C1", "C2"}},
+		{ID: "L2", Name: "Teams", Type: SectionStandard, ChannelIDs: []string{"C2", "C3"}},
+		{ID: "L3", Name: "Channels", Type: SectionChannels},
+		{ID: "L4", Name: "Direct messages", Type: SectionDirectMessages},
+	}, map[string]bool{"C3": true})
+
+	cases := []struct {
+		id   string
+		dm   bool
+		want string
+	}{
+		{"C1", false, "Projects"},
+		{"C2", false, "Projects"}, // Listed twice: the first section wins
+		{"C3", false, "Muted"},    // Muted wins over its section
+		{"C9", false, "Channels"},
+		{"D1", true, "Direct messages"},
+	}
+	for _, c := range cases {
+		i := s.SectionOf(c.id, c.dm)
+		if i < 0 || s.Sections[i].Name != c.want {
+			t.Errorf("SectionOf(%s) = %d, want %s", c.id, i, c.want)
+		}
+	}
+	if i := s.FindSection("projects"); i != 0 {
+		t.Errorf("FindSection ignoring case = %d", i)
+	}
+	if i := s.FindSection("Nope"); i != -1 {
+		t.Errorf("FindSection(unknown) = %d", i)
+	}
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// Starred conversations. With a browser session token they're the sidebar's
+// Starred section, and starring moves a conversation into it as the web
+// client does. App tokens use the older stars.* methods, which Slack still
+// answers for channels.
+
+// StarredConversations returns the IDs of the conversations the user has
+// starred. Fetches are reused for sidebarTTL.
+func (ap *ApiProvider) StarredConversations(ctx context.Context) (map[string]bool, error) {
+	if ap.internalClient != nil {
+		sidebar, err := ap.Sidebar(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return sidebar.starred(), nil
+	}
+
+	ap.sidebar.mu.Lock()
+	defer ap.sidebar.mu.Unlock()
+	if ap.sidebar.stars != nil && time.Since(ap.sidebar.starsAt) < sidebarTTL {
+		return ap.sidebar.stars, nil
+	}
+	client, err := ap.Provide()
+	if err != nil {
+		return nil, err
+	}
+	items, err := client.ListAllStarsContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("stars.list: %w", err)
+	}
+	starred := make(map[string]bool)
+	for _, item := range items {
+		switch item.Type {
+		case "channel", "group", "im", "mpim":
+			starred[item.Channel] = true
+		}
+	}
+	ap.sidebar.stars, ap.sidebar.starsAt = starred, time.Now()
+	return starred, nil
+}
+
+// CachedStarredConversations returns the starred conversations from the
+// last fetch without calling Slack, or nil
+func (ap *ApiProvider) CachedStarredConversations() map[string]bool {
+	ap.sidebar.mu.Lock()
+	defer ap.sidebar.mu.Unlock()
+	if s := ap.sidebar.sidebar; s != nil {
+		return s.starred()
+	}
+	return ap.sidebar.stars
+}
+
+// starred collects the conversations in the Starred section
+func (s *Sidebar) starred() map[string]bool {
+	starred := make(map[string]bool)
+	for _, sec := range s.Sections {
+		if sec.Type == SectionStars {
+			for _, id := range sec.ChannelIDs {
+				starred[id] = true
+			}
+		}
+	}
+	return starred
+}
+
+// SetStarred stars or unstars a conversation
+func (ap *ApiProvider) SetStarred(ctx context.Context, channelID string, star bool) error {
+	defer ap.invalidateSidebar()
+
+	if ap.internalClient != nil {
+		sidebar, err := ap.Sidebar(ctx)
+		if err != nil {
+			return err
+		}
+		for _, sec := range sidebar.Sections {
+			if sec.Type == SectionStars {
+				return ap.internalClient.MoveToSection(ctx, channelID, sec.ID, star)
+			}
+		}
+		// No Starred section yet; stars.add creates one
+	}
+
+	client, err := ap.Provide()
+	if err != nil {
+		return err
+	}
+	ref := slack.ItemRef{Channel: channelID}
+	if star {
+		err = client.AddStarContext(ctx, channelID, ref)
+		if err != nil && err.Error() == "already_starred" {
+			err = nil
+		}
+	} else {
+		err = client.RemoveStarContext(ctx, channelID, ref)
+		if err != nil && err.Error() == "not_starred" {
+			err = nil
+		}
+	}
+	return err
+}
+
+// invalidateSidebar drops the cached sidebar and stars after a change
+func (ap *ApiProvider) invalidateSidebar() {
+	ap.sidebar.mu.Lock()
+	ap.sidebar.sidebar = nil
+	ap.sidebar.stars = nil
+	ap.sidebar.mu.Unlock()
+}
+
+// sectionChannels is one entry of a bulkUpdate insert or remove list
+type sectionChannels struct {
+	SectionID  string   `json:"channel_section_id"`
+	ChannelIDs []string `json:"channel_ids"`
+}
+
+// MoveToSection adds a conversation to a sidebar section, or with add
+// false takes it out (back to Slack's default section)
+func (c *InternalClient) MoveToSection(ctx context.Context, channelID, sectionID string, add bool) error {
+	change, err := json.Marshal([]sectionChannels{{SectionID: sectionID, ChannelIDs: []string{channelID}}})
+	if err != nil {
+		return err
+	}
+	params := url.Values{}
+	if add {
+		params.Set("insert", string(change))
+	} else {
+		params.Set("remove", string(change))
+	}
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error,omitempty"`
+	}
+	if err := c.callInternalAPI(ctx, "/api/users.channelSections.channels.bulkUpdate", params, &result); err != nil {
+		return err
+	}
+	if !result.OK {
+		return fmt.Errorf("users.channelSections.channels.bulkUpdate: %s", result.Error)
+	}
+	return nil
+}
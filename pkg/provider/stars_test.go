@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMoveToSection(t *testing.T) {
+	var gotPath, gotInsert, gotRemove string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotInsert, gotRemove = r.URL.Query().Get("insert"), r.URL.Query().Get("remove")
+		fmt.Fprint(w, `{"ok":true}`)
+	}))
+	defer srv.Close()
+	c := NewInternalClient("xoxc-test", "xoxd-test")
+	c.baseURL = srv.URL
+
+	if err := c.MoveToSection(context.Background(), "C1", "L9", true); err != nil {
+		t.Fatal(err)
+	}
+	if gotPath != "/api/users.channelSections.channels.bulkUpdate" {
+		t.Errorf("path = %s", gotPath)
+	}
+	if want := `[{"channel_section_id":"L9","channel_ids":["C1"]}]`; gotInsert != want || gotRemove != "" {
+		t.Errorf("insert = %q, remove = %q", gotInsert, gotRemove)
+	}
+
+	if err := c.MoveToSection(context.Background(), "C1", "L9", false); err != nil {
+		t.Fatal(err)
+	}
+	if gotInsert != "" || gotRemove == "" {
+		t.Errorf("unstar sent insert = %q, remove = %q", gotInsert, gotRemove)
+	}
+}
+
+func TestSidebarStarred(t *testing.T) {
+	s := newSidebar([]SidebarSection{
+		{ID: "L1", Name: "Starred", Type: SectionStars, ChannelIDs: []string{"C1", "D1"}},
+		{ID: "L2", Name: "Channels", Type: SectionChannels, ChannelIDs: []string{"C2"}},
+	}, map[string]bool{"C1": true})
+
+	starred := s.starred()
+	// Muting doesn't unstar
+	if !starred["C1"] || !starred["D1"] || starred["C2"] || len(starred) != 2 {
+		t.Errorf("starred = %v", starred)
+	}
+}
@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/cache"
+)
+
+const (
+	usageCacheFile = "usage.json"
+	usageKeepDays  = 30
+)
+
+// Usage action names recorded by features
+const (
+	UsageMessagesSent     = "messagesSent"
+	UsageReactions        = "reactions"
+	UsageChannelsCaughtUp = "channelsCaughtUp"
+	UsageMarkedRead       = "conversationsMarkedRead"
+)
+
+// DailyUsage holds activity counters for a single local calendar day
+type DailyUsage struct {
+	Date             string         `json:"date"`
+	APICalls         int            `json:"apiCalls"`
+	InternalAPICalls int            `json:"internalApiCalls"`
+	Methods          map[string]int `json:"methods,omitempty"`
+	Actions          map[string]int `json:"actions,omitempty"`
+	ToolCalls        map[string]int `json:"toolCalls,omitempty"`
+}
+
+// UsageTracker counts what the server does on the user's behalf so the
+// user can audit how much the agent is doing. Counters are bucketed by
+// local day and persisted alongside the other caches.
+type UsageTracker struct {
+	mu    sync.Mutex
+	days  map[string]*DailyUsage
+	store *cache.Store
+}
+
+func newUsageTracker(store *cache.Store) *UsageTracker {
+	u := &UsageTracker{
+		days:  make(map[string]*DailyUsage),
+		store: store,
+	}
+	if store != nil {
+		var saved []DailyUsage
+		if err := store.Load(usageCacheFile, &saved); err == nil {
+			for i := range saved {
+				day := saved[i]
+				u.days[day.Date] = &day
+			}
+		}
+	}
+	return u
+}
+
+// today returns the counters for the current day (caller must hold mu)
+func (u *UsageTracker) today() *DailyUsage {
+	key := time.Now().Format("2006-01-02")
+	day, ok := u.days[key]
+	if !ok {
+		day = &DailyUsage{
+			Date:      key,
+			Methods:   make(map[string]int),
+			Actions:   make(map[string]int),
+			ToolCalls: make(map[string]int),
+		}
+		u.days[key] = day
+	}
+	if day.Methods == nil {
+		day.Methods = make(map[string]int)
+	}
+	if day.Actions == nil {
+		day.Actions = make(map[string]int)
+	}
+	if day.ToolCalls == nil {
+		day.ToolCalls = make(map[string]int)
+	}
+	return day
+}
+
+func (u *UsageTracker) recordAPICall(method string) {
+	if u == nil {
+		return
+	}
+	u.mu.Lock()
+	day := u.today()
+	day.APICalls++
+	if method != "" {
+		day.Methods[method]++
+	}
+	u.mu.Unlock()
+	u.markDirty()
+}
+
+func (u *UsageTracker) recordInternalCall(endpoint string) {
+	if u == nil {
+		return
+	}
+	u.mu.Lock()
+	day := u.today()
+	day.InternalAPICalls++
+	day.Methods[strings.TrimPrefix(endpoint, "/api/")]++
+	u.mu.Unlock()
+	u.markDirty()
+}
+
+func (u *UsageTracker) recordAction(action string, n int) {
+	if u == nil || n == 0 {
+		return
+	}
+	u.mu.Lock()
+	u.today().Actions[action] += n
+	u.mu.Unlock()
+	u.markDirty()
+}
+
+func (u *UsageTracker) recordToolCall(tool string) {
+	if u == nil {
+		return
+	}
+	u.mu.Lock()
+	u.today().ToolCalls[tool]++
+	u.mu.Unlock()
+	u.markDirty()
+}
+
+func (u *UsageTracker) markDirty() {
+	if u.store != nil {
+		u.store.MarkDirty()
+	}
+}
+
+// snapshot returns copies of the most recent n days, newest first
+func (u *UsageTracker) snapshot(n int) []DailyUsage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.today()
+	keys := make([]string, 0, len(u.days))
+	for k := range u.days {
+		keys = append(keys, k)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+
+	out := make([]DailyUsage, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, copyDailyUsage(u.days[k]))
+	}
+	return out
+}
+
+// save prunes old days and writes the counters to disk
+func (u *UsageTracker) save() error {
+	if u.store == nil {
+		return nil
+	}
+	days := u.snapshot(usageKeepDays)
+
+	u.mu.Lock()
+	keep := make(map[string]bool, len(days))
+	for _, d := range days {
+		keep[d.Date] = true
+	}
+	for k := range u.days {
+		if !keep[k] {
+			delete(u.days, k)
+		}
+	}
+	u.mu.Unlock()
+
+	return u.store.Save(usageCacheFile, days)
+}
+
+func copyDailyUsage(d *DailyUsage) DailyUsage {
+	c := DailyUsage{
+		Date:             d.Date,
+		APICalls:         d.APICalls,
+		InternalAPICalls: d.InternalAPICalls,
+		Methods:          make(map[string]int, len(d.Methods)),
+		Actions:          make(map[string]int, len(d.Actions)),
+		ToolCalls:        make(map[string]int, len(d.ToolCalls)),
+	}
+	for k, v := range d.Methods {
+		c.Methods[k] = v
+	}
+	for k, v := range d.Actions {
+		c.Actions[k] = v
+	}
+	for k, v := range d.ToolCalls {
+		c.ToolCalls[k] = v
+	}
+	return c
+}
+
+// countingTransport records every official API request in the usage tracker
+type countingTransport struct {
+	next  http.RoundTripper
+	usage *UsageTracker
+}
+
+// RoundTrip implements the RoundTripper interface.
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	method := req.URL.Path
+	if i := strings.LastIndex(method, "/"); i >= 0 {
+		method = method[i+1:]
+	}
+	t.usage.recordAPICall(method)
+	return t.next.RoundTrip(req)
+}
+
+// RecordAction adds n to a named action counter for today (see Usage* constants)
+func (ap *ApiProvider) RecordAction(action string, n int) {
+	ap.usage.recordAction(action, n)
+	// Every mark-read path records here, including ones through the
+	// official API, so cached unread counts are dropped here too
+	if action == UsageMarkedRead && n > 0 && ap.internalClient != nil {
+		ap.internalClient.InvalidateCounts()
+	}
+}
+
+// RecordToolCall counts an MCP tool invocation for today
+func (ap *ApiProvider) RecordToolCall(tool string) {
+	ap.usage.recordToolCall(tool)
+}
+
+// UsageHistory returns usage counters for the most recent days, newest first.
+// The first entry is always today.
+func (ap *ApiProvider) UsageHistory(days int) []DailyUsage {
+	if ap.usage == nil {
+		return nil
+	}
+	if days < 1 {
+		days = 1
+	}
+	return ap.usage.snapshot(days)
+}
+
+// flushUsage persists usage counters; failures are logged, not fatal
+func (ap *ApiProvider) flushUsage() {
+	if ap.usage == nil {
+		return
+	}
+	if err := ap.usage.save(); err != nil {
+		log.Printf("Failed to save usage stats: %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// looksLikeEmail reports whether s has the shape of an email address
+func looksLikeEmail(s string) bool {
+	at := strings.Index(s, "@")
+	return at > 0 && at == strings.LastIndex(s, "@") && strings.Contains(s[at+1:], ".")
+}
+
+// ResolveUserByEmail maps an email address to a user. Cached profiles are
+// checked first; otherwise users.lookupByEmail is called and the user is
+// added to the cache, so addresses taken from calendars or ticketing
+// systems work wherever a person is expected.
+func (ap *ApiProvider) ResolveUserByEmail(ctx context.Context, email string) (*slack.User, error) {
+	email = strings.TrimSpace(email)
+	if !looksLikeEmail(email) {
+		return nil, fmt.Errorf("not an email address: %q", email)
+	}
+	if user, ok := ap.cachedUserByEmail(email); ok {
+		return &user, nil
+	}
+
+	client, err := ap.Provide()
+	if err != nil {
+		return nil, err
+	}
+	user, err := client.GetUserByEmailContext(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("lookup %s: %w", email, err)
+	}
+
+	ap.usersMutex.Lock()
+	ap.users[user.ID] = *user
+	ap.usersMutex.Unlock()
+	ap.markDirty()
+	return user, nil
+}
+
+func (ap *ApiProvider) cachedUserByEmail(email string) (slack.User, bool) {
+	ap.usersMutex.RLock()
+	defer ap.usersMutex.RUnlock()
+	for _, user := range ap.users {
+		if user.Profile.Email != "" && strings.EqualFold(user.Profile.Email, email) {
+			return user, true
+		}
+	}
+	return slack.User{}, false
+}
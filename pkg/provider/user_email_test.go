@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestLooksLikeEmail(t *testing.T) {
+	for s, want := range map[string]bool{
+		"alice@example.com":    true,
+		"a.b+tag@corp.example": true,
+		"@alice":               false,
+		"alice":                false,
+		"alice@localhost":      false,
+		"a@b@example.com":      false,
+	} {
+		if got := looksLikeEmail(s); got != want {
+			t.Errorf("looksLikeEmail(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestResolveUserByEmailUsesCachedProfiles(t *testing.T) {
+	ap := newTestProvider()
+	ap.users = map[string]slack.User{
+		"U1": {ID: "U1", Name: "alice", Profile: slack.UserProfile{Email: "Alice@Example.com"}},
+	}
+	ap.dmMap = map[string]string{}
+
+	user, err := ap.ResolveUserByEmail(context.Background(), "alice@example.com")
+	if err != nil || user.ID != "U1" {
+		t.Fatalf("ResolveUserByEmail = %+v, %v; want U1", user, err)
+	}
+	if id := ap.findUserID("alice@example.com"); id != "U1" {
+		t.Fatalf("findUserID by email = %q, want U1", id)
+	}
+}
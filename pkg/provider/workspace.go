@@ -32,6 +32,32 @@ func (wm *WorkspaceManager) AddWorkspace(name, xoxcToken, xoxdToken string) {
 	}
 }
 
+// AddProvider registers an already-built provider, such as a named
+// identity from NewIdentity
+func (wm *WorkspaceManager) AddProvider(name string, p *ApiProvider) {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+
+	wm.providers[name] = p
+	if wm.defaultWorkspace == "" {
+		wm.defaultWorkspace = name
+	}
+}
+
+// ReplaceProvider swaps in a new provider for name, returning the one it
+// replaced (nil if none) so the caller can close it
+func (wm *WorkspaceManager) ReplaceProvider(name string, p *ApiProvider) *ApiProvider {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+
+	old := wm.providers[name]
+	wm.providers[name] = p
+	if wm.defaultWorkspace == "" {
+		wm.defaultWorkspace = name
+	}
+	return old
+}
+
 // SetDefault sets the default workspace
 func (wm *WorkspaceManager) SetDefault(name string) {
 	wm.mu.Lock()
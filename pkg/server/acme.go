@@ -0,0 +1,24 @@
+package server
+
+import (
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// newACMEManager returns a manager that obtains and renews a certificate
+// for the configured domains from Let's Encrypt. It answers TLS-ALPN-01
+// challenges on the server's own listener and HTTP-01 challenges through
+// its HTTP handler. Issued certificates and the account key are cached on
+// disk and reused across restarts.
+func newACMEManager(t TLSSettings) *autocert.Manager {
+	m := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Cache:      autocert.DirCache(t.CacheDir),
+		HostPolicy: autocert.HostWhitelist(t.Domains...),
+		Email:      t.Email,
+	}
+	if t.Directory != "" {
+		m.Client = &acme.Client{DirectoryURL: t.Directory}
+	}
+	return m
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/aaronsb/slack-mcp/pkg/features"
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+// Tools that change something and so accept an idempotencyKey
+var idempotentTools = map[string]bool{
+	"send-message":              true,
+	"revise-draft":              true,
+	"post-snippet":              true,
+	"send-nudge":                true,
+	"react":                     true,
+	"quick-respond":             true,
+	"mark-read":                 true,
+	"log-decision":              true,
+	"create-ticket-from-thread": true,
+	"flush-outbox":              true,
+	"batch":                     true,
+}
+
+// idempotencyKeyOption describes the idempotencyKey parameter
+var idempotencyKeyOption = map[string]interface{}{
+	"type":        "string",
+	"description": "Any unique string for this action. Retrying with the same key returns the first call's result instead of acting twice. Use a new key for each distinct action.",
+}
+
+// callFingerprint identifies a call's arguments, so a key reused for a
+// different call is caught rather than replayed
+func callFingerprint(args map[string]interface{}) string {
+	clean := make(map[string]interface{}, len(args))
+	for k, v := range args {
+		if k != "idempotencyKey" {
+			clean[k] = v
+		}
+	}
+	// Map keys marshal sorted, so equal arguments hash equally
+	raw, _ := json.Marshal(clean)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:16])
+}
+
+// beginIdempotent claims the key for this call. It returns a result to
+// hand back instead of running the tool — the replayed original, or why
+// the key can't be used — or a finish func to pass the tool's result to
+// once it returns. Only successful results are remembered, so a call that
+// failed can be retried under the same key — except a batch where some
+// operations went ahead, which a retry must not run again.
+func beginIdempotent(ctx context.Context, p *provider.ApiProvider, tool, key, fingerprint string) (*features.FeatureResult, func(*features.FeatureResult)) {
+	replay, done, err := p.BeginIdempotent(ctx, tool, key, fingerprint)
+	if err != nil {
+		return &features.FeatureResult{
+			Success:  false,
+			Message:  err.Error(),
+			Guidance: "Use a new idempotencyKey for each distinct action",
+		}, nil
+	}
+	if replay != nil {
+		var result features.FeatureResult
+		if err := json.Unmarshal(replay, &result); err != nil {
+			return &features.FeatureResult{
+				Success:  false,
+				Message:  "This idempotencyKey was already used, but its result can't be read back",
+				Guidance: "Check whether the action happened before retrying with a new idempotencyKey",
+			}, nil
+		}
+		note := "🔁 Replayed the result of an earlier call with this idempotencyKey; nothing was done again."
+		if result.Guidance != "" {
+			note += " " + result.Guidance
+		}
+		result.Guidance = note
+		return &result, nil
+	}
+	return nil, func(result *features.FeatureResult) {
+		if result == nil || !(result.Success || tool == "batch" && features.BatchActed(result)) {
+			done(nil)
+			return
+		}
+		raw, err := json.Marshal(result)
+		if err != nil {
+			done(nil)
+			return
+		}
+		done(raw)
+	}
+}
+
+// runKeyed runs a tool call within the limits, passing its result to
+// finish (from beginIdempotent, or nil) when the handler returns — even
+// past a timeout, so a retry waits for it rather than acting twice. A call
+// that never ran releases its key, so a retry isn't left waiting on it.
+func (l *toolLimiter) runKeyed(ctx context.Context, tool string, finish func(*features.FeatureResult), handler func(context.Context) (*features.FeatureResult, error)) (result *features.FeatureResult, busy bool, err error) {
+	result, started, err := l.run(ctx, tool, func(ctx context.Context) (*features.FeatureResult, error) {
+		r, err := handler(ctx)
+		if finish != nil {
+			finish(r)
+		}
+		return r, err
+	})
+	if !started && finish != nil {
+		finish(nil)
+	}
+	return result, !started && err == nil, err
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/features"
+	"github.com/aaronsb/slack-mcp/pkg/provider"
+)
+
+func TestCallFingerprint(t *testing.T) {
+	a := callFingerprint(map[string]interface{}{"channel": "#general", "message": "hi", "idempotencyKey": "k1"})
+	b := callFingerprint(map[string]interface{}{"message": "hi", "channel": "#general", "idempotencyKey": "k2"})
+	if a != b {
+		t.Error("fingerprint should ignore the key and argument order")
+	}
+	if c := callFingerprint(map[string]interface{}{"channel": "#general", "message": "hello"}); c == a {
+		t.Error("different arguments should fingerprint differently")
+	}
+}
+
+func TestRunKeyedReleasesKeyWhenNotStarted(t *testing.T) {
+	t.Setenv("SLACK_MCP_DATA_DIR", t.TempDir())
+	p := provider.NewWithTokens("xoxp-test", "")
+	l := testLimiter(1, 0)
+	l.wait = time.Minute
+
+	// Fill the only slot
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go l.run(context.Background(), "slow", func(context.Context) (*features.FeatureResult, error) {
+		close(started)
+		<-release
+		return &features.FeatureResult{Success: true}, nil
+	})
+	<-started
+
+	// The caller gives up while waiting for a slot
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	replay, finish := beginIdempotent(ctx, p, "send-message", "k1", "fp")
+	if replay != nil || finish == nil {
+		t.Fatalf("first call should run: %+v", replay)
+	}
+	if _, busy, err := l.runKeyed(ctx, "send-message", finish, func(context.Context) (*features.FeatureResult, error) {
+		t.Error("ran while the only slot was taken")
+		return nil, nil
+	}); busy || err == nil {
+		t.Fatalf("busy = %v, err = %v", busy, err)
+	}
+	close(release)
+
+	// The retry runs rather than waiting on the abandoned call
+	retryCtx, cancelRetry := context.WithTimeout(context.Background(), time.Second)
+	defer cancelRetry()
+	replay, finish = beginIdempotent(retryCtx, p, "send-message", "k1", "fp")
+	if replay != nil || finish == nil {
+		t.Fatalf("retry should run, got %+v", replay)
+	}
+	result, busy, err := l.runKeyed(retryCtx, "send-message", finish, func(context.Context) (*features.FeatureResult, error) {
+		return &features.FeatureResult{Success: true, Message: "sent"}, nil
+	})
+	if busy || err != nil || !result.Success {
+		t.Fatalf("retry: result = %+v, busy = %v, err = %v", result, busy, err)
+	}
+}
+
+func TestPartialBatchIsNotRepeated(t *testing.T) {
+	t.Setenv("SLACK_MCP_DATA_DIR", t.TempDir())
+	p := provider.NewWithTokens("xoxp-test", "")
+	l := testLimiter(1, 0)
+
+	var sent []string
+	registry := features.NewRegistry()
+	registry.Register(&features.Feature{Name: "send-message", Handler: func(ctx context.Context, params map[string]interface{}) (*features.FeatureResult, error) {
+		if params["fail"] == true {
+			return &features.FeatureResult{Success: false, Message: "failed"}, nil
+		}
+		sent = append(sent, params["message"].(string))
+		return &features.FeatureResult{Success: true, Message: "sent"}, nil
+	}})
+	batch := features.NewBatch(registry)
+	registry.Register(batch)
+
+	args := map[string]interface{}{
+		"operations": []interface{}{
+			map[string]interface{}{"tool": "send-message", "params": map[string]interface{}{"message": "one"}},
+			map[string]interface{}{"tool": "send-message", "params": map[string]interface{}{"message": "two"}},
+			map[string]interface{}{"tool": "send-message", "params": map[string]interface{}{"fail": true}},
+		},
+	}
+	call := func() *features.FeatureResult {
+		replay, finish := beginIdempotent(context.Background(), p, "batch", "k1", callFingerprint(args))
+		if replay != nil {
+			return replay
+		}
+		params := map[string]interface{}{"operations": args["operations"], "_provider": p}
+		result, _, err := l.runKeyed(context.Background(), "batch", finish, func(ctx context.Context) (*features.FeatureResult, error) {
+			return batch.Handler(ctx, params)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	if result := call(); result.Success || len(sent) != 2 {
+		t.Fatalf("first call: success = %v, sent = %v", result.Success, sent)
+	}
+	result := call()
+	if len(sent) != 2 {
+		t.Fatalf("retry sent again: %v", sent)
+	}
+	if !strings.Contains(result.Guidance, "Replayed") {
+		t.Errorf("retry should replay the first result, got %+v", result)
+	}
+}
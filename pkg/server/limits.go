@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/features"
+)
+
+// Tool execution limits:
+//
+//	SLACK_MCP_MAX_CONCURRENT="4"                        tool calls run at once (default 4; "off" for no limit)
+//	SLACK_MCP_TOOL_TIMEOUT="2m"                         per-call time limit (default 2m; "off" for none)
+//	SLACK_MCP_TOOL_TIMEOUTS="search-semantic=5m,..."    per-tool overrides
+const (
+	defaultMaxConcurrent = 4
+	defaultToolTimeout   = 2 * time.Minute
+
+	// How long a call waits for a free slot before it's turned away
+	slotWait = 3 * time.Second
+	// How long a timed-out handler gets to hand back what it has
+	timeoutGrace = 3 * time.Second
+)
+
+// Tools that wait on a person and so get no time limit
+var timeoutExempt = map[string]bool{
+	"auth-setup": true,
+}
+
+// toolLimiter caps concurrent tool calls and how long each may run, so an
+// agent fanning out parallel calls can't push the account into Slack's
+// rate limits
+type toolLimiter struct {
+	slots    chan struct{} // nil for no limit
+	running  atomic.Int32
+	timeout  time.Duration
+	timeouts map[string]time.Duration
+	wait     time.Duration // slotWait; shortened in tests
+	grace    time.Duration // timeoutGrace
+}
+
+func newToolLimiter() *toolLimiter {
+	l := &toolLimiter{
+		timeout:  envDuration("SLACK_MCP_TOOL_TIMEOUT", defaultToolTimeout),
+		timeouts: parseToolTimeouts(os.Getenv("SLACK_MCP_TOOL_TIMEOUTS")),
+		wait:     slotWait,
+		grace:    timeoutGrace,
+	}
+	if n := loadMaxConcurrent(); n > 0 {
+		l.slots = make(chan struct{}, n)
+	}
+	return l
+}
+
+// timeoutFor returns the tool's time limit, or 0 for none
+func (l *toolLimiter) timeoutFor(tool string) time.Duration {
+	if timeoutExempt[tool] {
+		return 0
+	}
+	if d, ok := l.timeouts[tool]; ok {
+		return d
+	}
+	return l.timeout
+}
+
+// run executes a handler within the limits. started is false when the
+// handler never ran: with a nil err no slot freed up in time (the call is
+// busy), otherwise the context ended while waiting. A handler that
+// overruns gets a short grace period to return partial results, after
+// which the call fails; the handler keeps its slot until it actually
+// returns.
+func (l *toolLimiter) run(ctx context.Context, tool string, handler func(context.Context) (*features.FeatureResult, error)) (result *features.FeatureResult, started bool, err error) {
+	if l.slots != nil {
+		wait := time.NewTimer(l.wait)
+		defer wait.Stop()
+		select {
+		case l.slots <- struct{}{}:
+		case <-wait.C:
+			return nil, false, nil
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	}
+	l.running.Add(1)
+
+	limit := l.timeoutFor(tool)
+	callCtx, cancel := ctx, context.CancelFunc(func() {})
+	if limit > 0 {
+		callCtx, cancel = context.WithTimeout(ctx, limit)
+	}
+
+	type outcome struct {
+		result *features.FeatureResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		defer func() {
+			cancel()
+			l.running.Add(-1)
+			if l.slots != nil {
+				<-l.slots
+			}
+		}()
+		r, err := handler(callCtx)
+		done <- outcome{r, err}
+	}()
+
+	var out outcome
+	select {
+	case out = <-done:
+	case <-callCtx.Done():
+		if ctx.Err() != nil {
+			return nil, true, ctx.Err()
+		}
+		select {
+		case out = <-done:
+		case <-time.After(l.grace):
+			log.Printf("%s still running after its %s limit; its result will be dropped", tool, limit)
+			return timedOut(tool, limit), true, nil
+		}
+	}
+	if out.err == nil && out.result != nil && limit > 0 && callCtx.Err() == context.DeadlineExceeded {
+		markPartial(out.result, limit)
+	}
+	return out.result, true, out.err
+}
+
+// busyResponse is the structured refusal for a call that found no free slot
+func (l *toolLimiter) busyResponse(tool string) string {
+	data, _ := json.MarshalIndent(map[string]interface{}{
+		"status":            "busy",
+		"message":           fmt.Sprintf("%s was not run: %d tool calls are already running (limit %d)", tool, l.running.Load(), cap(l.slots)),
+		"retryAfterSeconds": max(1, int(l.wait.Seconds())),
+		"hint":              "Wait for running calls to finish and retry. Run Slack tools a few at a time rather than fanning out in parallel.",
+	}, "", "  ")
+	return string(data)
+}
+
+func timedOut(tool string, limit time.Duration) *features.FeatureResult {
+	return &features.FeatureResult{
+		Success:  false,
+		Message:  fmt.Sprintf("%s didn't finish within %s", tool, limit),
+		Guidance: "Narrow the request (fewer channels, a shorter period, a smaller limit) and try again",
+	}
+}
+
+// markPartial notes on a result that the handler was cut off
+func markPartial(result *features.FeatureResult, limit time.Duration) {
+	note := fmt.Sprintf("⚠️ Stopped at the %s time limit; results may be incomplete", limit)
+	if result.Guidance != "" {
+		note += ". " + result.Guidance
+	}
+	result.Guidance = note
+	result.MarkPartial(features.Coverage{
+		Reason: features.PartialTimeout,
+		Detail: fmt.Sprintf("stopped at the %s time limit", limit),
+	})
+}
+
+func loadMaxConcurrent() int {
+	spec := strings.TrimSpace(os.Getenv("SLACK_MCP_MAX_CONCURRENT"))
+	if spec == "" {
+		return defaultMaxConcurrent
+	}
+	if strings.EqualFold(spec, "off") {
+		return 0
+	}
+	n, err := strconv.Atoi(spec)
+	if err != nil || n < 1 {
+		log.Printf("Ignoring SLACK_MCP_MAX_CONCURRENT: invalid count %q", spec)
+		return defaultMaxConcurrent
+	}
+	return n
+}
+
+// parseToolTimeouts reads "tool=duration" pairs; "off" lifts a tool's limit
+func parseToolTimeouts(spec string) map[string]time.Duration {
+	out := make(map[string]time.Duration)
+	for _, pair := range strings.Split(spec, ",") {
+		tool, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			continue
+		}
+		tool, val = strings.TrimSpace(tool), strings.TrimSpace(val)
+		if strings.EqualFold(val, "off") {
+			out[tool] = 0
+			continue
+		}
+		d, err := time.ParseDuration(val)
+		if err != nil || d < time.Second {
+			log.Printf("Ignoring SLACK_MCP_TOOL_TIMEOUTS entry %q", pair)
+			continue
+		}
+		out[tool] = d
+	}
+	return out
+}
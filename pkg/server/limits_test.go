@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/features"
+)
+
+func testLimiter(slots int, timeout time.Duration) *toolLimiter {
+	return &toolLimiter{
+		slots:    make(chan struct{}, slots),
+		timeout:  timeout,
+		timeouts: map[string]time.Duration{},
+		wait:     20 * time.Millisecond,
+		grace:    20 * time.Millisecond,
+	}
+}
+
+func TestToolLimiterBusy(t *testing.T) {
+	l := testLimiter(1, 0)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go l.run(context.Background(), "slow", func(context.Context) (*features.FeatureResult, error) {
+		close(started)
+		<-release
+		return &features.FeatureResult{Success: true}, nil
+	})
+	<-started
+
+	_, gotSlot, err := l.run(context.Background(), "search", func(context.Context) (*features.FeatureResult, error) {
+		t.Error("ran while the only slot was taken")
+		return nil, nil
+	})
+	if gotSlot || err != nil {
+		t.Fatalf("gotSlot = %v, err = %v", gotSlot, err)
+	}
+	if resp := l.busyResponse("search"); !strings.Contains(resp, `"status": "busy"`) {
+		t.Errorf("busy response = %s", resp)
+	}
+
+	close(release)
+	time.Sleep(10 * time.Millisecond)
+	if result, gotSlot, _ := l.run(context.Background(), "search", func(context.Context) (*features.FeatureResult, error) {
+		return &features.FeatureResult{Success: true}, nil
+	}); !gotSlot || !result.Success {
+		t.Errorf("slot not released: gotSlot = %v", gotSlot)
+	}
+}
+
+func TestToolLimiterTimeout(t *testing.T) {
+	l := testLimiter(2, 20*time.Millisecond)
+
+	// A handler that honors the deadline hands back what it has
+	result, _, _ := l.run(context.Background(), "catch-up", func(ctx context.Context) (*features.FeatureResult, error) {
+		<-ctx.Done()
+		return &features.FeatureResult{Success: true, Message: "3 of 5 channels"}, nil
+	})
+	if !result.Success || !strings.Contains(result.Guidance, "time limit") {
+		t.Errorf("partial result = %+v", result)
+	}
+	if !result.Partial || len(result.Coverage) != 1 || result.Coverage[0].Reason != features.PartialTimeout {
+		t.Errorf("coverage = %+v", result.Coverage)
+	}
+
+	// One that doesn't is given up on
+	stuck := make(chan struct{})
+	defer close(stuck)
+	result, _, _ = l.run(context.Background(), "catch-up", func(context.Context) (*features.FeatureResult, error) {
+		<-stuck
+		return nil, nil
+	})
+	if result.Success || !strings.Contains(result.Message, "didn't finish") {
+		t.Errorf("timed out result = %+v", result)
+	}
+
+	l.timeouts["auth-setup"] = time.Hour
+	if d := l.timeoutFor("auth-setup"); d != 0 {
+		t.Errorf("auth-setup limit = %s", d)
+	}
+}
+
+func TestParseToolTimeouts(t *testing.T) {
+	got := parseToolTimeouts("search-semantic=5m, list-channels=off, bad, broken=soon")
+	if got["search-semantic"] != 5*time.Minute || got["list-channels"] != 0 || len(got) != 2 {
+		t.Errorf("parseToolTimeouts = %v", got)
+	}
+}
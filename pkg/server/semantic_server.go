@@ -5,8 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"sort"
+	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/aaronsb/slack-mcp/pkg/features"
 	"github.com/aaronsb/slack-mcp/pkg/provider"
@@ -19,10 +23,24 @@ type SemanticMCPServer struct {
 	server   *server.MCPServer
 	registry *features.Registry
 	provider atomic.Pointer[provider.ApiProvider]
+
+	// Named identities; when set, each tool call names the one acting
+	identities *provider.WorkspaceManager
+
+	limits *toolLimiter
+}
+
+// Tools that work without an acting identity: they don't touch Slack as
+// anyone
+var identityExempt = map[string]bool{
+	"auth-setup": true,
 }
 
-// NewSemanticMCPServer creates a new semantic MCP server
-func NewSemanticMCPServer(provider *provider.ApiProvider) *SemanticMCPServer {
+const auditLogFile = "audit.jsonl"
+
+// NewSemanticMCPServer creates a new semantic MCP server. identities may be
+// nil; see SemanticMCPServer.identities.
+func NewSemanticMCPServer(provider *provider.ApiProvider, identities *provider.WorkspaceManager) *SemanticMCPServer {
 	// Get personality from environment, default to "slack-user"
 	personality := os.Getenv("SLACK_MCP_PERSONALITY")
 	if personality == "" {
@@ -43,22 +61,72 @@ func NewSemanticMCPServer(provider *provider.ApiProvider) *SemanticMCPServer {
 
 	// Register all available features
 	registry.Register(features.CheckUnreads)
+	registry.Register(features.RecentActivityTimeline)
 	registry.Register(features.CatchUpOnChannel)
+	registry.Register(features.CatchUpOnPerson)
+	registry.Register(features.SetMeetings)
+	registry.Register(features.PrepForMeeting)
 	registry.Register(features.ListChannels)
+	registry.Register(features.ListDMs)
+	registry.Register(features.SuggestChannel)
+	registry.Register(features.DiscoverChannels)
+	registry.Register(features.AnalyzeChannelOverlap)
+	registry.Register(features.RankMyChannels)
 	registry.Register(features.CheckMyMentions)
+	registry.Register(features.GetRecentEvents)
 	registry.Register(features.FindDiscussion)
+	registry.Register(features.CheckSavedSearches)
+	registry.Register(features.TopicTimeline)
+	registry.Register(features.SearchSemantic)
+	registry.Register(features.FindExpert)
 	registry.Register(features.PaceConversation)
+	registry.Register(features.ChannelActivityProfile)
+	registry.Register(features.ChannelHealth)
+	registry.Register(features.BriefMeOnChannel)
+	registry.Register(features.GenerateTeamReport)
 	registry.Register(features.WriteMessage)
+	registry.Register(features.ReviseDraft)
+	registry.Register(features.ListOutbox)
+	registry.Register(features.FlushOutbox)
+	registry.Register(features.PostSnippet)
+	registry.Register(features.LogDecision)
+	registry.Register(features.CreateTicketFromThread)
+	registry.Register(features.CheckMessageReach)
+	registry.Register(features.CheckReactionsToMe)
+	registry.Register(features.SendNudge)
 	registry.Register(features.MarkAsRead)
 	registry.Register(features.GetContext)
+	registry.Register(features.GetReplyContext)
+	registry.Register(features.ReadMessages)
+	registry.Register(features.ReadThread)
+	registry.Register(features.ListMyThreads)
+	registry.Register(features.CollectMessage)
+	registry.Register(features.ListCollection)
 	registry.Register(features.React)
+	registry.Register(features.QuickRespond)
+	registry.Register(features.StarChannel)
+	registry.Register(features.UnstarChannel)
 	registry.Register(features.ListUsers)
+	registry.Register(features.ExportDirectory)
+	registry.Register(features.ExportToVault)
 	registry.Register(features.AuthSetup)
+	registry.Register(features.RefreshCredentials)
 	registry.Register(features.DownloadFile)
+	registry.Register(features.UsageStats)
+	registry.Register(features.Capabilities)
+	registry.Register(features.WorkspaceOverview)
+	registry.Register(features.DebugInternal)
+	registry.Register(features.PauseBackgroundRefresh)
+	registry.Register(features.PurgeLocalData)
+	registry.Register(features.NewBatch(registry))
 
 	semanticServer := &SemanticMCPServer{
 		server:   s,
 		registry: registry,
+		limits:   newToolLimiter(),
+	}
+	if identities != nil && identities.WorkspaceCount() > 0 {
+		semanticServer.identities = identities
 	}
 	if provider != nil {
 		semanticServer.provider.Store(provider)
@@ -98,6 +166,16 @@ func (s *SemanticMCPServer) registerFeature(feature *features.Feature) {
 			}
 		}
 	}
+	if idempotentTools[feature.Name] {
+		toolOptions = append(toolOptions, s.createToolOption("idempotencyKey", idempotencyKeyOption, nil)...)
+	}
+	actingRequired := s.identities != nil && !identityExempt[feature.Name]
+	if actingRequired {
+		toolOptions = append(toolOptions, s.createToolOption("actingAs", map[string]interface{}{
+			"type":        "string",
+			"description": fmt.Sprintf("Identity acting for this call, one of: %s. Use the identity of the person you're working for.", strings.Join(s.identityNames(), ", ")),
+		}, []string{"actingAs"})...)
+	}
 
 	// Create handler wrapper
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -106,10 +184,14 @@ func (s *SemanticMCPServer) registerFeature(feature *features.Feature) {
 		for k, v := range request.GetArguments() {
 			params[k] = v
 		}
+		idempotencyKey, _ := params["idempotencyKey"].(string)
+		delete(params, "idempotencyKey")
 
 		// Provide a callback so auth-setup can hot-load the provider after success
 		params["_setProvider"] = func(p *provider.ApiProvider) {
-			s.provider.Store(p)
+			if old := s.provider.Swap(p); old != nil && old != p {
+				old.Close()
+			}
 			log.Println("Provider hot-loaded after successful auth setup")
 			go func() {
 				log.Println("Booting provider in background after auth...")
@@ -123,7 +205,34 @@ func (s *SemanticMCPServer) registerFeature(feature *features.Feature) {
 
 		// Add provider to params for features that need it
 		p := s.provider.Load()
-		if p == nil && feature.Name != "auth-setup" {
+		identity := ""
+		if actingRequired {
+			identity, _ = params["actingAs"].(string)
+			delete(params, "actingAs")
+			ip, err := s.actingProvider(identity)
+			if err != nil {
+				return mcp.NewToolResultText(features.FormatResult(feature.Name, &features.FeatureResult{
+					Success:  false,
+					Message:  err.Error(),
+					Guidance: fmt.Sprintf("Pass actingAs with one of: %s. Never guess; ask the user which identity to act as.", strings.Join(s.identityNames(), ", ")),
+				})), nil
+			}
+			p = ip
+			// A swap replaces this identity's provider, not the default one
+			params["_identity"] = identity
+			params["_setProvider"] = func(np *provider.ApiProvider) {
+				if old := s.identities.ReplaceProvider(identity, np); old != nil && old != np {
+					old.Close()
+				}
+				log.Printf("Provider for identity %q hot-loaded", identity)
+				go func() {
+					if _, err := np.Provide(); err != nil {
+						log.Printf("Warning: identity %q failed to boot: %v", identity, err)
+					}
+				}()
+			}
+		}
+		if p == nil && feature.Name != "auth-setup" && feature.Name != "purge-local-data" {
 			guidance := map[string]interface{}{
 				"status":  "setup_needed",
 				"message": "Slack credentials are not configured yet. Use the auth-setup tool to connect a workspace.",
@@ -133,15 +242,42 @@ func (s *SemanticMCPServer) registerFeature(feature *features.Feature) {
 			return mcp.NewToolResultText(string(jsonData)), nil
 		}
 		params["_provider"] = p
+		if p != nil {
+			p.RecordToolCall(feature.Name)
+			features.Depseudonymize(p, params)
+		}
 
-		// Execute feature
-		result, err := feature.Handler(ctx, params)
-		if err != nil {
-			return nil, err
+		// Execute feature, unless the token type rules it out
+		result := features.TokenUnsupported(p, feature.Name)
+
+		// A retry with a used idempotency key gets the first result back
+		var finish func(*features.FeatureResult)
+		if result == nil && idempotencyKey != "" && idempotentTools[feature.Name] && p.IdempotencyEnabled() {
+			result, finish = beginIdempotent(ctx, p, feature.Name, idempotencyKey, callFingerprint(request.GetArguments()))
+		}
+
+		if result == nil {
+			var busy bool
+			var err error
+			result, busy, err = s.limits.runKeyed(ctx, feature.Name, finish, func(ctx context.Context) (*features.FeatureResult, error) {
+				return feature.Handler(ctx, params)
+			})
+			if err != nil {
+				return nil, err
+			}
+			if busy {
+				return mcp.NewToolResultText(s.limits.busyResponse(feature.Name)), nil
+			}
+		}
+
+		if identity != "" {
+			s.audit(p, identity, feature.Name, result)
 		}
 
-		// Format as markdown for AI consumption
-		text := features.FormatResult(feature.Name, result)
+		// Format as markdown for AI consumption, withholding confidential
+		// channels and secrets before anything leaves the server
+		text := features.FormatResult(feature.Name, features.RedactResult(feature.Name, result))
+		text = features.RedactText(features.Pseudonymize(p, text))
 		return mcp.NewToolResultText(text), nil
 	}
 
@@ -149,6 +285,44 @@ func (s *SemanticMCPServer) registerFeature(feature *features.Feature) {
 	s.server.AddTool(mcp.NewTool(feature.Name, toolOptions...), handler)
 }
 
+// actingProvider returns the named identity's provider. There's no
+// default: a missing or unknown name is refused so nothing is sent from
+// the wrong account.
+func (s *SemanticMCPServer) actingProvider(name string) (*provider.ApiProvider, error) {
+	if name == "" {
+		return nil, fmt.Errorf("actingAs is required: this server is shared by several identities")
+	}
+	p, err := s.identities.GetProvider(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown identity %q", name)
+	}
+	return p, nil
+}
+
+func (s *SemanticMCPServer) identityNames() []string {
+	names := s.identities.ListWorkspaces()
+	sort.Strings(names)
+	return names
+}
+
+// audit appends a tool call to the acting identity's audit log. Only the
+// tool and outcome are kept, not message content.
+func (s *SemanticMCPServer) audit(p *provider.ApiProvider, identity, tool string, result *features.FeatureResult) {
+	store := p.Store()
+	if store == nil {
+		return
+	}
+	line, _ := json.Marshal(map[string]interface{}{
+		"time":     time.Now().Format(time.RFC3339),
+		"identity": identity,
+		"tool":     tool,
+		"success":  result.Success,
+	})
+	if err := store.Append(auditLogFile, line); err != nil {
+		log.Printf("Could not write audit log for %s: %v", identity, err)
+	}
+}
+
 // createToolOption converts schema properties to MCP tool options
 func (s *SemanticMCPServer) createToolOption(name string, prop map[string]interface{}, required []string) []mcp.ToolOption {
 	options := []mcp.ToolOption{}
@@ -188,6 +362,28 @@ func (s *SemanticMCPServer) createToolOption(name string, prop map[string]interf
 		// Just skip default for now
 		options = append(options, opt)
 
+	case "number":
+		opts := []mcp.PropertyOption{mcp.Description(desc)}
+		if isRequired {
+			opts = append(opts, mcp.Required())
+		}
+		if def, ok := prop["default"].(int); ok {
+			opts = append(opts, mcp.DefaultNumber(float64(def)))
+		} else if def, ok := prop["default"].(float64); ok {
+			opts = append(opts, mcp.DefaultNumber(def))
+		}
+		options = append(options, mcp.WithNumber(name, opts...))
+
+	case "object":
+		opts := []mcp.PropertyOption{mcp.Description(desc)}
+		if isRequired {
+			opts = append(opts, mcp.Required())
+		}
+		if props, ok := prop["properties"].(map[string]interface{}); ok {
+			opts = append(opts, mcp.Properties(props))
+		}
+		options = append(options, mcp.WithObject(name, opts...))
+
 	case "array":
 		items := map[string]any{"type": "string"}
 		if itemsProp, ok := prop["items"].(map[string]interface{}); ok {
@@ -241,7 +437,37 @@ func (s *SemanticMCPServer) registerResources() {
 				mcp.TextResourceContents{
 					URI:      "slack-mcp://identity",
 					MIMEType: "application/json",
-					Text:     string(data),
+					Text:     features.Pseudonymize(p, string(data)),
+				},
+			}, nil
+		},
+	)
+
+	// Event log resource — the same rolling log get-recent-events polls
+	s.server.AddResource(
+		mcp.Resource{
+			URI:         "slack-mcp://events/recent",
+			Name:        "Recent Events",
+			Description: "The rolling local log of mentions, DMs, thread replies, and reactions to your messages, oldest first. Use the get-recent-events tool to read only what's new since a previous look.",
+			MIMEType:    "application/json",
+		},
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			text := `{"status": "not_authenticated", "message": "Use auth-setup to connect a workspace"}`
+			if p := s.provider.Load(); p != nil {
+				events, err := p.RecentEvents(ctx, "", nil)
+				if err != nil && len(events) == 0 {
+					text = fmt.Sprintf(`{"status": "unavailable", "message": %q}`, err.Error())
+				} else {
+					events = features.RedactEvents(ctx, p, events)
+					data, _ := json.MarshalIndent(map[string]interface{}{"events": events}, "", "  ")
+					text = features.RedactText(features.Pseudonymize(p, string(data)))
+				}
+			}
+			return []mcp.ResourceContents{
+				mcp.TextResourceContents{
+					URI:      "slack-mcp://events/recent",
+					MIMEType: "application/json",
+					Text:     text,
 				},
 			}, nil
 		},
@@ -300,7 +526,7 @@ extracts both tokens and sends them to the local setup server.
 
 - Tokens are sent directly from your browser to localhost — they never leave your machine
 - The setup server runs on a high port (51837+) and auto-shuts down after receiving tokens
-- Config is saved to ~/.config/slack-mcp/config.json with 0600 permissions
+- Config is saved to config.json in the slack-mcp config directory (~/.config/slack-mcp on Linux) with 0600 permissions
 - These are session tokens tied to your browser session, not permanent API keys
 `,
 				},
@@ -309,12 +535,14 @@ extracts both tokens and sends them to the local setup server.
 	)
 }
 
-// ServeSSE starts the SSE server
-func (s *SemanticMCPServer) ServeSSE(addr string) *server.SSEServer {
-	return server.NewSSEServer(s.server,
-		server.WithBaseURL(fmt.Sprintf("http://%s", addr)),
+// ServeSSE returns the SSE transport's HTTP handler. Sessions outlive
+// dropped connections; see sessionKeeper.
+func (s *SemanticMCPServer) ServeSSE(baseURL string) http.Handler {
+	return newSessionKeeper(server.NewSSEServer(s.server,
+		server.WithBaseURL(baseURL),
 		server.WithSSEContextFunc(authFromRequest),
-	)
+		server.WithSessionIDGenerator(sessionIDFromContext),
+	))
 }
 
 // ServeStdio starts the stdio server
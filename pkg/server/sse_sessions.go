@@ -0,0 +1,432 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// SSE connection settings:
+//
+//	SLACK_MCP_SSE_HEARTBEAT="25s"       comment sent on idle streams (default 25s; "off" disables)
+//	SLACK_MCP_SSE_RESUME_WINDOW="5m"    how long a dropped session waits for its client (default 5m)
+//	SLACK_MCP_SSE_IDLE_TIMEOUT="2h"     close sessions with no requests for this long (default off)
+const (
+	defaultSSEHeartbeat    = 25 * time.Second
+	defaultSSEResumeWindow = 5 * time.Minute
+
+	// Delivered events kept for clients that reconnect with Last-Event-ID;
+	// the last write before a drop may never have arrived
+	sseReplayKeep = 64
+	// Undelivered events kept per detached session before the oldest go
+	sseMaxPending = 1000
+	sseSweepEvery = 30 * time.Second
+)
+
+type sseSessionIDKey struct{}
+
+// sessionKeeper keeps SSE sessions alive across dropped connections.
+//
+// mcp-go ties a session to its GET request and forgets it when the request
+// ends, taking any in-flight tool results with it. The keeper runs that
+// request itself, detached from the client, and buffers what it writes. A
+// client attaches to the buffer; when its connection drops the session
+// waits out the resume window, and a reconnect with Last-Event-ID (or
+// ?sessionId=) picks up where it left off, including results of tool calls
+// that finished in between. Each event is tagged with an id so standard
+// EventSource clients resume on their own.
+type sessionKeeper struct {
+	sse       *server.SSEServer
+	ssePath   string
+	heartbeat time.Duration
+	resume    time.Duration
+	idle      time.Duration
+
+	mu       sync.Mutex
+	sessions map[string]*keptSession
+}
+
+// keptSession is one mcp-go session and the events it has written
+type keptSession struct {
+	id     string
+	cancel context.CancelFunc
+	done   chan struct{} // Closed when mcp-go's handler returns
+
+	mu         sync.Mutex
+	endpoint   []byte     // First event; tells a resuming client where to post
+	events     []sseEvent // Recent events in seq order
+	partial    []byte     // Written but not yet flushed
+	seq        int
+	sent       int           // Highest seq written to a client
+	notify     chan struct{} // Signals new events to the attached client
+	attached   chan struct{} // Closed to detach the current client; nil when none
+	detachedAt time.Time
+	lastActive time.Time
+}
+
+type sseEvent struct {
+	seq  int
+	data []byte
+}
+
+func newSessionKeeper(sse *server.SSEServer) *sessionKeeper {
+	k := &sessionKeeper{
+		sse:       sse,
+		ssePath:   sse.CompleteSsePath(),
+		heartbeat: envDuration("SLACK_MCP_SSE_HEARTBEAT", defaultSSEHeartbeat),
+		resume:    envDuration("SLACK_MCP_SSE_RESUME_WINDOW", defaultSSEResumeWindow),
+		idle:      envDuration("SLACK_MCP_SSE_IDLE_TIMEOUT", 0),
+		sessions:  make(map[string]*keptSession),
+	}
+	go k.sweep()
+	return k
+}
+
+// sessionIDFromContext hands mcp-go the ID the keeper chose
+func sessionIDFromContext(ctx context.Context, _ *http.Request) (string, error) {
+	if id, ok := ctx.Value(sseSessionIDKey{}).(string); ok {
+		return id, nil
+	}
+	return newSSESessionID()
+}
+
+func (k *sessionKeeper) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != k.ssePath || r.Method != http.MethodGet {
+		if id := r.URL.Query().Get("sessionId"); id != "" {
+			if s := k.get(id); s != nil {
+				s.touch()
+			}
+		}
+		k.sse.ServeHTTP(w, r)
+		return
+	}
+
+	if _, ok := w.(http.Flusher); !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	after := -1
+	s := k.get(r.URL.Query().Get("sessionId"))
+	if id, seq, ok := parseEventID(r.Header.Get("Last-Event-ID")); ok {
+		if resumed := k.get(id); resumed != nil {
+			s, after = resumed, seq
+		}
+	}
+	if s != nil {
+		log.Printf("SSE session %s resumed", s.id)
+	} else {
+		var err error
+		if s, err = k.start(r); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	k.attach(w, r, s, after)
+}
+
+// start runs mcp-go's SSE handler for a new session, detached from the
+// client's request
+func (k *sessionKeeper) start(r *http.Request) (*keptSession, error) {
+	id, err := newSSESessionID()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.WithValue(context.WithoutCancel(r.Context()), sseSessionIDKey{}, id))
+	s := &keptSession{
+		id:         id,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+		notify:     make(chan struct{}, 1),
+		detachedAt: time.Now(),
+		lastActive: time.Now(),
+	}
+	k.mu.Lock()
+	k.sessions[id] = s
+	k.mu.Unlock()
+
+	go func() {
+		defer func() {
+			k.mu.Lock()
+			delete(k.sessions, id)
+			k.mu.Unlock()
+			s.detach(nil)
+			close(s.done)
+		}()
+		k.sse.ServeHTTP(&sessionWriter{s: s, header: http.Header{}}, r.Clone(ctx))
+	}()
+	return s, nil
+}
+
+// attach streams a session's events to a client until either goes away.
+// after is the last event the client saw, or -1 to resume from what was
+// last sent.
+func (k *sessionKeeper) attach(w http.ResponseWriter, r *http.Request, s *keptSession, after int) {
+	flusher := w.(http.Flusher)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	kicked := s.attachClient()
+	defer s.detach(kicked)
+
+	var heartbeat <-chan time.Time
+	if k.heartbeat > 0 {
+		t := time.NewTicker(k.heartbeat)
+		defer t.Stop()
+		heartbeat = t.C
+	}
+
+	resuming := after >= 0 || s.seenEndpoint()
+	for {
+		batch := s.next(after, resuming)
+		resuming = false
+		for _, e := range batch {
+			if _, err := w.Write(e.data); err != nil {
+				return
+			}
+			after = e.seq
+		}
+		if len(batch) > 0 {
+			flusher.Flush()
+			s.markSent(after)
+		}
+
+		select {
+		case <-s.notify:
+		case <-heartbeat:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-kicked:
+			return
+		case <-s.done:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (k *sessionKeeper) get(id string) *keptSession {
+	if id == "" {
+		return nil
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.sessions[id]
+}
+
+// sweep closes sessions whose client didn't come back in time or that
+// have gone unused past the idle timeout
+func (k *sessionKeeper) sweep() {
+	for range time.Tick(sseSweepEvery) {
+		k.mu.Lock()
+		sessions := make([]*keptSession, 0, len(k.sessions))
+		for _, s := range k.sessions {
+			sessions = append(sessions, s)
+		}
+		k.mu.Unlock()
+
+		now := time.Now()
+		for _, s := range sessions {
+			if reason := s.expired(now, k.resume, k.idle); reason != "" {
+				log.Printf("Closing SSE session %s: %s", s.id, reason)
+				s.cancel()
+			}
+		}
+	}
+}
+
+func (s *keptSession) expired(now time.Time, resume, idle time.Duration) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case s.attached == nil && !s.detachedAt.IsZero() && now.Sub(s.detachedAt) > resume:
+		return fmt.Sprintf("client gone for over %s", resume)
+	case idle > 0 && now.Sub(s.lastActive) > idle:
+		return fmt.Sprintf("no requests for over %s", idle)
+	}
+	return ""
+}
+
+// attachClient makes the caller the session's only client, detaching any
+// earlier one, and returns the channel closed when it's replaced
+func (s *keptSession) attachClient() chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.attached != nil {
+		close(s.attached)
+	}
+	s.attached = make(chan struct{})
+	s.detachedAt = time.Time{}
+	return s.attached
+}
+
+// detach drops the given client (or whichever is attached, for nil) and
+// starts the resume window
+func (s *keptSession) detach(client chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.attached == nil || (client != nil && s.attached != client) {
+		return
+	}
+	if client == nil {
+		close(s.attached)
+	}
+	s.attached = nil
+	s.detachedAt = time.Now()
+}
+
+func (s *keptSession) touch() {
+	s.mu.Lock()
+	s.lastActive = time.Now()
+	s.mu.Unlock()
+}
+
+func (s *keptSession) seenEndpoint() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sent > 0
+}
+
+// next returns the events after seq (after the last sent, for -1). A
+// resuming client is sent the endpoint event again first.
+func (s *keptSession) next(after int, resuming bool) []sseEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if after < 0 {
+		after = s.sent
+	}
+	var out []sseEvent
+	if resuming && s.endpoint != nil {
+		out = append(out, sseEvent{seq: after, data: s.endpoint})
+	}
+	for _, e := range s.events {
+		if e.seq > after {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func (s *keptSession) markSent(seq int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if seq > s.sent {
+		s.sent = seq
+	}
+	s.trim()
+}
+
+// trim drops delivered events beyond the replay allowance and, while the
+// client is away, the oldest undelivered ones past the cap
+func (s *keptSession) trim() {
+	drop := 0
+	for drop < len(s.events) && s.events[drop].seq <= s.sent-sseReplayKeep {
+		drop++
+	}
+	if over := len(s.events) - drop - sseMaxPending; over > 0 {
+		log.Printf("SSE session %s: dropping %d undelivered events", s.id, over)
+		drop += over
+	}
+	s.events = s.events[drop:]
+}
+
+// add records a complete event written by mcp-go
+func (s *keptSession) add(data []byte) {
+	s.mu.Lock()
+	s.seq++
+	// The id line lets EventSource clients send Last-Event-ID on reconnect
+	tagged := append([]byte(fmt.Sprintf("id: %s:%d\n", s.id, s.seq)), data...)
+	if s.endpoint == nil && bytes.HasPrefix(data, []byte("event: endpoint")) {
+		s.endpoint = data
+	}
+	s.events = append(s.events, sseEvent{seq: s.seq, data: tagged})
+	s.trim()
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// sessionWriter is the ResponseWriter mcp-go's SSE handler writes to; each
+// flush completes an event
+type sessionWriter struct {
+	s      *keptSession
+	header http.Header
+}
+
+func (w *sessionWriter) Header() http.Header { return w.header }
+func (w *sessionWriter) WriteHeader(int)     {}
+
+func (w *sessionWriter) Write(p []byte) (int, error) {
+	w.s.mu.Lock()
+	w.s.partial = append(w.s.partial, p...)
+	w.s.mu.Unlock()
+	return len(p), nil
+}
+
+func (w *sessionWriter) Flush() {
+	w.s.mu.Lock()
+	data := w.s.partial
+	w.s.partial = nil
+	w.s.mu.Unlock()
+	if len(data) > 0 {
+		w.s.add(data)
+	}
+}
+
+// parseEventID splits a Last-Event-ID of the form "<session>:<seq>"
+func parseEventID(v string) (string, int, bool) {
+	id, seq, ok := strings.Cut(strings.TrimSpace(v), ":")
+	if !ok || id == "" {
+		return "", 0, false
+	}
+	n, err := strconv.Atoi(seq)
+	if err != nil || n < 0 {
+		return "", 0, false
+	}
+	return id, n, true
+}
+
+func newSSESessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("session ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// envDuration reads a duration from the environment; 0 means off
+func envDuration(env string, def time.Duration) time.Duration {
+	spec := strings.TrimSpace(os.Getenv(env))
+	if spec == "" {
+		return def
+	}
+	if strings.EqualFold(spec, "off") {
+		return 0
+	}
+	d, err := time.ParseDuration(spec)
+	if err != nil || d < time.Second {
+		log.Printf("Ignoring %s: invalid duration %q (minimum 1s)", env, spec)
+		return def
+	}
+	return d
+}
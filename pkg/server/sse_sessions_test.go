@@ -0,0 +1,119 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// readEvent reads one SSE event, returning its id and data lines
+func readEvent(t *testing.T, r *bufio.Reader) (id, data string) {
+	t.Helper()
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read event: %v", err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "" && data != "":
+			return id, data
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data:"):
+			data = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		}
+	}
+}
+
+func openStream(t *testing.T, ctx context.Context, url, lastEventID string) *bufio.Reader {
+	t.Helper()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url+"/sse", nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return bufio.NewReader(resp.Body)
+}
+
+func TestSessionKeeperResume(t *testing.T) {
+	t.Setenv("SLACK_MCP_SSE_HEARTBEAT", "off")
+	release := make(chan struct{})
+	mcpServer := server.NewMCPServer("test", "1.0")
+	mcpServer.AddTool(mcp.NewTool("slow"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		<-release
+		return mcp.NewToolResultText("finished while you were away"), nil
+	})
+	keeper := newSessionKeeper(server.NewSSEServer(mcpServer, server.WithSessionIDGenerator(sessionIDFromContext)))
+	ts := httptest.NewServer(keeper)
+	defer ts.Close()
+
+	ctx, drop := context.WithCancel(context.Background())
+	stream := openStream(t, ctx, ts.URL, "")
+	firstID, endpoint := readEvent(t, stream)
+	if !strings.Contains(endpoint, "sessionId=") || !strings.HasSuffix(firstID, ":1") {
+		t.Fatalf("endpoint event = %q %q", firstID, endpoint)
+	}
+
+	body := `{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"slow"}}`
+	resp, err := http.Post(ts.URL+endpoint, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	// The connection drops mid-call; the result must wait for the client
+	drop()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, done := context.WithCancel(context.Background())
+	defer done()
+	stream = openStream(t, ctx, ts.URL, firstID)
+	if _, data := readEvent(t, stream); data != endpoint {
+		t.Errorf("resumed stream didn't repeat the endpoint: %q", data)
+	}
+	id, data := readEvent(t, stream)
+	if !strings.Contains(data, "finished while you were away") || !strings.HasSuffix(id, ":2") {
+		t.Errorf("replayed event = %q %q", id, data)
+	}
+}
+
+func TestSessionExpiry(t *testing.T) {
+	now := time.Now()
+	s := &keptSession{detachedAt: now.Add(-10 * time.Minute), lastActive: now}
+	if s.expired(now, 5*time.Minute, 0) == "" {
+		t.Error("detached past the resume window but not expired")
+	}
+	s.attached = make(chan struct{})
+	if reason := s.expired(now, 5*time.Minute, 0); reason != "" {
+		t.Errorf("attached session expired: %s", reason)
+	}
+	s.lastActive = now.Add(-3 * time.Hour)
+	if s.expired(now, 5*time.Minute, 2*time.Hour) == "" {
+		t.Error("idle session not expired")
+	}
+}
+
+func TestParseEventID(t *testing.T) {
+	if id, seq, ok := parseEventID("abc123:42"); !ok || id != "abc123" || seq != 42 {
+		t.Errorf("parseEventID = %q %d %v", id, seq, ok)
+	}
+	for _, bad := range []string{"", "abc", ":3", "abc:x", "abc:-1"} {
+		if _, _, ok := parseEventID(bad); ok {
+			t.Errorf("parseEventID(%q) accepted", bad)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aaronsb/slack-mcp/pkg/paths"
+)
+
+// TLSSettings configures HTTPS for the SSE transport: either certificate
+// files (e.g. from certbot or a corporate CA) or certificates issued by
+// Let's Encrypt for Domains
+type TLSSettings struct {
+	CertFile  string
+	KeyFile   string
+	Domains   []string // Let's Encrypt; ignored when CertFile is set
+	Email     string   // ACME account contact, optional
+	Directory string   // ACME directory URL; defaults to Let's Encrypt production
+	CacheDir  string   // Where issued certificates and the account key are kept
+}
+
+// TLSSettingsFromEnv reads SLACK_MCP_TLS_CERT, SLACK_MCP_TLS_KEY,
+// SLACK_MCP_TLS_DOMAIN (comma-separated), SLACK_MCP_ACME_EMAIL, and
+// SLACK_MCP_ACME_DIRECTORY
+func TLSSettingsFromEnv() TLSSettings {
+	t := TLSSettings{
+		CertFile:  strings.TrimSpace(os.Getenv("SLACK_MCP_TLS_CERT")),
+		KeyFile:   strings.TrimSpace(os.Getenv("SLACK_MCP_TLS_KEY")),
+		Email:     strings.TrimSpace(os.Getenv("SLACK_MCP_ACME_EMAIL")),
+		Directory: strings.TrimSpace(os.Getenv("SLACK_MCP_ACME_DIRECTORY")),
+		CacheDir:  filepath.Join(paths.ConfigDir(), "acme"),
+	}
+	for _, d := range strings.Split(os.Getenv("SLACK_MCP_TLS_DOMAIN"), ",") {
+		if d = strings.ToLower(strings.TrimSpace(d)); d != "" {
+			t.Domains = append(t.Domains, d)
+		}
+	}
+	return t
+}
+
+// Enabled reports whether HTTPS was asked for
+func (t TLSSettings) Enabled() bool {
+	return t.CertFile != "" || t.KeyFile != "" || len(t.Domains) > 0
+}
+
+// Host is the name clients should use to reach the server, or "" when it
+// isn't known
+func (t TLSSettings) Host() string {
+	if t.CertFile == "" && len(t.Domains) > 0 {
+		return t.Domains[0]
+	}
+	return ""
+}
+
+// Config builds the TLS configuration for the settings. With Let's
+// Encrypt it also returns a handler to serve on port 80, which answers
+// HTTP-01 challenges and redirects everything else to HTTPS.
+func (t TLSSettings) Config() (*tls.Config, http.Handler, error) {
+	switch {
+	case t.CertFile != "" || t.KeyFile != "":
+		if t.CertFile == "" || t.KeyFile == "" {
+			return nil, nil, fmt.Errorf("SLACK_MCP_TLS_CERT and SLACK_MCP_TLS_KEY must be set together")
+		}
+		fc := &fileCert{certFile: t.CertFile, keyFile: t.KeyFile}
+		if _, err := fc.load(); err != nil {
+			return nil, nil, err
+		}
+		return &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: fc.getCertificate,
+			NextProtos:     []string{"h2", "http/1.1"},
+		}, nil, nil
+	case len(t.Domains) > 0:
+		m := newACMEManager(t)
+		// Also validates over TLS-ALPN-01 on the same listener
+		cfg := m.TLSConfig()
+		cfg.MinVersion = tls.VersionTLS12
+		return cfg, m.HTTPHandler(nil), nil
+	default:
+		return nil, nil, nil
+	}
+}
+
+// fileCert serves a certificate from disk, reloading it when the files
+// change so renewals by an external tool don't need a restart
+type fileCert struct {
+	certFile, keyFile string
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+	checked time.Time
+}
+
+// certRecheck is how often fileCert looks for a renewed certificate
+const certRecheck = time.Minute
+
+func (f *fileCert) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.cert != nil && time.Since(f.checked) < certRecheck {
+		return f.cert, nil
+	}
+	cert, err := f.loadLocked()
+	if err != nil {
+		if f.cert != nil {
+			log.Printf("Keeping the loaded TLS certificate: %v", err)
+			return f.cert, nil
+		}
+		return nil, err
+	}
+	return cert, nil
+}
+
+func (f *fileCert) load() (*tls.Certificate, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.loadLocked()
+}
+
+func (f *fileCert) loadLocked() (*tls.Certificate, error) {
+	f.checked = time.Now()
+	info, err := os.Stat(f.certFile)
+	if err != nil {
+		return nil, fmt.Errorf("TLS certificate: %w", err)
+	}
+	if f.cert != nil && info.ModTime().Equal(f.modTime) {
+		return f.cert, nil
+	}
+	cert, err := tls.LoadX509KeyPair(f.certFile, f.keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("TLS certificate: %w", err)
+	}
+	if f.cert != nil {
+		log.Printf("Reloaded TLS certificate from %s", f.certFile)
+	}
+	f.cert, f.modTime = &cert, info.ModTime()
+	return f.cert, nil
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/acme"
+)
+
+// selfSigned returns a PEM file holding a certificate for name and its key
+func selfSigned(t *testing.T, name string, notAfter time.Time) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}, &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: name}}, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return append(out, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})...)
+}
+
+func TestTLSSettingsFromEnv(t *testing.T) {
+	t.Setenv("SLACK_MCP_TLS_DOMAIN", " MCP.example.com, alt.example.com ,")
+	s := TLSSettingsFromEnv()
+	if !s.Enabled() || len(s.Domains) != 2 || s.Host() != "mcp.example.com" {
+		t.Fatalf("settings = %+v", s)
+	}
+
+	if _, _, err := (TLSSettings{CertFile: "cert.pem"}).Config(); err == nil {
+		t.Error("cert without key accepted")
+	}
+	if cfg, challenges, err := (TLSSettings{}).Config(); cfg != nil || challenges != nil || err != nil {
+		t.Errorf("empty settings = %v, %v, %v", cfg, challenges, err)
+	}
+}
+
+func TestFileCertReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cert.pem")
+	write := func(name string) {
+		os.WriteFile(path, selfSigned(t, name, time.Now().Add(24*time.Hour)), 0600)
+	}
+	write("one.example.com")
+
+	cfg, _, err := (TLSSettings{CertFile: path, KeyFile: path}).Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := cfg.GetCertificate(&tls.ClientHelloInfo{})
+	if err != nil || cert.Leaf.Subject.CommonName != "one.example.com" {
+		t.Fatalf("cert = %v, %v", cert, err)
+	}
+
+	write("two.example.com")
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(path, later, later)
+	fc := &fileCert{certFile: path, keyFile: path}
+	fc.load()
+	fc.checked = time.Time{}
+	if cert, _ := fc.getCertificate(nil); cert.Leaf.Subject.CommonName != "two.example.com" {
+		t.Errorf("reloaded cert = %s", cert.Leaf.Subject.CommonName)
+	}
+}
+
+func TestACMEConfig(t *testing.T) {
+	cfg, challenges, err := (TLSSettings{Domains: []string{"mcp.example.com"}, CacheDir: t.TempDir()}).Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(cfg.NextProtos, acme.ALPNProto) || cfg.MinVersion != tls.VersionTLS12 {
+		t.Errorf("config = %+v", cfg)
+	}
+	if challenges == nil {
+		t.Error("no HTTP-01 challenge handler")
+	}
+	if _, err := cfg.GetCertificate(&tls.ClientHelloInfo{ServerName: "other.example.com"}); err == nil {
+		t.Error("served a certificate for an unconfigured domain")
+	}
+}
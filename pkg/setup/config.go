@@ -16,6 +16,16 @@ type WorkspaceConfig struct {
 	TeamName  string `json:"team_name,omitempty"`
 	UserName  string `json:"user_name,omitempty"`
 	UserID    string `json:"user_id,omitempty"`
+
+	// A Slack app's bot (xoxb) or user (xoxp) token, used instead of the
+	// browser session tokens when set
+	Token string `json:"token,omitempty"`
+
+	// Set by `slack-mcp auth login` when the app rotates tokens
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	TokenExpiry  time.Time `json:"token_expiry,omitzero"`
+	ClientID     string    `json:"client_id,omitempty"`
+	ClientSecret string    `json:"client_secret,omitempty"`
 }
 
 // FlowState persists the setup flow's current position across server restarts
@@ -46,6 +56,23 @@ type Config struct {
 	Workspaces       map[string]WorkspaceConfig `json:"workspaces"`
 	DefaultWorkspace string                     `json:"default_workspace,omitempty"`
 	SetupFlow        *FlowState                 `json:"setup_flow,omitempty"`
+
+	// Named identities for a deployment shared by several people. When
+	// any are set, every tool call must say which one is acting.
+	Identities map[string]WorkspaceConfig `json:"identities,omitempty"`
+}
+
+// RemoveIdentity deletes a named identity's stored credentials
+func RemoveIdentity(name string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Identities[name]; !ok {
+		return fmt.Errorf("identity %q not found in config", name)
+	}
+	delete(cfg.Identities, name)
+	return SaveConfig(cfg)
 }
 
 // ClearFlow removes setup flow state and saves the config
@@ -54,6 +81,18 @@ func (c *Config) ClearFlow() error {
 	return SaveConfig(c)
 }
 
+// ActiveWorkspace returns the workspace the server runs with: the default,
+// or any configured one when no default is set
+func (c *Config) ActiveWorkspace() string {
+	if c.DefaultWorkspace != "" {
+		return c.DefaultWorkspace
+	}
+	for name := range c.Workspaces {
+		return name
+	}
+	return ""
+}
+
 // ConfigDir returns the XDG config directory: $XDG_CONFIG_HOME/slack-mcp
 func ConfigDir() string {
 	return paths.ConfigDir()
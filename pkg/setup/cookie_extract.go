@@ -132,3 +132,61 @@ func ExtractSlackDCookie(userDataDir, profileDir string) (string, error) {
 	log.Printf("Cookie extract: successfully decrypted d cookie (%d chars)", len(result))
 	return result, nil
 }
+
+// ExtractFirefoxDCookie reads the "d" cookie from a Firefox profile's
+// cookies.sqlite. Firefox doesn't encrypt cookie values, so this needs no
+// keychain access. The database and its write-ahead log are copied first
+// because Firefox keeps them locked and recent cookies may only be in the log.
+func ExtractFirefoxDCookie(profileDir string) (string, error) {
+	cookiesPath := filepath.Join(profileDir, "cookies.sqlite")
+	if _, err := os.Stat(cookiesPath); err != nil {
+		return "", fmt.Errorf("cookies file not found: %s", cookiesPath)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "slack-mcp-ff-cookies-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	tmpPath := filepath.Join(tmpDir, "cookies.sqlite")
+	for _, suffix := range []string{"", "-wal"} {
+		data, err := os.ReadFile(cookiesPath + suffix)
+		if err != nil {
+			if suffix == "" {
+				return "", fmt.Errorf("failed to read cookies file: %w", err)
+			}
+			continue
+		}
+		if err := os.WriteFile(tmpPath+suffix, data, 0600); err != nil {
+			return "", fmt.Errorf("failed to write temp cookies: %w", err)
+		}
+	}
+
+	log.Printf("Cookie extract: reading %s", cookiesPath)
+
+	db, err := sql.Open("sqlite", tmpPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open cookies db: %w", err)
+	}
+	defer db.Close()
+
+	var value string
+	err = db.QueryRow(
+		`SELECT value FROM moz_cookies
+		 WHERE name = 'd' AND host LIKE '%slack.com'
+		 ORDER BY expiry DESC
+		 LIMIT 1`,
+	).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("no d cookie found for slack.com in %s", profileDir)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to query cookies: %w", err)
+	}
+	if value == "" {
+		return "", fmt.Errorf("d cookie is empty")
+	}
+
+	log.Printf("Cookie extract: found Firefox d cookie (%d chars)", len(value))
+	return value, nil
+}
@@ -0,0 +1,322 @@
+package setup
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// Scopes requested by `slack-mcp auth login`. The user token acts as you,
+// so it's the default; a bot token can't search.
+var (
+	DefaultUserScopes = []string{
+		"channels:history", "channels:read", "groups:history", "groups:read",
+		"im:history", "im:read", "im:write", "mpim:history", "mpim:read", "mpim:write",
+		"users:read", "users:read.email", "chat:write", "reactions:read", "reactions:write",
+		"search:read", "files:read", "files:write", "pins:read",
+	}
+	DefaultBotScopes = []string{
+		"channels:history", "channels:read", "groups:history", "groups:read",
+		"im:history", "im:read", "im:write", "mpim:history", "mpim:read",
+		"users:read", "users:read.email", "chat:write", "reactions:read", "reactions:write",
+		"files:read", "files:write",
+	}
+)
+
+const (
+	oauthAuthorizeURL   = "https://slack.com/oauth/v2/authorize"
+	oauthCallbackPath   = "/oauth/callback"
+	oauthTimeout        = 5 * time.Minute
+	tokenRefreshEarly   = 10 * time.Minute
+	envOAuthClientID    = "SLACK_MCP_CLIENT_ID"
+	envOAuthSecret      = "SLACK_MCP_CLIENT_SECRET"
+	envOAuthRedirectURL = "SLACK_MCP_REDIRECT_URL"
+)
+
+// OAuthOptions configures `slack-mcp auth login`
+type OAuthOptions struct {
+	ClientID     string
+	ClientSecret string
+	Bot          bool     // Install for a bot token instead of a user token
+	Scopes       []string // Overrides the defaults for the chosen token kind
+	RedirectURL  string   // Overrides http://localhost:<port>/oauth/callback
+}
+
+// OAuthOptionsFromEnv fills the app credentials from SLACK_MCP_CLIENT_ID,
+// SLACK_MCP_CLIENT_SECRET, and SLACK_MCP_REDIRECT_URL
+func OAuthOptionsFromEnv() OAuthOptions {
+	return OAuthOptions{
+		ClientID:     strings.TrimSpace(os.Getenv(envOAuthClientID)),
+		ClientSecret: strings.TrimSpace(os.Getenv(envOAuthSecret)),
+		RedirectURL:  strings.TrimSpace(os.Getenv(envOAuthRedirectURL)),
+	}
+}
+
+// authorizeURL builds the Slack consent page URL
+func (o OAuthOptions) authorizeURL(redirectURL, state string) string {
+	scopes := o.Scopes
+	if len(scopes) == 0 {
+		scopes = DefaultUserScopes
+		if o.Bot {
+			scopes = DefaultBotScopes
+		}
+	}
+	q := url.Values{
+		"client_id":    {o.ClientID},
+		"redirect_uri": {redirectURL},
+		"state":        {state},
+	}
+	if o.Bot {
+		q.Set("scope", strings.Join(scopes, ","))
+	} else {
+		q.Set("user_scope", strings.Join(scopes, ","))
+	}
+	return oauthAuthorizeURL + "?" + q.Encode()
+}
+
+// workspaceFromOAuth picks the requested token out of an oauth.v2.access
+// response
+func workspaceFromOAuth(resp *slack.OAuthV2Response, o OAuthOptions, now time.Time) (WorkspaceConfig, error) {
+	ws := WorkspaceConfig{
+		TeamName:     resp.Team.Name,
+		UserID:       resp.AuthedUser.ID,
+		ClientID:     o.ClientID,
+		ClientSecret: o.ClientSecret,
+	}
+	expiresIn := 0
+	if o.Bot {
+		ws.Token, ws.RefreshToken, expiresIn = resp.AccessToken, resp.RefreshToken, resp.ExpiresIn
+		ws.UserID = resp.BotUserID
+	} else {
+		u := resp.AuthedUser
+		ws.Token, ws.RefreshToken, expiresIn = u.AccessToken, u.RefreshToken, u.ExpiresIn
+	}
+	if ws.Token == "" {
+		kind := "user"
+		if o.Bot {
+			kind = "bot"
+		}
+		return ws, fmt.Errorf("slack returned no %s token; check the app's scopes", kind)
+	}
+	if ws.TeamName == "" {
+		ws.TeamName = resp.Team.ID
+	}
+	if expiresIn > 0 {
+		ws.TokenExpiry = now.Add(time.Duration(expiresIn) * time.Second)
+	}
+	// Without rotation there's nothing to refresh, so don't keep the secret
+	if ws.RefreshToken == "" {
+		ws.ClientSecret = ""
+	}
+	return ws, nil
+}
+
+// RunOAuthLogin installs the Slack app through the browser and saves the
+// resulting token as a workspace (CLI entry point for `slack-mcp auth login`)
+func RunOAuthLogin(o OAuthOptions) error {
+	if o.ClientID == "" || o.ClientSecret == "" {
+		return fmt.Errorf("the app's client ID and secret are required (--client-id/--client-secret or %s/%s)", envOAuthClientID, envOAuthSecret)
+	}
+
+	port, listener, err := FindPort()
+	if err != nil {
+		return fmt.Errorf("could not find available port: %w", err)
+	}
+	redirectURL := o.RedirectURL
+	if redirectURL == "" {
+		redirectURL = fmt.Sprintf("http://localhost:%d%s", port, oauthCallbackPath)
+	}
+	state, err := randomState()
+	if err != nil {
+		return err
+	}
+
+	type outcome struct {
+		ws  WorkspaceConfig
+		err error
+	}
+	done := make(chan outcome, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(oauthCallbackPath, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var res outcome
+		switch {
+		case q.Get("state") != state:
+			http.Error(w, "state mismatch", http.StatusBadRequest)
+			return
+		case q.Get("error") != "":
+			res.err = fmt.Errorf("slack declined the install: %s", q.Get("error"))
+		default:
+			ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+			resp, err := slack.GetOAuthV2ResponseContext(ctx, http.DefaultClient, o.ClientID, o.ClientSecret, q.Get("code"), redirectURL)
+			cancel()
+			if err != nil {
+				res.err = fmt.Errorf("token exchange failed: %w", err)
+			} else {
+				res.ws, res.err = workspaceFromOAuth(resp, o, time.Now())
+			}
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if res.err != nil {
+			fmt.Fprintf(w, "<h2>Slack MCP: install failed</h2><p>%s</p>", html.EscapeString(res.err.Error()))
+		} else {
+			fmt.Fprintf(w, "<h2>Slack MCP connected to %s</h2><p>You can close this tab.</p>", html.EscapeString(res.ws.TeamName))
+		}
+		select {
+		case done <- res:
+		default:
+		}
+	})
+
+	srv := &http.Server{Handler: mux}
+	go func() {
+		if err := srv.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Printf("OAuth callback server error: %v", err)
+		}
+	}()
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		srv.Shutdown(ctx)
+	}()
+
+	consent := o.authorizeURL(redirectURL, state)
+	fmt.Printf("\n  Slack MCP Login\n")
+	fmt.Printf("  ───────────────\n")
+	fmt.Printf("  Redirect URL (must be listed in the app's OAuth settings):\n    %s\n\n", redirectURL)
+	fmt.Printf("  Opening browser to approve the install. If it doesn't open, visit:\n    %s\n\n", consent)
+	fmt.Printf("  Waiting for Slack... (Ctrl+C to cancel)\n\n")
+	OpenBrowserURL(consent)
+
+	var res outcome
+	select {
+	case res = <-done:
+	case <-time.After(oauthTimeout):
+		return fmt.Errorf("timed out after %s waiting for the install", oauthTimeout)
+	}
+	if res.err != nil {
+		return res.err
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	cfg.Workspaces[res.ws.TeamName] = res.ws
+	if cfg.DefaultWorkspace == "" {
+		cfg.DefaultWorkspace = res.ws.TeamName
+	}
+	if err := SaveConfig(cfg); err != nil {
+		return err
+	}
+
+	fmt.Printf("  ✓ Got a %s token for workspace %q\n", tokenKindLabel(res.ws.Token), res.ws.TeamName)
+	if !res.ws.TokenExpiry.IsZero() {
+		fmt.Printf("  ✓ Token rotation is on; it will be refreshed before %s\n", res.ws.TokenExpiry.Format(time.RFC1123))
+	}
+	fmt.Printf("  ✓ Saved to %s\n\n", ConfigPath())
+	return nil
+}
+
+// Credentials returns what a provider is built from: the app token when
+// there is one, else the browser session token and its cookie
+func (ws WorkspaceConfig) Credentials() (token, cookie string) {
+	if ws.Token != "" {
+		return ws.Token, ""
+	}
+	return ws.XoxcToken, ws.XoxdToken
+}
+
+// NeedsRefresh reports whether a rotating token is expired or about to be
+func (ws WorkspaceConfig) NeedsRefresh(now time.Time) bool {
+	return ws.RefreshToken != "" && !ws.TokenExpiry.IsZero() && now.Add(tokenRefreshEarly).After(ws.TokenExpiry)
+}
+
+// RefreshAt returns when a rotating token should be refreshed, or the zero
+// time if it doesn't expire
+func (ws WorkspaceConfig) RefreshAt() time.Time {
+	if ws.RefreshToken == "" || ws.TokenExpiry.IsZero() {
+		return time.Time{}
+	}
+	return ws.TokenExpiry.Add(-tokenRefreshEarly)
+}
+
+// RefreshWorkspaceToken exchanges a workspace's refresh token for a new
+// token and saves it. Slack refresh tokens are single use, so the new one
+// is saved along with it.
+func RefreshWorkspaceToken(ctx context.Context, name string) (WorkspaceConfig, error) {
+	return refreshToken(ctx, name, false)
+}
+
+// RefreshIdentityToken does the same for a named identity
+func RefreshIdentityToken(ctx context.Context, name string) (WorkspaceConfig, error) {
+	return refreshToken(ctx, name, true)
+}
+
+func refreshToken(ctx context.Context, name string, identity bool) (WorkspaceConfig, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return WorkspaceConfig{}, err
+	}
+	entries, kind := cfg.Workspaces, "workspace"
+	if identity {
+		entries, kind = cfg.Identities, "identity"
+	}
+	ws, ok := entries[name]
+	if !ok {
+		return ws, fmt.Errorf("%s %q not found in config", kind, name)
+	}
+	if ws.RefreshToken == "" || ws.ClientID == "" || ws.ClientSecret == "" {
+		return ws, fmt.Errorf("%s %q has no refresh token; run `slack-mcp auth login` again", kind, name)
+	}
+
+	resp, err := slack.RefreshOAuthV2TokenContext(ctx, http.DefaultClient, ws.ClientID, ws.ClientSecret, ws.RefreshToken)
+	if err != nil {
+		return ws, fmt.Errorf("token refresh failed: %w", err)
+	}
+	token, refresh, expiresIn := resp.AccessToken, resp.RefreshToken, resp.ExpiresIn
+	if token == "" {
+		token, refresh, expiresIn = resp.AuthedUser.AccessToken, resp.AuthedUser.RefreshToken, resp.AuthedUser.ExpiresIn
+	}
+	if token == "" {
+		return ws, fmt.Errorf("token refresh returned no token")
+	}
+	ws.Token = token
+	if refresh != "" {
+		ws.RefreshToken = refresh
+	}
+	if expiresIn > 0 {
+		ws.TokenExpiry = time.Now().Add(time.Duration(expiresIn) * time.Second)
+	}
+	entries[name] = ws
+	if err := SaveConfig(cfg); err != nil {
+		return ws, err
+	}
+	return ws, nil
+}
+
+func randomState() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func tokenKindLabel(token string) string {
+	if strings.HasPrefix(token, "xoxb-") {
+		return "bot"
+	}
+	return "user"
+}
@@ -0,0 +1,74 @@
+package setup
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+func TestAuthorizeURL(t *testing.T) {
+	user := OAuthOptions{ClientID: "123.456"}
+	u, err := url.Parse(user.authorizeURL("http://localhost:51837/oauth/callback", "s1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("client_id") != "123.456" || q.Get("state") != "s1" || q.Get("scope") != "" {
+		t.Errorf("user install query = %v", q)
+	}
+	if !strings.Contains(q.Get("user_scope"), "search:read") {
+		t.Errorf("user_scope = %q, want search:read", q.Get("user_scope"))
+	}
+
+	bot := OAuthOptions{ClientID: "123.456", Bot: true, Scopes: []string{"chat:write"}}
+	u, _ = url.Parse(bot.authorizeURL("https://example.test/cb", "s2"))
+	if q := u.Query(); q.Get("scope") != "chat:write" || q.Get("user_scope") != "" {
+		t.Errorf("bot install query = %v", q)
+	}
+}
+
+func TestWorkspaceFromOAuth(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	resp := &slack.OAuthV2Response{
+		AccessToken:  "xoxb-bot",
+		BotUserID:    "B1",
+		RefreshToken: "xoxe-bot",
+		ExpiresIn:    43200,
+		Team:         slack.OAuthV2ResponseTeam{ID: "T1", Name: "Acme"},
+		AuthedUser:   slack.OAuthV2ResponseAuthedUser{ID: "U1", AccessToken: "xoxp-user"},
+	}
+	opts := OAuthOptions{ClientID: "id", ClientSecret: "secret"}
+
+	ws, err := workspaceFromOAuth(resp, opts, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ws.Token != "xoxp-user" || ws.UserID != "U1" || ws.TeamName != "Acme" {
+		t.Errorf("user workspace = %+v", ws)
+	}
+	if ws.RefreshToken != "" || ws.ClientSecret != "" || !ws.TokenExpiry.IsZero() {
+		t.Errorf("non-rotating user token kept refresh state: %+v", ws)
+	}
+
+	opts.Bot = true
+	ws, err = workspaceFromOAuth(resp, opts, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ws.Token != "xoxb-bot" || ws.RefreshToken != "xoxe-bot" || ws.ClientSecret != "secret" {
+		t.Errorf("bot workspace = %+v", ws)
+	}
+	if want := now.Add(12 * time.Hour); !ws.TokenExpiry.Equal(want) {
+		t.Errorf("expiry = %v, want %v", ws.TokenExpiry, want)
+	}
+	if ws.NeedsRefresh(now) || !ws.NeedsRefresh(now.Add(11*time.Hour+55*time.Minute)) {
+		t.Error("NeedsRefresh wrong around expiry")
+	}
+
+	if _, err := workspaceFromOAuth(&slack.OAuthV2Response{}, OAuthOptions{}, now); err == nil {
+		t.Error("accepted a response without a token")
+	}
+}
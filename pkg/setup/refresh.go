@@ -0,0 +1,156 @@
+package setup
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+)
+
+// RefreshResult describes the outcome of RefreshCredentials
+type RefreshResult struct {
+	Workspace string
+	Refreshed bool   // False when the stored credentials still worked
+	Source    string // Browser profile the new cookie came from
+	User      string
+	Tried     []string // Profiles checked, with why each was passed over
+}
+
+// sessionCandidate is a pair of session credentials read from one browser
+// profile. Xoxc is empty when only the cookie could be read.
+type sessionCandidate struct {
+	source string
+	xoxc   string
+	xoxd   string
+	err    error
+}
+
+// validateFunc checks a token pair, returning its team and user; swapped in
+// tests
+var validateFunc = func(xoxc, xoxd string) (team, user string, err error) {
+	team, user, _, err = ValidateTokens(xoxc, xoxd)
+	return team, user, err
+}
+
+// RefreshCredentials checks a workspace's browser-session credentials and,
+// when Slack rejects them (the xoxd cookie rots), reads fresh ones from the
+// local browser profiles on disk: Chromium-family cookie stores are
+// decrypted with the OS keychain key, Firefox stores cookies in the clear.
+// A profile's credentials are only taken if they sign into the same
+// workspace. force skips the initial check. An empty name picks the
+// default workspace.
+func RefreshCredentials(name string, force bool) (*RefreshResult, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = cfg.ActiveWorkspace()
+	}
+	ws, ok := cfg.Workspaces[name]
+	if !ok {
+		return nil, fmt.Errorf("no workspace %q in %s; run setup first", name, ConfigPath())
+	}
+	if ws.Token != "" {
+		return nil, fmt.Errorf("workspace %q uses an app token, which has no browser cookie; run `slack-mcp auth login` if it stopped working", name)
+	}
+
+	result := &RefreshResult{Workspace: name}
+	if !force {
+		_, user, err := validateFunc(ws.XoxcToken, ws.XoxdToken)
+		if err == nil {
+			result.User = user
+			return result, nil
+		}
+		log.Printf("Credential refresh: stored credentials for %q rejected: %v", name, err)
+	}
+
+	for _, c := range sessionsFunc() {
+		if c.err != nil {
+			result.Tried = append(result.Tried, fmt.Sprintf("%s: %v", c.source, c.err))
+			continue
+		}
+		// Prefer the stored token with the new cookie; the token outlives it
+		pairs := [][2]string{{ws.XoxcToken, c.xoxd}}
+		if c.xoxc != "" && c.xoxc != ws.XoxcToken {
+			pairs = append(pairs, [2]string{c.xoxc, c.xoxd})
+		}
+		var lastErr error
+		for _, pair := range pairs {
+			team, user, err := validateFunc(pair[0], pair[1])
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			if ws.TeamName != "" && team != ws.TeamName {
+				lastErr = fmt.Errorf("signed into %q, not %q", team, ws.TeamName)
+				continue
+			}
+
+			ws.XoxcToken, ws.XoxdToken = pair[0], pair[1]
+			if user != "" {
+				ws.UserName = user
+			}
+			cfg.Workspaces[name] = ws
+			if err := SaveConfig(cfg); err != nil {
+				return nil, err
+			}
+			result.Refreshed, result.Source, result.User = true, c.source, user
+			return result, nil
+		}
+		result.Tried = append(result.Tried, fmt.Sprintf("%s: %v", c.source, lastErr))
+	}
+
+	if len(result.Tried) == 0 {
+		return result, fmt.Errorf("no browser profiles with a Slack session were found")
+	}
+	return result, fmt.Errorf("no browser profile had working credentials for %q; log into Slack in a browser and try again, or run auth-setup", name)
+}
+
+// sessionsFunc lists browser sessions; swapped in tests
+var sessionsFunc = browserSessions
+
+// browserSessions reads the Slack session from every detected browser
+// profile
+func browserSessions() []sessionCandidate {
+	var out []sessionCandidate
+	for _, b := range DetectBrowsers() {
+		switch b.Type {
+		case "chromium":
+			profiles, err := EnumerateProfiles(b.UserDataDir)
+			if err != nil {
+				out = append(out, sessionCandidate{source: b.DisplayName, err: err})
+				continue
+			}
+			for _, p := range profiles {
+				source := fmt.Sprintf("%s (%s)", b.DisplayName, p.DirName)
+				xoxd, err := ExtractSlackDCookie(b.UserDataDir, p.DirName)
+				if err != nil {
+					out = append(out, sessionCandidate{source: source, err: err})
+					continue
+				}
+				xoxc, _ := ExtractSlackXoxcToken(b.UserDataDir, p.DirName)
+				out = append(out, sessionCandidate{source: source, xoxc: xoxc, xoxd: xoxd})
+			}
+		case "firefox":
+			dirs, _ := filepath.Glob(filepath.Join(b.UserDataDir, "*", "cookies.sqlite"))
+			for _, db := range dirs {
+				profile := filepath.Dir(db)
+				source := fmt.Sprintf("%s (%s)", b.DisplayName, firefoxProfileName(profile))
+				xoxd, err := ExtractFirefoxDCookie(profile)
+				out = append(out, sessionCandidate{source: source, xoxd: xoxd, err: err})
+			}
+		}
+	}
+	return out
+}
+
+// firefoxProfileName drops the random prefix from a profile directory
+// ("abcd1234.default-release" → "default-release")
+func firefoxProfileName(dir string) string {
+	base := filepath.Base(dir)
+	if _, name, ok := strings.Cut(base, "."); ok {
+		return name
+	}
+	return base
+}
@@ -0,0 +1,91 @@
+package setup
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestRefreshCredentials(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := SaveConfig(&Config{
+		Workspaces:       map[string]WorkspaceConfig{"Acme": {XoxcToken: "xoxc-1", XoxdToken: "xoxd-old", TeamName: "Acme"}},
+		DefaultWorkspace: "Acme",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Slack accepts xoxc-1 with xoxd-new for Acme, and xoxd-other for Globex
+	origValidate, origSessions := validateFunc, sessionsFunc
+	t.Cleanup(func() { validateFunc, sessionsFunc = origValidate, origSessions })
+	validateFunc = func(xoxc, xoxd string) (string, string, error) {
+		switch {
+		case xoxc == "xoxc-1" && xoxd == "xoxd-new":
+			return "Acme", "sam", nil
+		case xoxd == "xoxd-other":
+			return "Globex", "sam", nil
+		}
+		return "", "", fmt.Errorf("invalid_auth")
+	}
+	sessionsFunc = func() []sessionCandidate {
+		return []sessionCandidate{
+			{source: "Chrome (Default)", err: fmt.Errorf("no d cookie")},
+			{source: "Firefox (work)", xoxd: "xoxd-other"},
+			{source: "Firefox (default-release)", xoxd: "xoxd-new"},
+		}
+	}
+
+	res, err := RefreshCredentials("", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Refreshed || res.Source != "Firefox (default-release)" || len(res.Tried) != 2 {
+		t.Errorf("result = %+v", res)
+	}
+	cfg, _ := LoadConfig()
+	if got := cfg.Workspaces["Acme"].XoxdToken; got != "xoxd-new" {
+		t.Errorf("saved cookie = %q", got)
+	}
+
+	// Working credentials are left alone unless forced
+	res, err = RefreshCredentials("Acme", false)
+	if err != nil || res.Refreshed {
+		t.Errorf("refresh of working credentials = %+v, %v", res, err)
+	}
+
+	sessionsFunc = func() []sessionCandidate { return nil }
+	if _, err := RefreshCredentials("Acme", true); err == nil {
+		t.Error("forced refresh with no browser sessions succeeded")
+	}
+}
+
+func TestExtractFirefoxDCookie(t *testing.T) {
+	dir := t.TempDir()
+	db, err := sql.Open("sqlite", filepath.Join(dir, "cookies.sqlite"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range []string{
+		`CREATE TABLE moz_cookies (name TEXT, value TEXT, host TEXT, expiry INTEGER)`,
+		`INSERT INTO moz_cookies VALUES ('d', 'xoxd-stale', '.slack.com', 100)`,
+		`INSERT INTO moz_cookies VALUES ('d', 'xoxd-fresh', '.slack.com', 200)`,
+		`INSERT INTO moz_cookies VALUES ('d', 'other', '.example.com', 300)`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db.Close()
+
+	got, err := ExtractFirefoxDCookie(dir)
+	if err != nil || got != "xoxd-fresh" {
+		t.Errorf("ExtractFirefoxDCookie = %q, %v", got, err)
+	}
+	if _, err := ExtractFirefoxDCookie(t.TempDir()); err == nil {
+		t.Error("extracted a cookie from an empty profile")
+	}
+	if name := firefoxProfileName("/p/abcd1234.default-release"); name != "default-release" {
+		t.Errorf("firefoxProfileName = %q", name)
+	}
+}
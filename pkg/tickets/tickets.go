@@ -0,0 +1,232 @@
+package tickets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Ticket is what a Slack thread becomes in an external tracker
+type Ticket struct {
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	Permalink    string    `json:"permalink,omitempty"`
+	Channel      string    `json:"channel"`
+	ThreadID     string    `json:"threadId"`
+	Participants []string  `json:"participants"`
+	Labels       []string  `json:"labels,omitempty"`
+	Reporter     string    `json:"reporter,omitempty"`
+	Created      time.Time `json:"created"`
+}
+
+// Result identifies the ticket the tracker created. Any field may be empty
+// when the tracker's response doesn't carry it.
+type Result struct {
+	ID  string `json:"id,omitempty"`
+	Key string `json:"key,omitempty"`
+	URL string `json:"url,omitempty"`
+}
+
+// Payload formats
+const (
+	FormatGeneric = "generic"
+	FormatJira    = "jira"
+	FormatLinear  = "linear"
+)
+
+// Bridge hands tickets to a tracker, either by POSTing to a webhook or by
+// running a command with the payload on stdin
+type Bridge struct {
+	httpClient *http.Client
+	webhook    string
+	command    []string
+	auth       string
+	format     string
+	project    string
+}
+
+// FromEnv builds a bridge from SLACK_MCP_TICKET_WEBHOOK or
+// SLACK_MCP_TICKET_COMMAND, with _FORMAT, _PROJECT and _AUTH. Returns nil
+// when neither is configured.
+func FromEnv() *Bridge {
+	b := &Bridge{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		webhook:    strings.TrimSpace(os.Getenv("SLACK_MCP_TICKET_WEBHOOK")),
+		command:    strings.Fields(os.Getenv("SLACK_MCP_TICKET_COMMAND")),
+		auth:       strings.TrimSpace(os.Getenv("SLACK_MCP_TICKET_AUTH")),
+		format:     strings.ToLower(strings.TrimSpace(os.Getenv("SLACK_MCP_TICKET_FORMAT"))),
+		project:    strings.TrimSpace(os.Getenv("SLACK_MCP_TICKET_PROJECT")),
+	}
+	if b.webhook == "" && len(b.command) == 0 {
+		return nil
+	}
+	if b.format == "" {
+		b.format = FormatGeneric
+	}
+	return b
+}
+
+// Format returns the payload format the tracker expects
+func (b *Bridge) Format() string {
+	return b.format
+}
+
+// Target describes where tickets go, for display
+func (b *Bridge) Target() string {
+	if b.webhook != "" {
+		return b.webhook
+	}
+	return b.command[0]
+}
+
+// Payload renders a ticket in the tracker's request format
+func (b *Bridge) Payload(t Ticket) (interface{}, error) {
+	return buildPayload(b.format, b.project, t)
+}
+
+func buildPayload(format, project string, t Ticket) (interface{}, error) {
+	switch format {
+	case FormatGeneric:
+		return t, nil
+
+	case FormatJira:
+		if project == "" {
+			return nil, fmt.Errorf("jira format needs SLACK_MCP_TICKET_PROJECT (the project key)")
+		}
+		fields := map[string]interface{}{
+			"project":     map[string]string{"key": project},
+			"summary":     t.Title,
+			"description": t.Description,
+			"issuetype":   map[string]string{"name": "Task"},
+		}
+		if len(t.Labels) > 0 {
+			fields["labels"] = t.Labels
+		}
+		return map[string]interface{}{"fields": fields}, nil
+
+	case FormatLinear:
+		if project == "" {
+			return nil, fmt.Errorf("linear format needs SLACK_MCP_TICKET_PROJECT (the team ID)")
+		}
+		return map[string]interface{}{
+			"query": "mutation IssueCreate($input: IssueCreateInput!) { issueCreate(input: $input) { success issue { id identifier url } } }",
+			"variables": map[string]interface{}{
+				"input": map[string]interface{}{
+					"teamId":      project,
+					"title":       t.Title,
+					"description": t.Description,
+				},
+			},
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown ticket format %q; use generic, jira, or linear", format)
+}
+
+// Create sends the ticket and returns what the tracker reports back
+func (b *Bridge) Create(ctx context.Context, t Ticket) (*Result, error) {
+	payload, err := b.Payload(t)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []byte
+	if b.webhook != "" {
+		out, err = b.post(ctx, body)
+	} else {
+		out, err = b.run(ctx, body)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseResult(out), nil
+}
+
+func (b *Bridge) post(ctx context.Context, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", b.webhook, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if b.auth != "" {
+		req.Header.Set("Authorization", b.auth)
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ticket request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return data, nil
+}
+
+func (b *Bridge) run(ctx context.Context, body []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, b.command[0], b.command[1:]...)
+	cmd.Stdin = bytes.NewReader(body)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("ticket command: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// parseResult picks the ticket's identity out of a tracker response: Jira
+// ({id, key, self}), Linear ({data: {issueCreate: {issue: {...}}}}), a flat
+// {id, key, url}, or a bare URL printed by a command
+func parseResult(data []byte) *Result {
+	data = bytes.TrimSpace(data)
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		if s := string(data); strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") {
+			return &Result{URL: strings.Fields(s)[0]}
+		}
+		return &Result{}
+	}
+
+	if d, ok := raw["data"].(map[string]interface{}); ok {
+		if ic, ok := d["issueCreate"].(map[string]interface{}); ok {
+			if issue, ok := ic["issue"].(map[string]interface{}); ok {
+				raw = issue
+			}
+		}
+	}
+
+	get := func(keys ...string) string {
+		for _, k := range keys {
+			switch v := raw[k].(type) {
+			case string:
+				if v != "" {
+					return v
+				}
+			case float64:
+				return fmt.Sprintf("%.0f", v)
+			}
+		}
+		return ""
+	}
+	return &Result{
+		ID:  get("id"),
+		Key: get("key", "identifier"),
+		URL: get("url", "html_url", "self"),
+	}
+}
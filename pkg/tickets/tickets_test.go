@@ -0,0 +1,91 @@
+package tickets
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBuildPayload(t *testing.T) {
+	ticket := Ticket{Title: "Fix login", Description: "From #eng", Labels: []string{"slack"}}
+
+	p, err := buildPayload(FormatJira, "ENG", ticket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := p.(map[string]interface{})["fields"].(map[string]interface{})
+	if fields["summary"] != "Fix login" || fields["project"].(map[string]string)["key"] != "ENG" {
+		t.Errorf("jira fields = %v", fields)
+	}
+
+	p, err = buildPayload(FormatLinear, "team-1", ticket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := p.(map[string]interface{})["variables"].(map[string]interface{})["input"].(map[string]interface{})
+	if input["teamId"] != "team-1" || input["title"] != "Fix login" {
+		t.Errorf("linear input = %v", input)
+	}
+
+	if _, err := buildPayload(FormatJira, "", ticket); err == nil {
+		t.Error("jira without a project should fail")
+	}
+	if _, err := buildPayload("github", "", ticket); err == nil {
+		t.Error("unknown format should fail")
+	}
+}
+
+func TestParseResult(t *testing.T) {
+	cases := map[string]Result{
+		`{"id":"10001","key":"ENG-7","self":"https://jira/rest/api/2/issue/10001"}`:                              {ID: "10001", Key: "ENG-7", URL: "https://jira/rest/api/2/issue/10001"},
+		`{"data":{"issueCreate":{"success":true,"issue":{"id":"a1","identifier":"ENG-3","url":"https://l/3"}}}}`: {ID: "a1", Key: "ENG-3", URL: "https://l/3"},
+		`{"id": 42, "html_url": "https://gh/42"}`:                                                                {ID: "42", URL: "https://gh/42"},
+		"https://tracker/T-1\n": {URL: "https://tracker/T-1"},
+		"ok":                    {},
+	}
+	for in, want := range cases {
+		if got := parseResult([]byte(in)); *got != want {
+			t.Errorf("parseResult(%q) = %+v, want %+v", in, *got, want)
+		}
+	}
+}
+
+func TestBridgeWebhook(t *testing.T) {
+	var gotAuth string
+	var gotBody map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &gotBody)
+		w.Write([]byte(`{"id":"1","url":"https://tracker/1"}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("SLACK_MCP_TICKET_WEBHOOK", srv.URL)
+	t.Setenv("SLACK_MCP_TICKET_COMMAND", "")
+	t.Setenv("SLACK_MCP_TICKET_AUTH", "Bearer secret")
+	t.Setenv("SLACK_MCP_TICKET_FORMAT", "")
+	b := FromEnv()
+	if b == nil || b.Format() != FormatGeneric {
+		t.Fatalf("FromEnv = %+v", b)
+	}
+
+	res, err := b.Create(context.Background(), Ticket{Title: "Fix login", ThreadID: "C1:1.2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.URL != "https://tracker/1" || gotAuth != "Bearer secret" || gotBody["title"] != "Fix login" {
+		t.Errorf("res=%+v auth=%q body=%v", res, gotAuth, gotBody)
+	}
+}
+
+func TestFromEnvUnconfigured(t *testing.T) {
+	t.Setenv("SLACK_MCP_TICKET_WEBHOOK", "")
+	t.Setenv("SLACK_MCP_TICKET_COMMAND", "")
+	if b := FromEnv(); b != nil {
+		t.Errorf("FromEnv = %+v, want nil", b)
+	}
+}
@@ -20,7 +20,10 @@ func New(roundTripper http.RoundTripper, userAgent string, cookie string) *UserA
 func (t *UserAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	clonedReq := req.Clone(req.Context())
 	clonedReq.Header.Set("User-Agent", t.userAgent)
-	clonedReq.Header.Set("Cookie", "d="+t.cookie+";d-s=1744415074")
+	// Only browser session tokens carry the d cookie
+	if t.cookie != "" {
+		clonedReq.Header.Set("Cookie", "d="+t.cookie+";d-s=1744415074")
+	}
 
 	return t.roundTripper.RoundTrip(clonedReq)
 }